@@ -22,7 +22,10 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -43,9 +46,12 @@ import (
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
 	"github.com/cert-manager/cert-manager/pkg/controller"
+	crvenaficontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/venafi"
+	csrvenaficontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/venafi"
 	"github.com/cert-manager/cert-manager/pkg/controller/clusterissuers"
 	"github.com/cert-manager/cert-manager/pkg/healthz"
 	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+	venaficlient "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/server"
@@ -140,6 +146,9 @@ func Run(rootCtx context.Context, opts *config.ControllerConfiguration) error {
 		profilerMux := http.NewServeMux()
 		// Add pprof endpoints to this mux
 		profiling.Install(profilerMux)
+		if ctx.VenafiResponseLog != nil {
+			profilerMux.Handle(venaficlient.ResponseLogPath, ctx.VenafiResponseLog)
+		}
 		profilerServer := &http.Server{
 			Handler:           profilerMux,
 			ReadHeaderTimeout: defaultReadHeaderTimeout, // Mitigation for G112: Potential slowloris attack
@@ -172,6 +181,12 @@ func Run(rootCtx context.Context, opts *config.ControllerConfiguration) error {
 		return healthzServer.Start(rootCtx, healthzListener)
 	})
 
+	// The leader election lease is only released once the controllers have
+	// drained the items in flight, so that another instance does not start
+	// processing them while they are still being processed by this one.
+	leaderElectionCtx, releaseLeaderElection := context.WithCancel(context.WithoutCancel(rootCtx))
+	defer releaseLeaderElection()
+
 	elected := make(chan struct{})
 	if opts.LeaderElectionConfig.Enabled {
 		g.Go(func() error {
@@ -181,7 +196,7 @@ func Run(rootCtx context.Context, opts *config.ControllerConfiguration) error {
 				return err
 			}
 			errorCh := make(chan error, 1)
-			if err := startLeaderElection(rootCtx, opts, ctx.Client, ctx.Recorder, leaderelection.LeaderCallbacks{
+			if err := startLeaderElection(leaderElectionCtx, opts, ctx.Client, ctx.Recorder, leaderelection.LeaderCallbacks{
 				OnStartedLeading: func(_ context.Context) {
 					close(elected)
 				},
@@ -212,11 +227,13 @@ func Run(rootCtx context.Context, opts *config.ControllerConfiguration) error {
 	select {
 	case <-rootCtx.Done(): // Exit early if we are shutting down or if the errgroup has already exited with an error
 		// Wait for error group to complete and return
+		releaseLeaderElection()
 		return g.Wait()
 	case <-elected: // Don't launch the controllers unless we have been elected leader
 		// Continue with setting up controller
 	}
 
+	var controllers sync.WaitGroup
 	for n, fn := range controller.Known() {
 		log := log.WithValues("controller", n)
 
@@ -237,6 +254,7 @@ func Run(rootCtx context.Context, opts *config.ControllerConfiguration) error {
 			err = fmt.Errorf("error starting controller: %v", err)
 
 			cancelContext()
+			releaseLeaderElection()
 			err2 := g.Wait() // Don't process errors, we already have an error
 			if err2 != nil {
 				return utilerrors.NewAggregate([]error{err, err2})
@@ -244,13 +262,22 @@ func Run(rootCtx context.Context, opts *config.ControllerConfiguration) error {
 			return err
 		}
 
+		controllers.Add(1)
 		g.Go(func() error {
+			defer controllers.Done()
 			log.V(logf.InfoLevel).Info("starting controller")
 
 			return iface.Run(opts.NumberOfConcurrentWorkers, rootCtx)
 		})
 	}
 
+	g.Go(func() error {
+		<-rootCtx.Done()
+		controllers.Wait()
+		releaseLeaderElection()
+		return nil
+	})
+
 	log.V(logf.DebugLevel).Info("starting shared informer factories")
 	ctx.SharedInformerFactory.Start(rootCtx.Done())
 	ctx.KubeSharedInformerFactory.Start(rootCtx.Done())
@@ -306,6 +333,58 @@ func buildControllerContextFactory(ctx context.Context, opts *config.ControllerC
 	ACMEHTTP01SolverRunAsNonRoot := opts.ACMEHTTP01Config.SolverRunAsNonRoot
 	acmeAccountRegistry := accounts.NewDefaultRegistry()
 
+	// The audit log file is kept open for the lifetime of the controller.
+	// Each record is sent to all of the configured audit sinks.
+	var issuanceAuditSinks []controller.IssuanceAuditSink
+	if opts.IssuanceAuditLogFile != "" {
+		f, err := os.OpenFile(opts.IssuanceAuditLogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("error opening issuance audit log file: %w", err)
+		}
+		issuanceAuditSinks = append(issuanceAuditSinks, controller.NewJSONLinesIssuanceAuditSink(f))
+	}
+	if opts.IssuanceAuditWebhookURL != "" {
+		// The records are sent until the process exits, so that the
+		// issuances completed while the controllers drain on shutdown are
+		// sent too.
+		webhookCtx := context.WithoutCancel(ctx)
+		issuanceAuditSinks = append(issuanceAuditSinks, controller.NewWebhookIssuanceAuditSink(webhookCtx, opts.IssuanceAuditWebhookURL, controller.IssuanceAuditWebhookOptions{}))
+	}
+
+	// The token is read once, so the controller must be restarted for a new
+	// token to be used.
+	var venafiCallbackToken string
+	if opts.VenafiCallbackTokenFile != "" {
+		token, err := os.ReadFile(opts.VenafiCallbackTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading venafi callback token file: %w", err)
+		}
+		venafiCallbackToken = strings.TrimSpace(string(token))
+		if venafiCallbackToken == "" {
+			return nil, fmt.Errorf("venafi callback token file %s is empty", opts.VenafiCallbackTokenFile)
+		}
+	}
+
+	// The Venafi clients use the proxy configured in the environment unless
+	// a proxy is configured for them.
+	var venafiTransport *http.Transport
+	if opts.VenafiProxyURL != "" {
+		proxyURL, err := url.Parse(opts.VenafiProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing venafi proxy URL: %w", err)
+		}
+		venafiTransport = venaficlient.NewProxyTransport(proxyURL)
+	}
+
+	var issuanceAuditSink controller.IssuanceAuditSink
+	switch len(issuanceAuditSinks) {
+	case 0:
+	case 1:
+		issuanceAuditSink = issuanceAuditSinks[0]
+	default:
+		issuanceAuditSink = controller.NewMultiIssuanceAuditSink(issuanceAuditSinks...)
+	}
+
 	ctxFactory, err := controller.NewContextFactory(ctx, controller.ContextOptions{
 		Kubeconfig:         opts.KubeConfig,
 		KubernetesAPIQPS:   opts.KubernetesAPIQPS,
@@ -317,6 +396,18 @@ func buildControllerContextFactory(ctx context.Context, opts *config.ControllerC
 		Clock:   clock.RealClock{},
 		Metrics: metrics.New(log, clock.RealClock{}),
 
+		IssuanceAuditSink: issuanceAuditSink,
+
+		VenafiResponseLog: venaficlient.NewResponseLog(clock.RealClock{}, opts.VenafiResponseLogSize),
+		VenafiTransport:   venafiTransport,
+
+		ConcurrentWorkers: map[string]int{
+			crvenaficontroller.CRControllerName:   opts.VenafiConcurrentWorkers,
+			csrvenaficontroller.CSRControllerName: opts.VenafiConcurrentWorkers,
+		},
+
+		ShutdownGracePeriod: opts.ShutdownGracePeriod,
+
 		ACMEOptions: controller.ACMEOptions{
 			HTTP01SolverResourceRequestCPU:    http01SolverResourceRequestCPU,
 			HTTP01SolverResourceRequestMemory: http01SolverResourceRequestMemory,
@@ -339,9 +430,26 @@ func buildControllerContextFactory(ctx context.Context, opts *config.ControllerC
 		},
 
 		IssuerOptions: controller.IssuerOptions{
-			ClusterIssuerAmbientCredentials: opts.ClusterIssuerAmbientCredentials,
-			IssuerAmbientCredentials:        opts.IssuerAmbientCredentials,
-			ClusterResourceNamespace:        opts.ClusterResourceNamespace,
+			ClusterIssuerAmbientCredentials:  opts.ClusterIssuerAmbientCredentials,
+			IssuerAmbientCredentials:         opts.IssuerAmbientCredentials,
+			ClusterResourceNamespace:         opts.ClusterResourceNamespace,
+			VenafiMaxConcurrentSignings:      opts.VenafiMaxConcurrentSignings,
+			IssuerHealthCheckInterval:        opts.IssuerHealthCheckInterval,
+			VenafiRequestTimeout:             opts.VenafiRequestTimeout,
+			VenafiReconcileTimeout:           opts.VenafiReconcileTimeout,
+			VenafiIssuerWarmUpTimeout:        opts.VenafiIssuerWarmUpTimeout,
+			VenafiRetrieveFailureTimeout:     opts.VenafiRetrieveFailureTimeout,
+			VenafiCircuitBreakerThreshold:    opts.VenafiCircuitBreakerThreshold,
+			VenafiCircuitBreakerOpenDuration: opts.VenafiCircuitBreakerOpenDuration,
+			CertificateRequestEventCooldown:  opts.CertificateRequestEventCooldown,
+			VenafiErrorLogInterval:           opts.VenafiErrorLogInterval,
+			VenafiZoneCacheTTL:               opts.VenafiZoneCacheTTL,
+			EnableVenafiIssuerCache:          opts.EnableVenafiIssuerCache,
+			VenafiValidityHintExtensionOID:   opts.VenafiValidityHintExtensionOID,
+			VenafiFieldManager:               opts.VenafiFieldManager,
+			VenafiCallbackListenAddress:      opts.VenafiCallbackListenAddress,
+			VenafiCallbackToken:              venafiCallbackToken,
+			VenafiCallbackTLSConfig:          opts.VenafiCallbackTLSConfig,
 		},
 
 		IngressShimOptions: controller.IngressShimOptions{
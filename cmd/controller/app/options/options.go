@@ -183,6 +183,94 @@ func AddConfigFlags(fs *pflag.FlagSet, c *config.ControllerConfiguration) {
 		"The number of concurrent workers for each controller.")
 	fs.IntVar(&c.MaxConcurrentChallenges, "max-concurrent-challenges", c.MaxConcurrentChallenges, ""+
 		"The maximum number of challenges that can be scheduled as 'processing' at once.")
+	fs.DurationVar(&c.ShutdownGracePeriod, "shutdown-grace-period", c.ShutdownGracePeriod, ""+
+		"The maximum time the controllers are given to finish processing the items in flight when the controller "+
+		"shuts down, for example so that the pickup IDs of Venafi certificates being requested are persisted. "+
+		"No new items are processed once the shutdown has started. A value of 0 cancels the items in flight immediately.")
+	fs.IntVar(&c.VenafiMaxConcurrentSignings, "venafi-max-concurrent-signings", c.VenafiMaxConcurrentSignings, ""+
+		"The maximum number of CertificateRequests that can be signed at once by each Venafi issuer. "+
+		"Further requests wait until a signing completes.")
+	fs.IntVar(&c.VenafiConcurrentWorkers, "venafi-concurrent-workers", c.VenafiConcurrentWorkers, ""+
+		"The number of concurrent workers of the Venafi CertificateRequest and CertificateSigningRequest controllers. "+
+		"If 0, --concurrent-workers is used. Workers wait for a signing slot once an issuer has reached "+
+		"--venafi-max-concurrent-signings, so this bounds the signings in flight across all Venafi issuers.")
+	fs.DurationVar(&c.IssuerHealthCheckInterval, "issuer-health-check-interval", c.IssuerHealthCheckInterval, ""+
+		"How often each Issuer and ClusterIssuer is set up again to verify that it can reach its backend. "+
+		"A value of 0 disables periodic checks.")
+	fs.DurationVar(&c.VenafiRequestTimeout, "venafi-request-timeout", c.VenafiRequestTimeout, ""+
+		"The maximum time to wait for each call to the Venafi platform when signing a CertificateRequest. "+
+		"Calls which take longer are abandoned and retried later. A value of 0 disables the timeout.")
+	fs.DurationVar(&c.VenafiReconcileTimeout, "venafi-reconcile-timeout", c.VenafiReconcileTimeout, ""+
+		"The maximum time a single sync of a Venafi CertificateRequest may spend building the Venafi client and "+
+		"calling the Venafi platform. The budget is shared by all the steps of the sync, each of which is also "+
+		"bounded by --venafi-request-timeout. Syncs which exceed it are retried later. A value of 0 disables the budget.")
+	fs.DurationVar(&c.VenafiIssuerWarmUpTimeout, "venafi-issuer-warm-up-timeout", c.VenafiIssuerWarmUpTimeout, ""+
+		"If set, every Venafi Issuer and ClusterIssuer is set up when the controller starts, before any Venafi "+
+		"CertificateRequest is signed, so that misconfigured issuers are reported immediately. Issuers which have "+
+		"not been set up within the timeout are left to the issuers controllers. A value of 0 disables the warm up.")
+	fs.DurationVar(&c.VenafiRetrieveFailureTimeout, "venafi-retrieve-failure-timeout", c.VenafiRetrieveFailureTimeout, ""+
+		"The maximum time for which retrieving a certificate from the Venafi platform is retried with a backoff "+
+		"after unexpected errors, before the CertificateRequest is failed. A value of 0 retries indefinitely.")
+	fs.IntVar(&c.VenafiCircuitBreakerThreshold, "venafi-circuit-breaker-threshold", c.VenafiCircuitBreakerThreshold, ""+
+		"The number of consecutive failures to reach the Venafi platform after which signings for the issuer "+
+		"are failed fast until the platform is reachable again. A value of 0 disables the circuit breaker.")
+	fs.DurationVar(&c.VenafiCircuitBreakerOpenDuration, "venafi-circuit-breaker-open-duration", c.VenafiCircuitBreakerOpenDuration, ""+
+		"How long signings for a Venafi issuer are failed fast once its circuit breaker has opened, before a "+
+		"single signing is let through to probe whether the Venafi platform is reachable again.")
+	fs.DurationVar(&c.CertificateRequestEventCooldown, "certificate-request-event-cooldown", c.CertificateRequestEventCooldown, ""+
+		"The period during which identical consecutive events for a CertificateRequest are suppressed. "+
+		"An event is always recorded when its reason or message changes. A value of 0 disables the suppression.")
+	fs.DurationVar(&c.VenafiErrorLogInterval, "venafi-error-log-interval", c.VenafiErrorLogInterval, ""+
+		"The minimum interval between logging identical errors encountered while signing the same CertificateRequest "+
+		"with a Venafi issuer. The first occurrence of an error is always logged. A value of 0 logs every error.")
+	fs.DurationVar(&c.VenafiZoneCacheTTL, "venafi-zone-cache-ttl", c.VenafiZoneCacheTTL, ""+
+		"How long the zone configuration read from the Venafi platform is cached for each issuer and zone. "+
+		"The cache is invalidated when the issuer spec changes. A value of 0 disables the cache.")
+	fs.BoolVar(&c.EnableVenafiIssuerCache, "enable-venafi-issuer-cache", c.EnableVenafiIssuerCache, ""+
+		"Whether the Venafi CertificateRequest controller caches the issuers it reads until they are next updated, "+
+		"so that bursts of CertificateRequests referencing the same issuer read it once.")
+	fs.StringVar(&c.VenafiValidityHintExtensionOID, "venafi-validity-hint-extension-oid", c.VenafiValidityHintExtensionOID, ""+
+		"Dotted OID of a CSR extension, containing a DER encoded INTEGER number of seconds, from which the validity "+
+		"requested for Venafi certificates is read. A conflicting duration on the CertificateRequest takes precedence. "+
+		"If empty, the CSR is not inspected.")
+	fs.StringVar(&c.VenafiFieldManager, "venafi-field-manager", c.VenafiFieldManager, ""+
+		"The field manager name used by the Venafi issuer when updating CertificateRequests, so that its changes "+
+		"can be attributed to it in the managed fields of the resources.")
+	fs.StringVar(&c.VenafiProxyURL, "venafi-proxy-url", c.VenafiProxyURL, ""+
+		"URL of the HTTP proxy through which the Venafi issuers send requests to the Venafi platform. "+
+		"If empty, the proxy configured by the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables is used.")
+	fs.StringVar(&c.VenafiCallbackListenAddress, "venafi-callback-listen-address", c.VenafiCallbackListenAddress, ""+
+		"The host and port that the Venafi callback endpoint should listen on. When the Venafi platform calls the "+
+		"endpoint, the CertificateRequests pending issuance with the given pickup ID are synced immediately rather "+
+		"than at the next poll. If empty, the endpoint is disabled.")
+	fs.StringVar(&c.VenafiCallbackTokenFile, "venafi-callback-token-file", c.VenafiCallbackTokenFile, ""+
+		"Path of a file containing the shared token that calls to the Venafi callback endpoint must carry as a "+
+		"bearer token. Required if --venafi-callback-listen-address is set.")
+	fs.StringVar(&c.VenafiCallbackTLSConfig.Filesystem.CertFile, "venafi-callback-tls-cert-file", c.VenafiCallbackTLSConfig.Filesystem.CertFile, ""+
+		"Path to the file containing the TLS certificate to serve the Venafi callback endpoint with. TLS is required "+
+		"unless --venafi-callback-listen-address is a loopback address, as the bearer token is otherwise sent in cleartext.")
+	fs.StringVar(&c.VenafiCallbackTLSConfig.Filesystem.KeyFile, "venafi-callback-tls-private-key-file", c.VenafiCallbackTLSConfig.Filesystem.KeyFile, ""+
+		"Path to the file containing the TLS private key to serve the Venafi callback endpoint with.")
+	fs.DurationVar(&c.VenafiCallbackTLSConfig.Dynamic.LeafDuration, "venafi-callback-dynamic-serving-leaf-duration", c.VenafiCallbackTLSConfig.Dynamic.LeafDuration, "leaf duration of Venafi callback serving certificates")
+	fs.StringVar(&c.VenafiCallbackTLSConfig.Dynamic.SecretNamespace, "venafi-callback-dynamic-serving-ca-secret-namespace", c.VenafiCallbackTLSConfig.Dynamic.SecretNamespace, "namespace of the secret used to store the CA that signs Venafi callback serving certificates")
+	fs.StringVar(&c.VenafiCallbackTLSConfig.Dynamic.SecretName, "venafi-callback-dynamic-serving-ca-secret-name", c.VenafiCallbackTLSConfig.Dynamic.SecretName, "name of the secret used to store the CA that signs Venafi callback serving certificates")
+	fs.StringSliceVar(&c.VenafiCallbackTLSConfig.Dynamic.DNSNames, "venafi-callback-dynamic-serving-dns-names", c.VenafiCallbackTLSConfig.Dynamic.DNSNames, "DNS names that should be present on certificates generated by the Venafi callback dynamic serving CA")
+	fs.StringSliceVar(&c.VenafiCallbackTLSConfig.CipherSuites, "venafi-callback-tls-cipher-suites", c.VenafiCallbackTLSConfig.CipherSuites, ""+
+		"Comma-separated list of cipher suites for the Venafi callback endpoint. "+
+		"If omitted, the default Go cipher suites will be used.")
+	fs.StringVar(&c.VenafiCallbackTLSConfig.MinTLSVersion, "venafi-callback-tls-min-version", c.VenafiCallbackTLSConfig.MinTLSVersion, ""+
+		"Minimum TLS version supported by the Venafi callback endpoint. If omitted, the default Go minimum version will be used.")
+	fs.IntVar(&c.VenafiResponseLogSize, "venafi-response-log-size", c.VenafiResponseLogSize, ""+
+		"The number of raw responses of the Venafi platform to enrollment and retrieval requests kept in memory, "+
+		"with their secrets redacted, and served at /debug/venafi/responses on the profiler address. "+
+		"Each response body is truncated to 16KiB. Requires --enable-profiling. A value of 0 disables the log.")
+	fs.StringVar(&c.IssuanceAuditLogFile, "issuance-audit-log-file", c.IssuanceAuditLogFile, ""+
+		"Path of a file to which a record of every certificate issued is appended as a line of JSON. "+
+		"If empty, no records are kept.")
+	fs.StringVar(&c.IssuanceAuditWebhookURL, "issuance-audit-webhook-url", c.IssuanceAuditWebhookURL, ""+
+		"URL of a webhook to which a record of every certificate issued is sent as JSON in a POST request, in "+
+		"addition to --issuance-audit-log-file if both are set. Records are buffered and retried in the background. "+
+		"If empty, no records are sent.")
 
 	fs.StringVar(&c.MetricsListenAddress, "metrics-listen-address", c.MetricsListenAddress, ""+
 		"The host and port that the metrics endpoint should listen on.")
@@ -338,6 +338,12 @@ type ACMEChallengeSolverDNS01 struct {
 	// records when found in DNS zones.
 	CNAMEStrategy CNAMEStrategy
 
+	// CNAMENameservers is a list of DNS servers, in host:port form, used to
+	// resolve the CNAME chain of the challenge record when CNAMEStrategy is
+	// Follow. If not set, the recursive nameservers configured on the
+	// controller are used.
+	CNAMENameservers []string
+
 	// Use the Akamai DNS zone management API to manage DNS01 challenge records.
 	Akamai *ACMEIssuerDNS01ProviderAkamai
 
@@ -676,4 +682,9 @@ type ACMEIssuerStatus struct {
 	// registered ACME account, in order to track changes made to registered account
 	// associated with the Issuer
 	LastPrivateKeyHash string
+
+	// LastExternalAccountBindingHash is a hash of the External Account Binding
+	// key ID and key associated with the latest registered ACME account, in
+	// order to detect when the External Account Binding credentials are rotated
+	LastExternalAccountBindingHash string
 }
@@ -374,6 +374,13 @@ type ACMEChallengeSolverDNS01 struct {
 	// +optional
 	CNAMEStrategy CNAMEStrategy `json:"cnameStrategy,omitempty"`
 
+	// CNAMENameservers is a list of DNS servers, in host:port form, used to
+	// resolve the CNAME chain of the challenge record when CNAMEStrategy is
+	// Follow. If not set, the recursive nameservers configured on the
+	// controller are used.
+	// +optional
+	CNAMENameservers []string `json:"cnameNameservers,omitempty"`
+
 	// Use the Akamai DNS zone management API to manage DNS01 challenge records.
 	// +optional
 	Akamai *ACMEIssuerDNS01ProviderAkamai `json:"akamai,omitempty"`
@@ -751,4 +758,10 @@ type ACMEIssuerStatus struct {
 	// registered ACME account, in order to track changes made to registered account
 	// associated with the Issuer
 	LastPrivateKeyHash string `json:"lastPrivateKeyHash,omitempty"`
+
+	// LastExternalAccountBindingHash is a hash of the External Account Binding
+	// key ID and key associated with the latest registered ACME account, in
+	// order to detect when the External Account Binding credentials are rotated
+	// +optional
+	LastExternalAccountBindingHash string `json:"lastExternalAccountBindingHash,omitempty"`
 }
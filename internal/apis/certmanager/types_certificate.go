@@ -207,6 +207,12 @@ type CertificateSpec struct {
 	// Additional keystore output formats to be stored in the Certificate's Secret.
 	Keystores *CertificateKeystores
 
+	// CABundle configures a CA bundle to be stored in the `ca.crt` key of the
+	// Certificate's Secret, in place of or in addition to the CA certificate
+	// returned by the issuer. This is useful when the issuer does not return
+	// the root CA of the chain, for example for some Venafi TPP policies.
+	CABundle *CertificateCABundle
+
 	// Reference to the issuer responsible for issuing the certificate.
 	// If the issuer is namespace-scoped, it must be in the same namespace
 	// as the Certificate. If the issuer is cluster-scoped, it can be used
@@ -269,6 +275,12 @@ type CertificateSpec struct {
 	// the controller and webhook components.
 	// +optional
 	NameConstraints *NameConstraints
+
+	// Requests the OCSP Must-Staple (TLS feature status_request) extension in
+	// the certificate, which tells clients to reject the certificate unless
+	// the server staples a valid OCSP response to the TLS handshake.
+	// Issuers which cannot honor the extension fail the request.
+	OCSPMustStaple bool
 }
 
 type OtherName struct {
@@ -397,6 +409,49 @@ type X509Subject struct {
 	SerialNumber string
 }
 
+// CertificateCABundle configures the CA bundle stored in the `ca.crt` key of
+// the Certificate's Secret. Exactly one of SecretRef or ConfigMapRef must be
+// set. The bundle must contain at least one PEM encoded certificate, and is
+// read again whenever the Secret is updated.
+type CertificateCABundle struct {
+	// SecretRef is a reference to a key in a Secret resource containing the
+	// PEM encoded CA certificates of the bundle. The Secret is read from the
+	// namespace of the Certificate. If the key is not set, it defaults to
+	// `ca.crt`.
+	SecretRef *cmmeta.SecretKeySelector
+
+	// ConfigMapRef is a reference to a key in a ConfigMap resource containing
+	// the PEM encoded CA certificates of the bundle. The ConfigMap is read
+	// from the namespace of the Certificate. If the key is not set, it
+	// defaults to `ca.crt`.
+	ConfigMapRef *cmmeta.ConfigMapKeySelector
+
+	// Mode specifies how the bundle is combined with the CA certificate
+	// returned by the issuer. Default value is `Replace`.
+	//
+	// If provided, allowed values are:
+	// `Replace`: the `ca.crt` key only contains the bundle, and the CA
+	// certificate returned by the issuer is not stored.
+	// `Append`: the `ca.crt` key contains the CA certificate returned by the
+	// issuer, followed by the certificates of the bundle which it does not
+	// already contain.
+	//
+	// The truststores of the keystores contain the same certificates as the
+	// `ca.crt` key.
+	Mode CABundleMode
+}
+
+type CABundleMode string
+
+const (
+	// ReplaceCABundleMode stores only the bundle in the `ca.crt` key.
+	ReplaceCABundleMode CABundleMode = "Replace"
+
+	// AppendCABundleMode stores the CA certificate returned by the issuer
+	// followed by the bundle in the `ca.crt` key.
+	AppendCABundleMode CABundleMode = "Append"
+)
+
 // CertificateKeystores configures additional keystore output formats to be
 // created in the Certificate's output Secret.
 type CertificateKeystores struct {
@@ -591,6 +646,14 @@ const (
 	//
 	// It will be removed by the 'issuing' controller upon completing issuance.
 	CertificateConditionIssuing CertificateConditionType = "Issuing"
+
+	// A condition added to Certificate resources when the certificate stored
+	// in the target Secret is valid for less time than the requested
+	// `spec.duration`, for example because the issuer enforces a maximum
+	// certificate lifetime.
+	// It is only present when `spec.duration` is set, and is removed once a
+	// certificate with the full requested duration has been issued.
+	CertificateConditionDurationTruncated CertificateConditionType = "DurationTruncated"
 )
 
 // CertificateSecretTemplate defines the default labels and annotations
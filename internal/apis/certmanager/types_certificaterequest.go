@@ -147,6 +147,13 @@ type CertificateRequestSpec struct {
 	// Extra contains extra attributes of the user that created the CertificateRequest.
 	// Populated by the cert-manager webhook on creation and immutable.
 	Extra map[string][]string
+
+	// Requested time at which the issued certificate should expire. Can be
+	// used instead of `duration` for certificates which must expire at a
+	// specific point in time. Note that the issuer may choose to ignore the
+	// requested notAfter time, just like any other requested attribute.
+	// Must not be set together with `duration`.
+	NotAfter *metav1.Time
 }
 
 // CertificateRequestStatus defines the observed state of CertificateRequest and
@@ -172,6 +179,16 @@ type CertificateRequestStatus struct {
 	// FailureTime stores the time that this CertificateRequest failed. This is
 	// used to influence garbage collection and back-off.
 	FailureTime *metav1.Time
+
+	// ChainLength is the number of certificates in the `certificate` field,
+	// including the issued certificate itself. A value of 1 means that the
+	// issuer did not return any intermediate certificates.
+	ChainLength *int
+
+	// SerialNumber is the serial number of the issued certificate, formatted
+	// as colon-separated pairs of upper case hexadecimal digits, for example
+	// `0A:1B:2C`.
+	SerialNumber string
 }
 
 // CertificateRequestCondition contains condition information for a CertificateRequest.
@@ -82,6 +82,21 @@ type IssuerList struct {
 // configuration required for the issuer.
 type IssuerSpec struct {
 	IssuerConfig
+
+	// MinRSAKeySize is the minimum size, in bits, of the RSA keys of the
+	// requests signed by this issuer. Requests for smaller RSA keys are failed
+	// before they are signed or submitted. Applies to CA, SelfSigned, Vault
+	// and Venafi issuers. If not set, RSA keys of any size are signed.
+	// +optional
+	MinRSAKeySize int
+
+	// AllowedECDSACurves are the names of the elliptic curves of the ECDSA keys
+	// allowed in the requests signed by this issuer, out of "P-256", "P-384"
+	// and "P-521". Requests for ECDSA keys on other curves are failed before
+	// they are signed or submitted. Applies to CA, SelfSigned, Vault and Venafi
+	// issuers. If not set, ECDSA keys on any curve are signed.
+	// +optional
+	AllowedECDSACurves []string
 }
 
 // IssuerConfig is a generic wrapper around custom issuer types
@@ -117,6 +132,18 @@ type VenafiIssuer struct {
 	// This field is required.
 	Zone string
 
+	// AdditionalZones are further Venafi Policy Zones that requests may be
+	// enrolled in. Each request is enrolled in the first zone whose policy
+	// accepts it, trying Zone first and then these zones in order. Requests
+	// which are not accepted by any of the zones fail.
+	AdditionalZones []string
+
+	// AllowedZoneOverrides are the Venafi Policy Zones which requests may
+	// select with the "venafi.cert-manager.io/zone-override" annotation, in place of
+	// Zone and AdditionalZones. Requests selecting any other zone fail. If
+	// empty, the annotation is not allowed.
+	AllowedZoneOverrides []string
+
 	// TPP specifies Trust Protection Platform configuration settings.
 	// Only one of TPP or Cloud may be specified.
 	TPP *VenafiTPP
@@ -124,6 +151,257 @@ type VenafiIssuer struct {
 	// Cloud specifies the Venafi cloud configuration settings.
 	// Only one of TPP or Cloud may be specified.
 	Cloud *VenafiCloud
+
+	// RetryBackoff configures how often cert-manager polls the Venafi platform
+	// for a certificate which is still pending issuance.
+	// If not set, pending certificates are polled after 5 seconds, doubling
+	// on each attempt up to a maximum of 5 minutes.
+	RetryBackoff *VenafiRetryBackoff
+
+	// IssuanceDeadline is the maximum time from the creation of a
+	// CertificateRequest for its certificate to be issued. Requests which are
+	// still pending once it has elapsed, for example because their approval in
+	// the Venafi platform is never given, are failed with the
+	// IssuanceDeadlineExceeded reason instead of being retried forever.
+	// If not set, pending requests are retried until they are issued or
+	// rejected.
+	IssuanceDeadline *metav1.Duration
+
+	// IncludeRootCA specifies whether the self-signed root CA of the issued
+	// certificate is included at the end of the certificate chain returned by
+	// this issuer. By default, the root CA is only returned as the CA of a
+	// CertificateRequest, since clients are expected to already trust it.
+	IncludeRootCA bool
+
+	// MaxDuration is the maximum validity of certificates allowed by the Venafi
+	// zone. If set, and the webhook is run with
+	// `enableVenafiDurationValidation`, the webhook rejects CertificateRequests
+	// for this issuer which request a longer duration when they are created,
+	// instead of the Venafi platform silently truncating their validity.
+	MaxDuration *metav1.Duration
+
+	// MinDuration is the minimum validity of certificates issued by the Venafi
+	// zone, which extends the validity of certificates requested with a shorter
+	// duration. If set, an event is sent for the CertificateRequests of this
+	// issuer which request a shorter duration, recording the validity they will
+	// actually be issued with. The same applies to MaxDuration for longer
+	// durations.
+	MinDuration *metav1.Duration
+
+	// DefaultDuration is the validity requested for certificates issued by this
+	// issuer when the CertificateRequest requests neither a notAfter time nor a
+	// validity through the CSR, for example the typical lifetime of certificates
+	// of the Venafi zone. It must be at least 1h, and must not exceed MaxDuration
+	// if set. If not set, the validity configured for the Venafi zone is used.
+	DefaultDuration *metav1.Duration
+
+	// CredentialsRef is a reference to an object containing the credentials
+	// used to authenticate to the Venafi platform, which is read by the
+	// credentials resolver of the cert-manager controller. If set, it takes
+	// precedence over `tpp.credentialsRef` and `cloud.apiTokenSecretRef`.
+	// The default resolver only supports Secrets, which must contain the same
+	// keys as the Secret referenced by `tpp.credentialsRef` for TPP, or the
+	// key set by `cloud.apiTokenSecretRef.key`, or 'api-key' if not set, for
+	// Venafi Cloud.
+	CredentialsRef *VenafiCredentialsReference
+
+	// FallbackCredentialsRefs are references to objects containing alternative
+	// credentials, which are tried in order when the Venafi platform rejects the
+	// credentials referenced by `credentialsRef`, `tpp.credentialsRef` or
+	// `cloud.apiTokenSecretRef`, for example while credentials are being rotated.
+	// They must contain the same keys as the object referenced by `credentialsRef`.
+	FallbackCredentialsRefs []VenafiCredentialsReference
+
+	// RevokeOnDelete specifies whether certificates issued by this issuer are
+	// revoked in the Venafi platform when the Certificate they were issued
+	// for is deleted. cert-manager adds a finalizer to such Certificates, so
+	// that they are only removed once the certificate has been revoked, or
+	// could not be revoked within an hour of the deletion, or the
+	// credentials of the issuer no longer exist.
+	// Revocation is only supported by Venafi TPP, and the zone must allow it.
+	RevokeOnDelete bool
+
+	// ChainBundleSecretRef is a reference to a key in a Secret containing the
+	// PEM encoded intermediate and root certificates of the Venafi zone. It is
+	// used to complete the chain of issued certificates which the Venafi
+	// platform returns without their intermediates, and the completed chain
+	// must verify against the root certificates of the bundle. The Secret is
+	// read from the namespace of the Issuer, or the cluster resource namespace
+	// for ClusterIssuers. If the key is not set, it defaults to `ca.crt`.
+	ChainBundleSecretRef *cmmeta.SecretKeySelector
+
+	// ChainVerification configures the verification of the certificate chains
+	// returned by the Venafi platform against trust anchors, after the chain
+	// is completed from ChainBundleSecretRef if set. Requests whose chain does
+	// not verify are failed, so that a certificate with a broken chain, for
+	// example because of misconfigured intermediates in the Venafi platform,
+	// is not stored in the Secret of a Certificate. If not set, the returned
+	// chains are not verified.
+	ChainVerification *VenafiChainVerification
+
+	// AllowedDomains are the patterns of the domains which requests may be
+	// issued for, for example to restrict the domains that the namespaces
+	// sharing an issuer can request. A `*` matches any characters within a
+	// single label of a domain, so that `*.example.com` allows the direct
+	// subdomains of example.com, and a leading `**` label matches one or more
+	// labels, so that `**.example.com` allows all of its subdomains. Requests
+	// whose common name or DNS names are not allowed by any of the patterns are
+	// rejected before they are submitted. If not set, requests may be issued
+	// for any domain allowed by the policy of the Venafi zone.
+	AllowedDomains []string
+
+	// AllowedExtensions are the object identifiers, in dotted notation, of the
+	// non-standard X.509 extensions that the policy of the Venafi zone allows
+	// in requests, for example "1.3.6.1.4.1.311.20.2". The Venafi platform does
+	// not expose which extensions its zones allow, so they are configured on
+	// the issuer. If set, requests containing other non-standard extensions
+	// are rejected before they are submitted. If not set, the extensions of
+	// requests are passed to the Venafi platform as is.
+	AllowedExtensions []string
+
+	// LegacyExtensions specifies whether certificates issued by this issuer
+	// must include the legacy Netscape certificate type extension, which some
+	// old clients and appliances require. The extension is added by the Venafi
+	// platform, so the zone must be configured to include it, and issued
+	// certificates without it are rejected. If AllowedExtensions is set, it
+	// must include the object identifier of the extension,
+	// "2.16.840.1.113730.1.1". Defaults to false.
+	// +optional
+	LegacyExtensions bool
+
+	// ReuseExisting specifies whether a valid certificate already issued by the
+	// Venafi platform for the same subject, DNS names and public key as a
+	// request is returned for it, instead of issuing a new certificate. This
+	// avoids issuing duplicate certificates when requests are repeated, for
+	// example for Certificates which do not rotate their private key. Only
+	// certificates issued within ReuseMaxAge are reused. Reuse is only
+	// supported by Venafi TPP.
+	ReuseExisting bool
+
+	// ReuseMaxAge is the maximum time since the issuance of a certificate for
+	// it to be reused when ReuseExisting is set. If several certificates can
+	// be reused, the one which expires last is returned.
+	// Defaults to 24h.
+	ReuseMaxAge *metav1.Duration
+
+	// SubjectDefaults are the subject fields set on requests which do not set
+	// them in their CSR, before the zone defaults are applied. They are
+	// checked against the subject policy of the Venafi zone when requests are
+	// validated. The CSR is submitted as is, so the zone must be configured
+	// with the same values for them to be included in issued certificates.
+	SubjectDefaults *VenafiSubjectDefaults
+
+	// DefaultAnnotations are the Venafi annotations applied to every
+	// CertificateRequest signed by this issuer, for example to set the custom
+	// fields of all its certificates. Annotations set on a CertificateRequest
+	// take precedence over the defaults of its issuer, except for the
+	// `venafi.cert-manager.io/custom-fields` annotation whose fields are merged
+	// by name, the fields of the request overriding the fields of the issuer.
+	// Only the custom-fields, friendly-name, instance and workload annotations,
+	// and the `cert-manager.io/signature-hash-algorithm` annotation, may be
+	// defaulted.
+	DefaultAnnotations map[string]string
+
+	// OriginCustomField is the name of a Venafi custom field set on the
+	// certificates requested by this issuer to record the Kubernetes object
+	// which requested them, so that certificates in the Venafi platform can be
+	// traced back to it. Its value is rendered from OriginTemplate, and it
+	// replaces any custom field of the same name set by a CertificateRequest.
+	// If not set, the origin of certificates is not recorded.
+	OriginCustomField string
+
+	// OriginTemplate is the value of the custom field named by
+	// OriginCustomField, in which `{namespace}` is replaced by the namespace of
+	// the CertificateRequest, `{certificate}` by the name of the Certificate it
+	// was created for, or its own name if it was not created for a Certificate,
+	// and `{request}` by the name of the CertificateRequest. The rendered value
+	// must be no more than 255 characters.
+	// Defaults to `{namespace}/{certificate}`.
+	OriginTemplate string
+
+	// FailClosed specifies whether requests wait for the policy of the Venafi
+	// zone to be confirmed before they are submitted. If set, requests are
+	// kept pending with the PolicyUnavailable reason and retried while the
+	// Venafi client cannot be initialised or the zone policy cannot be read,
+	// instead of being failed. This guarantees that no certificate is
+	// requested which was not checked against the zone policy.
+	FailClosed bool
+}
+
+// VenafiChainVerification configures the verification of the certificate
+// chains issued by a Venafi issuer.
+type VenafiChainVerification struct {
+	// TrustAnchorsSecretRef is a reference to a key in a Secret containing the
+	// PEM encoded root certificates which issued chains must verify against.
+	// The Secret is read from the namespace of the Issuer, or the cluster
+	// resource namespace for ClusterIssuers. If the key is not set, it
+	// defaults to `ca.crt`. If not set, the system trust store of the
+	// cert-manager controller is used.
+	TrustAnchorsSecretRef *cmmeta.SecretKeySelector
+
+	// SkipIfUnavailable specifies whether certificates are issued without
+	// verifying their chain when the trust anchors cannot be loaded, for
+	// example because the referenced Secret does not exist. By default,
+	// requests are kept pending until the trust anchors can be loaded.
+	SkipIfUnavailable bool
+}
+
+// VenafiCredentialsReference is a reference to an object containing the
+// credentials of a Venafi issuer. The object is read from the namespace of
+// the Issuer, or the cluster resource namespace for ClusterIssuers.
+type VenafiCredentialsReference struct {
+	// Name of the object being referred to.
+	Name string
+
+	// Kind of the object being referred to.
+	// Defaults to "Secret".
+	Kind string
+
+	// Group of the object being referred to.
+	// Defaults to the core API group.
+	Group string
+}
+
+// VenafiRetryBackoff configures an exponential backoff for polling the
+// Venafi platform for pending certificates.
+type VenafiRetryBackoff struct {
+	// InitialInterval is the delay before the first attempt to retrieve a
+	// pending certificate. The delay doubles on each subsequent attempt.
+	// Defaults to 5s.
+	InitialInterval *metav1.Duration
+
+	// MaxInterval is the upper bound for the delay between attempts to
+	// retrieve a pending certificate.
+	// Defaults to 5m.
+	MaxInterval *metav1.Duration
+
+	// Jitter is the upper bound of a random delay added to each attempt to
+	// retrieve a pending certificate, so that CertificateRequests created at
+	// the same time do not all poll the Venafi platform at once. The delay is
+	// derived from the UID of the CertificateRequest, so it is stable across
+	// controller restarts.
+	// Defaults to 0, which disables jitter.
+	Jitter *metav1.Duration
+}
+
+// VenafiSubjectDefaults are the subject fields set on requests to a Venafi
+// issuer which do not set them in their CSR.
+type VenafiSubjectDefaults struct {
+	// Organizations to be used on requests which do not set an organization.
+	Organizations []string
+
+	// Organizational units to be used on requests which do not set an
+	// organizational unit.
+	OrganizationalUnits []string
+
+	// Localities to be used on requests which do not set a locality.
+	Localities []string
+
+	// Provinces (states) to be used on requests which do not set a province.
+	Provinces []string
+
+	// Countries to be used on requests which do not set a country.
+	Countries []string
 }
 
 // VenafiTPP defines connection configuration details for a Venafi TPP instance
@@ -135,6 +413,7 @@ type VenafiTPP struct {
 	// CredentialsRef is a reference to a Secret containing the Venafi TPP API credentials.
 	// The secret must contain the key 'access-token' for the Access Token Authentication,
 	// or two keys, 'username' and 'password' for the API Keys Authentication.
+	// Not required if the `credentialsRef` of the Venafi issuer is set.
 	CredentialsRef cmmeta.LocalObjectReference
 
 	// Base64-encoded bundle of PEM CAs which will be used to validate the certificate
@@ -149,6 +428,31 @@ type VenafiTPP struct {
 	// If neither CABundle nor CABundleSecretRef is defined, the certificate bundle in
 	// the cert-manager controller container is used to validate the TLS connection.
 	CABundleSecretRef *cmmeta.SecretKeySelector `json:"caBundleSecretRef,omitempty"`
+
+	// ClientCertificateSecretRef is a reference to a Secret of type
+	// `kubernetes.io/tls` containing the client certificate and private key,
+	// in its 'tls.crt' and 'tls.key' keys, which are presented to the TPP
+	// server when it requires mutual TLS authentication. The client
+	// certificate is used in addition to the credentials of the issuer.
+	ClientCertificateSecretRef *cmmeta.LocalObjectReference
+
+	// ServiceGeneratedKeys specifies whether the private keys of certificates
+	// are generated by Venafi TPP instead of by cert-manager. If set, the CSR
+	// of a request is only used for the names and key algorithm requested
+	// from TPP, and the private key generated by TPP is returned with the
+	// certificate and stored in the Secret of the Certificate in place of the
+	// key generated by cert-manager.
+	// This changes the trust assumptions of the issuer: the private keys are
+	// known to and stored by TPP, so anyone with access to them in TPP can
+	// impersonate the workloads using the certificates, and they are
+	// transferred over the network, encrypted with a password generated for
+	// each retrieval. Until it has been stored in the Secret of the
+	// Certificate, the key is also stored in a Secret named after the
+	// CertificateRequest with the suffix "-issued-key", in the namespace of
+	// the request, which is deleted once the key has been stored.
+	// Only enable it for zones whose keys must be escrowed or generated by
+	// TPP.
+	ServiceGeneratedKeys bool
 }
 
 // VenafiCloud defines connection configuration details for Venafi Cloud
@@ -158,6 +462,7 @@ type VenafiCloud struct {
 	URL string
 
 	// APITokenSecretRef is a secret key selector for the Venafi Cloud API token.
+	// Not required if the `credentialsRef` of the Venafi issuer is set.
 	APITokenSecretRef cmmeta.SecretKeySelector
 }
 
@@ -168,6 +473,19 @@ type SelfSignedIssuer struct {
 	// the location of the CRL from which the revocation of this certificate can be checked.
 	// If not set certificate will be issued without CDP. Values are strings.
 	CRLDistributionPoints []string
+
+	// NotBeforeBackdate is the amount of time by which the notBefore
+	// timestamp of issued certificates is moved into the past, to tolerate
+	// clock skew between the issuing and the validating machines.
+	// Must not be negative or greater than 1h. Defaults to no backdating.
+	NotBeforeBackdate *metav1.Duration
+
+	// LegacyExtensions specifies whether certificates issued by this Issuer
+	// include the legacy Netscape certificate type extension, which some old
+	// clients and appliances require. The certificate types are derived from
+	// the usages and basic constraints of the certificate. Defaults to false.
+	// +optional
+	LegacyExtensions bool
 }
 
 // VaultIssuer configures an issuer to sign certificates using a HashiCorp Vault
@@ -214,6 +532,12 @@ type VaultIssuer struct {
 	// Vault server requires mTLS.
 	// +optional
 	ClientKeySecretRef *cmmeta.SecretKeySelector
+
+	// IncludeRootCA specifies whether the self-signed root CA of the issued
+	// certificate is included at the end of the certificate chain returned by
+	// this issuer. By default, the root CA is only returned as the CA of a
+	// CertificateRequest, since clients are expected to already trust it.
+	IncludeRootCA bool
 }
 
 // VaultAuth is configuration used to authenticate with a Vault server. The
@@ -348,6 +672,26 @@ type CAIssuer struct {
 	// As an example, such a URL might be "http://ca.domain.com/ca.crt".
 	// +optional
 	IssuingCertificateURLs []string `json:"issuingCertificateURLs,omitempty"`
+
+	// MaxPathLen sets the path length constraint on CA certificates issued by
+	// this Issuer, i.e. the maximum number of intermediate CAs that may follow
+	// them in a chain. A value of 0 only allows the issued CA to sign leaf
+	// certificates. It has no effect on non-CA certificates. If not set, issued
+	// CA certificates have no path length constraint.
+	MaxPathLen *int32
+
+	// IncludeRootCA specifies whether the self-signed root CA of the issued
+	// certificate is included at the end of the certificate chain returned by
+	// this issuer. By default, the root CA is only returned as the CA of a
+	// CertificateRequest, since clients are expected to already trust it.
+	IncludeRootCA bool
+
+	// LegacyExtensions specifies whether certificates issued by this Issuer
+	// include the legacy Netscape certificate type extension, which some old
+	// clients and appliances require. The certificate types are derived from
+	// the usages and basic constraints of the certificate. Defaults to false.
+	// +optional
+	LegacyExtensions bool
 }
 
 // IssuerStatus contains status information about an Issuer
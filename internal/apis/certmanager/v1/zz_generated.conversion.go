@@ -74,6 +74,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateCABundle)(nil), (*certmanager.CertificateCABundle)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateCABundle_To_certmanager_CertificateCABundle(a.(*v1.CertificateCABundle), b.(*certmanager.CertificateCABundle), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateCABundle)(nil), (*v1.CertificateCABundle)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateCABundle_To_v1_CertificateCABundle(a.(*certmanager.CertificateCABundle), b.(*v1.CertificateCABundle), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateCondition)(nil), (*certmanager.CertificateCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateCondition_To_certmanager_CertificateCondition(a.(*v1.CertificateCondition), b.(*certmanager.CertificateCondition), scope)
 	}); err != nil {
@@ -394,6 +404,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.VenafiChainVerification)(nil), (*certmanager.VenafiChainVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VenafiChainVerification_To_certmanager_VenafiChainVerification(a.(*v1.VenafiChainVerification), b.(*certmanager.VenafiChainVerification), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiChainVerification)(nil), (*v1.VenafiChainVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiChainVerification_To_v1_VenafiChainVerification(a.(*certmanager.VenafiChainVerification), b.(*v1.VenafiChainVerification), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.VenafiCloud)(nil), (*certmanager.VenafiCloud)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_VenafiCloud_To_certmanager_VenafiCloud(a.(*v1.VenafiCloud), b.(*certmanager.VenafiCloud), scope)
 	}); err != nil {
@@ -404,6 +424,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.VenafiCredentialsReference)(nil), (*certmanager.VenafiCredentialsReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VenafiCredentialsReference_To_certmanager_VenafiCredentialsReference(a.(*v1.VenafiCredentialsReference), b.(*certmanager.VenafiCredentialsReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiCredentialsReference)(nil), (*v1.VenafiCredentialsReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiCredentialsReference_To_v1_VenafiCredentialsReference(a.(*certmanager.VenafiCredentialsReference), b.(*v1.VenafiCredentialsReference), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.VenafiIssuer)(nil), (*certmanager.VenafiIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_VenafiIssuer_To_certmanager_VenafiIssuer(a.(*v1.VenafiIssuer), b.(*certmanager.VenafiIssuer), scope)
 	}); err != nil {
@@ -414,6 +444,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.VenafiRetryBackoff)(nil), (*certmanager.VenafiRetryBackoff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VenafiRetryBackoff_To_certmanager_VenafiRetryBackoff(a.(*v1.VenafiRetryBackoff), b.(*certmanager.VenafiRetryBackoff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiRetryBackoff)(nil), (*v1.VenafiRetryBackoff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiRetryBackoff_To_v1_VenafiRetryBackoff(a.(*certmanager.VenafiRetryBackoff), b.(*v1.VenafiRetryBackoff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.VenafiSubjectDefaults)(nil), (*certmanager.VenafiSubjectDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VenafiSubjectDefaults_To_certmanager_VenafiSubjectDefaults(a.(*v1.VenafiSubjectDefaults), b.(*certmanager.VenafiSubjectDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiSubjectDefaults)(nil), (*v1.VenafiSubjectDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiSubjectDefaults_To_v1_VenafiSubjectDefaults(a.(*certmanager.VenafiSubjectDefaults), b.(*v1.VenafiSubjectDefaults), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.VenafiTPP)(nil), (*certmanager.VenafiTPP)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_VenafiTPP_To_certmanager_VenafiTPP(a.(*v1.VenafiTPP), b.(*certmanager.VenafiTPP), scope)
 	}); err != nil {
@@ -442,6 +492,9 @@ func autoConvert_v1_CAIssuer_To_certmanager_CAIssuer(in *v1.CAIssuer, out *certm
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
 	out.IssuingCertificateURLs = *(*[]string)(unsafe.Pointer(&in.IssuingCertificateURLs))
+	out.MaxPathLen = (*int32)(unsafe.Pointer(in.MaxPathLen))
+	out.IncludeRootCA = in.IncludeRootCA
+	out.LegacyExtensions = in.LegacyExtensions
 	return nil
 }
 
@@ -455,6 +508,9 @@ func autoConvert_certmanager_CAIssuer_To_v1_CAIssuer(in *certmanager.CAIssuer, o
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
 	out.IssuingCertificateURLs = *(*[]string)(unsafe.Pointer(&in.IssuingCertificateURLs))
+	out.MaxPathLen = (*int32)(unsafe.Pointer(in.MaxPathLen))
+	out.IncludeRootCA = in.IncludeRootCA
+	out.LegacyExtensions = in.LegacyExtensions
 	return nil
 }
 
@@ -515,6 +571,62 @@ func Convert_certmanager_CertificateAdditionalOutputFormat_To_v1_CertificateAddi
 	return autoConvert_certmanager_CertificateAdditionalOutputFormat_To_v1_CertificateAdditionalOutputFormat(in, out, s)
 }
 
+func autoConvert_v1_CertificateCABundle_To_certmanager_CertificateCABundle(in *v1.CertificateCABundle, out *certmanager.CertificateCABundle, s conversion.Scope) error {
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SecretRef = nil
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(meta.ConfigMapKeySelector)
+		if err := internalapismetav1.Convert_v1_ConfigMapKeySelector_To_meta_ConfigMapKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ConfigMapRef = nil
+	}
+	out.Mode = certmanager.CABundleMode(in.Mode)
+	return nil
+}
+
+// Convert_v1_CertificateCABundle_To_certmanager_CertificateCABundle is an autogenerated conversion function.
+func Convert_v1_CertificateCABundle_To_certmanager_CertificateCABundle(in *v1.CertificateCABundle, out *certmanager.CertificateCABundle, s conversion.Scope) error {
+	return autoConvert_v1_CertificateCABundle_To_certmanager_CertificateCABundle(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateCABundle_To_v1_CertificateCABundle(in *certmanager.CertificateCABundle, out *v1.CertificateCABundle, s conversion.Scope) error {
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SecretRef = nil
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(apismetav1.ConfigMapKeySelector)
+		if err := internalapismetav1.Convert_meta_ConfigMapKeySelector_To_v1_ConfigMapKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ConfigMapRef = nil
+	}
+	out.Mode = v1.CABundleMode(in.Mode)
+	return nil
+}
+
+// Convert_certmanager_CertificateCABundle_To_v1_CertificateCABundle is an autogenerated conversion function.
+func Convert_certmanager_CertificateCABundle_To_v1_CertificateCABundle(in *certmanager.CertificateCABundle, out *v1.CertificateCABundle, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateCABundle_To_v1_CertificateCABundle(in, out, s)
+}
+
 func autoConvert_v1_CertificateCondition_To_certmanager_CertificateCondition(in *v1.CertificateCondition, out *certmanager.CertificateCondition, s conversion.Scope) error {
 	out.Type = certmanager.CertificateConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
@@ -781,6 +893,7 @@ func autoConvert_v1_CertificateRequestSpec_To_certmanager_CertificateRequestSpec
 	out.UID = in.UID
 	out.Groups = *(*[]string)(unsafe.Pointer(&in.Groups))
 	out.Extra = *(*map[string][]string)(unsafe.Pointer(&in.Extra))
+	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
 	return nil
 }
 
@@ -801,6 +914,7 @@ func autoConvert_certmanager_CertificateRequestSpec_To_v1_CertificateRequestSpec
 	out.UID = in.UID
 	out.Groups = *(*[]string)(unsafe.Pointer(&in.Groups))
 	out.Extra = *(*map[string][]string)(unsafe.Pointer(&in.Extra))
+	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
 	return nil
 }
 
@@ -814,6 +928,8 @@ func autoConvert_v1_CertificateRequestStatus_To_certmanager_CertificateRequestSt
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
 	out.FailureTime = (*metav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.ChainLength = (*int)(unsafe.Pointer(in.ChainLength))
+	out.SerialNumber = in.SerialNumber
 	return nil
 }
 
@@ -827,6 +943,8 @@ func autoConvert_certmanager_CertificateRequestStatus_To_v1_CertificateRequestSt
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
 	out.FailureTime = (*metav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.ChainLength = (*int)(unsafe.Pointer(in.ChainLength))
+	out.SerialNumber = in.SerialNumber
 	return nil
 }
 
@@ -880,6 +998,15 @@ func autoConvert_v1_CertificateSpec_To_certmanager_CertificateSpec(in *v1.Certif
 	} else {
 		out.Keystores = nil
 	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = new(certmanager.CertificateCABundle)
+		if err := Convert_v1_CertificateCABundle_To_certmanager_CertificateCABundle(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundle = nil
+	}
 	if err := internalapismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
@@ -890,6 +1017,7 @@ func autoConvert_v1_CertificateSpec_To_certmanager_CertificateSpec(in *v1.Certif
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]certmanager.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
 	out.NameConstraints = (*certmanager.NameConstraints)(unsafe.Pointer(in.NameConstraints))
+	out.OCSPMustStaple = in.OCSPMustStaple
 	return nil
 }
 
@@ -921,6 +1049,15 @@ func autoConvert_certmanager_CertificateSpec_To_v1_CertificateSpec(in *certmanag
 	} else {
 		out.Keystores = nil
 	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = new(v1.CertificateCABundle)
+		if err := Convert_certmanager_CertificateCABundle_To_v1_CertificateCABundle(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundle = nil
+	}
 	if err := internalapismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
@@ -931,6 +1068,7 @@ func autoConvert_certmanager_CertificateSpec_To_v1_CertificateSpec(in *certmanag
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]v1.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
 	out.NameConstraints = (*v1.NameConstraints)(unsafe.Pointer(in.NameConstraints))
+	out.OCSPMustStaple = in.OCSPMustStaple
 	return nil
 }
 
@@ -1231,6 +1369,8 @@ func autoConvert_v1_IssuerSpec_To_certmanager_IssuerSpec(in *v1.IssuerSpec, out
 	if err := Convert_v1_IssuerConfig_To_certmanager_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.MinRSAKeySize = in.MinRSAKeySize
+	out.AllowedECDSACurves = *(*[]string)(unsafe.Pointer(&in.AllowedECDSACurves))
 	return nil
 }
 
@@ -1243,6 +1383,8 @@ func autoConvert_certmanager_IssuerSpec_To_v1_IssuerSpec(in *certmanager.IssuerS
 	if err := Convert_certmanager_IssuerConfig_To_v1_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.MinRSAKeySize = in.MinRSAKeySize
+	out.AllowedECDSACurves = *(*[]string)(unsafe.Pointer(&in.AllowedECDSACurves))
 	return nil
 }
 
@@ -1403,6 +1545,8 @@ func Convert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(in *certmanager.PKC
 
 func autoConvert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *v1.SelfSignedIssuer, out *certmanager.SelfSignedIssuer, s conversion.Scope) error {
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
+	out.NotBeforeBackdate = (*metav1.Duration)(unsafe.Pointer(in.NotBeforeBackdate))
+	out.LegacyExtensions = in.LegacyExtensions
 	return nil
 }
 
@@ -1413,6 +1557,8 @@ func Convert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *v1.SelfSign
 
 func autoConvert_certmanager_SelfSignedIssuer_To_v1_SelfSignedIssuer(in *certmanager.SelfSignedIssuer, out *v1.SelfSignedIssuer, s conversion.Scope) error {
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
+	out.NotBeforeBackdate = (*metav1.Duration)(unsafe.Pointer(in.NotBeforeBackdate))
+	out.LegacyExtensions = in.LegacyExtensions
 	return nil
 }
 
@@ -1604,6 +1750,7 @@ func autoConvert_v1_VaultIssuer_To_certmanager_VaultIssuer(in *v1.VaultIssuer, o
 	} else {
 		out.ClientKeySecretRef = nil
 	}
+	out.IncludeRootCA = in.IncludeRootCA
 	return nil
 }
 
@@ -1647,6 +1794,7 @@ func autoConvert_certmanager_VaultIssuer_To_v1_VaultIssuer(in *certmanager.Vault
 	} else {
 		out.ClientKeySecretRef = nil
 	}
+	out.IncludeRootCA = in.IncludeRootCA
 	return nil
 }
 
@@ -1685,6 +1833,44 @@ func Convert_certmanager_VaultKubernetesAuth_To_v1_VaultKubernetesAuth(in *certm
 	return autoConvert_certmanager_VaultKubernetesAuth_To_v1_VaultKubernetesAuth(in, out, s)
 }
 
+func autoConvert_v1_VenafiChainVerification_To_certmanager_VenafiChainVerification(in *v1.VenafiChainVerification, out *certmanager.VenafiChainVerification, s conversion.Scope) error {
+	if in.TrustAnchorsSecretRef != nil {
+		in, out := &in.TrustAnchorsSecretRef, &out.TrustAnchorsSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TrustAnchorsSecretRef = nil
+	}
+	out.SkipIfUnavailable = in.SkipIfUnavailable
+	return nil
+}
+
+// Convert_v1_VenafiChainVerification_To_certmanager_VenafiChainVerification is an autogenerated conversion function.
+func Convert_v1_VenafiChainVerification_To_certmanager_VenafiChainVerification(in *v1.VenafiChainVerification, out *certmanager.VenafiChainVerification, s conversion.Scope) error {
+	return autoConvert_v1_VenafiChainVerification_To_certmanager_VenafiChainVerification(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiChainVerification_To_v1_VenafiChainVerification(in *certmanager.VenafiChainVerification, out *v1.VenafiChainVerification, s conversion.Scope) error {
+	if in.TrustAnchorsSecretRef != nil {
+		in, out := &in.TrustAnchorsSecretRef, &out.TrustAnchorsSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TrustAnchorsSecretRef = nil
+	}
+	out.SkipIfUnavailable = in.SkipIfUnavailable
+	return nil
+}
+
+// Convert_certmanager_VenafiChainVerification_To_v1_VenafiChainVerification is an autogenerated conversion function.
+func Convert_certmanager_VenafiChainVerification_To_v1_VenafiChainVerification(in *certmanager.VenafiChainVerification, out *v1.VenafiChainVerification, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiChainVerification_To_v1_VenafiChainVerification(in, out, s)
+}
+
 func autoConvert_v1_VenafiCloud_To_certmanager_VenafiCloud(in *v1.VenafiCloud, out *certmanager.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
 	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
@@ -1711,8 +1897,34 @@ func Convert_certmanager_VenafiCloud_To_v1_VenafiCloud(in *certmanager.VenafiClo
 	return autoConvert_certmanager_VenafiCloud_To_v1_VenafiCloud(in, out, s)
 }
 
+func autoConvert_v1_VenafiCredentialsReference_To_certmanager_VenafiCredentialsReference(in *v1.VenafiCredentialsReference, out *certmanager.VenafiCredentialsReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Kind = in.Kind
+	out.Group = in.Group
+	return nil
+}
+
+// Convert_v1_VenafiCredentialsReference_To_certmanager_VenafiCredentialsReference is an autogenerated conversion function.
+func Convert_v1_VenafiCredentialsReference_To_certmanager_VenafiCredentialsReference(in *v1.VenafiCredentialsReference, out *certmanager.VenafiCredentialsReference, s conversion.Scope) error {
+	return autoConvert_v1_VenafiCredentialsReference_To_certmanager_VenafiCredentialsReference(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiCredentialsReference_To_v1_VenafiCredentialsReference(in *certmanager.VenafiCredentialsReference, out *v1.VenafiCredentialsReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Kind = in.Kind
+	out.Group = in.Group
+	return nil
+}
+
+// Convert_certmanager_VenafiCredentialsReference_To_v1_VenafiCredentialsReference is an autogenerated conversion function.
+func Convert_certmanager_VenafiCredentialsReference_To_v1_VenafiCredentialsReference(in *certmanager.VenafiCredentialsReference, out *v1.VenafiCredentialsReference, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiCredentialsReference_To_v1_VenafiCredentialsReference(in, out, s)
+}
+
 func autoConvert_v1_VenafiIssuer_To_certmanager_VenafiIssuer(in *v1.VenafiIssuer, out *certmanager.VenafiIssuer, s conversion.Scope) error {
 	out.Zone = in.Zone
+	out.AdditionalZones = *(*[]string)(unsafe.Pointer(&in.AdditionalZones))
+	out.AllowedZoneOverrides = *(*[]string)(unsafe.Pointer(&in.AllowedZoneOverrides))
 	if in.TPP != nil {
 		in, out := &in.TPP, &out.TPP
 		*out = new(certmanager.VenafiTPP)
@@ -1731,6 +1943,43 @@ func autoConvert_v1_VenafiIssuer_To_certmanager_VenafiIssuer(in *v1.VenafiIssuer
 	} else {
 		out.Cloud = nil
 	}
+	out.RetryBackoff = (*certmanager.VenafiRetryBackoff)(unsafe.Pointer(in.RetryBackoff))
+	out.IssuanceDeadline = (*metav1.Duration)(unsafe.Pointer(in.IssuanceDeadline))
+	out.IncludeRootCA = in.IncludeRootCA
+	out.MaxDuration = (*metav1.Duration)(unsafe.Pointer(in.MaxDuration))
+	out.MinDuration = (*metav1.Duration)(unsafe.Pointer(in.MinDuration))
+	out.DefaultDuration = (*metav1.Duration)(unsafe.Pointer(in.DefaultDuration))
+	out.CredentialsRef = (*certmanager.VenafiCredentialsReference)(unsafe.Pointer(in.CredentialsRef))
+	out.FallbackCredentialsRefs = *(*[]certmanager.VenafiCredentialsReference)(unsafe.Pointer(&in.FallbackCredentialsRefs))
+	out.RevokeOnDelete = in.RevokeOnDelete
+	if in.ChainBundleSecretRef != nil {
+		in, out := &in.ChainBundleSecretRef, &out.ChainBundleSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ChainBundleSecretRef = nil
+	}
+	if in.ChainVerification != nil {
+		in, out := &in.ChainVerification, &out.ChainVerification
+		*out = new(certmanager.VenafiChainVerification)
+		if err := Convert_v1_VenafiChainVerification_To_certmanager_VenafiChainVerification(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ChainVerification = nil
+	}
+	out.AllowedDomains = *(*[]string)(unsafe.Pointer(&in.AllowedDomains))
+	out.AllowedExtensions = *(*[]string)(unsafe.Pointer(&in.AllowedExtensions))
+	out.LegacyExtensions = in.LegacyExtensions
+	out.ReuseExisting = in.ReuseExisting
+	out.ReuseMaxAge = (*metav1.Duration)(unsafe.Pointer(in.ReuseMaxAge))
+	out.SubjectDefaults = (*certmanager.VenafiSubjectDefaults)(unsafe.Pointer(in.SubjectDefaults))
+	out.DefaultAnnotations = *(*map[string]string)(unsafe.Pointer(&in.DefaultAnnotations))
+	out.OriginCustomField = in.OriginCustomField
+	out.OriginTemplate = in.OriginTemplate
+	out.FailClosed = in.FailClosed
 	return nil
 }
 
@@ -1741,6 +1990,8 @@ func Convert_v1_VenafiIssuer_To_certmanager_VenafiIssuer(in *v1.VenafiIssuer, ou
 
 func autoConvert_certmanager_VenafiIssuer_To_v1_VenafiIssuer(in *certmanager.VenafiIssuer, out *v1.VenafiIssuer, s conversion.Scope) error {
 	out.Zone = in.Zone
+	out.AdditionalZones = *(*[]string)(unsafe.Pointer(&in.AdditionalZones))
+	out.AllowedZoneOverrides = *(*[]string)(unsafe.Pointer(&in.AllowedZoneOverrides))
 	if in.TPP != nil {
 		in, out := &in.TPP, &out.TPP
 		*out = new(v1.VenafiTPP)
@@ -1759,6 +2010,43 @@ func autoConvert_certmanager_VenafiIssuer_To_v1_VenafiIssuer(in *certmanager.Ven
 	} else {
 		out.Cloud = nil
 	}
+	out.RetryBackoff = (*v1.VenafiRetryBackoff)(unsafe.Pointer(in.RetryBackoff))
+	out.IssuanceDeadline = (*metav1.Duration)(unsafe.Pointer(in.IssuanceDeadline))
+	out.IncludeRootCA = in.IncludeRootCA
+	out.MaxDuration = (*metav1.Duration)(unsafe.Pointer(in.MaxDuration))
+	out.MinDuration = (*metav1.Duration)(unsafe.Pointer(in.MinDuration))
+	out.DefaultDuration = (*metav1.Duration)(unsafe.Pointer(in.DefaultDuration))
+	out.CredentialsRef = (*v1.VenafiCredentialsReference)(unsafe.Pointer(in.CredentialsRef))
+	out.FallbackCredentialsRefs = *(*[]v1.VenafiCredentialsReference)(unsafe.Pointer(&in.FallbackCredentialsRefs))
+	out.RevokeOnDelete = in.RevokeOnDelete
+	if in.ChainBundleSecretRef != nil {
+		in, out := &in.ChainBundleSecretRef, &out.ChainBundleSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ChainBundleSecretRef = nil
+	}
+	if in.ChainVerification != nil {
+		in, out := &in.ChainVerification, &out.ChainVerification
+		*out = new(v1.VenafiChainVerification)
+		if err := Convert_certmanager_VenafiChainVerification_To_v1_VenafiChainVerification(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ChainVerification = nil
+	}
+	out.AllowedDomains = *(*[]string)(unsafe.Pointer(&in.AllowedDomains))
+	out.AllowedExtensions = *(*[]string)(unsafe.Pointer(&in.AllowedExtensions))
+	out.LegacyExtensions = in.LegacyExtensions
+	out.ReuseExisting = in.ReuseExisting
+	out.ReuseMaxAge = (*metav1.Duration)(unsafe.Pointer(in.ReuseMaxAge))
+	out.SubjectDefaults = (*v1.VenafiSubjectDefaults)(unsafe.Pointer(in.SubjectDefaults))
+	out.DefaultAnnotations = *(*map[string]string)(unsafe.Pointer(&in.DefaultAnnotations))
+	out.OriginCustomField = in.OriginCustomField
+	out.OriginTemplate = in.OriginTemplate
+	out.FailClosed = in.FailClosed
 	return nil
 }
 
@@ -1767,6 +2055,58 @@ func Convert_certmanager_VenafiIssuer_To_v1_VenafiIssuer(in *certmanager.VenafiI
 	return autoConvert_certmanager_VenafiIssuer_To_v1_VenafiIssuer(in, out, s)
 }
 
+func autoConvert_v1_VenafiRetryBackoff_To_certmanager_VenafiRetryBackoff(in *v1.VenafiRetryBackoff, out *certmanager.VenafiRetryBackoff, s conversion.Scope) error {
+	out.InitialInterval = (*metav1.Duration)(unsafe.Pointer(in.InitialInterval))
+	out.MaxInterval = (*metav1.Duration)(unsafe.Pointer(in.MaxInterval))
+	out.Jitter = (*metav1.Duration)(unsafe.Pointer(in.Jitter))
+	return nil
+}
+
+// Convert_v1_VenafiRetryBackoff_To_certmanager_VenafiRetryBackoff is an autogenerated conversion function.
+func Convert_v1_VenafiRetryBackoff_To_certmanager_VenafiRetryBackoff(in *v1.VenafiRetryBackoff, out *certmanager.VenafiRetryBackoff, s conversion.Scope) error {
+	return autoConvert_v1_VenafiRetryBackoff_To_certmanager_VenafiRetryBackoff(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiRetryBackoff_To_v1_VenafiRetryBackoff(in *certmanager.VenafiRetryBackoff, out *v1.VenafiRetryBackoff, s conversion.Scope) error {
+	out.InitialInterval = (*metav1.Duration)(unsafe.Pointer(in.InitialInterval))
+	out.MaxInterval = (*metav1.Duration)(unsafe.Pointer(in.MaxInterval))
+	out.Jitter = (*metav1.Duration)(unsafe.Pointer(in.Jitter))
+	return nil
+}
+
+// Convert_certmanager_VenafiRetryBackoff_To_v1_VenafiRetryBackoff is an autogenerated conversion function.
+func Convert_certmanager_VenafiRetryBackoff_To_v1_VenafiRetryBackoff(in *certmanager.VenafiRetryBackoff, out *v1.VenafiRetryBackoff, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiRetryBackoff_To_v1_VenafiRetryBackoff(in, out, s)
+}
+
+func autoConvert_v1_VenafiSubjectDefaults_To_certmanager_VenafiSubjectDefaults(in *v1.VenafiSubjectDefaults, out *certmanager.VenafiSubjectDefaults, s conversion.Scope) error {
+	out.Organizations = *(*[]string)(unsafe.Pointer(&in.Organizations))
+	out.OrganizationalUnits = *(*[]string)(unsafe.Pointer(&in.OrganizationalUnits))
+	out.Localities = *(*[]string)(unsafe.Pointer(&in.Localities))
+	out.Provinces = *(*[]string)(unsafe.Pointer(&in.Provinces))
+	out.Countries = *(*[]string)(unsafe.Pointer(&in.Countries))
+	return nil
+}
+
+// Convert_v1_VenafiSubjectDefaults_To_certmanager_VenafiSubjectDefaults is an autogenerated conversion function.
+func Convert_v1_VenafiSubjectDefaults_To_certmanager_VenafiSubjectDefaults(in *v1.VenafiSubjectDefaults, out *certmanager.VenafiSubjectDefaults, s conversion.Scope) error {
+	return autoConvert_v1_VenafiSubjectDefaults_To_certmanager_VenafiSubjectDefaults(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiSubjectDefaults_To_v1_VenafiSubjectDefaults(in *certmanager.VenafiSubjectDefaults, out *v1.VenafiSubjectDefaults, s conversion.Scope) error {
+	out.Organizations = *(*[]string)(unsafe.Pointer(&in.Organizations))
+	out.OrganizationalUnits = *(*[]string)(unsafe.Pointer(&in.OrganizationalUnits))
+	out.Localities = *(*[]string)(unsafe.Pointer(&in.Localities))
+	out.Provinces = *(*[]string)(unsafe.Pointer(&in.Provinces))
+	out.Countries = *(*[]string)(unsafe.Pointer(&in.Countries))
+	return nil
+}
+
+// Convert_certmanager_VenafiSubjectDefaults_To_v1_VenafiSubjectDefaults is an autogenerated conversion function.
+func Convert_certmanager_VenafiSubjectDefaults_To_v1_VenafiSubjectDefaults(in *certmanager.VenafiSubjectDefaults, out *v1.VenafiSubjectDefaults, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiSubjectDefaults_To_v1_VenafiSubjectDefaults(in, out, s)
+}
+
 func autoConvert_v1_VenafiTPP_To_certmanager_VenafiTPP(in *v1.VenafiTPP, out *certmanager.VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
 	if err := internalapismetav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
@@ -1782,6 +2122,16 @@ func autoConvert_v1_VenafiTPP_To_certmanager_VenafiTPP(in *v1.VenafiTPP, out *ce
 	} else {
 		out.CABundleSecretRef = nil
 	}
+	if in.ClientCertificateSecretRef != nil {
+		in, out := &in.ClientCertificateSecretRef, &out.ClientCertificateSecretRef
+		*out = new(meta.LocalObjectReference)
+		if err := internalapismetav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ClientCertificateSecretRef = nil
+	}
+	out.ServiceGeneratedKeys = in.ServiceGeneratedKeys
 	return nil
 }
 
@@ -1805,6 +2155,16 @@ func autoConvert_certmanager_VenafiTPP_To_v1_VenafiTPP(in *certmanager.VenafiTPP
 	} else {
 		out.CABundleSecretRef = nil
 	}
+	if in.ClientCertificateSecretRef != nil {
+		in, out := &in.ClientCertificateSecretRef, &out.ClientCertificateSecretRef
+		*out = new(apismetav1.LocalObjectReference)
+		if err := internalapismetav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ClientCertificateSecretRef = nil
+	}
+	out.ServiceGeneratedKeys = in.ServiceGeneratedKeys
 	return nil
 }
 
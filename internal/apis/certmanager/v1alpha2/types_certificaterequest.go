@@ -122,6 +122,14 @@ type CertificateRequestSpec struct {
 	// Populated by the cert-manager webhook on creation and immutable.
 	// +optional
 	Extra map[string][]string `json:"extra,omitempty"`
+
+	// Requested time at which the issued certificate should expire. Can be
+	// used instead of `duration` for certificates which must expire at a
+	// specific point in time. Note that the issuer may choose to ignore the
+	// requested notAfter time, just like any other requested attribute.
+	// Must not be set together with `duration`.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
 }
 
 // CertificateRequestStatus defines the observed state of CertificateRequest and
@@ -153,6 +161,18 @@ type CertificateRequestStatus struct {
 	// used to influence garbage collection and back-off.
 	// +optional
 	FailureTime *metav1.Time `json:"failureTime,omitempty"`
+
+	// ChainLength is the number of certificates in the `certificate` field,
+	// including the issued certificate itself. A value of 1 means that the
+	// issuer did not return any intermediate certificates.
+	// +optional
+	ChainLength *int `json:"chainLength,omitempty"`
+
+	// SerialNumber is the serial number of the issued certificate, formatted
+	// as colon-separated pairs of upper case hexadecimal digits, for example
+	// `0A:1B:2C`.
+	// +optional
+	SerialNumber string `json:"serialNumber,omitempty"`
 }
 
 // CertificateRequestCondition contains condition information for a CertificateRequest.
@@ -46,6 +46,11 @@ func (in *CAIssuer) DeepCopyInto(out *CAIssuer) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.MaxPathLen != nil {
+		in, out := &in.MaxPathLen, &out.MaxPathLen
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -103,6 +108,32 @@ func (in *CertificateAdditionalOutputFormat) DeepCopy() *CertificateAdditionalOu
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateCABundle) DeepCopyInto(out *CertificateCABundle) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(metav1.SecretKeySelector)
+		**out = **in
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(metav1.ConfigMapKeySelector)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateCABundle.
+func (in *CertificateCABundle) DeepCopy() *CertificateCABundle {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateCABundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateCondition) DeepCopyInto(out *CertificateCondition) {
 	*out = *in
@@ -318,6 +349,10 @@ func (in *CertificateRequestSpec) DeepCopyInto(out *CertificateRequestSpec) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -355,6 +390,11 @@ func (in *CertificateRequestStatus) DeepCopyInto(out *CertificateRequestStatus)
 		in, out := &in.FailureTime, &out.FailureTime
 		*out = (*in).DeepCopy()
 	}
+	if in.ChainLength != nil {
+		in, out := &in.ChainLength, &out.ChainLength
+		*out = new(int)
+		**out = **in
+	}
 	return
 }
 
@@ -461,6 +501,11 @@ func (in *CertificateSpec) DeepCopyInto(out *CertificateSpec) {
 		*out = new(CertificateKeystores)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = new(CertificateCABundle)
+		(*in).DeepCopyInto(*out)
+	}
 	out.IssuerRef = in.IssuerRef
 	if in.Usages != nil {
 		in, out := &in.Usages, &out.Usages
@@ -746,6 +791,11 @@ func (in *IssuerList) DeepCopyObject() runtime.Object {
 func (in *IssuerSpec) DeepCopyInto(out *IssuerSpec) {
 	*out = *in
 	in.IssuerConfig.DeepCopyInto(&out.IssuerConfig)
+	if in.AllowedECDSACurves != nil {
+		in, out := &in.AllowedECDSACurves, &out.AllowedECDSACurves
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -912,6 +962,11 @@ func (in *SelfSignedIssuer) DeepCopyInto(out *SelfSignedIssuer) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.NotBeforeBackdate != nil {
+		in, out := &in.NotBeforeBackdate, &out.NotBeforeBackdate
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -1074,6 +1129,27 @@ func (in *VaultKubernetesAuth) DeepCopy() *VaultKubernetesAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VenafiChainVerification) DeepCopyInto(out *VenafiChainVerification) {
+	*out = *in
+	if in.TrustAnchorsSecretRef != nil {
+		in, out := &in.TrustAnchorsSecretRef, &out.TrustAnchorsSecretRef
+		*out = new(metav1.SecretKeySelector)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VenafiChainVerification.
+func (in *VenafiChainVerification) DeepCopy() *VenafiChainVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(VenafiChainVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VenafiCloud) DeepCopyInto(out *VenafiCloud) {
 	*out = *in
@@ -1091,9 +1167,35 @@ func (in *VenafiCloud) DeepCopy() *VenafiCloud {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VenafiCredentialsReference) DeepCopyInto(out *VenafiCredentialsReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VenafiCredentialsReference.
+func (in *VenafiCredentialsReference) DeepCopy() *VenafiCredentialsReference {
+	if in == nil {
+		return nil
+	}
+	out := new(VenafiCredentialsReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VenafiIssuer) DeepCopyInto(out *VenafiIssuer) {
 	*out = *in
+	if in.AdditionalZones != nil {
+		in, out := &in.AdditionalZones, &out.AdditionalZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedZoneOverrides != nil {
+		in, out := &in.AllowedZoneOverrides, &out.AllowedZoneOverrides
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.TPP != nil {
 		in, out := &in.TPP, &out.TPP
 		*out = new(VenafiTPP)
@@ -1104,6 +1206,78 @@ func (in *VenafiIssuer) DeepCopyInto(out *VenafiIssuer) {
 		*out = new(VenafiCloud)
 		**out = **in
 	}
+	if in.RetryBackoff != nil {
+		in, out := &in.RetryBackoff, &out.RetryBackoff
+		*out = new(VenafiRetryBackoff)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IssuanceDeadline != nil {
+		in, out := &in.IssuanceDeadline, &out.IssuanceDeadline
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxDuration != nil {
+		in, out := &in.MaxDuration, &out.MaxDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MinDuration != nil {
+		in, out := &in.MinDuration, &out.MinDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DefaultDuration != nil {
+		in, out := &in.DefaultDuration, &out.DefaultDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CredentialsRef != nil {
+		in, out := &in.CredentialsRef, &out.CredentialsRef
+		*out = new(VenafiCredentialsReference)
+		**out = **in
+	}
+	if in.FallbackCredentialsRefs != nil {
+		in, out := &in.FallbackCredentialsRefs, &out.FallbackCredentialsRefs
+		*out = make([]VenafiCredentialsReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ChainBundleSecretRef != nil {
+		in, out := &in.ChainBundleSecretRef, &out.ChainBundleSecretRef
+		*out = new(metav1.SecretKeySelector)
+		**out = **in
+	}
+	if in.ChainVerification != nil {
+		in, out := &in.ChainVerification, &out.ChainVerification
+		*out = new(VenafiChainVerification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedDomains != nil {
+		in, out := &in.AllowedDomains, &out.AllowedDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedExtensions != nil {
+		in, out := &in.AllowedExtensions, &out.AllowedExtensions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReuseMaxAge != nil {
+		in, out := &in.ReuseMaxAge, &out.ReuseMaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SubjectDefaults != nil {
+		in, out := &in.SubjectDefaults, &out.SubjectDefaults
+		*out = new(VenafiSubjectDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultAnnotations != nil {
+		in, out := &in.DefaultAnnotations, &out.DefaultAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -1117,6 +1291,78 @@ func (in *VenafiIssuer) DeepCopy() *VenafiIssuer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VenafiRetryBackoff) DeepCopyInto(out *VenafiRetryBackoff) {
+	*out = *in
+	if in.InitialInterval != nil {
+		in, out := &in.InitialInterval, &out.InitialInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxInterval != nil {
+		in, out := &in.MaxInterval, &out.MaxInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Jitter != nil {
+		in, out := &in.Jitter, &out.Jitter
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VenafiRetryBackoff.
+func (in *VenafiRetryBackoff) DeepCopy() *VenafiRetryBackoff {
+	if in == nil {
+		return nil
+	}
+	out := new(VenafiRetryBackoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VenafiSubjectDefaults) DeepCopyInto(out *VenafiSubjectDefaults) {
+	*out = *in
+	if in.Organizations != nil {
+		in, out := &in.Organizations, &out.Organizations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OrganizationalUnits != nil {
+		in, out := &in.OrganizationalUnits, &out.OrganizationalUnits
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Localities != nil {
+		in, out := &in.Localities, &out.Localities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Provinces != nil {
+		in, out := &in.Provinces, &out.Provinces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Countries != nil {
+		in, out := &in.Countries, &out.Countries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VenafiSubjectDefaults.
+func (in *VenafiSubjectDefaults) DeepCopy() *VenafiSubjectDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(VenafiSubjectDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VenafiTPP) DeepCopyInto(out *VenafiTPP) {
 	*out = *in
@@ -1131,6 +1377,11 @@ func (in *VenafiTPP) DeepCopyInto(out *VenafiTPP) {
 		*out = new(metav1.SecretKeySelector)
 		**out = **in
 	}
+	if in.ClientCertificateSecretRef != nil {
+		in, out := &in.ClientCertificateSecretRef, &out.ClientCertificateSecretRef
+		*out = new(metav1.LocalObjectReference)
+		**out = **in
+	}
 	return
 }
 
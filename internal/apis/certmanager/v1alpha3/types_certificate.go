@@ -176,6 +176,13 @@ type CertificateSpec struct {
 	// +optional
 	Keystores *CertificateKeystores `json:"keystores,omitempty"`
 
+	// CABundle configures a CA bundle to be stored in the `ca.crt` key of the
+	// Certificate's Secret, in place of or in addition to the CA certificate
+	// returned by the issuer. This is useful when the issuer does not return
+	// the root CA of the chain, for example for some Venafi TPP policies.
+	// +optional
+	CABundle *CertificateCABundle `json:"caBundle,omitempty"`
+
 	// IssuerRef is a reference to the issuer for this certificate.
 	// If the `kind` field is not set, or set to `Issuer`, an Issuer resource
 	// with the given name in the same namespace as the Certificate will be used.
@@ -254,6 +261,13 @@ type CertificateSpec struct {
 	// the controller and webhook components.
 	// +optional
 	NameConstraints *NameConstraints `json:"nameConstraints,omitempty"`
+
+	// Requests the OCSP Must-Staple (TLS feature status_request) extension in
+	// the certificate, which tells clients to reject the certificate unless
+	// the server staples a valid OCSP response to the TLS handshake.
+	// Issuers which cannot honor the extension fail the request.
+	// +optional
+	OCSPMustStaple bool `json:"ocspMustStaple,omitempty"`
 }
 
 type OtherName struct {
@@ -328,6 +342,53 @@ type X509Subject struct {
 	SerialNumber string `json:"serialNumber,omitempty"`
 }
 
+// CertificateCABundle configures the CA bundle stored in the `ca.crt` key of
+// the Certificate's Secret. Exactly one of SecretRef or ConfigMapRef must be
+// set. The bundle must contain at least one PEM encoded certificate, and is
+// read again whenever the Secret is updated.
+type CertificateCABundle struct {
+	// SecretRef is a reference to a key in a Secret resource containing the
+	// PEM encoded CA certificates of the bundle. The Secret is read from the
+	// namespace of the Certificate. If the key is not set, it defaults to
+	// `ca.crt`.
+	// +optional
+	SecretRef *cmmeta.SecretKeySelector `json:"secretRef,omitempty"`
+
+	// ConfigMapRef is a reference to a key in a ConfigMap resource containing
+	// the PEM encoded CA certificates of the bundle. The ConfigMap is read
+	// from the namespace of the Certificate. If the key is not set, it
+	// defaults to `ca.crt`.
+	// +optional
+	ConfigMapRef *cmmeta.ConfigMapKeySelector `json:"configMapRef,omitempty"`
+
+	// Mode specifies how the bundle is combined with the CA certificate
+	// returned by the issuer. Default value is `Replace`.
+	//
+	// If provided, allowed values are:
+	// `Replace`: the `ca.crt` key only contains the bundle, and the CA
+	// certificate returned by the issuer is not stored.
+	// `Append`: the `ca.crt` key contains the CA certificate returned by the
+	// issuer, followed by the certificates of the bundle which it does not
+	// already contain.
+	//
+	// The truststores of the keystores contain the same certificates as the
+	// `ca.crt` key.
+	// +optional
+	Mode CABundleMode `json:"mode,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Replace;Append
+type CABundleMode string
+
+const (
+	// ReplaceCABundleMode stores only the bundle in the `ca.crt` key.
+	ReplaceCABundleMode CABundleMode = "Replace"
+
+	// AppendCABundleMode stores the CA certificate returned by the issuer
+	// followed by the bundle in the `ca.crt` key.
+	AppendCABundleMode CABundleMode = "Append"
+)
+
 // CertificateKeystores configures additional keystore output formats to be
 // created in the Certificate's output Secret.
 type CertificateKeystores struct {
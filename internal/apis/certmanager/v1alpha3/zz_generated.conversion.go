@@ -72,6 +72,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateCABundle)(nil), (*certmanager.CertificateCABundle)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateCABundle_To_certmanager_CertificateCABundle(a.(*CertificateCABundle), b.(*certmanager.CertificateCABundle), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateCABundle)(nil), (*CertificateCABundle)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateCABundle_To_v1alpha3_CertificateCABundle(a.(*certmanager.CertificateCABundle), b.(*CertificateCABundle), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificateCondition)(nil), (*certmanager.CertificateCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CertificateCondition_To_certmanager_CertificateCondition(a.(*CertificateCondition), b.(*certmanager.CertificateCondition), scope)
 	}); err != nil {
@@ -362,6 +372,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*VenafiChainVerification)(nil), (*certmanager.VenafiChainVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VenafiChainVerification_To_certmanager_VenafiChainVerification(a.(*VenafiChainVerification), b.(*certmanager.VenafiChainVerification), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiChainVerification)(nil), (*VenafiChainVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiChainVerification_To_v1alpha3_VenafiChainVerification(a.(*certmanager.VenafiChainVerification), b.(*VenafiChainVerification), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*VenafiCloud)(nil), (*certmanager.VenafiCloud)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VenafiCloud_To_certmanager_VenafiCloud(a.(*VenafiCloud), b.(*certmanager.VenafiCloud), scope)
 	}); err != nil {
@@ -372,6 +392,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*VenafiCredentialsReference)(nil), (*certmanager.VenafiCredentialsReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VenafiCredentialsReference_To_certmanager_VenafiCredentialsReference(a.(*VenafiCredentialsReference), b.(*certmanager.VenafiCredentialsReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiCredentialsReference)(nil), (*VenafiCredentialsReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiCredentialsReference_To_v1alpha3_VenafiCredentialsReference(a.(*certmanager.VenafiCredentialsReference), b.(*VenafiCredentialsReference), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*VenafiIssuer)(nil), (*certmanager.VenafiIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VenafiIssuer_To_certmanager_VenafiIssuer(a.(*VenafiIssuer), b.(*certmanager.VenafiIssuer), scope)
 	}); err != nil {
@@ -382,6 +412,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*VenafiRetryBackoff)(nil), (*certmanager.VenafiRetryBackoff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VenafiRetryBackoff_To_certmanager_VenafiRetryBackoff(a.(*VenafiRetryBackoff), b.(*certmanager.VenafiRetryBackoff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiRetryBackoff)(nil), (*VenafiRetryBackoff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiRetryBackoff_To_v1alpha3_VenafiRetryBackoff(a.(*certmanager.VenafiRetryBackoff), b.(*VenafiRetryBackoff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VenafiSubjectDefaults)(nil), (*certmanager.VenafiSubjectDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VenafiSubjectDefaults_To_certmanager_VenafiSubjectDefaults(a.(*VenafiSubjectDefaults), b.(*certmanager.VenafiSubjectDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiSubjectDefaults)(nil), (*VenafiSubjectDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiSubjectDefaults_To_v1alpha3_VenafiSubjectDefaults(a.(*certmanager.VenafiSubjectDefaults), b.(*VenafiSubjectDefaults), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*VenafiTPP)(nil), (*certmanager.VenafiTPP)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VenafiTPP_To_certmanager_VenafiTPP(a.(*VenafiTPP), b.(*certmanager.VenafiTPP), scope)
 	}); err != nil {
@@ -440,6 +490,9 @@ func autoConvert_v1alpha3_CAIssuer_To_certmanager_CAIssuer(in *CAIssuer, out *ce
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
 	out.IssuingCertificateURLs = *(*[]string)(unsafe.Pointer(&in.IssuingCertificateURLs))
+	out.MaxPathLen = (*int32)(unsafe.Pointer(in.MaxPathLen))
+	out.IncludeRootCA = in.IncludeRootCA
+	out.LegacyExtensions = in.LegacyExtensions
 	return nil
 }
 
@@ -453,6 +506,9 @@ func autoConvert_certmanager_CAIssuer_To_v1alpha3_CAIssuer(in *certmanager.CAIss
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
 	out.IssuingCertificateURLs = *(*[]string)(unsafe.Pointer(&in.IssuingCertificateURLs))
+	out.MaxPathLen = (*int32)(unsafe.Pointer(in.MaxPathLen))
+	out.IncludeRootCA = in.IncludeRootCA
+	out.LegacyExtensions = in.LegacyExtensions
 	return nil
 }
 
@@ -513,6 +569,62 @@ func Convert_certmanager_CertificateAdditionalOutputFormat_To_v1alpha3_Certifica
 	return autoConvert_certmanager_CertificateAdditionalOutputFormat_To_v1alpha3_CertificateAdditionalOutputFormat(in, out, s)
 }
 
+func autoConvert_v1alpha3_CertificateCABundle_To_certmanager_CertificateCABundle(in *CertificateCABundle, out *certmanager.CertificateCABundle, s conversion.Scope) error {
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SecretRef = nil
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(meta.ConfigMapKeySelector)
+		if err := apismetav1.Convert_v1_ConfigMapKeySelector_To_meta_ConfigMapKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ConfigMapRef = nil
+	}
+	out.Mode = certmanager.CABundleMode(in.Mode)
+	return nil
+}
+
+// Convert_v1alpha3_CertificateCABundle_To_certmanager_CertificateCABundle is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateCABundle_To_certmanager_CertificateCABundle(in *CertificateCABundle, out *certmanager.CertificateCABundle, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateCABundle_To_certmanager_CertificateCABundle(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateCABundle_To_v1alpha3_CertificateCABundle(in *certmanager.CertificateCABundle, out *CertificateCABundle, s conversion.Scope) error {
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(metav1.SecretKeySelector)
+		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SecretRef = nil
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(metav1.ConfigMapKeySelector)
+		if err := apismetav1.Convert_meta_ConfigMapKeySelector_To_v1_ConfigMapKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ConfigMapRef = nil
+	}
+	out.Mode = CABundleMode(in.Mode)
+	return nil
+}
+
+// Convert_certmanager_CertificateCABundle_To_v1alpha3_CertificateCABundle is an autogenerated conversion function.
+func Convert_certmanager_CertificateCABundle_To_v1alpha3_CertificateCABundle(in *certmanager.CertificateCABundle, out *CertificateCABundle, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateCABundle_To_v1alpha3_CertificateCABundle(in, out, s)
+}
+
 func autoConvert_v1alpha3_CertificateCondition_To_certmanager_CertificateCondition(in *CertificateCondition, out *certmanager.CertificateCondition, s conversion.Scope) error {
 	out.Type = certmanager.CertificateConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
@@ -771,6 +883,7 @@ func autoConvert_v1alpha3_CertificateRequestSpec_To_certmanager_CertificateReque
 	out.UID = in.UID
 	out.Groups = *(*[]string)(unsafe.Pointer(&in.Groups))
 	out.Extra = *(*map[string][]string)(unsafe.Pointer(&in.Extra))
+	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
 	return nil
 }
 
@@ -786,6 +899,7 @@ func autoConvert_certmanager_CertificateRequestSpec_To_v1alpha3_CertificateReque
 	out.UID = in.UID
 	out.Groups = *(*[]string)(unsafe.Pointer(&in.Groups))
 	out.Extra = *(*map[string][]string)(unsafe.Pointer(&in.Extra))
+	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
 	return nil
 }
 
@@ -794,6 +908,8 @@ func autoConvert_v1alpha3_CertificateRequestStatus_To_certmanager_CertificateReq
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
 	out.FailureTime = (*v1.Time)(unsafe.Pointer(in.FailureTime))
+	out.ChainLength = (*int)(unsafe.Pointer(in.ChainLength))
+	out.SerialNumber = in.SerialNumber
 	return nil
 }
 
@@ -807,6 +923,8 @@ func autoConvert_certmanager_CertificateRequestStatus_To_v1alpha3_CertificateReq
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
 	out.FailureTime = (*v1.Time)(unsafe.Pointer(in.FailureTime))
+	out.ChainLength = (*int)(unsafe.Pointer(in.ChainLength))
+	out.SerialNumber = in.SerialNumber
 	return nil
 }
 
@@ -868,6 +986,15 @@ func autoConvert_v1alpha3_CertificateSpec_To_certmanager_CertificateSpec(in *Cer
 	} else {
 		out.Keystores = nil
 	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = new(certmanager.CertificateCABundle)
+		if err := Convert_v1alpha3_CertificateCABundle_To_certmanager_CertificateCABundle(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundle = nil
+	}
 	if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
@@ -889,6 +1016,7 @@ func autoConvert_v1alpha3_CertificateSpec_To_certmanager_CertificateSpec(in *Cer
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]certmanager.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
 	out.NameConstraints = (*certmanager.NameConstraints)(unsafe.Pointer(in.NameConstraints))
+	out.OCSPMustStaple = in.OCSPMustStaple
 	return nil
 }
 
@@ -923,6 +1051,15 @@ func autoConvert_certmanager_CertificateSpec_To_v1alpha3_CertificateSpec(in *cer
 	} else {
 		out.Keystores = nil
 	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = new(CertificateCABundle)
+		if err := Convert_certmanager_CertificateCABundle_To_v1alpha3_CertificateCABundle(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundle = nil
+	}
 	if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
@@ -941,6 +1078,7 @@ func autoConvert_certmanager_CertificateSpec_To_v1alpha3_CertificateSpec(in *cer
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
 	out.NameConstraints = (*NameConstraints)(unsafe.Pointer(in.NameConstraints))
+	out.OCSPMustStaple = in.OCSPMustStaple
 	return nil
 }
 
@@ -1236,6 +1374,8 @@ func autoConvert_v1alpha3_IssuerSpec_To_certmanager_IssuerSpec(in *IssuerSpec, o
 	if err := Convert_v1alpha3_IssuerConfig_To_certmanager_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.MinRSAKeySize = in.MinRSAKeySize
+	out.AllowedECDSACurves = *(*[]string)(unsafe.Pointer(&in.AllowedECDSACurves))
 	return nil
 }
 
@@ -1248,6 +1388,8 @@ func autoConvert_certmanager_IssuerSpec_To_v1alpha3_IssuerSpec(in *certmanager.I
 	if err := Convert_certmanager_IssuerConfig_To_v1alpha3_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.MinRSAKeySize = in.MinRSAKeySize
+	out.AllowedECDSACurves = *(*[]string)(unsafe.Pointer(&in.AllowedECDSACurves))
 	return nil
 }
 
@@ -1408,6 +1550,8 @@ func Convert_certmanager_PKCS12Keystore_To_v1alpha3_PKCS12Keystore(in *certmanag
 
 func autoConvert_v1alpha3_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *SelfSignedIssuer, out *certmanager.SelfSignedIssuer, s conversion.Scope) error {
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
+	out.NotBeforeBackdate = (*v1.Duration)(unsafe.Pointer(in.NotBeforeBackdate))
+	out.LegacyExtensions = in.LegacyExtensions
 	return nil
 }
 
@@ -1418,6 +1562,8 @@ func Convert_v1alpha3_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *SelfS
 
 func autoConvert_certmanager_SelfSignedIssuer_To_v1alpha3_SelfSignedIssuer(in *certmanager.SelfSignedIssuer, out *SelfSignedIssuer, s conversion.Scope) error {
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
+	out.NotBeforeBackdate = (*v1.Duration)(unsafe.Pointer(in.NotBeforeBackdate))
+	out.LegacyExtensions = in.LegacyExtensions
 	return nil
 }
 
@@ -1609,6 +1755,7 @@ func autoConvert_v1alpha3_VaultIssuer_To_certmanager_VaultIssuer(in *VaultIssuer
 	} else {
 		out.ClientKeySecretRef = nil
 	}
+	out.IncludeRootCA = in.IncludeRootCA
 	return nil
 }
 
@@ -1652,6 +1799,7 @@ func autoConvert_certmanager_VaultIssuer_To_v1alpha3_VaultIssuer(in *certmanager
 	} else {
 		out.ClientKeySecretRef = nil
 	}
+	out.IncludeRootCA = in.IncludeRootCA
 	return nil
 }
 
@@ -1685,6 +1833,44 @@ func autoConvert_certmanager_VaultKubernetesAuth_To_v1alpha3_VaultKubernetesAuth
 	return nil
 }
 
+func autoConvert_v1alpha3_VenafiChainVerification_To_certmanager_VenafiChainVerification(in *VenafiChainVerification, out *certmanager.VenafiChainVerification, s conversion.Scope) error {
+	if in.TrustAnchorsSecretRef != nil {
+		in, out := &in.TrustAnchorsSecretRef, &out.TrustAnchorsSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TrustAnchorsSecretRef = nil
+	}
+	out.SkipIfUnavailable = in.SkipIfUnavailable
+	return nil
+}
+
+// Convert_v1alpha3_VenafiChainVerification_To_certmanager_VenafiChainVerification is an autogenerated conversion function.
+func Convert_v1alpha3_VenafiChainVerification_To_certmanager_VenafiChainVerification(in *VenafiChainVerification, out *certmanager.VenafiChainVerification, s conversion.Scope) error {
+	return autoConvert_v1alpha3_VenafiChainVerification_To_certmanager_VenafiChainVerification(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiChainVerification_To_v1alpha3_VenafiChainVerification(in *certmanager.VenafiChainVerification, out *VenafiChainVerification, s conversion.Scope) error {
+	if in.TrustAnchorsSecretRef != nil {
+		in, out := &in.TrustAnchorsSecretRef, &out.TrustAnchorsSecretRef
+		*out = new(metav1.SecretKeySelector)
+		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TrustAnchorsSecretRef = nil
+	}
+	out.SkipIfUnavailable = in.SkipIfUnavailable
+	return nil
+}
+
+// Convert_certmanager_VenafiChainVerification_To_v1alpha3_VenafiChainVerification is an autogenerated conversion function.
+func Convert_certmanager_VenafiChainVerification_To_v1alpha3_VenafiChainVerification(in *certmanager.VenafiChainVerification, out *VenafiChainVerification, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiChainVerification_To_v1alpha3_VenafiChainVerification(in, out, s)
+}
+
 func autoConvert_v1alpha3_VenafiCloud_To_certmanager_VenafiCloud(in *VenafiCloud, out *certmanager.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
 	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
@@ -1711,8 +1897,34 @@ func Convert_certmanager_VenafiCloud_To_v1alpha3_VenafiCloud(in *certmanager.Ven
 	return autoConvert_certmanager_VenafiCloud_To_v1alpha3_VenafiCloud(in, out, s)
 }
 
+func autoConvert_v1alpha3_VenafiCredentialsReference_To_certmanager_VenafiCredentialsReference(in *VenafiCredentialsReference, out *certmanager.VenafiCredentialsReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Kind = in.Kind
+	out.Group = in.Group
+	return nil
+}
+
+// Convert_v1alpha3_VenafiCredentialsReference_To_certmanager_VenafiCredentialsReference is an autogenerated conversion function.
+func Convert_v1alpha3_VenafiCredentialsReference_To_certmanager_VenafiCredentialsReference(in *VenafiCredentialsReference, out *certmanager.VenafiCredentialsReference, s conversion.Scope) error {
+	return autoConvert_v1alpha3_VenafiCredentialsReference_To_certmanager_VenafiCredentialsReference(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiCredentialsReference_To_v1alpha3_VenafiCredentialsReference(in *certmanager.VenafiCredentialsReference, out *VenafiCredentialsReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Kind = in.Kind
+	out.Group = in.Group
+	return nil
+}
+
+// Convert_certmanager_VenafiCredentialsReference_To_v1alpha3_VenafiCredentialsReference is an autogenerated conversion function.
+func Convert_certmanager_VenafiCredentialsReference_To_v1alpha3_VenafiCredentialsReference(in *certmanager.VenafiCredentialsReference, out *VenafiCredentialsReference, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiCredentialsReference_To_v1alpha3_VenafiCredentialsReference(in, out, s)
+}
+
 func autoConvert_v1alpha3_VenafiIssuer_To_certmanager_VenafiIssuer(in *VenafiIssuer, out *certmanager.VenafiIssuer, s conversion.Scope) error {
 	out.Zone = in.Zone
+	out.AdditionalZones = *(*[]string)(unsafe.Pointer(&in.AdditionalZones))
+	out.AllowedZoneOverrides = *(*[]string)(unsafe.Pointer(&in.AllowedZoneOverrides))
 	if in.TPP != nil {
 		in, out := &in.TPP, &out.TPP
 		*out = new(certmanager.VenafiTPP)
@@ -1731,6 +1943,43 @@ func autoConvert_v1alpha3_VenafiIssuer_To_certmanager_VenafiIssuer(in *VenafiIss
 	} else {
 		out.Cloud = nil
 	}
+	out.RetryBackoff = (*certmanager.VenafiRetryBackoff)(unsafe.Pointer(in.RetryBackoff))
+	out.IssuanceDeadline = (*v1.Duration)(unsafe.Pointer(in.IssuanceDeadline))
+	out.IncludeRootCA = in.IncludeRootCA
+	out.MaxDuration = (*v1.Duration)(unsafe.Pointer(in.MaxDuration))
+	out.MinDuration = (*v1.Duration)(unsafe.Pointer(in.MinDuration))
+	out.DefaultDuration = (*v1.Duration)(unsafe.Pointer(in.DefaultDuration))
+	out.CredentialsRef = (*certmanager.VenafiCredentialsReference)(unsafe.Pointer(in.CredentialsRef))
+	out.FallbackCredentialsRefs = *(*[]certmanager.VenafiCredentialsReference)(unsafe.Pointer(&in.FallbackCredentialsRefs))
+	out.RevokeOnDelete = in.RevokeOnDelete
+	if in.ChainBundleSecretRef != nil {
+		in, out := &in.ChainBundleSecretRef, &out.ChainBundleSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ChainBundleSecretRef = nil
+	}
+	if in.ChainVerification != nil {
+		in, out := &in.ChainVerification, &out.ChainVerification
+		*out = new(certmanager.VenafiChainVerification)
+		if err := Convert_v1alpha3_VenafiChainVerification_To_certmanager_VenafiChainVerification(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ChainVerification = nil
+	}
+	out.AllowedDomains = *(*[]string)(unsafe.Pointer(&in.AllowedDomains))
+	out.AllowedExtensions = *(*[]string)(unsafe.Pointer(&in.AllowedExtensions))
+	out.LegacyExtensions = in.LegacyExtensions
+	out.ReuseExisting = in.ReuseExisting
+	out.ReuseMaxAge = (*v1.Duration)(unsafe.Pointer(in.ReuseMaxAge))
+	out.SubjectDefaults = (*certmanager.VenafiSubjectDefaults)(unsafe.Pointer(in.SubjectDefaults))
+	out.DefaultAnnotations = *(*map[string]string)(unsafe.Pointer(&in.DefaultAnnotations))
+	out.OriginCustomField = in.OriginCustomField
+	out.OriginTemplate = in.OriginTemplate
+	out.FailClosed = in.FailClosed
 	return nil
 }
 
@@ -1741,6 +1990,8 @@ func Convert_v1alpha3_VenafiIssuer_To_certmanager_VenafiIssuer(in *VenafiIssuer,
 
 func autoConvert_certmanager_VenafiIssuer_To_v1alpha3_VenafiIssuer(in *certmanager.VenafiIssuer, out *VenafiIssuer, s conversion.Scope) error {
 	out.Zone = in.Zone
+	out.AdditionalZones = *(*[]string)(unsafe.Pointer(&in.AdditionalZones))
+	out.AllowedZoneOverrides = *(*[]string)(unsafe.Pointer(&in.AllowedZoneOverrides))
 	if in.TPP != nil {
 		in, out := &in.TPP, &out.TPP
 		*out = new(VenafiTPP)
@@ -1759,6 +2010,43 @@ func autoConvert_certmanager_VenafiIssuer_To_v1alpha3_VenafiIssuer(in *certmanag
 	} else {
 		out.Cloud = nil
 	}
+	out.RetryBackoff = (*VenafiRetryBackoff)(unsafe.Pointer(in.RetryBackoff))
+	out.IssuanceDeadline = (*v1.Duration)(unsafe.Pointer(in.IssuanceDeadline))
+	out.IncludeRootCA = in.IncludeRootCA
+	out.MaxDuration = (*v1.Duration)(unsafe.Pointer(in.MaxDuration))
+	out.MinDuration = (*v1.Duration)(unsafe.Pointer(in.MinDuration))
+	out.DefaultDuration = (*v1.Duration)(unsafe.Pointer(in.DefaultDuration))
+	out.CredentialsRef = (*VenafiCredentialsReference)(unsafe.Pointer(in.CredentialsRef))
+	out.FallbackCredentialsRefs = *(*[]VenafiCredentialsReference)(unsafe.Pointer(&in.FallbackCredentialsRefs))
+	out.RevokeOnDelete = in.RevokeOnDelete
+	if in.ChainBundleSecretRef != nil {
+		in, out := &in.ChainBundleSecretRef, &out.ChainBundleSecretRef
+		*out = new(metav1.SecretKeySelector)
+		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ChainBundleSecretRef = nil
+	}
+	if in.ChainVerification != nil {
+		in, out := &in.ChainVerification, &out.ChainVerification
+		*out = new(VenafiChainVerification)
+		if err := Convert_certmanager_VenafiChainVerification_To_v1alpha3_VenafiChainVerification(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ChainVerification = nil
+	}
+	out.AllowedDomains = *(*[]string)(unsafe.Pointer(&in.AllowedDomains))
+	out.AllowedExtensions = *(*[]string)(unsafe.Pointer(&in.AllowedExtensions))
+	out.LegacyExtensions = in.LegacyExtensions
+	out.ReuseExisting = in.ReuseExisting
+	out.ReuseMaxAge = (*v1.Duration)(unsafe.Pointer(in.ReuseMaxAge))
+	out.SubjectDefaults = (*VenafiSubjectDefaults)(unsafe.Pointer(in.SubjectDefaults))
+	out.DefaultAnnotations = *(*map[string]string)(unsafe.Pointer(&in.DefaultAnnotations))
+	out.OriginCustomField = in.OriginCustomField
+	out.OriginTemplate = in.OriginTemplate
+	out.FailClosed = in.FailClosed
 	return nil
 }
 
@@ -1767,6 +2055,58 @@ func Convert_certmanager_VenafiIssuer_To_v1alpha3_VenafiIssuer(in *certmanager.V
 	return autoConvert_certmanager_VenafiIssuer_To_v1alpha3_VenafiIssuer(in, out, s)
 }
 
+func autoConvert_v1alpha3_VenafiRetryBackoff_To_certmanager_VenafiRetryBackoff(in *VenafiRetryBackoff, out *certmanager.VenafiRetryBackoff, s conversion.Scope) error {
+	out.InitialInterval = (*v1.Duration)(unsafe.Pointer(in.InitialInterval))
+	out.MaxInterval = (*v1.Duration)(unsafe.Pointer(in.MaxInterval))
+	out.Jitter = (*v1.Duration)(unsafe.Pointer(in.Jitter))
+	return nil
+}
+
+// Convert_v1alpha3_VenafiRetryBackoff_To_certmanager_VenafiRetryBackoff is an autogenerated conversion function.
+func Convert_v1alpha3_VenafiRetryBackoff_To_certmanager_VenafiRetryBackoff(in *VenafiRetryBackoff, out *certmanager.VenafiRetryBackoff, s conversion.Scope) error {
+	return autoConvert_v1alpha3_VenafiRetryBackoff_To_certmanager_VenafiRetryBackoff(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiRetryBackoff_To_v1alpha3_VenafiRetryBackoff(in *certmanager.VenafiRetryBackoff, out *VenafiRetryBackoff, s conversion.Scope) error {
+	out.InitialInterval = (*v1.Duration)(unsafe.Pointer(in.InitialInterval))
+	out.MaxInterval = (*v1.Duration)(unsafe.Pointer(in.MaxInterval))
+	out.Jitter = (*v1.Duration)(unsafe.Pointer(in.Jitter))
+	return nil
+}
+
+// Convert_certmanager_VenafiRetryBackoff_To_v1alpha3_VenafiRetryBackoff is an autogenerated conversion function.
+func Convert_certmanager_VenafiRetryBackoff_To_v1alpha3_VenafiRetryBackoff(in *certmanager.VenafiRetryBackoff, out *VenafiRetryBackoff, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiRetryBackoff_To_v1alpha3_VenafiRetryBackoff(in, out, s)
+}
+
+func autoConvert_v1alpha3_VenafiSubjectDefaults_To_certmanager_VenafiSubjectDefaults(in *VenafiSubjectDefaults, out *certmanager.VenafiSubjectDefaults, s conversion.Scope) error {
+	out.Organizations = *(*[]string)(unsafe.Pointer(&in.Organizations))
+	out.OrganizationalUnits = *(*[]string)(unsafe.Pointer(&in.OrganizationalUnits))
+	out.Localities = *(*[]string)(unsafe.Pointer(&in.Localities))
+	out.Provinces = *(*[]string)(unsafe.Pointer(&in.Provinces))
+	out.Countries = *(*[]string)(unsafe.Pointer(&in.Countries))
+	return nil
+}
+
+// Convert_v1alpha3_VenafiSubjectDefaults_To_certmanager_VenafiSubjectDefaults is an autogenerated conversion function.
+func Convert_v1alpha3_VenafiSubjectDefaults_To_certmanager_VenafiSubjectDefaults(in *VenafiSubjectDefaults, out *certmanager.VenafiSubjectDefaults, s conversion.Scope) error {
+	return autoConvert_v1alpha3_VenafiSubjectDefaults_To_certmanager_VenafiSubjectDefaults(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiSubjectDefaults_To_v1alpha3_VenafiSubjectDefaults(in *certmanager.VenafiSubjectDefaults, out *VenafiSubjectDefaults, s conversion.Scope) error {
+	out.Organizations = *(*[]string)(unsafe.Pointer(&in.Organizations))
+	out.OrganizationalUnits = *(*[]string)(unsafe.Pointer(&in.OrganizationalUnits))
+	out.Localities = *(*[]string)(unsafe.Pointer(&in.Localities))
+	out.Provinces = *(*[]string)(unsafe.Pointer(&in.Provinces))
+	out.Countries = *(*[]string)(unsafe.Pointer(&in.Countries))
+	return nil
+}
+
+// Convert_certmanager_VenafiSubjectDefaults_To_v1alpha3_VenafiSubjectDefaults is an autogenerated conversion function.
+func Convert_certmanager_VenafiSubjectDefaults_To_v1alpha3_VenafiSubjectDefaults(in *certmanager.VenafiSubjectDefaults, out *VenafiSubjectDefaults, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiSubjectDefaults_To_v1alpha3_VenafiSubjectDefaults(in, out, s)
+}
+
 func autoConvert_v1alpha3_VenafiTPP_To_certmanager_VenafiTPP(in *VenafiTPP, out *certmanager.VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
 	if err := apismetav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
@@ -1782,6 +2122,16 @@ func autoConvert_v1alpha3_VenafiTPP_To_certmanager_VenafiTPP(in *VenafiTPP, out
 	} else {
 		out.CABundleSecretRef = nil
 	}
+	if in.ClientCertificateSecretRef != nil {
+		in, out := &in.ClientCertificateSecretRef, &out.ClientCertificateSecretRef
+		*out = new(meta.LocalObjectReference)
+		if err := apismetav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ClientCertificateSecretRef = nil
+	}
+	out.ServiceGeneratedKeys = in.ServiceGeneratedKeys
 	return nil
 }
 
@@ -1805,6 +2155,16 @@ func autoConvert_certmanager_VenafiTPP_To_v1alpha3_VenafiTPP(in *certmanager.Ven
 	} else {
 		out.CABundleSecretRef = nil
 	}
+	if in.ClientCertificateSecretRef != nil {
+		in, out := &in.ClientCertificateSecretRef, &out.ClientCertificateSecretRef
+		*out = new(metav1.LocalObjectReference)
+		if err := apismetav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ClientCertificateSecretRef = nil
+	}
+	out.ServiceGeneratedKeys = in.ServiceGeneratedKeys
 	return nil
 }
 
@@ -195,6 +195,10 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 		}
 	}
 
+	if crt.CABundle != nil {
+		el = append(el, validateCABundle(crt.CABundle, fldPath.Child("caBundle"))...)
+	}
+
 	el = append(el, validateAdditionalOutputFormats(crt, fldPath)...)
 
 	return el
@@ -376,3 +380,30 @@ func validateAdditionalOutputFormats(crt *internalcmapi.CertificateSpec, fldPath
 
 	return el
 }
+
+func validateCABundle(bundle *internalcmapi.CertificateCABundle, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	switch {
+	case bundle.SecretRef != nil && bundle.ConfigMapRef != nil:
+		el = append(el, field.Forbidden(fldPath, "only one of secretRef or configMapRef may be set"))
+	case bundle.SecretRef != nil:
+		if len(bundle.SecretRef.Name) == 0 {
+			el = append(el, field.Required(fldPath.Child("secretRef", "name"), "secret name is required"))
+		}
+	case bundle.ConfigMapRef != nil:
+		if len(bundle.ConfigMapRef.Name) == 0 {
+			el = append(el, field.Required(fldPath.Child("configMapRef", "name"), "configmap name is required"))
+		}
+	default:
+		el = append(el, field.Required(fldPath, "one of secretRef or configMapRef must be set"))
+	}
+
+	switch bundle.Mode {
+	case "", internalcmapi.ReplaceCABundleMode, internalcmapi.AppendCABundleMode:
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("mode"), bundle.Mode, []string{string(internalcmapi.ReplaceCABundleMode), string(internalcmapi.AppendCABundleMode)}))
+	}
+
+	return el
+}
@@ -1189,3 +1189,64 @@ func Test_validateLiteralSubject(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateCABundle(t *testing.T) {
+	fldPath := field.NewPath("spec", "caBundle")
+	ref := cmmeta.LocalObjectReference{Name: "bundle"}
+
+	tests := map[string]struct {
+		bundle *internalcmapi.CertificateCABundle
+		expErr field.ErrorList
+	}{
+		"a Secret reference is valid": {
+			bundle: &internalcmapi.CertificateCABundle{
+				SecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: ref},
+			},
+		},
+		"a ConfigMap reference with the Append mode is valid": {
+			bundle: &internalcmapi.CertificateCABundle{
+				ConfigMapRef: &cmmeta.ConfigMapKeySelector{LocalObjectReference: ref, Key: "roots.pem"},
+				Mode:         internalcmapi.AppendCABundleMode,
+			},
+		},
+		"a reference is required": {
+			bundle: &internalcmapi.CertificateCABundle{},
+			expErr: field.ErrorList{
+				field.Required(fldPath, "one of secretRef or configMapRef must be set"),
+			},
+		},
+		"both references cannot be set": {
+			bundle: &internalcmapi.CertificateCABundle{
+				SecretRef:    &cmmeta.SecretKeySelector{LocalObjectReference: ref},
+				ConfigMapRef: &cmmeta.ConfigMapKeySelector{LocalObjectReference: ref},
+			},
+			expErr: field.ErrorList{
+				field.Forbidden(fldPath, "only one of secretRef or configMapRef may be set"),
+			},
+		},
+		"the name of the referenced object is required": {
+			bundle: &internalcmapi.CertificateCABundle{
+				ConfigMapRef: &cmmeta.ConfigMapKeySelector{},
+			},
+			expErr: field.ErrorList{
+				field.Required(fldPath.Child("configMapRef", "name"), "configmap name is required"),
+			},
+		},
+		"unknown modes are not supported": {
+			bundle: &internalcmapi.CertificateCABundle{
+				SecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: ref},
+				Mode:      "Prepend",
+			},
+			expErr: field.ErrorList{
+				field.NotSupported(fldPath.Child("mode"), internalcmapi.CABundleMode("Prepend"), []string{"Replace", "Append"}),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotErr := validateCABundle(test.bundle, fldPath)
+			assert.Equal(t, test.expErr, gotErr)
+		})
+	}
+}
@@ -85,6 +85,10 @@ func ValidateCertificateRequestSpec(crSpec *cmapi.CertificateRequestSpec, fldPat
 
 	el = append(el, validateCertificateRequestSpecRequest(crSpec, fldPath)...)
 
+	if crSpec.Duration != nil && crSpec.NotAfter != nil {
+		el = append(el, field.Forbidden(fldPath.Child("notAfter"), "may not be set together with duration"))
+	}
+
 	return el
 }
 
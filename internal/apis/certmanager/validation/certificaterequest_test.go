@@ -22,6 +22,7 @@ import (
 	"encoding/asn1"
 	"reflect"
 	"testing"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -654,6 +655,31 @@ func TestValidateCertificateRequest(t *testing.T) {
 			a:     someAdmissionRequest,
 			wantE: []*field.Error{},
 		},
+		"Test cr with notAfter set": {
+			cr: &cminternal.CertificateRequest{
+				Spec: cminternal.CertificateRequestSpec{
+					Request:   mustGenerateCSR(t, gen.Certificate("test", gen.SetCertificateDNSNames("example.com"))),
+					IssuerRef: validIssuerRef,
+					NotAfter:  &metav1.Time{Time: time.Now().Add(time.Hour)},
+				},
+			},
+			a:     someAdmissionRequest,
+			wantE: []*field.Error{},
+		},
+		"Error on cr with both duration and notAfter set": {
+			cr: &cminternal.CertificateRequest{
+				Spec: cminternal.CertificateRequestSpec{
+					Request:   mustGenerateCSR(t, gen.Certificate("test", gen.SetCertificateDNSNames("example.com"))),
+					IssuerRef: validIssuerRef,
+					Duration:  &metav1.Duration{Duration: time.Hour},
+					NotAfter:  &metav1.Time{Time: time.Now().Add(time.Hour)},
+				},
+			},
+			a: someAdmissionRequest,
+			wantE: []*field.Error{
+				field.Forbidden(fldPath.Child("notAfter"), "may not be set together with duration"),
+			},
+		},
 		"CertificateRequest with single Approved=true condition, shouldn't error": {
 			cr: &cminternal.CertificateRequest{
 				Spec: cminternal.CertificateRequestSpec{
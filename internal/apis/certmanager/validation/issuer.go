@@ -19,7 +19,12 @@ package validation
 import (
 	"crypto/x509"
 	"fmt"
+	"net"
+	"path"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -31,6 +36,9 @@ import (
 	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
 	"github.com/cert-manager/cert-manager/internal/apis/certmanager/validation/util"
 	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 // Validation functions for cert-manager Issuer types.
@@ -49,7 +57,44 @@ func ValidateUpdateIssuer(a *admissionv1.AdmissionRequest, oldObj, obj runtime.O
 }
 
 func ValidateIssuerSpec(iss *certmanager.IssuerSpec, fldPath *field.Path) (field.ErrorList, []string) {
-	return ValidateIssuerConfig(&iss.IssuerConfig, fldPath)
+	el, warnings := ValidateIssuerConfig(&iss.IssuerConfig, fldPath)
+	el = append(el, validateIssuerKeyPolicy(iss, fldPath)...)
+	return el, warnings
+}
+
+// ecdsaCurveNames are the names of the elliptic curves which may be allowed
+// by the key policy of an issuer.
+var ecdsaCurveNames = []string{"P-256", "P-384", "P-521"}
+
+func validateIssuerKeyPolicy(iss *certmanager.IssuerSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	if iss.MinRSAKeySize < 0 || iss.MinRSAKeySize > pki.MaxRSAKeySize {
+		el = append(el, field.Invalid(fldPath.Child("minRSAKeySize"), iss.MinRSAKeySize, fmt.Sprintf("must be between 0 and %d", pki.MaxRSAKeySize)))
+	}
+
+	curves := map[string]bool{}
+	for i, curve := range iss.AllowedECDSACurves {
+		switch {
+		case !slices.Contains(ecdsaCurveNames, curve):
+			el = append(el, field.NotSupported(fldPath.Child("allowedECDSACurves").Index(i), curve, ecdsaCurveNames))
+		case curves[curve]:
+			el = append(el, field.Duplicate(fldPath.Child("allowedECDSACurves").Index(i), curve))
+		}
+		curves[curve] = true
+	}
+
+	// The keys of ACME requests are checked by the ACME server.
+	if iss.ACME != nil {
+		if iss.MinRSAKeySize != 0 {
+			el = append(el, field.Forbidden(fldPath.Child("minRSAKeySize"), "not supported by ACME issuers"))
+		}
+		if len(iss.AllowedECDSACurves) > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("allowedECDSACurves"), "not supported by ACME issuers"))
+		}
+	}
+
+	return el
 }
 
 func ValidateIssuerConfig(iss *certmanager.IssuerConfig, fldPath *field.Path) (field.ErrorList, []string) {
@@ -250,11 +295,29 @@ func ValidateCAIssuerConfig(iss *certmanager.CAIssuer, fldPath *field.Path) fiel
 			el = append(el, field.Invalid(fldPath.Child("issuingCertificateURLs").Index(i), issuerURL, "must be a valid URL"))
 		}
 	}
+	if iss.MaxPathLen != nil && *iss.MaxPathLen < 0 {
+		el = append(el, field.Invalid(fldPath.Child("maxPathLen"), *iss.MaxPathLen, "must not be negative"))
+	}
 	return el
 }
 
+// maxSelfSignedNotBeforeBackdate is the largest notBefore backdate accepted on
+// a SelfSigned issuer. It is only meant to cover clock skew between machines.
+const maxSelfSignedNotBeforeBackdate = time.Hour
+
 func ValidateSelfSignedIssuerConfig(iss *certmanager.SelfSignedIssuer, fldPath *field.Path) field.ErrorList {
-	return nil
+	el := field.ErrorList{}
+
+	if iss.NotBeforeBackdate != nil {
+		backdate := iss.NotBeforeBackdate.Duration
+		if backdate < 0 {
+			el = append(el, field.Invalid(fldPath.Child("notBeforeBackdate"), backdate, "must not be negative"))
+		} else if backdate > maxSelfSignedNotBeforeBackdate {
+			el = append(el, field.Invalid(fldPath.Child("notBeforeBackdate"), backdate, fmt.Sprintf("must not be greater than %s", maxSelfSignedNotBeforeBackdate)))
+		}
+	}
+
+	return el
 }
 
 func ValidateVaultIssuerConfig(iss *certmanager.VaultIssuer, fldPath *field.Path) field.ErrorList {
@@ -366,6 +429,10 @@ func ValidateVenafiTPP(tpp *certmanager.VenafiTPP, fldPath *field.Path) (el fiel
 	// Validate only one of CABundle/CABundleSecretRef is passed
 	el = append(el, validateVenafiTPPCABundleUnique(tpp, fldPath)...)
 
+	if tpp.ClientCertificateSecretRef != nil && tpp.ClientCertificateSecretRef.Name == "" {
+		el = append(el, field.Required(fldPath.Child("clientCertificateSecretRef", "name"), ""))
+	}
+
 	return el
 }
 
@@ -393,6 +460,26 @@ func ValidateVenafiIssuerConfig(iss *certmanager.VenafiIssuer, fldPath *field.Pa
 	if iss.Zone == "" {
 		el = append(el, field.Required(fldPath.Child("zone"), ""))
 	}
+	zones := map[string]bool{iss.Zone: true}
+	for i, zone := range iss.AdditionalZones {
+		switch {
+		case zone == "":
+			el = append(el, field.Required(fldPath.Child("additionalZones").Index(i), ""))
+		case zones[zone]:
+			el = append(el, field.Duplicate(fldPath.Child("additionalZones").Index(i), zone))
+		}
+		zones[zone] = true
+	}
+	allowedZoneOverrides := map[string]bool{}
+	for i, zone := range iss.AllowedZoneOverrides {
+		switch {
+		case zone == "":
+			el = append(el, field.Required(fldPath.Child("allowedZoneOverrides").Index(i), ""))
+		case allowedZoneOverrides[zone]:
+			el = append(el, field.Duplicate(fldPath.Child("allowedZoneOverrides").Index(i), zone))
+		}
+		allowedZoneOverrides[zone] = true
+	}
 	unionCount := 0
 	if iss.TPP != nil {
 		unionCount++
@@ -410,6 +497,239 @@ func ValidateVenafiIssuerConfig(iss *certmanager.VenafiIssuer, fldPath *field.Pa
 		el = append(el, field.Forbidden(fldPath, "please supply one of: tpp, cloud"))
 	}
 
+	if iss.RetryBackoff != nil {
+		el = append(el, validateVenafiRetryBackoff(iss.RetryBackoff, fldPath.Child("retryBackoff"))...)
+	}
+
+	if iss.IssuanceDeadline != nil && iss.IssuanceDeadline.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("issuanceDeadline"), iss.IssuanceDeadline.Duration, "must be greater than zero"))
+	}
+
+	if iss.CredentialsRef != nil && iss.CredentialsRef.Name == "" {
+		el = append(el, field.Required(fldPath.Child("credentialsRef", "name"), ""))
+	}
+	// The credentials are read from the TPP or Cloud configuration unless
+	// they are referenced by the issuer.
+	if iss.CredentialsRef == nil {
+		if iss.TPP != nil && iss.TPP.CredentialsRef.Name == "" {
+			el = append(el, field.Required(fldPath.Child("tpp", "credentialsRef", "name"), "required unless credentialsRef is set"))
+		}
+		if iss.Cloud != nil && iss.Cloud.APITokenSecretRef.Name == "" {
+			el = append(el, field.Required(fldPath.Child("cloud", "apiTokenSecretRef", "name"), "required unless credentialsRef is set"))
+		}
+	}
+	fallbackRefs := map[certmanager.VenafiCredentialsReference]bool{}
+	for i, ref := range iss.FallbackCredentialsRefs {
+		switch {
+		case ref.Name == "":
+			el = append(el, field.Required(fldPath.Child("fallbackCredentialsRefs").Index(i).Child("name"), ""))
+		case fallbackRefs[ref]:
+			el = append(el, field.Duplicate(fldPath.Child("fallbackCredentialsRefs").Index(i), ref))
+		}
+		fallbackRefs[ref] = true
+	}
+
+	if iss.MaxDuration != nil && iss.MaxDuration.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("maxDuration"), iss.MaxDuration.Duration, "must be greater than zero"))
+	}
+
+	if iss.MinDuration != nil {
+		switch duration := iss.MinDuration.Duration; {
+		case duration <= 0:
+			el = append(el, field.Invalid(fldPath.Child("minDuration"), duration, "must be greater than zero"))
+		case iss.MaxDuration != nil && duration > iss.MaxDuration.Duration:
+			el = append(el, field.Invalid(fldPath.Child("minDuration"), duration, fmt.Sprintf("must not be greater than maxDuration %s", iss.MaxDuration.Duration)))
+		}
+	}
+
+	if iss.DefaultDuration != nil {
+		switch duration := iss.DefaultDuration.Duration; {
+		case duration < cmapi.MinimumCertificateDuration:
+			el = append(el, field.Invalid(fldPath.Child("defaultDuration"), duration, fmt.Sprintf("must be at least %s", cmapi.MinimumCertificateDuration)))
+		case iss.MaxDuration != nil && duration > iss.MaxDuration.Duration:
+			el = append(el, field.Invalid(fldPath.Child("defaultDuration"), duration, fmt.Sprintf("must not be greater than maxDuration %s", iss.MaxDuration.Duration)))
+		}
+	}
+
+	if iss.RevokeOnDelete && iss.Cloud != nil {
+		el = append(el, field.Forbidden(fldPath.Child("revokeOnDelete"), "revocation is not supported by Venafi Cloud"))
+	}
+
+	if iss.ChainBundleSecretRef != nil && iss.ChainBundleSecretRef.Name == "" {
+		el = append(el, field.Required(fldPath.Child("chainBundleSecretRef", "name"), "secret name is required"))
+	}
+
+	if cv := iss.ChainVerification; cv != nil && cv.TrustAnchorsSecretRef != nil && cv.TrustAnchorsSecretRef.Name == "" {
+		el = append(el, field.Required(fldPath.Child("chainVerification", "trustAnchorsSecretRef", "name"), "secret name is required"))
+	}
+
+	el = append(el, validateVenafiAllowedDomains(iss.AllowedDomains, fldPath.Child("allowedDomains"))...)
+
+	extensions := map[string]bool{}
+	for i, oid := range iss.AllowedExtensions {
+		switch _, err := pki.ParseObjectIdentifier(oid); {
+		case err != nil:
+			el = append(el, field.Invalid(fldPath.Child("allowedExtensions").Index(i), oid, "oid syntax invalid"))
+		case extensions[oid]:
+			el = append(el, field.Duplicate(fldPath.Child("allowedExtensions").Index(i), oid))
+		}
+		extensions[oid] = true
+	}
+
+	if iss.LegacyExtensions && len(iss.AllowedExtensions) > 0 && !extensions[pki.OIDExtensionNetscapeCertType.String()] {
+		el = append(el, field.Invalid(fldPath.Child("allowedExtensions"), iss.AllowedExtensions,
+			fmt.Sprintf("must include the Netscape certificate type extension %q if legacyExtensions is set", pki.OIDExtensionNetscapeCertType.String())))
+	}
+
+	if iss.ReuseExisting && iss.Cloud != nil {
+		el = append(el, field.Forbidden(fldPath.Child("reuseExisting"), "reuse of existing certificates is not supported by Venafi Cloud"))
+	}
+
+	if iss.ReuseExisting && iss.TPP != nil && iss.TPP.ServiceGeneratedKeys {
+		el = append(el, field.Forbidden(fldPath.Child("reuseExisting"), "reuse of existing certificates is not supported with service generated keys"))
+	}
+
+	if iss.ReuseMaxAge != nil && iss.ReuseMaxAge.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("reuseMaxAge"), iss.ReuseMaxAge.Duration, "must be greater than zero"))
+	}
+
+	if iss.SubjectDefaults != nil {
+		el = append(el, validateVenafiSubjectDefaults(iss.SubjectDefaults, fldPath.Child("subjectDefaults"))...)
+	}
+
+	el = append(el, validateVenafiDefaultAnnotations(iss.DefaultAnnotations, fldPath.Child("defaultAnnotations"))...)
+
+	if iss.OriginCustomField != "" && strings.TrimSpace(iss.OriginCustomField) == "" {
+		el = append(el, field.Invalid(fldPath.Child("originCustomField"), iss.OriginCustomField, "must not be blank"))
+	}
+	if iss.OriginTemplate != "" {
+		if iss.OriginCustomField == "" {
+			el = append(el, field.Required(fldPath.Child("originCustomField"), "must be set when originTemplate is set"))
+		}
+		if err := api.ValidateOriginTemplate(iss.OriginTemplate); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("originTemplate"), iss.OriginTemplate, err.Error()))
+		}
+	}
+
+	return el
+}
+
+// venafiDefaultableAnnotations are the annotations of CertificateRequests
+// which may be defaulted by the DefaultAnnotations of a Venafi issuer.
+var venafiDefaultableAnnotations = []string{
+	cmapi.CertificateRequestSignatureHashAnnotationKey,
+	cmapi.VenafiCustomFieldsAnnotationKey,
+	cmapi.VenafiFriendlyNameAnnotationKey,
+	cmapi.VenafiInstanceAnnotationKey,
+	cmapi.VenafiWorkloadAnnotationKey,
+}
+
+func validateVenafiDefaultAnnotations(annotations map[string]string, fldPath *field.Path) (el field.ErrorList) {
+	keys := make([]string, 0, len(annotations))
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !slices.Contains(venafiDefaultableAnnotations, key) {
+			el = append(el, field.NotSupported(fldPath.Key(key), key, venafiDefaultableAnnotations))
+			continue
+		}
+
+		switch key {
+		case cmapi.VenafiCustomFieldsAnnotationKey:
+			if _, err := api.ParseCustomFields([]byte(annotations[key])); err != nil {
+				el = append(el, field.Invalid(fldPath.Key(key), annotations[key], fmt.Sprintf("failed to parse custom fields: %v", err)))
+			}
+		case cmapi.CertificateRequestSignatureHashAnnotationKey:
+			if _, err := pki.ParseSignatureHash(annotations[key]); err != nil {
+				el = append(el, field.Invalid(fldPath.Key(key), annotations[key], err.Error()))
+			}
+		}
+	}
+
+	return el
+}
+
+func validateVenafiAllowedDomains(patterns []string, fldPath *field.Path) (el field.ErrorList) {
+	seen := map[string]bool{}
+	for i, pattern := range patterns {
+		if err := validateVenafiDomainPattern(pattern); err != nil {
+			el = append(el, field.Invalid(fldPath.Index(i), pattern, err.Error()))
+		} else if seen[strings.ToLower(pattern)] {
+			el = append(el, field.Duplicate(fldPath.Index(i), pattern))
+		}
+		seen[strings.ToLower(pattern)] = true
+	}
+
+	return el
+}
+
+// validateVenafiDomainPattern checks that the pattern is made of non-empty
+// labels which are valid path.Match patterns, and that `**` is only used as
+// the first label of a pattern which has further labels.
+func validateVenafiDomainPattern(pattern string) error {
+	labels := strings.Split(strings.TrimSuffix(pattern, "."), ".")
+	for i, label := range labels {
+		switch {
+		case label == "":
+			return fmt.Errorf("must not contain empty labels")
+		case strings.Contains(label, "**") && (i > 0 || label != "**" || len(labels) == 1):
+			return fmt.Errorf("`**` may only be used as the first label of a pattern")
+		}
+		if _, err := path.Match(label, ""); err != nil {
+			return fmt.Errorf("invalid pattern: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func validateVenafiSubjectDefaults(defaults *certmanager.VenafiSubjectDefaults, fldPath *field.Path) (el field.ErrorList) {
+	fields := []struct {
+		name   string
+		values []string
+	}{
+		{"organizations", defaults.Organizations},
+		{"organizationalUnits", defaults.OrganizationalUnits},
+		{"localities", defaults.Localities},
+		{"provinces", defaults.Provinces},
+		{"countries", defaults.Countries},
+	}
+	for _, f := range fields {
+		for i, value := range f.values {
+			if strings.TrimSpace(value) == "" {
+				el = append(el, field.Required(fldPath.Child(f.name).Index(i), "must not be empty"))
+			}
+		}
+	}
+
+	// X.509 country names are two-letter ISO 3166 codes.
+	for i, country := range defaults.Countries {
+		if strings.TrimSpace(country) != "" && len(country) != 2 {
+			el = append(el, field.Invalid(fldPath.Child("countries").Index(i), country, "must be a two-letter country code"))
+		}
+	}
+
+	return el
+}
+
+func validateVenafiRetryBackoff(backoff *certmanager.VenafiRetryBackoff, fldPath *field.Path) (el field.ErrorList) {
+	if backoff.InitialInterval != nil && backoff.InitialInterval.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("initialInterval"), backoff.InitialInterval.Duration, "must be greater than zero"))
+	}
+	if backoff.MaxInterval != nil && backoff.MaxInterval.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("maxInterval"), backoff.MaxInterval.Duration, "must be greater than zero"))
+	}
+	if backoff.Jitter != nil && backoff.Jitter.Duration < 0 {
+		el = append(el, field.Invalid(fldPath.Child("jitter"), backoff.Jitter.Duration, "must not be negative"))
+	}
+	if backoff.InitialInterval != nil && backoff.MaxInterval != nil &&
+		backoff.InitialInterval.Duration > backoff.MaxInterval.Duration {
+		el = append(el, field.Invalid(fldPath.Child("initialInterval"), backoff.InitialInterval.Duration, "must not be greater than maxInterval"))
+	}
+
 	return el
 }
 
@@ -434,6 +754,16 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			el = append(el, field.Invalid(fldPath.Child("cnameStrategy"), p.CNAMEStrategy, fmt.Sprintf("must be one of %q or %q", cmacme.NoneStrategy, cmacme.FollowStrategy)))
 		}
 	}
+	if len(p.CNAMENameservers) > 0 {
+		if p.CNAMEStrategy != cmacme.FollowStrategy {
+			el = append(el, field.Forbidden(fldPath.Child("cnameNameservers"), fmt.Sprintf("may only be set when cnameStrategy is %q", cmacme.FollowStrategy)))
+		}
+		for i, ns := range p.CNAMENameservers {
+			if _, _, err := net.SplitHostPort(ns); err != nil {
+				el = append(el, field.Invalid(fldPath.Child("cnameNameservers").Index(i), ns, "must be in host:port form"))
+			}
+		}
+	}
 	numProviders := 0
 	if p.Akamai != nil {
 		numProviders++
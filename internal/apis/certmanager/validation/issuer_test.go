@@ -19,10 +19,12 @@ package validation
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
@@ -817,6 +819,71 @@ func TestValidateIssuerSpec(t *testing.T) {
 				field.Invalid(fldPath.Child("ca", "issuingCertificateURLs").Index(0), "", `must be a valid URL`),
 			},
 		},
+		"valid MaxPathLen": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						MaxPathLen: ptr.To[int32](0),
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"negative MaxPathLen": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						MaxPathLen: ptr.To[int32](-1),
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ca", "maxPathLen"), int32(-1), "must not be negative"),
+			},
+		},
+		"valid key policy": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+					},
+				},
+				MinRSAKeySize:      3072,
+				AllowedECDSACurves: []string{"P-384", "P-521"},
+			},
+			errs: []*field.Error{},
+		},
+		"invalid key policy": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+					},
+				},
+				MinRSAKeySize:      16384,
+				AllowedECDSACurves: []string{"P-384", "secp256k1", "P-384"},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("minRSAKeySize"), 16384, "must be between 0 and 8192"),
+				field.NotSupported(fldPath.Child("allowedECDSACurves").Index(1), "secp256k1", []string{"P-256", "P-384", "P-521"}),
+				field.Duplicate(fldPath.Child("allowedECDSACurves").Index(2), "P-384"),
+			},
+		},
+		"key policy on an acme issuer": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					ACME: &validACMEIssuer,
+				},
+				MinRSAKeySize:      2048,
+				AllowedECDSACurves: []string{"P-256"},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("minRSAKeySize"), "not supported by ACME issuers"),
+				field.Forbidden(fldPath.Child("allowedECDSACurves"), "not supported by ACME issuers"),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -1503,6 +1570,39 @@ func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 				field.Forbidden(fldPath.Child("cloudflare"), "may not specify more than one provider type"),
 			},
 		},
+		"cname nameservers with follow strategy": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				CNAMEStrategy:    cmacme.FollowStrategy,
+				CNAMENameservers: []string{"1.1.1.1:53", "[2001:db8::1]:53"},
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver: "127.0.0.1",
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"cname nameservers without follow strategy": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				CNAMENameservers: []string{"1.1.1.1:53"},
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver: "127.0.0.1",
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("cnameNameservers"), `may only be set when cnameStrategy is "Follow"`),
+			},
+		},
+		"cname nameserver without port": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				CNAMEStrategy:    cmacme.FollowStrategy,
+				CNAMENameservers: []string{"1.1.1.1"},
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver: "127.0.0.1",
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("cnameNameservers").Index(0), "1.1.1.1", "must be in host:port form"),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -1589,6 +1689,9 @@ func TestValidateSecretKeySelector(t *testing.T) {
 
 func TestValidateVenafiIssuerConfig(t *testing.T) {
 	fldPath := field.NewPath("test")
+	cloud := &cmapi.VenafiCloud{
+		APITokenSecretRef: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "secret"}},
+	}
 	scenarios := map[string]struct {
 		cfg  *cmapi.VenafiIssuer
 		errs []*field.Error
@@ -1597,7 +1700,8 @@ func TestValidateVenafiIssuerConfig(t *testing.T) {
 			cfg: &cmapi.VenafiIssuer{
 				Zone: "a\\b\\c",
 				TPP: &cmapi.VenafiTPP{
-					URL: "https://tpp.example.com/vedsdk",
+					URL:            "https://tpp.example.com/vedsdk",
+					CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"},
 				},
 			},
 		},
@@ -1605,13 +1709,41 @@ func TestValidateVenafiIssuerConfig(t *testing.T) {
 			cfg: &cmapi.VenafiIssuer{
 				Zone: "",
 				TPP: &cmapi.VenafiTPP{
-					URL: "https://tpp.example.com/vedsdk",
+					URL:            "https://tpp.example.com/vedsdk",
+					CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"},
 				},
 			},
 			errs: []*field.Error{
 				field.Required(fldPath.Child("zone"), ""),
 			},
 		},
+		"missing TPP credentials": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone: "a\\b\\c",
+				TPP: &cmapi.VenafiTPP{
+					URL: "https://tpp.example.com/vedsdk",
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("tpp", "credentialsRef", "name"), "required unless credentialsRef is set"),
+			},
+		},
+		"missing Cloud credentials": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:  "a\\b\\c",
+				Cloud: &cmapi.VenafiCloud{},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("cloud", "apiTokenSecretRef", "name"), "required unless credentialsRef is set"),
+			},
+		},
+		"credentials referenced by the issuer": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:           "a\\b\\c",
+				Cloud:          &cmapi.VenafiCloud{},
+				CredentialsRef: &cmapi.VenafiCredentialsReference{Name: "secret"},
+			},
+		},
 		"missing configuration": {
 			cfg: &cmapi.VenafiIssuer{
 				Zone: "a\\b\\c",
@@ -1624,14 +1756,504 @@ func TestValidateVenafiIssuerConfig(t *testing.T) {
 			cfg: &cmapi.VenafiIssuer{
 				Zone: "a\\b\\c",
 				TPP: &cmapi.VenafiTPP{
-					URL: "https://tpp.example.com/vedsdk",
+					URL:            "https://tpp.example.com/vedsdk",
+					CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"},
 				},
-				Cloud: &cmapi.VenafiCloud{},
+				Cloud: cloud,
 			},
 			errs: []*field.Error{
 				field.Forbidden(fldPath, "please supply one of: tpp, cloud"),
 			},
 		},
+		"valid retry backoff": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone: "a\\b\\c",
+				TPP: &cmapi.VenafiTPP{
+					URL:            "https://tpp.example.com/vedsdk",
+					CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"},
+				},
+				RetryBackoff: &cmapi.VenafiRetryBackoff{
+					InitialInterval: &metav1.Duration{Duration: time.Second * 10},
+					MaxInterval:     &metav1.Duration{Duration: time.Minute * 10},
+					Jitter:          &metav1.Duration{Duration: time.Second * 30},
+				},
+			},
+		},
+		"retry backoff with non-positive intervals": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone: "a\\b\\c",
+				TPP: &cmapi.VenafiTPP{
+					URL:            "https://tpp.example.com/vedsdk",
+					CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"},
+				},
+				RetryBackoff: &cmapi.VenafiRetryBackoff{
+					InitialInterval: &metav1.Duration{Duration: 0},
+					MaxInterval:     &metav1.Duration{Duration: -time.Second},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("retryBackoff", "initialInterval"), time.Duration(0), "must be greater than zero"),
+				field.Invalid(fldPath.Child("retryBackoff", "maxInterval"), -time.Second, "must be greater than zero"),
+				field.Invalid(fldPath.Child("retryBackoff", "initialInterval"), time.Duration(0), "must not be greater than maxInterval"),
+			},
+		},
+		"retry backoff with negative jitter": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone: "a\\b\\c",
+				TPP: &cmapi.VenafiTPP{
+					URL:            "https://tpp.example.com/vedsdk",
+					CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"},
+				},
+				RetryBackoff: &cmapi.VenafiRetryBackoff{
+					Jitter: &metav1.Duration{Duration: -time.Second},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("retryBackoff", "jitter"), -time.Second, "must not be negative"),
+			},
+		},
+		"retry backoff with initial interval greater than max interval": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone: "a\\b\\c",
+				TPP: &cmapi.VenafiTPP{
+					URL:            "https://tpp.example.com/vedsdk",
+					CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"},
+				},
+				RetryBackoff: &cmapi.VenafiRetryBackoff{
+					InitialInterval: &metav1.Duration{Duration: time.Minute * 10},
+					MaxInterval:     &metav1.Duration{Duration: time.Minute},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("retryBackoff", "initialInterval"), time.Minute*10, "must not be greater than maxInterval"),
+			},
+		},
+		"valid max duration": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone: "a\\b\\c",
+				TPP: &cmapi.VenafiTPP{
+					URL:            "https://tpp.example.com/vedsdk",
+					CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"},
+				},
+				MaxDuration: &metav1.Duration{Duration: time.Hour * 24 * 365},
+			},
+		},
+		"max duration which is not positive": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone: "a\\b\\c",
+				TPP: &cmapi.VenafiTPP{
+					URL:            "https://tpp.example.com/vedsdk",
+					CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"},
+				},
+				MaxDuration: &metav1.Duration{},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("maxDuration"), time.Duration(0), "must be greater than zero"),
+			},
+		},
+		"valid min duration": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone: "a\\b\\c",
+				TPP: &cmapi.VenafiTPP{
+					URL:            "https://tpp.example.com/vedsdk",
+					CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"},
+				},
+				MinDuration: &metav1.Duration{Duration: time.Hour * 24},
+				MaxDuration: &metav1.Duration{Duration: time.Hour * 24 * 365},
+			},
+		},
+		"min duration which is not positive": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone: "a\\b\\c",
+				TPP: &cmapi.VenafiTPP{
+					URL:            "https://tpp.example.com/vedsdk",
+					CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"},
+				},
+				MinDuration: &metav1.Duration{},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("minDuration"), time.Duration(0), "must be greater than zero"),
+			},
+		},
+		"min duration which is greater than the max duration": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone: "a\\b\\c",
+				TPP: &cmapi.VenafiTPP{
+					URL:            "https://tpp.example.com/vedsdk",
+					CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"},
+				},
+				MinDuration: &metav1.Duration{Duration: time.Hour * 24 * 90},
+				MaxDuration: &metav1.Duration{Duration: time.Hour * 24 * 30},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("minDuration"), time.Hour*24*90, "must not be greater than maxDuration 720h0m0s"),
+			},
+		},
+		"valid default duration": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone: "a\\b\\c",
+				TPP: &cmapi.VenafiTPP{
+					URL:            "https://tpp.example.com/vedsdk",
+					CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"},
+				},
+				MaxDuration:     &metav1.Duration{Duration: time.Hour * 24 * 365},
+				DefaultDuration: &metav1.Duration{Duration: time.Hour * 24 * 90},
+			},
+		},
+		"default duration which is shorter than the minimum certificate duration": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone: "a\\b\\c",
+				TPP: &cmapi.VenafiTPP{
+					URL:            "https://tpp.example.com/vedsdk",
+					CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"},
+				},
+				DefaultDuration: &metav1.Duration{Duration: time.Minute},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("defaultDuration"), time.Minute, "must be at least 1h0m0s"),
+			},
+		},
+		"default duration which is greater than the max duration": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone: "a\\b\\c",
+				TPP: &cmapi.VenafiTPP{
+					URL:            "https://tpp.example.com/vedsdk",
+					CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"},
+				},
+				MaxDuration:     &metav1.Duration{Duration: time.Hour * 24 * 30},
+				DefaultDuration: &metav1.Duration{Duration: time.Hour * 24 * 90},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("defaultDuration"), time.Hour*24*90, "must not be greater than maxDuration 720h0m0s"),
+			},
+		},
+		"valid credentials reference": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:  "a\\b\\c",
+				Cloud: cloud,
+				CredentialsRef: &cmapi.VenafiCredentialsReference{
+					Name:  "venafi-credentials",
+					Kind:  "ExternalSecret",
+					Group: "secrets.example.com",
+				},
+			},
+		},
+		"credentials reference without a name": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:           "a\\b\\c",
+				Cloud:          cloud,
+				CredentialsRef: &cmapi.VenafiCredentialsReference{},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("credentialsRef", "name"), ""),
+			},
+		},
+		"tpp issuer which revokes on delete": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:           "a\\b\\c",
+				TPP:            &cmapi.VenafiTPP{URL: "https://tpp.example.com/vedsdk", CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"}},
+				RevokeOnDelete: true,
+			},
+		},
+		"cloud issuer which revokes on delete": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:           "a\\b\\c",
+				Cloud:          cloud,
+				RevokeOnDelete: true,
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("revokeOnDelete"), "revocation is not supported by Venafi Cloud"),
+			},
+		},
+		"additional zones": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:            "a\\b\\c",
+				AdditionalZones: []string{"a\\b\\d", "a\\b\\e"},
+				Cloud:           cloud,
+			},
+		},
+		"empty and duplicate additional zones": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:            "a\\b\\c",
+				AdditionalZones: []string{"a\\b\\d", "", "a\\b\\c", "a\\b\\d"},
+				Cloud:           cloud,
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("additionalZones").Index(1), ""),
+				field.Duplicate(fldPath.Child("additionalZones").Index(2), "a\\b\\c"),
+				field.Duplicate(fldPath.Child("additionalZones").Index(3), "a\\b\\d"),
+			},
+		},
+		"allowed zone overrides": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:                 "a\\b\\c",
+				AllowedZoneOverrides: []string{"a\\b\\c", "a\\b\\d"},
+				Cloud:                cloud,
+			},
+		},
+		"empty and duplicate allowed zone overrides": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:                 "a\\b\\c",
+				AllowedZoneOverrides: []string{"a\\b\\d", "", "a\\b\\d"},
+				Cloud:                cloud,
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("allowedZoneOverrides").Index(1), ""),
+				field.Duplicate(fldPath.Child("allowedZoneOverrides").Index(2), "a\\b\\d"),
+			},
+		},
+		"chain bundle secret reference": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:                 "a\\b\\c",
+				Cloud:                cloud,
+				ChainBundleSecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "chain"}},
+			},
+		},
+		"chain bundle secret reference without a name": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:                 "a\\b\\c",
+				Cloud:                cloud,
+				ChainBundleSecretRef: &cmmeta.SecretKeySelector{Key: "ca.crt"},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("chainBundleSecretRef", "name"), "secret name is required"),
+			},
+		},
+		"chain verification against the system trust store": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:              "a\\b\\c",
+				Cloud:             cloud,
+				ChainVerification: &cmapi.VenafiChainVerification{SkipIfUnavailable: true},
+			},
+		},
+		"chain verification trust anchors secret reference without a name": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:  "a\\b\\c",
+				Cloud: cloud,
+				ChainVerification: &cmapi.VenafiChainVerification{
+					TrustAnchorsSecretRef: &cmmeta.SecretKeySelector{Key: "ca.crt"},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("chainVerification", "trustAnchorsSecretRef", "name"), "secret name is required"),
+			},
+		},
+		"allowed domains": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:           "a\\b\\c",
+				Cloud:          cloud,
+				AllowedDomains: []string{"example.com", "*.example.com", "**.apps.example.com", "web-?.example.org"},
+			},
+		},
+		"invalid and duplicate allowed domains": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:           "a\\b\\c",
+				Cloud:          cloud,
+				AllowedDomains: []string{"", "a..example.com", "*.**.example.com", "**", "[a.example.com", "*.example.com", "*.Example.com"},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("allowedDomains").Index(0), "", "must not contain empty labels"),
+				field.Invalid(fldPath.Child("allowedDomains").Index(1), "a..example.com", "must not contain empty labels"),
+				field.Invalid(fldPath.Child("allowedDomains").Index(2), "*.**.example.com", "`**` may only be used as the first label of a pattern"),
+				field.Invalid(fldPath.Child("allowedDomains").Index(3), "**", "`**` may only be used as the first label of a pattern"),
+				field.Invalid(fldPath.Child("allowedDomains").Index(4), "[a.example.com", "invalid pattern: syntax error in pattern"),
+				field.Duplicate(fldPath.Child("allowedDomains").Index(6), "*.Example.com"),
+			},
+		},
+		"allowed extensions": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:              "a\\b\\c",
+				Cloud:             cloud,
+				AllowedExtensions: []string{"1.3.6.1.4.1.311.20.2", "1.2.3.4"},
+			},
+		},
+		"invalid and duplicate allowed extensions": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:              "a\\b\\c",
+				Cloud:             cloud,
+				AllowedExtensions: []string{"1.2.3.4", "", "1.2.x", "1.2.3.4"},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("allowedExtensions").Index(1), "", "oid syntax invalid"),
+				field.Invalid(fldPath.Child("allowedExtensions").Index(2), "1.2.x", "oid syntax invalid"),
+				field.Duplicate(fldPath.Child("allowedExtensions").Index(3), "1.2.3.4"),
+			},
+		},
+		"legacy extensions with allowed extensions including the Netscape certificate type": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:              "a\\b\\c",
+				Cloud:             cloud,
+				AllowedExtensions: []string{"1.2.3.4", "2.16.840.1.113730.1.1"},
+				LegacyExtensions:  true,
+			},
+		},
+		"legacy extensions with allowed extensions excluding the Netscape certificate type": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:              "a\\b\\c",
+				Cloud:             cloud,
+				AllowedExtensions: []string{"1.2.3.4"},
+				LegacyExtensions:  true,
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("allowedExtensions"), []string{"1.2.3.4"}, `must include the Netscape certificate type extension "2.16.840.1.113730.1.1" if legacyExtensions is set`),
+			},
+		},
+		"tpp issuer which reuses existing certificates": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:          "a\\b\\c",
+				TPP:           &cmapi.VenafiTPP{URL: "https://tpp.example.com/vedsdk", CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"}},
+				ReuseExisting: true,
+				ReuseMaxAge:   &metav1.Duration{Duration: time.Hour},
+			},
+		},
+		"cloud issuer which reuses existing certificates": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:          "a\\b\\c",
+				Cloud:         cloud,
+				ReuseExisting: true,
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("reuseExisting"), "reuse of existing certificates is not supported by Venafi Cloud"),
+			},
+		},
+		"tpp issuer with service generated keys which reuses existing certificates": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:          "a\\b\\c",
+				TPP:           &cmapi.VenafiTPP{URL: "https://tpp.example.com/vedsdk", CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"}, ServiceGeneratedKeys: true},
+				ReuseExisting: true,
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("reuseExisting"), "reuse of existing certificates is not supported with service generated keys"),
+			},
+		},
+		"non-positive issuance deadline": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:             "a\\b\\c",
+				TPP:              &cmapi.VenafiTPP{URL: "https://tpp.example.com/vedsdk", CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"}},
+				IssuanceDeadline: &metav1.Duration{Duration: -time.Hour},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("issuanceDeadline"), -time.Hour, "must be greater than zero"),
+			},
+		},
+		"non-positive reuse max age": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:        "a\\b\\c",
+				TPP:         &cmapi.VenafiTPP{URL: "https://tpp.example.com/vedsdk", CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"}},
+				ReuseMaxAge: &metav1.Duration{},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("reuseMaxAge"), time.Duration(0), "must be greater than zero"),
+			},
+		},
+		"valid subject defaults": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone: "a\\b\\c",
+				TPP:  &cmapi.VenafiTPP{URL: "https://tpp.example.com/vedsdk", CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"}},
+				SubjectDefaults: &cmapi.VenafiSubjectDefaults{
+					Organizations:       []string{"Example Inc."},
+					OrganizationalUnits: []string{"Engineering"},
+					Localities:          []string{"Salt Lake City"},
+					Provinces:           []string{"Utah"},
+					Countries:           []string{"US"},
+				},
+			},
+		},
+		"invalid subject defaults": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone: "a\\b\\c",
+				TPP:  &cmapi.VenafiTPP{URL: "https://tpp.example.com/vedsdk", CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"}},
+				SubjectDefaults: &cmapi.VenafiSubjectDefaults{
+					Organizations: []string{"Example Inc.", " "},
+					Countries:     []string{"USA"},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("subjectDefaults", "organizations").Index(1), "must not be empty"),
+				field.Invalid(fldPath.Child("subjectDefaults", "countries").Index(0), "USA", "must be a two-letter country code"),
+			},
+		},
+		"valid fallback credentials": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:                    "a\\b\\c",
+				TPP:                     &cmapi.VenafiTPP{URL: "https://tpp.example.com/vedsdk", CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"}},
+				FallbackCredentialsRefs: []cmapi.VenafiCredentialsReference{{Name: "backup"}, {Name: "other-backup"}},
+			},
+		},
+		"invalid fallback credentials": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:                    "a\\b\\c",
+				TPP:                     &cmapi.VenafiTPP{URL: "https://tpp.example.com/vedsdk", CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"}},
+				FallbackCredentialsRefs: []cmapi.VenafiCredentialsReference{{Name: "backup"}, {}, {Name: "backup"}},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("fallbackCredentialsRefs").Index(1).Child("name"), ""),
+				field.Duplicate(fldPath.Child("fallbackCredentialsRefs").Index(2), cmapi.VenafiCredentialsReference{Name: "backup"}),
+			},
+		},
+		"valid default annotations": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone: "a\\b\\c",
+				TPP:  &cmapi.VenafiTPP{URL: "https://tpp.example.com/vedsdk", CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"}},
+				DefaultAnnotations: map[string]string{
+					"venafi.cert-manager.io/custom-fields":     `{"cost-center": "1234"}`,
+					"venafi.cert-manager.io/friendly-name":     "example",
+					"cert-manager.io/signature-hash-algorithm": "SHA384",
+				},
+			},
+		},
+		"invalid default annotations": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone: "a\\b\\c",
+				TPP:  &cmapi.VenafiTPP{URL: "https://tpp.example.com/vedsdk", CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"}},
+				DefaultAnnotations: map[string]string{
+					"venafi.cert-manager.io/custom-fields":     "not-json",
+					"venafi.cert-manager.io/zone-override":     "other-zone",
+					"cert-manager.io/signature-hash-algorithm": "MD5",
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("defaultAnnotations").Key("cert-manager.io/signature-hash-algorithm"), "MD5", `unsupported signature hash algorithm "MD5", must be one of SHA256, SHA384 or SHA512`),
+				field.Invalid(fldPath.Child("defaultAnnotations").Key("venafi.cert-manager.io/custom-fields"), "not-json", "failed to parse custom fields: invalid character 'o' in literal null (expecting 'u')"),
+				field.NotSupported(fldPath.Child("defaultAnnotations").Key("venafi.cert-manager.io/zone-override"), "venafi.cert-manager.io/zone-override", []string{
+					"cert-manager.io/signature-hash-algorithm",
+					"venafi.cert-manager.io/custom-fields",
+					"venafi.cert-manager.io/friendly-name",
+					"venafi.cert-manager.io/instance",
+					"venafi.cert-manager.io/workload",
+				}),
+			},
+		},
+		"valid origin custom field": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:              "a\\b\\c",
+				TPP:               &cmapi.VenafiTPP{URL: "https://tpp.example.com/vedsdk", CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"}},
+				OriginCustomField: "Kubernetes Origin",
+				OriginTemplate:    "cluster-a/{namespace}/{certificate} ({request})",
+			},
+		},
+		"origin template without origin custom field": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:           "a\\b\\c",
+				TPP:            &cmapi.VenafiTPP{URL: "https://tpp.example.com/vedsdk", CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"}},
+				OriginTemplate: "{namespace}/{certificate}",
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("originCustomField"), "must be set when originTemplate is set"),
+			},
+		},
+		"invalid origin custom field and template": {
+			cfg: &cmapi.VenafiIssuer{
+				Zone:              "a\\b\\c",
+				TPP:               &cmapi.VenafiTPP{URL: "https://tpp.example.com/vedsdk", CredentialsRef: cmmeta.LocalObjectReference{Name: "secret"}},
+				OriginCustomField: " ",
+				OriginTemplate:    "{namespace}/{certificate_name}",
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("originCustomField"), " ", "must not be blank"),
+				field.Invalid(fldPath.Child("originTemplate"), "{namespace}/{certificate_name}", `unknown placeholder "{certificate_name}", must be one of {namespace}, {certificate}, {request}`),
+			},
+		},
 	}
 
 	for n, s := range scenarios {
@@ -1650,6 +2272,54 @@ func TestValidateVenafiIssuerConfig(t *testing.T) {
 	}
 }
 
+func TestValidateSelfSignedIssuerConfig(t *testing.T) {
+	fldPath := field.NewPath("test")
+	scenarios := map[string]struct {
+		cfg  *cmapi.SelfSignedIssuer
+		errs []*field.Error
+	}{
+		"valid": {
+			cfg: &cmapi.SelfSignedIssuer{},
+		},
+		"valid notBeforeBackdate": {
+			cfg: &cmapi.SelfSignedIssuer{
+				NotBeforeBackdate: &metav1.Duration{Duration: time.Minute * 5},
+			},
+		},
+		"negative notBeforeBackdate": {
+			cfg: &cmapi.SelfSignedIssuer{
+				NotBeforeBackdate: &metav1.Duration{Duration: -time.Minute},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("notBeforeBackdate"), -time.Minute, "must not be negative"),
+			},
+		},
+		"too large notBeforeBackdate": {
+			cfg: &cmapi.SelfSignedIssuer{
+				NotBeforeBackdate: &metav1.Duration{Duration: time.Hour * 2},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("notBeforeBackdate"), time.Hour*2, "must not be greater than 1h0m0s"),
+			},
+		},
+	}
+
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs := ValidateSelfSignedIssuerConfig(s.cfg, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Fatalf("Expected %v but got %v", s.errs, errs)
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
+
 func TestValidateVenafiTPP(t *testing.T) {
 	caBundle := unitcrypto.MustCreateCryptoBundle(t,
 		&pubcmapi.Certificate{Spec: pubcmapi.CertificateSpec{CommonName: "test"}},
@@ -1686,6 +2356,21 @@ func TestValidateVenafiTPP(t *testing.T) {
 				field.Forbidden(fldPath, "may not specify more than one of caBundle/caBundleSecretRef as TPP CA Bundle"),
 			},
 		},
+		"valid clientCertificateSecretRef": {
+			cfg: &cmapi.VenafiTPP{
+				URL:                        "https://tpp.example.com/vedsdk",
+				ClientCertificateSecretRef: &cmmeta.LocalObjectReference{Name: "client-cert"},
+			},
+		},
+		"clientCertificateSecretRef without a name": {
+			cfg: &cmapi.VenafiTPP{
+				URL:                        "https://tpp.example.com/vedsdk",
+				ClientCertificateSecretRef: &cmmeta.LocalObjectReference{},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("clientCertificateSecretRef", "name"), ""),
+			},
+		},
 	}
 
 	for n, s := range scenarios {
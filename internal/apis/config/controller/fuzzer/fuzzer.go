@@ -57,6 +57,10 @@ var Funcs = func(codecs runtimeserializer.CodecFactory) []interface{} {
 				s.PprofAddress = "test-roundtrip"
 			}
 
+			if s.VenafiFieldManager == "" {
+				s.VenafiFieldManager = "test-roundtrip"
+			}
+
 			logsapi.SetRecommendedLoggingConfiguration(&s.Logging)
 
 			if s.LeaderElectionConfig.Namespace == "" {
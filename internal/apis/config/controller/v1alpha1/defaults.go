@@ -43,6 +43,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/requestmanager"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/revisionmanager"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/trigger"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/venafirevoker"
 	csracmecontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/acme"
 	csrcacontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/ca"
 	csrselfsignedcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/selfsigned"
@@ -81,6 +82,33 @@ var (
 	defaultNumberOfConcurrentWorkers int32 = 5
 	defaultMaxConcurrentChallenges   int32 = 60
 
+	defaultShutdownGracePeriod = 10 * time.Second
+
+	defaultVenafiMaxConcurrentSignings int32 = 5
+
+	defaultIssuerHealthCheckInterval = time.Duration(0)
+
+	defaultVenafiRequestTimeout = 5 * time.Minute
+
+	defaultVenafiReconcileTimeout = time.Duration(0)
+
+	defaultVenafiIssuerWarmUpTimeout = time.Duration(0)
+
+	defaultVenafiRetrieveFailureTimeout = time.Hour
+
+	defaultVenafiCircuitBreakerThreshold    int32 = 5
+	defaultVenafiCircuitBreakerOpenDuration       = time.Minute
+
+	defaultCertificateRequestEventCooldown = 5 * time.Minute
+
+	defaultVenafiErrorLogInterval = 5 * time.Minute
+
+	defaultVenafiZoneCacheTTL = time.Minute
+
+	defaultEnableVenafiIssuerCache = false
+
+	defaultVenafiFieldManager = "cert-manager-venafi"
+
 	defaultPrometheusMetricsServerAddress = "0.0.0.0:9402"
 
 	defaultHealthzServerAddress = "0.0.0.0:9403"
@@ -121,6 +149,7 @@ var (
 		requestmanager.ControllerName,
 		readiness.ControllerName,
 		revisionmanager.ControllerName,
+		venafirevoker.ControllerName,
 	}
 
 	DefaultEnabledControllers = []string{
@@ -143,6 +172,7 @@ var (
 		requestmanager.ControllerName,
 		readiness.ControllerName,
 		revisionmanager.ControllerName,
+		venafirevoker.ControllerName,
 	}
 
 	ExperimentalCertificateSigningRequestControllers = []string{
@@ -224,6 +254,62 @@ func SetDefaults_ControllerConfiguration(obj *v1alpha1.ControllerConfiguration)
 		obj.MaxConcurrentChallenges = &defaultMaxConcurrentChallenges
 	}
 
+	if obj.ShutdownGracePeriod == nil {
+		obj.ShutdownGracePeriod = sharedv1alpha1.DurationFromTime(defaultShutdownGracePeriod)
+	}
+
+	if obj.VenafiMaxConcurrentSignings == nil {
+		obj.VenafiMaxConcurrentSignings = &defaultVenafiMaxConcurrentSignings
+	}
+
+	if obj.IssuerHealthCheckInterval == nil {
+		obj.IssuerHealthCheckInterval = sharedv1alpha1.DurationFromTime(defaultIssuerHealthCheckInterval)
+	}
+
+	if obj.VenafiRequestTimeout == nil {
+		obj.VenafiRequestTimeout = sharedv1alpha1.DurationFromTime(defaultVenafiRequestTimeout)
+	}
+
+	if obj.VenafiReconcileTimeout == nil {
+		obj.VenafiReconcileTimeout = sharedv1alpha1.DurationFromTime(defaultVenafiReconcileTimeout)
+	}
+
+	if obj.VenafiIssuerWarmUpTimeout == nil {
+		obj.VenafiIssuerWarmUpTimeout = sharedv1alpha1.DurationFromTime(defaultVenafiIssuerWarmUpTimeout)
+	}
+
+	if obj.VenafiRetrieveFailureTimeout == nil {
+		obj.VenafiRetrieveFailureTimeout = sharedv1alpha1.DurationFromTime(defaultVenafiRetrieveFailureTimeout)
+	}
+
+	if obj.VenafiCircuitBreakerThreshold == nil {
+		obj.VenafiCircuitBreakerThreshold = &defaultVenafiCircuitBreakerThreshold
+	}
+
+	if obj.VenafiCircuitBreakerOpenDuration == nil {
+		obj.VenafiCircuitBreakerOpenDuration = sharedv1alpha1.DurationFromTime(defaultVenafiCircuitBreakerOpenDuration)
+	}
+
+	if obj.CertificateRequestEventCooldown == nil {
+		obj.CertificateRequestEventCooldown = sharedv1alpha1.DurationFromTime(defaultCertificateRequestEventCooldown)
+	}
+
+	if obj.VenafiErrorLogInterval == nil {
+		obj.VenafiErrorLogInterval = sharedv1alpha1.DurationFromTime(defaultVenafiErrorLogInterval)
+	}
+
+	if obj.VenafiZoneCacheTTL == nil {
+		obj.VenafiZoneCacheTTL = sharedv1alpha1.DurationFromTime(defaultVenafiZoneCacheTTL)
+	}
+
+	if obj.EnableVenafiIssuerCache == nil {
+		obj.EnableVenafiIssuerCache = &defaultEnableVenafiIssuerCache
+	}
+
+	if obj.VenafiFieldManager == "" {
+		obj.VenafiFieldManager = defaultVenafiFieldManager
+	}
+
 	if obj.MetricsListenAddress == "" {
 		obj.MetricsListenAddress = defaultPrometheusMetricsServerAddress
 	}
@@ -194,6 +194,61 @@ func autoConvert_v1alpha1_ControllerConfiguration_To_controller_ControllerConfig
 	if err := sharedv1alpha1.Convert_Pointer_int32_To_int(&in.MaxConcurrentChallenges, &out.MaxConcurrentChallenges, s); err != nil {
 		return err
 	}
+	if err := sharedv1alpha1.Convert_Pointer_v1alpha1_Duration_To_time_Duration(&in.ShutdownGracePeriod, &out.ShutdownGracePeriod, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_int32_To_int(&in.VenafiMaxConcurrentSignings, &out.VenafiMaxConcurrentSignings, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_int32_To_int(&in.VenafiConcurrentWorkers, &out.VenafiConcurrentWorkers, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_v1alpha1_Duration_To_time_Duration(&in.IssuerHealthCheckInterval, &out.IssuerHealthCheckInterval, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_v1alpha1_Duration_To_time_Duration(&in.VenafiRequestTimeout, &out.VenafiRequestTimeout, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_v1alpha1_Duration_To_time_Duration(&in.VenafiReconcileTimeout, &out.VenafiReconcileTimeout, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_v1alpha1_Duration_To_time_Duration(&in.VenafiIssuerWarmUpTimeout, &out.VenafiIssuerWarmUpTimeout, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_v1alpha1_Duration_To_time_Duration(&in.VenafiRetrieveFailureTimeout, &out.VenafiRetrieveFailureTimeout, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_int32_To_int(&in.VenafiCircuitBreakerThreshold, &out.VenafiCircuitBreakerThreshold, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_v1alpha1_Duration_To_time_Duration(&in.VenafiCircuitBreakerOpenDuration, &out.VenafiCircuitBreakerOpenDuration, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_v1alpha1_Duration_To_time_Duration(&in.CertificateRequestEventCooldown, &out.CertificateRequestEventCooldown, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_v1alpha1_Duration_To_time_Duration(&in.VenafiErrorLogInterval, &out.VenafiErrorLogInterval, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_v1alpha1_Duration_To_time_Duration(&in.VenafiZoneCacheTTL, &out.VenafiZoneCacheTTL, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableVenafiIssuerCache, &out.EnableVenafiIssuerCache, s); err != nil {
+		return err
+	}
+	out.VenafiValidityHintExtensionOID = in.VenafiValidityHintExtensionOID
+	out.VenafiFieldManager = in.VenafiFieldManager
+	out.VenafiProxyURL = in.VenafiProxyURL
+	out.VenafiCallbackListenAddress = in.VenafiCallbackListenAddress
+	out.VenafiCallbackTokenFile = in.VenafiCallbackTokenFile
+	if err := sharedv1alpha1.Convert_v1alpha1_TLSConfig_To_shared_TLSConfig(&in.VenafiCallbackTLSConfig, &out.VenafiCallbackTLSConfig, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_Pointer_int32_To_int(&in.VenafiResponseLogSize, &out.VenafiResponseLogSize, s); err != nil {
+		return err
+	}
+	out.IssuanceAuditLogFile = in.IssuanceAuditLogFile
+	out.IssuanceAuditWebhookURL = in.IssuanceAuditWebhookURL
 	out.MetricsListenAddress = in.MetricsListenAddress
 	if err := sharedv1alpha1.Convert_v1alpha1_TLSConfig_To_shared_TLSConfig(&in.MetricsTLSConfig, &out.MetricsTLSConfig, s); err != nil {
 		return err
@@ -256,6 +311,61 @@ func autoConvert_controller_ControllerConfiguration_To_v1alpha1_ControllerConfig
 	if err := sharedv1alpha1.Convert_int_To_Pointer_int32(&in.MaxConcurrentChallenges, &out.MaxConcurrentChallenges, s); err != nil {
 		return err
 	}
+	if err := sharedv1alpha1.Convert_time_Duration_To_Pointer_v1alpha1_Duration(&in.ShutdownGracePeriod, &out.ShutdownGracePeriod, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_int_To_Pointer_int32(&in.VenafiMaxConcurrentSignings, &out.VenafiMaxConcurrentSignings, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_int_To_Pointer_int32(&in.VenafiConcurrentWorkers, &out.VenafiConcurrentWorkers, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_time_Duration_To_Pointer_v1alpha1_Duration(&in.IssuerHealthCheckInterval, &out.IssuerHealthCheckInterval, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_time_Duration_To_Pointer_v1alpha1_Duration(&in.VenafiRequestTimeout, &out.VenafiRequestTimeout, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_time_Duration_To_Pointer_v1alpha1_Duration(&in.VenafiReconcileTimeout, &out.VenafiReconcileTimeout, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_time_Duration_To_Pointer_v1alpha1_Duration(&in.VenafiIssuerWarmUpTimeout, &out.VenafiIssuerWarmUpTimeout, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_time_Duration_To_Pointer_v1alpha1_Duration(&in.VenafiRetrieveFailureTimeout, &out.VenafiRetrieveFailureTimeout, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_int_To_Pointer_int32(&in.VenafiCircuitBreakerThreshold, &out.VenafiCircuitBreakerThreshold, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_time_Duration_To_Pointer_v1alpha1_Duration(&in.VenafiCircuitBreakerOpenDuration, &out.VenafiCircuitBreakerOpenDuration, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_time_Duration_To_Pointer_v1alpha1_Duration(&in.CertificateRequestEventCooldown, &out.CertificateRequestEventCooldown, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_time_Duration_To_Pointer_v1alpha1_Duration(&in.VenafiErrorLogInterval, &out.VenafiErrorLogInterval, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_time_Duration_To_Pointer_v1alpha1_Duration(&in.VenafiZoneCacheTTL, &out.VenafiZoneCacheTTL, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableVenafiIssuerCache, &out.EnableVenafiIssuerCache, s); err != nil {
+		return err
+	}
+	out.VenafiValidityHintExtensionOID = in.VenafiValidityHintExtensionOID
+	out.VenafiFieldManager = in.VenafiFieldManager
+	out.VenafiProxyURL = in.VenafiProxyURL
+	out.VenafiCallbackListenAddress = in.VenafiCallbackListenAddress
+	out.VenafiCallbackTokenFile = in.VenafiCallbackTokenFile
+	if err := sharedv1alpha1.Convert_shared_TLSConfig_To_v1alpha1_TLSConfig(&in.VenafiCallbackTLSConfig, &out.VenafiCallbackTLSConfig, s); err != nil {
+		return err
+	}
+	if err := sharedv1alpha1.Convert_int_To_Pointer_int32(&in.VenafiResponseLogSize, &out.VenafiResponseLogSize, s); err != nil {
+		return err
+	}
+	out.IssuanceAuditLogFile = in.IssuanceAuditLogFile
+	out.IssuanceAuditWebhookURL = in.IssuanceAuditWebhookURL
 	out.MetricsListenAddress = in.MetricsListenAddress
 	if err := sharedv1alpha1.Convert_shared_TLSConfig_To_v1alpha1_TLSConfig(&in.MetricsTLSConfig, &out.MetricsTLSConfig, s); err != nil {
 		return err
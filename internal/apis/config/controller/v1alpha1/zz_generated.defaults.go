@@ -41,6 +41,7 @@ func SetObjectDefaults_ControllerConfiguration(in *v1alpha1.ControllerConfigurat
 	SetDefaults_ControllerConfiguration(in)
 	SetDefaults_LeaderElectionConfig(&in.LeaderElectionConfig)
 	sharedv1alpha1.SetDefaults_LeaderElectionConfig(&in.LeaderElectionConfig.LeaderElectionConfig)
+	sharedv1alpha1.SetDefaults_DynamicServingConfig(&in.VenafiCallbackTLSConfig.Dynamic)
 	sharedv1alpha1.SetDefaults_DynamicServingConfig(&in.MetricsTLSConfig.Dynamic)
 	SetDefaults_IngressShimConfig(&in.IngressShimConfig)
 	SetDefaults_ACMEHTTP01Config(&in.ACMEHTTP01Config)
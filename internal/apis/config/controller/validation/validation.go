@@ -17,6 +17,7 @@ limitations under the License.
 package validation
 
 import (
+	"fmt"
 	"net"
 	"net/url"
 	"strings"
@@ -28,13 +29,19 @@ import (
 	config "github.com/cert-manager/cert-manager/internal/apis/config/controller"
 	defaults "github.com/cert-manager/cert-manager/internal/apis/config/controller/v1alpha1"
 	sharedvalidation "github.com/cert-manager/cert-manager/internal/apis/config/shared/validation"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
+// maxVenafiResponseLogSize bounds the memory used by the Venafi response log,
+// whose entries are each truncated to 16KiB, to about 16MiB.
+const maxVenafiResponseLogSize = 1000
+
 func ValidateControllerConfiguration(cfg *config.ControllerConfiguration, fldPath *field.Path) field.ErrorList {
 	var allErrors field.ErrorList
 
 	allErrors = append(allErrors, logsapi.Validate(&cfg.Logging, nil, fldPath.Child("logging"))...)
 	allErrors = append(allErrors, sharedvalidation.ValidateTLSConfig(&cfg.MetricsTLSConfig, fldPath.Child("metricsTLSConfig"))...)
+	allErrors = append(allErrors, sharedvalidation.ValidateTLSConfig(&cfg.VenafiCallbackTLSConfig, fldPath.Child("venafiCallbackTLSConfig"))...)
 
 	if cfg.LeaderElectionConfig.Enabled && cfg.LeaderElectionConfig.HealthzTimeout <= 0 {
 		allErrors = append(allErrors, field.Invalid(fldPath.Child("leaderElectionConfig").Child("healthzTimeout"), cfg.LeaderElectionConfig.HealthzTimeout, "must be higher than 0"))
@@ -57,6 +64,100 @@ func ValidateControllerConfiguration(cfg *config.ControllerConfiguration, fldPat
 		allErrors = append(allErrors, field.Invalid(fldPath.Child("kubernetesAPIBurst"), cfg.KubernetesAPIBurst, "must be higher or equal to kubernetesAPIQPS"))
 	}
 
+	if cfg.ShutdownGracePeriod < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("shutdownGracePeriod"), cfg.ShutdownGracePeriod, "must not be negative"))
+	}
+
+	if cfg.VenafiConcurrentWorkers < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("venafiConcurrentWorkers"), cfg.VenafiConcurrentWorkers, "must not be negative"))
+	}
+
+	if cfg.IssuerHealthCheckInterval < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("issuerHealthCheckInterval"), cfg.IssuerHealthCheckInterval, "must not be negative"))
+	}
+
+	if cfg.VenafiRequestTimeout < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("venafiRequestTimeout"), cfg.VenafiRequestTimeout, "must not be negative"))
+	}
+
+	if cfg.VenafiReconcileTimeout < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("venafiReconcileTimeout"), cfg.VenafiReconcileTimeout, "must not be negative"))
+	}
+
+	if cfg.VenafiIssuerWarmUpTimeout < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("venafiIssuerWarmUpTimeout"), cfg.VenafiIssuerWarmUpTimeout, "must not be negative"))
+	}
+
+	if cfg.VenafiRetrieveFailureTimeout < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("venafiRetrieveFailureTimeout"), cfg.VenafiRetrieveFailureTimeout, "must not be negative"))
+	}
+
+	if cfg.VenafiCircuitBreakerThreshold < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("venafiCircuitBreakerThreshold"), cfg.VenafiCircuitBreakerThreshold, "must not be negative"))
+	}
+
+	if cfg.VenafiCircuitBreakerThreshold > 0 && cfg.VenafiCircuitBreakerOpenDuration <= 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("venafiCircuitBreakerOpenDuration"), cfg.VenafiCircuitBreakerOpenDuration, "must be positive when the circuit breaker is enabled"))
+	}
+
+	if cfg.CertificateRequestEventCooldown < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("certificateRequestEventCooldown"), cfg.CertificateRequestEventCooldown, "must not be negative"))
+	}
+
+	if cfg.VenafiErrorLogInterval < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("venafiErrorLogInterval"), cfg.VenafiErrorLogInterval, "must not be negative"))
+	}
+
+	if cfg.VenafiZoneCacheTTL < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("venafiZoneCacheTTL"), cfg.VenafiZoneCacheTTL, "must not be negative"))
+	}
+
+	if cfg.VenafiValidityHintExtensionOID != "" {
+		if _, err := pki.ParseObjectIdentifier(cfg.VenafiValidityHintExtensionOID); err != nil {
+			allErrors = append(allErrors, field.Invalid(fldPath.Child("venafiValidityHintExtensionOID"), cfg.VenafiValidityHintExtensionOID, "must be a dotted OID"))
+		}
+	}
+
+	// The API server rejects field managers longer than 128 characters.
+	if len(cfg.VenafiFieldManager) > 128 {
+		allErrors = append(allErrors, field.TooLong(fldPath.Child("venafiFieldManager"), cfg.VenafiFieldManager, 128))
+	}
+
+	if cfg.VenafiProxyURL != "" {
+		if u, err := url.ParseRequestURI(cfg.VenafiProxyURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			allErrors = append(allErrors, field.Invalid(fldPath.Child("venafiProxyURL"), cfg.VenafiProxyURL, "must be an http or https URL"))
+		}
+	}
+
+	if cfg.VenafiCallbackListenAddress != "" {
+		host, _, err := net.SplitHostPort(cfg.VenafiCallbackListenAddress)
+		if err != nil {
+			allErrors = append(allErrors, field.Invalid(fldPath.Child("venafiCallbackListenAddress"), cfg.VenafiCallbackListenAddress, "must be a host and port"))
+		}
+		// The bearer token of callback requests must not be sent in
+		// cleartext over the network.
+		tlsConfigured := cfg.VenafiCallbackTLSConfig.FilesystemConfigProvided() || cfg.VenafiCallbackTLSConfig.DynamicConfigProvided()
+		if err == nil && !tlsConfigured && !isLoopbackHost(host) {
+			allErrors = append(allErrors, field.Required(fldPath.Child("venafiCallbackTLSConfig"), "required unless venafiCallbackListenAddress is a loopback address"))
+		}
+		if cfg.VenafiCallbackTokenFile == "" {
+			allErrors = append(allErrors, field.Required(fldPath.Child("venafiCallbackTokenFile"), "required when venafiCallbackListenAddress is set"))
+		}
+	}
+
+	if cfg.VenafiResponseLogSize < 0 || cfg.VenafiResponseLogSize > maxVenafiResponseLogSize {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("venafiResponseLogSize"), cfg.VenafiResponseLogSize, fmt.Sprintf("must be between 0 and %d", maxVenafiResponseLogSize)))
+	}
+	if cfg.VenafiResponseLogSize > 0 && !cfg.EnablePprof {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("venafiResponseLogSize"), cfg.VenafiResponseLogSize, "requires enablePprof, as the responses are served on the profiler address"))
+	}
+
+	if cfg.IssuanceAuditWebhookURL != "" {
+		if u, err := url.ParseRequestURI(cfg.IssuanceAuditWebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			allErrors = append(allErrors, field.Invalid(fldPath.Child("issuanceAuditWebhookURL"), cfg.IssuanceAuditWebhookURL, "must be an http or https URL"))
+		}
+	}
+
 	for i, server := range cfg.ACMEHTTP01Config.SolverNameservers {
 		// ensure all servers have a port number
 		_, _, err := net.SplitHostPort(server)
@@ -95,3 +196,13 @@ func ValidateControllerConfiguration(cfg *config.ControllerConfiguration, fldPat
 
 	return allErrors
 }
+
+// isLoopbackHost returns true if the given host of a listen address only
+// accepts connections from the local machine.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package validation
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -200,6 +201,430 @@ func TestValidateControllerConfiguration(t *testing.T) {
 				}
 			},
 		},
+		{
+			"with negative issuer health check interval",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:        1,
+				KubernetesAPIQPS:          1,
+				IssuerHealthCheckInterval: -time.Minute,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("issuerHealthCheckInterval"), cc.IssuerHealthCheckInterval, "must not be negative"),
+				}
+			},
+		},
+		{
+			"with negative venafi request timeout",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:   1,
+				KubernetesAPIQPS:     1,
+				VenafiRequestTimeout: -time.Minute,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("venafiRequestTimeout"), cc.VenafiRequestTimeout, "must not be negative"),
+				}
+			},
+		},
+		{
+			"with negative venafi reconcile timeout",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:     1,
+				KubernetesAPIQPS:       1,
+				VenafiReconcileTimeout: -time.Minute,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("venafiReconcileTimeout"), cc.VenafiReconcileTimeout, "must not be negative"),
+				}
+			},
+		},
+		{
+			"with negative venafi issuer warm up timeout",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:        1,
+				KubernetesAPIQPS:          1,
+				VenafiIssuerWarmUpTimeout: -time.Minute,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("venafiIssuerWarmUpTimeout"), cc.VenafiIssuerWarmUpTimeout, "must not be negative"),
+				}
+			},
+		},
+		{
+			"with negative venafi retrieve failure timeout",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:           1,
+				KubernetesAPIQPS:             1,
+				VenafiRetrieveFailureTimeout: -time.Minute,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("venafiRetrieveFailureTimeout"), cc.VenafiRetrieveFailureTimeout, "must not be negative"),
+				}
+			},
+		},
+		{
+			"with negative venafi circuit breaker threshold",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:            1,
+				KubernetesAPIQPS:              1,
+				VenafiCircuitBreakerThreshold: -1,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("venafiCircuitBreakerThreshold"), cc.VenafiCircuitBreakerThreshold, "must not be negative"),
+				}
+			},
+		},
+		{
+			"with venafi circuit breaker enabled without an open duration",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:            1,
+				KubernetesAPIQPS:              1,
+				VenafiCircuitBreakerThreshold: 5,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("venafiCircuitBreakerOpenDuration"), cc.VenafiCircuitBreakerOpenDuration, "must be positive when the circuit breaker is enabled"),
+				}
+			},
+		},
+		{
+			"with an invalid venafi proxy URL",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst: 1,
+				KubernetesAPIQPS:   1,
+				VenafiProxyURL:     "proxy.example.com:3128",
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("venafiProxyURL"), cc.VenafiProxyURL, "must be an http or https URL"),
+				}
+			},
+		},
+		{
+			"with an invalid venafi callback listen address",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:          1,
+				KubernetesAPIQPS:            1,
+				VenafiCallbackListenAddress: "localhost",
+				VenafiCallbackTokenFile:     "/etc/cert-manager/venafi-callback-token",
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("venafiCallbackListenAddress"), cc.VenafiCallbackListenAddress, "must be a host and port"),
+				}
+			},
+		},
+		{
+			"with a venafi callback listen address without a token file",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:          1,
+				KubernetesAPIQPS:            1,
+				VenafiCallbackListenAddress: "127.0.0.1:9443",
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Required(field.NewPath("venafiCallbackTokenFile"), "required when venafiCallbackListenAddress is set"),
+				}
+			},
+		},
+		{
+			"with a venafi callback listen address which is not loopback without TLS",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:          1,
+				KubernetesAPIQPS:            1,
+				VenafiCallbackListenAddress: ":9443",
+				VenafiCallbackTokenFile:     "/etc/cert-manager/venafi-callback-token",
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Required(field.NewPath("venafiCallbackTLSConfig"), "required unless venafiCallbackListenAddress is a loopback address"),
+				}
+			},
+		},
+		{
+			"with a venafi callback listen address which is not loopback with TLS",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:          1,
+				KubernetesAPIQPS:            1,
+				VenafiCallbackListenAddress: ":9443",
+				VenafiCallbackTokenFile:     "/etc/cert-manager/venafi-callback-token",
+				VenafiCallbackTLSConfig: shared.TLSConfig{
+					Filesystem: shared.FilesystemServingConfig{
+						CertFile: "/etc/cert-manager/venafi-callback/tls.crt",
+						KeyFile:  "/etc/cert-manager/venafi-callback/tls.key",
+					},
+				},
+			},
+			nil,
+		},
+		{
+			"with a venafi response log size which is too large",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:    1,
+				KubernetesAPIQPS:      1,
+				EnablePprof:           true,
+				VenafiResponseLogSize: 1001,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("venafiResponseLogSize"), cc.VenafiResponseLogSize, "must be between 0 and 1000"),
+				}
+			},
+		},
+		{
+			"with a venafi response log without profiling",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:    1,
+				KubernetesAPIQPS:      1,
+				VenafiResponseLogSize: 100,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("venafiResponseLogSize"), cc.VenafiResponseLogSize, "requires enablePprof, as the responses are served on the profiler address"),
+				}
+			},
+		},
+		{
+			"with negative certificate request event cooldown",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:              1,
+				KubernetesAPIQPS:                1,
+				CertificateRequestEventCooldown: -time.Minute,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("certificateRequestEventCooldown"), cc.CertificateRequestEventCooldown, "must not be negative"),
+				}
+			},
+		},
+		{
+			"with negative venafi error log interval",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:     1,
+				KubernetesAPIQPS:       1,
+				VenafiErrorLogInterval: -time.Minute,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("venafiErrorLogInterval"), cc.VenafiErrorLogInterval, "must not be negative"),
+				}
+			},
+		},
+		{
+			"with negative venafi zone cache ttl",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst: 1,
+				KubernetesAPIQPS:   1,
+				VenafiZoneCacheTTL: -time.Minute,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("venafiZoneCacheTTL"), cc.VenafiZoneCacheTTL, "must not be negative"),
+				}
+			},
+		},
+		{
+			"with negative shutdown grace period",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:  1,
+				KubernetesAPIQPS:    1,
+				ShutdownGracePeriod: -time.Second,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("shutdownGracePeriod"), cc.ShutdownGracePeriod, "must not be negative"),
+				}
+			},
+		},
+		{
+			"with negative venafi concurrent workers",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:      1,
+				KubernetesAPIQPS:        1,
+				VenafiConcurrentWorkers: -1,
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("venafiConcurrentWorkers"), cc.VenafiConcurrentWorkers, "must not be negative"),
+				}
+			},
+		},
+		{
+			"with invalid venafi validity hint extension oid",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:             1,
+				KubernetesAPIQPS:               1,
+				VenafiValidityHintExtensionOID: "not-an-oid",
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("venafiValidityHintExtensionOID"), cc.VenafiValidityHintExtensionOID, "must be a dotted OID"),
+				}
+			},
+		},
+		{
+			"with too long venafi field manager",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst: 1,
+				KubernetesAPIQPS:   1,
+				VenafiFieldManager: strings.Repeat("a", 129),
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.TooLong(field.NewPath("venafiFieldManager"), cc.VenafiFieldManager, 128),
+				}
+			},
+		},
+		{
+			"with invalid issuance audit webhook url",
+			&config.ControllerConfiguration{
+				Logging: logsapi.LoggingConfiguration{
+					Format: "text",
+				},
+				IngressShimConfig: config.IngressShimConfig{
+					DefaultIssuerKind: "Issuer",
+				},
+				KubernetesAPIBurst:      1,
+				KubernetesAPIQPS:        1,
+				IssuanceAuditWebhookURL: "ftp://audit.example.com/records",
+			},
+			func(cc *config.ControllerConfiguration) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(field.NewPath("issuanceAuditWebhookURL"), cc.IssuanceAuditWebhookURL, "must be an http or https URL"),
+				}
+			},
+		},
 		{
 			"with invalid kube-api-qps config",
 			&config.ControllerConfiguration{
@@ -82,6 +82,7 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.VenafiCallbackTLSConfig.DeepCopyInto(&out.VenafiCallbackTLSConfig)
 	in.MetricsTLSConfig.DeepCopyInto(&out.MetricsTLSConfig)
 	in.Logging.DeepCopyInto(&out.Logging)
 	if in.FeatureGates != nil {
@@ -70,4 +70,10 @@ type WebhookConfiguration struct {
 
 	// Metrics endpoint TLS config
 	MetricsTLSConfig shared.TLSConfig
+
+	// enableVenafiDurationValidation configures whether CertificateRequests
+	// requesting a longer duration than the `maxDuration` of their Venafi
+	// issuer are rejected. The webhook then watches Issuers and
+	// ClusterIssuers, so it must be allowed to list and watch them.
+	EnableVenafiDurationValidation bool
 }
@@ -23,6 +23,18 @@ import (
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 )
 
+// Convert_meta_ConfigMapKeySelector_To_v1_ConfigMapKeySelector is explicitly defined to avoid issues in conversion-gen
+// when referencing types in other API groups.
+func Convert_meta_ConfigMapKeySelector_To_v1_ConfigMapKeySelector(in *meta.ConfigMapKeySelector, out *cmmeta.ConfigMapKeySelector, s conversion.Scope) error {
+	return autoConvert_meta_ConfigMapKeySelector_To_v1_ConfigMapKeySelector(in, out, s)
+}
+
+// Convert_v1_ConfigMapKeySelector_To_meta_ConfigMapKeySelector is explicitly defined to avoid issues in conversion-gen
+// when referencing types in other API groups.
+func Convert_v1_ConfigMapKeySelector_To_meta_ConfigMapKeySelector(in *cmmeta.ConfigMapKeySelector, out *meta.ConfigMapKeySelector, s conversion.Scope) error {
+	return autoConvert_v1_ConfigMapKeySelector_To_meta_ConfigMapKeySelector(in, out, s)
+}
+
 // Convert_meta_LocalObjectReference_To_v1_LocalObjectReference is explicitly defined to avoid issues in conversion-gen
 // when referencing types in other API groups.
 func Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(in *meta.LocalObjectReference, out *cmmeta.LocalObjectReference, s conversion.Scope) error {
@@ -19,6 +19,7 @@ package policies
 import (
 	"bytes"
 	"cmp"
+	"crypto"
 	"crypto/x509"
 	"fmt"
 	"slices"
@@ -195,12 +196,24 @@ func SecretPublicKeyDiffersFromCurrentCertificateRequest(input Input) (string, s
 		return InvalidKeyPair, fmt.Sprintf("Issuing certificate as Secret contains invalid private key data: %v", err), true
 	}
 
-	csr, err := pki.DecodeX509CertificateRequestBytes(input.CurrentRevisionRequest.Spec.Request)
-	if err != nil {
-		return InvalidCertificateRequest, fmt.Sprintf("Failed to decode current CertificateRequest: %v", err), true
+	// If the private key was generated by the issuer, the Secret contains
+	// the key of the issued certificate rather than that of the request.
+	var publicKey crypto.PublicKey
+	if _, ok := input.CurrentRevisionRequest.Annotations[cmapi.CertificateRequestIssuedPrivateKeyAnnotationKey]; ok {
+		cert, err := pki.DecodeX509CertificateBytes(input.CurrentRevisionRequest.Status.Certificate)
+		if err != nil {
+			return InvalidCertificateRequest, fmt.Sprintf("Failed to decode the certificate of the current CertificateRequest: %v", err), true
+		}
+		publicKey = cert.PublicKey
+	} else {
+		csr, err := pki.DecodeX509CertificateRequestBytes(input.CurrentRevisionRequest.Spec.Request)
+		if err != nil {
+			return InvalidCertificateRequest, fmt.Sprintf("Failed to decode current CertificateRequest: %v", err), true
+		}
+		publicKey = csr.PublicKey
 	}
 
-	equal, err := pki.PublicKeysEqual(csr.PublicKey, pk.Public())
+	equal, err := pki.PublicKeysEqual(publicKey, pk.Public())
 	if err != nil {
 		return InvalidCertificateRequest, fmt.Sprintf("CertificateRequest's public key is invalid: %v", err), true
 	}
@@ -43,6 +43,9 @@ import (
 func Test_NewTriggerPolicyChain(t *testing.T) {
 	clock := &fakeclock.FakeClock{}
 	staticFixedPrivateKey := testcrypto.MustCreatePEMPrivateKey(t)
+	issuedCert := testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+		&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+	)
 	tests := map[string]struct {
 		// policy inputs
 		certificate *cmapi.Certificate
@@ -349,6 +352,51 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 				}}),
 			}},
 		},
+		"do nothing if the Secret contains the private key generated by the issuer for the CertificateRequest": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				CommonName: "example.com",
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				},
+			}},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something",
+					Annotations: map[string]string{
+						cmapi.IssuerNameAnnotationKey:  "testissuer",
+						cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+						cmapi.IssuerGroupAnnotationKey: "group.example.com",
+					},
+				},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey:       issuedCert,
+				},
+			},
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						cmapi.CertificateRequestIssuedPrivateKeyAnnotationKey: "something-issued-key",
+					},
+				},
+				Spec: cmapi.CertificateRequestSpec{
+					IssuerRef: cmmeta.ObjectReference{
+						Name:  "testissuer",
+						Kind:  "IssuerKind",
+						Group: "group.example.com",
+					},
+					// The CSR is signed by a key which was only used for the
+					// request.
+					Request: testcrypto.MustGenerateCSRImpl(t, testcrypto.MustCreatePEMPrivateKey(t), &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+						CommonName: "example.com",
+					}}),
+				},
+				Status: cmapi.CertificateRequestStatus{
+					Certificate: issuedCert,
+				},
+			},
+		},
 		"compare signed x509 certificate in Secret with spec if CertificateRequest does not exist": {
 			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
 				CommonName: "new.example.com",
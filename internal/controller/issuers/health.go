@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuers
+
+import (
+	"sync"
+
+	apitypes "k8s.io/apimachinery/pkg/types"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// ReadyFailureThreshold is the number of consecutive failures to set up an
+// issuer after which an issuer which is Ready is marked as not Ready. This
+// stops a single transient error from flapping the Ready condition.
+const ReadyFailureThreshold = 3
+
+// FailureTracker counts the consecutive failures to set up each issuer.
+type FailureTracker struct {
+	lock     sync.Mutex
+	failures map[apitypes.NamespacedName]int
+}
+
+func NewFailureTracker() *FailureTracker {
+	return &FailureTracker{
+		failures: make(map[apitypes.NamespacedName]int),
+	}
+}
+
+// Failed records a failure to set up the issuer with the given key, and
+// returns the number of consecutive failures for that issuer.
+func (f *FailureTracker) Failed(key apitypes.NamespacedName) int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.failures[key]++
+	return f.failures[key]
+}
+
+// Reset resets the consecutive failures of the issuer with the given key,
+// after it was set up successfully or has been deleted.
+func (f *FailureTracker) Reset(key apitypes.NamespacedName) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	delete(f.failures, key)
+}
+
+// KeepReadyCondition restores the Ready condition of the old issuer on the
+// new issuer if the old issuer was Ready for its current generation and fewer
+// than ReadyFailureThreshold consecutive failures have occurred. It returns
+// whether the condition was restored.
+// Changes to the issuer spec are always reflected immediately, because the
+// Ready condition then refers to an older generation.
+func KeepReadyCondition(oldIssuer, newIssuer cmapi.GenericIssuer, failures int) bool {
+	if failures >= ReadyFailureThreshold {
+		return false
+	}
+
+	var ready *cmapi.IssuerCondition
+	for _, cond := range oldIssuer.GetStatus().Conditions {
+		if cond.Type == cmapi.IssuerConditionReady {
+			ready = cond.DeepCopy()
+			break
+		}
+	}
+
+	if ready == nil || ready.Status != cmmeta.ConditionTrue || ready.ObservedGeneration != oldIssuer.GetGeneration() {
+		return false
+	}
+
+	status := newIssuer.GetStatus()
+	for i, cond := range status.Conditions {
+		if cond.Type == cmapi.IssuerConditionReady {
+			status.Conditions[i] = *ready
+			return true
+		}
+	}
+	status.Conditions = append(status.Conditions, *ready)
+	return true
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apitypes "k8s.io/apimachinery/pkg/types"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestFailureTracker(t *testing.T) {
+	keyA := apitypes.NamespacedName{Namespace: "ns", Name: "a"}
+	keyB := apitypes.NamespacedName{Namespace: "ns", Name: "b"}
+
+	f := NewFailureTracker()
+	assert.Equal(t, 1, f.Failed(keyA))
+	assert.Equal(t, 2, f.Failed(keyA))
+	assert.Equal(t, 1, f.Failed(keyB))
+
+	f.Reset(keyA)
+	assert.Equal(t, 1, f.Failed(keyA))
+	assert.Equal(t, 2, f.Failed(keyB))
+}
+
+func TestKeepReadyCondition(t *testing.T) {
+	readyCondition := cmapi.IssuerCondition{
+		Type:               cmapi.IssuerConditionReady,
+		Status:             cmmeta.ConditionTrue,
+		Reason:             "Reachable",
+		Message:            "issuer is ready",
+		ObservedGeneration: 2,
+	}
+	failedCondition := cmapi.IssuerCondition{
+		Type:               cmapi.IssuerConditionReady,
+		Status:             cmmeta.ConditionFalse,
+		Reason:             "Unreachable",
+		Message:            "issuer is not reachable",
+		ObservedGeneration: 2,
+	}
+
+	tests := map[string]struct {
+		oldIssuer *cmapi.Issuer
+		failures  int
+		expKept   bool
+	}{
+		"a single failure of a Ready issuer keeps the Ready condition": {
+			oldIssuer: gen.Issuer("a", gen.SetIssuerGeneration(2), gen.AddIssuerCondition(readyCondition)),
+			failures:  1,
+			expKept:   true,
+		},
+		"failures up to the threshold mark the issuer as not Ready": {
+			oldIssuer: gen.Issuer("a", gen.SetIssuerGeneration(2), gen.AddIssuerCondition(readyCondition)),
+			failures:  ReadyFailureThreshold,
+		},
+		"an issuer which was not Ready is marked as not Ready immediately": {
+			oldIssuer: gen.Issuer("a", gen.SetIssuerGeneration(2), gen.AddIssuerCondition(failedCondition)),
+			failures:  1,
+		},
+		"an issuer without a Ready condition is marked as not Ready immediately": {
+			oldIssuer: gen.Issuer("a", gen.SetIssuerGeneration(2)),
+			failures:  1,
+		},
+		"an issuer whose spec has changed is marked as not Ready immediately": {
+			oldIssuer: gen.Issuer("a", gen.SetIssuerGeneration(3), gen.AddIssuerCondition(readyCondition)),
+			failures:  1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			newIssuer := gen.IssuerFrom(test.oldIssuer)
+			newIssuer.Status.Conditions = []cmapi.IssuerCondition{failedCondition}
+
+			kept := KeepReadyCondition(test.oldIssuer, newIssuer, test.failures)
+			assert.Equal(t, test.expKept, kept)
+
+			expCondition := failedCondition
+			if test.expKept {
+				expCondition = readyCondition
+			}
+			assert.Equal(t, []cmapi.IssuerCondition{expCondition}, newIssuer.Status.Conditions)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// kubernetesAuthTokens caches the Vault tokens obtained using the Kubernetes
+// auth method, so that signing many certificates with the same issuer does not
+// require a Vault login for each of them.
+var kubernetesAuthTokens = newTokenCache(clock.RealClock{})
+
+// tokenCache holds Vault tokens keyed by issuer. Each token is stored along
+// with a fingerprint of the auth configuration it was obtained with, so that a
+// change to that configuration (e.g. the referenced secret is updated)
+// invalidates the token.
+type tokenCache struct {
+	clock clock.Clock
+
+	lock    sync.Mutex
+	entries map[string]cachedToken
+}
+
+type cachedToken struct {
+	fingerprint string
+	token       string
+
+	// refreshAt is the time after which the token should no longer be
+	// reused, which is before it actually expires so that a new token is
+	// obtained while the old one is still valid.
+	refreshAt time.Time
+	expiresAt time.Time
+}
+
+func newTokenCache(clock clock.Clock) *tokenCache {
+	return &tokenCache{
+		clock:   clock,
+		entries: make(map[string]cachedToken),
+	}
+}
+
+// get returns the token cached for the given key, along with its remaining
+// TTL. The boolean is false if there is no token for the key, if it was
+// obtained with a different fingerprint, or if it is due to be refreshed.
+func (c *tokenCache) get(key, fingerprint string) (string, time.Duration, bool) {
+	if c == nil {
+		return "", 0, false
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return "", 0, false
+	}
+
+	now := c.clock.Now()
+	if entry.fingerprint != fingerprint || !now.Before(entry.refreshAt) {
+		delete(c.entries, key)
+		return "", 0, false
+	}
+
+	return entry.token, entry.expiresAt.Sub(now), true
+}
+
+// set caches the token for the given key. Tokens are reused for two thirds
+// of their TTL. Tokens without a TTL are not cached.
+func (c *tokenCache) set(key, fingerprint, token string, ttl time.Duration) {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if ttl <= 0 {
+		delete(c.entries, key)
+		return
+	}
+
+	now := c.clock.Now()
+	c.entries[key] = cachedToken{
+		fingerprint: fingerprint,
+		token:       token,
+		refreshAt:   now.Add(ttl * 2 / 3),
+		expiresAt:   now.Add(ttl),
+	}
+}
+
+// invalidate removes the token cached for the given key, if any.
+func (c *tokenCache) invalidate(key string) {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.entries, key)
+}
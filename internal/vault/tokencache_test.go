@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+func TestTokenCache(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	c := newTokenCache(clock)
+
+	_, _, ok := c.get("ns/issuer", "fingerprint")
+	assert.False(t, ok, "expected no token before one is set")
+
+	c.set("ns/issuer", "fingerprint", "token", time.Hour)
+
+	token, remaining, ok := c.get("ns/issuer", "fingerprint")
+	assert.True(t, ok)
+	assert.Equal(t, "token", token)
+	assert.Equal(t, time.Hour, remaining)
+
+	_, _, ok = c.get("ns/other-issuer", "fingerprint")
+	assert.False(t, ok, "expected tokens to be cached per issuer")
+
+	clock.Step(time.Minute * 30)
+	token, remaining, ok = c.get("ns/issuer", "fingerprint")
+	assert.True(t, ok)
+	assert.Equal(t, "token", token)
+	assert.Equal(t, time.Minute*30, remaining)
+
+	// Tokens are refreshed once two thirds of their TTL has elapsed.
+	clock.Step(time.Minute * 10)
+	_, _, ok = c.get("ns/issuer", "fingerprint")
+	assert.False(t, ok, "expected the token to be refreshed")
+}
+
+func TestTokenCacheFingerprintChange(t *testing.T) {
+	c := newTokenCache(fakeclock.NewFakeClock(time.Now()))
+
+	c.set("ns/issuer", "fingerprint", "token", time.Hour)
+
+	_, _, ok := c.get("ns/issuer", "other-fingerprint")
+	assert.False(t, ok, "expected a different fingerprint to invalidate the token")
+
+	_, _, ok = c.get("ns/issuer", "fingerprint")
+	assert.False(t, ok, "expected the invalidated token to have been removed")
+}
+
+func TestTokenCacheInvalidate(t *testing.T) {
+	c := newTokenCache(fakeclock.NewFakeClock(time.Now()))
+
+	c.set("ns/issuer", "fingerprint", "token", time.Hour)
+	c.invalidate("ns/issuer")
+
+	_, _, ok := c.get("ns/issuer", "fingerprint")
+	assert.False(t, ok)
+}
+
+func TestTokenCacheNoTTL(t *testing.T) {
+	c := newTokenCache(fakeclock.NewFakeClock(time.Now()))
+
+	c.set("ns/issuer", "fingerprint", "token", 0)
+
+	_, _, ok := c.get("ns/issuer", "fingerprint")
+	assert.False(t, ok, "expected tokens without a TTL not to be cached")
+}
+
+func TestTokenCacheNil(t *testing.T) {
+	var c *tokenCache
+
+	c.set("ns/issuer", "fingerprint", "token", time.Hour)
+	c.invalidate("ns/issuer")
+
+	_, _, ok := c.get("ns/issuer", "fingerprint")
+	assert.False(t, ok)
+}
@@ -18,8 +18,10 @@ package vault
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/http"
@@ -38,6 +40,7 @@ import (
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
@@ -91,6 +94,10 @@ type Vault struct {
 	// header is provided
 	// See https://developer.hashicorp.com/vault/docs/enterprise/namespaces#root-only-api-paths
 	clientSys Client
+
+	// tokenCache is used to reuse Vault tokens obtained with the Kubernetes
+	// auth method across signings. A nil cache disables the reuse.
+	tokenCache *tokenCache
 }
 
 // New returns a new Vault instance with the given namespace, issuer and
@@ -103,6 +110,7 @@ func New(ctx context.Context, namespace string, createTokenFn func(ns string) Cr
 		secretsLister: secretsLister,
 		namespace:     namespace,
 		issuer:        issuer,
+		tokenCache:    kubernetesAuthTokens,
 	}
 
 	cfg, err := v.newConfig()
@@ -168,6 +176,12 @@ func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []b
 
 	resp, err := v.client.RawRequest(request)
 	if err != nil {
+		// The token may have been revoked before its TTL, make sure the
+		// next signing logs in again rather than reusing it.
+		var respErr *vault.ResponseError
+		if errors.As(err, &respErr) && respErr.StatusCode == http.StatusForbidden {
+			v.tokenCache.invalidate(v.tokenCacheKey())
+		}
 		return nil, nil, fmt.Errorf("failed to sign certificate by vault: %s", err)
 	}
 
@@ -225,7 +239,7 @@ func (v *Vault) setToken(ctx context.Context, client Client) error {
 
 	kubernetesAuth := v.issuer.GetSpec().Vault.Auth.Kubernetes
 	if kubernetesAuth != nil {
-		token, err := v.requestTokenWithKubernetesAuth(ctx, client, kubernetesAuth)
+		token, err := v.kubernetesAuthToken(ctx, client, kubernetesAuth)
 		if err != nil {
 			return fmt.Errorf("while requesting a Vault token using the Kubernetes auth: %w", err)
 		}
@@ -515,8 +529,58 @@ func (v *Vault) requestTokenWithClientCertificate(client Client, clientCertifica
 	return token, nil
 }
 
-func (v *Vault) requestTokenWithKubernetesAuth(ctx context.Context, client Client, kubernetesAuth *v1.VaultKubernetesAuth) (string, error) {
-	var jwt string
+// kubernetesAuthToken returns a Vault token obtained using the Kubernetes auth
+// method, reusing a cached token for the issuer when one is available.
+func (v *Vault) kubernetesAuthToken(ctx context.Context, client Client, kubernetesAuth *v1.VaultKubernetesAuth) (string, error) {
+	log := logf.FromContext(ctx)
+	key := v.tokenCacheKey()
+
+	// If the fingerprint cannot be computed, e.g. because the referenced
+	// secret is missing, skip the cache and let the login report the error.
+	fingerprint, err := v.kubernetesAuthFingerprint(kubernetesAuth)
+	if err == nil {
+		if token, remaining, ok := v.tokenCache.get(key, fingerprint); ok {
+			log.V(logf.DebugLevel).Info("reusing cached Vault token", "remainingTTL", remaining.Round(time.Second))
+			return token, nil
+		}
+	}
+
+	token, ttl, err := v.requestTokenWithKubernetesAuth(ctx, client, kubernetesAuth)
+	if err != nil {
+		return "", err
+	}
+
+	if fingerprint != "" {
+		v.tokenCache.set(key, fingerprint, token, ttl)
+		log.V(logf.DebugLevel).Info("obtained new Vault token", "remainingTTL", ttl)
+	}
+
+	return token, nil
+}
+
+// tokenCacheKey returns the key under which the Vault token of the issuer is
+// cached. ClusterIssuers have no namespace, so cannot collide with Issuers.
+func (v *Vault) tokenCacheKey() string {
+	return v.issuer.GetNamespace() + "/" + v.issuer.GetName()
+}
+
+// kubernetesAuthFingerprint returns a hash of everything that a token obtained
+// with the given Kubernetes auth depends on, including the contents of the
+// referenced secret, so that cached tokens are not reused after any of it
+// changes.
+func (v *Vault) kubernetesAuthFingerprint(kubernetesAuth *v1.VaultKubernetesAuth) (string, error) {
+	vaultIssuer := v.issuer.GetSpec().Vault
+
+	h := sha256.New()
+	write := func(values ...string) {
+		for _, value := range values {
+			h.Write([]byte(value))
+			h.Write([]byte{0})
+		}
+	}
+
+	write(vaultIssuer.Server, vaultIssuer.Namespace, kubernetesAuth.Path, kubernetesAuth.Role)
+
 	switch {
 	case kubernetesAuth.SecretRef.Name != "":
 		secret, err := v.secretsLister.Secrets(v.namespace).Get(kubernetesAuth.SecretRef.Name)
@@ -529,9 +593,32 @@ func (v *Vault) requestTokenWithKubernetesAuth(ctx context.Context, client Clien
 			key = v1.DefaultVaultTokenAuthSecretKey
 		}
 
+		write("secret", kubernetesAuth.SecretRef.Name, key, string(secret.Data[key]))
+	case kubernetesAuth.ServiceAccountRef != nil:
+		write("serviceaccount", kubernetesAuth.ServiceAccountRef.Name)
+		write(kubernetesAuth.ServiceAccountRef.TokenAudiences...)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (v *Vault) requestTokenWithKubernetesAuth(ctx context.Context, client Client, kubernetesAuth *v1.VaultKubernetesAuth) (string, time.Duration, error) {
+	var jwt string
+	switch {
+	case kubernetesAuth.SecretRef.Name != "":
+		secret, err := v.secretsLister.Secrets(v.namespace).Get(kubernetesAuth.SecretRef.Name)
+		if err != nil {
+			return "", 0, err
+		}
+
+		key := kubernetesAuth.SecretRef.Key
+		if key == "" {
+			key = v1.DefaultVaultTokenAuthSecretKey
+		}
+
 		keyBytes, ok := secret.Data[key]
 		if !ok {
-			return "", fmt.Errorf("no data for %q in secret '%s/%s'", key, v.namespace, kubernetesAuth.SecretRef.Name)
+			return "", 0, fmt.Errorf("no data for %q in secret '%s/%s'", key, v.namespace, kubernetesAuth.SecretRef.Name)
 		}
 
 		jwt = string(keyBytes)
@@ -571,12 +658,12 @@ func (v *Vault) requestTokenWithKubernetesAuth(ctx context.Context, client Clien
 			},
 		}, metav1.CreateOptions{})
 		if err != nil {
-			return "", fmt.Errorf("while requesting a token for the service account %s/%s: %s", v.issuer.GetNamespace(), kubernetesAuth.ServiceAccountRef.Name, err.Error())
+			return "", 0, fmt.Errorf("while requesting a token for the service account %s/%s: %s", v.issuer.GetNamespace(), kubernetesAuth.ServiceAccountRef.Name, err.Error())
 		}
 
 		jwt = tokenrequest.Status.Token
 	default:
-		return "", fmt.Errorf("programmer mistake: both serviceAccountRef and tokenRef.name are empty")
+		return "", 0, fmt.Errorf("programmer mistake: both serviceAccountRef and tokenRef.name are empty")
 	}
 
 	parameters := map[string]string{
@@ -593,27 +680,32 @@ func (v *Vault) requestTokenWithKubernetesAuth(ctx context.Context, client Clien
 	request := client.NewRequest("POST", url)
 	err := request.SetJSONBody(parameters)
 	if err != nil {
-		return "", fmt.Errorf("error encoding Vault parameters: %s", err.Error())
+		return "", 0, fmt.Errorf("error encoding Vault parameters: %s", err.Error())
 	}
 
 	resp, err := client.RawRequest(request)
 	if err != nil {
-		return "", fmt.Errorf("error calling Vault server: %s", err.Error())
+		return "", 0, fmt.Errorf("error calling Vault server: %s", err.Error())
 	}
 
 	defer resp.Body.Close()
 	vaultResult := vault.Secret{}
 	err = resp.DecodeJSON(&vaultResult)
 	if err != nil {
-		return "", fmt.Errorf("unable to decode JSON payload: %s", err.Error())
+		return "", 0, fmt.Errorf("unable to decode JSON payload: %s", err.Error())
 	}
 
 	token, err := vaultResult.TokenID()
 	if err != nil {
-		return "", fmt.Errorf("unable to read token: %s", err.Error())
+		return "", 0, fmt.Errorf("unable to read token: %s", err.Error())
 	}
 
-	return token, nil
+	ttl, err := vaultResult.TokenTTL()
+	if err != nil {
+		return "", 0, fmt.Errorf("unable to read token TTL: %s", err.Error())
+	}
+
+	return token, ttl, nil
 }
 
 func extractCertificatesFromVaultCertificateSecret(secret *certutil.Secret) ([]byte, []byte, error) {
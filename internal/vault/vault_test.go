@@ -40,6 +40,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientcorev1 "k8s.io/client-go/listers/core/v1"
+	fakeclock "k8s.io/utils/clock/testing"
 
 	vaultfake "github.com/cert-manager/cert-manager/internal/vault/fake"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -973,6 +974,62 @@ func TestSetToken(t *testing.T) {
 	}
 }
 
+func TestSetTokenKubernetesAuthCache(t *testing.T) {
+	kubeAuthSecret := &corev1.Secret{
+		Data: map[string][]byte{
+			"my-kube-key": []byte("my-secret-kube-token"),
+		},
+	}
+	lister := listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+		listers.SetFakeSecretNamespaceListerGet(kubeAuthSecret, nil),
+	)
+
+	logins := 0
+	fakeClient := vaultfake.NewFakeClient().WithRawRequestFn(func(t *testing.T, r *vault.Request) (*vault.Response, error) {
+		logins++
+		return &vault.Response{Response: &http.Response{Body: io.NopCloser(strings.NewReader(
+			fmt.Sprintf(`{"auth":{"client_token":"vault-token-%d","lease_duration":3600}}`, logins),
+		))}}, nil
+	})
+	fakeClient.T = t
+
+	v := &Vault{
+		namespace:     "test-namespace",
+		secretsLister: lister,
+		issuer: gen.Issuer("vault-issuer",
+			gen.SetIssuerNamespace("test-namespace"),
+			gen.SetIssuerVault(cmapi.VaultIssuer{
+				Auth: cmapi.VaultAuth{
+					Kubernetes: &cmapi.VaultKubernetesAuth{
+						Role: "kube-vault-role",
+						SecretRef: cmmeta.SecretKeySelector{
+							LocalObjectReference: cmmeta.LocalObjectReference{
+								Name: "secret-ref-name",
+							},
+							Key: "my-kube-key",
+						},
+					},
+				},
+			}),
+		),
+		tokenCache: newTokenCache(fakeclock.NewFakeClock(time.Now())),
+	}
+
+	require.NoError(t, v.setToken(context.TODO(), fakeClient))
+	assert.Equal(t, "vault-token-1", fakeClient.GotToken)
+
+	// The token is reused while the auth secret is unchanged.
+	require.NoError(t, v.setToken(context.TODO(), fakeClient))
+	assert.Equal(t, "vault-token-1", fakeClient.GotToken)
+	assert.Equal(t, 1, logins)
+
+	// Changing the auth secret invalidates the cached token.
+	kubeAuthSecret.Data["my-kube-key"] = []byte("my-rotated-kube-token")
+	require.NoError(t, v.setToken(context.TODO(), fakeClient))
+	assert.Equal(t, "vault-token-2", fakeClient.GotToken)
+	assert.Equal(t, 2, logins)
+}
+
 type testAppRoleRefT struct {
 	expectedRoleID   string
 	expectedSecretID string
@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafiduration
+
+// CertificateRequestVenafiDuration is a plugin that rejects CertificateRequests
+// for a Venafi Issuer or ClusterIssuer which request a longer duration than
+// the `spec.venafi.maxDuration` configured on the issuer.
+// The Venafi platform silently truncates the validity of such requests to the
+// maximum allowed by the zone, so rejecting them at admission time gives
+// immediate feedback to the requester.
+// The plugin is opt-in, as the issuers are read from informers which watch
+// all Issuers and ClusterIssuers, and the check is skipped for issuers
+// without a maxDuration.
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+)
+
+type certificateRequestVenafiDuration struct {
+	*admission.Handler
+
+	issuerLister        cmlisters.IssuerLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+	clock               clock.Clock
+}
+
+var _ admission.ValidationInterface = &certificateRequestVenafiDuration{}
+
+func NewPlugin(issuerLister cmlisters.IssuerLister, clusterIssuerLister cmlisters.ClusterIssuerLister) admission.Interface {
+	return &certificateRequestVenafiDuration{
+		Handler: admission.NewHandler(admissionv1.Create),
+
+		issuerLister:        issuerLister,
+		clusterIssuerLister: clusterIssuerLister,
+		clock:               clock.RealClock{},
+	}
+}
+
+func (p *certificateRequestVenafiDuration) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) ([]string, error) {
+	// Only run this admission plugin when CertificateRequest resources are
+	// created, which is the only time their duration can be set.
+	if request.RequestResource.Group != "cert-manager.io" ||
+		request.RequestResource.Resource != "certificaterequests" ||
+		request.RequestSubResource != "" ||
+		request.Operation != admissionv1.Create {
+		return nil, nil
+	}
+
+	cr, ok := obj.(*certmanager.CertificateRequest)
+	if !ok {
+		return nil, fmt.Errorf("internal error: object in admission request is not of type *certmanager.CertificateRequest")
+	}
+
+	duration, fldPath := requestedDuration(cr, p.clock.Now())
+	if duration == nil {
+		// The validity configured for the Venafi zone is used.
+		return nil, nil
+	}
+
+	issuerRef := cr.Spec.IssuerRef
+	if issuerRef.Group != "" && issuerRef.Group != "cert-manager.io" {
+		return nil, nil
+	}
+
+	var (
+		issuerObj cmapi.GenericIssuer
+		err       error
+	)
+	switch issuerRef.Kind {
+	case "", cmapi.IssuerKind:
+		issuerObj, err = p.issuerLister.Issuers(cr.Namespace).Get(issuerRef.Name)
+	case cmapi.ClusterIssuerKind:
+		issuerObj, err = p.clusterIssuerLister.Get(issuerRef.Name)
+	default:
+		return nil, nil
+	}
+	if apierrors.IsNotFound(err) {
+		// The issuer may be created after the CertificateRequest, or not be
+		// synced yet, in which case its maximum duration cannot be checked
+		// here.
+		return nil, nil
+	}
+	if err != nil {
+		// Do not block the creation of CertificateRequests if the issuer
+		// cannot be read, the check is only there to give early feedback.
+		return []string{fmt.Sprintf("unable to check the requested duration against the maximum duration of issuer %q: %v", issuerRef.Name, err)}, nil
+	}
+
+	venafi := issuerObj.GetSpec().Venafi
+	if venafi == nil || venafi.MaxDuration == nil {
+		return nil, nil
+	}
+
+	if *duration > venafi.MaxDuration.Duration {
+		return nil, field.ErrorList{
+			field.Forbidden(fldPath, fmt.Sprintf("the requested validity of %s exceeds the maximum duration of %s allowed by the Venafi zone of issuer %q",
+				duration.Round(time.Second), venafi.MaxDuration.Duration, issuerRef.Name)),
+		}.ToAggregate()
+	}
+
+	return nil, nil
+}
+
+// requestedDuration returns the validity requested by the CertificateRequest,
+// and the path of the field it was requested with. Nil is returned if no
+// validity is requested.
+func requestedDuration(cr *certmanager.CertificateRequest, now time.Time) (*time.Duration, *field.Path) {
+	fldPath := field.NewPath("spec")
+
+	switch {
+	case cr.Spec.Duration != nil:
+		return &cr.Spec.Duration.Duration, fldPath.Child("duration")
+	case cr.Spec.NotAfter != nil:
+		duration := cr.Spec.NotAfter.Sub(now)
+		return &duration, fldPath.Child("notAfter")
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafiduration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	"github.com/cert-manager/cert-manager/internal/apis/meta"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// failingIssuerLister fails to read any Issuer.
+type failingIssuerLister struct {
+	cmlisters.IssuerLister
+	err error
+}
+
+func (l *failingIssuerLister) Issuers(string) cmlisters.IssuerNamespaceLister {
+	return &failingIssuerNamespaceLister{err: l.err}
+}
+
+type failingIssuerNamespaceLister struct {
+	cmlisters.IssuerNamespaceLister
+	err error
+}
+
+func (l *failingIssuerNamespaceLister) Get(string) (*cmapi.Issuer, error) {
+	return nil, l.err
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Now()
+
+	createRequest := admissionv1.AdmissionRequest{
+		Operation: admissionv1.Create,
+		RequestResource: &metav1.GroupVersionResource{
+			Group:    "cert-manager.io",
+			Resource: "certificaterequests",
+		},
+	}
+
+	venafiIssuer := gen.Issuer("venafi",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+			Zone:        "zone",
+			MaxDuration: &metav1.Duration{Duration: time.Hour * 24 * 30},
+		}),
+	)
+	venafiClusterIssuer := gen.ClusterIssuer("venafi",
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+			Zone:        "zone",
+			MaxDuration: &metav1.Duration{Duration: time.Hour * 24 * 7},
+		}),
+	)
+	unlimitedVenafiIssuer := gen.Issuer("unlimited",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "zone"}),
+	)
+
+	cr := func(kind string, name string, duration *metav1.Duration, notAfter *metav1.Time) *certmanager.CertificateRequest {
+		return &certmanager.CertificateRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "testns"},
+			Spec: certmanager.CertificateRequestSpec{
+				IssuerRef: meta.ObjectReference{Name: name, Kind: kind},
+				Duration:  duration,
+				NotAfter:  notAfter,
+			},
+		}
+	}
+	days := func(n int) *metav1.Duration {
+		return &metav1.Duration{Duration: time.Hour * 24 * time.Duration(n)}
+	}
+
+	tests := map[string]struct {
+		req       admissionv1.AdmissionRequest
+		cr        *certmanager.CertificateRequest
+		objects   []runtime.Object
+		getErr    error
+		expErr    string
+		expWarned bool
+	}{
+		"requests for other resources are ignored": {
+			req: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				RequestResource: &metav1.GroupVersionResource{
+					Group:    "cert-manager.io",
+					Resource: "certificates",
+				},
+			},
+			cr:      cr("Issuer", "venafi", days(90), nil),
+			objects: []runtime.Object{venafiIssuer},
+		},
+		"duration within the maximum duration is allowed": {
+			req:     createRequest,
+			cr:      cr("Issuer", "venafi", days(30), nil),
+			objects: []runtime.Object{venafiIssuer},
+		},
+		"duration exceeding the maximum duration is rejected": {
+			req:     createRequest,
+			cr:      cr("Issuer", "venafi", days(90), nil),
+			objects: []runtime.Object{venafiIssuer},
+			expErr:  `spec.duration: Forbidden: the requested validity of 2160h0m0s exceeds the maximum duration of 720h0m0s allowed by the Venafi zone of issuer "venafi"`,
+		},
+		"issuer kind defaults to Issuer": {
+			req:     createRequest,
+			cr:      cr("", "venafi", days(90), nil),
+			objects: []runtime.Object{venafiIssuer},
+			expErr:  `spec.duration: Forbidden: the requested validity of 2160h0m0s exceeds the maximum duration of 720h0m0s allowed by the Venafi zone of issuer "venafi"`,
+		},
+		"notAfter time exceeding the maximum duration of a ClusterIssuer is rejected": {
+			req:     createRequest,
+			cr:      cr("ClusterIssuer", "venafi", nil, &metav1.Time{Time: now.Add(time.Hour * 24 * 8)}),
+			objects: []runtime.Object{venafiClusterIssuer},
+			expErr:  `spec.notAfter: Forbidden: the requested validity of 192h0m0s exceeds the maximum duration of 168h0m0s allowed by the Venafi zone of issuer "venafi"`,
+		},
+		"no requested duration is allowed": {
+			req:     createRequest,
+			cr:      cr("Issuer", "venafi", nil, nil),
+			objects: []runtime.Object{venafiIssuer},
+		},
+		"issuer without a maximum duration is allowed": {
+			req:     createRequest,
+			cr:      cr("Issuer", "unlimited", days(365), nil),
+			objects: []runtime.Object{unlimitedVenafiIssuer},
+		},
+		"issuer which does not exist is allowed": {
+			req: createRequest,
+			cr:  cr("Issuer", "venafi", days(365), nil),
+		},
+		"external issuers are ignored": {
+			req: createRequest,
+			cr: &certmanager.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns"},
+				Spec: certmanager.CertificateRequestSpec{
+					IssuerRef: meta.ObjectReference{Name: "venafi", Kind: "Issuer", Group: "example.io"},
+					Duration:  days(365),
+				},
+			},
+			objects: []runtime.Object{venafiIssuer},
+		},
+		"error reading the issuer is allowed with a warning": {
+			req:       createRequest,
+			cr:        cr("Issuer", "venafi", days(365), nil),
+			getErr:    errors.New("connection refused"),
+			expWarned: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuers := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			clusterIssuers := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, obj := range test.objects {
+				switch obj.(type) {
+				case *cmapi.Issuer:
+					if err := issuers.Add(obj); err != nil {
+						t.Fatal(err)
+					}
+				case *cmapi.ClusterIssuer:
+					if err := clusterIssuers.Add(obj); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			var issuerLister cmlisters.IssuerLister = cmlisters.NewIssuerLister(issuers)
+			if test.getErr != nil {
+				issuerLister = &failingIssuerLister{err: test.getErr}
+			}
+
+			p := NewPlugin(issuerLister, cmlisters.NewClusterIssuerLister(clusterIssuers)).(*certificateRequestVenafiDuration)
+			p.clock = fakeclock.NewFakeClock(now)
+
+			warnings, err := p.Validate(context.Background(), test.req, nil, test.cr)
+			switch {
+			case test.expErr == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case test.expErr != "" && (err == nil || err.Error() != test.expErr):
+				t.Errorf("unexpected error, exp=%q got=%v", test.expErr, err)
+			}
+			if test.expWarned != (len(warnings) > 0) {
+				t.Errorf("unexpected warnings: %v", warnings)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package webhook
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	crlog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	acmeinstall "github.com/cert-manager/cert-manager/internal/apis/acme/install"
 	cminstall "github.com/cert-manager/cert-manager/internal/apis/certmanager/install"
@@ -36,7 +38,10 @@ import (
 	metainstall "github.com/cert-manager/cert-manager/internal/apis/meta/install"
 	crapproval "github.com/cert-manager/cert-manager/internal/webhook/admission/certificaterequest/approval"
 	cridentity "github.com/cert-manager/cert-manager/internal/webhook/admission/certificaterequest/identity"
+	crvenafiduration "github.com/cert-manager/cert-manager/internal/webhook/admission/certificaterequest/venafiduration"
 	"github.com/cert-manager/cert-manager/internal/webhook/admission/resourcevalidation"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/server/tls"
 	"github.com/cert-manager/cert-manager/pkg/server/tls/authority"
@@ -60,7 +65,32 @@ func NewCertManagerWebhookServer(log logr.Logger, opts config.WebhookConfigurati
 	}
 
 	// Set up the admission chain
-	admissionHandler, err := buildAdmissionChain(cl)
+	var (
+		plugins   []admission.Interface
+		runnables []manager.Runnable
+	)
+	if opts.EnableVenafiDurationValidation {
+		cmcl, err := cmclient.NewForConfig(restcfg)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cert-manager client: %s", err)
+		}
+
+		// The issuers are read from informers, so that CertificateRequests
+		// are not delayed by reading their issuer from the API server.
+		factory := cminformers.NewSharedInformerFactory(cmcl, 0)
+		plugins = append(plugins, crvenafiduration.NewPlugin(
+			factory.Certmanager().V1().Issuers().Lister(),
+			factory.Certmanager().V1().ClusterIssuers().Lister(),
+		))
+		runnables = append(runnables, manager.RunnableFunc(func(ctx context.Context) error {
+			factory.Start(ctx.Done())
+			<-ctx.Done()
+			factory.Shutdown()
+			return nil
+		}))
+	}
+
+	admissionHandler, err := buildAdmissionChain(cl, plugins...)
 	if err != nil {
 		return nil, err
 	}
@@ -85,6 +115,7 @@ func NewCertManagerWebhookServer(log logr.Logger, opts config.WebhookConfigurati
 		MetricsCertificateSource: buildCertificateSource(log, opts.MetricsTLSConfig, restcfg),
 		MetricsCipherSuites:      opts.MetricsTLSConfig.CipherSuites,
 		MetricsMinTLSVersion:     opts.MetricsTLSConfig.MinTLSVersion,
+		Runnables:                runnables,
 	}
 	for _, fn := range optionFunctions {
 		fn(s)
@@ -92,7 +123,9 @@ func NewCertManagerWebhookServer(log logr.Logger, opts config.WebhookConfigurati
 	return s, nil
 }
 
-func buildAdmissionChain(client kubernetes.Interface) (admission.PluginChain, error) {
+// buildAdmissionChain builds the admission chain of the webhook, with the
+// given optional plugins run before the resource validation.
+func buildAdmissionChain(client kubernetes.Interface, optionalPlugins ...admission.Interface) (admission.PluginChain, error) {
 	authorizer, err := authorizerfactory.DelegatingAuthorizerConfig{
 		SubjectAccessReviewClient: client.AuthorizationV1(),
 		// cache responses for 1 second
@@ -110,11 +143,13 @@ func buildAdmissionChain(client kubernetes.Interface) (admission.PluginChain, er
 		return nil, fmt.Errorf("error creating authorization handler: %v", err)
 	}
 
-	pluginChain := admission.PluginChain([]admission.Interface{
+	plugins := []admission.Interface{
 		cridentity.NewPlugin(),
 		crapproval.NewPlugin(authorizer, client.Discovery()),
-		resourcevalidation.NewPlugin(),
-	})
+	}
+	plugins = append(plugins, optionalPlugins...)
+	plugins = append(plugins, resourcevalidation.NewPlugin())
+	pluginChain := admission.PluginChain(plugins)
 
 	return pluginChain, nil
 }
@@ -163,8 +163,24 @@ const (
 	// issuer type to self-sign certificates.
 	CertificateRequestPrivateKeyAnnotationKey = "cert-manager.io/private-key-secret-name"
 
+	// Annotation added to CertificateRequest resources whose private key was
+	// generated by the issuer, rather than being the key used to sign the
+	// CSR, to denote the name of the Secret resource containing the private
+	// key returned by the issuer. The Secret is owned by the
+	// CertificateRequest, and its key is stored with the issued certificate.
+	CertificateRequestIssuedPrivateKeyAnnotationKey = "cert-manager.io/issued-private-key-secret-name"
+
 	// Annotation to declare the CertificateRequest "revision", belonging to a Certificate Resource
 	CertificateRequestRevisionAnnotationKey = "cert-manager.io/certificate-revision"
+
+	// CertificateRequestSignatureHashAnnotationKey is the annotation key which
+	// can be set on a CertificateRequest to request that the certificate is
+	// signed with the given hash algorithm, for example because downstream
+	// systems require SHA-384 signatures. Valid values are "SHA256", "SHA384"
+	// and "SHA512". The request is failed if the issuer cannot sign the
+	// certificate with the requested hash algorithm. It is supported by the CA
+	// and Venafi issuers.
+	CertificateRequestSignatureHashAnnotationKey = "cert-manager.io/signature-hash-algorithm"
 )
 
 const (
@@ -214,13 +230,149 @@ const (
 	// VenafiCustomFieldsAnnotationKey is the annotation that passes on JSON encoded custom fields to the Venafi issuer
 	// This will only work with Venafi TPP v19.3 and higher
 	// The value is an array with objects containing the name and value keys
-	// for example: `[{"name": "custom-field", "value": "custom-value"}]`
+	// for example: `[{"name": "custom-field", "value": "custom-value"}]`,
+	// or an object mapping names to values for plain custom fields
+	// for example: `{"custom-field": "custom-value"}`
 	VenafiCustomFieldsAnnotationKey = "venafi.cert-manager.io/custom-fields"
 
 	// VenafiPickupIDAnnotationKey is the annotation key used to record the
 	// Venafi Pickup ID of a certificate signing request that has been submitted
 	// to the Venafi API for collection later.
 	VenafiPickupIDAnnotationKey = "venafi.cert-manager.io/pickup-id"
+
+	// VenafiRetryCountAnnotationKey is the annotation key used to record the
+	// number of attempts made to retrieve a certificate which is pending
+	// issuance on the Venafi platform.
+	VenafiRetryCountAnnotationKey = "venafi.cert-manager.io/retry-count"
+
+	// VenafiNextRetryTimeAnnotationKey is the annotation key used to record
+	// the time, in RFC3339 format, after which the next attempt to retrieve a
+	// pending certificate from the Venafi platform will be made.
+	VenafiNextRetryTimeAnnotationKey = "venafi.cert-manager.io/next-retry-time"
+
+	// VenafiDryRunAnnotationKey is the annotation key which, when set to "true"
+	// on a CertificateRequest, causes the Venafi issuer to only validate the
+	// request against the zone policy. No certificate will be requested from
+	// the Venafi platform and the CertificateRequest will not be issued.
+	VenafiDryRunAnnotationKey = "venafi.cert-manager.io/dry-run"
+
+	// VenafiZoneOverrideAnnotationKey is the annotation key used to request a
+	// certificate from a different Venafi zone than the one configured on the
+	// issuer, for example to select a different Venafi Cloud issuing template.
+	// Only the zones listed in the allowedZoneOverrides of the issuer may be
+	// selected.
+	VenafiZoneOverrideAnnotationKey = "venafi.cert-manager.io/zone-override"
+
+	// VenafiFriendlyNameAnnotationKey is the annotation key used to set the
+	// friendly name of the certificate requested from the Venafi platform,
+	// which is the name of the certificate object created in TPP. If not set,
+	// the common name of the request is used, falling back to its first SAN.
+	VenafiFriendlyNameAnnotationKey = "venafi.cert-manager.io/friendly-name"
+
+	// VenafiInstanceAnnotationKey is the annotation key used to set the name
+	// of the instance using the certificate, for example a host or node name,
+	// which Venafi Cloud records for usage metering. Only supported by Venafi
+	// Cloud issuers.
+	VenafiInstanceAnnotationKey = "venafi.cert-manager.io/instance"
+
+	// VenafiWorkloadAnnotationKey is the annotation key used to set the name
+	// of the application using the certificate, which Venafi Cloud records for
+	// usage metering and ownership. Only supported by Venafi Cloud issuers.
+	VenafiWorkloadAnnotationKey = "venafi.cert-manager.io/workload"
+
+	// VenafiTPPDevicePathAnnotationKey is the annotation key used to set the
+	// path of the TPP device and application object the certificate is
+	// installed on, for example `\VED\Policy\Devices\web-1\nginx`. The
+	// last two components of the path are the names of the device and the
+	// application, and the preceding ones the policy folder of the device,
+	// which defaults to the folder of the zone. The objects are created if
+	// they do not exist yet. Only supported by TPP issuers.
+	VenafiTPPDevicePathAnnotationKey = "venafi.cert-manager.io/tpp-device-path"
+
+	// VenafiDebugLoggingAnnotationKey is the annotation key which, when set to
+	// "true" on a Venafi Issuer or ClusterIssuer, causes the requests signed by
+	// that issuer to be logged at trace verbosity, including the calls made to
+	// the Venafi platform and the resolved requests sent to it, regardless of
+	// the configured log level. Credentials are never logged.
+	VenafiDebugLoggingAnnotationKey = "venafi.cert-manager.io/debug-logging"
+
+	// VenafiZoneAnnotationKey is the annotation key used to record the Venafi
+	// zone a CertificateRequest was enrolled in, taking any zone override
+	// into account.
+	VenafiZoneAnnotationKey = "venafi.cert-manager.io/zone"
+
+	// VenafiConnectorTypeAnnotationKey is the annotation key used to record
+	// the type of Venafi platform a CertificateRequest was enrolled with.
+	// The value is either "TPP" or "Cloud".
+	VenafiConnectorTypeAnnotationKey = "venafi.cert-manager.io/connector-type"
+
+	// VenafiEnrollmentHashAnnotationKey is the annotation key used to record
+	// a hash of the CSR and issuer of a CertificateRequest along with its
+	// pickup ID. A pickup ID recorded for a different hash, for example on a
+	// CertificateRequest copied from another one, is ignored.
+	VenafiEnrollmentHashAnnotationKey = "venafi.cert-manager.io/enrollment-hash"
+
+	// VenafiCredentialsAnnotationKey is the annotation key used to record the
+	// name of the object containing the credentials a CertificateRequest was
+	// last signed with, which may be one of the fallback credentials of its
+	// Venafi issuer.
+	VenafiCredentialsAnnotationKey = "venafi.cert-manager.io/credentials"
+
+	// VenafiPriorityAnnotationKey is the annotation key which can be set on a
+	// CertificateRequest, or on a Certificate to be copied to its
+	// CertificateRequests, to change the order in which the Venafi issuer
+	// processes it when there is a backlog. Valid values are "High" and
+	// "Low"; without the annotation, or with any other value, the request
+	// has normal priority. Requests with a higher priority are processed
+	// first, but a request which has been waiting the longest is only passed
+	// over a few times in a row, so low priority requests are delayed rather
+	// than starved. Retries keep their usual backoff.
+	VenafiPriorityAnnotationKey = "venafi.cert-manager.io/priority"
+
+	// IssuerChainOrderAnnotationKey is the annotation key which can be set on
+	// an Issuer or ClusterIssuer to reorder the certificate chains it returns
+	// so that they start with the leaf certificate, followed by each
+	// intermediate in order. Valid values are "LeafFirst" and
+	// "LeafFirstWithoutRoot", the latter also removing the root certificate
+	// from the chain if the issuer returned it.
+	IssuerChainOrderAnnotationKey = "cert-manager.io/chain-order"
+)
+
+const (
+	// ChainOrderLeafFirst orders a chain from the leaf certificate up to the
+	// root certificate, if present.
+	ChainOrderLeafFirst = "LeafFirst"
+
+	// ChainOrderLeafFirstWithoutRoot orders a chain from the leaf certificate
+	// up to the highest intermediate certificate, dropping the root.
+	ChainOrderLeafFirstWithoutRoot = "LeafFirstWithoutRoot"
+)
+
+const (
+	// VenafiPriorityHigh processes a CertificateRequest ahead of the
+	// requests of normal and low priority.
+	VenafiPriorityHigh = "High"
+
+	// VenafiPriorityLow processes a CertificateRequest after the requests of
+	// normal and high priority.
+	VenafiPriorityLow = "Low"
+)
+
+const (
+	// VenafiConnectorTypeTPP is the connector type of issuers using Venafi
+	// Trust Protection Platform.
+	VenafiConnectorTypeTPP = "TPP"
+
+	// VenafiConnectorTypeCloud is the connector type of issuers using Venafi
+	// Cloud.
+	VenafiConnectorTypeCloud = "Cloud"
+)
+
+const (
+	// VenafiRevocationFinalizer is the finalizer added to Certificates whose
+	// issuer revokes certificates on delete, so that the issued certificate
+	// can be revoked in the Venafi platform before the Certificate is removed.
+	VenafiRevocationFinalizer = "venafi.cert-manager.io/revoke-on-delete"
 )
 
 // KeyUsage specifies valid usage contexts for keys.
@@ -100,6 +100,153 @@ type ControllerConfiguration struct {
 	// The maximum number of challenges that can be scheduled as 'processing' at once.
 	MaxConcurrentChallenges *int32 `json:"maxConcurrentChallenges,omitempty"`
 
+	// The maximum time the controllers are given to finish processing the
+	// items in flight when the controller manager shuts down, for example so
+	// that the pickup IDs of Venafi certificates being requested are persisted
+	// rather than orphaning their enrollments. No new items are processed once
+	// the shutdown has started. Items still being processed after the grace
+	// period are cancelled. A value of 0 cancels them immediately.
+	ShutdownGracePeriod *sharedv1alpha1.Duration `json:"shutdownGracePeriod,omitempty"`
+
+	// The maximum number of CertificateRequests that can be signed at once by
+	// each Venafi issuer. Further requests wait until a signing completes.
+	VenafiMaxConcurrentSignings *int32 `json:"venafiMaxConcurrentSignings,omitempty"`
+
+	// The number of concurrent workers of the Venafi CertificateRequest and
+	// CertificateSigningRequest controllers. If 0, the number of concurrent
+	// workers for each controller is used. Each worker signing a request for
+	// an issuer which has reached the venafi max concurrent signings waits
+	// for a signing slot, so this bounds the signings in flight across all
+	// Venafi issuers while the latter bounds them per issuer.
+	VenafiConcurrentWorkers *int32 `json:"venafiConcurrentWorkers,omitempty"`
+
+	// How often each Issuer and ClusterIssuer is set up again to verify that
+	// it can reach its backend. A value of 0 disables periodic checks, in
+	// which case issuers are only checked when they change.
+	IssuerHealthCheckInterval *sharedv1alpha1.Duration `json:"issuerHealthCheckInterval,omitempty"`
+
+	// The maximum time to wait for each call to the Venafi platform when
+	// signing a CertificateRequest. Calls which take longer are abandoned so
+	// that an unresponsive Venafi platform does not block controller workers.
+	// A value of 0 disables the timeout.
+	VenafiRequestTimeout *sharedv1alpha1.Duration `json:"venafiRequestTimeout,omitempty"`
+
+	// The maximum time a single sync of a Venafi CertificateRequest may spend
+	// building the Venafi client and calling the Venafi platform. The budget
+	// is shared by all the steps of the sync, so that the time a worker is
+	// occupied is bounded regardless of which step is slow. Syncs which exceed
+	// it are retried later. A value of 0 disables the budget.
+	VenafiReconcileTimeout *sharedv1alpha1.Duration `json:"venafiReconcileTimeout,omitempty"`
+
+	// If set, every Venafi Issuer and ClusterIssuer is set up when the
+	// controller starts, before any Venafi CertificateRequest is signed, so
+	// that misconfigured issuers are reported immediately rather than by the
+	// first CertificateRequest. Issuers which have not been set up within the
+	// timeout are left to the issuers controllers. A value of 0 disables the
+	// warm up.
+	VenafiIssuerWarmUpTimeout *sharedv1alpha1.Duration `json:"venafiIssuerWarmUpTimeout,omitempty"`
+
+	// The maximum time for which retrieving a certificate from the Venafi
+	// platform is retried after unexpected errors, such as during an outage
+	// of the Venafi platform, before the CertificateRequest is failed. Retries
+	// are made with an exponential backoff. A value of 0 retries indefinitely.
+	VenafiRetrieveFailureTimeout *sharedv1alpha1.Duration `json:"venafiRetrieveFailureTimeout,omitempty"`
+
+	// The number of consecutive failures to reach the Venafi platform after
+	// which the circuit breaker of the issuer opens, so that signings for the
+	// issuer are failed fast rather than adding load to the Venafi platform
+	// during an outage. A value of 0 disables the circuit breaker.
+	VenafiCircuitBreakerThreshold *int32 `json:"venafiCircuitBreakerThreshold,omitempty"`
+
+	// How long the circuit breaker of a Venafi issuer stays open before a
+	// single signing is let through to probe whether the Venafi platform is
+	// reachable again.
+	VenafiCircuitBreakerOpenDuration *sharedv1alpha1.Duration `json:"venafiCircuitBreakerOpenDuration,omitempty"`
+
+	// The period during which identical consecutive events for a
+	// CertificateRequest are suppressed. An event is always recorded when its
+	// reason or message changes. A value of 0 disables the suppression.
+	CertificateRequestEventCooldown *sharedv1alpha1.Duration `json:"certificateRequestEventCooldown,omitempty"`
+
+	// The minimum interval between logging identical errors encountered
+	// while signing the same CertificateRequest with a Venafi issuer. The
+	// first occurrence of an error is always logged. A value of 0 logs every
+	// error.
+	VenafiErrorLogInterval *sharedv1alpha1.Duration `json:"venafiErrorLogInterval,omitempty"`
+
+	// How long the zone configuration read from the Venafi platform is cached
+	// for each issuer and zone, so that bursts of CertificateRequests do not
+	// each read the zone configuration. The cache is invalidated when the
+	// issuer spec changes. A value of 0 disables the cache.
+	VenafiZoneCacheTTL *sharedv1alpha1.Duration `json:"venafiZoneCacheTTL,omitempty"`
+
+	// Whether the Venafi CertificateRequest controller caches the issuers it
+	// reads from the informer cache, so that bursts of CertificateRequests
+	// referencing the same issuer read it once until it is next updated. The
+	// cached issuers are invalidated by the informer events of the issuers.
+	EnableVenafiIssuerCache *bool `json:"enableVenafiIssuerCache,omitempty"`
+
+	// Dotted OID of a CSR extension from which the validity requested for
+	// Venafi certificates is read, for clients which can only encode the
+	// requested validity in the CSR. The extension value must be a DER encoded
+	// INTEGER number of seconds. The validity is only used if the
+	// CertificateRequest does not request a notAfter time, and a conflicting
+	// duration requested by the CertificateRequest takes precedence. If
+	// empty, the CSR is not inspected.
+	VenafiValidityHintExtensionOID string `json:"venafiValidityHintExtensionOID,omitempty"`
+
+	// The field manager name used by the Venafi issuer when updating
+	// CertificateRequests, so that the changes it makes can be attributed to
+	// it in the managed fields of the resources. Defaults to
+	// `cert-manager-venafi`.
+	VenafiFieldManager string `json:"venafiFieldManager,omitempty"`
+
+	// URL of the HTTP proxy through which the Venafi issuers send requests to
+	// the Venafi platform, for example "http://proxy.example.com:3128". If
+	// empty, the proxy configured by the HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+	// environment variables is used.
+	VenafiProxyURL string `json:"venafiProxyURL,omitempty"`
+
+	// The host and port that the Venafi callback endpoint should listen on.
+	// When the Venafi platform calls the endpoint once a certificate has been
+	// issued, the CertificateRequests pending issuance with its pickup ID are
+	// synced immediately rather than at the next poll. Requests must carry the
+	// token read from VenafiCallbackTokenFile as a bearer token. If empty, the
+	// endpoint is disabled.
+	VenafiCallbackListenAddress string `json:"venafiCallbackListenAddress,omitempty"`
+
+	// Path of a file containing the shared token that calls to the Venafi
+	// callback endpoint must carry as a bearer token. Required if
+	// VenafiCallbackListenAddress is set.
+	VenafiCallbackTokenFile string `json:"venafiCallbackTokenFile,omitempty"`
+
+	// TLS config for the Venafi callback endpoint. Without TLS the bearer
+	// token of callback requests is sent in cleartext, so TLS is required
+	// unless VenafiCallbackListenAddress is a loopback address.
+	VenafiCallbackTLSConfig sharedv1alpha1.TLSConfig `json:"venafiCallbackTLSConfig"`
+
+	// The number of raw responses of the Venafi platform to enrollment and
+	// retrieval requests kept in memory with their secrets redacted, to
+	// debug unexpected behaviour of the Venafi platform. The most recent
+	// responses are served at /debug/venafi/responses on the profiler
+	// address, so profiling must be enabled. Each response body is truncated
+	// to 16KiB, older responses are discarded once the limit is reached, and
+	// the responses are lost when the controller restarts. A value of 0
+	// disables the log.
+	VenafiResponseLogSize *int32 `json:"venafiResponseLogSize,omitempty"`
+
+	// Path of a file to which a record of every certificate issued is
+	// appended as a line of JSON, to keep an audit trail of issuance separate
+	// from the controller logs. If empty, no records are kept.
+	IssuanceAuditLogFile string `json:"issuanceAuditLogFile,omitempty"`
+
+	// URL of a webhook to which a record of every certificate issued is sent
+	// as JSON in the body of a POST request, in addition to the issuance
+	// audit log file if both are set. Records are buffered and retried in the
+	// background, so that issuance is never blocked by the webhook. If empty,
+	// no records are sent.
+	IssuanceAuditWebhookURL string `json:"issuanceAuditWebhookURL,omitempty"`
+
 	// The host and port that the metrics endpoint should listen on.
 	MetricsListenAddress string `json:"metricsListenAddress,omitempty"`
 
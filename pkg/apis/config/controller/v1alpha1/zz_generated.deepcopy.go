@@ -138,6 +138,82 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ShutdownGracePeriod != nil {
+		in, out := &in.ShutdownGracePeriod, &out.ShutdownGracePeriod
+		*out = new(sharedv1alpha1.Duration)
+		**out = **in
+	}
+	if in.VenafiMaxConcurrentSignings != nil {
+		in, out := &in.VenafiMaxConcurrentSignings, &out.VenafiMaxConcurrentSignings
+		*out = new(int32)
+		**out = **in
+	}
+	if in.VenafiConcurrentWorkers != nil {
+		in, out := &in.VenafiConcurrentWorkers, &out.VenafiConcurrentWorkers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IssuerHealthCheckInterval != nil {
+		in, out := &in.IssuerHealthCheckInterval, &out.IssuerHealthCheckInterval
+		*out = new(sharedv1alpha1.Duration)
+		**out = **in
+	}
+	if in.VenafiRequestTimeout != nil {
+		in, out := &in.VenafiRequestTimeout, &out.VenafiRequestTimeout
+		*out = new(sharedv1alpha1.Duration)
+		**out = **in
+	}
+	if in.VenafiReconcileTimeout != nil {
+		in, out := &in.VenafiReconcileTimeout, &out.VenafiReconcileTimeout
+		*out = new(sharedv1alpha1.Duration)
+		**out = **in
+	}
+	if in.VenafiIssuerWarmUpTimeout != nil {
+		in, out := &in.VenafiIssuerWarmUpTimeout, &out.VenafiIssuerWarmUpTimeout
+		*out = new(sharedv1alpha1.Duration)
+		**out = **in
+	}
+	if in.VenafiRetrieveFailureTimeout != nil {
+		in, out := &in.VenafiRetrieveFailureTimeout, &out.VenafiRetrieveFailureTimeout
+		*out = new(sharedv1alpha1.Duration)
+		**out = **in
+	}
+	if in.VenafiCircuitBreakerThreshold != nil {
+		in, out := &in.VenafiCircuitBreakerThreshold, &out.VenafiCircuitBreakerThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.VenafiCircuitBreakerOpenDuration != nil {
+		in, out := &in.VenafiCircuitBreakerOpenDuration, &out.VenafiCircuitBreakerOpenDuration
+		*out = new(sharedv1alpha1.Duration)
+		**out = **in
+	}
+	if in.CertificateRequestEventCooldown != nil {
+		in, out := &in.CertificateRequestEventCooldown, &out.CertificateRequestEventCooldown
+		*out = new(sharedv1alpha1.Duration)
+		**out = **in
+	}
+	if in.VenafiErrorLogInterval != nil {
+		in, out := &in.VenafiErrorLogInterval, &out.VenafiErrorLogInterval
+		*out = new(sharedv1alpha1.Duration)
+		**out = **in
+	}
+	if in.VenafiZoneCacheTTL != nil {
+		in, out := &in.VenafiZoneCacheTTL, &out.VenafiZoneCacheTTL
+		*out = new(sharedv1alpha1.Duration)
+		**out = **in
+	}
+	if in.EnableVenafiIssuerCache != nil {
+		in, out := &in.EnableVenafiIssuerCache, &out.EnableVenafiIssuerCache
+		*out = new(bool)
+		**out = **in
+	}
+	in.VenafiCallbackTLSConfig.DeepCopyInto(&out.VenafiCallbackTLSConfig)
+	if in.VenafiResponseLogSize != nil {
+		in, out := &in.VenafiResponseLogSize, &out.VenafiResponseLogSize
+		*out = new(int32)
+		**out = **in
+	}
 	in.MetricsTLSConfig.DeepCopyInto(&out.MetricsTLSConfig)
 	if in.EnablePprof != nil {
 		in, out := &in.EnablePprof, &out.EnablePprof
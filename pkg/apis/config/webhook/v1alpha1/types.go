@@ -72,4 +72,11 @@ type WebhookConfiguration struct {
 
 	// metricsTLSConfig is used to configure the metrics server TLS settings.
 	MetricsTLSConfig sharedv1alpha1.TLSConfig `json:"metricsTLSConfig"`
+
+	// enableVenafiDurationValidation configures whether CertificateRequests
+	// requesting a longer duration than the `maxDuration` of their Venafi
+	// issuer are rejected. The webhook then watches Issuers and
+	// ClusterIssuers, so it must be allowed to list and watch them.
+	// +optional
+	EnableVenafiDurationValidation bool `json:"enableVenafiDurationValidation,omitempty"`
 }
@@ -180,6 +180,14 @@ func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (er
 
 	if !ch.Status.Presented {
 		err := solver.Present(ctx, genericIssuer, ch)
+		if errors.Is(err, dnsutil.ErrCNAMELoop) {
+			// A CNAME loop will not resolve itself by retrying, so fail the
+			// challenge instead of presenting it again.
+			ch.Status.State = cmacme.Errored
+			ch.Status.Reason = fmt.Sprintf("Error presenting challenge: %v", err)
+			c.recorder.Eventf(ch, corev1.EventTypeWarning, reasonFailed, "Error presenting challenge: %v", err)
+			return nil
+		}
 		if err != nil {
 			c.recorder.Eventf(ch, corev1.EventTypeWarning, reasonPresentError, "Error presenting challenge: %v", err)
 			ch.Status.Reason = err.Error()
@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+const (
+	// IssuanceAuditResultIssued is the result of an issuance audit record for
+	// a certificate which was issued.
+	IssuanceAuditResultIssued = "Issued"
+)
+
+// IssuanceAuditRecord is a structured record of a certificate issued by an
+// issuer, which is sent to the IssuanceAuditSink.
+type IssuanceAuditRecord struct {
+	// Time is the time at which the certificate was issued.
+	Time time.Time `json:"time"`
+
+	// Issuer is the issuer which issued the certificate.
+	Issuer cmmeta.ObjectReference `json:"issuer"`
+
+	// Namespace and Name of the CertificateRequest the certificate was
+	// issued for.
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	// CommonName and subject alternative names of the issued certificate.
+	CommonName     string   `json:"commonName,omitempty"`
+	DNSNames       []string `json:"dnsNames,omitempty"`
+	IPAddresses    []string `json:"ipAddresses,omitempty"`
+	URIs           []string `json:"uris,omitempty"`
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+
+	// NotAfter is the time at which the issued certificate expires.
+	NotAfter time.Time `json:"notAfter"`
+
+	// Result is the result of the issuance.
+	Result string `json:"result"`
+}
+
+// NewIssuanceAuditRecord returns the IssuanceAuditRecord of the given
+// certificate, issued at the given time for the CertificateRequest.
+func NewIssuanceAuditRecord(now time.Time, cr *cmapi.CertificateRequest, crt *x509.Certificate, result string) IssuanceAuditRecord {
+	record := IssuanceAuditRecord{
+		Time:           now.UTC(),
+		Issuer:         cr.Spec.IssuerRef,
+		Namespace:      cr.Namespace,
+		Name:           cr.Name,
+		CommonName:     crt.Subject.CommonName,
+		DNSNames:       crt.DNSNames,
+		EmailAddresses: crt.EmailAddresses,
+		NotAfter:       crt.NotAfter.UTC(),
+		Result:         result,
+	}
+
+	for _, ip := range crt.IPAddresses {
+		record.IPAddresses = append(record.IPAddresses, ip.String())
+	}
+	for _, uri := range crt.URIs {
+		record.URIs = append(record.URIs, uri.String())
+	}
+
+	return record
+}
+
+// IssuanceAuditSink receives a record of every certificate issued, so that an
+// audit trail of issuance can be kept separately from the controller logs.
+type IssuanceAuditSink interface {
+	// RecordIssuance records the given issuance. Implementations must be safe
+	// for concurrent use.
+	RecordIssuance(record IssuanceAuditRecord) error
+}
+
+// jsonLinesIssuanceAuditSink writes each record as a line of JSON.
+type jsonLinesIssuanceAuditSink struct {
+	lock    sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewJSONLinesIssuanceAuditSink returns an IssuanceAuditSink which writes each
+// record to the given writer as a single line of JSON.
+func NewJSONLinesIssuanceAuditSink(w io.Writer) IssuanceAuditSink {
+	return &jsonLinesIssuanceAuditSink{encoder: json.NewEncoder(w)}
+}
+
+func (s *jsonLinesIssuanceAuditSink) RecordIssuance(record IssuanceAuditRecord) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.encoder.Encode(record)
+}
+
+// multiIssuanceAuditSink records each record in all of its sinks.
+type multiIssuanceAuditSink []IssuanceAuditSink
+
+// NewMultiIssuanceAuditSink returns an IssuanceAuditSink which records each
+// record in all of the given sinks, so that the audit trail can be kept in
+// more than one destination. A record is passed to every sink even if some
+// of them fail to record it, and the errors of all the sinks are returned.
+func NewMultiIssuanceAuditSink(sinks ...IssuanceAuditSink) IssuanceAuditSink {
+	return multiIssuanceAuditSink(sinks)
+}
+
+func (s multiIssuanceAuditSink) RecordIssuance(record IssuanceAuditRecord) error {
+	var errs []error
+	for _, sink := range s {
+		if err := sink.RecordIssuance(record); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestJSONLinesIssuanceAuditSink(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	cr := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestNamespace("test-ns"),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "test-issuer", Kind: "Issuer", Group: "cert-manager.io"}),
+	)
+	crt := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "example.com"},
+		DNSNames:    []string{"example.com", "www.example.com"},
+		IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+		URIs:        []*url.URL{{Scheme: "spiffe", Host: "example.com", Path: "/workload"}},
+		NotAfter:    now.Add(time.Hour),
+	}
+
+	var buf bytes.Buffer
+	sink := NewJSONLinesIssuanceAuditSink(&buf)
+
+	record := NewIssuanceAuditRecord(now, cr, crt, IssuanceAuditResultIssued)
+	require.NoError(t, sink.RecordIssuance(record))
+	require.NoError(t, sink.RecordIssuance(record))
+
+	line := `{"time":"2024-01-02T03:04:05Z","issuer":{"name":"test-issuer","kind":"Issuer","group":"cert-manager.io"},` +
+		`"namespace":"test-ns","name":"test-cr","commonName":"example.com","dnsNames":["example.com","www.example.com"],` +
+		`"ipAddresses":["10.0.0.1"],"uris":["spiffe://example.com/workload"],"notAfter":"2024-01-02T04:04:05Z","result":"Issued"}` + "\n"
+	assert.Equal(t, line+line, buf.String())
+}
+
+type fakeIssuanceAuditSink struct {
+	err     error
+	records []IssuanceAuditRecord
+}
+
+func (s *fakeIssuanceAuditSink) RecordIssuance(record IssuanceAuditRecord) error {
+	s.records = append(s.records, record)
+	return s.err
+}
+
+func TestMultiIssuanceAuditSink(t *testing.T) {
+	failing := &fakeIssuanceAuditSink{err: errors.New("this is an error")}
+	first := &fakeIssuanceAuditSink{}
+	last := &fakeIssuanceAuditSink{}
+	sink := NewMultiIssuanceAuditSink(first, failing, last)
+
+	record := IssuanceAuditRecord{Namespace: "test-ns", Name: "test-cr", Result: IssuanceAuditResultIssued}
+	err := sink.RecordIssuance(record)
+
+	// A failing sink does not prevent the other sinks from recording.
+	assert.EqualError(t, err, "this is an error")
+	for _, s := range []*fakeIssuanceAuditSink{first, failing, last} {
+		assert.Equal(t, []IssuanceAuditRecord{record}, s.records)
+	}
+
+	assert.NoError(t, NewMultiIssuanceAuditSink(first, last).RecordIssuance(record))
+}
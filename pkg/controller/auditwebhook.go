@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	defaultIssuanceAuditWebhookBufferSize     = 1000
+	defaultIssuanceAuditWebhookMaxAttempts    = 5
+	defaultIssuanceAuditWebhookInitialBackoff = time.Second
+	defaultIssuanceAuditWebhookMaxBackoff     = 30 * time.Second
+	defaultIssuanceAuditWebhookTimeout        = 10 * time.Second
+)
+
+// IssuanceAuditWebhookOptions configures the buffering and retries of an
+// IssuanceAuditSink which sends the records to a webhook. Fields which are
+// zero are defaulted.
+type IssuanceAuditWebhookOptions struct {
+	// Client is the HTTP client used to send the records. Defaults to a
+	// client with a timeout of 10 seconds.
+	Client *http.Client
+
+	// BufferSize is the number of records which may be waiting to be sent.
+	// Records are dropped while the buffer is full. Defaults to 1000.
+	BufferSize int
+
+	// MaxAttempts is the number of times sending a record is attempted
+	// before it is dropped. Defaults to 5.
+	MaxAttempts int
+
+	// InitialBackoff is the time waited before the first retry, which is
+	// doubled for each further retry up to MaxBackoff. They default to 1 and
+	// 30 seconds.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// webhookIssuanceAuditSink sends each record to a webhook from a buffer, so
+// that issuance is never blocked by the webhook.
+type webhookIssuanceAuditSink struct {
+	url     string
+	log     logr.Logger
+	options IssuanceAuditWebhookOptions
+	records chan IssuanceAuditRecord
+}
+
+// NewWebhookIssuanceAuditSink returns an IssuanceAuditSink which sends each
+// record as JSON in the body of a POST request to the given URL. Records are
+// buffered and sent in the background until ctx is cancelled, so recording
+// an issuance never waits for the webhook. Records which cannot be sent
+// after the configured number of attempts, or which do not fit in the
+// buffer, are dropped and logged.
+func NewWebhookIssuanceAuditSink(ctx context.Context, url string, options IssuanceAuditWebhookOptions) IssuanceAuditSink {
+	if options.Client == nil {
+		options.Client = &http.Client{Timeout: defaultIssuanceAuditWebhookTimeout}
+	}
+	if options.BufferSize <= 0 {
+		options.BufferSize = defaultIssuanceAuditWebhookBufferSize
+	}
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = defaultIssuanceAuditWebhookMaxAttempts
+	}
+	if options.InitialBackoff <= 0 {
+		options.InitialBackoff = defaultIssuanceAuditWebhookInitialBackoff
+	}
+	if options.MaxBackoff <= 0 {
+		options.MaxBackoff = defaultIssuanceAuditWebhookMaxBackoff
+	}
+
+	s := &webhookIssuanceAuditSink{
+		url:     url,
+		log:     logf.FromContext(ctx, "issuance-audit-webhook"),
+		options: options,
+		records: make(chan IssuanceAuditRecord, options.BufferSize),
+	}
+	go s.run(ctx)
+
+	return s
+}
+
+// RecordIssuance queues the record to be sent to the webhook. An error is
+// only returned if the buffer is full, in which case the record is dropped.
+func (s *webhookIssuanceAuditSink) RecordIssuance(record IssuanceAuditRecord) error {
+	select {
+	case s.records <- record:
+		return nil
+	default:
+		return fmt.Errorf("the issuance audit webhook buffer of %d records is full, dropping the record", s.options.BufferSize)
+	}
+}
+
+// run sends the buffered records in order until ctx is cancelled. The
+// records still buffered at that point are not sent.
+func (s *webhookIssuanceAuditSink) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			if dropped := len(s.records); dropped > 0 {
+				s.log.Error(ctx.Err(), "dropping the issuance audit records which have not been sent to the webhook", "records", dropped)
+			}
+			return
+		case record := <-s.records:
+			if err := s.send(ctx, record); err != nil && ctx.Err() == nil {
+				s.log.Error(err, "failed to send the issuance audit record to the webhook, dropping the record",
+					"namespace", record.Namespace, "name", record.Name)
+			}
+		}
+	}
+}
+
+// send sends the record to the webhook, retrying with a backoff until it is
+// accepted, the webhook rejects it, or the attempts are exhausted.
+func (s *webhookIssuanceAuditSink) send(ctx context.Context, record IssuanceAuditRecord) error {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	backoff := s.options.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		retry, err := s.post(ctx, body)
+		if err == nil || !retry || attempt >= s.options.MaxAttempts {
+			return err
+		}
+
+		s.log.V(logf.DebugLevel).Info("retrying to send the issuance audit record to the webhook",
+			"attempt", attempt, "backoff", backoff, "error", err.Error())
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		backoff = min(2*backoff, s.options.MaxBackoff)
+	}
+}
+
+// post makes a single attempt to send the record, and returns whether a
+// failure should be retried. Server errors and rate limiting are retried,
+// other rejections of the record are not.
+func (s *webhookIssuanceAuditSink) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.options.Client.Do(req)
+	if err != nil {
+		return !errors.Is(err, context.Canceled), err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("the issuance audit webhook responded with status %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("the issuance audit webhook rejected the record with status %d", resp.StatusCode)
+	}
+}
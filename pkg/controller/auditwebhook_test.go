@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookIssuanceAuditSink(t *testing.T) {
+	tests := map[string]struct {
+		// statuses are the statuses the webhook responds with, in order,
+		// after which it responds with 200.
+		statuses []int
+
+		expRequests int32
+		expReceived bool
+	}{
+		"the record is sent": {
+			expRequests: 1,
+			expReceived: true,
+		},
+		"server errors and rate limiting are retried": {
+			statuses:    []int{http.StatusServiceUnavailable, http.StatusTooManyRequests},
+			expRequests: 3,
+			expReceived: true,
+		},
+		"the record is dropped once the attempts are exhausted": {
+			statuses:    []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			expRequests: 3,
+		},
+		"records rejected by the webhook are not retried": {
+			statuses:    []int{http.StatusBadRequest},
+			expRequests: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests atomic.Int32
+			received := make(chan IssuanceAuditRecord, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(requests.Add(1))
+				if n <= len(test.statuses) {
+					w.WriteHeader(test.statuses[n-1])
+					return
+				}
+
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+				var record IssuanceAuditRecord
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&record))
+				received <- record
+			}))
+			defer server.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			sink := NewWebhookIssuanceAuditSink(ctx, server.URL, IssuanceAuditWebhookOptions{
+				MaxAttempts:    3,
+				InitialBackoff: time.Millisecond,
+			})
+
+			record := IssuanceAuditRecord{Namespace: "test-ns", Name: "test-cr", Result: IssuanceAuditResultIssued}
+			require.NoError(t, sink.RecordIssuance(record))
+
+			if test.expReceived {
+				select {
+				case got := <-received:
+					assert.Equal(t, record.Name, got.Name)
+				case <-time.After(5 * time.Second):
+					t.Fatal("expected the record to be sent")
+				}
+			} else {
+				assert.Eventually(t, func() bool {
+					return requests.Load() >= test.expRequests
+				}, 5*time.Second, time.Millisecond)
+				// Leave time for any unexpected retry.
+				time.Sleep(50 * time.Millisecond)
+			}
+
+			assert.Equal(t, test.expRequests, requests.Load())
+		})
+	}
+}
+
+func TestWebhookIssuanceAuditSinkBufferFull(t *testing.T) {
+	blocked := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-blocked
+	}))
+	defer server.Close()
+	defer close(blocked)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sink := NewWebhookIssuanceAuditSink(ctx, server.URL, IssuanceAuditWebhookOptions{BufferSize: 1})
+
+	// Recording never blocks on the webhook: the first record is being sent
+	// and the second is buffered, so the third is dropped.
+	record := IssuanceAuditRecord{Namespace: "test-ns", Name: "test-cr", Result: IssuanceAuditResultIssued}
+	require.NoError(t, sink.RecordIssuance(record))
+	assert.Eventually(t, func() bool {
+		return sink.RecordIssuance(record) == nil
+	}, 5*time.Second, time.Millisecond)
+	assert.EqualError(t, sink.RecordIssuance(record), "the issuance audit webhook buffer of 1 records is full, dropping the record")
+}
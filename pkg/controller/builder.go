@@ -78,5 +78,17 @@ func (b *Builder) Complete() (Interface, error) {
 		return nil, fmt.Errorf("error registering controller: %v", err)
 	}
 
-	return NewController(b.name, controllerctx.Metrics, b.impl.ProcessItem, mustSync, b.runDurationFuncs, queue), nil
+	ctrl := newController(b.name, controllerctx.Metrics, b.impl.ProcessItem, mustSync, b.runDurationFuncs, queue)
+	ctrl.workers = controllerctx.ConcurrentWorkers[b.name]
+	ctrl.shutdownGracePeriod = controllerctx.ShutdownGracePeriod
+	if r, ok := b.impl.(runningController); ok {
+		for _, f := range r.Runnables() {
+			ctrl.runnables = append(ctrl.runnables, f)
+		}
+	}
+	if w, ok := b.impl.(warmingUpController); ok {
+		ctrl.runFirstFuncs = append(ctrl.runFirstFuncs, w.WarmUp)
+	}
+
+	return ctrl, nil
 }
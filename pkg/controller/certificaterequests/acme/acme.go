@@ -104,7 +104,7 @@ func NewACME(ctx *controllerpkg.Context) certificaterequests.Issuer {
 		issuerOptions: ctx.IssuerOptions,
 		orderLister:   ctx.SharedInformerFactory.Acme().V1().Orders().Lister(),
 		acmeClientV:   ctx.CMClient.AcmeV1(),
-		reporter:      crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		reporter:      crutil.NewReporter(ctx.Clock, ctx.Recorder, ctx.IssuerOptions.CertificateRequestEventCooldown),
 		fieldManager:  ctx.FieldManager,
 	}
 }
@@ -123,7 +123,7 @@ func (a *ACME) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cm
 	if err != nil {
 		message := "Failed to decode CSR in spec.request"
 
-		a.reporter.Failed(cr, err, "RequestParsingError", message)
+		a.reporter.Failed(cr, err, crutil.ReasonRequestParsingError, message)
 		log.Error(err, message)
 
 		return nil, nil
@@ -134,7 +134,7 @@ func (a *ACME) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cm
 		err = fmt.Errorf("%q does not exist in %s or %s", csr.Subject.CommonName, csr.DNSNames, pki.IPAddressesToString(csr.IPAddresses))
 		message := "The CSR PEM requests a commonName that is not present in the list of dnsNames or ipAddresses. If a commonName is set, ACME requires that the value is also present in the list of dnsNames or ipAddresses"
 
-		a.reporter.Failed(cr, err, "InvalidOrder", message)
+		a.reporter.Failed(cr, err, crutil.ReasonInvalidOrder, message)
 
 		log.V(logf.DebugLevel).Info(fmt.Sprintf("%s: %s", message, err))
 
@@ -146,7 +146,7 @@ func (a *ACME) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cm
 	if err != nil {
 		message := "Failed to build order"
 
-		a.reporter.Failed(cr, err, "OrderBuildingError", message)
+		a.reporter.Failed(cr, err, crutil.ReasonOrderBuildingError, message)
 		log.Error(err, message)
 
 		return nil, nil
@@ -160,7 +160,7 @@ func (a *ACME) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cm
 		if err != nil {
 			message := fmt.Sprintf("Failed create new order resource %s/%s", expectedOrder.Namespace, expectedOrder.Name)
 
-			a.reporter.Pending(cr, err, "OrderCreatingError", message)
+			a.reporter.Pending(cr, err, crutil.ReasonOrderCreatingError, message)
 			log.Error(err, message)
 
 			return nil, err
@@ -168,7 +168,7 @@ func (a *ACME) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cm
 
 		message := fmt.Sprintf("Created Order resource %s/%s",
 			expectedOrder.Namespace, expectedOrder.Name)
-		a.reporter.Pending(cr, nil, "OrderCreated", message)
+		a.reporter.Pending(cr, nil, crutil.ReasonOrderCreated, message)
 		log.V(logf.DebugLevel).Info(message)
 
 		return nil, nil
@@ -177,7 +177,7 @@ func (a *ACME) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cm
 		// We are probably in a network error here so we should backoff and retry
 		message := fmt.Sprintf("Failed to get order resource %s/%s", expectedOrder.Namespace, expectedOrder.Name)
 
-		a.reporter.Pending(cr, err, "OrderGetError", message)
+		a.reporter.Pending(cr, err, crutil.ReasonOrderGetError, message)
 		log.Error(err, message)
 
 		return nil, err
@@ -198,13 +198,13 @@ func (a *ACME) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cm
 	if acme.IsFailureState(order.Status.State) {
 		message := fmt.Sprintf("Failed to wait for order resource %q to become ready", expectedOrder.Name)
 		err := fmt.Errorf("order is in %q state: %s", order.Status.State, order.Status.Reason)
-		a.reporter.Failed(cr, err, "OrderFailed", message)
+		a.reporter.Failed(cr, err, crutil.ReasonOrderFailed, message)
 		return nil, nil
 	}
 
 	if order.Status.State != cmacme.Valid {
 		// We update here to just pending while we wait for the order to be resolved.
-		a.reporter.Pending(cr, nil, "OrderPending",
+		a.reporter.Pending(cr, nil, crutil.ReasonOrderPending,
 			fmt.Sprintf("Waiting on certificate issuance from order %s/%s: %q",
 				expectedOrder.Namespace, order.Name, order.Status.State))
 
@@ -214,7 +214,7 @@ func (a *ACME) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cm
 	}
 
 	if len(order.Status.Certificate) == 0 {
-		a.reporter.Pending(cr, nil, "OrderPending",
+		a.reporter.Pending(cr, nil, crutil.ReasonOrderPending,
 			fmt.Sprintf("Waiting for order-controller to add certificate data to Order %s/%s",
 				expectedOrder.Namespace, order.Name))
 
@@ -68,7 +68,7 @@ func NewCA(ctx *controllerpkg.Context) certificaterequests.Issuer {
 	return &CA{
 		issuerOptions:     ctx.IssuerOptions,
 		secretsLister:     ctx.KubeSharedInformerFactory.Secrets().Lister(),
-		reporter:          crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		reporter:          crutil.NewReporter(ctx.Clock, ctx.Recorder, ctx.IssuerOptions.CertificateRequestEventCooldown),
 		templateGenerator: pki.CertificateTemplateFromCertificateRequest,
 		signingFn:         pki.SignCSRTemplate,
 	}
@@ -80,6 +80,15 @@ func NewCA(ctx *controllerpkg.Context) certificaterequests.Issuer {
 func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) (*issuerpkg.IssueResponse, error) {
 	log := logf.FromContext(ctx, "sign")
 
+	if err := crutil.VerifyKeyPolicy(cr, issuerObj.GetSpec()); err != nil {
+		message := "The key of the request is not allowed by the key policy of the issuer"
+
+		c.reporter.Failed(cr, err, crutil.ReasonWeakKey, message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
+
 	secretName := issuerObj.GetSpec().CA.SecretName
 	resourceNamespace := c.issuerOptions.ResourceNamespace(issuerObj)
 
@@ -88,7 +97,7 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 	if k8sErrors.IsNotFound(err) {
 		message := fmt.Sprintf("Referenced secret %s/%s not found", resourceNamespace, secretName)
 
-		c.reporter.Pending(cr, err, "SecretMissing", message)
+		c.reporter.Pending(cr, err, crutil.ReasonSecretMissing, message)
 		log.Error(err, message)
 
 		return nil, nil
@@ -97,7 +106,7 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 	if cmerrors.IsInvalidData(err) {
 		message := fmt.Sprintf("Failed to parse signing CA keypair from secret %s/%s", resourceNamespace, secretName)
 
-		c.reporter.Pending(cr, err, "SecretInvalidData", message)
+		c.reporter.Pending(cr, err, crutil.ReasonSecretInvalidData, message)
 		log.Error(err, message)
 		return nil, nil
 	}
@@ -105,7 +114,7 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 	if err != nil {
 		// We are probably in a network error here so we should backoff and retry
 		message := fmt.Sprintf("Failed to get certificate key pair from secret %s/%s", resourceNamespace, secretName)
-		c.reporter.Pending(cr, err, "SecretGetError", message)
+		c.reporter.Pending(cr, err, crutil.ReasonSecretGetError, message)
 		log.Error(err, message)
 		return nil, err
 	}
@@ -113,19 +122,59 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 	template, err := c.templateGenerator(cr)
 	if err != nil {
 		message := "Error generating certificate template"
-		c.reporter.Failed(cr, err, "SigningError", message)
+		c.reporter.Failed(cr, err, crutil.ReasonSigningError, message)
 		log.Error(err, message)
 		return nil, nil
 	}
 
+	if !cr.Spec.IsCA {
+		maxPathLen, err := requestedMaxPathLen(cr.Spec.Request)
+		if err == nil && maxPathLen != nil {
+			err = fmt.Errorf("the request has a path length constraint of %d but isCA is not set", *maxPathLen)
+		}
+		if err != nil {
+			message := "Path length constraints may only be requested for CA certificates"
+			c.reporter.Failed(cr, err, crutil.ReasonInvalidPathLen, message)
+			log.Error(err, message)
+			return nil, nil
+		}
+	}
+
 	template.CRLDistributionPoints = issuerObj.GetSpec().CA.CRLDistributionPoints
 	template.OCSPServer = issuerObj.GetSpec().CA.OCSPServers
 	template.IssuingCertificateURL = issuerObj.GetSpec().CA.IssuingCertificateURLs
 
+	if maxPathLen := issuerObj.GetSpec().CA.MaxPathLen; maxPathLen != nil && template.IsCA {
+		template.MaxPathLen = int(*maxPathLen)
+		template.MaxPathLenZero = *maxPathLen == 0
+	}
+
+	if issuerObj.GetSpec().CA.LegacyExtensions {
+		if err := pki.AddNetscapeCertType(template); err != nil {
+			message := "Error adding legacy extensions to certificate template"
+			c.reporter.Failed(cr, err, crutil.ReasonSigningError, message)
+			log.Error(err, message)
+			return nil, nil
+		}
+	}
+
+	// The certificate is signed with the hash algorithm chosen by the CA key,
+	// unless the request asks for a specific one.
+	hash, err := crutil.SignatureHash(cr.Annotations)
+	if err == nil && hash != 0 {
+		template.SignatureAlgorithm, err = pki.SignatureAlgorithmWithHash(caKey.Public(), hash)
+	}
+	if err != nil {
+		message := "The requested signature hash algorithm is not supported by the CA"
+		c.reporter.Failed(cr, err, crutil.ReasonUnsupportedSignatureAlgorithm, message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
 	bundle, err := c.signingFn(caCerts, caKey, template)
 	if err != nil {
 		message := "Error signing certificate"
-		c.reporter.Failed(cr, err, "SigningError", message)
+		c.reporter.Failed(cr, err, crutil.ReasonSigningError, message)
 		log.Error(err, message)
 		return nil, err
 	}
@@ -137,3 +186,21 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 		CA:          bundle.CAPEM,
 	}, nil
 }
+
+// requestedMaxPathLen returns the path length constraint encoded in the basic
+// constraints extension of the given CSR, or nil if there is none.
+func requestedMaxPathLen(csrPEM []byte) (*int, error) {
+	csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ext := range csr.Extensions {
+		if ext.Id.Equal(pki.OIDExtensionBasicConstraints) {
+			_, maxPathLen, err := pki.UnmarshalBasicConstraints(ext.Value)
+			return maxPathLen, err
+		}
+	}
+
+	return nil, nil
+}
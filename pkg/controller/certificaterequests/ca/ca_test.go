@@ -37,6 +37,7 @@ import (
 	clientcorev1 "k8s.io/client-go/listers/core/v1"
 	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
@@ -170,6 +171,29 @@ func TestSign(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	pathLenCSR, err := gen.CSRWithSigner(testpk,
+		gen.SetCSRCommonName("test"),
+		func(csr *x509.CertificateRequest) error {
+			ext, err := pki.MarshalBasicConstraints(false, ptr.To(1))
+			csr.ExtraExtensions = append(csr.ExtraExtensions, ext)
+			return err
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pathLenCR := gen.CertificateRequestFrom(baseCR,
+		gen.SetCertificateRequestIsCA(false),
+		gen.SetCertificateRequestCSR(pathLenCSR),
+	)
+
+	signatureHashCR := gen.CertificateRequestFrom(baseCR,
+		gen.AddCertificateRequestAnnotations(map[string]string{cmapi.CertificateRequestSignatureHashAnnotationKey: "MD5"}),
+	)
+
+	keyPolicyIssuer := baseIssuer.DeepCopy()
+	keyPolicyIssuer.Spec.AllowedECDSACurves = []string{"P-384"}
+
 	tests := map[string]testT{
 		"a CertificateRequest without an approved condition should do nothing": {
 			certificateRequest: baseCRNotApproved.DeepCopy(),
@@ -186,7 +210,7 @@ func TestSign(t *testing.T) {
 			builder: &testpkg.Builder{
 				KubeObjects:        []runtime.Object{},
 				CertManagerObjects: []runtime.Object{baseCRDenied.DeepCopy(), baseIssuer.DeepCopy()},
-				ExpectedEvents:     []string{},
+				ExpectedEvents:     []string{"Warning Denied The CertificateRequest was denied by an approval controller"},
 				ExpectedActions: []testpkg.Action{
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
@@ -357,6 +381,87 @@ func TestSign(t *testing.T) {
 				},
 			},
 		},
+		"a non-CA CertificateRequest with a path length constraint should set condition to failed": {
+			certificateRequest: pathLenCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{rsaCASecret},
+				CertManagerObjects: []runtime.Object{pathLenCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Warning InvalidPathLen Path length constraints may only be requested for CA certificates: the request has a path length constraint of 1 but isCA is not set",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(pathLenCR.DeepCopy(),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            "Path length constraints may only be requested for CA certificates: the request has a path length constraint of 1 but isCA is not set",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+		},
+		"a CertificateRequest whose key is not allowed by the key policy of the issuer should set condition to failed": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{rsaCASecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), keyPolicyIssuer},
+				ExpectedEvents: []string{
+					"Warning WeakKey The key of the request is not allowed by the key policy of the issuer: the ECDSA key of the request is on the P-256 curve, but the issuer only allows P-384",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR.DeepCopy(),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            "The key of the request is not allowed by the key policy of the issuer: the ECDSA key of the request is on the P-256 curve, but the issuer only allows P-384",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+		},
+		"a CertificateRequest requesting an unsupported signature hash algorithm should set condition to failed": {
+			certificateRequest: signatureHashCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{rsaCASecret},
+				CertManagerObjects: []runtime.Object{signatureHashCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Warning UnsupportedSignatureAlgorithm The requested signature hash algorithm is not supported by the CA: invalid "cert-manager.io/signature-hash-algorithm" annotation: unsupported signature hash algorithm "MD5", must be one of SHA256, SHA384 or SHA512`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(signatureHashCR.DeepCopy(),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `The requested signature hash algorithm is not supported by the CA: invalid "cert-manager.io/signature-hash-algorithm" annotation: unsupported signature hash algorithm "MD5", must be one of SHA256, SHA384 or SHA512`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+		},
 		"a successful signing should set condition to Ready": {
 			certificateRequest: baseCR.DeepCopy(),
 			templateGenerator: func(cr *cmapi.CertificateRequest) (*x509.Certificate, error) {
@@ -390,6 +495,8 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 							gen.SetCertificateRequestCertificate(certBundle.ChainPEM),
+							gen.SetCertificateRequestChainLength(1),
+							gen.SetCertificateRequestSerialNumberOf(certBundle.ChainPEM),
 							gen.SetCertificateRequestCA(rootCertPEM),
 						),
 					)),
@@ -469,6 +576,17 @@ func TestCA_Sign(t *testing.T) {
 		t.Fatal(err)
 	}
 	testCSR := generateCSR(t, testpk)
+	mustStapleCSR, err := gen.CSRWithSigner(testpk,
+		gen.SetCSRCommonName("test"),
+		func(csr *x509.CertificateRequest) error {
+			ext, err := pki.MarshalOCSPMustStaple()
+			csr.ExtraExtensions = append(csr.ExtraExtensions, ext)
+			return err
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	tests := map[string]struct {
 		givenCASecret    *corev1.Secret
@@ -536,6 +654,133 @@ func TestCA_Sign(t *testing.T) {
 				assert.Equal(t, true, got.IsCA)
 			},
 		},
+		"when the Issuer has maxPathLen set, it should appear on the signed ca": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+				SecretName: "secret-1",
+				MaxPathLen: ptr.To[int32](0),
+			})),
+			givenCR: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestCSR(testCSR),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "issuer-1",
+					Group: certmanager.GroupName,
+					Kind:  "Issuer",
+				}),
+				gen.SetCertificateRequestIsCA(true),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				assert.Equal(t, true, got.IsCA)
+				assert.Equal(t, 0, got.MaxPathLen)
+				assert.Equal(t, true, got.MaxPathLenZero)
+			},
+		},
+		"when the Issuer has maxPathLen set, it should not appear on a signed leaf": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+				SecretName: "secret-1",
+				MaxPathLen: ptr.To[int32](2),
+			})),
+			givenCR: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestCSR(testCSR),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "issuer-1",
+					Group: certmanager.GroupName,
+					Kind:  "Issuer",
+				}),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				assert.Equal(t, false, got.IsCA)
+				assert.Equal(t, -1, got.MaxPathLen)
+			},
+		},
+		"when the Issuer has legacyExtensions set, the Netscape certificate type should appear on the signed cert": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+				SecretName:       "secret-1",
+				LegacyExtensions: true,
+			})),
+			givenCR: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestCSR(testCSR),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "issuer-1",
+					Group: certmanager.GroupName,
+					Kind:  "Issuer",
+				}),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				assert.True(t, pki.HasNetscapeCertType(got))
+			},
+		},
+		"when the Issuer does not have legacyExtensions set, the Netscape certificate type should not appear on the signed cert": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+				SecretName: "secret-1",
+			})),
+			givenCR: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestCSR(testCSR),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "issuer-1",
+					Group: certmanager.GroupName,
+					Kind:  "Issuer",
+				}),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				assert.False(t, pki.HasNetscapeCertType(got))
+			},
+		},
+		"when the CertificateRequest requests OCSP Must-Staple, the extension should appear on the signed cert": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+				SecretName: "secret-1",
+			})),
+			givenCR: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestCSR(mustStapleCSR),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "issuer-1",
+					Group: certmanager.GroupName,
+					Kind:  "Issuer",
+				}),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				assert.True(t, pki.HasOCSPMustStaple(got))
+			},
+		},
+		"when the CertificateRequest does not request OCSP Must-Staple, the extension should not appear on the signed cert": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+				SecretName: "secret-1",
+			})),
+			givenCR: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestCSR(testCSR),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "issuer-1",
+					Group: certmanager.GroupName,
+					Kind:  "Issuer",
+				}),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				assert.False(t, pki.HasOCSPMustStaple(got))
+			},
+		},
+		"when the CertificateRequest requests a signature hash algorithm, the certificate should be signed with it": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+				SecretName: "secret-1",
+			})),
+			givenCR: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestCSR(testCSR),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "issuer-1",
+					Group: certmanager.GroupName,
+					Kind:  "Issuer",
+				}),
+				gen.AddCertificateRequestAnnotations(map[string]string{cmapi.CertificateRequestSignatureHashAnnotationKey: "SHA384"}),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				assert.Equal(t, x509.ECDSAWithSHA384, got.SignatureAlgorithm)
+			},
+		},
 		"when the Issuer has ocspServers set, it should appear on the signed ca": {
 			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
 			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
@@ -602,7 +847,7 @@ func TestCA_Sign(t *testing.T) {
 					ClusterIssuerAmbientCredentials: false,
 					IssuerAmbientCredentials:        false,
 				},
-				reporter: util.NewReporter(fixedClock, rec),
+				reporter: util.NewReporter(fixedClock, rec, 0),
 				secretsLister: testlisters.FakeSecretListerFrom(testlisters.NewFakeSecretLister(),
 					testlisters.SetFakeSecretNamespaceListerGet(test.givenCASecret, nil),
 				),
@@ -18,14 +18,84 @@ package certificaterequests
 
 import (
 	"fmt"
+	"reflect"
 
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
 
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
+// issuerRefIndex is the name of the index of the CertificateRequest
+// informer on the issuer referenced by each CertificateRequest, see
+// issuerRefIndexKey.
+const issuerRefIndex = "issuerRef"
+
+// issuerRefIndexKey returns the key of the issuerRefIndex for the Issuer or
+// ClusterIssuer with the given kind, namespace and name.
+func issuerRefIndexKey(kind, namespace, name string) string {
+	if kind == cmapi.ClusterIssuerKind {
+		return kind + "/" + name
+	}
+	return cmapi.IssuerKind + "/" + namespace + "/" + name
+}
+
+// indexIssuerRef indexes a CertificateRequest on the cert-manager Issuer or
+// ClusterIssuer it references.
+func indexIssuerRef(obj interface{}) ([]string, error) {
+	cr, ok := obj.(*cmapi.CertificateRequest)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type %T", obj)
+	}
+
+	ref := cr.Spec.IssuerRef
+	if ref.Group != "" && ref.Group != certmanager.GroupName {
+		return nil, nil
+	}
+
+	return []string{issuerRefIndexKey(ref.Kind, cr.Namespace, ref.Name)}, nil
+}
+
+// addIssuerRefIndex adds the issuerRefIndex to the CertificateRequest
+// informer, unless the controller of another issuer type sharing the
+// informer has already added it.
+func addIssuerRefIndex(informer cache.SharedIndexInformer) error {
+	if _, ok := informer.GetIndexer().GetIndexers()[issuerRefIndex]; ok {
+		return nil
+	}
+	return informer.AddIndexers(cache.Indexers{issuerRefIndex: indexIssuerRef})
+}
+
+// issuerEventHandler returns the handler of the events of the Issuers or
+// ClusterIssuers, which requeues the CertificateRequests referencing them.
+func (c *Controller) issuerEventHandler() cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: c.handleGenericIssuer,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if reflect.DeepEqual(oldObj, newObj) {
+				return
+			}
+			c.handleGenericIssuer(newObj)
+
+			oldIss, oldOK := oldObj.(cmapi.GenericIssuer)
+			newIss, newOK := newObj.(cmapi.GenericIssuer)
+			if oldOK && newOK && !issuerIsReady(oldIss) && issuerIsReady(newIss) {
+				c.handleIssuerBecameReady(newIss)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			c.handleGenericIssuer(obj)
+		},
+	}
+}
+
 func (c *Controller) handleGenericIssuer(obj interface{}) {
 	log := c.log.WithName("handleGenericIssuer")
 
@@ -35,6 +105,10 @@ func (c *Controller) handleGenericIssuer(obj interface{}) {
 		return
 	}
 
+	// The cached issuer is invalidated before the CertificateRequests are
+	// requeued, so that their syncs read the changed issuer.
+	c.issuerCache.Invalidate(iss)
+
 	log = logf.WithResource(log, iss)
 	crs, err := c.certificatesRequestsForGenericIssuer(iss)
 	if err != nil {
@@ -49,30 +123,64 @@ func (c *Controller) handleGenericIssuer(obj interface{}) {
 	}
 }
 
-func (c *Controller) certificatesRequestsForGenericIssuer(iss cmapi.GenericIssuer) ([]*cmapi.CertificateRequest, error) {
-	crts, err := c.certificateRequestLister.List(labels.NewSelector())
+// handleIssuerBecameReady resets the rate limited backoff of the pending
+// CertificateRequests of an issuer which has just become Ready. They may
+// have been retried for a long time while the issuer was not ready, for
+// example while its credentials were being created, and are now requeued
+// without delay by handleGenericIssuer, with any further retry starting from
+// the initial backoff.
+func (c *Controller) handleIssuerBecameReady(iss cmapi.GenericIssuer) {
+	log := logf.WithResource(c.log.WithName("handleIssuerBecameReady"), iss)
 
+	crs, err := c.certificatesRequestsForGenericIssuer(iss)
 	if err != nil {
-		return nil, fmt.Errorf("error listing certificates: %s", err.Error())
+		log.Error(err, "error looking up certificates observing issuer or clusterissuer")
+		return
 	}
 
-	_, isClusterIssuer := iss.(*cmapi.ClusterIssuer)
-
-	var affected []*cmapi.CertificateRequest
-	for _, crt := range crts {
-		if isClusterIssuer && crt.Spec.IssuerRef.Kind != cmapi.ClusterIssuerKind {
+	reset := 0
+	for _, cr := range crs {
+		switch apiutil.CertificateRequestReadyReason(cr) {
+		case cmapi.CertificateRequestReasonIssued, cmapi.CertificateRequestReasonFailed, cmapi.CertificateRequestReasonDenied:
 			continue
 		}
-		if !isClusterIssuer {
-			if crt.Namespace != iss.GetObjectMeta().Namespace {
-				continue
-			}
-		}
-		if crt.Spec.IssuerRef.Name != iss.GetObjectMeta().Name {
-			continue
+
+		c.queue.Forget(types.NamespacedName{
+			Name:      cr.Name,
+			Namespace: cr.Namespace,
+		})
+		reset++
+	}
+
+	if reset > 0 {
+		log.V(logf.DebugLevel).Info("issuer became ready, reset the backoff of its pending certificate requests", "count", reset)
+	}
+}
+
+func (c *Controller) certificatesRequestsForGenericIssuer(iss cmapi.GenericIssuer) ([]*cmapi.CertificateRequest, error) {
+	kind := cmapi.IssuerKind
+	if _, isClusterIssuer := iss.(*cmapi.ClusterIssuer); isClusterIssuer {
+		kind = cmapi.ClusterIssuerKind
+	}
+
+	objs, err := c.certificateRequestIndexer.ByIndex(issuerRefIndex, issuerRefIndexKey(kind, iss.GetObjectMeta().Namespace, iss.GetObjectMeta().Name))
+	if err != nil {
+		return nil, fmt.Errorf("error listing certificates: %s", err.Error())
+	}
+
+	affected := make([]*cmapi.CertificateRequest, 0, len(objs))
+	for _, obj := range objs {
+		if cr, ok := obj.(*cmapi.CertificateRequest); ok {
+			affected = append(affected, cr)
 		}
-		affected = append(affected, crt)
 	}
 
 	return affected, nil
 }
+
+func issuerIsReady(iss cmapi.GenericIssuer) bool {
+	return apiutil.IssuerHasCondition(iss, cmapi.IssuerCondition{
+		Type:   cmapi.IssuerConditionReady,
+		Status: cmmeta.ConditionTrue,
+	})
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/fake"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestIssuerBecameReady(t *testing.T) {
+	notReady := gen.Issuer("test-issuer",
+		gen.SetIssuerNamespace("test-ns"),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{
+			Type:   cmapi.IssuerConditionReady,
+			Status: cmmeta.ConditionFalse,
+		}),
+	)
+	ready := gen.IssuerFrom(notReady,
+		gen.SetIssuerNamespace("test-ns"),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{
+			Type:   cmapi.IssuerConditionReady,
+			Status: cmmeta.ConditionTrue,
+		}),
+	)
+
+	crFor := func(name, namespace string, ref cmmeta.ObjectReference, mods ...gen.CertificateRequestModifier) *cmapi.CertificateRequest {
+		return gen.CertificateRequest(name, append([]gen.CertificateRequestModifier{
+			gen.SetCertificateRequestNamespace(namespace),
+			gen.SetCertificateRequestIssuer(ref),
+		}, mods...)...)
+	}
+	issuerRef := cmmeta.ObjectReference{Name: "test-issuer", Kind: cmapi.IssuerKind}
+
+	pending := crFor("pending", "test-ns", issuerRef, gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+		Type:   cmapi.CertificateRequestConditionReady,
+		Status: cmmeta.ConditionFalse,
+		Reason: cmapi.CertificateRequestReasonPending,
+	}))
+	defaultKind := crFor("default-kind", "test-ns", cmmeta.ObjectReference{Name: "test-issuer"})
+	issued := crFor("issued", "test-ns", issuerRef, gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+		Type:   cmapi.CertificateRequestConditionReady,
+		Status: cmmeta.ConditionTrue,
+		Reason: cmapi.CertificateRequestReasonIssued,
+	}))
+	otherNamespace := crFor("other-namespace", "other-ns", issuerRef)
+	clusterIssuer := crFor("cluster-issuer", "test-ns", cmmeta.ObjectReference{Name: "test-issuer", Kind: cmapi.ClusterIssuerKind})
+	otherGroup := crFor("other-group", "test-ns", cmmeta.ObjectReference{Name: "test-issuer", Kind: cmapi.IssuerKind, Group: "example.com"})
+
+	builder := &testpkg.Builder{
+		T:     t,
+		Clock: fixedClock,
+		CertManagerObjects: []runtime.Object{
+			pending, defaultKind, issued, otherNamespace, clusterIssuer, otherGroup,
+		},
+	}
+	builder.Init()
+	defer builder.Stop()
+
+	c := New(util.IssuerSelfSigned, func(*controller.Context) Issuer { return &fake.Issuer{} })
+	_, _, err := c.Register(builder.Context)
+	require.NoError(t, err)
+	builder.Start()
+
+	keyOf := func(cr *cmapi.CertificateRequest) types.NamespacedName {
+		return types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}
+	}
+	drain := func() []string {
+		var names []string
+		for c.queue.Len() > 0 {
+			key, _ := c.queue.Get()
+			names = append(names, key.Name)
+			c.queue.Done(key)
+		}
+		return names
+	}
+
+	// The requests are queued once when the informers start.
+	require.Eventually(t, func() bool { return c.queue.Len() == 6 }, wait.ForeverTestTimeout, time.Millisecond)
+	drain()
+
+	// The requests have been retried with a backoff while the issuer was
+	// not ready.
+	for _, cr := range []*cmapi.CertificateRequest{pending, defaultKind, issued} {
+		c.queue.AddRateLimited(keyOf(cr))
+		c.queue.AddRateLimited(keyOf(cr))
+	}
+
+	c.issuerEventHandler().OnUpdate(notReady, ready)
+
+	assert.ElementsMatch(t, []string{"pending", "default-kind", "issued"}, drain())
+
+	assert.Equal(t, 0, c.queue.NumRequeues(keyOf(pending)), "expected the backoff of pending requests to be reset")
+	assert.Equal(t, 0, c.queue.NumRequeues(keyOf(defaultKind)), "expected the backoff of pending requests to be reset")
+	assert.Equal(t, 2, c.queue.NumRequeues(keyOf(issued)), "expected the backoff of issued requests to be left unchanged")
+
+	// Updates which do not make the issuer ready only requeue the requests.
+	c.queue.AddRateLimited(keyOf(pending))
+	c.issuerEventHandler().OnUpdate(ready, gen.IssuerFrom(ready, gen.SetIssuerACMEEmail("test@example.com")))
+	assert.Equal(t, 1, c.queue.NumRequeues(keyOf(pending)))
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/go-logr/logr"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
@@ -44,6 +45,78 @@ type Issuer interface {
 	Sign(context.Context, *v1.CertificateRequest, v1.GenericIssuer) (*issuer.IssueResponse, error)
 }
 
+// QueueingIssuer is an optional interface that may be implemented by an
+// Issuer which needs to schedule its own resyncs of CertificateRequests, for
+// example to poll a remote API for a certificate which is pending issuance.
+type QueueingIssuer interface {
+	Issuer
+
+	// SetQueue is called with the controller's workqueue once the Issuer has
+	// been constructed.
+	SetQueue(workqueue.TypedRateLimitingInterface[types.NamespacedName])
+}
+
+// FieldManagerIssuer is an optional interface that may be implemented by an
+// Issuer which should be identified by its own field manager name when the
+// controller updates the CertificateRequests it signs.
+type FieldManagerIssuer interface {
+	Issuer
+
+	// FieldManager returns the field manager name to use for updates. If
+	// empty, the field manager of the controller is used.
+	FieldManager() string
+}
+
+// WarmingUpIssuer is an optional interface that may be implemented by an
+// Issuer which needs to do some work when the controller starts, before any
+// CertificateRequest is signed.
+type WarmingUpIssuer interface {
+	Issuer
+
+	// WarmUp is called once the informer caches of the controller have
+	// synced, before its workers are started. It must return once ctx is
+	// cancelled.
+	WarmUp(ctx context.Context)
+}
+
+// RunningIssuer is an optional interface that may be implemented by an Issuer
+// which runs background tasks, such as servers, alongside the workers of the
+// controller, independently of whether it warms up.
+type RunningIssuer interface {
+	Issuer
+
+	// Runnables returns the functions run in their own goroutine once the
+	// informer caches of the controller have synced. They must return once
+	// ctx is cancelled.
+	Runnables() []func(ctx context.Context)
+}
+
+// PrioritizingIssuer is an optional interface that may be implemented by an
+// Issuer which processes some CertificateRequests ahead of others when the
+// controller has a backlog. CertificateRequests with a higher priority are
+// taken from the workqueue first, but a CertificateRequest which has been
+// queued the longest is never passed over more than a few times in a row, so
+// that lower priority CertificateRequests are not starved. The priority does
+// not change the backoff of CertificateRequests which are retried.
+type PrioritizingIssuer interface {
+	Issuer
+
+	// Priority returns the priority of the CertificateRequest.
+	Priority(*v1.CertificateRequest) Priority
+}
+
+// IssuerCachingIssuer is an optional interface that may be implemented by an
+// Issuer whose controller should cache the issuers referenced by
+// CertificateRequests, rather than reading them from the listers on every
+// sync. The cached issuers are invalidated by the events of the issuer
+// informers, before the CertificateRequests referencing them are requeued.
+type IssuerCachingIssuer interface {
+	Issuer
+
+	// CacheIssuers returns true if the issuers should be cached.
+	CacheIssuers() bool
+}
+
 // Issuer Contractor builds a Issuer instance using the given controller
 // context.
 type IssuerConstructor func(*controllerpkg.Context) Issuer
@@ -59,14 +132,24 @@ type RegisterExtraInformerFn func(*controllerpkg.Context, logr.Logger, workqueue
 // certificate requests.
 type Controller struct {
 	helper issuer.Helper
+	// issuerCache is the cache of the issuers read by helper, or nil if the
+	// issuer implementation does not cache issuers.
+	issuerCache *issuer.CachingHelper
 
 	// clientset used to update cert-manager API resources
 	cmClient cmclient.Interface
 
-	// fieldManager is the manager name used for the Apply operations.
+	// kubeClient is used to store the private keys returned by issuers
+	kubeClient kubernetes.Interface
+
+	// fieldManager is the manager name used for the Update and Apply
+	// operations.
 	fieldManager string
 
 	certificateRequestLister cmlisters.CertificateRequestLister
+	// certificateRequestIndexer indexes the CertificateRequests on the issuer
+	// they reference, see issuerRefIndex.
+	certificateRequestIndexer cache.Indexer
 
 	// we need to wait for Secrets to be synced to avoid a situation where CA issuer's Secret
 	// is not yet in cached at a time when issuance is attempted,
@@ -95,6 +178,10 @@ type Controller struct {
 	issuerConstructor IssuerConstructor
 	issuer            Issuer
 
+	// inflight tracks the calls to Sign in progress, which are cancelled if
+	// their CertificateRequest is deleted.
+	inflight inflightSigns
+
 	// used for testing
 	clock clock.Clock
 
@@ -128,11 +215,18 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.TypedRateLi
 	// construct a new named logger to be reused throughout the controller
 	c.log = logf.FromContext(ctx.RootContext, componentName)
 
-	// create a queue used to queue up items to be processed
+	// create a queue used to queue up items to be processed, in the order
+	// of their priority if the issuer implements PrioritizingIssuer
 	c.queue = workqueue.NewTypedRateLimitingQueueWithConfig(
 		controllerpkg.DefaultItemBasedRateLimiter(),
 		workqueue.TypedRateLimitingQueueConfig[types.NamespacedName]{
-			Name: componentName,
+			DelayingQueue: workqueue.NewTypedDelayingQueueWithConfig(workqueue.TypedDelayingQueueConfig[types.NamespacedName]{
+				Name: componentName,
+				Queue: workqueue.NewTypedWithConfig(workqueue.TypedQueueConfig[types.NamespacedName]{
+					Name:  componentName,
+					Queue: newPriorityQueue(c.priority),
+				}),
+			}),
 		},
 	)
 
@@ -168,7 +262,7 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.TypedRateLi
 		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
 		c.clusterIssuerLister = clusterIssuerInformer.Lister()
 		// register handler function for clusterissuer resources
-		if _, err := clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleGenericIssuer}); err != nil {
+		if _, err := clusterIssuerInformer.Informer().AddEventHandler(c.issuerEventHandler()); err != nil {
 			return nil, nil, fmt.Errorf("error setting up event handler: %v", err)
 		}
 		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
@@ -176,12 +270,19 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.TypedRateLi
 
 	// set all the references to the listers for used by the Sync function
 	c.certificateRequestLister = certificateRequestInformer.Lister()
+	if err := addIssuerRefIndex(certificateRequestInformer.Informer()); err != nil {
+		return nil, nil, fmt.Errorf("error adding issuer index: %v", err)
+	}
+	c.certificateRequestIndexer = certificateRequestInformer.Informer().GetIndexer()
 
 	// register handler functions
 	if _, err := certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue}); err != nil {
 		return nil, nil, fmt.Errorf("error setting up event handler: %v", err)
 	}
-	if _, err := issuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleGenericIssuer}); err != nil {
+	if _, err := certificateRequestInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{DeleteFunc: c.handleDeletedCertificateRequest}); err != nil {
+		return nil, nil, fmt.Errorf("error setting up event handler: %v", err)
+	}
+	if _, err := issuerInformer.Informer().AddEventHandler(c.issuerEventHandler()); err != nil {
 		return nil, nil, fmt.Errorf("error setting up event handler: %v", err)
 	}
 	// create an issuer helper for reading generic issuers
@@ -191,12 +292,23 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.TypedRateLi
 	c.clock = ctx.Clock
 	// recorder records events about resources to the Kubernetes api
 	c.recorder = ctx.Recorder
-	c.reporter = util.NewReporter(c.clock, c.recorder)
+	c.reporter = util.NewReporter(c.clock, c.recorder, ctx.IssuerOptions.CertificateRequestEventCooldown)
 	c.cmClient = ctx.CMClient
+	c.kubeClient = ctx.Client
 	c.fieldManager = ctx.FieldManager
 
 	// Construct the issuer implementation with the built component context.
 	c.issuer = c.issuerConstructor(ctx)
+	if qi, ok := c.issuer.(QueueingIssuer); ok {
+		qi.SetQueue(c.queue)
+	}
+	if fi, ok := c.issuer.(FieldManagerIssuer); ok && fi.FieldManager() != "" {
+		c.fieldManager = fi.FieldManager()
+	}
+	if ci, ok := c.issuer.(IssuerCachingIssuer); ok && ci.CacheIssuers() {
+		c.issuerCache = issuer.NewCachingHelper(c.helper, ctx.Metrics)
+		c.helper = c.issuerCache
+	}
 
 	c.log.V(logf.DebugLevel).Info("new certificate request controller registered",
 		"type", c.issuerType)
@@ -204,6 +316,38 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.TypedRateLi
 	return c.queue, mustSync, nil
 }
 
+// WarmUp warms up the issuer implementation of the controller, if it
+// implements WarmingUpIssuer.
+func (c *Controller) WarmUp(ctx context.Context) {
+	if wi, ok := c.issuer.(WarmingUpIssuer); ok {
+		wi.WarmUp(ctx)
+	}
+}
+
+// Runnables returns the background tasks of the issuer implementation of the
+// controller, if it implements RunningIssuer.
+func (c *Controller) Runnables() []func(ctx context.Context) {
+	if ri, ok := c.issuer.(RunningIssuer); ok {
+		return ri.Runnables()
+	}
+	return nil
+}
+
+// priority returns the priority of the CertificateRequest with the given key,
+// which is PriorityNormal unless the issuer implements PrioritizingIssuer.
+func (c *Controller) priority(key types.NamespacedName) Priority {
+	pi, ok := c.issuer.(PrioritizingIssuer)
+	if !ok || c.certificateRequestLister == nil {
+		return PriorityNormal
+	}
+
+	cr, err := c.certificateRequestLister.CertificateRequests(key.Namespace).Get(key.Name)
+	if err != nil {
+		return PriorityNormal
+	}
+	return pi.Priority(cr)
+}
+
 // ProcessItem is the worker function that will be called with a new key from
 // the workqueue. A key corresponds to a certificate request object.
 func (c *Controller) ProcessItem(ctx context.Context, key types.NamespacedName) error {
@@ -223,5 +367,9 @@ func (c *Controller) ProcessItem(ctx context.Context, key types.NamespacedName)
 	}
 
 	ctx = logf.NewContext(ctx, logf.WithResource(log, cr))
+
+	ctx, endSpan := controllerpkg.StartReconcileSpan(ctx, "CertificateRequest.Sync")
+	defer endSpan()
+
 	return c.Sync(ctx, cr)
 }
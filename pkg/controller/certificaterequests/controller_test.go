@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/fake"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+type fieldManagerIssuer struct {
+	fake.Issuer
+	fieldManager string
+}
+
+func (f *fieldManagerIssuer) FieldManager() string {
+	return f.fieldManager
+}
+
+func TestRegisterFieldManager(t *testing.T) {
+	tests := map[string]struct {
+		issuer               Issuer
+		expectedFieldManager string
+	}{
+		"the field manager of the controller is used by default": {
+			issuer:               &fake.Issuer{},
+			expectedFieldManager: "cert-manager-test",
+		},
+		"the field manager of the issuer is used if set": {
+			issuer:               &fieldManagerIssuer{fieldManager: "cert-manager-issuer"},
+			expectedFieldManager: "cert-manager-issuer",
+		},
+		"the field manager of the controller is used if the issuer has none": {
+			issuer:               &fieldManagerIssuer{},
+			expectedFieldManager: "cert-manager-test",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{T: t, Clock: fixedClock}
+			builder.Init()
+			defer builder.Stop()
+			builder.Context.FieldManager = "cert-manager-test"
+
+			c := New(util.IssuerSelfSigned, func(*controller.Context) Issuer { return test.issuer })
+			_, _, err := c.Register(builder.Context)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedFieldManager, c.fieldManager)
+		})
+	}
+}
+
+type issuerCachingIssuer struct {
+	fake.Issuer
+	cacheIssuers bool
+}
+
+func (i *issuerCachingIssuer) CacheIssuers() bool {
+	return i.cacheIssuers
+}
+
+func TestRegisterIssuerCache(t *testing.T) {
+	issuer := gen.Issuer("test-issuer",
+		gen.SetIssuerNamespace(gen.DefaultTestNamespace),
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "old"}),
+	)
+	issuerRef := cmmeta.ObjectReference{Name: "test-issuer", Kind: cmapi.IssuerKind}
+
+	for name, cacheIssuers := range map[string]bool{
+		"issuers are read from the listers by default": false,
+		"issuers are cached if the issuer caches them": true,
+	} {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{T: t, Clock: fixedClock, CertManagerObjects: []runtime.Object{issuer}}
+			builder.Init()
+			defer builder.Stop()
+
+			c := New(util.IssuerVenafi, func(*controller.Context) Issuer { return &issuerCachingIssuer{cacheIssuers: cacheIssuers} })
+			_, _, err := c.Register(builder.Context)
+			require.NoError(t, err)
+			builder.Start()
+
+			assert.Equal(t, cacheIssuers, c.issuerCache != nil)
+
+			iss, err := c.helper.GetGenericIssuer(issuerRef, gen.DefaultTestNamespace)
+			require.NoError(t, err)
+			assert.Equal(t, "old", iss.GetSpec().Venafi.Zone)
+
+			// A change of the issuer is read once the informer has observed
+			// it, whether or not the issuer was cached.
+			updated := gen.IssuerFrom(issuer, gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "updated"}))
+			_, err = builder.CMClient.CertmanagerV1().Issuers(gen.DefaultTestNamespace).Update(context.TODO(), updated, metav1.UpdateOptions{})
+			require.NoError(t, err)
+			require.Eventually(t, func() bool {
+				iss, err := c.helper.GetGenericIssuer(issuerRef, gen.DefaultTestNamespace)
+				return err == nil && iss.GetSpec().Venafi.Zone == "updated"
+			}, wait.ForeverTestTimeout, time.Millisecond)
+		})
+	}
+}
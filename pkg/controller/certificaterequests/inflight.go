@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequests
+
+import (
+	"context"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// inflightSigns tracks the calls to Sign in progress, so that they can be
+// cancelled when their CertificateRequest is deleted instead of waiting on an
+// issuer for a certificate which is no longer wanted. The zero value is ready
+// to use.
+type inflightSigns struct {
+	lock  sync.Mutex
+	signs map[types.UID]*inflightSign
+}
+
+type inflightSign struct {
+	cancel    context.CancelFunc
+	cancelled bool
+}
+
+// start returns the context of a call to Sign for the CertificateRequest with
+// the given UID, and a function which must be called once the call returns.
+// The function reports whether the call was cancelled by cancel.
+func (s *inflightSigns) start(ctx context.Context, uid types.UID) (context.Context, func() bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	sign := &inflightSign{cancel: cancel}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.signs == nil {
+		s.signs = make(map[types.UID]*inflightSign)
+	}
+	s.signs[uid] = sign
+
+	return ctx, func() bool {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		if s.signs[uid] == sign {
+			delete(s.signs, uid)
+		}
+		cancel()
+		return sign.cancelled
+	}
+}
+
+// cancel cancels the call to Sign in progress for the CertificateRequest with
+// the given UID, if any, and reports whether there was one.
+func (s *inflightSigns) cancel(uid types.UID) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	sign, ok := s.signs[uid]
+	if !ok {
+		return false
+	}
+	sign.cancelled = true
+	sign.cancel()
+	return true
+}
+
+// handleDeletedCertificateRequest cancels the call to Sign in progress for a
+// deleted CertificateRequest, if any, and records a Cancelled event for it.
+func (c *Controller) handleDeletedCertificateRequest(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	cr, ok := obj.(*cmapi.CertificateRequest)
+	if !ok {
+		return
+	}
+
+	if c.inflight.cancel(cr.UID) {
+		logf.WithResource(c.log, cr).V(logf.DebugLevel).Info("Cancelled signing of the deleted certificate request")
+		c.recorder.Event(cr, corev1.EventTypeNormal, "Cancelled", "Signing cancelled as the CertificateRequest was deleted")
+	}
+}
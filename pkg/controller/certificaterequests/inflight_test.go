@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/fake"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestInflightSigns(t *testing.T) {
+	var signs inflightSigns
+
+	assert.False(t, signs.cancel("test-uid"), "expected no sign in progress")
+
+	ctx, done := signs.start(context.Background(), "test-uid")
+	otherCtx, otherDone := signs.start(context.Background(), "other-uid")
+
+	assert.True(t, signs.cancel("test-uid"))
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	assert.NoError(t, otherCtx.Err(), "expected other signs not to be cancelled")
+	assert.True(t, done(), "expected the sign to be reported as cancelled")
+
+	assert.False(t, otherDone(), "expected the sign not to be reported as cancelled")
+	assert.ErrorIs(t, otherCtx.Err(), context.Canceled, "expected the context to be released")
+	assert.False(t, signs.cancel("other-uid"), "expected completed signs not to be tracked")
+}
+
+func TestSyncCancelledOnDeletion(t *testing.T) {
+	sk, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	issuerObj := gen.Issuer("test-issuer",
+		gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{}),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{
+			Type:   cmapi.IssuerConditionReady,
+			Status: cmmeta.ConditionTrue,
+		}),
+	)
+	cr := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestUID("test-uid"),
+		gen.SetCertificateRequestCSR(generateCSR(t, sk)),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+			Kind: issuerObj.Kind,
+			Name: issuerObj.Name,
+		}),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:   cmapi.CertificateRequestConditionApproved,
+			Status: cmmeta.ConditionTrue,
+		}),
+	)
+
+	builder := &testpkg.Builder{
+		T:                  t,
+		Clock:              fixedClock,
+		CertManagerObjects: []runtime.Object{cr, issuerObj},
+		ExpectedEvents: []string{
+			"Normal Cancelled Signing cancelled as the CertificateRequest was deleted",
+		},
+		ExpectedActions: []testpkg.Action{},
+	}
+	builder.Init()
+	defer builder.Stop()
+
+	var c *Controller
+	issuerImpl := &fake.Issuer{
+		FakeSign: func(ctx context.Context, cr *cmapi.CertificateRequest, _ cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+			// The CertificateRequest is deleted while the issuer waits for
+			// the certificate.
+			c.handleDeletedCertificateRequest(cache.DeletedFinalStateUnknown{Obj: cr})
+			<-ctx.Done()
+
+			util.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady,
+				cmmeta.ConditionFalse, cmapi.CertificateRequestReasonPending, "the status must not be updated")
+			return nil, ctx.Err()
+		},
+	}
+
+	c = New(util.IssuerSelfSigned, func(*controller.Context) Issuer { return issuerImpl })
+	_, _, err = c.Register(builder.Context)
+	require.NoError(t, err)
+
+	builder.Start()
+
+	err = c.Sync(context.Background(), cr)
+	assert.NoError(t, err)
+	builder.CheckAndFinish(err)
+}
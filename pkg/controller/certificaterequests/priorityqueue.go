@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequests
+
+import (
+	"container/list"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Priority is the priority of a CertificateRequest in the workqueue of the
+// controller. CertificateRequests with a higher priority are processed first.
+type Priority int
+
+const (
+	PriorityLow    Priority = -1
+	PriorityNormal Priority = 0
+	PriorityHigh   Priority = 1
+)
+
+// maxPriorityBypass is the number of items which may be taken from the queue
+// ahead of the item which has been queued the longest, before that item is
+// taken regardless of its priority. This bounds the time low priority items
+// wait while higher priority items keep being added.
+const maxPriorityBypass = 10
+
+// priorityQueueItem is an item of a priorityQueue.
+type priorityQueueItem struct {
+	key      types.NamespacedName
+	priority Priority
+	// seq orders the items by the time they were added to the queue.
+	seq uint64
+}
+
+// priorityQueue is a workqueue.Queue which takes the items with the highest
+// priority first, and the items of the same priority in the order they were
+// added. To prevent starvation, the item which has been queued the longest is
+// taken once maxPriorityBypass items have been taken ahead of it.
+// The functions of the queue are always called with the lock of the workqueue
+// held, so the queue has no lock of its own.
+type priorityQueue struct {
+	// priority returns the priority of the item with the given key. It is
+	// called when the item is added, and again when an item which is already
+	// queued is added again, so that changes of priority are taken into
+	// account.
+	priority func(types.NamespacedName) Priority
+
+	// buckets are the FIFO lists of the items of each priority, from
+	// PriorityLow to PriorityHigh.
+	buckets  [PriorityHigh - PriorityLow + 1]list.List
+	elements map[types.NamespacedName]*list.Element
+
+	nextSeq uint64
+	// bypassed is the number of items taken ahead of the oldest item since
+	// the oldest item was last taken.
+	bypassed int
+}
+
+func newPriorityQueue(priority func(types.NamespacedName) Priority) *priorityQueue {
+	return &priorityQueue{
+		priority: priority,
+		elements: make(map[types.NamespacedName]*list.Element),
+	}
+}
+
+// bucket returns the list of the items of the given priority. Priorities out
+// of range are clamped.
+func (q *priorityQueue) bucket(p Priority) *list.List {
+	p = max(PriorityLow, min(PriorityHigh, p))
+	return &q.buckets[p-PriorityLow]
+}
+
+// Touch moves the queued item to the list of its current priority, if it
+// has changed. The item keeps its place in the order in which the items were
+// added.
+func (q *priorityQueue) Touch(key types.NamespacedName) {
+	e, ok := q.elements[key]
+	if !ok {
+		return
+	}
+	item := e.Value.(*priorityQueueItem)
+	priority := q.priority(key)
+	if q.bucket(priority) == q.bucket(item.priority) {
+		return
+	}
+
+	q.bucket(item.priority).Remove(e)
+	item.priority = priority
+	q.elements[key] = insertBySeq(q.bucket(priority), item)
+}
+
+// insertBySeq inserts the item in the list, which is ordered by seq.
+func insertBySeq(l *list.List, item *priorityQueueItem) *list.Element {
+	for e := l.Back(); e != nil; e = e.Prev() {
+		if e.Value.(*priorityQueueItem).seq < item.seq {
+			return l.InsertAfter(item, e)
+		}
+	}
+	return l.PushFront(item)
+}
+
+func (q *priorityQueue) Push(key types.NamespacedName) {
+	item := &priorityQueueItem{key: key, priority: q.priority(key), seq: q.nextSeq}
+	q.nextSeq++
+	q.elements[key] = q.bucket(item.priority).PushBack(item)
+}
+
+func (q *priorityQueue) Len() int {
+	return len(q.elements)
+}
+
+func (q *priorityQueue) Pop() types.NamespacedName {
+	var highest, oldest *list.List
+	for i := len(q.buckets) - 1; i >= 0; i-- {
+		b := &q.buckets[i]
+		if b.Len() == 0 {
+			continue
+		}
+		if highest == nil {
+			highest = b
+		}
+		if oldest == nil || b.Front().Value.(*priorityQueueItem).seq < oldest.Front().Value.(*priorityQueueItem).seq {
+			oldest = b
+		}
+	}
+
+	from := highest
+	if q.bypassed >= maxPriorityBypass {
+		from = oldest
+	}
+	if from == oldest {
+		q.bypassed = 0
+	} else {
+		q.bypassed++
+	}
+
+	item := from.Remove(from.Front()).(*priorityQueueItem)
+	delete(q.elements, item.key)
+	return item.key
+}
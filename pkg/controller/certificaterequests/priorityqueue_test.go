@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func key(name string) types.NamespacedName {
+	return types.NamespacedName{Namespace: "test-ns", Name: name}
+}
+
+func popAll(q *priorityQueue) []string {
+	var names []string
+	for q.Len() > 0 {
+		names = append(names, q.Pop().Name)
+	}
+	return names
+}
+
+func TestPriorityQueue(t *testing.T) {
+	priorities := map[string]Priority{
+		"high-1": PriorityHigh,
+		"high-2": PriorityHigh,
+		"low-1":  PriorityLow,
+	}
+	q := newPriorityQueue(func(k types.NamespacedName) Priority { return priorities[k.Name] })
+
+	for _, name := range []string{"low-1", "normal-1", "high-1", "normal-2", "high-2"} {
+		q.Push(key(name))
+	}
+
+	assert.Equal(t, 5, q.Len())
+	assert.Equal(t, []string{"high-1", "high-2", "normal-1", "normal-2", "low-1"}, popAll(q))
+}
+
+func TestPriorityQueueTouch(t *testing.T) {
+	priorities := map[string]Priority{}
+	q := newPriorityQueue(func(k types.NamespacedName) Priority { return priorities[k.Name] })
+
+	for _, name := range []string{"a", "b", "c", "d"} {
+		q.Push(key(name))
+	}
+
+	// A change of priority takes effect when the item is added again, and
+	// the item keeps its place amongst the items of its new priority.
+	priorities["c"] = PriorityHigh
+	priorities["a"] = PriorityHigh
+	q.Touch(key("c"))
+	q.Touch(key("a"))
+	priorities["b"] = PriorityLow
+	q.Touch(key("b"))
+
+	assert.Equal(t, []string{"a", "c", "d", "b"}, popAll(q))
+}
+
+func TestPriorityQueueDoesNotStarveLowPriorityItems(t *testing.T) {
+	q := newPriorityQueue(func(k types.NamespacedName) Priority {
+		if k.Name == "low" {
+			return PriorityLow
+		}
+		return PriorityHigh
+	})
+
+	q.Push(key("low"))
+	for i := 0; i < 2*maxPriorityBypass; i++ {
+		q.Push(key(fmt.Sprintf("high-%d", i)))
+	}
+
+	// The high priority items keep being added as they are taken, but the
+	// low priority item is taken once maxPriorityBypass items have been taken
+	// ahead of it.
+	for i := 0; i < maxPriorityBypass; i++ {
+		assert.NotEqual(t, "low", q.Pop().Name)
+		q.Push(key(fmt.Sprintf("high-new-%d", i)))
+	}
+	assert.Equal(t, "low", q.Pop().Name)
+	assert.Equal(t, "high-10", q.Pop().Name)
+}
+
+func TestPriorityQueueWithWorkqueue(t *testing.T) {
+	priorities := map[string]Priority{"high": PriorityHigh}
+	queue := workqueue.NewTypedWithConfig(workqueue.TypedQueueConfig[types.NamespacedName]{
+		Queue: newPriorityQueue(func(k types.NamespacedName) Priority { return priorities[k.Name] }),
+	})
+	defer queue.ShutDown()
+
+	queue.Add(key("normal"))
+	queue.Add(key("high"))
+	// Adding an item which is already queued does not queue it twice.
+	queue.Add(key("normal"))
+
+	assert.Equal(t, 2, queue.Len())
+	for _, expected := range []string{"high", "normal"} {
+		item, shutdown := queue.Get()
+		assert.False(t, shutdown)
+		assert.Equal(t, expected, item.Name)
+		queue.Done(item)
+	}
+}
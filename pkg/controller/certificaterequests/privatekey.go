@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequests
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// issuedPrivateKeySecretName returns the name of the Secret in which the
+// private key returned by the issuer for the CertificateRequest is stored.
+func issuedPrivateKeySecretName(cr *cmapi.CertificateRequest) string {
+	return cr.Name + "-issued-key"
+}
+
+// storeIssuedPrivateKey stores the private key returned by the issuer for the
+// CertificateRequest in a Secret owned by the request, and records the name
+// of the Secret on the request. The key is never stored in the status of the
+// request, which can be read by anyone who can read CertificateRequests. The
+// Secret is deleted by the issuing controller once it has stored the key in
+// the Secret of the Certificate.
+func (c *Controller) storeIssuedPrivateKey(ctx context.Context, cr *cmapi.CertificateRequest, keyPEM []byte) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       cr.Namespace,
+			Name:            issuedPrivateKeySecretName(cr),
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(cr, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateRequestKind))},
+			Labels: map[string]string{
+				cmapi.PartOfCertManagerControllerLabelKey: "true",
+			},
+		},
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	}
+
+	_, err := c.kubeClient.CoreV1().Secrets(cr.Namespace).Create(ctx, secret, metav1.CreateOptions{FieldManager: c.fieldManager})
+	if k8sErrors.IsAlreadyExists(err) {
+		err = c.updateIssuedPrivateKey(ctx, cr, secret)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to store the private key returned by the issuer: %w", err)
+	}
+
+	metav1.SetMetaDataAnnotation(&cr.ObjectMeta, cmapi.CertificateRequestIssuedPrivateKeyAnnotationKey, secret.Name)
+
+	return nil
+}
+
+// updateIssuedPrivateKey updates the existing Secret of the private key
+// returned by the issuer, for example if the request is signed again because
+// its status could not be updated.
+func (c *Controller) updateIssuedPrivateKey(ctx context.Context, cr *cmapi.CertificateRequest, secret *corev1.Secret) error {
+	existing, err := c.kubeClient.CoreV1().Secrets(cr.Namespace).Get(ctx, secret.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	// The Secret must not be one which happens to have the same name.
+	if !metav1.IsControlledBy(existing, cr) {
+		return fmt.Errorf("secret %q already exists and is not owned by the CertificateRequest", secret.Name)
+	}
+	if bytes.Equal(existing.Data[corev1.TLSPrivateKeyKey], secret.Data[corev1.TLSPrivateKeyKey]) {
+		return nil
+	}
+
+	existing = existing.DeepCopy()
+	existing.Data = secret.Data
+	_, err = c.kubeClient.CoreV1().Secrets(cr.Namespace).Update(ctx, existing, metav1.UpdateOptions{FieldManager: c.fieldManager})
+	return err
+}
@@ -99,7 +99,7 @@ func NewSelfSigned(ctx *controllerpkg.Context) certificaterequests.Issuer {
 	return &SelfSigned{
 		issuerOptions: ctx.IssuerOptions,
 		secretsLister: ctx.KubeSharedInformerFactory.Secrets().Lister(),
-		reporter:      crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		reporter:      crutil.NewReporter(ctx.Clock, ctx.Recorder, ctx.IssuerOptions.CertificateRequestEventCooldown),
 		recorder:      ctx.Recorder,
 		signingFn:     pki.SignCertificate,
 	}
@@ -108,6 +108,15 @@ func NewSelfSigned(ctx *controllerpkg.Context) certificaterequests.Issuer {
 func (s *SelfSigned) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
 	log := logf.FromContext(ctx, "sign")
 
+	if err := crutil.VerifyKeyPolicy(cr, issuerObj.GetSpec()); err != nil {
+		message := "The key of the request is not allowed by the key policy of the issuer"
+
+		s.reporter.Failed(cr, err, crutil.ReasonWeakKey, message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
+
 	resourceNamespace := s.issuerOptions.ResourceNamespace(issuerObj)
 
 	secretName, ok := cr.ObjectMeta.Annotations[cmapi.CertificateRequestPrivateKeyAnnotationKey]
@@ -116,7 +125,7 @@ func (s *SelfSigned) Sign(ctx context.Context, cr *cmapi.CertificateRequest, iss
 			cmapi.CertificateRequestPrivateKeyAnnotationKey)
 		err := errors.New("secret name missing")
 
-		s.reporter.Failed(cr, err, "MissingAnnotation", message)
+		s.reporter.Failed(cr, err, crutil.ReasonMissingAnnotation, message)
 		log.Error(err, message)
 
 		return nil, nil
@@ -126,7 +135,7 @@ func (s *SelfSigned) Sign(ctx context.Context, cr *cmapi.CertificateRequest, iss
 	if k8sErrors.IsNotFound(err) {
 		message := fmt.Sprintf("Referenced secret %s/%s not found", cr.Namespace, secretName)
 
-		s.reporter.Pending(cr, err, "MissingSecret", message)
+		s.reporter.Pending(cr, err, crutil.ReasonMissingSecret, message)
 		log.Error(err, message)
 
 		return nil, nil
@@ -136,7 +145,7 @@ func (s *SelfSigned) Sign(ctx context.Context, cr *cmapi.CertificateRequest, iss
 		message := fmt.Sprintf("Failed to get key %q referenced in annotation %q",
 			secretName, cmapi.CertificateRequestPrivateKeyAnnotationKey)
 
-		s.reporter.Pending(cr, err, "ErrorParsingKey", message)
+		s.reporter.Pending(cr, err, crutil.ReasonErrorParsingKey, message)
 		log.Error(err, message)
 
 		return nil, nil
@@ -145,7 +154,7 @@ func (s *SelfSigned) Sign(ctx context.Context, cr *cmapi.CertificateRequest, iss
 	if err != nil {
 		// We are probably in a network error here so we should backoff and retry
 		message := fmt.Sprintf("Failed to get certificate key pair from secret %s/%s", resourceNamespace, secretName)
-		s.reporter.Pending(cr, err, "ErrorGettingSecret", message)
+		s.reporter.Pending(cr, err, crutil.ReasonErrorGettingSecret, message)
 		log.Error(err, message)
 		return nil, err
 	}
@@ -154,13 +163,26 @@ func (s *SelfSigned) Sign(ctx context.Context, cr *cmapi.CertificateRequest, iss
 	template, err = pki.CertificateTemplateFromCertificateRequest(cr)
 	if err != nil {
 		message := "Error generating certificate template"
-		s.reporter.Failed(cr, err, "ErrorGenerating", message)
+		s.reporter.Failed(cr, err, crutil.ReasonErrorGenerating, message)
 		log.Error(err, message)
 		return nil, nil
 	}
 
 	template.CRLDistributionPoints = issuerObj.GetSpec().SelfSigned.CRLDistributionPoints
 
+	if backdate := issuerObj.GetSpec().SelfSigned.NotBeforeBackdate; backdate != nil {
+		template.NotBefore = template.NotBefore.Add(-backdate.Duration)
+	}
+
+	if issuerObj.GetSpec().SelfSigned.LegacyExtensions {
+		if err := pki.AddNetscapeCertType(template); err != nil {
+			message := "Error adding legacy extensions to certificate template"
+			s.reporter.Failed(cr, err, crutil.ReasonErrorGenerating, message)
+			log.Error(err, message)
+			return nil, nil
+		}
+	}
+
 	if template.Subject.String() == "" {
 		// RFC 5280 (https://tools.ietf.org/html/rfc5280#section-4.1.2.4) says that:
 		// "The issuer field MUST contain a non-empty distinguished name (DN)."
@@ -174,7 +196,7 @@ func (s *SelfSigned) Sign(ctx context.Context, cr *cmapi.CertificateRequest, iss
 	publickey, err := pki.PublicKeyForPrivateKey(privatekey)
 	if err != nil {
 		message := "Failed to get public key from private key"
-		s.reporter.Failed(cr, err, "ErrorPublicKey", message)
+		s.reporter.Failed(cr, err, crutil.ReasonErrorPublicKey, message)
 		log.Error(err, message)
 		return nil, nil
 	}
@@ -187,7 +209,7 @@ func (s *SelfSigned) Sign(ctx context.Context, cr *cmapi.CertificateRequest, iss
 		}
 
 		message := "Error generating certificate template"
-		s.reporter.Failed(cr, err, "ErrorKeyMatch", message)
+		s.reporter.Failed(cr, err, crutil.ReasonErrorKeyMatch, message)
 		log.Error(err, message)
 
 		return nil, nil
@@ -197,7 +219,7 @@ func (s *SelfSigned) Sign(ctx context.Context, cr *cmapi.CertificateRequest, iss
 	certPem, _, err := s.signingFn(template, template, publickey, privatekey)
 	if err != nil {
 		message := "Error signing certificate"
-		s.reporter.Failed(cr, err, "ErrorSigning", message)
+		s.reporter.Failed(cr, err, crutil.ReasonErrorSigning, message)
 		log.Error(err, message)
 		return nil, nil
 	}
@@ -208,7 +208,7 @@ func TestSign(t *testing.T) {
 			builder: &testpkg.Builder{
 				KubeObjects:        []runtime.Object{},
 				CertManagerObjects: []runtime.Object{baseCRDenied.DeepCopy(), baseIssuer.DeepCopy()},
-				ExpectedEvents:     []string{},
+				ExpectedEvents:     []string{"Warning Denied The CertificateRequest was denied by an approval controller"},
 				ExpectedActions: []testpkg.Action{
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
@@ -502,6 +502,8 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 							gen.SetCertificateRequestCertificate(certRSAPEM),
+							gen.SetCertificateRequestChainLength(1),
+							gen.SetCertificateRequestSerialNumberOf(certRSAPEM),
 							gen.SetCertificateRequestCA(certRSAPEM),
 						),
 					)),
@@ -540,6 +542,50 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 							gen.SetCertificateRequestCertificate(certECPEM),
+							gen.SetCertificateRequestChainLength(1),
+							gen.SetCertificateRequestSerialNumberOf(certECPEM),
+							gen.SetCertificateRequestCA(certECPEM),
+						),
+					)),
+				},
+			},
+		},
+		"should backdate notBefore when the issuer has notBeforeBackdate set": {
+			certificateRequest: ecCR.DeepCopy(),
+			signingFn: func(c1 *x509.Certificate, c2 *x509.Certificate, pk crypto.PublicKey, sk interface{}) ([]byte, *x509.Certificate, error) {
+				expectNotBefore := fixedClockStart.Add(-5 * time.Minute)
+				if !c1.NotBefore.Equal(expectNotBefore) {
+					return nil, nil, fmt.Errorf("expected notBefore %s, got %s", expectNotBefore, c1.NotBefore)
+				}
+
+				return certECPEM, nil, nil
+			},
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{ecKeySecret},
+				CertManagerObjects: []runtime.Object{ecCR.DeepCopy(), gen.IssuerFrom(baseIssuer,
+					gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{
+						NotBeforeBackdate: &metav1.Duration{Duration: 5 * time.Minute},
+					}),
+				)},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(ecCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestCertificate(certECPEM),
+							gen.SetCertificateRequestChainLength(1),
+							gen.SetCertificateRequestSerialNumberOf(certECPEM),
 							gen.SetCertificateRequestCA(certECPEM),
 						),
 					)),
@@ -584,6 +630,8 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 							gen.SetCertificateRequestCertificate(emptyCertPEM),
+							gen.SetCertificateRequestChainLength(1),
+							gen.SetCertificateRequestSerialNumberOf(emptyCertPEM),
 							gen.SetCertificateRequestCA(emptyCertPEM),
 						),
 					)),
@@ -18,6 +18,7 @@ package certificaterequests
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 	"reflect"
 
@@ -27,6 +28,7 @@ import (
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/utils/ptr"
 
 	internalcertificaterequests "github.com/cert-manager/cert-manager/internal/controller/certificaterequests"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
@@ -34,6 +36,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -50,7 +53,14 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 
 	crCopy := cr.DeepCopy()
 
+	// cancelled is set if the CertificateRequest was deleted while it was
+	// being signed, in which case there is nothing left to update.
+	cancelled := false
+
 	defer func() {
+		if cancelled {
+			return
+		}
 		if saveErr := c.updateCertificateRequestStatusAndAnnotations(ctx, cr, crCopy); saveErr != nil {
 			err = utilerrors.NewAggregate([]error{saveErr, err})
 		}
@@ -72,7 +82,9 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 	// If CertificateRequest has not been approved, exit early.
 	if !apiutil.CertificateRequestIsApproved(cr) {
 		dbg.Info("certificate request has not been approved")
-		c.recorder.Event(cr, corev1.EventTypeNormal, "WaitingForApproval", "Not signing CertificateRequest until it is Approved")
+		if c.recorder != nil {
+			c.recorder.Event(cr, corev1.EventTypeNormal, "WaitingForApproval", "Not signing CertificateRequest until it is Approved")
+		}
 		return nil
 	}
 
@@ -90,7 +102,7 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 
 	issuerObj, err := c.helper.GetGenericIssuer(crCopy.Spec.IssuerRef, crCopy.Namespace)
 	if k8sErrors.IsNotFound(err) {
-		c.reporter.Pending(crCopy, err, "IssuerNotFound",
+		c.reporter.Pending(crCopy, err, util.ReasonIssuerNotFound,
 			fmt.Sprintf("Referenced %q not found", apiutil.IssuerKind(crCopy.Spec.IssuerRef)))
 		return nil
 	}
@@ -105,7 +117,7 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 
 	issuerType, err := apiutil.NameForIssuer(issuerObj)
 	if err != nil {
-		c.reporter.Pending(crCopy, err, "IssuerTypeMissing",
+		c.reporter.Pending(crCopy, err, util.ReasonIssuerTypeMissing,
 			"Missing issuer type")
 		return nil
 	}
@@ -123,7 +135,7 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 		Type:   cmapi.IssuerConditionReady,
 		Status: cmmeta.ConditionTrue,
 	}) {
-		c.reporter.Pending(crCopy, nil, "IssuerNotReady",
+		c.reporter.Pending(crCopy, nil, util.ReasonIssuerNotReady,
 			"Referenced issuer does not have a Ready status condition")
 		return nil
 	}
@@ -135,10 +147,37 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 		return nil
 	}
 
+	// A malformed or tampered CSR is rejected for all issuers before it is
+	// passed to the issuer, which would otherwise fail with an unhelpful
+	// error from its backend.
+	if err := util.ValidateCSR(crCopy); err != nil {
+		c.reporter.Failed(crCopy, err, util.ReasonInvalidCSR, "The CSR of the request is invalid")
+		return nil
+	}
+
 	dbg.Info("invoking sign function as existing certificate does not exist")
 
-	// Attempt to call the Sign function on our issuer
-	resp, err := c.issuer.Sign(ctx, crCopy, issuerObj)
+	// Attempt to call the Sign function on our issuer. The call is cancelled
+	// if the CertificateRequest is deleted in the meantime.
+	signCtx, signDone := c.inflight.start(ctx, cr.UID)
+
+	// The outcome of the signing is logged as a single structured entry once
+	// the status of the request is known, with the same fields for all
+	// issuers.
+	signStart := c.clock.Now()
+	reportedReason := util.TrackReportedReason(crCopy)
+	defer func() {
+		reason := reportedReason()
+		if !cancelled {
+			util.LogSignResult(log, crCopy, issuerObj, reason, c.clock.Since(signStart), err)
+		}
+	}()
+
+	resp, err := c.issuer.Sign(signCtx, crCopy, issuerObj)
+	if cancelled = signDone(); cancelled {
+		dbg.Info("certificate request was deleted while it was being signed")
+		return nil
+	}
 	if err != nil {
 		log.Error(err, "error issuing certificate request")
 		return err
@@ -151,30 +190,85 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 		return nil
 	}
 
+	certificate, err := util.IncludeRootCA(issuerObj, resp.Certificate, resp.CA)
+	if err != nil {
+		c.reporter.Failed(crCopy, err, util.ReasonDecodeError, "Failed to decode the CA returned by the issuer")
+		return nil
+	}
+
+	certificate, err = util.OrderCertificateChain(issuerObj, certificate)
+	if err != nil {
+		c.reporter.Failed(crCopy, err, util.ReasonChainOrderError, "Failed to reorder the certificate chain returned by the issuer")
+		return nil
+	}
+
 	// Update to status with the new given response.
-	crCopy.Status.Certificate = resp.Certificate
+	crCopy.Status.Certificate = certificate
 	crCopy.Status.CA = resp.CA
 
 	// invalid cert
-	_, err = pki.DecodeX509CertificateBytes(crCopy.Status.Certificate)
+	chain, err := pki.DecodeX509CertificateChainBytes(crCopy.Status.Certificate)
 	if err != nil {
-		c.reporter.Failed(crCopy, err, "DecodeError", "Failed to decode returned certificate")
+		c.reporter.Failed(crCopy, err, util.ReasonDecodeError, "Failed to decode returned certificate")
 		return nil
 	}
 
+	// The issuer may have generated the private key of the certificate, in
+	// which case it is stored for the issuing controller to store it with the
+	// certificate instead of the key used to sign the CSR.
+	if len(resp.PrivateKey) > 0 {
+		if err := c.storeIssuedPrivateKey(ctx, crCopy, resp.PrivateKey); err != nil {
+			return err
+		}
+	}
+
+	// Record how many certificates the issuer returned, to help spot issuers
+	// that only return the leaf certificate.
+	crCopy.Status.ChainLength = ptr.To(len(chain))
+
+	// The serial number of the issued certificate is recorded for revocation
+	// lists and inventories. Issuers may return the chain in any order, so the
+	// leaf is picked from the chain rather than assumed to come first.
+	crCopy.Status.SerialNumber = pki.FormatSerialNumber(leafCertificate(chain).SerialNumber)
+
 	// Set condition to Ready.
 	c.reporter.Ready(crCopy)
 
 	return nil
 }
 
+// leafCertificate returns the leaf of the given certificate chain, which is
+// the first certificate of the chain once it is ordered. If the chain cannot
+// be ordered, for example as it contains unrelated certificates, the first
+// certificate is returned.
+func leafCertificate(chain []*x509.Certificate) *x509.Certificate {
+	bundle, err := pki.ParseSingleCertificateChain(chain)
+	if err != nil {
+		return chain[0]
+	}
+	leaf, err := pki.DecodeX509CertificateBytes(bundle.ChainPEM)
+	if err != nil {
+		return chain[0]
+	}
+	return leaf
+}
+
 func (c *Controller) updateCertificateRequestStatusAndAnnotations(ctx context.Context, oldCR, newCR *cmapi.CertificateRequest) error {
 	log := logf.FromContext(ctx, "updateStatus")
 
-	// if annotations changed we have to call .Update() and not .UpdateStatus()
+	// if annotations changed we have to call .Update() as well as
+	// .UpdateStatus(), as updating the resource does not update its status
 	if !reflect.DeepEqual(oldCR.Annotations, newCR.Annotations) {
 		log.V(logf.DebugLevel).Info("updating resource due to change in annotations", "diff", pretty.Diff(oldCR.Annotations, newCR.Annotations))
-		return c.updateOrApply(ctx, newCR)
+		updated, err := c.updateOrApply(ctx, newCR)
+		if err != nil {
+			return err
+		}
+
+		// The status is updated on top of the updated resource, so that the
+		// update is not rejected as a conflict.
+		newCR = newCR.DeepCopy()
+		newCR.ResourceVersion = updated.ResourceVersion
 	}
 
 	if apiequality.Semantic.DeepEqual(oldCR.Status, newCR.Status) {
@@ -185,13 +279,11 @@ func (c *Controller) updateCertificateRequestStatusAndAnnotations(ctx context.Co
 	return c.updateStatusOrApply(ctx, newCR)
 }
 
-func (c *Controller) updateOrApply(ctx context.Context, cr *cmapi.CertificateRequest) error {
+func (c *Controller) updateOrApply(ctx context.Context, cr *cmapi.CertificateRequest) (*cmapi.CertificateRequest, error) {
 	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
-		_, err := internalcertificaterequests.Apply(ctx, c.cmClient, c.fieldManager, cr)
-		return err
+		return internalcertificaterequests.Apply(ctx, c.cmClient, c.fieldManager, cr)
 	} else {
-		_, err := c.cmClient.CertmanagerV1().CertificateRequests(cr.Namespace).Update(ctx, cr, metav1.UpdateOptions{})
-		return err
+		return c.cmClient.CertmanagerV1().CertificateRequests(cr.Namespace).Update(ctx, cr, metav1.UpdateOptions{FieldManager: c.fieldManager})
 	}
 }
 
@@ -199,7 +291,7 @@ func (c *Controller) updateStatusOrApply(ctx context.Context, cr *cmapi.Certific
 	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
 		return internalcertificaterequests.ApplyStatus(ctx, c.cmClient, c.fieldManager, cr)
 	} else {
-		_, err := c.cmClient.CertmanagerV1().CertificateRequests(cr.Namespace).UpdateStatus(ctx, cr, metav1.UpdateOptions{})
+		_, err := c.cmClient.CertmanagerV1().CertificateRequests(cr.Namespace).UpdateStatus(ctx, cr, metav1.UpdateOptions{FieldManager: c.fieldManager})
 		return err
 	}
 }
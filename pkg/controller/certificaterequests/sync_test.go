@@ -22,11 +22,14 @@ import (
 	"crypto"
 	"crypto/rand"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/pem"
 	"errors"
+	"math/big"
 	"testing"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
@@ -62,6 +65,22 @@ func generateCSR(t *testing.T, secretKey crypto.Signer) []byte {
 	return csr
 }
 
+// corruptCSRSignature returns the PEM encoded CSR with its signature altered,
+// as if the CSR had been tampered with.
+func corruptCSRSignature(t *testing.T, csrPEM []byte) []byte {
+	t.Helper()
+	block, _ := pem.Decode(csrPEM)
+	if block == nil {
+		t.Fatal("failed to decode CSR PEM block")
+	}
+
+	// The signature is the last field of the CSR.
+	der := bytes.Clone(block.Bytes)
+	der[len(der)-1] ^= 0xff
+
+	return pem.EncodeToMemory(&pem.Block{Type: block.Type, Bytes: der})
+}
+
 func generateSelfSignedCert(t *testing.T, cr *cmapi.CertificateRequest, key crypto.Signer, notBefore, notAfter time.Time) []byte {
 	t.Helper()
 	template, err := pki.CertificateTemplateFromCertificateRequest(cr)
@@ -89,6 +108,49 @@ func generateSelfSignedCert(t *testing.T, cr *cmapi.CertificateRequest, key cryp
 	return pemByteBuffer.Bytes()
 }
 
+// generateCertificateChain returns a leaf certificate with the given key and
+// the CA certificate which signed it, both PEM encoded.
+func generateCertificateChain(t *testing.T, key crypto.Signer, notBefore, notAfter time.Time) (leafPEM, caPEM []byte) {
+	t.Helper()
+	caKey, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leafTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, ca, key.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+}
+
 func TestSync(t *testing.T) {
 	nowMetaTime := metav1.NewTime(fixedClockStart)
 
@@ -106,6 +168,7 @@ func TestSync(t *testing.T) {
 
 	csrRSAPEM := generateCSR(t, skRSA)
 	csrECPEM := generateCSR(t, skEC)
+	csrRSAPEMCorrupted := corruptCSRSignature(t, csrRSAPEM)
 
 	baseIssuer := gen.Issuer("test-issuer",
 		gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{}),
@@ -156,8 +219,22 @@ func TestSync(t *testing.T) {
 	certRSAPEMExpired := generateSelfSignedCert(t, baseCR, skRSA, fixedClockStart.Add(-time.Hour*13), fixedClockStart.Add(-time.Hour*12))
 
 	certECPEM := generateSelfSignedCert(t, baseCREC, skEC, fixedClockStart, fixedClockStart.Add(time.Hour*12))
+
+	keyRSAPEM, err := pki.EncodePKCS8PrivateKey(skRSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certRSA, err := pki.DecodeX509CertificateBytes(certRSAPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
 	certECPEMExpired := generateSelfSignedCert(t, baseCREC, skEC, fixedClockStart.Add(-time.Hour*13), fixedClockStart.Add(-time.Hour*12))
 
+	// The CA certificate comes first, as returned by some issuers.
+	leafPEM, caPEM := generateCertificateChain(t, skRSA, fixedClockStart, fixedClockStart.Add(time.Hour*12))
+	caFirstChainPEM := append(append([]byte{}, caPEM...), leafPEM...)
+
 	tests := map[string]testT{
 		"should return nil (no action) if group name if not 'cert-manager.io' or ''": {
 			certificateRequest: gen.CertificateRequestFrom(baseCR,
@@ -193,7 +270,7 @@ func TestSync(t *testing.T) {
 			),
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{baseIssuer, baseCR},
-				ExpectedEvents:     []string{},
+				ExpectedEvents:     []string{"Warning Denied The CertificateRequest was denied by an approval controller"},
 				ExpectedActions: []testpkg.Action{
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
@@ -240,7 +317,7 @@ func TestSync(t *testing.T) {
 			),
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{baseIssuer, baseCR},
-				ExpectedEvents:     []string{},
+				ExpectedEvents:     []string{"Warning Denied The CertificateRequest was denied by an approval controller"},
 				ExpectedActions: []testpkg.Action{
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
@@ -328,7 +405,7 @@ func TestSync(t *testing.T) {
 			),
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{baseIssuer, baseCR},
-				ExpectedEvents:     []string{},
+				ExpectedEvents:     []string{"Warning Denied The CertificateRequest was denied by an approval controller"},
 				ExpectedActions: []testpkg.Action{
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
@@ -542,6 +619,70 @@ func TestSync(t *testing.T) {
 				ExpectedActions:    []testpkg.Action{},
 			},
 		},
+		"if the CSR is badly formed then fail without calling sign": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestCSR([]byte("a bad csr")),
+			),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer,
+					gen.CertificateRequestFrom(baseCR,
+						gen.SetCertificateRequestCSR([]byte("a bad csr")),
+					)},
+				ExpectedEvents: []string{
+					"Warning InvalidCSR The CSR of the request is invalid: failed to parse the CSR: error decoding certificate request PEM block",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestCSR([]byte("a bad csr")),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Failed",
+								Message:            "The CSR of the request is invalid: failed to parse the CSR: error decoding certificate request PEM block",
+								LastTransitionTime: &nowMetaTime,
+							}),
+							gen.SetCertificateRequestFailureTime(nowMetaTime),
+						),
+					)),
+				},
+			},
+		},
+		"if the signature of the CSR is corrupted then fail without calling sign": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestCSR(csrRSAPEMCorrupted),
+			),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer,
+					gen.CertificateRequestFrom(baseCR,
+						gen.SetCertificateRequestCSR(csrRSAPEMCorrupted),
+					)},
+				ExpectedEvents: []string{
+					"Warning InvalidCSR The CSR of the request is invalid: failed to verify the signature of the CSR: crypto/rsa: verification error",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestCSR(csrRSAPEMCorrupted),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Failed",
+								Message:            "The CSR of the request is invalid: failed to verify the signature of the CSR: crypto/rsa: verification error",
+								LastTransitionTime: &nowMetaTime,
+							}),
+							gen.SetCertificateRequestFailureTime(nowMetaTime),
+						),
+					)),
+				},
+			},
+		},
 		"if calling sign errors, we should not update condition and return error to retry": {
 			certificateRequest: gen.CertificateRequestFrom(baseCR),
 			issuerImpl: &fake.Issuer{
@@ -628,6 +769,153 @@ func TestSync(t *testing.T) {
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestCertificate(certRSAPEM),
+							gen.SetCertificateRequestChainLength(1),
+							gen.SetCertificateRequestSerialNumberOf(certRSAPEM),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "Issued",
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					)),
+				},
+			},
+		},
+		"if calling sign returns a response with a certificate chain then record the chain length and the serial number of the leaf": {
+			certificateRequest: baseCR.DeepCopy(),
+			issuerImpl: &fake.Issuer{
+				FakeSign: func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+					return &issuer.IssueResponse{
+						Certificate: append(append([]byte{}, certRSAPEM...), certECPEM...),
+					}, nil
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer, baseCR.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestCertificate(append(append([]byte{}, certRSAPEM...), certECPEM...)),
+							gen.SetCertificateRequestChainLength(2),
+							gen.SetCertificateRequestSerialNumber(pki.FormatSerialNumber(certRSA.SerialNumber)),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "Issued",
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					)),
+				},
+			},
+		},
+		"if calling sign returns a response with a certificate chain which does not start with the leaf then record the serial number of the leaf": {
+			certificateRequest: baseCR.DeepCopy(),
+			issuerImpl: &fake.Issuer{
+				FakeSign: func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+					return &issuer.IssueResponse{
+						Certificate: caFirstChainPEM,
+					}, nil
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer, baseCR.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestCertificate(caFirstChainPEM),
+							gen.SetCertificateRequestChainLength(2),
+							gen.SetCertificateRequestSerialNumberOf(leafPEM),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "Issued",
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					)),
+				},
+			},
+		},
+		"if calling sign returns a response with a private key then store it in a Secret owned by the request": {
+			certificateRequest: baseCR.DeepCopy(),
+			issuerImpl: &fake.Issuer{
+				FakeSign: func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+					return &issuer.IssueResponse{
+						Certificate: certRSAPEM,
+						PrivateKey:  keyRSAPEM,
+					}, nil
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer, baseCR.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewCreateAction(
+						corev1.SchemeGroupVersion.WithResource("secrets"),
+						gen.DefaultTestNamespace,
+						&corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace:       gen.DefaultTestNamespace,
+								Name:            "test-cr-issued-key",
+								OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(baseCR, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateRequestKind))},
+								Labels: map[string]string{
+									cmapi.PartOfCertManagerControllerLabelKey: "true",
+								},
+							},
+							Data: map[string][]byte{
+								corev1.TLSPrivateKeyKey: keyRSAPEM,
+							},
+						},
+					)),
+					testpkg.NewAction(coretesting.NewUpdateAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.CertificateRequestIssuedPrivateKeyAnnotationKey: "test-cr-issued-key",
+							}),
+							gen.SetCertificateRequestCertificate(certRSAPEM),
+							gen.SetCertificateRequestChainLength(1),
+							gen.SetCertificateRequestSerialNumberOf(certRSAPEM),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "Issued",
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					)),
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.CertificateRequestIssuedPrivateKeyAnnotationKey: "test-cr-issued-key",
+							}),
+							gen.SetCertificateRequestCertificate(certRSAPEM),
+							gen.SetCertificateRequestChainLength(1),
+							gen.SetCertificateRequestSerialNumberOf(certRSAPEM),
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionTrue,
@@ -661,6 +949,8 @@ func TestSync(t *testing.T) {
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestCertificate(certRSAPEMExpired),
+							gen.SetCertificateRequestChainLength(1),
+							gen.SetCertificateRequestSerialNumberOf(certRSAPEMExpired),
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionTrue,
@@ -694,6 +984,8 @@ func TestSync(t *testing.T) {
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestCertificate(certECPEM),
+							gen.SetCertificateRequestChainLength(1),
+							gen.SetCertificateRequestSerialNumberOf(certECPEM),
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionTrue,
@@ -727,6 +1019,8 @@ func TestSync(t *testing.T) {
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestCertificate(certECPEMExpired),
+							gen.SetCertificateRequestChainLength(1),
+							gen.SetCertificateRequestSerialNumberOf(certECPEMExpired),
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionTrue,
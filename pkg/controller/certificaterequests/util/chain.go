@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// OrderCertificateChain reorders the PEM encoded certificate chain returned
+// by an issuer as requested by the chain order annotation of the issuer. The
+// chain is returned unchanged if the annotation is not set.
+func OrderCertificateChain(issuerObj cmapi.GenericIssuer, chainPEM []byte) ([]byte, error) {
+	order, ok := issuerObj.GetAnnotations()[cmapi.IssuerChainOrderAnnotationKey]
+	if !ok {
+		return chainPEM, nil
+	}
+
+	switch order {
+	case cmapi.ChainOrderLeafFirst, cmapi.ChainOrderLeafFirstWithoutRoot:
+	default:
+		return nil, fmt.Errorf("invalid value %q for the %q annotation, must be one of %q or %q",
+			order, cmapi.IssuerChainOrderAnnotationKey, cmapi.ChainOrderLeafFirst, cmapi.ChainOrderLeafFirstWithoutRoot)
+	}
+
+	bundle, err := pki.ParseSingleCertificateChainPEM(chainPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	// The parsed chain never contains a self-signed root, in which case the
+	// root is only kept as the CA. Add it back if it should be kept.
+	if order == cmapi.ChainOrderLeafFirst && len(bundle.CAPEM) > 0 && !bytes.Contains(bundle.ChainPEM, bundle.CAPEM) {
+		return append(bundle.ChainPEM, bundle.CAPEM...), nil
+	}
+
+	return bundle.ChainPEM, nil
+}
+
+// IncludeRootCA appends the CA returned by an issuer to the PEM encoded
+// certificate chain if the issuer is configured to include the root CA. The
+// CA is only appended if it is a self-signed root which is not already part
+// of the chain.
+func IncludeRootCA(issuerObj cmapi.GenericIssuer, chainPEM, caPEM []byte) ([]byte, error) {
+	if !includeRootCA(issuerObj.GetSpec()) || len(caPEM) == 0 || bytes.Contains(chainPEM, caPEM) {
+		return chainPEM, nil
+	}
+
+	ca, err := pki.DecodeX509CertificateBytes(caPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	if ca.CheckSignatureFrom(ca) != nil {
+		return chainPEM, nil
+	}
+
+	return append(append([]byte{}, chainPEM...), caPEM...), nil
+}
+
+func includeRootCA(spec *cmapi.IssuerSpec) bool {
+	switch {
+	case spec.CA != nil:
+		return spec.CA.IncludeRootCA
+	case spec.Vault != nil:
+		return spec.Vault.IncludeRootCA
+	case spec.Venafi != nil:
+		return spec.Venafi.IncludeRootCA
+	default:
+		return false
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	pem  []byte
+	key  crypto.Signer
+}
+
+func mustCreateCert(t *testing.T, issuer *testCert, name string, isCA bool) *testCert {
+	key, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		Version:               3,
+		BasicConstraintsValid: true,
+		SerialNumber:          big.NewInt(1),
+		PublicKey:             key.Public(),
+		IsCA:                  isCA,
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Minute),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+
+	issuerCert, issuerKey := template, crypto.Signer(key)
+	if issuer != nil {
+		issuerCert, issuerKey = issuer.cert, issuer.key
+	}
+
+	certPEM, cert, err := pki.SignCertificate(template, issuerCert, key.Public(), issuerKey)
+	require.NoError(t, err)
+
+	return &testCert{cert: cert, pem: certPEM, key: key}
+}
+
+func TestOrderCertificateChain(t *testing.T) {
+	root := mustCreateCert(t, nil, "root", true)
+	intA := mustCreateCert(t, root, "intermediate-a", true)
+	intB := mustCreateCert(t, intA, "intermediate-b", true)
+	leaf := mustCreateCert(t, intB, "leaf", false)
+
+	join := func(certs ...*testCert) []byte {
+		var out []byte
+		for _, c := range certs {
+			out = append(out, c.pem...)
+		}
+		return out
+	}
+
+	tests := map[string]struct {
+		annotations map[string]string
+		chain       []byte
+		expChain    []byte
+		expErr      bool
+	}{
+		"no annotation should return the chain unchanged": {
+			chain:    join(root, intA, intB, leaf),
+			expChain: join(root, intA, intB, leaf),
+		},
+		"root first chain should be reordered leaf first": {
+			annotations: map[string]string{cmapi.IssuerChainOrderAnnotationKey: cmapi.ChainOrderLeafFirst},
+			chain:       join(root, intA, intB, leaf),
+			expChain:    join(leaf, intB, intA, root),
+		},
+		"shuffled chain should be reordered leaf first": {
+			annotations: map[string]string{cmapi.IssuerChainOrderAnnotationKey: cmapi.ChainOrderLeafFirst},
+			chain:       join(intA, leaf, root, intB),
+			expChain:    join(leaf, intB, intA, root),
+		},
+		"shuffled chain without a root should be reordered leaf first": {
+			annotations: map[string]string{cmapi.IssuerChainOrderAnnotationKey: cmapi.ChainOrderLeafFirst},
+			chain:       join(intA, leaf, intB),
+			expChain:    join(leaf, intB, intA),
+		},
+		"shuffled chain should be reordered leaf first and have the root dropped": {
+			annotations: map[string]string{cmapi.IssuerChainOrderAnnotationKey: cmapi.ChainOrderLeafFirstWithoutRoot},
+			chain:       join(intB, root, leaf, intA),
+			expChain:    join(leaf, intB, intA),
+		},
+		"single leaf certificate should be returned as is": {
+			annotations: map[string]string{cmapi.IssuerChainOrderAnnotationKey: cmapi.ChainOrderLeafFirstWithoutRoot},
+			chain:       join(leaf),
+			expChain:    join(leaf),
+		},
+		"self-signed certificate should be kept when dropping the root": {
+			annotations: map[string]string{cmapi.IssuerChainOrderAnnotationKey: cmapi.ChainOrderLeafFirstWithoutRoot},
+			chain:       join(root),
+			expChain:    join(root),
+		},
+		"broken chain should error": {
+			annotations: map[string]string{cmapi.IssuerChainOrderAnnotationKey: cmapi.ChainOrderLeafFirst},
+			chain:       join(leaf, intA),
+			expErr:      true,
+		},
+		"invalid annotation value should error": {
+			annotations: map[string]string{cmapi.IssuerChainOrderAnnotationKey: "RootFirst"},
+			chain:       join(leaf, intB, intA),
+			expErr:      true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuer := gen.Issuer("issuer", gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{}))
+			issuer.Annotations = test.annotations
+
+			chain, err := OrderCertificateChain(issuer, test.chain)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			if !bytes.Equal(test.expChain, chain) {
+				t.Errorf("unexpected chain, exp=\n%s\ngot=\n%s", test.expChain, chain)
+			}
+		})
+	}
+}
+
+func TestIncludeRootCA(t *testing.T) {
+	root := mustCreateCert(t, nil, "root", true)
+	intA := mustCreateCert(t, root, "intermediate-a", true)
+	leaf := mustCreateCert(t, intA, "leaf", false)
+
+	chain := append(append([]byte{}, leaf.pem...), intA.pem...)
+
+	tests := map[string]struct {
+		issuer   cmapi.GenericIssuer
+		chain    []byte
+		ca       []byte
+		expChain []byte
+	}{
+		"CA issuer without includeRootCA should not include the root": {
+			issuer:   gen.Issuer("issuer", gen.SetIssuerCA(cmapi.CAIssuer{})),
+			chain:    chain,
+			ca:       root.pem,
+			expChain: chain,
+		},
+		"CA issuer with includeRootCA should include the root": {
+			issuer:   gen.Issuer("issuer", gen.SetIssuerCA(cmapi.CAIssuer{IncludeRootCA: true})),
+			chain:    chain,
+			ca:       root.pem,
+			expChain: append(append([]byte{}, chain...), root.pem...),
+		},
+		"Vault issuer without includeRootCA should not include the root": {
+			issuer:   gen.Issuer("issuer", gen.SetIssuerVault(cmapi.VaultIssuer{})),
+			chain:    chain,
+			ca:       root.pem,
+			expChain: chain,
+		},
+		"Vault issuer with includeRootCA should include the root": {
+			issuer:   gen.Issuer("issuer", gen.SetIssuerVault(cmapi.VaultIssuer{IncludeRootCA: true})),
+			chain:    chain,
+			ca:       root.pem,
+			expChain: append(append([]byte{}, chain...), root.pem...),
+		},
+		"Venafi issuer without includeRootCA should not include the root": {
+			issuer:   gen.Issuer("issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{})),
+			chain:    chain,
+			ca:       root.pem,
+			expChain: chain,
+		},
+		"Venafi issuer with includeRootCA should include the root": {
+			issuer:   gen.Issuer("issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{IncludeRootCA: true})),
+			chain:    chain,
+			ca:       root.pem,
+			expChain: append(append([]byte{}, chain...), root.pem...),
+		},
+		"root already in the chain should not be included twice": {
+			issuer:   gen.Issuer("issuer", gen.SetIssuerCA(cmapi.CAIssuer{IncludeRootCA: true})),
+			chain:    append(append([]byte{}, chain...), root.pem...),
+			ca:       root.pem,
+			expChain: append(append([]byte{}, chain...), root.pem...),
+		},
+		"CA which is not self-signed should not be included": {
+			issuer:   gen.Issuer("issuer", gen.SetIssuerCA(cmapi.CAIssuer{IncludeRootCA: true})),
+			chain:    leaf.pem,
+			ca:       intA.pem,
+			expChain: leaf.pem,
+		},
+		"no CA returned should leave the chain unchanged": {
+			issuer:   gen.Issuer("issuer", gen.SetIssuerCA(cmapi.CAIssuer{IncludeRootCA: true})),
+			chain:    chain,
+			expChain: chain,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			chain, err := IncludeRootCA(test.issuer, test.chain, test.ca)
+			require.NoError(t, err)
+
+			if !bytes.Equal(test.expChain, chain) {
+				t.Errorf("unexpected chain, exp=\n%s\ngot=\n%s", test.expChain, chain)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// ValidateCSR checks that the CSR of the CertificateRequest is well-formed
+// and signed by the private key of its public key, so that a malformed or
+// tampered CSR is rejected before it reaches the issuer rather than failing
+// with an error from the issuer backend.
+func ValidateCSR(cr *cmapi.CertificateRequest) error {
+	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+	if err != nil {
+		return fmt.Errorf("failed to parse the CSR: %w", err)
+	}
+
+	if err := csr.CheckSignature(); err != nil {
+		return fmt.Errorf("failed to verify the signature of the CSR: %w", err)
+	}
+
+	return nil
+}
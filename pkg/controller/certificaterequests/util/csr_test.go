@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestValidateCSR(t *testing.T) {
+	pk, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	csrPEM, err := gen.CSRWithSigner(pk, gen.SetCSRCommonName("test"))
+	require.NoError(t, err)
+
+	block, _ := pem.Decode(csrPEM)
+	require.NotNil(t, block)
+	tampered := bytes.Clone(block.Bytes)
+	tampered[len(tampered)-1] ^= 0xff
+
+	tests := map[string]struct {
+		csr    []byte
+		expErr string
+	}{
+		"a valid CSR is accepted": {
+			csr: csrPEM,
+		},
+		"a CSR which is not PEM encoded is rejected": {
+			csr:    []byte("a bad csr"),
+			expErr: "failed to parse the CSR: error decoding certificate request PEM block",
+		},
+		"a CSR which is not well-formed is rejected": {
+			csr:    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: block.Bytes[:len(block.Bytes)/2]}),
+			expErr: "failed to parse the CSR: asn1: syntax error: data truncated",
+		},
+		"a CSR whose signature is corrupted is rejected": {
+			csr:    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: tampered}),
+			expErr: "failed to verify the signature of the CSR: x509: ECDSA verification failure",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateCSR(gen.CertificateRequest("test", gen.SetCertificateRequestCSR(test.csr)))
+			if test.expErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, test.expErr)
+		})
+	}
+}
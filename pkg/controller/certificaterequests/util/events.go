@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// eventKey identifies the CertificateRequest an event was recorded for. The
+// UID is included so that a recreated CertificateRequest does not inherit the
+// events of a deleted one.
+type eventKey struct {
+	types.NamespacedName
+	uid types.UID
+}
+
+type recordedEvent struct {
+	eventType string
+	reason    Reason
+	message   string
+	time      time.Time
+}
+
+// eventDeduplicator suppresses identical consecutive events for the same
+// CertificateRequest which are recorded within a cooldown period, so that
+// CertificateRequests which are reconciled repeatedly do not flood the
+// event stream.
+type eventDeduplicator struct {
+	clock    clock.Clock
+	cooldown time.Duration
+
+	lock      sync.Mutex
+	last      map[eventKey]recordedEvent
+	lastPrune time.Time
+}
+
+func newEventDeduplicator(clock clock.Clock, cooldown time.Duration) *eventDeduplicator {
+	return &eventDeduplicator{
+		clock:    clock,
+		cooldown: cooldown,
+		last:     make(map[eventKey]recordedEvent),
+	}
+}
+
+// shouldRecord returns whether the given event should be recorded for the
+// CertificateRequest. The first occurrence of an event is always recorded, as
+// is any event whose type, reason or message differs from the previous event
+// recorded for the CertificateRequest.
+func (d *eventDeduplicator) shouldRecord(cr *cmapi.CertificateRequest, eventType string, reason Reason, message string) bool {
+	if d.cooldown <= 0 {
+		return true
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	now := d.clock.Now()
+	d.prune(now)
+
+	key := eventKey{
+		NamespacedName: types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name},
+		uid:            cr.UID,
+	}
+
+	last, ok := d.last[key]
+	if ok && last.eventType == eventType && last.reason == reason && last.message == message && now.Sub(last.time) < d.cooldown {
+		return false
+	}
+
+	d.last[key] = recordedEvent{
+		eventType: eventType,
+		reason:    reason,
+		message:   message,
+		time:      now,
+	}
+
+	return true
+}
+
+// prune removes events which were recorded longer than the cooldown ago, as
+// they can no longer suppress an event. Pruning is done at most once per
+// cooldown period.
+func (d *eventDeduplicator) prune(now time.Time) {
+	if now.Sub(d.lastPrune) < d.cooldown {
+		return
+	}
+
+	for key, event := range d.last {
+		if now.Sub(event.time) >= d.cooldown {
+			delete(d.last, key)
+		}
+	}
+
+	d.lastPrune = now
+}
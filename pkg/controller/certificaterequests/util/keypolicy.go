@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"fmt"
+	"slices"
+	"strings"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// VerifyKeyPolicy checks that the public key of the CSR of the given
+// CertificateRequest is allowed by the key policy of the issuer, i.e. that
+// RSA keys are at least MinRSAKeySize bits and that ECDSA keys are on one of
+// the AllowedECDSACurves. Weak keys are rejected locally, so that they never
+// reach the backend of the issuer.
+func VerifyKeyPolicy(cr *cmapi.CertificateRequest, issuerSpec *cmapi.IssuerSpec) error {
+	if issuerSpec.MinRSAKeySize == 0 && len(issuerSpec.AllowedECDSACurves) == 0 {
+		return nil
+	}
+
+	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+	if err != nil {
+		return err
+	}
+
+	switch pub := csr.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if size := pub.N.BitLen(); size < issuerSpec.MinRSAKeySize {
+			return fmt.Errorf("the RSA key of the request is %d bits, but the issuer requires at least %d bits", size, issuerSpec.MinRSAKeySize)
+		}
+	case *ecdsa.PublicKey:
+		curve := pub.Curve.Params().Name
+		if len(issuerSpec.AllowedECDSACurves) > 0 && !slices.Contains(issuerSpec.AllowedECDSACurves, curve) {
+			return fmt.Errorf("the ECDSA key of the request is on the %s curve, but the issuer only allows %s", curve, strings.Join(issuerSpec.AllowedECDSACurves, ", "))
+		}
+	}
+
+	return nil
+}
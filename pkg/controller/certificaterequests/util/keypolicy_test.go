@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestVerifyKeyPolicy(t *testing.T) {
+	requestFor := func(sk crypto.Signer) *cmapi.CertificateRequest {
+		csr, err := gen.CSRWithSigner(sk, gen.SetCSRCommonName("example.com"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return gen.CertificateRequest("cr", gen.SetCertificateRequestCSR(csr))
+	}
+
+	weakRSAKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p256Key, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p384Key, err := pki.GenerateECPrivateKey(pki.ECCurve384)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy := &cmapi.IssuerSpec{MinRSAKeySize: 2048, AllowedECDSACurves: []string{"P-384", "P-521"}}
+
+	tests := map[string]struct {
+		cr         *cmapi.CertificateRequest
+		issuerSpec *cmapi.IssuerSpec
+		expErr     string
+	}{
+		"without a key policy any key is allowed": {
+			cr:         requestFor(weakRSAKey),
+			issuerSpec: &cmapi.IssuerSpec{},
+		},
+		"without a key policy the request is not decoded": {
+			cr:         gen.CertificateRequest("cr", gen.SetCertificateRequestCSR([]byte("invalid"))),
+			issuerSpec: &cmapi.IssuerSpec{},
+		},
+		"an RSA key of the minimum size is allowed": {
+			cr:         requestFor(rsaKey),
+			issuerSpec: policy,
+		},
+		"an RSA key smaller than the minimum size is rejected": {
+			cr:         requestFor(weakRSAKey),
+			issuerSpec: policy,
+			expErr:     "the RSA key of the request is 1024 bits, but the issuer requires at least 2048 bits",
+		},
+		"an ECDSA key on an allowed curve is allowed": {
+			cr:         requestFor(p384Key),
+			issuerSpec: policy,
+		},
+		"an ECDSA key on another curve is rejected": {
+			cr:         requestFor(p256Key),
+			issuerSpec: policy,
+			expErr:     "the ECDSA key of the request is on the P-256 curve, but the issuer only allows P-384, P-521",
+		},
+		"an ECDSA key on any curve is allowed if only the RSA key size is restricted": {
+			cr:         requestFor(p256Key),
+			issuerSpec: &cmapi.IssuerSpec{MinRSAKeySize: 2048},
+		},
+		"an Ed25519 key is not restricted": {
+			cr:         requestFor(ed25519Key),
+			issuerSpec: policy,
+		},
+		"an invalid request is rejected": {
+			cr:         gen.CertificateRequest("cr", gen.SetCertificateRequestCSR([]byte("invalid"))),
+			issuerSpec: policy,
+			expErr:     "error decoding certificate request PEM block",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := VerifyKeyPolicy(test.cr, test.issuerSpec)
+			if test.expErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expErr)
+			}
+		})
+	}
+}
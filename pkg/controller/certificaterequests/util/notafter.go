@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// notAfterTolerance is the maximum difference between the requested notAfter
+// time and the notAfter time of the issued certificate. Issuers which only
+// accept a validity duration compute the notAfter time relative to the time
+// of issuance, which is slightly later than the time of the request.
+const notAfterTolerance = 5 * time.Minute
+
+// NotAfterDuration returns the duration between now and the notAfter time
+// requested by the CertificateRequest, for issuers which only accept a
+// validity duration. Zero is returned if no notAfter time is requested. An
+// error is returned if the requested notAfter time is not in the future.
+func NotAfterDuration(cr *cmapi.CertificateRequest, now time.Time) (time.Duration, error) {
+	if cr.Spec.NotAfter == nil {
+		return 0, nil
+	}
+
+	duration := cr.Spec.NotAfter.Sub(now)
+	if duration <= 0 {
+		return 0, fmt.Errorf("requested notAfter time %s is not in the future", cr.Spec.NotAfter.UTC().Format(time.RFC3339))
+	}
+
+	return duration, nil
+}
+
+// VerifyNotAfter checks that the issued certificate expires at the notAfter
+// time requested by the CertificateRequest, if any. Issuers may silently
+// ignore or cap the requested validity, for example because of a policy.
+func VerifyNotAfter(cr *cmapi.CertificateRequest, crt *x509.Certificate) error {
+	if cr.Spec.NotAfter == nil {
+		return nil
+	}
+
+	diff := crt.NotAfter.Sub(cr.Spec.NotAfter.Time)
+	if diff < -notAfterTolerance || diff > notAfterTolerance {
+		return fmt.Errorf("the issued certificate expires at %s instead of the requested notAfter time %s",
+			crt.NotAfter.UTC().Format(time.RFC3339), cr.Spec.NotAfter.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
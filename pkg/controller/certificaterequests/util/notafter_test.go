@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestNotAfterDuration(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		notAfter    *metav1.Time
+		expDuration time.Duration
+		expErr      string
+	}{
+		"no notAfter requested": {},
+		"notAfter in the future": {
+			notAfter:    &metav1.Time{Time: now.Add(time.Hour)},
+			expDuration: time.Hour,
+		},
+		"notAfter in the past": {
+			notAfter: &metav1.Time{Time: now.Add(-time.Hour)},
+			expErr:   "requested notAfter time 2023-12-31T23:00:00Z is not in the future",
+		},
+		"notAfter now": {
+			notAfter: &metav1.Time{Time: now},
+			expErr:   "requested notAfter time 2024-01-01T00:00:00Z is not in the future",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := gen.CertificateRequest("cr")
+			cr.Spec.NotAfter = test.notAfter
+
+			duration, err := NotAfterDuration(cr, now)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expDuration, duration)
+		})
+	}
+}
+
+func TestVerifyNotAfter(t *testing.T) {
+	notAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		notAfter    *metav1.Time
+		crtNotAfter time.Time
+		expErr      string
+	}{
+		"no notAfter requested": {
+			crtNotAfter: notAfter.Add(24 * time.Hour),
+		},
+		"certificate expires at the requested time": {
+			notAfter:    &metav1.Time{Time: notAfter},
+			crtNotAfter: notAfter,
+		},
+		"certificate expires within the tolerance": {
+			notAfter:    &metav1.Time{Time: notAfter},
+			crtNotAfter: notAfter.Add(time.Minute),
+		},
+		"certificate expires too early": {
+			notAfter:    &metav1.Time{Time: notAfter},
+			crtNotAfter: notAfter.Add(-time.Hour),
+			expErr:      "the issued certificate expires at 2023-12-31T23:00:00Z instead of the requested notAfter time 2024-01-01T00:00:00Z",
+		},
+		"certificate expires too late": {
+			notAfter:    &metav1.Time{Time: notAfter},
+			crtNotAfter: notAfter.Add(24 * time.Hour),
+			expErr:      "the issued certificate expires at 2024-01-02T00:00:00Z instead of the requested notAfter time 2024-01-01T00:00:00Z",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := gen.CertificateRequest("cr")
+			cr.Spec.NotAfter = test.notAfter
+
+			err := VerifyNotAfter(cr, &x509.Certificate{NotAfter: test.crtNotAfter})
+			if test.expErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+// Reason is a machine-readable reason for the state of a CertificateRequest,
+// as reported by a Reporter. Reasons are used as the reason of the events
+// sent for a CertificateRequest, and are stable so that they can be relied on
+// by automation. New reasons may be added, but existing reasons must not be
+// changed.
+type Reason string
+
+const (
+	// Reasons relating to the issuer referenced by a CertificateRequest.
+	ReasonIssuerNotFound    Reason = "IssuerNotFound"
+	ReasonIssuerNotReady    Reason = "IssuerNotReady"
+	ReasonIssuerTypeMissing Reason = "IssuerTypeMissing"
+
+	// Reasons relating to the Secrets and credentials used by an issuer.
+	// The CA, Vault and Venafi issuers report Secrets which are not found
+	// with ReasonSecretMissing, and the SelfSigned issuer with
+	// ReasonMissingSecret, as they did before reasons were defined.
+	ReasonSecretMissing       Reason = "SecretMissing"
+	ReasonMissingSecret       Reason = "MissingSecret"
+	ReasonErrorGettingSecret  Reason = "ErrorGettingSecret"
+	ReasonSecretGetError      Reason = "SecretGetError"
+	ReasonSecretInvalidData   Reason = "SecretInvalidData"
+	ReasonAuthenticationError Reason = "AuthenticationError"
+	ReasonInvalidCredentials  Reason = "InvalidCredentials"
+	ReasonVaultInitError      Reason = "VaultInitError"
+	ReasonVenafiInitError     Reason = "VenafiInitError"
+
+	// Reasons relating to the CertificateRequest itself.
+	ReasonRequestParsingError Reason = "RequestParsingError"
+	ReasonDecodeError         Reason = "DecodeError"
+	ReasonErrorParsingKey     Reason = "ErrorParsingKey"
+	ReasonErrorPublicKey      Reason = "ErrorPublicKey"
+	ReasonErrorKeyMatch       Reason = "ErrorKeyMatch"
+	ReasonErrorGenerating     Reason = "ErrorGenerating"
+	ReasonMissingAnnotation   Reason = "MissingAnnotation"
+	ReasonCustomFieldsError   Reason = "CustomFieldsError"
+	ReasonInvalidZone         Reason = "InvalidZone"
+	ReasonInvalidFriendlyName Reason = "InvalidFriendlyName"
+	ReasonInvalidLocation     Reason = "InvalidLocation"
+	ReasonInvalidOrigin       Reason = "InvalidOrigin"
+	ReasonNoMatchingZone      Reason = "NoMatchingZone"
+	ReasonInvalidPathLen      Reason = "InvalidPathLen"
+	ReasonPolicyViolation     Reason = "PolicyViolation"
+	ReasonDenied              Reason = "Denied"
+	ReasonInvalidCSR          Reason = "InvalidCSR"
+	ReasonDomainNotAllowed    Reason = "DomainNotAllowed"
+
+	// Reasons relating to signing the CertificateRequest.
+	ReasonIssuancePending               Reason = "IssuancePending"
+	ReasonTimeout                       Reason = "Timeout"
+	ReasonIssuanceDeadlineExceeded      Reason = "IssuanceDeadlineExceeded"
+	ReasonSigningError                  Reason = "SigningError"
+	ReasonErrorSigning                  Reason = "ErrorSigning"
+	ReasonRequestError                  Reason = "RequestError"
+	ReasonRequestAborted                Reason = "RequestAborted"
+	ReasonRequestMutationError          Reason = "RequestMutationError"
+	ReasonRetrieveError                 Reason = "RetrieveError"
+	ReasonParseError                    Reason = "ParseError"
+	ReasonNotAllowedCA                  Reason = "NotAllowedCA"
+	ReasonUsagesNotPermitted            Reason = "UsagesNotPermitted"
+	ReasonNotAfterNotHonored            Reason = "NotAfterNotHonored"
+	ReasonLegacyExtensionsNotHonored    Reason = "LegacyExtensionsNotHonored"
+	ReasonMustStapleNotSupported        Reason = "MustStapleNotSupported"
+	ReasonWeakKey                       Reason = "WeakKey"
+	ReasonInvalidNotAfter               Reason = "InvalidNotAfter"
+	ReasonChainOrderError               Reason = "ChainOrderError"
+	ReasonIncompleteChain               Reason = "IncompleteChain"
+	ReasonUntrustedChain                Reason = "UntrustedChain"
+	ReasonUnsupportedSignatureAlgorithm Reason = "UnsupportedSignatureAlgorithm"
+	ReasonDryRunFailed                  Reason = "DryRunFailed"
+	ReasonDryRunValidated               Reason = "DryRunValidated"
+	ReasonCertificateIssued             Reason = "CertificateIssued"
+	ReasonReused                        Reason = "Reused"
+	ReasonBackendUnavailable            Reason = "BackendUnavailable"
+	ReasonRateLimited                   Reason = "RateLimited"
+	ReasonDurationAdjusted              Reason = "DurationAdjusted"
+	ReasonPolicyUnavailable             Reason = "PolicyUnavailable"
+
+	// Reasons relating to the ACME Order created for a CertificateRequest.
+	ReasonOrderCreated       Reason = "OrderCreated"
+	ReasonOrderPending       Reason = "OrderPending"
+	ReasonOrderFailed        Reason = "OrderFailed"
+	ReasonInvalidOrder       Reason = "InvalidOrder"
+	ReasonOrderBuildingError Reason = "OrderBuildingError"
+	ReasonOrderCreatingError Reason = "OrderCreatingError"
+	ReasonOrderGetError      Reason = "OrderGetError"
+)
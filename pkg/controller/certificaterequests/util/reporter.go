@@ -18,6 +18,7 @@ package util
 
 import (
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,22 +35,40 @@ const (
 )
 
 // A Reporter updates the Status of a CertificateRequest and sends an event
-// to the Kubernetes Events API.
+// to the Kubernetes Events API, if it has an EventRecorder.
 type Reporter struct {
 	clock    clock.Clock
 	recorder record.EventRecorder
+	events   *eventDeduplicator
 }
 
-// NewReporter returns a Reporter that will send events to the given EventRecorder.
-func NewReporter(clock clock.Clock, recorder record.EventRecorder) *Reporter {
+// NewReporter returns a Reporter that will send events to the given
+// EventRecorder. Identical consecutive events for a CertificateRequest are
+// only sent once per eventCooldown. An eventCooldown of zero or less means all
+// events are sent. If the EventRecorder is nil, no events are sent but the
+// Status of CertificateRequests is still updated.
+func NewReporter(clock clock.Clock, recorder record.EventRecorder, eventCooldown time.Duration) *Reporter {
 	return &Reporter{
 		clock:    clock,
 		recorder: recorder,
+		events:   newEventDeduplicator(clock, eventCooldown),
 	}
 }
 
+// event sends an event for the CertificateRequest, unless an identical event
+// was sent for it within the event cooldown.
+func (r *Reporter) event(cr *cmapi.CertificateRequest, eventType string, reason Reason, message string) {
+	if r.recorder == nil {
+		return
+	}
+	if !r.events.shouldRecord(cr, eventType, reason, message) {
+		return
+	}
+	r.recorder.Event(cr, eventType, string(reason), message)
+}
+
 // Failed marks a CertificateRequest as terminally failed and sends a corresponding event.
-func (r *Reporter) Failed(cr *cmapi.CertificateRequest, err error, reason, message string) {
+func (r *Reporter) Failed(cr *cmapi.CertificateRequest, err error, reason Reason, message string) {
 	// Set the FailureTime to c.clock.Now(), only if it has not been already set.
 	if cr.Status.FailureTime == nil {
 		nowTime := metav1.NewTime(r.clock.Now())
@@ -57,14 +76,34 @@ func (r *Reporter) Failed(cr *cmapi.CertificateRequest, err error, reason, messa
 	}
 
 	message = fmt.Sprintf("%s: %v", message, err)
-	r.recorder.Event(cr, corev1.EventTypeWarning, reason, message)
+	recordReason(cr, reason)
+	r.event(cr, corev1.EventTypeWarning, reason, message)
 	apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady,
 		cmmeta.ConditionFalse, cmapi.CertificateRequestReasonFailed, message)
 
 }
 
-// Denied marks a CertificateRequest as terminally denied. No event is sent as it is
-// expected to be sent by the approval controller.
+// DryRunValidated marks a CertificateRequest that was validated by the issuer
+// without a certificate being issued, and sends a corresponding event. The
+// CertificateRequest is marked as terminally failed as it will never be issued.
+func (r *Reporter) DryRunValidated(cr *cmapi.CertificateRequest, message string) {
+	// Set the FailureTime to c.clock.Now(), only if it has not been already set.
+	if cr.Status.FailureTime == nil {
+		nowTime := metav1.NewTime(r.clock.Now())
+		cr.Status.FailureTime = &nowTime
+	}
+
+	recordReason(cr, ReasonDryRunValidated)
+	r.event(cr, corev1.EventTypeNormal, ReasonDryRunValidated, message)
+	apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady,
+		cmmeta.ConditionFalse, cmapi.CertificateRequestReasonFailed, message)
+}
+
+// Denied marks a CertificateRequest as terminally denied and sends a
+// corresponding event, so that it is visible that the issuer will not sign
+// it.
+//
+// The event is only sent if the CertificateRequest is not already denied.
 func (r *Reporter) Denied(cr *cmapi.CertificateRequest) {
 	// Set the FailureTime to c.clock.Now(), only if it has not been already set.
 	if cr.Status.FailureTime == nil {
@@ -73,6 +112,10 @@ func (r *Reporter) Denied(cr *cmapi.CertificateRequest) {
 	}
 
 	message := "The CertificateRequest was denied by an approval controller"
+	recordReason(cr, ReasonDenied)
+	if apiutil.CertificateRequestReadyReason(cr) != cmapi.CertificateRequestReasonDenied {
+		r.event(cr, corev1.EventTypeWarning, ReasonDenied, message)
+	}
 	apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady,
 		cmmeta.ConditionFalse, cmapi.CertificateRequestReasonDenied, message)
 }
@@ -86,26 +129,44 @@ func (r *Reporter) InvalidRequest(cr *cmapi.CertificateRequest, reason, message
 
 // Pending marks a CertificateRequest as pending and sends a corresponding event.
 //
-// The event is only sent if the CertificateRequest is not already pending.
-func (r *Reporter) Pending(cr *cmapi.CertificateRequest, err error, reason, message string) {
+// The event is only sent if the CertificateRequest is not already pending
+// with the same message.
+func (r *Reporter) Pending(cr *cmapi.CertificateRequest, err error, reason Reason, message string) {
 	if err != nil {
 		message = fmt.Sprintf("%s: %v", message, err)
 	}
 
-	// If pending condition not already set then fire a Pending Event. This is to
-	// reduce strain on the API server and avoid rate limiting ourselves for
-	// Event creation.
-	if apiutil.CertificateRequestReadyReason(cr) != cmapi.CertificateRequestReasonPending {
-		r.recorder.Event(cr, corev1.EventTypeNormal, reason, message)
+	recordReason(cr, reason)
+
+	// If pending condition not already set with the same message then fire a
+	// Pending Event, for example so that the time a pending request will be
+	// retried in is visible. This is to reduce strain on the API server and
+	// avoid rate limiting ourselves for Event creation.
+	if cond := apiutil.GetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady); cond == nil ||
+		cond.Reason != cmapi.CertificateRequestReasonPending || cond.Message != message {
+		r.event(cr, corev1.EventTypeNormal, reason, message)
 	}
 
 	apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady,
 		cmmeta.ConditionFalse, cmapi.CertificateRequestReasonPending, message)
 }
 
+// Reused sends an event for a CertificateRequest which is fulfilled with a
+// certificate the issuer issued previously, rather than a new certificate.
+func (r *Reporter) Reused(cr *cmapi.CertificateRequest, message string) {
+	r.event(cr, corev1.EventTypeNormal, ReasonReused, message)
+}
+
+// DurationAdjusted sends an event for a CertificateRequest whose certificate
+// will be issued with a different duration than the one it requests.
+func (r *Reporter) DurationAdjusted(cr *cmapi.CertificateRequest, message string) {
+	r.event(cr, corev1.EventTypeNormal, ReasonDurationAdjusted, message)
+}
+
 // Ready marks a CertificateRequest as Ready and sends a corresponding event.
 func (r *Reporter) Ready(cr *cmapi.CertificateRequest) {
-	r.recorder.Event(cr, corev1.EventTypeNormal, "CertificateIssued", readyMessage)
+	recordReason(cr, ReasonCertificateIssued)
+	r.event(cr, corev1.EventTypeNormal, ReasonCertificateIssued, readyMessage)
 	apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady,
 		cmmeta.ConditionTrue, cmapi.CertificateRequestReasonIssued, readyMessage)
 }
@@ -108,6 +108,14 @@ func TestReporter(t *testing.T) {
 		LastTransitionTime: &nowMetaTime,
 	}
 
+	dryRunCondition := cmapi.CertificateRequestCondition{
+		Type:               cmapi.CertificateRequestConditionReady,
+		Reason:             "Failed",
+		Message:            exampleMessage,
+		Status:             "False",
+		LastTransitionTime: &nowMetaTime,
+	}
+
 	tests := map[string]reporterT{
 		"a failed report should update the conditions and set FailureTime as it is nil": {
 			certificateRequest: gen.CertificateRequestFrom(baseCR),
@@ -169,7 +177,7 @@ func TestReporter(t *testing.T) {
 			call: "pending",
 		},
 
-		"a pending report should update the conditions and not send an event as a Pending condition already exists": {
+		"a pending report should update the conditions and send an event as the message of the existing Pending condition changed": {
 			certificateRequest: gen.CertificateRequestFrom(baseCR,
 				gen.SetCertificateRequestStatusCondition(existingPendingCondition),
 			),
@@ -177,6 +185,22 @@ func TestReporter(t *testing.T) {
 			message: exampleMessage,
 			reason:  exampleReason,
 
+			expectedEvents: []string{
+				"Normal ThisIsAReason this is a message: this is an error",
+			},
+			expectedConditions:  []cmapi.CertificateRequestCondition{pendingCondition},
+			expectedFailureTime: nil,
+
+			call: "pending",
+		},
+		"a pending report should update the conditions and not send an event as a Pending condition with the same message already exists": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestStatusCondition(pendingCondition),
+			),
+			err:     exampleErr,
+			message: exampleMessage,
+			reason:  exampleReason,
+
 			// No event sent
 			expectedEvents:      []string{},
 			expectedConditions:  []cmapi.CertificateRequestCondition{pendingCondition},
@@ -213,16 +237,31 @@ func TestReporter(t *testing.T) {
 			call: "ready",
 		},
 
-		"a denied report should update the Ready condition to 'Denied'": {
-			certificateRequest:  gen.CertificateRequestFrom(baseCR),
-			expectedEvents:      []string{},
+		"a dry run report should update the conditions, set FailureTime and send an event": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR),
+			message:            exampleMessage,
+
+			expectedEvents: []string{
+				"Normal DryRunValidated this is a message",
+			},
+			expectedConditions:  []cmapi.CertificateRequestCondition{dryRunCondition},
+			expectedFailureTime: &nowMetaTime,
+
+			call: "dry-run-validated",
+		},
+
+		"a denied report should update the Ready condition to 'Denied' and send an event": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR),
+			expectedEvents: []string{
+				"Warning Denied The CertificateRequest was denied by an approval controller",
+			},
 			expectedConditions:  []cmapi.CertificateRequestCondition{deniedReadyCondition},
 			expectedFailureTime: &nowMetaTime,
 
 			call: "denied",
 		},
 
-		"a denied report should update the Ready condition to 'Denied', but not update failure time existing": {
+		"a denied report should update the Ready condition to 'Denied', but not update failure time existing or send an event": {
 			certificateRequest: gen.CertificateRequestFrom(baseCR,
 				gen.SetCertificateRequestStatusCondition(deniedReadyCondition),
 				gen.SetCertificateRequestFailureTime(oldMetaTime),
@@ -246,17 +285,19 @@ func TestReporter(t *testing.T) {
 
 func (tt *reporterT) runTest(t *testing.T) {
 	recorder := new(controllertest.FakeRecorder)
-	reporter := NewReporter(fixedClock, recorder)
+	reporter := NewReporter(fixedClock, recorder, 0)
 
 	switch tt.call {
 	case "failed":
 		reporter.Failed(tt.certificateRequest, tt.err,
-			tt.reason, tt.message)
+			Reason(tt.reason), tt.message)
 	case "invalid-request":
 		reporter.InvalidRequest(tt.certificateRequest, tt.reason, tt.message)
 	case "pending":
 		reporter.Pending(tt.certificateRequest, tt.err,
-			tt.reason, tt.message)
+			Reason(tt.reason), tt.message)
+	case "dry-run-validated":
+		reporter.DryRunValidated(tt.certificateRequest, tt.message)
 	case "denied":
 		reporter.Denied(tt.certificateRequest)
 	default:
@@ -294,3 +335,101 @@ func (tt *reporterT) runTest(t *testing.T) {
 func conditionsToString(conds []cmapi.CertificateRequestCondition) string {
 	return fmt.Sprintf("%+v", conds)
 }
+
+func TestReporterEventCooldown(t *testing.T) {
+	clock := clocktesting.NewFakeClock(fixedClockStart)
+	recorder := new(controllertest.FakeRecorder)
+	reporter := NewReporter(clock, recorder, 5*time.Minute)
+
+	cr := gen.CertificateRequest("test")
+	otherCR := gen.CertificateRequest("other")
+	errA, errB := errors.New("error a"), errors.New("error b")
+
+	steps := []struct {
+		cr      *cmapi.CertificateRequest
+		err     error
+		advance time.Duration
+	}{
+		// The first occurrence is always sent.
+		{cr: cr, err: errA},
+		// An identical event within the cooldown is suppressed.
+		{cr: cr, err: errA, advance: time.Minute},
+		// An event for another CertificateRequest is sent.
+		{cr: otherCR, err: errA},
+		// A changed message is sent.
+		{cr: cr, err: errB},
+		// Changing back to the previous message is sent.
+		{cr: cr, err: errA},
+		// An identical event after the cooldown is sent again.
+		{cr: cr, err: errA, advance: 5 * time.Minute},
+	}
+
+	for _, step := range steps {
+		clock.Step(step.advance)
+		reporter.Failed(step.cr, step.err, ReasonSigningError, "Failed to sign")
+	}
+
+	expectedEvents := []string{
+		"Warning SigningError Failed to sign: error a",
+		"Warning SigningError Failed to sign: error a",
+		"Warning SigningError Failed to sign: error b",
+		"Warning SigningError Failed to sign: error a",
+		"Warning SigningError Failed to sign: error a",
+	}
+	if !slices.Equal(expectedEvents, recorder.Events) {
+		t.Errorf("got unexpected events, exp=%+v got=%+v", expectedEvents, recorder.Events)
+	}
+
+	// The condition is still updated when the event is suppressed.
+	clock.Step(time.Minute)
+	cr.Status.Conditions = nil
+	reporter.Failed(cr, errA, ReasonSigningError, "Failed to sign")
+	if len(recorder.Events) != len(expectedEvents) {
+		t.Errorf("expected identical event within the cooldown to be suppressed, got events %+v", recorder.Events)
+	}
+	if apiutil.CertificateRequestReadyReason(cr) != cmapi.CertificateRequestReasonFailed {
+		t.Errorf("expected Ready condition to be set with reason Failed, got %+v", cr.Status.Conditions)
+	}
+}
+
+func TestReporterWithoutRecorder(t *testing.T) {
+	reporter := NewReporter(fixedClock, nil, 5*time.Minute)
+	err := errors.New("this is an error")
+
+	calls := map[string]struct {
+		call           func(cr *cmapi.CertificateRequest)
+		expectedReason string
+	}{
+		"failed": {
+			call:           func(cr *cmapi.CertificateRequest) { reporter.Failed(cr, err, ReasonSigningError, "Failed to sign") },
+			expectedReason: cmapi.CertificateRequestReasonFailed,
+		},
+		"pending": {
+			call:           func(cr *cmapi.CertificateRequest) { reporter.Pending(cr, err, ReasonIssuancePending, "Pending") },
+			expectedReason: cmapi.CertificateRequestReasonPending,
+		},
+		"dry-run-validated": {
+			call:           func(cr *cmapi.CertificateRequest) { reporter.DryRunValidated(cr, "Validated") },
+			expectedReason: cmapi.CertificateRequestReasonFailed,
+		},
+		"denied": {
+			call:           func(cr *cmapi.CertificateRequest) { reporter.Denied(cr) },
+			expectedReason: cmapi.CertificateRequestReasonDenied,
+		},
+		"ready": {
+			call:           func(cr *cmapi.CertificateRequest) { reporter.Ready(cr) },
+			expectedReason: cmapi.CertificateRequestReasonIssued,
+		},
+	}
+
+	for name, test := range calls {
+		t.Run(name, func(t *testing.T) {
+			cr := gen.CertificateRequest("test")
+			test.call(cr)
+
+			if reason := apiutil.CertificateRequestReadyReason(cr); reason != test.expectedReason {
+				t.Errorf("expected Ready condition to be set with reason %q, got %+v", test.expectedReason, cr.Status.Conditions)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto"
+	"crypto/x509"
+	"fmt"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// SignatureHash returns the hash algorithm requested by the given
+// annotations of a CertificateRequest for the signature of its certificate,
+// using the `cert-manager.io/signature-hash-algorithm` annotation. Zero is
+// returned if no hash algorithm is requested, in which case the issuer
+// chooses it.
+func SignatureHash(annotations map[string]string) (crypto.Hash, error) {
+	value, ok := annotations[cmapi.CertificateRequestSignatureHashAnnotationKey]
+	if !ok {
+		return 0, nil
+	}
+
+	hash, err := pki.ParseSignatureHash(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %q annotation: %w", cmapi.CertificateRequestSignatureHashAnnotationKey, err)
+	}
+
+	return hash, nil
+}
+
+// VerifySignatureHash checks that the issued certificate is signed with the
+// requested hash algorithm, if any. Issuers may silently sign with another
+// hash algorithm, for example because of a policy.
+func VerifySignatureHash(crt *x509.Certificate, requested crypto.Hash) error {
+	if requested == 0 {
+		return nil
+	}
+
+	if pki.SignatureAlgorithmHash(crt.SignatureAlgorithm) != requested {
+		return fmt.Errorf("the issued certificate is signed with %s instead of the requested %s hash algorithm", crt.SignatureAlgorithm, requested)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto"
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestSignatureHash(t *testing.T) {
+	tests := map[string]struct {
+		annotations map[string]string
+		expHash     crypto.Hash
+		expErr      string
+	}{
+		"no annotation": {},
+		"supported hash algorithm": {
+			annotations: map[string]string{cmapi.CertificateRequestSignatureHashAnnotationKey: "SHA384"},
+			expHash:     crypto.SHA384,
+		},
+		"unsupported hash algorithm": {
+			annotations: map[string]string{cmapi.CertificateRequestSignatureHashAnnotationKey: "MD5"},
+			expErr:      `invalid "cert-manager.io/signature-hash-algorithm" annotation: unsupported signature hash algorithm "MD5", must be one of SHA256, SHA384 or SHA512`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			hash, err := SignatureHash(test.annotations)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expHash, hash)
+		})
+	}
+}
+
+func TestVerifySignatureHash(t *testing.T) {
+	tests := map[string]struct {
+		algorithm x509.SignatureAlgorithm
+		requested crypto.Hash
+		expErr    string
+	}{
+		"no hash algorithm requested": {
+			algorithm: x509.SHA256WithRSA,
+		},
+		"certificate signed with the requested hash algorithm": {
+			algorithm: x509.ECDSAWithSHA384,
+			requested: crypto.SHA384,
+		},
+		"certificate signed with another hash algorithm": {
+			algorithm: x509.SHA256WithRSA,
+			requested: crypto.SHA512,
+			expErr:    "the issued certificate is signed with SHA256-RSA instead of the requested SHA-512 hash algorithm",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := VerifySignatureHash(&x509.Certificate{SignatureAlgorithm: test.algorithm}, test.requested)
+			if test.expErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expErr)
+			}
+		})
+	}
+}
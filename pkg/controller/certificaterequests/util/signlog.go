@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// SignResultLogMessage is the message of the structured log entry written
+// for the outcome of every signing of a CertificateRequest by the signing
+// controllers, see LogSignResult.
+const SignResultLogMessage = "certificate request sign result"
+
+// The keys of the structured log entry written for the outcome of every
+// signing of a CertificateRequest. Log pipelines and dashboards rely on these
+// keys, so they must not be changed.
+const (
+	// SignLogKeyIssuer is the namespaced name of the issuer, or the name of
+	// the cluster issuer, which signed the request.
+	SignLogKeyIssuer = "issuer"
+	// SignLogKeyRequest is the namespaced name of the CertificateRequest.
+	SignLogKeyRequest = "cr"
+	// SignLogKeyReason is the reason of the last report which set the Ready
+	// condition of the CertificateRequest while it was signed, if any.
+	SignLogKeyReason = "reason"
+	// SignLogKeyDuration is the time taken to sign the request, in seconds.
+	SignLogKeyDuration = "duration"
+	// SignLogKeyResult is one of the SignResult values.
+	SignLogKeyResult = "result"
+)
+
+// SignResult is the result of a single signing of a CertificateRequest, as
+// logged in the SignLogKeyResult field.
+type SignResult string
+
+const (
+	// SignResultIssued means that the certificate was issued.
+	SignResultIssued SignResult = "Issued"
+	// SignResultPending means that the certificate has not been issued yet.
+	SignResultPending SignResult = "Pending"
+	// SignResultFailed means that the request was terminally failed or
+	// denied.
+	SignResultFailed SignResult = "Failed"
+	// SignResultError means that the signing returned an error and will be
+	// retried.
+	SignResultError SignResult = "Error"
+)
+
+// trackedReasons holds the reason of the last report which set the Ready
+// condition of each CertificateRequest being signed, by the address of the CertificateRequest
+// passed to the issuer. Issuers report on CertificateRequests with their own
+// Reporter, so the reasons are tracked across all Reporters.
+var trackedReasons sync.Map
+
+// TrackReportedReason starts tracking the reports which set the Ready
+// condition of the given CertificateRequest, made by any Reporter. The
+// returned function stops tracking them, and returns the reason of the last
+// such report made in the meantime, if any. It must be called once the
+// CertificateRequest has been signed.
+func TrackReportedReason(cr *cmapi.CertificateRequest) func() Reason {
+	reason := new(Reason)
+	trackedReasons.Store(cr, reason)
+
+	return func() Reason {
+		trackedReasons.Delete(cr)
+		return *reason
+	}
+}
+
+// recordReason records the reason of a report which sets the Ready condition
+// of the CertificateRequest, if its reports are tracked.
+func recordReason(cr *cmapi.CertificateRequest, reason Reason) {
+	if tracked, ok := trackedReasons.Load(cr); ok {
+		*tracked.(*Reason) = reason
+	}
+}
+
+// LogSignResult writes a single structured log entry for the outcome of the
+// signing of the CertificateRequest, with stable field names, see
+// SignLogKeyIssuer and the other keys. err is the error returned by the
+// signing, if any.
+func LogSignResult(log logr.Logger, cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer, reason Reason, duration time.Duration, err error) {
+	result := signResult(cr, err)
+
+	issuer := issuerObj.GetName()
+	if ns := issuerObj.GetNamespace(); ns != "" {
+		issuer = ns + "/" + issuer
+	}
+
+	values := []any{
+		SignLogKeyIssuer, issuer,
+		SignLogKeyRequest, cr.Namespace + "/" + cr.Name,
+		SignLogKeyReason, string(reason),
+		SignLogKeyDuration, duration.Seconds(),
+		SignLogKeyResult, string(result),
+	}
+	if err != nil {
+		values = append(values, "error", err.Error())
+	}
+
+	log.V(logf.InfoLevel).Info(SignResultLogMessage, values...)
+}
+
+func signResult(cr *cmapi.CertificateRequest, err error) SignResult {
+	if err != nil {
+		return SignResultError
+	}
+
+	switch apiutil.CertificateRequestReadyReason(cr) {
+	case cmapi.CertificateRequestReasonIssued:
+		return SignResultIssued
+	case cmapi.CertificateRequestReasonFailed, cmapi.CertificateRequestReasonDenied:
+		return SignResultFailed
+	default:
+		return SignResultPending
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestTrackReportedReason(t *testing.T) {
+	reporter := NewReporter(fixedClock, nil, 0)
+	cr := gen.CertificateRequest("test-cr")
+	untracked := gen.CertificateRequest("test-cr")
+
+	reportedReason := TrackReportedReason(cr)
+	reporter.Pending(cr, nil, ReasonIssuancePending, "pending")
+	reporter.Failed(untracked, errors.New("this is an error"), ReasonTimeout, "failed")
+	reporter.Failed(cr, errors.New("this is an error"), ReasonDecodeError, "failed")
+	reporter.Reused(cr, "reused")
+	assert.Equal(t, ReasonDecodeError, reportedReason())
+
+	// The reports are no longer tracked once the returned func is called.
+	reporter.Ready(cr)
+	_, tracked := trackedReasons.Load(cr)
+	assert.False(t, tracked)
+}
+
+func TestLogSignResult(t *testing.T) {
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerNamespace("test-ns"))
+	clusterIssuer := gen.ClusterIssuer("test-cluster-issuer")
+	reporter := NewReporter(fixedClock, nil, 0)
+
+	tests := map[string]struct {
+		issuerObj cmapi.GenericIssuer
+		report    func(cr *cmapi.CertificateRequest)
+		err       error
+
+		expected []string
+	}{
+		"an issued request is logged with the reason of its report": {
+			issuerObj: issuer,
+			report:    reporter.Ready,
+			expected: []string{
+				`"issuer"="test-ns/test-issuer"`,
+				`"cr"="test-ns/test-cr"`,
+				`"reason"="CertificateIssued"`,
+				`"duration"=2.5`,
+				`"result"="Issued"`,
+			},
+		},
+		"a pending request is logged with the name of the cluster issuer": {
+			issuerObj: clusterIssuer,
+			report: func(cr *cmapi.CertificateRequest) {
+				reporter.Pending(cr, nil, ReasonTimeout, "pending")
+			},
+			expected: []string{
+				`"issuer"="test-cluster-issuer"`,
+				`"reason"="Timeout"`,
+				`"result"="Pending"`,
+			},
+		},
+		"a failed request is logged as failed": {
+			issuerObj: issuer,
+			report: func(cr *cmapi.CertificateRequest) {
+				reporter.Failed(cr, errors.New("this is an error"), ReasonDecodeError, "failed")
+			},
+			expected: []string{
+				`"reason"="DecodeError"`,
+				`"result"="Failed"`,
+			},
+		},
+		"a denied request is logged as failed": {
+			issuerObj: issuer,
+			report:    reporter.Denied,
+			expected: []string{
+				`"reason"="Denied"`,
+				`"result"="Failed"`,
+			},
+		},
+		"an error returned by the signing is logged as an error": {
+			issuerObj: issuer,
+			report:    func(*cmapi.CertificateRequest) {},
+			err:       errors.New("this is an error"),
+			expected: []string{
+				`"reason"=""`,
+				`"result"="Error"`,
+				`"error"="this is an error"`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var messages []string
+			log := funcr.New(func(prefix, args string) {
+				messages = append(messages, args)
+			}, funcr.Options{Verbosity: logf.InfoLevel})
+
+			cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestNamespace("test-ns"))
+			reportedReason := TrackReportedReason(cr)
+			test.report(cr)
+
+			LogSignResult(log, cr, test.issuerObj, reportedReason(), 2500*time.Millisecond, test.err)
+
+			require.Len(t, messages, 1)
+			assert.Contains(t, messages[0], `"msg"="`+SignResultLogMessage+`"`)
+			for _, expected := range test.expected {
+				assert.Contains(t, messages[0], expected)
+			}
+		})
+	}
+}
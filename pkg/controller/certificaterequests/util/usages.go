@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto/x509"
+	"fmt"
+	"slices"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// defaultExtKeyUsages are the extended key usages expected of a non-CA
+// certificate when the CertificateRequest does not request any usages.
+var defaultExtKeyUsages = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+
+// RequestedExtKeyUsages returns the extended key usages requested by the
+// CertificateRequest. If no usages are requested, server and client auth are
+// returned for non-CA certificates, and no extended key usages for CA
+// certificates.
+func RequestedExtKeyUsages(cr *cmapi.CertificateRequest) ([]x509.ExtKeyUsage, error) {
+	if len(cr.Spec.Usages) == 0 {
+		if cr.Spec.IsCA {
+			return nil, nil
+		}
+		return defaultExtKeyUsages, nil
+	}
+
+	_, eku, err := pki.KeyUsagesForCertificateOrCertificateRequest(cr.Spec.Usages, cr.Spec.IsCA)
+	if err != nil {
+		return nil, err
+	}
+
+	return eku, nil
+}
+
+// VerifyExtKeyUsages checks that the issued certificate permits all of the
+// extended key usages requested by the CertificateRequest. Issuers which
+// apply their own policy to the usages of a certificate may otherwise
+// silently drop requested usages.
+func VerifyExtKeyUsages(cr *cmapi.CertificateRequest, crt *x509.Certificate) error {
+	requested, err := RequestedExtKeyUsages(cr)
+	if err != nil {
+		return err
+	}
+
+	// A certificate without the extended key usage extension is not
+	// restricted to any particular usages.
+	if len(crt.ExtKeyUsage) == 0 && len(crt.UnknownExtKeyUsage) == 0 {
+		return nil
+	}
+
+	if slices.Contains(crt.ExtKeyUsage, x509.ExtKeyUsageAny) {
+		return nil
+	}
+
+	var missing []x509.ExtKeyUsage
+	for _, usage := range requested {
+		if !slices.Contains(crt.ExtKeyUsage, usage) {
+			missing = append(missing, usage)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("the issued certificate does not permit the requested usages %v, it only permits %v",
+			apiutil.ExtKeyUsageStrings(missing), apiutil.ExtKeyUsageStrings(crt.ExtKeyUsage))
+	}
+
+	return nil
+}
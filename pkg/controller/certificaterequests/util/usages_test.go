@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestVerifyExtKeyUsages(t *testing.T) {
+	tests := map[string]struct {
+		cr     *cmapi.CertificateRequest
+		eku    []x509.ExtKeyUsage
+		expErr string
+	}{
+		"no usages requested and certificate has server and client auth": {
+			cr:  gen.CertificateRequest("cr"),
+			eku: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		},
+		"no usages requested and certificate only has server auth": {
+			cr:     gen.CertificateRequest("cr"),
+			eku:    []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			expErr: "the issued certificate does not permit the requested usages [client auth], it only permits [server auth]",
+		},
+		"no usages requested for a CA certificate": {
+			cr:  gen.CertificateRequest("cr", gen.SetCertificateRequestIsCA(true)),
+			eku: []x509.ExtKeyUsage{x509.ExtKeyUsageOCSPSigning},
+		},
+		"certificate without extended key usages permits all usages": {
+			cr: gen.CertificateRequest("cr", gen.SetCertificateRequestKeyUsages(cmapi.UsageCodeSigning)),
+		},
+		"certificate with any extended key usage permits all usages": {
+			cr:  gen.CertificateRequest("cr", gen.SetCertificateRequestKeyUsages(cmapi.UsageCodeSigning)),
+			eku: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		},
+		"only key usages requested": {
+			cr:  gen.CertificateRequest("cr", gen.SetCertificateRequestKeyUsages(cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment)),
+			eku: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		},
+		"requested usages are permitted": {
+			cr:  gen.CertificateRequest("cr", gen.SetCertificateRequestKeyUsages(cmapi.UsageDigitalSignature, cmapi.UsageClientAuth)),
+			eku: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		},
+		"requested usages conflict with the issued certificate": {
+			cr:     gen.CertificateRequest("cr", gen.SetCertificateRequestKeyUsages(cmapi.UsageClientAuth, cmapi.UsageCodeSigning)),
+			eku:    []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+			expErr: "the issued certificate does not permit the requested usages [code signing], it only permits [server auth client auth]",
+		},
+		"requested any usage but certificate is restricted": {
+			cr:     gen.CertificateRequest("cr", gen.SetCertificateRequestKeyUsages(cmapi.UsageAny)),
+			eku:    []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			expErr: "the issued certificate does not permit the requested usages [any], it only permits [server auth]",
+		},
+		"unknown usage requested": {
+			cr:     gen.CertificateRequest("cr", gen.SetCertificateRequestKeyUsages("unknown")),
+			eku:    []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			expErr: "unknown key usages: [unknown]",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := VerifyExtKeyUsages(test.cr, &x509.Certificate{ExtKeyUsage: test.eku})
+			if test.expErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expErr)
+			}
+		})
+	}
+}
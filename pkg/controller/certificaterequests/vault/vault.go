@@ -20,6 +20,7 @@ import (
 	"context"
 
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/clock"
 
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	vaultinternal "github.com/cert-manager/cert-manager/internal/vault"
@@ -45,6 +46,7 @@ type Vault struct {
 	createTokenFn func(ns string) vaultinternal.CreateToken
 	secretsLister internalinformers.SecretLister
 	reporter      *crutil.Reporter
+	clock         clock.Clock
 
 	vaultClientBuilder vaultinternal.ClientBuilder
 }
@@ -66,7 +68,8 @@ func NewVault(ctx *controllerpkg.Context) certificaterequests.Issuer {
 			return ctx.Client.CoreV1().ServiceAccounts(ns).CreateToken
 		},
 		secretsLister:      ctx.KubeSharedInformerFactory.Secrets().Lister(),
-		reporter:           crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		reporter:           crutil.NewReporter(ctx.Clock, ctx.Recorder, ctx.IssuerOptions.CertificateRequestEventCooldown),
+		clock:              ctx.Clock,
 		vaultClientBuilder: vaultinternal.New,
 	}
 }
@@ -77,20 +80,29 @@ func (v *Vault) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v
 	log := logf.FromContext(ctx, "sign")
 	log = logf.WithRelatedResource(log, issuerObj)
 
+	if err := crutil.VerifyKeyPolicy(cr, issuerObj.GetSpec()); err != nil {
+		message := "The key of the request is not allowed by the key policy of the issuer"
+
+		v.reporter.Failed(cr, err, crutil.ReasonWeakKey, message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
+
 	resourceNamespace := v.issuerOptions.ResourceNamespace(issuerObj)
 
 	client, err := v.vaultClientBuilder(ctx, resourceNamespace, v.createTokenFn, v.secretsLister, issuerObj)
 	if k8sErrors.IsNotFound(err) {
 		message := "Required secret resource not found"
 
-		v.reporter.Pending(cr, err, "SecretMissing", message)
+		v.reporter.Pending(cr, err, crutil.ReasonSecretMissing, message)
 		log.Error(err, message)
 		return nil, nil
 	}
 
 	if err != nil {
 		message := "Failed to initialise vault client for signing"
-		v.reporter.Pending(cr, err, "VaultInitError", message)
+		v.reporter.Pending(cr, err, crutil.ReasonVaultInitError, message)
 		log.Error(err, message)
 
 		if cmerrors.IsInvalidData(err) {
@@ -101,11 +113,23 @@ func (v *Vault) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v
 	}
 
 	certDuration := apiutil.DefaultCertDuration(cr.Spec.Duration)
+	if cr.Spec.NotAfter != nil {
+		certDuration, err = crutil.NotAfterDuration(cr, v.clock.Now())
+		if err != nil {
+			message := "Invalid notAfter time requested"
+
+			v.reporter.Failed(cr, err, crutil.ReasonInvalidNotAfter, message)
+			log.Error(err, message)
+
+			return nil, nil
+		}
+	}
+
 	certPem, caPem, err := client.Sign(cr.Spec.Request, certDuration)
 	if err != nil {
 		message := "Vault failed to sign certificate"
 
-		v.reporter.Failed(cr, err, "SigningError", message)
+		v.reporter.Failed(cr, err, crutil.ReasonSigningError, message)
 		log.Error(err, message)
 
 		return nil, nil
@@ -175,7 +175,7 @@ func TestSign(t *testing.T) {
 			builder: &testpkg.Builder{
 				KubeObjects:        []runtime.Object{},
 				CertManagerObjects: []runtime.Object{baseCRDenied.DeepCopy(), baseIssuer.DeepCopy()},
-				ExpectedEvents:     []string{},
+				ExpectedEvents:     []string{"Warning Denied The CertificateRequest was denied by an approval controller"},
 				ExpectedActions: []testpkg.Action{
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
@@ -477,6 +477,8 @@ func TestSign(t *testing.T) {
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestCertificate(rsaPEMCert),
+							gen.SetCertificateRequestChainLength(1),
+							gen.SetCertificateRequestSerialNumberOf(rsaPEMCert),
 							gen.SetCertificateRequestCA(rsaPEMCert),
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
@@ -520,6 +522,8 @@ func TestSign(t *testing.T) {
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(baseCR,
 							gen.SetCertificateRequestCertificate(rsaPEMCert),
+							gen.SetCertificateRequestChainLength(1),
+							gen.SetCertificateRequestSerialNumberOf(rsaPEMCert),
 							gen.SetCertificateRequestCA(rsaPEMCert),
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
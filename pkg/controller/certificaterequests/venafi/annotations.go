@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
+)
+
+// requestAnnotations returns the annotations of the CertificateRequest merged
+// with the default annotations of the issuer, the annotations of the
+// CertificateRequest taking precedence.
+func requestAnnotations(cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) map[string]string {
+	defaults := issuerObj.GetSpec().Venafi.DefaultAnnotations
+	if len(defaults) == 0 {
+		return cr.GetAnnotations()
+	}
+
+	annotations := make(map[string]string, len(defaults)+len(cr.GetAnnotations()))
+	for key, value := range defaults {
+		annotations[key] = value
+	}
+	for key, value := range cr.GetAnnotations() {
+		annotations[key] = value
+	}
+	return annotations
+}
+
+// requestCustomFields returns the custom fields set by the custom fields
+// annotation of the CertificateRequest merged with the custom fields of the
+// default annotations of the issuer. The fields of the CertificateRequest
+// replace the fields of the issuer with the same name.
+func requestCustomFields(cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) ([]api.CustomField, error) {
+	var defaults []api.CustomField
+	if annotation := issuerObj.GetSpec().Venafi.DefaultAnnotations[cmapi.VenafiCustomFieldsAnnotationKey]; annotation != "" {
+		var err error
+		defaults, err = api.ParseCustomFields([]byte(annotation))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var fields []api.CustomField
+	if annotation := cr.GetAnnotations()[cmapi.VenafiCustomFieldsAnnotationKey]; annotation != "" {
+		var err error
+		fields, err = api.ParseCustomFields([]byte(annotation))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(defaults) == 0 {
+		return fields, nil
+	}
+
+	// A custom field may be set several times for multiple values, so all
+	// the values of the issuer for a field are replaced at once.
+	requested := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		requested[field.Name] = true
+	}
+
+	var merged []api.CustomField
+	for _, field := range defaults {
+		if !requested[field.Name] {
+			merged = append(merged, field)
+		}
+	}
+	return append(merged, fields...), nil
+}
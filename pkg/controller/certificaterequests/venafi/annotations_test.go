@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"crypto"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestRequestAnnotations(t *testing.T) {
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+		DefaultAnnotations: map[string]string{
+			cmapi.VenafiFriendlyNameAnnotationKey: "issuer-name",
+			cmapi.VenafiInstanceAnnotationKey:     "issuer-instance",
+		},
+	}))
+	cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestAnnotations(map[string]string{
+		cmapi.VenafiFriendlyNameAnnotationKey: "request-name",
+		cmapi.VenafiWorkloadAnnotationKey:     "request-workload",
+	}))
+
+	assert.Equal(t, map[string]string{
+		cmapi.VenafiFriendlyNameAnnotationKey: "request-name",
+		cmapi.VenafiInstanceAnnotationKey:     "issuer-instance",
+		cmapi.VenafiWorkloadAnnotationKey:     "request-workload",
+	}, requestAnnotations(cr, issuer))
+	assert.NotContains(t, cr.Annotations, cmapi.VenafiInstanceAnnotationKey, "expected the CertificateRequest not to be modified")
+
+	withoutDefaults := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{}))
+	assert.Equal(t, cr.Annotations, requestAnnotations(cr, withoutDefaults))
+}
+
+func TestRequestCustomFields(t *testing.T) {
+	tests := map[string]struct {
+		issuerFields  string
+		requestFields string
+
+		expectedFields []api.CustomField
+		expectedErr    bool
+	}{
+		"no custom fields": {},
+		"the custom fields of the request are used": {
+			requestFields:  `{"team": "example"}`,
+			expectedFields: []api.CustomField{{Type: api.CustomFieldTypePlain, Name: "team", Value: "example"}},
+		},
+		"the custom fields of the issuer are used": {
+			issuerFields:   `{"cost-center": "1234"}`,
+			expectedFields: []api.CustomField{{Type: api.CustomFieldTypePlain, Name: "cost-center", Value: "1234"}},
+		},
+		"the custom fields of the issuer and the request are merged": {
+			issuerFields:  `{"cost-center": "1234", "team": "platform"}`,
+			requestFields: `{"team": "example"}`,
+			expectedFields: []api.CustomField{
+				{Type: api.CustomFieldTypePlain, Name: "cost-center", Value: "1234"},
+				{Type: api.CustomFieldTypePlain, Name: "team", Value: "example"},
+			},
+		},
+		"all the values of the issuer for a field set by the request are replaced": {
+			issuerFields:  `[{"name": "environment", "value": "dev"}, {"name": "environment", "value": "test"}]`,
+			requestFields: `[{"name": "environment", "value": "prod"}]`,
+			expectedFields: []api.CustomField{
+				{Name: "environment", Value: "prod"},
+			},
+		},
+		"invalid custom fields of the request are rejected": {
+			issuerFields:  `{"cost-center": "1234"}`,
+			requestFields: "not-json",
+			expectedErr:   true,
+		},
+		"invalid custom fields of the issuer are rejected": {
+			issuerFields: "not-json",
+			expectedErr:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			venafiIssuer := cmapi.VenafiIssuer{}
+			if test.issuerFields != "" {
+				venafiIssuer.DefaultAnnotations = map[string]string{cmapi.VenafiCustomFieldsAnnotationKey: test.issuerFields}
+			}
+			cr := gen.CertificateRequest("test-cr")
+			if test.requestFields != "" {
+				cr.Annotations = map[string]string{cmapi.VenafiCustomFieldsAnnotationKey: test.requestFields}
+			}
+
+			fields, err := requestCustomFields(cr, gen.Issuer("test-issuer", gen.SetIssuerVenafi(venafiIssuer)))
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedFields, fields)
+		})
+	}
+}
+
+func TestSignWithDefaultAnnotations(t *testing.T) {
+	testPK, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+		Zone:  "cloud-zone",
+		Cloud: &cmapi.VenafiCloud{},
+		DefaultAnnotations: map[string]string{
+			cmapi.VenafiCustomFieldsAnnotationKey: `{"cost-center": "1234"}`,
+			cmapi.VenafiInstanceAnnotationKey:     "issuer-instance",
+			cmapi.VenafiWorkloadAnnotationKey:     "issuer-workload",
+		},
+	}))
+	cr := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestCSR(generateCSR(t, testPK)),
+		gen.SetCertificateRequestAnnotations(map[string]string{
+			cmapi.VenafiCustomFieldsAnnotationKey: `{"team": "example"}`,
+			cmapi.VenafiWorkloadAnnotationKey:     "request-workload",
+		}),
+	)
+
+	var requestedLocation *api.Location
+	var requestedFields []api.CustomField
+	v := &Venafi{
+		reporter: crutil.NewReporter(fixedClock, new(controllertest.FakeRecorder), 0),
+		clientBuilder: func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+			return &fake.Venafi{
+				RequestCertificateFn: func(_ []byte, _ time.Duration, _ string, location *api.Location, signatureHash crypto.Hash, fields []api.CustomField) (string, error) {
+					requestedLocation, requestedFields = location, fields
+					return "test-pickup-id", nil
+				},
+			}, nil
+		},
+		clock:                fixedClock,
+		limiter:              newSigningLimiter(0),
+		missingSecretRetries: newMissingSecretRetries(fixedClock),
+		retrieveFailures:     newRetrieveFailures(fixedClock, 0),
+		enrollments:          newPendingEnrollments(fixedClock),
+	}
+
+	_, err = v.Sign(context.Background(), cr, issuer)
+	require.NoError(t, err)
+
+	assert.Equal(t, &api.Location{Instance: "issuer-instance", Workload: "request-workload"}, requestedLocation)
+	assert.Equal(t, []api.CustomField{
+		{Type: api.CustomFieldTypePlain, Name: "cost-center", Value: "1234"},
+		{Type: api.CustomFieldTypePlain, Name: "team", Value: "example"},
+	}, requestedFields)
+	assert.NotContains(t, cr.Annotations, cmapi.VenafiInstanceAnnotationKey, "expected the default annotations not to be persisted")
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"strconv"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+const (
+	defaultRetryInitialInterval = time.Second * 5
+	defaultRetryMaxInterval     = time.Minute * 5
+)
+
+// pendingRetryDelay returns the delay to wait before making the given attempt
+// to retrieve a pending certificate. Attempts are numbered from 1. The delay
+// starts at the initial interval and doubles on each attempt, up to the
+// maximum interval.
+func pendingRetryDelay(backoff *cmapi.VenafiRetryBackoff, attempt int) time.Duration {
+	initial, maximum := defaultRetryInitialInterval, defaultRetryMaxInterval
+	if backoff != nil {
+		if backoff.InitialInterval != nil {
+			initial = backoff.InitialInterval.Duration
+		}
+		if backoff.MaxInterval != nil {
+			maximum = backoff.MaxInterval.Duration
+		}
+	}
+
+	delay := initial
+	for i := 1; i < attempt && delay < maximum; i++ {
+		delay *= 2
+	}
+	if delay > maximum {
+		delay = maximum
+	}
+
+	return delay
+}
+
+// pendingRetryJitter returns the jitter to add to the delay before making the
+// given attempt to retrieve a pending certificate. The jitter is less than the
+// configured jitter window and is derived from the UID of the
+// CertificateRequest and the attempt, so that requests created at the same
+// time retry at different times while each request's schedule is stable.
+func pendingRetryJitter(backoff *cmapi.VenafiRetryBackoff, cr *cmapi.CertificateRequest, attempt int) time.Duration {
+	if backoff == nil || backoff.Jitter == nil || backoff.Jitter.Duration <= 0 {
+		return 0
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(cr.UID))
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(attempt)))
+
+	return time.Duration(h.Sum64() % uint64(backoff.Jitter.Duration))
+}
+
+// pendingRetryCount returns the number of attempts that have been made to
+// retrieve a pending certificate, as recorded on the CertificateRequest.
+// Missing or malformed values are treated as no attempts having been made.
+func pendingRetryCount(cr *cmapi.CertificateRequest) int {
+	count, err := strconv.Atoi(cr.GetAnnotations()[cmapi.VenafiRetryCountAnnotationKey])
+	if err != nil || count < 0 {
+		return 0
+	}
+
+	return count
+}
+
+// nextPendingRetryTime returns the time after which the next attempt to
+// retrieve a pending certificate should be made, as recorded on the
+// CertificateRequest. The boolean is false if no valid time is recorded.
+func nextPendingRetryTime(cr *cmapi.CertificateRequest) (time.Time, bool) {
+	annotation, ok := cr.GetAnnotations()[cmapi.VenafiNextRetryTimeAnnotationKey]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	next, err := time.Parse(time.RFC3339, annotation)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return next, true
+}
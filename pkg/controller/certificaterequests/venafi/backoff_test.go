@@ -0,0 +1,238 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
+	venafitest "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestPendingRetryDelay(t *testing.T) {
+	tests := map[string]struct {
+		backoff  *cmapi.VenafiRetryBackoff
+		attempt  int
+		expDelay time.Duration
+	}{
+		"first attempt uses the default initial interval": {
+			attempt:  1,
+			expDelay: time.Second * 5,
+		},
+		"delay doubles on each attempt": {
+			attempt:  3,
+			expDelay: time.Second * 20,
+		},
+		"delay is capped at the default max interval": {
+			attempt:  20,
+			expDelay: time.Minute * 5,
+		},
+		"configured initial interval is used": {
+			backoff: &cmapi.VenafiRetryBackoff{
+				InitialInterval: &metav1.Duration{Duration: time.Second * 30},
+			},
+			attempt:  2,
+			expDelay: time.Minute,
+		},
+		"configured max interval caps the delay": {
+			backoff: &cmapi.VenafiRetryBackoff{
+				InitialInterval: &metav1.Duration{Duration: time.Second * 30},
+				MaxInterval:     &metav1.Duration{Duration: time.Second * 45},
+			},
+			attempt:  2,
+			expDelay: time.Second * 45,
+		},
+		"a very large attempt count does not overflow": {
+			backoff: &cmapi.VenafiRetryBackoff{
+				MaxInterval: &metav1.Duration{Duration: time.Hour},
+			},
+			attempt:  1000,
+			expDelay: time.Hour,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if delay := pendingRetryDelay(test.backoff, test.attempt); delay != test.expDelay {
+				t.Errorf("unexpected delay, exp=%s got=%s", test.expDelay, delay)
+			}
+		})
+	}
+}
+
+func TestPendingRetryJitter(t *testing.T) {
+	backoff := &cmapi.VenafiRetryBackoff{
+		Jitter: &metav1.Duration{Duration: time.Second * 30},
+	}
+
+	crA := gen.CertificateRequest("a", gen.SetCertificateRequestUID("6c8d4c6e-0d1f-4b7c-9a2e-f3b1c1a0e001"))
+	crB := gen.CertificateRequest("b", gen.SetCertificateRequestUID("6c8d4c6e-0d1f-4b7c-9a2e-f3b1c1a0e002"))
+
+	if jitter := pendingRetryJitter(nil, crA, 1); jitter != 0 {
+		t.Errorf("expected no jitter without a retry backoff, got=%s", jitter)
+	}
+	if jitter := pendingRetryJitter(&cmapi.VenafiRetryBackoff{}, crA, 1); jitter != 0 {
+		t.Errorf("expected no jitter without a configured jitter, got=%s", jitter)
+	}
+
+	seen := make(map[time.Duration]bool)
+	for _, cr := range []*cmapi.CertificateRequest{crA, crB} {
+		for attempt := 1; attempt <= 5; attempt++ {
+			jitter := pendingRetryJitter(backoff, cr, attempt)
+			if jitter < 0 || jitter >= backoff.Jitter.Duration {
+				t.Errorf("jitter out of range for %s attempt %d, got=%s", cr.Name, attempt, jitter)
+			}
+			if again := pendingRetryJitter(backoff, cr, attempt); again != jitter {
+				t.Errorf("expected jitter for %s attempt %d to be deterministic, got=%s and %s", cr.Name, attempt, jitter, again)
+			}
+			seen[jitter] = true
+		}
+	}
+
+	if len(seen) < 2 {
+		t.Errorf("expected jitter to differ between requests and attempts, got=%v", seen)
+	}
+}
+
+func TestPendingRetryAnnotations(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := map[string]struct {
+		annotations map[string]string
+		expCount    int
+		expNext     time.Time
+		expNextOK   bool
+	}{
+		"no annotations": {},
+		"valid annotations": {
+			annotations: map[string]string{
+				cmapi.VenafiRetryCountAnnotationKey:    "3",
+				cmapi.VenafiNextRetryTimeAnnotationKey: now.Format(time.RFC3339),
+			},
+			expCount:  3,
+			expNext:   now,
+			expNextOK: true,
+		},
+		"malformed annotations are ignored": {
+			annotations: map[string]string{
+				cmapi.VenafiRetryCountAnnotationKey:    "three",
+				cmapi.VenafiNextRetryTimeAnnotationKey: "tomorrow",
+			},
+		},
+		"negative retry count is ignored": {
+			annotations: map[string]string{
+				cmapi.VenafiRetryCountAnnotationKey: "-1",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := gen.CertificateRequest("test", gen.SetCertificateRequestAnnotations(test.annotations))
+
+			if count := pendingRetryCount(cr); count != test.expCount {
+				t.Errorf("unexpected retry count, exp=%d got=%d", test.expCount, count)
+			}
+
+			next, ok := nextPendingRetryTime(cr)
+			if ok != test.expNextOK || !next.Equal(test.expNext) {
+				t.Errorf("unexpected next retry time, exp=(%s, %t) got=(%s, %t)", test.expNext, test.expNextOK, next, ok)
+			}
+		})
+	}
+}
+
+func TestSignRetriesPendingCertificate(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+
+	pk, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	csrPEM, err := gen.CSRWithSigner(pk, gen.SetCSRCommonName("test-common-name"))
+	require.NoError(t, err)
+
+	cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestCSR(csrPEM))
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "tpp-zone", TPP: &cmapi.VenafiTPP{}}))
+
+	template, err := pki.CertificateTemplateFromCertificateRequest(cr)
+	require.NoError(t, err)
+	certPEM, _, err := pki.SignCertificate(template, template, pk.Public(), pk)
+	require.NoError(t, err)
+
+	script := venafitest.NewIssuingScript("test-pickup-id", certPEM, venafitest.Pending(), venafitest.TimedOut())
+	recorder := new(controllertest.FakeRecorder)
+	v := &Venafi{
+		reporter:             crutil.NewReporter(clock, recorder, 0),
+		clientBuilder:        script.ClientBuilder(),
+		clock:                clock,
+		limiter:              newSigningLimiter(0),
+		missingSecretRetries: newMissingSecretRetries(clock),
+		retrieveFailures:     newRetrieveFailures(clock, 0),
+	}
+
+	sign := func() *issuerpkg.IssueResponse {
+		t.Helper()
+		resp, err := v.Sign(context.Background(), cr, issuer)
+		require.NoError(t, err)
+		return resp
+	}
+
+	// The certificate is requested, and then retrieved on the next sync.
+	assert.Nil(t, sign())
+	assert.Equal(t, "test-pickup-id", cr.Annotations[cmapi.VenafiPickupIDAnnotationKey])
+	assert.Equal(t, 0, script.RetrieveCalls())
+
+	assert.Nil(t, sign())
+	assert.Equal(t, "1", cr.Annotations[cmapi.VenafiRetryCountAnnotationKey])
+	assert.Equal(t, 1, script.RetrieveCalls())
+
+	// The Venafi platform is not polled again until the backoff has elapsed.
+	clock.Step(time.Second)
+	assert.Nil(t, sign())
+	assert.Equal(t, 1, script.RetrieveCalls())
+
+	clock.Step(5 * time.Second)
+	assert.Nil(t, sign())
+	assert.Equal(t, "2", cr.Annotations[cmapi.VenafiRetryCountAnnotationKey])
+	assert.Equal(t, 2, script.RetrieveCalls())
+
+	clock.Step(10 * time.Second)
+	resp := sign()
+	require.NotNil(t, resp)
+	assert.Equal(t, certPEM, resp.Certificate)
+	assert.Equal(t, 1, script.RequestCalls())
+	assert.Equal(t, 3, script.RetrieveCalls())
+
+	// The time each retry is scheduled in is reported by an event, although
+	// the request is already pending, but waiting for the backoff is not.
+	assert.Equal(t, []string{
+		`Normal IssuancePending Venafi certificate is requested with pickup ID "test-pickup-id" for CN "test-common-name"`,
+		"Normal IssuancePending Venafi certificate still in a pending state, the request will be retried in 5s: Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
+		"Normal IssuancePending Venafi certificate still in a pending state, the request will be retried in 10s: Operation timed out. You may try retrieving the certificate later using Pickup ID: test-cert-id",
+	}, recorder.Events)
+}
@@ -0,0 +1,291 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/cert-manager/cert-manager/internal/apis/config/shared"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/server"
+	servertls "github.com/cert-manager/cert-manager/pkg/server/tls"
+	"github.com/cert-manager/cert-manager/pkg/server/tls/authority"
+)
+
+const (
+	// callbackPath is the path of the Venafi callback endpoint.
+	callbackPath = "/venafi/callback"
+
+	// maxCallbackBodySize bounds the size of the body of callback requests,
+	// which only contain a pickup ID.
+	maxCallbackBodySize = 4096
+
+	// callbackReadHeaderTimeout mitigates "slowloris" attacks by limiting the
+	// time a deliberately slow client can spend sending HTTP headers.
+	callbackReadHeaderTimeout = 32 * time.Second
+)
+
+// callbackRequest is the body of a call to the Venafi callback endpoint.
+type callbackRequest struct {
+	// PickupID is the pickup ID of the certificate which has been issued.
+	PickupID string `json:"pickupID"`
+}
+
+// callbackServer serves the Venafi callback endpoint, which the Venafi
+// platform may be configured to call once a certificate has been issued, so
+// that the CertificateRequests pending issuance with its pickup ID are synced
+// immediately rather than at their next poll, regardless of the backoff of
+// their retrievals. Only the leader runs the
+// controllers, so only the leader serves the endpoint.
+// The endpoint is served with TLS when a certificate source is configured, as
+// the bearer token is otherwise sent in cleartext.
+// A nil *callbackServer serves nothing.
+type callbackServer struct {
+	listenAddress string
+	token         string
+
+	// certificateSource provides the serving certificate of the endpoint. If
+	// nil, the endpoint is served without TLS.
+	certificateSource servertls.CertificateSource
+	cipherSuites      []string
+	minTLSVersion     string
+
+	certificateRequestLister cmlisters.CertificateRequestLister
+
+	// queue is the workqueue of the controller to which the matching
+	// CertificateRequests are added.
+	queue workqueue.TypedRateLimitingInterface[types.NamespacedName]
+
+	lock sync.Mutex
+	// received holds the UIDs of the CertificateRequests synced after a
+	// callback, until their certificate is next retrieved.
+	received map[types.UID]struct{}
+}
+
+// newCallbackServer returns the Venafi callback server, or nil if the
+// endpoint is disabled.
+func newCallbackServer(ctx *controllerpkg.Context) *callbackServer {
+	if ctx.IssuerOptions.VenafiCallbackListenAddress == "" {
+		return nil
+	}
+
+	tlsConfig := ctx.IssuerOptions.VenafiCallbackTLSConfig
+	return &callbackServer{
+		listenAddress:            ctx.IssuerOptions.VenafiCallbackListenAddress,
+		token:                    ctx.IssuerOptions.VenafiCallbackToken,
+		certificateSource:        callbackCertificateSource(tlsConfig, ctx.RESTConfig),
+		cipherSuites:             tlsConfig.CipherSuites,
+		minTLSVersion:            tlsConfig.MinTLSVersion,
+		certificateRequestLister: ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests().Lister(),
+	}
+}
+
+// callbackCertificateSource returns the source of the serving certificate of
+// the callback endpoint, or nil if no certificate is configured.
+func callbackCertificateSource(tlsConfig shared.TLSConfig, restConfig *rest.Config) servertls.CertificateSource {
+	switch {
+	case tlsConfig.FilesystemConfigProvided():
+		return &servertls.FileCertificateSource{
+			CertPath: tlsConfig.Filesystem.CertFile,
+			KeyPath:  tlsConfig.Filesystem.KeyFile,
+		}
+	case tlsConfig.DynamicConfigProvided():
+		return &servertls.DynamicSource{
+			DNSNames: tlsConfig.Dynamic.DNSNames,
+			Authority: &authority.DynamicAuthority{
+				SecretNamespace: tlsConfig.Dynamic.SecretNamespace,
+				SecretName:      tlsConfig.Dynamic.SecretName,
+				LeafDuration:    tlsConfig.Dynamic.LeafDuration,
+				RESTConfig:      restConfig,
+			},
+		}
+	}
+	return nil
+}
+
+// start listens on the address of the callback server and serves the
+// callback endpoint in the background until ctx is cancelled.
+func (s *callbackServer) start(ctx context.Context, queue workqueue.TypedRateLimitingInterface[types.NamespacedName]) {
+	if s == nil {
+		return
+	}
+
+	log := logf.FromContext(ctx, "callback")
+	s.queue = queue
+
+	if s.certificateSource != nil {
+		go func() {
+			if err := s.certificateSource.Start(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				log.Error(err, "venafi callback certificate source failed")
+			}
+		}()
+	} else {
+		log.V(logf.WarnLevel).Info("serving venafi callback endpoint insecurely as tls certificate data not provided", "address", s.listenAddress)
+	}
+
+	ln, err := server.Listen("tcp", s.listenAddress,
+		server.WithCertificateSource(s.certificateSource),
+		server.WithTLSCipherSuites(s.cipherSuites),
+		server.WithTLSMinVersion(s.minTLSVersion),
+	)
+	if err != nil {
+		log.Error(err, "failed to listen on venafi callback address", "address", s.listenAddress)
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(callbackPath, s)
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: callbackReadHeaderTimeout,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	go func() {
+		<-ctx.Done()
+		// allow a timeout for graceful shutdown
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		// nolint: contextcheck
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Error(err, "failed to shut down venafi callback server")
+		}
+	}()
+	go func() {
+		log.V(logf.InfoLevel).Info("starting venafi callback server", "address", ln.Addr())
+		if err := server.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+			log.Error(err, "venafi callback server failed")
+		}
+	}()
+}
+
+// ServeHTTP enqueues the CertificateRequests pending issuance with the
+// pickup ID of the callback request.
+func (s *callbackServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := logf.FromContext(r.Context(), "callback")
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !s.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req callbackRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxCallbackBodySize)).Decode(&req); err != nil || req.PickupID == "" {
+		http.Error(w, "the body must be a JSON object with a pickupID", http.StatusBadRequest)
+		return
+	}
+
+	crs, err := s.pendingRequests(req.PickupID)
+	if err != nil {
+		log.Error(err, "failed to list certificate requests")
+		http.Error(w, "failed to list certificate requests", http.StatusInternalServerError)
+		return
+	}
+	if len(crs) == 0 {
+		http.Error(w, "no certificate request is pending issuance with the pickup ID", http.StatusNotFound)
+		return
+	}
+
+	for _, cr := range crs {
+		log.V(logf.DebugLevel).Info("syncing certificate request after callback", "resource_namespace", cr.Namespace, "resource_name", cr.Name)
+		// The request is marked before it is queued, so that the sync
+		// retrieves the certificate without waiting for its backoff.
+		s.markReceived(cr)
+		s.queue.Add(types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name})
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// authorized returns true if the request carries the shared token as a
+// bearer token. The tokens are compared in constant time.
+func (s *callbackServer) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || s.token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+// pendingRequests returns the CertificateRequests which are pending issuance
+// with the given pickup ID. Requests which are already ready or failed are
+// not synced again.
+func (s *callbackServer) pendingRequests(pickupID string) ([]*cmapi.CertificateRequest, error) {
+	crs, err := s.certificateRequestLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*cmapi.CertificateRequest
+	for _, cr := range crs {
+		if cr.Annotations[cmapi.VenafiPickupIDAnnotationKey] != pickupID {
+			continue
+		}
+		if len(cr.Status.Certificate) > 0 || cr.Status.FailureTime != nil {
+			continue
+		}
+		pending = append(pending, cr)
+	}
+	return pending, nil
+}
+
+func (s *callbackServer) markReceived(cr *cmapi.CertificateRequest) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.received == nil {
+		s.received = make(map[types.UID]struct{})
+	}
+	s.received[cr.UID] = struct{}{}
+}
+
+// takeReceived returns true if a callback has been received for the request
+// since its certificate was last retrieved, and clears it.
+func (s *callbackServer) takeReceived(cr *cmapi.CertificateRequest) bool {
+	if s == nil {
+		return false
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	_, ok := s.received[cr.UID]
+	delete(s.received, cr.UID)
+	return ok
+}
@@ -0,0 +1,335 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	venafitest "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestCallbackServer(t *testing.T) {
+	pendingCR := gen.CertificateRequest("pending",
+		gen.SetCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: `\VED\Policy\test`}),
+	)
+	otherPendingCR := gen.CertificateRequest("other-pending",
+		gen.SetCertificateRequestNamespace("other"),
+		gen.SetCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: `\VED\Policy\test`}),
+	)
+	issuedCR := gen.CertificateRequest("issued",
+		gen.SetCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: `\VED\Policy\issued`}),
+		gen.SetCertificateRequestCertificate([]byte("certificate")),
+	)
+	failedCR := gen.CertificateRequest("failed",
+		gen.SetCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: `\VED\Policy\failed`}),
+		gen.SetCertificateRequestFailureTime(metav1.Now()),
+	)
+
+	tests := map[string]struct {
+		method        string
+		authorization string
+		body          string
+
+		expectedStatus int
+		expectedKeys   []types.NamespacedName
+	}{
+		"the requests pending issuance with the pickup ID are synced": {
+			method:         http.MethodPost,
+			authorization:  "Bearer test-token",
+			body:           `{"pickupID": "\\VED\\Policy\\test"}`,
+			expectedStatus: http.StatusAccepted,
+			expectedKeys: []types.NamespacedName{
+				{Namespace: "other", Name: "other-pending"},
+				{Namespace: gen.DefaultTestNamespace, Name: "pending"},
+			},
+		},
+		"requests which are already issued are not synced": {
+			method:         http.MethodPost,
+			authorization:  "Bearer test-token",
+			body:           `{"pickupID": "\\VED\\Policy\\issued"}`,
+			expectedStatus: http.StatusNotFound,
+		},
+		"requests which have failed are not synced": {
+			method:         http.MethodPost,
+			authorization:  "Bearer test-token",
+			body:           `{"pickupID": "\\VED\\Policy\\failed"}`,
+			expectedStatus: http.StatusNotFound,
+		},
+		"an unknown pickup ID is not found": {
+			method:         http.MethodPost,
+			authorization:  "Bearer test-token",
+			body:           `{"pickupID": "\\VED\\Policy\\unknown"}`,
+			expectedStatus: http.StatusNotFound,
+		},
+		"a request without a token is unauthorized": {
+			method:         http.MethodPost,
+			body:           `{"pickupID": "\\VED\\Policy\\test"}`,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		"a request with the wrong token is unauthorized": {
+			method:         http.MethodPost,
+			authorization:  "Bearer wrong-token",
+			body:           `{"pickupID": "\\VED\\Policy\\test"}`,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		"a request without a pickup ID is rejected": {
+			method:         http.MethodPost,
+			authorization:  "Bearer test-token",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		"a request with an invalid body is rejected": {
+			method:         http.MethodPost,
+			authorization:  "Bearer test-token",
+			body:           `pickupID`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		"only POST requests are allowed": {
+			method:         http.MethodGet,
+			authorization:  "Bearer test-token",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, cr := range []*cmapi.CertificateRequest{pendingCR, otherPendingCR, issuedCR, failedCR} {
+				if err := indexer.Add(cr); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[types.NamespacedName]())
+			defer queue.ShutDown()
+
+			s := &callbackServer{
+				token:                    "test-token",
+				certificateRequestLister: cmlisters.NewCertificateRequestLister(indexer),
+				queue:                    queue,
+			}
+
+			req := httptest.NewRequest(test.method, callbackPath, strings.NewReader(test.body))
+			if test.authorization != "" {
+				req.Header.Set("Authorization", test.authorization)
+			}
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, req)
+
+			assert.Equal(t, test.expectedStatus, rec.Code)
+
+			var keys []types.NamespacedName
+			for queue.Len() > 0 {
+				key, _ := queue.Get()
+				keys = append(keys, key)
+				queue.Done(key)
+			}
+			assert.ElementsMatch(t, test.expectedKeys, keys)
+		})
+	}
+}
+
+func TestCallbackServerWithoutToken(t *testing.T) {
+	s := &callbackServer{}
+
+	req := httptest.NewRequest(http.MethodPost, callbackPath, strings.NewReader(`{"pickupID": "test"}`))
+	req.Header.Set("Authorization", "Bearer ")
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestCallbackRetrievesWithoutWaitingForBackoff(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	pk, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	csrPEM, err := gen.CSRWithSigner(pk, gen.SetCSRCommonName("test-common-name"))
+	require.NoError(t, err)
+
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "tpp-zone", TPP: &cmapi.VenafiTPP{}}))
+	cr := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestCSR(csrPEM),
+		gen.SetCertificateRequestUID("test-uid"),
+		gen.SetCertificateRequestAnnotations(map[string]string{
+			cmapi.VenafiPickupIDAnnotationKey:      "test-pickup-id",
+			cmapi.VenafiRetryCountAnnotationKey:    "3",
+			cmapi.VenafiNextRetryTimeAnnotationKey: clock.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		}),
+	)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, indexer.Add(cr))
+
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[types.NamespacedName]())
+	defer queue.ShutDown()
+
+	script := &venafitest.Script{RetrieveCertificate: []venafitest.Step{venafitest.Pending()}}
+	v := &Venafi{
+		reporter:             crutil.NewReporter(clock, new(controllertest.FakeRecorder), 0),
+		clientBuilder:        script.ClientBuilder(),
+		clock:                clock,
+		limiter:              newSigningLimiter(0),
+		missingSecretRetries: newMissingSecretRetries(clock),
+		retrieveFailures:     newRetrieveFailures(clock, time.Hour),
+		enrollments:          newPendingEnrollments(clock),
+		callbacks: &callbackServer{
+			token:                    "test-token",
+			certificateRequestLister: cmlisters.NewCertificateRequestLister(indexer),
+			queue:                    queue,
+		},
+		queue: queue,
+	}
+
+	// The previous retrieval failed, so the retrieval is also waiting for
+	// the backoff of the retrieve failures.
+	v.retrieveFailures.record(cr)
+
+	sign := func() {
+		resp, err := v.Sign(context.Background(), cr.DeepCopy(), issuer)
+		require.NoError(t, err)
+		assert.Nil(t, resp)
+	}
+
+	// Without a callback, the certificate is not retrieved before the next
+	// retry time.
+	sign()
+	assert.Equal(t, 0, script.RetrieveCalls())
+
+	req := httptest.NewRequest(http.MethodPost, callbackPath, strings.NewReader(`{"pickupID": "test-pickup-id"}`))
+	req.Header.Set("Authorization", "Bearer test-token")
+	rec := httptest.NewRecorder()
+	v.callbacks.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+
+	// The sync triggered by the callback retrieves the certificate
+	// immediately.
+	sign()
+	assert.Equal(t, 1, script.RetrieveCalls())
+
+	// The callback only skips the backoff once.
+	sign()
+	assert.Equal(t, 1, script.RetrieveCalls())
+}
+
+func TestRunnablesStartCallbackServer(t *testing.T) {
+	assert.Empty(t, (&Venafi{}).Runnables())
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	// The callback server is started by its runnable, whether or not the
+	// issuers are warmed up.
+	v := &Venafi{callbacks: &callbackServer{listenAddress: address, token: "test-token"}}
+	runnables := v.Runnables()
+	require.Len(t, runnables, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go runnables[0](ctx)
+
+	require.Eventually(t, func() bool {
+		resp, err := http.Post("http://"+address+callbackPath, "application/json", strings.NewReader(`{"pickupID": "test"}`))
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusUnauthorized
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// staticCertificateSource serves a fixed certificate.
+type staticCertificateSource struct {
+	cert *tls.Certificate
+}
+
+func (s *staticCertificateSource) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return s.cert, nil
+}
+
+func (s *staticCertificateSource) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (s *staticCertificateSource) Healthy() bool {
+	return true
+}
+
+func TestCallbackServerServesTLS(t *testing.T) {
+	// The test server generates a certificate for 127.0.0.1 and a client
+	// which trusts it.
+	tlsServer := httptest.NewTLSServer(http.NotFoundHandler())
+	client := tlsServer.Client()
+	cert := tlsServer.TLS.Certificates[0]
+	tlsServer.Close()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	s := &callbackServer{
+		listenAddress:     address,
+		token:             "test-token",
+		certificateSource: &staticCertificateSource{cert: &cert},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.start(ctx, nil)
+
+	require.Eventually(t, func() bool {
+		resp, err := client.Post("https://"+address+callbackPath, "application/json", strings.NewReader(`{"pickupID": "test"}`))
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusUnauthorized
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Cleartext requests are not served.
+	resp, err := http.Post("http://"+address+callbackPath, "application/json", strings.NewReader(`{"pickupID": "test"}`))
+	if err == nil {
+		resp.Body.Close()
+		assert.NotEqual(t, http.StatusUnauthorized, resp.StatusCode)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// caZonePolicies tracks the Venafi zones known not to permit issuing CA
+// certificates. The zone configuration read through vcert does not say
+// whether a zone permits CA certificates, so a zone is only known not to
+// once it has issued a leaf certificate for a CA request. Later CA requests
+// for that zone are then failed before they are enrolled, until the entry
+// expires along with the rest of the cached zone configuration.
+// A nil *caZonePolicies tracks nothing.
+type caZonePolicies struct {
+	clock clock.Clock
+	ttl   time.Duration
+
+	lock sync.Mutex
+	// denied maps the zones which do not permit CA certificates to the time
+	// at which that stops being assumed.
+	denied map[string]time.Time
+}
+
+func newCAZonePolicies(clock clock.Clock, ttl time.Duration) *caZonePolicies {
+	return &caZonePolicies{
+		clock:  clock,
+		ttl:    ttl,
+		denied: make(map[string]time.Time),
+	}
+}
+
+func caZoneKey(issuerObj cmapi.GenericIssuer) string {
+	return issuerKey(issuerObj) + "/" + issuerObj.GetSpec().Venafi.Zone
+}
+
+// recordDenied records that the zone of the issuer does not permit issuing
+// CA certificates. Nothing is recorded if zone configurations are not
+// cached.
+func (p *caZonePolicies) recordDenied(issuerObj cmapi.GenericIssuer) {
+	if p == nil || p.ttl <= 0 {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := p.clock.Now()
+	for key, expiry := range p.denied {
+		if !now.Before(expiry) {
+			delete(p.denied, key)
+		}
+	}
+
+	p.denied[caZoneKey(issuerObj)] = now.Add(p.ttl)
+}
+
+// deniesCA returns true if the zone of the issuer is known not to permit
+// issuing CA certificates.
+func (p *caZonePolicies) deniesCA(issuerObj cmapi.GenericIssuer) bool {
+	if p == nil {
+		return false
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	expiry, ok := p.denied[caZoneKey(issuerObj)]
+	return ok && p.clock.Now().Before(expiry)
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestCAZonePolicies(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	p := newCAZonePolicies(clock, time.Minute)
+
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "test-zone"}))
+	otherZone := gen.IssuerFrom(issuer, gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "other-zone"}))
+
+	assert.False(t, p.deniesCA(issuer))
+
+	p.recordDenied(issuer)
+	assert.True(t, p.deniesCA(issuer))
+	assert.False(t, p.deniesCA(otherZone), "expected other zones of the issuer to be unaffected")
+
+	clock.Step(time.Minute)
+	assert.False(t, p.deniesCA(issuer), "expected the entry to expire with the zone cache TTL")
+
+	uncached := newCAZonePolicies(clock, 0)
+	uncached.recordDenied(issuer)
+	assert.False(t, uncached.deniesCA(issuer), "expected nothing to be recorded without a zone cache")
+
+	var nilPolicies *caZonePolicies
+	nilPolicies.recordDenied(issuer)
+	assert.False(t, nilPolicies.deniesCA(issuer))
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"bytes"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// secretKeyData returns the PEM encoded certificates of the Secret referenced
+// by the Venafi issuer, such as its chain bundle. If the key of the reference
+// is not set, it defaults to `ca.crt`.
+func (v *Venafi) secretKeyData(issuerObj cmapi.GenericIssuer, ref *cmmeta.SecretKeySelector) ([]byte, error) {
+	namespace := v.issuerOptions.ResourceNamespace(issuerObj)
+
+	secret, err := v.secretsLister.Secrets(namespace).Get(ref.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	key := ref.Key
+	if key == "" {
+		key = cmmeta.TLSCAKey
+	}
+
+	data, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("no data for %q in secret '%s/%s'", key, namespace, ref.Name)
+	}
+
+	return data, nil
+}
+
+// hasRootCA returns true if the chain of the bundle ends with a self-signed
+// root CA, in which case it does not need to be completed.
+func hasRootCA(bundle utilpki.PEMBundle) (bool, error) {
+	if len(bundle.CAPEM) == 0 {
+		return false, nil
+	}
+
+	ca, err := utilpki.DecodeX509CertificateBytes(bundle.CAPEM)
+	if err != nil {
+		return false, err
+	}
+
+	return isSelfSigned(ca), nil
+}
+
+// completeChain completes the chain of the bundle returned by the Venafi
+// platform with the certificates of the chain bundle of the issuer. The
+// completed chain must verify against a self-signed root CA of the chain
+// bundle at the given time, otherwise an error is returned.
+func completeChain(bundle utilpki.PEMBundle, chainBundlePEM []byte, now time.Time) (utilpki.PEMBundle, error) {
+	certs, err := utilpki.DecodeX509CertificateChainBytes(bundle.ChainPEM)
+	if err != nil {
+		return utilpki.PEMBundle{}, err
+	}
+
+	bundleCerts, err := utilpki.DecodeX509CertificateSetBytes(chainBundlePEM)
+	if err != nil {
+		return utilpki.PEMBundle{}, fmt.Errorf("failed to decode the chain bundle: %w", err)
+	}
+
+	roots := x509.NewCertPool()
+	intermediates := x509.NewCertPool()
+	for _, cert := range bundleCerts {
+		if isSelfSigned(cert) {
+			roots.AddCert(cert)
+		} else {
+			intermediates.AddCert(cert)
+		}
+	}
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	chains, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return utilpki.PEMBundle{}, err
+	}
+
+	return utilpki.ParseSingleCertificateChain(chains[0])
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil
+}
+
+// trustAnchors returns the root certificates which the chains issued by the
+// Venafi issuer must verify against, which are read from the referenced
+// Secret, or the system trust store if not set.
+func (v *Venafi) trustAnchors(issuerObj cmapi.GenericIssuer, verification *cmapi.VenafiChainVerification) (*x509.CertPool, error) {
+	if verification.TrustAnchorsSecretRef == nil {
+		return x509.SystemCertPool()
+	}
+
+	data, err := v.secretKeyData(issuerObj, verification.TrustAnchorsSecretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	certs, err := utilpki.DecodeX509CertificateSetBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the trust anchors: %w", err)
+	}
+
+	roots := x509.NewCertPool()
+	for _, cert := range certs {
+		roots.AddCert(cert)
+	}
+
+	return roots, nil
+}
+
+// verifyChain verifies that the chain of the bundle returned by the Venafi
+// platform, including its CA, chains up to one of the given roots at the
+// given time.
+func verifyChain(bundle utilpki.PEMBundle, roots *x509.CertPool, now time.Time) error {
+	certs, err := utilpki.DecodeX509CertificateChainBytes(bundle.ChainPEM)
+	if err != nil {
+		return err
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	if len(bundle.CAPEM) > 0 {
+		ca, err := utilpki.DecodeX509CertificateBytes(bundle.CAPEM)
+		if err != nil {
+			return err
+		}
+		intermediates.AddCert(ca)
+	}
+
+	_, err = certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// testChain is a certificate chain issued by an intermediate CA of a root CA,
+// and an unrelated root CA, which are valid for an hour around a time.
+type testChain struct {
+	rootPEM, intermediatePEM, otherRootPEM, leafPEM []byte
+}
+
+func newTestChain(t *testing.T, now time.Time) testChain {
+	newCA := func(name string, parent *x509.Certificate, parentKey any) ([]byte, *x509.Certificate, any) {
+		pk, err := pki.GenerateECPrivateKey(256)
+		require.NoError(t, err)
+
+		tmpl := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: name},
+			BasicConstraintsValid: true,
+			IsCA:                  true,
+			NotBefore:             now.Add(-time.Hour),
+			NotAfter:              now.Add(time.Hour),
+			KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		}
+		if parent == nil {
+			parent, parentKey = tmpl, pk
+		}
+
+		certPEM, cert, err := pki.SignCertificate(tmpl, parent, pk.Public(), parentKey)
+		require.NoError(t, err)
+		return certPEM, cert, pk
+	}
+
+	rootPEM, rootCert, rootPK := newCA("root-ca", nil, nil)
+	intermediatePEM, intermediateCert, intermediatePK := newCA("intermediate-ca", rootCert, rootPK)
+	otherRootPEM, _, _ := newCA("other-root-ca", nil, nil)
+
+	leafPK, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	leafPEM, _, err := pki.SignCertificate(&x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "leaf"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+	}, intermediateCert, leafPK.Public(), intermediatePK)
+	require.NoError(t, err)
+
+	return testChain{
+		rootPEM:         rootPEM,
+		intermediatePEM: intermediatePEM,
+		otherRootPEM:    otherRootPEM,
+		leafPEM:         leafPEM,
+	}
+}
+
+func join(pems ...[]byte) []byte {
+	var out []byte
+	for _, pem := range pems {
+		out = append(out, pem...)
+	}
+	return out
+}
+
+func TestCompleteChain(t *testing.T) {
+	now := time.Now()
+
+	chain := newTestChain(t, now)
+	rootPEM, intermediatePEM, otherRootPEM, leafPEM := chain.rootPEM, chain.intermediatePEM, chain.otherRootPEM, chain.leafPEM
+
+	tests := map[string]struct {
+		chainPEM       []byte
+		chainBundlePEM []byte
+		now            time.Time
+
+		expectedBundle pki.PEMBundle
+		expectedErr    bool
+	}{
+		"completes the chain with the intermediates of the bundle": {
+			chainPEM:       leafPEM,
+			chainBundlePEM: join(intermediatePEM, rootPEM),
+			now:            now,
+			expectedBundle: pki.PEMBundle{ChainPEM: join(leafPEM, intermediatePEM), CAPEM: rootPEM},
+		},
+		"completes the chain with the root of the bundle": {
+			chainPEM:       join(leafPEM, intermediatePEM),
+			chainBundlePEM: rootPEM,
+			now:            now,
+			expectedBundle: pki.PEMBundle{ChainPEM: join(leafPEM, intermediatePEM), CAPEM: rootPEM},
+		},
+		"fails if the bundle does not contain the intermediates": {
+			chainPEM:       leafPEM,
+			chainBundlePEM: rootPEM,
+			now:            now,
+			expectedErr:    true,
+		},
+		"fails if the bundle does not contain the root": {
+			chainPEM:       leafPEM,
+			chainBundlePEM: join(intermediatePEM, otherRootPEM),
+			now:            now,
+			expectedErr:    true,
+		},
+		"fails if the chain has expired": {
+			chainPEM:       leafPEM,
+			chainBundlePEM: join(intermediatePEM, rootPEM),
+			now:            now.Add(2 * time.Hour),
+			expectedErr:    true,
+		},
+		"fails if the bundle is not PEM encoded": {
+			chainPEM:       leafPEM,
+			chainBundlePEM: []byte("not a certificate"),
+			now:            now,
+			expectedErr:    true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			bundle, err := pki.ParseSingleCertificateChainPEM(test.chainPEM)
+			require.NoError(t, err)
+
+			complete, err := hasRootCA(bundle)
+			require.NoError(t, err)
+			assert.False(t, complete)
+
+			bundle, err = completeChain(bundle, test.chainBundlePEM, test.now)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, string(test.expectedBundle.ChainPEM), string(bundle.ChainPEM))
+			assert.Equal(t, string(test.expectedBundle.CAPEM), string(bundle.CAPEM))
+
+			complete, err = hasRootCA(bundle)
+			require.NoError(t, err)
+			assert.True(t, complete)
+		})
+	}
+}
+
+func TestVerifyChain(t *testing.T) {
+	now := time.Now()
+	chain := newTestChain(t, now)
+
+	tests := map[string]struct {
+		chainPEM       []byte
+		trustAnchorPEM []byte
+		now            time.Time
+
+		expectedErr bool
+	}{
+		"verifies a chain which includes its intermediates": {
+			chainPEM:       join(chain.leafPEM, chain.intermediatePEM),
+			trustAnchorPEM: chain.rootPEM,
+			now:            now,
+		},
+		"verifies a chain which includes its root": {
+			chainPEM:       join(chain.leafPEM, chain.intermediatePEM, chain.rootPEM),
+			trustAnchorPEM: chain.rootPEM,
+			now:            now,
+		},
+		"fails if the chain lacks its intermediates": {
+			chainPEM:       chain.leafPEM,
+			trustAnchorPEM: chain.rootPEM,
+			now:            now,
+			expectedErr:    true,
+		},
+		"fails if the chain does not chain up to the trust anchors": {
+			chainPEM:       join(chain.leafPEM, chain.intermediatePEM, chain.rootPEM),
+			trustAnchorPEM: chain.otherRootPEM,
+			now:            now,
+			expectedErr:    true,
+		},
+		"fails if the chain has expired": {
+			chainPEM:       join(chain.leafPEM, chain.intermediatePEM),
+			trustAnchorPEM: chain.rootPEM,
+			now:            now.Add(2 * time.Hour),
+			expectedErr:    true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			bundle, err := pki.ParseSingleCertificateChainPEM(test.chainPEM)
+			require.NoError(t, err)
+
+			roots := x509.NewCertPool()
+			require.True(t, roots.AppendCertsFromPEM(test.trustAnchorPEM))
+
+			err = verifyChain(bundle, roots, test.now)
+			if test.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTrustAnchors(t *testing.T) {
+	chain := newTestChain(t, time.Now())
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerNamespace("test-ns"))
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	require.NoError(t, indexer.Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "trust-anchors", Namespace: "test-ns"},
+		Data: map[string][]byte{
+			cmmeta.TLSCAKey: chain.rootPEM,
+			"invalid":       []byte("not a certificate"),
+		},
+	}))
+	v := &Venafi{secretsLister: corelisters.NewSecretLister(indexer)}
+
+	t.Run("the trust anchors are read from the referenced secret", func(t *testing.T) {
+		roots, err := v.trustAnchors(issuer, &cmapi.VenafiChainVerification{
+			TrustAnchorsSecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "trust-anchors"}},
+		})
+		require.NoError(t, err)
+
+		bundle, err := pki.ParseSingleCertificateChainPEM(join(chain.leafPEM, chain.intermediatePEM))
+		require.NoError(t, err)
+		assert.NoError(t, verifyChain(bundle, roots, time.Now()))
+	})
+
+	t.Run("an error is returned if the secret does not exist", func(t *testing.T) {
+		_, err := v.trustAnchors(issuer, &cmapi.VenafiChainVerification{
+			TrustAnchorsSecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "missing"}},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("an error is returned if the trust anchors are not PEM encoded", func(t *testing.T) {
+		_, err := v.trustAnchors(issuer, &cmapi.VenafiChainVerification{
+			TrustAnchorsSecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "trust-anchors"}, Key: "invalid"},
+		})
+		assert.Error(t, err)
+	})
+}
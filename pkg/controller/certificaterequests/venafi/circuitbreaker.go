@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+// errBackendUnavailable is returned when a signing is not attempted because
+// the circuit breaker of the issuer is open.
+type errBackendUnavailable struct {
+	// failures is the number of consecutive failures to reach the Venafi
+	// platform.
+	failures int
+}
+
+func (err errBackendUnavailable) Error() string {
+	return fmt.Sprintf("the last %d calls to the Venafi platform failed", err.failures)
+}
+
+// circuitBreakers tracks the failures to reach the Venafi platform of each
+// Venafi issuer. Once the calls for an issuer have failed threshold times in a
+// row, its circuit breaker opens and signings for the issuer are failed fast
+// rather than adding load to the Venafi platform during an outage. After the
+// open duration, a single signing is let through to probe the Venafi
+// platform, which closes the circuit breaker if it succeeds.
+type circuitBreakers struct {
+	clock   clock.Clock
+	metrics *metrics.Metrics
+
+	// threshold is the number of consecutive failures after which the circuit
+	// breaker of an issuer opens. A value of zero or less disables the
+	// circuit breakers.
+	threshold    int
+	openDuration time.Duration
+
+	lock     sync.Mutex
+	breakers map[string]*circuitBreaker
+}
+
+type circuitBreaker struct {
+	state    string
+	failures int
+	// probeAt is the time after which the next probe is let through while the
+	// circuit breaker is not closed.
+	probeAt time.Time
+}
+
+func newCircuitBreakers(clock clock.Clock, metrics *metrics.Metrics, threshold int, openDuration time.Duration) *circuitBreakers {
+	return &circuitBreakers{
+		clock:        clock,
+		metrics:      metrics,
+		threshold:    threshold,
+		openDuration: openDuration,
+		breakers:     make(map[string]*circuitBreaker),
+	}
+}
+
+// allow returns nil if the CertificateRequest may be signed by the issuer.
+// Otherwise, it returns errBackendUnavailable along with the time remaining
+// before the next probe is let through.
+func (b *circuitBreakers) allow(cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) (time.Duration, error) {
+	if b == nil || b.threshold <= 0 {
+		return 0, nil
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	breaker, ok := b.breakers[issuerKey(issuerObj)]
+	if !ok || breaker.state == metrics.VenafiCircuitBreakerStateClosed {
+		return 0, nil
+	}
+
+	now := b.clock.Now()
+	if now.Before(breaker.probeAt) {
+		return breaker.probeAt.Sub(now), errBackendUnavailable{failures: breaker.failures}
+	}
+
+	// Only a single probe is let through per open duration, so that a probe
+	// whose outcome is never recorded does not keep the circuit breaker
+	// half-open forever.
+	breaker.probeAt = now.Add(b.openDuration)
+	b.setState(cr, breaker, metrics.VenafiCircuitBreakerStateHalfOpen)
+
+	return 0, nil
+}
+
+// success records that the Venafi platform of the issuer responded, which
+// closes its circuit breaker.
+func (b *circuitBreakers) success(cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) {
+	if b == nil || b.threshold <= 0 {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	key := issuerKey(issuerObj)
+	breaker, ok := b.breakers[key]
+	if !ok {
+		return
+	}
+
+	delete(b.breakers, key)
+	if breaker.state != metrics.VenafiCircuitBreakerStateClosed {
+		b.setState(cr, breaker, metrics.VenafiCircuitBreakerStateClosed)
+	}
+}
+
+// failure records that a call to the Venafi platform of the issuer failed,
+// which opens its circuit breaker once the calls have failed threshold times
+// in a row, or if the call was a probe.
+func (b *circuitBreakers) failure(cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) {
+	if b == nil || b.threshold <= 0 {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	key := issuerKey(issuerObj)
+	breaker, ok := b.breakers[key]
+	if !ok {
+		breaker = &circuitBreaker{state: metrics.VenafiCircuitBreakerStateClosed}
+		b.breakers[key] = breaker
+	}
+
+	breaker.failures++
+
+	switch breaker.state {
+	case metrics.VenafiCircuitBreakerStateClosed:
+		if breaker.failures < b.threshold {
+			return
+		}
+	case metrics.VenafiCircuitBreakerStateOpen:
+		// Calls made before the circuit breaker opened do not extend the
+		// time before the next probe.
+		return
+	}
+
+	breaker.probeAt = b.clock.Now().Add(b.openDuration)
+	b.setState(cr, breaker, metrics.VenafiCircuitBreakerStateOpen)
+}
+
+func (b *circuitBreakers) setState(cr *cmapi.CertificateRequest, breaker *circuitBreaker, state string) {
+	breaker.state = state
+	if b.metrics != nil {
+		b.metrics.SetVenafiCircuitBreakerState(cr.Spec.IssuerRef, state)
+	}
+}
+
+// issuerKey returns the key identifying the issuer across Issuers and
+// ClusterIssuers.
+func issuerKey(issuerObj cmapi.GenericIssuer) string {
+	return issuerObj.GetNamespace() + "/" + issuerObj.GetName()
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	venafitest "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestCircuitBreakers(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	cr := gen.CertificateRequest("test-cr")
+	issuerA := gen.Issuer("issuer-a")
+	issuerB := gen.Issuer("issuer-b")
+
+	b := newCircuitBreakers(clock, nil, 3, time.Minute)
+
+	allowed := func(issuerObj cmapi.GenericIssuer) bool {
+		t.Helper()
+		_, err := b.allow(cr, issuerObj)
+		return err == nil
+	}
+
+	// The circuit breaker opens after threshold consecutive failures.
+	b.failure(cr, issuerA)
+	b.failure(cr, issuerA)
+	b.success(cr, issuerA)
+	b.failure(cr, issuerA)
+	b.failure(cr, issuerA)
+	assert.True(t, allowed(issuerA), "expected a success to reset the failures")
+
+	b.failure(cr, issuerA)
+	delay, err := b.allow(cr, issuerA)
+	assert.Equal(t, errBackendUnavailable{failures: 3}, err)
+	assert.Equal(t, time.Minute, delay)
+
+	// Circuit breakers are tracked per issuer.
+	assert.True(t, allowed(issuerB))
+
+	// Failures of calls made before the circuit breaker opened do not extend
+	// the time before the next probe.
+	clock.Step(30 * time.Second)
+	b.failure(cr, issuerA)
+	delay, _ = b.allow(cr, issuerA)
+	assert.Equal(t, 30*time.Second, delay)
+
+	// A single probe is let through once the open duration has elapsed, and
+	// the circuit breaker opens again if it fails.
+	clock.Step(30 * time.Second)
+	assert.True(t, allowed(issuerA))
+	assert.False(t, allowed(issuerA))
+	b.failure(cr, issuerA)
+	delay, _ = b.allow(cr, issuerA)
+	assert.Equal(t, time.Minute, delay)
+
+	// A probe whose outcome is not recorded does not keep the circuit breaker
+	// half-open.
+	clock.Step(time.Minute)
+	assert.True(t, allowed(issuerA))
+	clock.Step(time.Minute)
+	assert.True(t, allowed(issuerA))
+
+	// A successful probe closes the circuit breaker.
+	b.success(cr, issuerA)
+	assert.True(t, allowed(issuerA))
+	assert.True(t, allowed(issuerA))
+}
+
+func TestCircuitBreakersDisabled(t *testing.T) {
+	cr := gen.CertificateRequest("test-cr")
+	issuer := gen.Issuer("test-issuer")
+
+	for _, b := range []*circuitBreakers{nil, newCircuitBreakers(fakeclock.NewFakeClock(time.Now()), nil, 0, time.Minute)} {
+		for i := 0; i < 10; i++ {
+			b.failure(cr, issuer)
+		}
+		_, err := b.allow(cr, issuer)
+		assert.NoError(t, err)
+	}
+}
+
+func TestSignFailsFastWhileBackendUnavailable(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "tpp-zone", TPP: &cmapi.VenafiTPP{}}))
+	newCR := func(name string) *cmapi.CertificateRequest {
+		return gen.CertificateRequest(name,
+			gen.SetCertificateRequestUID(types.UID(name)),
+			gen.SetCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test-pickup-id"}),
+		)
+	}
+
+	outage := venafitest.Failed(errors.New("service unavailable"))
+	script := &venafitest.Script{
+		RetrieveCertificate: []venafitest.Step{outage, outage, outage, venafitest.Pending()},
+	}
+	recorder := new(controllertest.FakeRecorder)
+	v := &Venafi{
+		reporter:             crutil.NewReporter(clock, recorder, 0),
+		clientBuilder:        script.ClientBuilder(),
+		clock:                clock,
+		limiter:              newSigningLimiter(0),
+		missingSecretRetries: newMissingSecretRetries(clock),
+		retrieveFailures:     newRetrieveFailures(clock, 0),
+		breakers:             newCircuitBreakers(clock, nil, 2, time.Minute),
+	}
+
+	sign := func(cr *cmapi.CertificateRequest) {
+		t.Helper()
+		resp, err := v.Sign(context.Background(), cr, issuer)
+		require.NoError(t, err)
+		assert.Nil(t, resp)
+	}
+
+	// The circuit breaker opens after the failures of different requests for
+	// the issuer.
+	sign(newCR("test-cr-1"))
+	sign(newCR("test-cr-2"))
+	assert.Equal(t, 2, script.RetrieveCalls())
+
+	cr := newCR("test-cr-3")
+	recorder.Events = nil
+	sign(cr)
+	assert.Equal(t, 2, script.RetrieveCalls())
+	assert.Equal(t, cmapi.CertificateRequestReasonPending, apiutil.CertificateRequestReadyReason(cr))
+	assert.Equal(t, []string{
+		"Normal BackendUnavailable The Venafi platform is unavailable after repeated failures, the request will be retried in 1m0s: the last 2 calls to the Venafi platform failed",
+	}, recorder.Events)
+
+	// The probe fails, so the circuit breaker opens again.
+	clock.Step(time.Minute)
+	sign(cr)
+	assert.Equal(t, 3, script.RetrieveCalls())
+	sign(newCR("test-cr-4"))
+	assert.Equal(t, 3, script.RetrieveCalls())
+
+	// The Venafi platform responds to the next probe, which closes the
+	// circuit breaker.
+	clock.Step(time.Minute)
+	sign(newCR("test-cr-5"))
+	sign(newCR("test-cr-6"))
+	assert.Equal(t, 5, script.RetrieveCalls())
+}
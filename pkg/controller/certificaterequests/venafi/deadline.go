@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"fmt"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// errIssuanceDeadlineExceeded is the error of a CertificateRequest which was
+// not issued within the issuance deadline of its issuer.
+type errIssuanceDeadlineExceeded struct {
+	deadline time.Duration
+}
+
+func (err errIssuanceDeadlineExceeded) Error() string {
+	return fmt.Sprintf("the certificate was not issued within the issuance deadline of %s", err.deadline)
+}
+
+// issuanceDeadline returns the time by which the certificate of the
+// CertificateRequest must be issued, counted from the creation of the
+// CertificateRequest. The boolean is false if the issuer has no issuance
+// deadline.
+func issuanceDeadline(cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) (time.Time, bool) {
+	deadline := issuerObj.GetSpec().Venafi.IssuanceDeadline
+	if deadline == nil || cr.CreationTimestamp.IsZero() {
+		return time.Time{}, false
+	}
+
+	return cr.CreationTimestamp.Add(deadline.Duration), true
+}
+
+// untilIssuanceDeadline caps the delay before the CertificateRequest is
+// synced again to the time left before its issuance deadline, so that it is
+// failed on time rather than after a long backoff.
+func untilIssuanceDeadline(cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer, now time.Time, delay time.Duration) time.Duration {
+	deadline, ok := issuanceDeadline(cr, issuerObj)
+	if !ok {
+		return delay
+	}
+
+	return max(0, min(delay, deadline.Sub(now)))
+}
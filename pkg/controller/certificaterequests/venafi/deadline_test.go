@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	venafitest "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestUntilIssuanceDeadline(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cr := gen.CertificateRequest("test-cr")
+	cr.CreationTimestamp = metav1.NewTime(created)
+
+	tests := map[string]struct {
+		cr       *cmapi.CertificateRequest
+		deadline *metav1.Duration
+		now      time.Time
+		delay    time.Duration
+
+		expectedDelay time.Duration
+	}{
+		"without a deadline the delay is unchanged": {
+			cr:            cr,
+			now:           created.Add(time.Hour),
+			delay:         time.Minute,
+			expectedDelay: time.Minute,
+		},
+		"a delay ending before the deadline is unchanged": {
+			cr:            cr,
+			deadline:      &metav1.Duration{Duration: time.Hour},
+			now:           created.Add(30 * time.Minute),
+			delay:         time.Minute,
+			expectedDelay: time.Minute,
+		},
+		"a delay ending after the deadline is capped to the deadline": {
+			cr:            cr,
+			deadline:      &metav1.Duration{Duration: time.Hour},
+			now:           created.Add(59 * time.Minute),
+			delay:         5 * time.Minute,
+			expectedDelay: time.Minute,
+		},
+		"once the deadline has elapsed the delay is zero": {
+			cr:            cr,
+			deadline:      &metav1.Duration{Duration: time.Hour},
+			now:           created.Add(2 * time.Hour),
+			delay:         5 * time.Minute,
+			expectedDelay: 0,
+		},
+		"a request without a creation time has no deadline": {
+			cr:            gen.CertificateRequest("test-cr"),
+			deadline:      &metav1.Duration{Duration: time.Hour},
+			now:           created.Add(2 * time.Hour),
+			delay:         5 * time.Minute,
+			expectedDelay: 5 * time.Minute,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{IssuanceDeadline: test.deadline}))
+			assert.Equal(t, test.expectedDelay, untilIssuanceDeadline(test.cr, issuer, test.now, test.delay))
+		})
+	}
+}
+
+func TestSignFailsAfterIssuanceDeadline(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	created := clock.Now()
+
+	pk, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	csrPEM, err := gen.CSRWithSigner(pk, gen.SetCSRCommonName("test-common-name"))
+	require.NoError(t, err)
+
+	cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestCSR(csrPEM), gen.SetCertificateRequestUID("test-uid"))
+	cr.CreationTimestamp = metav1.NewTime(created)
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+		Zone:             "tpp-zone",
+		TPP:              &cmapi.VenafiTPP{},
+		IssuanceDeadline: &metav1.Duration{Duration: time.Minute},
+	}))
+
+	script := venafitest.NewPendingScript("test-pickup-id")
+	recorder := new(controllertest.FakeRecorder)
+	v := &Venafi{
+		reporter:             crutil.NewReporter(clock, recorder, 0),
+		clientBuilder:        script.ClientBuilder(),
+		clock:                clock,
+		limiter:              newSigningLimiter(0),
+		missingSecretRetries: newMissingSecretRetries(clock),
+		retrieveFailures:     newRetrieveFailures(clock, time.Hour),
+		enrollments:          newPendingEnrollments(clock),
+	}
+
+	for apiutil.CertificateRequestReadyReason(cr) != cmapi.CertificateRequestReasonFailed {
+		require.Less(t, script.RetrieveCalls(), 10, "expected the request to be failed")
+
+		resp, err := v.Sign(context.Background(), cr, issuer)
+		require.NoError(t, err)
+		assert.Nil(t, resp)
+
+		// The pending certificate is never retried after the deadline.
+		if next, ok := nextPendingRetryTime(cr); ok {
+			assert.False(t, next.After(created.Add(time.Minute)), "expected the next retry %s not to be after the deadline", next)
+			clock.SetTime(next)
+		}
+	}
+
+	assert.Equal(t, 1, script.RequestCalls())
+	assert.Equal(t, created.Add(time.Minute), clock.Now())
+	assert.Equal(t,
+		"Warning IssuanceDeadlineExceeded Venafi certificate was not issued before the issuance deadline of the issuer: the certificate was not issued within the issuance deadline of 1m0s",
+		recorder.Events[len(recorder.Events)-1])
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// disallowedDomains returns the common name and DNS names requested by the
+// PEM encoded CSR which are not matched by any of the allowed domain
+// patterns of the issuer. Nothing is returned if no patterns are configured.
+func disallowedDomains(csrPEM []byte, allowed []string) ([]string, error) {
+	if len(allowed) == 0 {
+		return nil, nil
+	}
+
+	csr, err := utilpki.DecodeX509CertificateRequestBytes(csrPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode CSR: %w", err)
+	}
+
+	names := csr.DNSNames
+	if cn := csr.Subject.CommonName; cn != "" {
+		names = append([]string{cn}, names...)
+	}
+
+	var disallowed []string
+	for _, name := range names {
+		if !domainAllowed(name, allowed) {
+			disallowed = append(disallowed, name)
+		}
+	}
+
+	return disallowed, nil
+}
+
+func domainAllowed(name string, allowed []string) bool {
+	for _, pattern := range allowed {
+		if matchDomain(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchDomain returns whether the domain matches the pattern, ignoring case.
+// The labels of the pattern are matched against the labels of the domain
+// with path.Match, so that a `*` matches any characters within a label. A
+// leading `**` label matches one or more labels of the domain. The labels of
+// requested wildcard domains are matched like any other, so `*.example.com`
+// is only allowed by patterns whose first label matches `*` itself, such as
+// `*.example.com` or `**.example.com`.
+func matchDomain(pattern, name string) bool {
+	patternLabels := strings.Split(strings.ToLower(strings.TrimSuffix(pattern, ".")), ".")
+	nameLabels := strings.Split(strings.ToLower(strings.TrimSuffix(name, ".")), ".")
+
+	if patternLabels[0] == "**" {
+		patternLabels = patternLabels[1:]
+		if len(nameLabels) <= len(patternLabels) {
+			return false
+		}
+		nameLabels = nameLabels[len(nameLabels)-len(patternLabels):]
+	}
+
+	if len(nameLabels) != len(patternLabels) {
+		return false
+	}
+
+	for i := range patternLabels {
+		if ok, err := path.Match(patternLabels[i], nameLabels[i]); err != nil || !ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestMatchDomain(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		match         bool
+	}{
+		{"example.com", "example.com", true},
+		{"example.com", "EXAMPLE.com.", true},
+		{"example.com", "www.example.com", false},
+
+		// A `*` matches within a single label.
+		{"*.example.com", "www.example.com", true},
+		{"*.example.com", "example.com", false},
+		{"*.example.com", "a.www.example.com", false},
+		{"web-*.example.com", "web-1.example.com", true},
+		{"web-*.example.com", "api.example.com", false},
+		{"*.example.com", "www.example.org", false},
+
+		// A leading `**` matches any subdomain.
+		{"**.example.com", "www.example.com", true},
+		{"**.example.com", "a.b.c.example.com", true},
+		{"**.example.com", "example.com", false},
+		{"**.example.com", "badexample.com", false},
+		{"**.example.com", "www.example.com.evil.org", false},
+
+		// Requested wildcard domains are matched like any other name.
+		{"*.example.com", "*.example.com", true},
+		{"**.example.com", "*.apps.example.com", true},
+		{"www.example.com", "*.example.com", false},
+		{"web-*.example.com", "*.example.com", false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.match, matchDomain(test.pattern, test.name), "pattern %q, name %q", test.pattern, test.name)
+	}
+}
+
+func TestDisallowedDomains(t *testing.T) {
+	csrPEM, _, err := gen.CSR(x509.ECDSA,
+		gen.SetCSRCommonName("example.com"),
+		gen.SetCSRDNSNames("example.com", "www.example.com", "api.example.org", "*.example.com"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	disallowed, err := disallowedDomains(csrPEM, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, disallowed)
+
+	disallowed, err = disallowedDomains(csrPEM, []string{"example.com", "www.example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"api.example.org", "*.example.com"}, disallowed)
+
+	disallowed, err = disallowedDomains(csrPEM, []string{"example.com", "*.example.com", "**.example.org"})
+	assert.NoError(t, err)
+	assert.Empty(t, disallowed)
+
+	_, err = disallowedDomains([]byte("not a csr"), []string{"example.com"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// pendingEnrollmentTTL is how long the pickup ID of a certificate requested
+// from the Venafi platform is remembered, in case it could not be persisted
+// on the CertificateRequest before the request is synced again.
+const pendingEnrollmentTTL = time.Hour
+
+// enrollmentHash returns a hash of the CSR of the CertificateRequest and of
+// the issuer signing it, which identifies the enrollment of the request on
+// the Venafi platform.
+func enrollmentHash(cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) string {
+	h := sha256.New()
+	h.Write(cr.Spec.Request)
+	h.Write([]byte{0})
+	h.Write([]byte(cr.Spec.IssuerRef.Kind))
+	h.Write([]byte{0})
+	h.Write([]byte(issuerKey(issuerObj)))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// pendingEnrollments tracks the certificates requested from the Venafi
+// platform which have not been retrieved yet, by the hash of their
+// enrollment. A sync racing with the update of a CertificateRequest, or
+// reading it from a stale informer cache, may not see the pickup ID of a
+// certificate already requested for it, so the pending enrollment is
+// continued rather than the same CSR being enrolled again.
+// A nil *pendingEnrollments tracks nothing.
+type pendingEnrollments struct {
+	clock clock.Clock
+
+	lock        sync.Mutex
+	enrollments map[string]pendingEnrollment
+}
+
+type pendingEnrollment struct {
+	pickupID string
+	expiry   time.Time
+}
+
+func newPendingEnrollments(clock clock.Clock) *pendingEnrollments {
+	return &pendingEnrollments{
+		clock:       clock,
+		enrollments: make(map[string]pendingEnrollment),
+	}
+}
+
+// record records the pickup ID of the certificate requested for the
+// enrollment with the given hash.
+func (e *pendingEnrollments) record(hash, pickupID string) {
+	if e == nil {
+		return
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	now := e.clock.Now()
+	for h, enrollment := range e.enrollments {
+		if !now.Before(enrollment.expiry) {
+			delete(e.enrollments, h)
+		}
+	}
+
+	e.enrollments[hash] = pendingEnrollment{
+		pickupID: pickupID,
+		expiry:   now.Add(pendingEnrollmentTTL),
+	}
+}
+
+// lookup returns the pickup ID of the pending enrollment with the given hash,
+// if any.
+func (e *pendingEnrollments) lookup(hash string) (string, bool) {
+	if e == nil {
+		return "", false
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	enrollment, ok := e.enrollments[hash]
+	if !ok || !e.clock.Now().Before(enrollment.expiry) {
+		return "", false
+	}
+	return enrollment.pickupID, true
+}
+
+// forget stops tracking the enrollment with the given hash, for example once
+// its certificate has been retrieved.
+func (e *pendingEnrollments) forget(hash string) {
+	if e == nil {
+		return
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	delete(e.enrollments, hash)
+}
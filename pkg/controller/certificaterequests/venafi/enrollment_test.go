@@ -0,0 +1,190 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"crypto"
+	"testing"
+	"time"
+
+	"github.com/Venafi/vcert/v5/pkg/endpoint"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestEnrollmentHash(t *testing.T) {
+	cr := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestCSR([]byte("csr")),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Kind: "Issuer", Name: "test-issuer"}),
+	)
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerNamespace("test-ns"))
+
+	hash := enrollmentHash(cr, issuer)
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, enrollmentHash(cr.DeepCopy(), issuer.DeepCopy()), "expected the hash to be stable")
+
+	otherCSR := gen.CertificateRequestFrom(cr, gen.SetCertificateRequestCSR([]byte("other-csr")))
+	assert.NotEqual(t, hash, enrollmentHash(otherCSR, issuer))
+
+	otherNamespace := gen.IssuerFrom(issuer, gen.SetIssuerNamespace("other-ns"))
+	assert.NotEqual(t, hash, enrollmentHash(cr, otherNamespace))
+
+	// The zone of the issuer may change while a certificate is pending, so it
+	// is not part of the hash.
+	otherZone := gen.IssuerFrom(issuer, gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "other-zone"}))
+	assert.Equal(t, hash, enrollmentHash(cr, otherZone))
+}
+
+func TestPendingEnrollments(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	e := newPendingEnrollments(clock)
+
+	_, ok := e.lookup("test-hash")
+	assert.False(t, ok)
+
+	e.record("test-hash", "test-pickup-id")
+	pickupID, ok := e.lookup("test-hash")
+	assert.True(t, ok)
+	assert.Equal(t, "test-pickup-id", pickupID)
+
+	e.forget("test-hash")
+	_, ok = e.lookup("test-hash")
+	assert.False(t, ok)
+
+	// Enrollments are forgotten once they expire.
+	e.record("test-hash", "test-pickup-id")
+	clock.Step(pendingEnrollmentTTL)
+	_, ok = e.lookup("test-hash")
+	assert.False(t, ok)
+
+	e.record("other-hash", "other-pickup-id")
+	assert.NotContains(t, e.enrollments, "test-hash", "expected expired enrollments to be removed")
+
+	// A nil tracker tracks nothing.
+	var nilEnrollments *pendingEnrollments
+	nilEnrollments.record("test-hash", "test-pickup-id")
+	_, ok = nilEnrollments.lookup("test-hash")
+	assert.False(t, ok)
+}
+
+func TestSignDeduplicatesEnrollments(t *testing.T) {
+	testPK, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	csrPEM := generateCSR(t, testPK)
+
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+		Zone: "tpp-zone",
+		TPP:  &cmapi.VenafiTPP{},
+	}))
+
+	var requested int
+	var retrievedPickupIDs []string
+	v := &Venafi{
+		reporter: crutil.NewReporter(fixedClock, new(controllertest.FakeRecorder), 0),
+		clientBuilder: func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+			return &fake.Venafi{
+				RequestCertificateFn: func([]byte, time.Duration, string, *api.Location, crypto.Hash, []api.CustomField) (string, error) {
+					requested++
+					return "new-pickup-id", nil
+				},
+				RetrieveCertificateFn: func(pickupID string, _ []byte, _ []api.CustomField) ([]byte, error) {
+					retrievedPickupIDs = append(retrievedPickupIDs, pickupID)
+					return nil, endpoint.ErrCertificatePending{}
+				},
+			}, nil
+		},
+		clock:                fixedClock,
+		limiter:              newSigningLimiter(0),
+		missingSecretRetries: newMissingSecretRetries(fixedClock),
+		retrieveFailures:     newRetrieveFailures(fixedClock, 0),
+		enrollments:          newPendingEnrollments(fixedClock),
+	}
+
+	t.Run("the pending enrollment of a request is continued if its pickup ID was not persisted", func(t *testing.T) {
+		cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestCSR(csrPEM))
+
+		_, err := v.Sign(context.Background(), cr.DeepCopy(), issuer)
+		require.NoError(t, err)
+		assert.Equal(t, 1, requested)
+
+		// The CertificateRequest is synced again without the annotations set
+		// by the first sync, for example from a stale informer cache.
+		stale := cr.DeepCopy()
+		_, err = v.Sign(context.Background(), stale, issuer)
+		require.NoError(t, err)
+		assert.Equal(t, 1, requested, "expected the CSR not to be enrolled again")
+		assert.Equal(t, []string{"new-pickup-id"}, retrievedPickupIDs)
+		assert.Equal(t, "new-pickup-id", stale.Annotations[cmapi.VenafiPickupIDAnnotationKey])
+		assert.Equal(t, enrollmentHash(cr, issuer), stale.Annotations[cmapi.VenafiEnrollmentHashAnnotationKey])
+	})
+
+	t.Run("the pickup ID recorded for a different request is ignored", func(t *testing.T) {
+		requested, retrievedPickupIDs = 0, nil
+
+		otherPK, err := pki.GenerateECPrivateKey(256)
+		require.NoError(t, err)
+		cr := gen.CertificateRequest("copied-cr",
+			gen.SetCertificateRequestCSR(generateCSR(t, otherPK)),
+			gen.SetCertificateRequestAnnotations(map[string]string{
+				cmapi.VenafiPickupIDAnnotationKey:       "copied-pickup-id",
+				cmapi.VenafiEnrollmentHashAnnotationKey: "copied-hash",
+				cmapi.VenafiRetryCountAnnotationKey:     "3",
+			}),
+		)
+
+		_, err = v.Sign(context.Background(), cr, issuer)
+		require.NoError(t, err)
+		assert.Equal(t, 1, requested)
+		assert.Empty(t, retrievedPickupIDs)
+		assert.Equal(t, "new-pickup-id", cr.Annotations[cmapi.VenafiPickupIDAnnotationKey])
+		assert.Equal(t, enrollmentHash(cr, issuer), cr.Annotations[cmapi.VenafiEnrollmentHashAnnotationKey])
+		assert.NotContains(t, cr.Annotations, cmapi.VenafiRetryCountAnnotationKey)
+	})
+
+	t.Run("the pickup ID of requests without an enrollment hash is used", func(t *testing.T) {
+		requested, retrievedPickupIDs = 0, nil
+
+		otherPK, err := pki.GenerateECPrivateKey(256)
+		require.NoError(t, err)
+		cr := gen.CertificateRequest("existing-cr",
+			gen.SetCertificateRequestCSR(generateCSR(t, otherPK)),
+			gen.SetCertificateRequestAnnotations(map[string]string{
+				cmapi.VenafiPickupIDAnnotationKey: "existing-pickup-id",
+			}),
+		)
+
+		_, err = v.Sign(context.Background(), cr, issuer)
+		require.NoError(t, err)
+		assert.Zero(t, requested)
+		assert.Equal(t, []string{"existing-pickup-id"}, retrievedPickupIDs)
+		assert.Equal(t, enrollmentHash(cr, issuer), cr.Annotations[cmapi.VenafiEnrollmentHashAnnotationKey])
+	})
+}
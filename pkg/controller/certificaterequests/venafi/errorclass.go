@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"strings"
+
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+)
+
+// errorClass is whether an error returned by the Venafi platform is worth
+// retrying.
+type errorClass int
+
+const (
+	// errorClassPolicy is an error caused by the request being rejected by
+	// the policy of the Venafi zone, which retrying will not resolve.
+	errorClassPolicy errorClass = iota + 1
+
+	// errorClassTransient is an error, such as rate limiting, which is
+	// expected to be resolved by retrying later.
+	errorClassTransient
+)
+
+// errorClassification classifies the errors whose message contains the
+// substring.
+type errorClassification struct {
+	substring string
+	class     errorClass
+	reason    crutil.Reason
+}
+
+// errorClassifications classify the errors of the Venafi platform which are
+// only distinguishable by their message, because they are returned as
+// generic errors rather than as errors of a known type. The substrings are
+// lower case and are matched in order, transient errors first so that an
+// error is retried rather than failed when in doubt.
+var errorClassifications = []errorClassification{
+	{substring: "rate limit", class: errorClassTransient, reason: crutil.ReasonRateLimited},
+	{substring: "too many requests", class: errorClassTransient, reason: crutil.ReasonRateLimited},
+	{substring: "server unavailable", class: errorClassTransient, reason: crutil.ReasonBackendUnavailable},
+	{substring: "service unavailable", class: errorClassTransient, reason: crutil.ReasonBackendUnavailable},
+	{substring: "temporarily unavailable", class: errorClassTransient, reason: crutil.ReasonBackendUnavailable},
+	{substring: "connection refused", class: errorClassTransient, reason: crutil.ReasonBackendUnavailable},
+	{substring: "connection reset", class: errorClassTransient, reason: crutil.ReasonBackendUnavailable},
+	{substring: "i/o timeout", class: errorClassTransient, reason: crutil.ReasonTimeout},
+
+	{substring: "policy does not allow", class: errorClassPolicy, reason: crutil.ReasonPolicyViolation},
+	{substring: "policy doesn't match request", class: errorClassPolicy, reason: crutil.ReasonPolicyViolation},
+	{substring: "not allowed by policy", class: errorClassPolicy, reason: crutil.ReasonPolicyViolation},
+	{substring: "denied by policy", class: errorClassPolicy, reason: crutil.ReasonPolicyViolation},
+	{substring: "does not comply with policy", class: errorClassPolicy, reason: crutil.ReasonPolicyViolation},
+}
+
+// classifyError returns the classification of the error from its message,
+// and false if the message does not match any of the errorClassifications.
+// It is a fallback for the errors which cannot be matched by type.
+func classifyError(err error) (errorClassification, bool) {
+	message := strings.ToLower(err.Error())
+	for _, classification := range errorClassifications {
+		if strings.Contains(message, classification.substring) {
+			return classification, true
+		}
+	}
+	return errorClassification{}, false
+}
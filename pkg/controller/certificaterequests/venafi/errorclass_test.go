@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Venafi/vcert/v5/pkg/verror"
+	"github.com/stretchr/testify/assert"
+
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := map[string]struct {
+		err error
+
+		expectedClass  errorClass
+		expectedReason crutil.Reason
+		expectedOK     bool
+	}{
+		"a rate limited request is transient": {
+			err:            errors.New("unexpected status code on TPP Certificate Request.\n Status:\n 429 Too Many Requests"),
+			expectedClass:  errorClassTransient,
+			expectedReason: crutil.ReasonRateLimited,
+			expectedOK:     true,
+		},
+		"messages are matched regardless of case": {
+			err:            errors.New("Rate Limit exceeded, try again later"),
+			expectedClass:  errorClassTransient,
+			expectedReason: crutil.ReasonRateLimited,
+			expectedOK:     true,
+		},
+		"an unavailable server is transient": {
+			err:            fmt.Errorf("retrieving certificate: %w", verror.ServerTemporaryUnavailableError),
+			expectedClass:  errorClassTransient,
+			expectedReason: crutil.ReasonBackendUnavailable,
+			expectedOK:     true,
+		},
+		"a network timeout is transient": {
+			err:            errors.New(`Post "https://tpp.example.com/vedsdk/certificates/request": dial tcp 10.0.0.1:443: i/o timeout`),
+			expectedClass:  errorClassTransient,
+			expectedReason: crutil.ReasonTimeout,
+			expectedOK:     true,
+		},
+		"a request rejected by the policy is a policy error": {
+			err:            errors.New("certificate request failed: the policy does not allow the requested key size"),
+			expectedClass:  errorClassPolicy,
+			expectedReason: crutil.ReasonPolicyViolation,
+			expectedOK:     true,
+		},
+		"a policy validation error of vcert is a policy error": {
+			err:            fmt.Errorf("%w: common name is not allowed", verror.PolicyValidationError),
+			expectedClass:  errorClassPolicy,
+			expectedReason: crutil.ReasonPolicyViolation,
+			expectedOK:     true,
+		},
+		"transient errors take precedence over policy errors": {
+			err:            errors.New("policy does not allow the request: rate limit exceeded"),
+			expectedClass:  errorClassTransient,
+			expectedReason: crutil.ReasonRateLimited,
+			expectedOK:     true,
+		},
+		"an unknown error is not classified": {
+			err: errors.New("this is an error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			classification, ok := classifyError(test.err)
+			assert.Equal(t, test.expectedOK, ok)
+			assert.Equal(t, test.expectedClass, classification.class)
+			assert.Equal(t, test.expectedReason, classification.reason)
+		})
+	}
+}
+
+func TestErrorClassificationsAreLowerCase(t *testing.T) {
+	for _, classification := range errorClassifications {
+		assert.Equal(t, strings.ToLower(classification.substring), classification.substring)
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+type errorLogKey struct {
+	uid    types.UID
+	reason crutil.Reason
+}
+
+type loggedError struct {
+	time       time.Time
+	suppressed int
+}
+
+// errorLogLimiter limits how often the errors encountered while signing a
+// CertificateRequest are logged. During a sustained failure the same error
+// is encountered on every sync of the request, so only its first occurrence
+// is logged, and then at most one occurrence per interval along with the
+// number of occurrences which were not logged.
+type errorLogLimiter struct {
+	clock    clock.Clock
+	interval time.Duration
+
+	lock      sync.Mutex
+	logged    map[errorLogKey]loggedError
+	lastPrune time.Time
+}
+
+func newErrorLogLimiter(clock clock.Clock, interval time.Duration) *errorLogLimiter {
+	return &errorLogLimiter{
+		clock:    clock,
+		interval: interval,
+		logged:   make(map[errorLogKey]loggedError),
+	}
+}
+
+// allow returns whether an error with the given reason should be logged for
+// the CertificateRequest, and if so the number of occurrences of the error
+// which were not logged since it was last logged.
+func (l *errorLogLimiter) allow(cr *cmapi.CertificateRequest, reason crutil.Reason) (bool, int) {
+	if l == nil || l.interval <= 0 {
+		return true, 0
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.clock.Now()
+	l.prune(now)
+
+	key := errorLogKey{uid: cr.UID, reason: reason}
+
+	last, ok := l.logged[key]
+	if ok && now.Sub(last.time) < l.interval {
+		last.suppressed++
+		l.logged[key] = last
+		return false, 0
+	}
+
+	l.logged[key] = loggedError{time: now}
+
+	return true, last.suppressed
+}
+
+// prune removes the errors which were last logged more than two intervals
+// ago. They can no longer suppress an error, and the requests which have not
+// encountered them again within an interval have most likely stopped
+// failing. Pruning is done at most once per interval.
+func (l *errorLogLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < l.interval {
+		return
+	}
+
+	for key, logged := range l.logged {
+		if now.Sub(logged.time) >= 2*l.interval {
+			delete(l.logged, key)
+		}
+	}
+
+	l.lastPrune = now
+}
+
+// logSignError logs an error encountered while signing the CertificateRequest
+// with the reason last reported on it, unless an error with the same reason
+// has been logged for the request within the error log interval. Suppressed
+// errors are still logged at the debug level.
+func (v *Venafi) logSignError(log logr.Logger, reporter *signReporter, cr *cmapi.CertificateRequest, err error, message string) {
+	ok, suppressed := v.errorLogs.allow(cr, reporter.reason)
+	if !ok {
+		log.V(logf.DebugLevel).Info(message, "error", err.Error(), "reason", reporter.reason, "rateLimited", true)
+		return
+	}
+
+	if suppressed > 0 {
+		log = log.WithValues("suppressed", suppressed)
+	}
+	log.Error(err, message)
+}
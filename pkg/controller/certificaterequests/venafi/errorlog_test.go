@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestErrorLogLimiter(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestUID("test-uid"))
+	otherCR := gen.CertificateRequest("other-cr", gen.SetCertificateRequestUID("other-uid"))
+
+	l := newErrorLogLimiter(clock, time.Minute)
+
+	// The first occurrence of an error is logged.
+	allowed, suppressed := l.allow(cr, crutil.ReasonRequestError)
+	assert.True(t, allowed)
+	assert.Equal(t, 0, suppressed)
+
+	// Further occurrences within the interval are not.
+	for range 3 {
+		clock.Step(10 * time.Second)
+		allowed, _ = l.allow(cr, crutil.ReasonRequestError)
+		assert.False(t, allowed)
+	}
+
+	// Errors with another reason, or for another request, are logged.
+	allowed, _ = l.allow(cr, crutil.ReasonRetrieveError)
+	assert.True(t, allowed)
+	allowed, _ = l.allow(otherCR, crutil.ReasonRequestError)
+	assert.True(t, allowed)
+
+	// Once the interval has elapsed, the error is logged again along with the
+	// number of occurrences which were not logged.
+	clock.Step(30 * time.Second)
+	allowed, suppressed = l.allow(cr, crutil.ReasonRequestError)
+	assert.True(t, allowed)
+	assert.Equal(t, 3, suppressed)
+
+	allowed, _ = l.allow(cr, crutil.ReasonRequestError)
+	assert.False(t, allowed)
+
+	// Errors which have not been encountered again are pruned.
+	clock.Step(3 * time.Minute)
+	_, _ = l.allow(otherCR, crutil.ReasonRetrieveError)
+	assert.Len(t, l.logged, 1)
+}
+
+func TestErrorLogLimiterDisabled(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestUID("test-uid"))
+
+	for _, l := range []*errorLogLimiter{nil, newErrorLogLimiter(clock, 0)} {
+		for range 3 {
+			allowed, suppressed := l.allow(cr, crutil.ReasonRequestError)
+			assert.True(t, allowed)
+			assert.Equal(t, 0, suppressed)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// signingLimiter limits the number of concurrent signings made against each
+// Venafi issuer, so that a large number of CertificateRequests for the same
+// issuer do not overwhelm the Venafi API.
+type signingLimiter struct {
+	limit int
+
+	lock       sync.Mutex
+	semaphores map[string]chan struct{}
+}
+
+// newSigningLimiter returns a signingLimiter which allows up to limit
+// concurrent signings per issuer. A limit of zero or less means no limit.
+func newSigningLimiter(limit int) *signingLimiter {
+	return &signingLimiter{
+		limit:      limit,
+		semaphores: make(map[string]chan struct{}),
+	}
+}
+
+// acquire blocks until a signing slot is available for the given issuer, or
+// the context is cancelled. The returned function must be called to release
+// the slot once signing has completed.
+func (l *signingLimiter) acquire(ctx context.Context, issuerObj cmapi.GenericIssuer) (func(), error) {
+	if l == nil || l.limit <= 0 {
+		return func() {}, nil
+	}
+
+	sem := l.semaphoreFor(issuerKey(issuerObj))
+
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (l *signingLimiter) semaphoreFor(key string) chan struct{} {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	sem, ok := l.semaphores[key]
+	if !ok {
+		sem = make(chan struct{}, l.limit)
+		l.semaphores[key] = sem
+	}
+
+	return sem
+}
+
+// withSigningWait appends the time spent waiting for a signing slot to the
+// message, if any.
+func withSigningWait(message string, wait time.Duration) string {
+	if wait <= 0 {
+		return message
+	}
+
+	return fmt.Sprintf("%s (waited %s for a concurrent signing slot)", message, wait.Round(time.Millisecond))
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestSigningLimiter(t *testing.T) {
+	issuerA := gen.Issuer("issuer-a", gen.SetIssuerNamespace("ns"))
+	issuerB := gen.Issuer("issuer-b", gen.SetIssuerNamespace("ns"))
+
+	l := newSigningLimiter(1)
+
+	releaseA, err := l.acquire(context.Background(), issuerA)
+	require.NoError(t, err)
+
+	// A different issuer has its own limit.
+	releaseB, err := l.acquire(context.Background(), issuerB)
+	require.NoError(t, err)
+	releaseB()
+
+	// The same issuer blocks until the slot is released.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	_, err = l.acquire(ctx, issuerA)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	releaseA()
+
+	releaseA, err = l.acquire(context.Background(), issuerA)
+	require.NoError(t, err)
+	releaseA()
+}
+
+func TestSigningLimiterUnlimited(t *testing.T) {
+	issuer := gen.Issuer("issuer", gen.SetIssuerNamespace("ns"))
+
+	l := newSigningLimiter(0)
+	for i := 0; i < 10; i++ {
+		_, err := l.acquire(context.Background(), issuer)
+		require.NoError(t, err)
+	}
+}
+
+func TestWithSigningWait(t *testing.T) {
+	assert.Equal(t, "message", withSigningWait("message", 0))
+	assert.Equal(t, "message (waited 1.5s for a concurrent signing slot)", withSigningWait("message", time.Millisecond*1500))
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+const (
+	// maxMissingSecretRetries is the number of times a CertificateRequest is
+	// retried when the Secret referenced by its issuer is not found, before
+	// the request is left pending until the Secret changes.
+	maxMissingSecretRetries = 5
+
+	missingSecretRetryInitialInterval = time.Second
+)
+
+// missingSecretRetries tracks the CertificateRequests whose issuer references
+// a Secret which could not be found, or which does not contain valid
+// credentials. A Secret which was just created or updated may not have been
+// synced to the informer cache yet, so such requests are retried a bounded
+// number of times before they are left pending until the Secret changes.
+type missingSecretRetries struct {
+	clock clock.Clock
+
+	lock     sync.Mutex
+	attempts map[types.UID]missingSecretAttempt
+}
+
+type missingSecretAttempt struct {
+	count int
+	next  time.Time
+}
+
+func newMissingSecretRetries(clock clock.Clock) *missingSecretRetries {
+	return &missingSecretRetries{
+		clock:    clock,
+		attempts: make(map[types.UID]missingSecretAttempt),
+	}
+}
+
+// record records that the Secret referenced by the issuer of the given
+// CertificateRequest was not found, and returns the delay after which the
+// request should be retried. The boolean is false once the request has been
+// retried maxMissingSecretRetries times, in which case the request should not
+// be retried until the Secret changes. Syncs made before the previously returned delay has elapsed, for
+// example because the status of the request was updated, are not counted.
+func (r *missingSecretRetries) record(cr *cmapi.CertificateRequest) (time.Duration, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.clock.Now()
+
+	attempt, ok := r.attempts[cr.UID]
+	if ok && now.Before(attempt.next) {
+		return attempt.next.Sub(now), true
+	}
+
+	if attempt.count >= maxMissingSecretRetries {
+		delete(r.attempts, cr.UID)
+		return 0, false
+	}
+
+	delay := missingSecretRetryInitialInterval << attempt.count
+	r.attempts[cr.UID] = missingSecretAttempt{
+		count: attempt.count + 1,
+		next:  now.Add(delay),
+	}
+
+	return delay, true
+}
+
+// forget stops tracking the given CertificateRequest, for example once the
+// Secret referenced by its issuer has been found.
+func (r *missingSecretRetries) forget(cr *cmapi.CertificateRequest) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.attempts, cr.UID)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestMissingSecretRetries(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestUID("test-uid"))
+
+	r := newMissingSecretRetries(clock)
+
+	// The delay doubles on each retry.
+	for _, expected := range []time.Duration{time.Second, time.Second * 2, time.Second * 4, time.Second * 8, time.Second * 16} {
+		delay, retry := r.record(cr)
+		assert.True(t, retry)
+		assert.Equal(t, expected, delay)
+
+		// Syncs made before the delay has elapsed are not counted.
+		clock.Step(expected / 2)
+		delay, retry = r.record(cr)
+		assert.True(t, retry)
+		assert.Equal(t, expected/2, delay)
+
+		clock.Step(expected / 2)
+	}
+
+	_, retry := r.record(cr)
+	assert.False(t, retry, "expected the request not to be retried after the maximum number of retries")
+
+	// Forgetting the request resets the number of retries.
+	_, _ = r.record(cr)
+	r.forget(cr)
+	delay, retry := r.record(cr)
+	assert.True(t, retry)
+	assert.Equal(t, time.Second, delay)
+}
+
+func TestSignRetriesInvalidCredentials(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	queue := workqueue.NewTypedRateLimitingQueueWithConfig(
+		workqueue.DefaultTypedControllerRateLimiter[types.NamespacedName](),
+		workqueue.TypedRateLimitingQueueConfig[types.NamespacedName]{Clock: clock},
+	)
+	defer queue.ShutDown()
+
+	cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestUID("test-uid"))
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+		Zone: "tpp-zone",
+		TPP:  &cmapi.VenafiTPP{},
+	}))
+
+	credentialsErr := client.InvalidCredentialsError{SecretName: "test-tpp-secret", Reason: "the \"access-token\" key must be set"}
+	recorder := new(controllertest.FakeRecorder)
+	v := &Venafi{
+		reporter: crutil.NewReporter(clock, recorder, 0),
+		clientBuilder: func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+			return nil, credentialsErr
+		},
+		clock:                clock,
+		limiter:              newSigningLimiter(0),
+		missingSecretRetries: newMissingSecretRetries(clock),
+		retrieveFailures:     newRetrieveFailures(clock, time.Hour),
+		enrollments:          newPendingEnrollments(clock),
+		queue:                queue,
+	}
+
+	// The request is requeued with a growing delay while the credentials
+	// are invalid, then left pending until the Secret is updated.
+	for _, expected := range []time.Duration{time.Second, time.Second * 2, time.Second * 4, time.Second * 8, time.Second * 16} {
+		resp, err := v.Sign(context.Background(), cr, issuer)
+		require.NoError(t, err)
+		assert.Nil(t, resp)
+		assert.Equal(t, cmapi.CertificateRequestReasonPending, apiutil.CertificateRequestReadyReason(cr))
+
+		clock.Step(expected)
+		require.Eventually(t, func() bool { return queue.Len() == 1 }, time.Second*5, time.Millisecond*10, "expected the request to be requeued after %s", expected)
+		key, _ := queue.Get()
+		assert.Equal(t, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, key)
+		queue.Done(key)
+	}
+
+	resp, err := v.Sign(context.Background(), cr, issuer)
+	require.NoError(t, err)
+	assert.Nil(t, resp)
+	assert.Equal(t, cmapi.CertificateRequestReasonPending, apiutil.CertificateRequestReadyReason(cr))
+	assert.Equal(t, 0, queue.Len(), "expected the request not to be requeued until the secret is updated")
+	assert.Equal(t,
+		`Normal InvalidCredentials Required secret resource does not contain valid Venafi credentials after 5 retries, the request will be retried once it is updated: invalid Venafi credentials in secret "test-tpp-secret": the "access-token" key must be set`,
+		recorder.Events[len(recorder.Events)-1])
+}
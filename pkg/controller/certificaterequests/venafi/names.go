@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"fmt"
+	"strings"
+
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// maxSummarizedSANs is the maximum number of SANs listed in the summary of
+// the names requested by a CSR, so that the events of requests for many
+// domains stay readable.
+const maxSummarizedSANs = 5
+
+// withRequestedNames appends a summary of the common name and SANs requested
+// by the PEM encoded CSR to the message. The message is returned unchanged if
+// the CSR cannot be decoded or requests no names.
+func withRequestedNames(message string, csrPEM []byte) string {
+	csr, err := utilpki.DecodeX509CertificateRequestBytes(csrPEM)
+	if err != nil {
+		return message
+	}
+
+	var sans []string
+	sans = append(sans, csr.DNSNames...)
+	for _, ip := range csr.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	for _, uri := range csr.URIs {
+		sans = append(sans, uri.String())
+	}
+	sans = append(sans, csr.EmailAddresses...)
+
+	var parts []string
+	if cn := csr.Subject.CommonName; cn != "" {
+		parts = append(parts, fmt.Sprintf("CN %q", cn))
+	}
+	if len(sans) > 0 {
+		parts = append(parts, "SANs "+summarizeNames(sans, maxSummarizedSANs))
+	}
+	if len(parts) == 0 {
+		return message
+	}
+
+	return fmt.Sprintf("%s for %s", message, strings.Join(parts, " and "))
+}
+
+// summarizeNames joins the first max names, followed by the number of the
+// names which were left out.
+func summarizeNames(names []string, max int) string {
+	if len(names) <= max {
+		return strings.Join(names, ", ")
+	}
+
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:max], ", "), len(names)-max)
+}
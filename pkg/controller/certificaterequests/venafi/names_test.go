@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestWithRequestedNames(t *testing.T) {
+	pk, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	csr := func(mods ...gen.CSRModifier) []byte {
+		csrPEM, err := gen.CSRWithSigner(pk, mods...)
+		require.NoError(t, err)
+		return csrPEM
+	}
+
+	tests := map[string]struct {
+		csrPEM          []byte
+		expectedMessage string
+	}{
+		"the common name and SANs are listed": {
+			csrPEM: csr(
+				gen.SetCSRCommonName("example.com"),
+				gen.SetCSRDNSNames("example.com", "www.example.com"),
+				gen.SetCSRIPAddresses(net.ParseIP("10.0.0.1")),
+				gen.SetCSRURIs(&url.URL{Scheme: "spiffe", Host: "example.com", Path: "/workload"}),
+				gen.SetCSREmails([]string{"admin@example.com"}),
+			),
+			expectedMessage: `requested for CN "example.com" and SANs example.com, www.example.com, 10.0.0.1, spiffe://example.com/workload, admin@example.com`,
+		},
+		"long lists of SANs are truncated": {
+			csrPEM:          csr(gen.SetCSRDNSNames("a.example.com", "b.example.com", "c.example.com", "d.example.com", "e.example.com", "f.example.com", "g.example.com")),
+			expectedMessage: "requested for SANs a.example.com, b.example.com, c.example.com, d.example.com, e.example.com and 2 more",
+		},
+		"the message is unchanged if no names are requested": {
+			csrPEM:          csr(),
+			expectedMessage: "requested",
+		},
+		"the message is unchanged if the CSR cannot be decoded": {
+			csrPEM:          []byte("not-a-csr"),
+			expectedMessage: "requested",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expectedMessage, withRequestedNames("requested", test.csrPEM))
+		})
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
+)
+
+// withOriginCustomField returns the given custom fields with the origin of the
+// CertificateRequest set as the value of the origin custom field of the
+// issuer, replacing any field of the same name. The custom fields are returned
+// as is if the issuer does not record origins.
+func withOriginCustomField(fields []api.CustomField, cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) ([]api.CustomField, error) {
+	venCfg := issuerObj.GetSpec().Venafi
+	if venCfg.OriginCustomField == "" {
+		return fields, nil
+	}
+
+	certificate := cr.GetAnnotations()[cmapi.CertificateNameKey]
+	if certificate == "" {
+		certificate = cr.Name
+	}
+
+	value, err := api.Origin{
+		Namespace:   cr.Namespace,
+		Certificate: certificate,
+		Request:     cr.Name,
+	}.Render(venCfg.OriginTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := make([]api.CustomField, 0, len(fields)+1)
+	for _, field := range fields {
+		if field.Name != venCfg.OriginCustomField {
+			merged = append(merged, field)
+		}
+	}
+	return append(merged, api.CustomField{
+		Type:  api.CustomFieldTypePlain,
+		Name:  venCfg.OriginCustomField,
+		Value: value,
+	}), nil
+}
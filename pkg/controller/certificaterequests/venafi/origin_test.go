@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"crypto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestWithOriginCustomField(t *testing.T) {
+	requestFields := []api.CustomField{
+		{Type: api.CustomFieldTypePlain, Name: "team", Value: "example"},
+		{Type: api.CustomFieldTypePlain, Name: "origin", Value: "spoofed"},
+	}
+
+	tests := map[string]struct {
+		venafi      cmapi.VenafiIssuer
+		annotations map[string]string
+
+		expectedFields []api.CustomField
+		expectedErr    string
+	}{
+		"the custom fields are not changed if the issuer does not record origins": {
+			expectedFields: requestFields,
+		},
+		"the origin replaces the custom field of the same name": {
+			venafi:      cmapi.VenafiIssuer{OriginCustomField: "origin"},
+			annotations: map[string]string{cmapi.CertificateNameKey: "test-certificate"},
+			expectedFields: []api.CustomField{
+				{Type: api.CustomFieldTypePlain, Name: "team", Value: "example"},
+				{Type: api.CustomFieldTypePlain, Name: "origin", Value: "test-ns/test-certificate"},
+			},
+		},
+		"the origin is rendered from the template of the issuer": {
+			venafi: cmapi.VenafiIssuer{
+				OriginCustomField: "k8s-object",
+				OriginTemplate:    "cluster-a/{namespace}/{certificate}/{request}",
+			},
+			annotations: map[string]string{cmapi.CertificateNameKey: "test-certificate"},
+			expectedFields: append(requestFields[:2:2], api.CustomField{
+				Type: api.CustomFieldTypePlain, Name: "k8s-object", Value: "cluster-a/test-ns/test-certificate/test-cr",
+			}),
+		},
+		"the name of the request is used if it was not created for a Certificate": {
+			venafi: cmapi.VenafiIssuer{OriginCustomField: "k8s-object"},
+			expectedFields: append(requestFields[:2:2], api.CustomField{
+				Type: api.CustomFieldTypePlain, Name: "k8s-object", Value: "test-ns/test-cr",
+			}),
+		},
+		"origins which are too long are rejected": {
+			venafi: cmapi.VenafiIssuer{
+				OriginCustomField: "k8s-object",
+				OriginTemplate:    strings.Repeat("x", 250) + "/{certificate}",
+			},
+			annotations: map[string]string{cmapi.CertificateNameKey: "test-certificate"},
+			expectedErr: "must be no more than 255 characters, got 267",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := gen.CertificateRequest("test-cr",
+				gen.SetCertificateRequestNamespace("test-ns"),
+				gen.SetCertificateRequestAnnotations(test.annotations),
+			)
+
+			fields, err := withOriginCustomField(requestFields, cr, gen.Issuer("test-issuer", gen.SetIssuerVenafi(test.venafi)))
+			if test.expectedErr != "" {
+				assert.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedFields, fields)
+		})
+	}
+}
+
+func TestSignRecordsOrigin(t *testing.T) {
+	testPK, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+		Zone:              "tpp-zone",
+		TPP:               &cmapi.VenafiTPP{},
+		OriginCustomField: "Kubernetes Object",
+	}))
+
+	var requestedFields []api.CustomField
+	v := &Venafi{
+		reporter: crutil.NewReporter(fixedClock, new(controllertest.FakeRecorder), 0),
+		clientBuilder: func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+			return &fake.Venafi{
+				RequestCertificateFn: func(_ []byte, _ time.Duration, _ string, _ *api.Location, _ crypto.Hash, fields []api.CustomField) (string, error) {
+					requestedFields = fields
+					return "test-pickup-id", nil
+				},
+			}, nil
+		},
+		clock:                fixedClock,
+		limiter:              newSigningLimiter(0),
+		missingSecretRetries: newMissingSecretRetries(fixedClock),
+		retrieveFailures:     newRetrieveFailures(fixedClock, 0),
+		enrollments:          newPendingEnrollments(fixedClock),
+	}
+
+	t.Run("the origin of the request is recorded in the custom field of the issuer", func(t *testing.T) {
+		cr := gen.CertificateRequest("test-cr-1",
+			gen.SetCertificateRequestNamespace("test-ns"),
+			gen.SetCertificateRequestCSR(generateCSR(t, testPK)),
+			gen.SetCertificateRequestAnnotations(map[string]string{
+				cmapi.CertificateNameKey: "test-certificate",
+			}),
+		)
+
+		_, err := v.Sign(context.Background(), cr, issuer)
+		require.NoError(t, err)
+		assert.Equal(t, []api.CustomField{
+			{Type: api.CustomFieldTypePlain, Name: "Kubernetes Object", Value: "test-ns/test-certificate"},
+		}, requestedFields)
+	})
+
+	t.Run("requests whose origin cannot be recorded fail", func(t *testing.T) {
+		requestedFields = nil
+
+		cr := gen.CertificateRequest(strings.Repeat("x", 253),
+			gen.SetCertificateRequestNamespace("test-ns"),
+			gen.SetCertificateRequestCSR(generateCSR(t, testPK)),
+		)
+
+		_, err := v.Sign(context.Background(), cr, issuer)
+		require.NoError(t, err)
+		assert.Nil(t, requestedFields)
+
+		ready := cr.Status.Conditions[len(cr.Status.Conditions)-1]
+		assert.Equal(t, cmapi.CertificateRequestReasonFailed, ready.Reason)
+		assert.Contains(t, ready.Message, "Failed to record the origin of the request")
+	})
+}
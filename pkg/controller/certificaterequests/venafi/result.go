@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
+)
+
+// signOutcome is the outcome of a single call to Sign.
+type signOutcome string
+
+const (
+	// signOutcomeIssued means that the certificate was issued.
+	signOutcomeIssued signOutcome = "Issued"
+	// signOutcomePending means that the certificate has not been issued yet,
+	// and that the request will be retried.
+	signOutcomePending signOutcome = "Pending"
+	// signOutcomeTimeout means that a call to the Venafi platform timed out,
+	// and that the request will be retried.
+	signOutcomeTimeout signOutcome = "Timeout"
+	// signOutcomeFailed means that the request was terminally failed.
+	signOutcomeFailed signOutcome = "Failed"
+)
+
+// signResult is the result of a single call to Sign, so that the
+// classification of the outcome can be asserted without inspecting the
+// events sent for the CertificateRequest.
+type signResult struct {
+	outcome signOutcome
+	// reason is the reason reported for the outcome, if any. It is empty for
+	// issued certificates, and for requests which were not reported on, for
+	// example because they are waiting for a retry backoff to elapse.
+	reason crutil.Reason
+	// response is the issued certificate, if any.
+	response *issuerpkg.IssueResponse
+}
+
+// signReporter is a Reporter which records the last report made on a
+// CertificateRequest during a single call to Sign.
+type signReporter struct {
+	*crutil.Reporter
+
+	outcome signOutcome
+	reason  crutil.Reason
+}
+
+func newSignReporter(reporter *crutil.Reporter) *signReporter {
+	return &signReporter{Reporter: reporter}
+}
+
+func (r *signReporter) Failed(cr *cmapi.CertificateRequest, err error, reason crutil.Reason, message string) {
+	r.outcome, r.reason = signOutcomeFailed, reason
+	r.Reporter.Failed(cr, err, reason, message)
+}
+
+func (r *signReporter) DryRunValidated(cr *cmapi.CertificateRequest, message string) {
+	r.outcome, r.reason = signOutcomeFailed, crutil.ReasonDryRunValidated
+	r.Reporter.DryRunValidated(cr, message)
+}
+
+func (r *signReporter) Denied(cr *cmapi.CertificateRequest) {
+	r.outcome, r.reason = signOutcomeFailed, crutil.ReasonDenied
+	r.Reporter.Denied(cr)
+}
+
+func (r *signReporter) Pending(cr *cmapi.CertificateRequest, err error, reason crutil.Reason, message string) {
+	r.outcome, r.reason = signOutcomePending, reason
+	if reason == crutil.ReasonTimeout {
+		r.outcome = signOutcomeTimeout
+	}
+	r.Reporter.Pending(cr, err, reason, message)
+}
+
+// result returns the result of the call to Sign which returned the given
+// response. Requests which were not reported on keep the outcome of their
+// Ready condition.
+func (r *signReporter) result(cr *cmapi.CertificateRequest, response *issuerpkg.IssueResponse) signResult {
+	if response != nil {
+		return signResult{outcome: signOutcomeIssued, response: response}
+	}
+
+	outcome := r.outcome
+	if outcome == "" {
+		switch apiutil.CertificateRequestReadyReason(cr) {
+		case cmapi.CertificateRequestReasonPending:
+			outcome = signOutcomePending
+		case cmapi.CertificateRequestReasonFailed, cmapi.CertificateRequestReasonDenied:
+			outcome = signOutcomeFailed
+		}
+	}
+
+	return signResult{outcome: outcome, reason: r.reason}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	venafitest "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestSignWithResult(t *testing.T) {
+	pk, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	csrPEM, err := gen.CSRWithSigner(pk, gen.SetCSRCommonName("test-common-name"))
+	require.NoError(t, err)
+
+	baseCR := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestCSR(csrPEM),
+		gen.SetCertificateRequestUID("test-uid"),
+	)
+	enrolledCR := gen.CertificateRequestFrom(baseCR,
+		gen.SetCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test-pickup-id"}),
+	)
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "tpp-zone", TPP: &cmapi.VenafiTPP{}}))
+
+	template, err := pki.CertificateTemplateFromCertificateRequest(baseCR)
+	require.NoError(t, err)
+	certPEM, _, err := pki.SignCertificate(template, template, pk.Public(), pk)
+	require.NoError(t, err)
+
+	retrieving := func(step venafitest.Step) *venafitest.Script {
+		return &venafitest.Script{RetrieveCertificate: []venafitest.Step{step}}
+	}
+
+	tests := map[string]struct {
+		cr     *cmapi.CertificateRequest
+		script *venafitest.Script
+
+		expectedOutcome signOutcome
+		expectedReason  crutil.Reason
+	}{
+		"requested certificates are pending": {
+			cr:              baseCR,
+			script:          &venafitest.Script{RequestCertificate: []venafitest.Step{venafitest.Requested("test-pickup-id")}},
+			expectedOutcome: signOutcomePending,
+			expectedReason:  crutil.ReasonIssuancePending,
+		},
+		"retrieved certificates are issued": {
+			cr:              enrolledCR,
+			script:          retrieving(venafitest.Issued(certPEM)),
+			expectedOutcome: signOutcomeIssued,
+		},
+		"certificates pending issuance on the Venafi platform are pending": {
+			cr:              enrolledCR,
+			script:          retrieving(venafitest.Pending()),
+			expectedOutcome: signOutcomePending,
+			expectedReason:  crutil.ReasonIssuancePending,
+		},
+		"retrievals which time out on the Venafi platform are pending": {
+			cr:              enrolledCR,
+			script:          retrieving(venafitest.TimedOut()),
+			expectedOutcome: signOutcomePending,
+			expectedReason:  crutil.ReasonIssuancePending,
+		},
+		"rejected credentials keep the request pending": {
+			cr:              enrolledCR,
+			script:          retrieving(venafitest.Unauthorized()),
+			expectedOutcome: signOutcomePending,
+			expectedReason:  crutil.ReasonAuthenticationError,
+		},
+		"unexpected retrieval errors are retried": {
+			cr:              enrolledCR,
+			script:          retrieving(venafitest.Failed(errors.New("unexpected error"))),
+			expectedOutcome: signOutcomePending,
+			expectedReason:  crutil.ReasonRetrieveError,
+		},
+		"invalid custom fields fail the request": {
+			cr: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestAnnotations(map[string]string{cmapi.VenafiCustomFieldsAnnotationKey: "not json"}),
+			),
+			script:          &venafitest.Script{},
+			expectedOutcome: signOutcomeFailed,
+			expectedReason:  crutil.ReasonCustomFieldsError,
+		},
+		"requests waiting for a retry backoff stay pending": {
+			cr: gen.CertificateRequestFrom(enrolledCR,
+				gen.AddCertificateRequestAnnotations(map[string]string{
+					cmapi.VenafiNextRetryTimeAnnotationKey: fixedClock.Now().Add(time.Minute).UTC().Format(time.RFC3339),
+				}),
+				gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+					Type:   cmapi.CertificateRequestConditionReady,
+					Status: cmmeta.ConditionFalse,
+					Reason: cmapi.CertificateRequestReasonPending,
+				}),
+			),
+			script:          &venafitest.Script{},
+			expectedOutcome: signOutcomePending,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := &Venafi{
+				reporter:             crutil.NewReporter(fixedClock, new(controllertest.FakeRecorder), 0),
+				clientBuilder:        test.script.ClientBuilder(),
+				clock:                fixedClock,
+				limiter:              newSigningLimiter(0),
+				missingSecretRetries: newMissingSecretRetries(fixedClock),
+				retrieveFailures:     newRetrieveFailures(fixedClock, 0),
+			}
+
+			result, err := v.signWithResult(context.Background(), test.cr.DeepCopy(), issuer)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedOutcome, result.outcome)
+			assert.Equal(t, test.expectedReason, result.reason)
+			assert.Equal(t, test.expectedOutcome == signOutcomeIssued, result.response != nil)
+		})
+	}
+}
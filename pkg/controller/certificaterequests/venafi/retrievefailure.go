@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+const (
+	retrieveFailureInitialInterval = time.Second * 5
+	retrieveFailureMaxInterval     = time.Minute * 5
+)
+
+// retrieveFailures tracks the CertificateRequests for which retrieving the
+// certificate from the Venafi platform failed with an unexpected error, such
+// as during an outage of the Venafi platform. Such requests are retried with
+// an exponential backoff, until they have been failing for longer than the
+// maximum elapsed time.
+type retrieveFailures struct {
+	clock clock.Clock
+
+	// maxElapsed is the time after the first failure after which requests
+	// are no longer retried. A value of zero or less means that requests are
+	// retried indefinitely.
+	maxElapsed time.Duration
+
+	lock     sync.Mutex
+	failures map[types.UID]retrieveFailure
+}
+
+type retrieveFailure struct {
+	first time.Time
+	count int
+	next  time.Time
+}
+
+func newRetrieveFailures(clock clock.Clock, maxElapsed time.Duration) *retrieveFailures {
+	return &retrieveFailures{
+		clock:      clock,
+		maxElapsed: maxElapsed,
+		failures:   make(map[types.UID]retrieveFailure),
+	}
+}
+
+// wait returns the time remaining before the certificate of the given
+// CertificateRequest should be retrieved again. The boolean is false if the
+// request is not backing off.
+func (r *retrieveFailures) wait(cr *cmapi.CertificateRequest) (time.Duration, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.clock.Now()
+
+	failure, ok := r.failures[cr.UID]
+	if !ok || !now.Before(failure.next) {
+		return 0, false
+	}
+
+	return failure.next.Sub(now), true
+}
+
+// record records that retrieving the certificate of the given
+// CertificateRequest failed, and returns the delay after which it should be
+// retried. The delay doubles on each failure, up to
+// retrieveFailureMaxInterval. The boolean is false once the request has been
+// failing for longer than the maximum elapsed time, in which case the request
+// should be failed.
+func (r *retrieveFailures) record(cr *cmapi.CertificateRequest) (time.Duration, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.clock.Now()
+
+	failure, ok := r.failures[cr.UID]
+	if !ok {
+		failure.first = now
+	}
+
+	if r.maxElapsed > 0 && now.Sub(failure.first) >= r.maxElapsed {
+		delete(r.failures, cr.UID)
+		return 0, false
+	}
+
+	delay := retrieveFailureInitialInterval
+	for i := 0; i < failure.count && delay < retrieveFailureMaxInterval; i++ {
+		delay *= 2
+	}
+	delay = min(delay, retrieveFailureMaxInterval)
+
+	failure.count++
+	failure.next = now.Add(delay)
+	r.failures[cr.UID] = failure
+
+	return delay, true
+}
+
+// forget stops tracking the given CertificateRequest, for example once the
+// Venafi platform has responded successfully, so that any later failure starts
+// backing off from the initial interval again.
+func (r *retrieveFailures) forget(cr *cmapi.CertificateRequest) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.failures, cr.UID)
+}
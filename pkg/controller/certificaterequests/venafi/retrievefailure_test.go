@@ -0,0 +1,197 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
+	venafitest "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestRetrieveFailures(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestUID("test-uid"))
+
+	r := newRetrieveFailures(clock, time.Hour)
+
+	_, waiting := r.wait(cr)
+	assert.False(t, waiting, "expected no backoff before the first failure")
+
+	// The delay doubles on each failure, up to the maximum interval.
+	var elapsed time.Duration
+	for _, expected := range []time.Duration{
+		5 * time.Second, 10 * time.Second, 20 * time.Second, 40 * time.Second, 80 * time.Second,
+		160 * time.Second, 5 * time.Minute, 5 * time.Minute,
+	} {
+		delay, retry := r.record(cr)
+		assert.True(t, retry)
+		assert.Equal(t, expected, delay)
+
+		clock.Step(expected / 2)
+		remaining, waiting := r.wait(cr)
+		assert.True(t, waiting)
+		assert.Equal(t, expected/2, remaining)
+
+		clock.Step(expected / 2)
+		_, waiting = r.wait(cr)
+		assert.False(t, waiting)
+
+		elapsed += expected
+	}
+
+	// The request is failed once it has been failing for the maximum
+	// elapsed time.
+	clock.Step(time.Hour - elapsed)
+	_, retry := r.record(cr)
+	assert.False(t, retry, "expected the request to be failed after the maximum elapsed time")
+
+	// Forgetting the request resets the backoff.
+	_, _ = r.record(cr)
+	_, _ = r.record(cr)
+	r.forget(cr)
+	_, waiting = r.wait(cr)
+	assert.False(t, waiting)
+	delay, retry := r.record(cr)
+	assert.True(t, retry)
+	assert.Equal(t, 5*time.Second, delay)
+}
+
+func TestRetrieveFailuresWithoutMaxElapsed(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestUID("test-uid"))
+
+	r := newRetrieveFailures(clock, 0)
+
+	for i := 0; i < 100; i++ {
+		delay, retry := r.record(cr)
+		assert.True(t, retry)
+		clock.Step(delay)
+	}
+}
+
+func TestSignRetriesRetrieveFailures(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+
+	pk, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	csrPEM, err := gen.CSRWithSigner(pk, gen.SetCSRCommonName("test-common-name"))
+	require.NoError(t, err)
+
+	cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestCSR(csrPEM), gen.SetCertificateRequestUID("test-uid"))
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "tpp-zone", TPP: &cmapi.VenafiTPP{}}))
+
+	template, err := pki.CertificateTemplateFromCertificateRequest(cr)
+	require.NoError(t, err)
+	certPEM, _, err := pki.SignCertificate(template, template, pk.Public(), pk)
+	require.NoError(t, err)
+
+	outage := venafitest.Failed(errors.New("service unavailable"))
+	script := venafitest.NewIssuingScript("test-pickup-id", certPEM, outage, outage)
+	v := &Venafi{
+		reporter:             crutil.NewReporter(clock, new(controllertest.FakeRecorder), 0),
+		clientBuilder:        script.ClientBuilder(),
+		clock:                clock,
+		limiter:              newSigningLimiter(0),
+		missingSecretRetries: newMissingSecretRetries(clock),
+		retrieveFailures:     newRetrieveFailures(clock, time.Hour),
+	}
+
+	sign := func() *issuerpkg.IssueResponse {
+		t.Helper()
+		resp, err := v.Sign(context.Background(), cr, issuer)
+		require.NoError(t, err)
+		return resp
+	}
+
+	assert.Nil(t, sign())
+	assert.Equal(t, 0, script.RetrieveCalls())
+
+	// Failures are reported as pending, and retried after a backoff.
+	assert.Nil(t, sign())
+	assert.Equal(t, 1, script.RetrieveCalls())
+	assert.Equal(t, cmapi.CertificateRequestReasonPending, apiutil.CertificateRequestReadyReason(cr))
+
+	clock.Step(time.Second)
+	assert.Nil(t, sign())
+	assert.Equal(t, 1, script.RetrieveCalls())
+
+	clock.Step(4 * time.Second)
+	assert.Nil(t, sign())
+	assert.Equal(t, 2, script.RetrieveCalls())
+
+	clock.Step(10 * time.Second)
+	resp := sign()
+	require.NotNil(t, resp)
+	assert.Equal(t, certPEM, resp.Certificate)
+	assert.Equal(t, 3, script.RetrieveCalls())
+
+	// A successful retrieval resets the backoff.
+	_, waiting := v.retrieveFailures.wait(cr)
+	assert.False(t, waiting)
+}
+
+func TestSignFailsAfterRetrieveFailureTimeout(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+
+	cr := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestUID("test-uid"),
+		gen.SetCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test-pickup-id"}),
+	)
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "tpp-zone", TPP: &cmapi.VenafiTPP{}}))
+
+	script := &venafitest.Script{
+		RetrieveCertificate: []venafitest.Step{venafitest.Failed(errors.New("service unavailable"))},
+	}
+	recorder := new(controllertest.FakeRecorder)
+	v := &Venafi{
+		reporter:             crutil.NewReporter(clock, recorder, 0),
+		clientBuilder:        script.ClientBuilder(),
+		clock:                clock,
+		limiter:              newSigningLimiter(0),
+		missingSecretRetries: newMissingSecretRetries(clock),
+		retrieveFailures:     newRetrieveFailures(clock, time.Minute),
+	}
+
+	for apiutil.CertificateRequestReadyReason(cr) != cmapi.CertificateRequestReasonFailed {
+		require.Less(t, script.RetrieveCalls(), 10, "expected the request to be failed")
+
+		resp, err := v.Sign(context.Background(), cr, issuer)
+		require.NoError(t, err)
+		assert.Nil(t, resp)
+
+		clock.Step(retrieveFailureMaxInterval)
+	}
+
+	assert.Equal(t, []string{
+		"Normal BackendUnavailable Failed to obtain venafi certificate, the request will be retried in 5s: service unavailable",
+		"Warning RetrieveError Failed to obtain venafi certificate, giving up after repeated failures: service unavailable",
+	}, recorder.Events)
+}
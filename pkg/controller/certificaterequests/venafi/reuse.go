@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	venaficlient "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
+)
+
+// defaultReuseMaxAge is the maximum age of the certificates reused by issuers
+// which do not set reuseMaxAge.
+const defaultReuseMaxAge = 24 * time.Hour
+
+// reusableCertificate is a certificate already issued by the Venafi platform
+// which can be returned for a CertificateRequest.
+type reusableCertificate struct {
+	pickupID string
+	certPEM  []byte
+}
+
+// findReusableCertificate searches the Venafi platform for a certificate
+// issued within the reuse max age of the issuer which can be returned for the
+// CertificateRequest. The pickup ID of the returned certificate is empty if
+// there is none.
+func (v *Venafi) findReusableCertificate(ctx context.Context, client venaficlient.Interface, cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) (reusableCertificate, error) {
+	maxAge := defaultReuseMaxAge
+	if age := issuerObj.GetSpec().Venafi.ReuseMaxAge; age != nil {
+		maxAge = age.Duration
+	}
+	issuedAfter := v.clock.Now().Add(-maxAge)
+
+	return callWithTimeout(ctx, v.requestTimeout, func() (reusableCertificate, error) {
+		pickupID, certPEM, err := client.FindReusableCertificate(cr.Spec.Request, issuedAfter)
+		return reusableCertificate{pickupID: pickupID, certPEM: certPEM}, err
+	})
+}
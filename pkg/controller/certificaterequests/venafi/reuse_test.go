@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestSignReuseExisting(t *testing.T) {
+	testPK, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	csrPEM := generateCSR(t, testPK)
+
+	tmpl, err := pki.CertificateTemplateFromCSRPEM(csrPEM)
+	require.NoError(t, err)
+	tmpl.NotBefore = fixedClockStart.Add(-time.Hour)
+	tmpl.NotAfter = fixedClockStart.Add(time.Hour)
+	certPEM, _, err := pki.SignCertificate(tmpl, tmpl, testPK.Public(), testPK)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		reuseExisting bool
+		reuseMaxAge   *metav1.Duration
+		pickupID      string
+		findErr       error
+
+		expectedIssuedAfter time.Time
+		expectedRequested   bool
+		expectedReused      bool
+	}{
+		"a new certificate is requested if reuse is disabled": {
+			pickupID:          "existing-pickup-id",
+			expectedRequested: true,
+		},
+		"an existing certificate is reused if one is found": {
+			reuseExisting:       true,
+			pickupID:            "existing-pickup-id",
+			expectedIssuedAfter: fixedClockStart.Add(-defaultReuseMaxAge),
+			expectedReused:      true,
+		},
+		"the max age of reused certificates is configured by the issuer": {
+			reuseExisting:       true,
+			reuseMaxAge:         &metav1.Duration{Duration: time.Hour},
+			pickupID:            "existing-pickup-id",
+			expectedIssuedAfter: fixedClockStart.Add(-time.Hour),
+			expectedReused:      true,
+		},
+		"a new certificate is requested if none can be reused": {
+			reuseExisting:       true,
+			expectedIssuedAfter: fixedClockStart.Add(-defaultReuseMaxAge),
+			expectedRequested:   true,
+		},
+		"a new certificate is requested if the search fails": {
+			reuseExisting:       true,
+			findErr:             errors.New("search error"),
+			expectedIssuedAfter: fixedClockStart.Add(-defaultReuseMaxAge),
+			expectedRequested:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestCSR(csrPEM))
+			issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+				Zone:          "tpp-zone",
+				TPP:           &cmapi.VenafiTPP{},
+				ReuseExisting: test.reuseExisting,
+				ReuseMaxAge:   test.reuseMaxAge,
+			}))
+
+			var searched, requested bool
+			recorder := new(controllertest.FakeRecorder)
+			v := &Venafi{
+				reporter: crutil.NewReporter(fixedClock, recorder, 0),
+				clientBuilder: func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+					return &fake.Venafi{
+						FindReusableCertificateFn: func(_ []byte, issuedAfter time.Time) (string, []byte, error) {
+							searched = true
+							assert.Equal(t, test.expectedIssuedAfter, issuedAfter)
+							if test.findErr != nil {
+								return "", nil, test.findErr
+							}
+							if test.pickupID == "" {
+								return "", nil, nil
+							}
+							return test.pickupID, certPEM, nil
+						},
+						RequestCertificateFn: func([]byte, time.Duration, string, *api.Location, crypto.Hash, []api.CustomField) (string, error) {
+							requested = true
+							return "new-pickup-id", nil
+						},
+					}, nil
+				},
+				clock:                fixedClock,
+				limiter:              newSigningLimiter(0),
+				missingSecretRetries: newMissingSecretRetries(fixedClock),
+				retrieveFailures:     newRetrieveFailures(fixedClock, 0),
+			}
+
+			resp, err := v.Sign(context.Background(), cr, issuer)
+			require.NoError(t, err)
+			assert.Equal(t, test.reuseExisting, searched)
+			assert.Equal(t, test.expectedRequested, requested)
+
+			if !test.expectedReused {
+				assert.Nil(t, resp)
+				assert.Equal(t, "new-pickup-id", cr.Annotations[cmapi.VenafiPickupIDAnnotationKey])
+				return
+			}
+
+			require.NotNil(t, resp)
+			assert.Equal(t, certPEM, resp.Certificate)
+			assert.Equal(t, test.pickupID, cr.Annotations[cmapi.VenafiPickupIDAnnotationKey])
+			assert.Equal(t, "tpp-zone", cr.Annotations[cmapi.VenafiZoneAnnotationKey])
+			assert.Equal(t, []string{
+				`Normal Reused Reusing the existing Venafi certificate with pickup ID "existing-pickup-id"`,
+			}, recorder.Events)
+		})
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmdoc "github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// handleSecretReferenceWorkFunc returns an informer event handler work
+// function which requeues the pending CertificateRequests of the Venafi
+// issuers whose credentials are stored in the synced Secret. Requests whose
+// credentials are missing or rejected are kept pending without being
+// retried, so they are only retried once the Secret changes.
+// clusterIssuerLister is nil if ClusterIssuers are not watched.
+func handleSecretReferenceWorkFunc(log logr.Logger,
+	issuerOptions controllerpkg.IssuerOptions,
+	certificateRequestLister cmlisters.CertificateRequestLister,
+	issuerLister cmlisters.IssuerLister,
+	clusterIssuerLister cmlisters.ClusterIssuerLister,
+	queue workqueue.TypedRateLimitingInterface[types.NamespacedName],
+) func(obj any) {
+	return func(obj any) {
+		log := log.WithName("handleSecretReference")
+		secret, ok := controllerpkg.ToSecret(obj)
+		if !ok {
+			log.Error(nil, "object is not a secret", "object", obj)
+			return
+		}
+		log = logf.WithResource(log, secret)
+
+		issuers, err := issuersForSecret(issuerOptions, issuerLister, clusterIssuerLister, secret)
+		if err != nil {
+			log.Error(err, "failed to determine the venafi issuers referencing the secret")
+			return
+		}
+
+		for _, iss := range issuers {
+			requests, err := pendingCertificateRequestsForIssuer(certificateRequestLister, iss)
+			if err != nil {
+				logf.WithRelatedResource(log, iss).Error(err, "failed to determine affected certificate requests")
+				continue
+			}
+			for _, request := range requests {
+				queue.Add(types.NamespacedName{
+					Name:      request.Name,
+					Namespace: request.Namespace,
+				})
+			}
+		}
+	}
+}
+
+// issuersForSecret returns the Venafi Issuers and ClusterIssuers which read
+// their credentials from the given Secret.
+func issuersForSecret(issuerOptions controllerpkg.IssuerOptions,
+	issuerLister cmlisters.IssuerLister,
+	clusterIssuerLister cmlisters.ClusterIssuerLister,
+	secret *corev1.Secret,
+) ([]cmapi.GenericIssuer, error) {
+	var affected []cmapi.GenericIssuer
+
+	issuers, err := issuerLister.Issuers(secret.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issuers: %w", err)
+	}
+	for _, iss := range issuers {
+		if referencesCredentialsSecret(iss, secret.Name) {
+			affected = append(affected, iss)
+		}
+	}
+
+	if clusterIssuerLister == nil || secret.Namespace != issuerOptions.ClusterResourceNamespace {
+		return affected, nil
+	}
+
+	clusterIssuers, err := clusterIssuerLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster issuers: %w", err)
+	}
+	for _, iss := range clusterIssuers {
+		if referencesCredentialsSecret(iss, secret.Name) {
+			affected = append(affected, iss)
+		}
+	}
+
+	return affected, nil
+}
+
+// referencesCredentialsSecret returns true if the given issuer is a Venafi
+// issuer which reads its credentials, or its fallback credentials, from the
+// Secret with the given name.
+func referencesCredentialsSecret(iss cmapi.GenericIssuer, name string) bool {
+	venCfg := iss.GetSpec().Venafi
+	if venCfg == nil {
+		return false
+	}
+
+	if venCfg.CredentialsRef != nil && venCfg.CredentialsRef.Name == name {
+		return true
+	}
+	if venCfg.TPP != nil && venCfg.TPP.CredentialsRef.Name == name {
+		return true
+	}
+	if venCfg.Cloud != nil && venCfg.Cloud.APITokenSecretRef.Name == name {
+		return true
+	}
+	for _, ref := range venCfg.FallbackCredentialsRefs {
+		if ref.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pendingCertificateRequestsForIssuer returns the CertificateRequests
+// referencing the given issuer which are pending issuance.
+func pendingCertificateRequestsForIssuer(lister cmlisters.CertificateRequestLister, iss cmapi.GenericIssuer) ([]*cmapi.CertificateRequest, error) {
+	kind := cmapi.IssuerKind
+	var requests []*cmapi.CertificateRequest
+	var err error
+	if _, isClusterIssuer := iss.(*cmapi.ClusterIssuer); isClusterIssuer {
+		kind = cmapi.ClusterIssuerKind
+		requests, err = lister.List(labels.Everything())
+	} else {
+		requests, err = lister.CertificateRequests(iss.GetNamespace()).List(labels.Everything())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificate requests: %w", err)
+	}
+
+	var affected []*cmapi.CertificateRequest
+	for _, request := range requests {
+		ref := request.Spec.IssuerRef
+		if ref.Group != "" && ref.Group != cmdoc.GroupName {
+			continue
+		}
+		refKind := ref.Kind
+		if refKind == "" {
+			refKind = cmapi.IssuerKind
+		}
+		if refKind != kind || ref.Name != iss.GetName() {
+			continue
+		}
+		if apiutil.CertificateRequestReadyReason(request) != cmapi.CertificateRequestReasonPending {
+			continue
+		}
+		affected = append(affected, request)
+	}
+
+	return affected, nil
+}
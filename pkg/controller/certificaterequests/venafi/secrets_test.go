@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2/ktesting"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func Test_handleSecretReferenceWorkFunc(t *testing.T) {
+	pending := gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+		Type:   cmapi.CertificateRequestConditionReady,
+		Status: cmmeta.ConditionFalse,
+		Reason: cmapi.CertificateRequestReasonPending,
+	})
+	failed := gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+		Type:   cmapi.CertificateRequestConditionReady,
+		Status: cmmeta.ConditionFalse,
+		Reason: cmapi.CertificateRequestReasonFailed,
+	})
+	issuerRef := func(name, kind string) gen.CertificateRequestModifier {
+		return gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: name, Kind: kind, Group: "cert-manager.io"})
+	}
+
+	tppIssuer := gen.Issuer("tpp",
+		gen.SetIssuerNamespace("test-namespace"),
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{TPP: &cmapi.VenafiTPP{CredentialsRef: cmmeta.LocalObjectReference{Name: "test-secret"}}}),
+	)
+	fallbackIssuer := gen.Issuer("fallback",
+		gen.SetIssuerNamespace("test-namespace"),
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+			TPP:                     &cmapi.VenafiTPP{CredentialsRef: cmmeta.LocalObjectReference{Name: "other-secret"}},
+			FallbackCredentialsRefs: []cmapi.VenafiCredentialsReference{{Name: "test-secret"}},
+		}),
+	)
+	otherIssuer := gen.Issuer("other",
+		gen.SetIssuerNamespace("test-namespace"),
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{TPP: &cmapi.VenafiTPP{CredentialsRef: cmmeta.LocalObjectReference{Name: "other-secret"}}}),
+	)
+	cloudClusterIssuer := gen.ClusterIssuer("cloud",
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{Cloud: &cmapi.VenafiCloud{APITokenSecretRef: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "test-secret"}}}}),
+	)
+
+	tests := map[string]struct {
+		secret        runtime.Object
+		existing      []runtime.Object
+		expectedQueue []types.NamespacedName
+	}{
+		"if given object is not a secret, expect empty queue": {
+			secret: gen.Certificate("not-a-secret"),
+			existing: []runtime.Object{
+				tppIssuer,
+				gen.CertificateRequest("a", gen.SetCertificateRequestNamespace("test-namespace"), issuerRef("tpp", "Issuer"), pending),
+			},
+		},
+		"pending requests of the issuers referencing the secret should be added to the queue": {
+			secret: gen.Secret("test-secret", gen.SetSecretNamespace("test-namespace")),
+			existing: []runtime.Object{
+				tppIssuer, fallbackIssuer, otherIssuer,
+				gen.CertificateRequest("a", gen.SetCertificateRequestNamespace("test-namespace"), issuerRef("tpp", "Issuer"), pending),
+				gen.CertificateRequest("b", gen.SetCertificateRequestNamespace("test-namespace"), issuerRef("fallback", ""), pending),
+				gen.CertificateRequest("c", gen.SetCertificateRequestNamespace("test-namespace"), issuerRef("other", "Issuer"), pending),
+				gen.CertificateRequest("d", gen.SetCertificateRequestNamespace("test-namespace"), issuerRef("tpp", "Issuer"), failed),
+				gen.CertificateRequest("e", gen.SetCertificateRequestNamespace("test-namespace"), issuerRef("tpp", "ClusterIssuer"), pending),
+			},
+			expectedQueue: []types.NamespacedName{
+				{Namespace: "test-namespace", Name: "a"},
+				{Namespace: "test-namespace", Name: "b"},
+			},
+		},
+		"secrets in other namespaces should be ignored": {
+			secret: gen.Secret("test-secret", gen.SetSecretNamespace("other-namespace")),
+			existing: []runtime.Object{
+				tppIssuer, cloudClusterIssuer,
+				gen.CertificateRequest("a", gen.SetCertificateRequestNamespace("test-namespace"), issuerRef("tpp", "Issuer"), pending),
+				gen.CertificateRequest("b", gen.SetCertificateRequestNamespace("test-namespace"), issuerRef("cloud", "ClusterIssuer"), pending),
+			},
+		},
+		"pending requests of cluster issuers referencing a secret in the cluster resource namespace should be added to the queue": {
+			secret: gen.Secret("test-secret", gen.SetSecretNamespace("cluster-resource-namespace")),
+			existing: []runtime.Object{
+				tppIssuer, cloudClusterIssuer,
+				gen.CertificateRequest("a", gen.SetCertificateRequestNamespace("test-namespace"), issuerRef("tpp", "Issuer"), pending),
+				gen.CertificateRequest("b", gen.SetCertificateRequestNamespace("test-namespace"), issuerRef("cloud", "ClusterIssuer"), pending),
+				gen.CertificateRequest("c", gen.SetCertificateRequestNamespace("other-namespace"), issuerRef("cloud", "ClusterIssuer"), pending),
+			},
+			expectedQueue: []types.NamespacedName{
+				{Namespace: "test-namespace", Name: "b"},
+				{Namespace: "other-namespace", Name: "c"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				CertManagerObjects: test.existing,
+			}
+			defer builder.Stop()
+			builder.Init()
+
+			factory := builder.Context.SharedInformerFactory.Certmanager().V1()
+			lister := factory.CertificateRequests().Lister()
+			issuerLister := factory.Issuers().Lister()
+			clusterIssuerLister := factory.ClusterIssuers().Lister()
+
+			builder.Start()
+
+			queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[types.NamespacedName]())
+			issuerOptions := controllerpkg.IssuerOptions{ClusterResourceNamespace: "cluster-resource-namespace"}
+			handleSecretReferenceWorkFunc(ktesting.NewLogger(t, ktesting.NewConfig()), issuerOptions, lister, issuerLister, clusterIssuerLister, queue)(test.secret)
+			require.Equal(t, len(test.expectedQueue), queue.Len())
+			var actualQueue []types.NamespacedName
+			for range test.expectedQueue {
+				i, _ := queue.Get()
+				actualQueue = append(actualQueue, i)
+			}
+			assert.ElementsMatch(t, test.expectedQueue, actualQueue)
+		})
+	}
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// errCallTimeout is returned by callWithTimeout when a call to the Venafi
+// platform does not return in time.
+type errCallTimeout struct {
+	timeout time.Duration
+
+	// reconcileBudget is true if the call did not return before the end of
+	// the reconcile budget, rather than within its own timeout. timeout is
+	// then the whole budget.
+	reconcileBudget bool
+}
+
+func (err errCallTimeout) Error() string {
+	if err.reconcileBudget {
+		return fmt.Sprintf("the request could not be signed within the reconcile timeout of %s", err.timeout)
+	}
+	return fmt.Sprintf("the Venafi platform did not respond within %s", err.timeout)
+}
+
+// isCallTimeout returns true if the error, or an error it wraps, was
+// returned because a call to the Venafi platform did not return in time.
+func isCallTimeout(err error) bool {
+	var timeoutErr errCallTimeout
+	return errors.As(err, &timeoutErr)
+}
+
+type reconcileBudgetKey struct{}
+
+// reconcileBudget is the time a single sync of a CertificateRequest may spend
+// building the Venafi client and calling the Venafi platform. It is shared by
+// all the calls made with callWithTimeout during the sync, so that the time a
+// controller worker is occupied is bounded regardless of which call is slow.
+type reconcileBudget struct {
+	clock    clock.PassiveClock
+	timeout  time.Duration
+	deadline time.Time
+}
+
+// withReconcileBudget returns a copy of ctx carrying a reconcile budget of the
+// given timeout, starting now. A timeout of zero or less means no budget.
+func withReconcileBudget(ctx context.Context, clock clock.PassiveClock, timeout time.Duration) context.Context {
+	if timeout <= 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, reconcileBudgetKey{}, &reconcileBudget{
+		clock:    clock,
+		timeout:  timeout,
+		deadline: clock.Now().Add(timeout),
+	})
+}
+
+// reconcileBudgetFrom returns the reconcile budget carried by ctx, or nil if
+// it has none.
+func reconcileBudgetFrom(ctx context.Context) *reconcileBudget {
+	budget, _ := ctx.Value(reconcileBudgetKey{}).(*reconcileBudget)
+	return budget
+}
+
+// remaining returns the time left in the budget.
+func (b *reconcileBudget) remaining() time.Duration {
+	return b.deadline.Sub(b.clock.Now())
+}
+
+// exhausted returns the error returned once the budget has been used up.
+func (b *reconcileBudget) exhausted() errCallTimeout {
+	return errCallTimeout{timeout: b.timeout, reconcileBudget: true}
+}
+
+// withBudgetDeadline returns a copy of ctx which is cancelled at the end of
+// its reconcile budget, if it has one, for waits which are not made with
+// callWithTimeout.
+func withBudgetDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	budget := reconcileBudgetFrom(ctx)
+	if budget == nil {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, budget.remaining())
+}
+
+type signingSlotKey struct{}
+
+// signingSlot is the concurrent signing slot of an issuer held by a sync. The
+// slot is released once the sync and every call to the Venafi platform it
+// made with callWithTimeout have returned, so that calls which timed out but
+// keep running in the background still count against the limit of the
+// issuer.
+// A nil *signingSlot releases nothing.
+type signingSlot struct {
+	lock    sync.Mutex
+	holders int
+	release func()
+}
+
+// withSigningSlot returns a copy of ctx carrying a signing slot held by the
+// sync, which releases the slot with the given function once done has been
+// called by the sync and by every call made with callWithTimeout.
+func withSigningSlot(ctx context.Context, release func()) (context.Context, *signingSlot) {
+	slot := &signingSlot{holders: 1, release: release}
+	return context.WithValue(ctx, signingSlotKey{}, slot), slot
+}
+
+// signingSlotFrom returns the signing slot carried by ctx, or nil if it has
+// none.
+func signingSlotFrom(ctx context.Context) *signingSlot {
+	slot, _ := ctx.Value(signingSlotKey{}).(*signingSlot)
+	return slot
+}
+
+func (s *signingSlot) hold() {
+	if s == nil {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.holders++
+}
+
+func (s *signingSlot) done() {
+	if s == nil {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.holders--
+	if s.holders == 0 {
+		s.release()
+	}
+}
+
+// callWithTimeout calls fn and waits at most timeout for it to return. vcert
+// does not support cancellation, so a call which times out keeps running in
+// the background and its result is discarded, but the controller worker is
+// released. The same applies if ctx is cancelled, for example because the
+// CertificateRequest was deleted. A timeout of zero or less means no timeout.
+// If ctx carries a reconcile budget, the call is also given at most the time
+// left in the budget. If ctx carries a signing slot, the slot is held until
+// the call returns, even after a timeout.
+func callWithTimeout[T any](ctx context.Context, timeout time.Duration, fn func() (T, error)) (T, error) {
+	return callWithLateResult(ctx, timeout, fn, nil)
+}
+
+// callWithLateResult is callWithTimeout, except that the result of a call
+// which returns after callWithLateResult has stopped waiting for it is passed
+// to late, if set, rather than being discarded.
+func callWithLateResult[T any](ctx context.Context, timeout time.Duration, fn func() (T, error), late func(T, error)) (T, error) {
+	var zero T
+
+	timeoutErr := errCallTimeout{timeout: timeout}
+	if budget := reconcileBudgetFrom(ctx); budget != nil {
+		remaining := budget.remaining()
+		if remaining <= 0 {
+			return zero, budget.exhausted()
+		}
+
+		if timeout <= 0 || remaining < timeout {
+			timeout, timeoutErr = remaining, budget.exhausted()
+		}
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	type result struct {
+		val T
+		err error
+	}
+
+	// The channel is buffered so that the call can complete after a timeout
+	// without blocking forever. abandoned is set once the caller has stopped
+	// waiting, so that the call hands its result to late instead.
+	results := make(chan result, 1)
+	var lock sync.Mutex
+	abandoned := false
+
+	slot := signingSlotFrom(ctx)
+	slot.hold()
+	go func() {
+		defer slot.done()
+
+		val, err := fn()
+
+		lock.Lock()
+		isLate := abandoned
+		if !isLate {
+			results <- result{val: val, err: err}
+		}
+		lock.Unlock()
+
+		if isLate && late != nil {
+			late(val, err)
+		}
+	}()
+
+	select {
+	case res := <-results:
+		return res.val, res.err
+	case <-ctx.Done():
+		lock.Lock()
+		abandoned = true
+		lock.Unlock()
+
+		// The call may have returned just as it was abandoned.
+		select {
+		case res := <-results:
+			return res.val, res.err
+		default:
+		}
+
+		if ctx.Err() == context.DeadlineExceeded {
+			return zero, timeoutErr
+		}
+		return zero, ctx.Err()
+	}
+}
@@ -0,0 +1,370 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Venafi/vcert/v5/pkg/endpoint"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestCallWithTimeout(t *testing.T) {
+	unblock := make(chan struct{})
+	defer close(unblock)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	clock := fakeclock.NewFakeClock(time.Now())
+	exhausted := withReconcileBudget(context.Background(), clock, time.Minute)
+	clock.Step(time.Minute)
+
+	tests := map[string]struct {
+		ctx     context.Context
+		timeout time.Duration
+		fn      func() (string, error)
+		expVal  string
+		expErr  error
+	}{
+		"call returning in time returns its result": {
+			timeout: time.Minute,
+			fn:      func() (string, error) { return "ok", nil },
+			expVal:  "ok",
+		},
+		"call returning an error in time returns the error": {
+			timeout: time.Minute,
+			fn:      func() (string, error) { return "", errors.New("this is an error") },
+			expErr:  errors.New("this is an error"),
+		},
+		"call not returning in time returns a timeout error": {
+			timeout: 10 * time.Millisecond,
+			fn: func() (string, error) {
+				<-unblock
+				return "ok", nil
+			},
+			expErr: errCallTimeout{timeout: 10 * time.Millisecond},
+		},
+		"no timeout waits for the call": {
+			fn: func() (string, error) {
+				time.Sleep(20 * time.Millisecond)
+				return "ok", nil
+			},
+			expVal: "ok",
+		},
+		"cancelled call without a timeout returns the cancellation error": {
+			ctx: cancelled,
+			fn: func() (string, error) {
+				<-unblock
+				return "ok", nil
+			},
+			expErr: context.Canceled,
+		},
+		"cancelled call returns the cancellation error": {
+			ctx:     cancelled,
+			timeout: time.Minute,
+			fn: func() (string, error) {
+				<-unblock
+				return "ok", nil
+			},
+			expErr: context.Canceled,
+		},
+		"call not returning within the reconcile budget returns a budget timeout error": {
+			ctx:     withReconcileBudget(context.Background(), clock, 10*time.Millisecond),
+			timeout: time.Minute,
+			fn: func() (string, error) {
+				<-unblock
+				return "ok", nil
+			},
+			expErr: errCallTimeout{timeout: 10 * time.Millisecond, reconcileBudget: true},
+		},
+		"call not returning within a timeout shorter than the reconcile budget returns a timeout error": {
+			ctx:     withReconcileBudget(context.Background(), clock, time.Minute),
+			timeout: 10 * time.Millisecond,
+			fn: func() (string, error) {
+				<-unblock
+				return "ok", nil
+			},
+			expErr: errCallTimeout{timeout: 10 * time.Millisecond},
+		},
+		"call is not made once the reconcile budget is exhausted": {
+			ctx:     exhausted,
+			timeout: time.Minute,
+			fn:      func() (string, error) { return "ok", nil },
+			expErr:  errCallTimeout{timeout: time.Minute, reconcileBudget: true},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := test.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			val, err := callWithTimeout(ctx, test.timeout, test.fn)
+			assert.Equal(t, test.expErr, err)
+			assert.Equal(t, test.expVal, val)
+		})
+	}
+}
+
+func TestIsCallTimeout(t *testing.T) {
+	timeoutErr := errCallTimeout{timeout: time.Minute}
+
+	assert.True(t, isCallTimeout(timeoutErr))
+	assert.True(t, isCallTimeout(fmt.Errorf("failed to select zone: %w", timeoutErr)), "expected wrapped timeouts to be detected")
+	assert.False(t, isCallTimeout(errors.New("the Venafi platform did not respond within 1m0s")))
+	assert.False(t, isCallTimeout(nil))
+}
+
+func TestSignCancelled(t *testing.T) {
+	unblock := make(chan struct{})
+	defer close(unblock)
+
+	cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestAnnotations(map[string]string{
+		cmapi.VenafiPickupIDAnnotationKey: "test-pickup-id",
+	}))
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+		Zone: "tpp-zone",
+		TPP:  &cmapi.VenafiTPP{},
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	recorder := new(controllertest.FakeRecorder)
+	v := &Venafi{
+		reporter: crutil.NewReporter(fixedClock, recorder, 0),
+		clientBuilder: func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+			return &fake.Venafi{
+				RetrieveCertificateFn: func(string, []byte, []api.CustomField) ([]byte, error) {
+					// The CertificateRequest is deleted while the certificate
+					// is being retrieved.
+					cancel()
+					<-unblock
+					return nil, nil
+				},
+			}, nil
+		},
+		clock:                fixedClock,
+		limiter:              newSigningLimiter(0),
+		missingSecretRetries: newMissingSecretRetries(fixedClock),
+		retrieveFailures:     newRetrieveFailures(fixedClock, 0),
+	}
+
+	resp, err := v.Sign(ctx, cr, issuer)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, resp)
+	assert.Empty(t, recorder.Events, "expected the cancelled request not to be reported on")
+
+	_, waiting := v.retrieveFailures.wait(cr)
+	assert.False(t, waiting, "expected the cancellation not to be recorded as a failure")
+}
+
+func TestSignReconcileTimeout(t *testing.T) {
+	unblock := make(chan struct{})
+	defer close(unblock)
+
+	testPK, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	csrPEM := generateCSR(t, testPK)
+
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+		Zone: "tpp-zone",
+		TPP:  &cmapi.VenafiTPP{},
+	}))
+
+	newVenafi := func(recorder *controllertest.FakeRecorder, builder client.VenafiClientBuilder) *Venafi {
+		clock := fakeclock.NewFakeClock(time.Now())
+		return &Venafi{
+			reporter:             crutil.NewReporter(clock, recorder, 0),
+			clientBuilder:        builder,
+			clock:                clock,
+			limiter:              newSigningLimiter(0),
+			missingSecretRetries: newMissingSecretRetries(clock),
+			retrieveFailures:     newRetrieveFailures(clock, 0),
+			enrollments:          newPendingEnrollments(clock),
+			requestTimeout:       time.Minute,
+			reconcileTimeout:     20 * time.Millisecond,
+		}
+	}
+
+	t.Run("a client build exceeding the budget is retried", func(t *testing.T) {
+		recorder := new(controllertest.FakeRecorder)
+		v := newVenafi(recorder, func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+			<-unblock
+			return &fake.Venafi{}, nil
+		})
+
+		cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestCSR(csrPEM))
+		resp, err := v.Sign(context.Background(), cr, issuer)
+		assert.Equal(t, errCallTimeout{timeout: 20 * time.Millisecond, reconcileBudget: true}, err)
+		assert.Nil(t, resp)
+		assert.Equal(t, []string{
+			"Normal Timeout Timed out initialising venafi client for signing, the request will be retried: the request could not be signed within the reconcile timeout of 20ms",
+		}, recorder.Events)
+	})
+
+	t.Run("the time spent building the client is deducted from the budget of the request", func(t *testing.T) {
+		recorder := new(controllertest.FakeRecorder)
+		var v *Venafi
+		v = newVenafi(recorder, func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+			// Building the client uses up the whole budget.
+			v.clock.(*fakeclock.FakeClock).Step(20 * time.Millisecond)
+			return &fake.Venafi{
+				RequestCertificateFn: func([]byte, time.Duration, string, *api.Location, crypto.Hash, []api.CustomField) (string, error) {
+					t.Error("expected the certificate not to be requested once the budget is exhausted")
+					return "", nil
+				},
+			}, nil
+		})
+
+		cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestCSR(csrPEM))
+		resp, err := v.Sign(context.Background(), cr, issuer)
+		assert.Equal(t, errCallTimeout{timeout: 20 * time.Millisecond, reconcileBudget: true}, err)
+		assert.Nil(t, resp)
+		assert.Equal(t, []string{
+			"Normal Timeout Timed out requesting venafi certificate, the request will be retried: the request could not be signed within the reconcile timeout of 20ms",
+		}, recorder.Events)
+	})
+}
+
+func TestCallWithLateResult(t *testing.T) {
+	unblock := make(chan struct{})
+	lateResults := make(chan string, 1)
+
+	released := make(chan struct{})
+	ctx, slot := withSigningSlot(context.Background(), func() { close(released) })
+
+	val, err := callWithLateResult(ctx, 10*time.Millisecond, func() (string, error) {
+		<-unblock
+		return "late", nil
+	}, func(val string, err error) {
+		assert.NoError(t, err)
+		lateResults <- val
+	})
+	assert.Equal(t, errCallTimeout{timeout: 10 * time.Millisecond}, err)
+	assert.Empty(t, val)
+
+	// The slot is still held by the call running in the background once the
+	// caller is done with it.
+	slot.done()
+	select {
+	case <-released:
+		t.Fatal("expected the signing slot to be held until the call returns")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(unblock)
+	select {
+	case val := <-lateResults:
+		assert.Equal(t, "late", val)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the late result to be passed on")
+	}
+	select {
+	case <-released:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the signing slot to be released once the call returns")
+	}
+}
+
+func TestSignRequestReturningAfterTimeout(t *testing.T) {
+	testPK, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	csrPEM := generateCSR(t, testPK)
+
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+		Zone: "tpp-zone",
+		TPP:  &cmapi.VenafiTPP{},
+	}))
+	cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestCSR(csrPEM))
+
+	unblock := make(chan struct{})
+	returned := make(chan struct{})
+	var requestCalls, retrieveCalls int
+	clock := fakeclock.NewFakeClock(time.Now())
+	v := &Venafi{
+		reporter: crutil.NewReporter(clock, new(controllertest.FakeRecorder), 0),
+		clientBuilder: func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+			return &fake.Venafi{
+				RequestCertificateFn: func([]byte, time.Duration, string, *api.Location, crypto.Hash, []api.CustomField) (string, error) {
+					requestCalls++
+					// The certificate is requested after the call has timed
+					// out.
+					<-unblock
+					defer close(returned)
+					return "test-pickup-id", nil
+				},
+				RetrieveCertificateFn: func(pickupID string, _ []byte, _ []api.CustomField) ([]byte, error) {
+					retrieveCalls++
+					assert.Equal(t, "test-pickup-id", pickupID)
+					return nil, endpoint.ErrCertificatePending{CertificateID: pickupID}
+				},
+			}, nil
+		},
+		clock:                clock,
+		limiter:              newSigningLimiter(1),
+		missingSecretRetries: newMissingSecretRetries(clock),
+		retrieveFailures:     newRetrieveFailures(clock, 0),
+		enrollments:          newPendingEnrollments(clock),
+		requestTimeout:       10 * time.Millisecond,
+	}
+
+	resp, err := v.Sign(context.Background(), cr.DeepCopy(), issuer)
+	assert.Equal(t, errCallTimeout{timeout: 10 * time.Millisecond}, err)
+	assert.Nil(t, resp)
+
+	// The signing slot of the issuer is held by the call still running in
+	// the background.
+	acquireCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = v.limiter.acquire(acquireCtx, issuer)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(unblock)
+	<-returned
+	require.Eventually(t, func() bool {
+		_, ok := v.enrollments.lookup(enrollmentHash(cr, issuer))
+		return ok
+	}, 5*time.Second, time.Millisecond, "expected the late pickup ID to be recorded")
+
+	// The next sync retrieves the certificate requested by the call which
+	// timed out rather than requesting a duplicate.
+	resp, err = v.Sign(context.Background(), cr.DeepCopy(), issuer)
+	assert.NoError(t, err)
+	assert.Nil(t, resp)
+	assert.Equal(t, 1, requestCalls)
+	assert.Equal(t, 1, retrieveCalls)
+}
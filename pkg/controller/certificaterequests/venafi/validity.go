@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// validityHintDuration returns the duration to request for the
+// CertificateRequest based on the validity hint in the CSR extension with
+// the given OID. The hint is clamped between the minimum and the default
+// certificate duration. If the CertificateRequest also requests a duration
+// which conflicts with the hint, the duration of the CertificateRequest is
+// returned instead. Zero is returned if the CSR has no validity hint.
+func validityHintDuration(log logr.Logger, cr *cmapi.CertificateRequest, oid asn1.ObjectIdentifier) (time.Duration, error) {
+	hint, ok, err := csrValidityHint(cr.Spec.Request, oid)
+	if err != nil || !ok {
+		return 0, err
+	}
+
+	hint = min(max(hint, cmapi.MinimumCertificateDuration), apiutil.DefaultCertDuration(nil))
+
+	if cr.Spec.Duration != nil && cr.Spec.Duration.Duration != hint {
+		log.V(logf.InfoLevel).Info("the validity hint of the CSR conflicts with the duration of the request, using the duration of the request",
+			"hint", hint, "duration", cr.Spec.Duration.Duration)
+		return cr.Spec.Duration.Duration, nil
+	}
+
+	return hint, nil
+}
+
+// zoneDuration returns the validity with which the Venafi zone of the issuer
+// issues a certificate requested with the given duration, as the zone extends
+// durations shorter than its minimum and truncates durations longer than its
+// maximum. The bounds of the zone are read from the issuer, as they are not
+// exposed by the Venafi zone configuration. Zero is returned unchanged, since
+// the validity configured for the zone is then used.
+func zoneDuration(duration time.Duration, venafi *cmapi.VenafiIssuer) time.Duration {
+	switch {
+	case duration == 0:
+		return 0
+	case venafi.MinDuration != nil && duration < venafi.MinDuration.Duration:
+		return venafi.MinDuration.Duration
+	case venafi.MaxDuration != nil && duration > venafi.MaxDuration.Duration:
+		return venafi.MaxDuration.Duration
+	}
+	return duration
+}
+
+// csrValidityHint returns the validity encoded in the extension with the
+// given OID of the PEM encoded CSR. The extension value must be a DER encoded
+// INTEGER number of seconds. The boolean is false if the CSR does not have
+// the extension.
+func csrValidityHint(csrPEM []byte, oid asn1.ObjectIdentifier) (time.Duration, bool, error) {
+	csr, err := utilpki.DecodeX509CertificateRequestBytes(csrPEM)
+	if err != nil {
+		return 0, false, err
+	}
+
+	for _, ext := range csr.Extensions {
+		if !ext.Id.Equal(oid) {
+			continue
+		}
+
+		var seconds int64
+		rest, err := asn1.Unmarshal(ext.Value, &seconds)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to decode the validity hint extension %s: %w", oid, err)
+		}
+		if len(rest) > 0 {
+			return 0, false, fmt.Errorf("failed to decode the validity hint extension %s: trailing data", oid)
+		}
+		if seconds <= 0 {
+			return 0, false, errors.New("the validity hint must be a positive number of seconds")
+		}
+		if seconds > math.MaxInt64/int64(time.Second) {
+			return 0, false, errors.New("the validity hint is too large")
+		}
+
+		return time.Duration(seconds) * time.Second, true, nil
+	}
+
+	return 0, false, nil
+}
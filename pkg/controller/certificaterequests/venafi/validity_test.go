@@ -0,0 +1,303 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+var testValidityHintOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1}
+
+// csrWithExtensions returns a PEM encoded CSR requesting the given extensions.
+func csrWithExtensions(t *testing.T, exts ...pkix.Extension) []byte {
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:         pkix.Name{CommonName: "example.com"},
+		ExtraExtensions: exts,
+	}, pk)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})
+}
+
+// validityHintExtension returns a validity hint extension with the given
+// value.
+func validityHintExtension(t *testing.T, value any) pkix.Extension {
+	b, err := asn1.Marshal(value)
+	require.NoError(t, err)
+
+	return pkix.Extension{Id: testValidityHintOID, Value: b}
+}
+
+func TestValidityHintDuration(t *testing.T) {
+	tests := map[string]struct {
+		csr         []byte
+		duration    *metav1.Duration
+		expected    time.Duration
+		expectedErr bool
+	}{
+		"no validity hint": {
+			csr:      csrWithExtensions(t),
+			expected: 0,
+		},
+		"validity hint is used": {
+			csr:      csrWithExtensions(t, validityHintExtension(t, int64(time.Hour*24*30/time.Second))),
+			expected: time.Hour * 24 * 30,
+		},
+		"validity hint below the minimum duration is clamped": {
+			csr:      csrWithExtensions(t, validityHintExtension(t, int64(60))),
+			expected: cmapi.MinimumCertificateDuration,
+		},
+		"validity hint above the default duration is clamped": {
+			csr:      csrWithExtensions(t, validityHintExtension(t, int64(time.Hour*24*365/time.Second))),
+			expected: cmapi.DefaultCertificateDuration,
+		},
+		"matching duration of the request": {
+			csr:      csrWithExtensions(t, validityHintExtension(t, int64(time.Hour*24*30/time.Second))),
+			duration: &metav1.Duration{Duration: time.Hour * 24 * 30},
+			expected: time.Hour * 24 * 30,
+		},
+		"conflicting duration of the request takes precedence": {
+			csr:      csrWithExtensions(t, validityHintExtension(t, int64(time.Hour*24*30/time.Second))),
+			duration: &metav1.Duration{Duration: time.Hour * 24 * 10},
+			expected: time.Hour * 24 * 10,
+		},
+		"validity hint which is not an integer": {
+			csr:         csrWithExtensions(t, validityHintExtension(t, "30d")),
+			expectedErr: true,
+		},
+		"validity hint which is not positive": {
+			csr:         csrWithExtensions(t, validityHintExtension(t, int64(0))),
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := gen.CertificateRequest("test-cr",
+				gen.SetCertificateRequestCSR(test.csr),
+				gen.SetCertificateRequestDuration(test.duration),
+			)
+
+			duration, err := validityHintDuration(logr.Discard(), cr, testValidityHintOID)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, duration)
+		})
+	}
+}
+
+func TestSignRequestedDuration(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	defaultDuration := &metav1.Duration{Duration: 90 * 24 * time.Hour}
+
+	tests := map[string]struct {
+		csr             []byte
+		notAfter        *metav1.Time
+		defaultDuration *metav1.Duration
+
+		expected time.Duration
+	}{
+		"the validity of the zone is used if nothing is requested and the issuer has no default duration": {
+			csr:      csrWithExtensions(t),
+			expected: 0,
+		},
+		"the default duration of the issuer is used if nothing is requested": {
+			csr:             csrWithExtensions(t),
+			defaultDuration: defaultDuration,
+			expected:        defaultDuration.Duration,
+		},
+		"the validity hint of the CSR takes precedence over the default duration of the issuer": {
+			csr:             csrWithExtensions(t, validityHintExtension(t, int64(2*24*60*60))),
+			defaultDuration: defaultDuration,
+			expected:        48 * time.Hour,
+		},
+		"the requested notAfter time takes precedence over the default duration of the issuer": {
+			csr:             csrWithExtensions(t),
+			notAfter:        &metav1.Time{Time: clock.Now().Add(24 * time.Hour)},
+			defaultDuration: defaultDuration,
+			expected:        24 * time.Hour,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestCSR(test.csr))
+			cr.Spec.NotAfter = test.notAfter
+			issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+				Zone:            "tpp-zone",
+				TPP:             &cmapi.VenafiTPP{},
+				DefaultDuration: test.defaultDuration,
+			}))
+
+			var requested *time.Duration
+			v := &Venafi{
+				reporter: crutil.NewReporter(clock, new(controllertest.FakeRecorder), 0),
+				clientBuilder: func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+					return &fake.Venafi{
+						RequestCertificateFn: func(_ []byte, duration time.Duration, _ string, _ *api.Location, _ crypto.Hash, _ []api.CustomField) (string, error) {
+							requested = &duration
+							return "test-pickup-id", nil
+						},
+					}, nil
+				},
+				clock:                clock,
+				limiter:              newSigningLimiter(0),
+				missingSecretRetries: newMissingSecretRetries(clock),
+				retrieveFailures:     newRetrieveFailures(clock, 0),
+				validityHintOID:      testValidityHintOID,
+			}
+
+			_, err := v.Sign(context.Background(), cr, issuer)
+			require.NoError(t, err)
+			require.NotNil(t, requested, "expected a certificate to be requested")
+			assert.Equal(t, test.expected, *requested)
+		})
+	}
+}
+
+func TestZoneDuration(t *testing.T) {
+	venafi := &cmapi.VenafiIssuer{
+		MinDuration: &metav1.Duration{Duration: 24 * time.Hour},
+		MaxDuration: &metav1.Duration{Duration: 90 * 24 * time.Hour},
+	}
+
+	tests := map[string]struct {
+		duration time.Duration
+		venafi   *cmapi.VenafiIssuer
+		expected time.Duration
+	}{
+		"the validity of the zone is used if no duration is requested": {
+			venafi: venafi,
+		},
+		"a duration within the bounds of the zone is not changed": {
+			duration: 30 * 24 * time.Hour,
+			venafi:   venafi,
+			expected: 30 * 24 * time.Hour,
+		},
+		"a duration shorter than the minimum is extended": {
+			duration: time.Hour,
+			venafi:   venafi,
+			expected: 24 * time.Hour,
+		},
+		"a duration longer than the maximum is truncated": {
+			duration: 365 * 24 * time.Hour,
+			venafi:   venafi,
+			expected: 90 * 24 * time.Hour,
+		},
+		"a duration is not changed if the issuer has no bounds": {
+			duration: time.Hour,
+			venafi:   &cmapi.VenafiIssuer{},
+			expected: time.Hour,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, zoneDuration(test.duration, test.venafi))
+		})
+	}
+}
+
+func TestSignDurationAdjusted(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+
+	tests := map[string]struct {
+		notAfter       *metav1.Time
+		expectedEvents []string
+	}{
+		"an event is sent if the zone will extend the requested duration": {
+			notAfter: &metav1.Time{Time: clock.Now().Add(2 * time.Hour)},
+			expectedEvents: []string{
+				"Normal DurationAdjusted The requested duration 2h0m0s is not allowed by the Venafi zone, the certificate will be issued with a duration of 24h0m0s",
+				`Normal IssuancePending Venafi certificate is requested with pickup ID "test-pickup-id" for CN "example.com"`,
+			},
+		},
+		"no event is sent if the zone allows the requested duration": {
+			notAfter: &metav1.Time{Time: clock.Now().Add(48 * time.Hour)},
+			expectedEvents: []string{
+				`Normal IssuancePending Venafi certificate is requested with pickup ID "test-pickup-id" for CN "example.com"`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestCSR(csrWithExtensions(t)))
+			cr.Spec.NotAfter = test.notAfter
+			issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+				Zone:        "tpp-zone",
+				TPP:         &cmapi.VenafiTPP{},
+				MinDuration: &metav1.Duration{Duration: 24 * time.Hour},
+			}))
+
+			var requested time.Duration
+			recorder := new(controllertest.FakeRecorder)
+			v := &Venafi{
+				reporter: crutil.NewReporter(clock, recorder, 0),
+				clientBuilder: func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+					return &fake.Venafi{
+						RequestCertificateFn: func(_ []byte, duration time.Duration, _ string, _ *api.Location, _ crypto.Hash, _ []api.CustomField) (string, error) {
+							requested = duration
+							return "test-pickup-id", nil
+						},
+					}, nil
+				},
+				clock:                clock,
+				limiter:              newSigningLimiter(0),
+				missingSecretRetries: newMissingSecretRetries(clock),
+				retrieveFailures:     newRetrieveFailures(clock, 0),
+			}
+
+			_, err := v.Sign(context.Background(), cr, issuer)
+			require.NoError(t, err)
+			assert.Equal(t, test.notAfter.Sub(clock.Now()), requested, "expected the requested duration not to be changed")
+			assert.Equal(t, test.expectedEvents, recorder.Events)
+		})
+	}
+}
@@ -18,17 +18,30 @@ package venafi
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Venafi/vcert/v5/pkg/endpoint"
+	"github.com/Venafi/vcert/v5/pkg/verror"
+	"github.com/go-logr/logr"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	clientset "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests"
 	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
@@ -45,140 +58,1387 @@ const (
 )
 
 type Venafi struct {
-	issuerOptions controllerpkg.IssuerOptions
-	secretsLister internalinformers.SecretLister
-	reporter      *crutil.Reporter
-	cmClient      clientset.Interface
+	issuerOptions       controllerpkg.IssuerOptions
+	credentialsResolver venaficlient.CredentialsResolver
+	secretsLister       internalinformers.SecretLister
+	reporter            *crutil.Reporter
+	cmClient            clientset.Interface
 
 	clientBuilder venaficlient.VenafiClientBuilder
 
 	metrics *metrics.Metrics
 
+	// auditSink receives a record of every certificate issued, if set.
+	auditSink controllerpkg.IssuanceAuditSink
+
+	// requestMutators are invoked on each request before it is enrolled.
+	requestMutators []controllerpkg.RequestMutator
+
 	// userAgent is the string used as the UserAgent when making HTTP calls.
 	userAgent string
+
+	clock clock.Clock
+
+	// limiter limits the number of concurrent signings per Venafi issuer.
+	limiter *signingLimiter
+
+	// missingSecretRetries tracks the requests whose issuer references a
+	// Secret which has not been found yet, or which does not contain valid
+	// credentials yet.
+	missingSecretRetries *missingSecretRetries
+
+	// retrieveFailures tracks the requests whose certificate could not be
+	// retrieved from the Venafi platform because of unexpected errors.
+	retrieveFailures *retrieveFailures
+
+	// enrollments tracks the certificates requested from the Venafi platform
+	// which have not been retrieved yet.
+	enrollments *pendingEnrollments
+
+	// caZones tracks the zones known not to permit issuing CA certificates.
+	caZones *caZonePolicies
+
+	// breakers fail signings fast for the issuers whose Venafi platform has
+	// repeatedly failed to respond.
+	breakers *circuitBreakers
+
+	// errorLogs limits how often identical signing errors are logged for
+	// each request.
+	errorLogs *errorLogLimiter
+
+	// validityHintOID is the OID of the CSR extension from which the
+	// requested validity is read, if set.
+	validityHintOID asn1.ObjectIdentifier
+
+	// requestTimeout is the maximum time to wait for each call to the Venafi
+	// platform. A value of zero or less means no timeout.
+	requestTimeout time.Duration
+
+	// reconcileTimeout is the maximum time a single sync may spend building
+	// the Venafi client and calling the Venafi platform, shared by all the
+	// steps of the sync. A value of zero or less means no budget.
+	reconcileTimeout time.Duration
+
+	// warmUp sets up the Venafi issuers when the controller starts, if
+	// enabled.
+	warmUp *issuerWarmUp
+
+	// callbacks serves the endpoint called by the Venafi platform once a
+	// certificate has been issued, if enabled.
+	callbacks *callbackServer
+
+	// fieldManager is the field manager name used when updating the
+	// CertificateRequests signed by this issuer.
+	fieldManager string
+
+	// queue is used to schedule resyncs of CertificateRequests which are
+	// pending issuance on the Venafi platform.
+	queue workqueue.TypedRateLimitingInterface[types.NamespacedName]
 }
 
+var _ certificaterequests.QueueingIssuer = &Venafi{}
+var _ certificaterequests.FieldManagerIssuer = &Venafi{}
+var _ certificaterequests.WarmingUpIssuer = &Venafi{}
+var _ certificaterequests.RunningIssuer = &Venafi{}
+var _ certificaterequests.PrioritizingIssuer = &Venafi{}
+var _ certificaterequests.IssuerCachingIssuer = &Venafi{}
+
 func init() {
 	// create certificate request controller for venafi issuer
 	controllerpkg.Register(CRControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
 		return controllerpkg.NewBuilder(ctx, CRControllerName).
-			For(certificaterequests.New(apiutil.IssuerVenafi, NewVenafi)).
+			For(certificaterequests.New(
+				apiutil.IssuerVenafi,
+				NewVenafi,
+
+				// Requests whose credentials are missing or rejected are
+				// retried once the Secret containing them changes.
+				func(ctx *controllerpkg.Context, log logr.Logger, queue workqueue.TypedRateLimitingInterface[types.NamespacedName]) ([]cache.InformerSynced, error) {
+					secretInformer := ctx.KubeSharedInformerFactory.Secrets().Informer()
+					issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
+					mustSync := []cache.InformerSynced{secretInformer.HasSynced, issuerInformer.Informer().HasSynced}
+
+					var clusterIssuerLister cmlisters.ClusterIssuerLister
+					if ctx.Namespace == "" {
+						clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+						clusterIssuerLister = clusterIssuerInformer.Lister()
+						mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+					}
+
+					if _, err := secretInformer.AddEventHandler(&controllerpkg.BlockingEventHandler{
+						WorkFunc: handleSecretReferenceWorkFunc(log, ctx.IssuerOptions,
+							ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests().Lister(),
+							issuerInformer.Lister(), clusterIssuerLister, queue),
+					}); err != nil {
+						return nil, fmt.Errorf("error setting up event handler: %v", err)
+					}
+					return mustSync, nil
+				},
+			)).
 			Complete()
 	})
 }
 
 func NewVenafi(ctx *controllerpkg.Context) certificaterequests.Issuer {
+	zoneCache := venaficlient.NewZoneConfigurationCache(ctx.Clock, ctx.IssuerOptions.VenafiZoneCacheTTL, ctx.Metrics)
+	clientBuilder := venaficlient.NewBuilder(
+		venaficlient.WithZoneConfigurationCache(zoneCache),
+		venaficlient.WithResponseLog(ctx.VenafiResponseLog),
+		venaficlient.WithTransport(ctx.VenafiTransport),
+	)
+
+	// The OID is validated when the controller configuration is loaded.
+	var validityHintOID asn1.ObjectIdentifier
+	if oid := ctx.IssuerOptions.VenafiValidityHintExtensionOID; oid != "" {
+		validityHintOID, _ = utilpki.ParseObjectIdentifier(oid)
+	}
+
 	return &Venafi{
-		issuerOptions: ctx.IssuerOptions,
-		secretsLister: ctx.KubeSharedInformerFactory.Secrets().Lister(),
-		reporter:      crutil.NewReporter(ctx.Clock, ctx.Recorder),
-		clientBuilder: venaficlient.New,
-		metrics:       ctx.Metrics,
-		cmClient:      ctx.CMClient,
-		userAgent:     ctx.RESTConfig.UserAgent,
+		issuerOptions:       ctx.IssuerOptions,
+		credentialsResolver: venaficlient.NewSecretCredentialsResolver(ctx.KubeSharedInformerFactory.Secrets().Lister()),
+		secretsLister:       ctx.KubeSharedInformerFactory.Secrets().Lister(),
+		reporter:            crutil.NewReporter(ctx.Clock, ctx.Recorder, ctx.IssuerOptions.CertificateRequestEventCooldown),
+		clientBuilder:       clientBuilder,
+		metrics:             ctx.Metrics,
+		auditSink:           ctx.IssuanceAuditSink,
+		requestMutators:     ctx.RequestMutators,
+		cmClient:            ctx.CMClient,
+		userAgent:           ctx.RESTConfig.UserAgent,
+		clock:               ctx.Clock,
+		limiter:             newSigningLimiter(ctx.IssuerOptions.VenafiMaxConcurrentSignings),
+
+		missingSecretRetries: newMissingSecretRetries(ctx.Clock),
+		retrieveFailures:     newRetrieveFailures(ctx.Clock, ctx.IssuerOptions.VenafiRetrieveFailureTimeout),
+		enrollments:          newPendingEnrollments(ctx.Clock),
+		caZones:              newCAZonePolicies(ctx.Clock, ctx.IssuerOptions.VenafiZoneCacheTTL),
+		breakers:             newCircuitBreakers(ctx.Clock, ctx.Metrics, ctx.IssuerOptions.VenafiCircuitBreakerThreshold, ctx.IssuerOptions.VenafiCircuitBreakerOpenDuration),
+		errorLogs:            newErrorLogLimiter(ctx.Clock, ctx.IssuerOptions.VenafiErrorLogInterval),
+		validityHintOID:      validityHintOID,
+
+		requestTimeout:   ctx.IssuerOptions.VenafiRequestTimeout,
+		reconcileTimeout: ctx.IssuerOptions.VenafiReconcileTimeout,
+		fieldManager:     ctx.IssuerOptions.VenafiFieldManager,
+		warmUp:           newIssuerWarmUp(ctx),
+		callbacks:        newCallbackServer(ctx),
+	}
+}
+
+// buildClient builds the Venafi client of the issuer. Building the client
+// authenticates to the Venafi platform, so it is bounded by the reconcile
+// budget of ctx, if any.
+func (v *Venafi) buildClient(ctx context.Context, log logr.Logger, issuerObj cmapi.GenericIssuer) (venaficlient.Interface, error) {
+	return callWithTimeout(ctx, 0, func() (venaficlient.Interface, error) {
+		return v.clientBuilder(v.issuerOptions.ResourceNamespace(issuerObj), v.credentialsResolver, issuerObj, v.metrics, log, v.userAgent)
+	})
+}
+
+// SetQueue sets the workqueue used to schedule retries of pending
+// certificates.
+func (v *Venafi) SetQueue(queue workqueue.TypedRateLimitingInterface[types.NamespacedName]) {
+	v.queue = queue
+}
+
+// Priority returns the priority of the CertificateRequest in the workqueue,
+// which is set with the priority annotation.
+func (v *Venafi) Priority(cr *cmapi.CertificateRequest) certificaterequests.Priority {
+	switch cr.Annotations[cmapi.VenafiPriorityAnnotationKey] {
+	case cmapi.VenafiPriorityHigh:
+		return certificaterequests.PriorityHigh
+	case cmapi.VenafiPriorityLow:
+		return certificaterequests.PriorityLow
+	default:
+		return certificaterequests.PriorityNormal
+	}
+}
+
+// Runnables returns the Venafi callback server, if enabled. It is started
+// before the issuers are warmed up, so that the certificates issued during
+// the warm up are synced once the workers start.
+func (v *Venafi) Runnables() []func(ctx context.Context) {
+	if v.callbacks == nil {
+		return nil
+	}
+	return []func(ctx context.Context){
+		func(ctx context.Context) { v.callbacks.start(ctx, v.queue) },
+	}
+}
+
+// CacheIssuers returns true if the controller should cache the issuers of
+// the CertificateRequests it syncs.
+func (v *Venafi) CacheIssuers() bool {
+	return v.issuerOptions.EnableVenafiIssuerCache
+}
+
+// FieldManager returns the field manager name used when updating the
+// CertificateRequests signed by this issuer.
+func (v *Venafi) FieldManager() string {
+	return v.fieldManager
+}
+
+// IssuerOptions returns a copy of the issuer options the issuer was
+// constructed with, such as the cluster resource namespace from which the
+// resources of ClusterIssuers are read.
+func (v *Venafi) IssuerOptions() controllerpkg.IssuerOptions {
+	return v.issuerOptions
+}
+
+// requeueAfter schedules the CertificateRequest to be synced again after the
+// given delay.
+func (v *Venafi) requeueAfter(cr *cmapi.CertificateRequest, delay time.Duration) {
+	if v.queue == nil {
+		return
 	}
+	v.queue.AddAfter(types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, delay)
 }
 
 func (v *Venafi) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) (*issuerpkg.IssueResponse, error) {
+	result, err := v.signWithResult(ctx, cr, issuerObj)
+	return result.response, err
+}
+
+// signWithResult signs the CertificateRequest like Sign, and also returns the
+// outcome of the call.
+func (v *Venafi) signWithResult(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) (signResult, error) {
+	reporter := newSignReporter(v.reporter)
+	response, err := v.sign(ctx, reporter, cr, issuerObj)
+	return reporter.result(cr, response), err
+}
+
+func (v *Venafi) sign(ctx context.Context, reporter *signReporter, cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) (*issuerpkg.IssueResponse, error) {
 	log := logf.FromContext(ctx, "sign")
 	log = logf.WithRelatedResource(log, issuerObj)
 
-	client, err := v.clientBuilder(v.issuerOptions.ResourceNamespace(issuerObj), v.secretsLister, issuerObj, v.metrics, log, v.userAgent)
+	// Debug logging can be enabled for a single issuer to diagnose its
+	// integration with the Venafi platform, without raising the global log
+	// level. The logger is also passed to the client builder, so that the
+	// calls to the Venafi platform are logged too.
+	if issuerObj.GetAnnotations()[cmapi.VenafiDebugLoggingAnnotationKey] == "true" {
+		log = logf.WithVerbosity(log, logf.TraceLevel)
+	}
+
+	// Requests denied by an approval controller are not passed to Sign by the
+	// shared controller. This is checked again before anything is enrolled,
+	// so that a Venafi enrollment is never made for a denied request.
+	if apiutil.CertificateRequestIsDenied(cr) {
+		log.V(logf.DebugLevel).Info("not signing denied certificate request")
+		reporter.Denied(cr)
+		return nil, nil
+	}
+
+	// Requests which are still not issued once the issuance deadline of the
+	// issuer has elapsed are failed, so that a request whose approval is
+	// never given in the Venafi platform is not retried forever.
+	if deadline, ok := issuanceDeadline(cr, issuerObj); ok && !v.clock.Now().Before(deadline) {
+		v.countSignError(cr, metrics.VenafiSignErrorTimeout)
+		v.missingSecretRetries.forget(cr)
+		v.retrieveFailures.forget(cr)
+		v.enrollments.forget(enrollmentHash(cr, issuerObj))
+
+		err := errIssuanceDeadlineExceeded{deadline: issuerObj.GetSpec().Venafi.IssuanceDeadline.Duration}
+		message := "Venafi certificate was not issued before the issuance deadline of the issuer"
+
+		reporter.Failed(cr, err, crutil.ReasonIssuanceDeadlineExceeded, message)
+		v.logSignError(log, reporter, cr, err, message)
+
+		return nil, nil
+	}
+
+	// Requests for domains which the issuer does not allow are rejected
+	// before anything is submitted, so that namespaces sharing an issuer
+	// cannot request certificates for the domains of others.
+	disallowed, err := disallowedDomains(cr.Spec.Request, issuerObj.GetSpec().Venafi.AllowedDomains)
+	if err != nil {
+		message := "Failed to decode CSR in spec.request"
+
+		reporter.Failed(cr, err, crutil.ReasonRequestParsingError, message)
+		v.logSignError(log, reporter, cr, err, message)
+
+		return nil, nil
+	}
+	if len(disallowed) > 0 {
+		err := fmt.Errorf("%s not matched by the allowed domains of the issuer", summarizeNames(disallowed, maxSummarizedSANs))
+		message := "Requested domains are not allowed"
+
+		reporter.Failed(cr, err, crutil.ReasonDomainNotAllowed, message)
+		v.logSignError(log, reporter, cr, err, message)
+
+		return nil, nil
+	}
+
+	if err := crutil.VerifyKeyPolicy(cr, issuerObj.GetSpec()); err != nil {
+		message := "The key of the request is not allowed by the key policy of the issuer"
+
+		reporter.Failed(cr, err, crutil.ReasonWeakKey, message)
+		v.logSignError(log, reporter, cr, err, message)
+
+		return nil, nil
+	}
+
+	// The request mutators are only invoked before the request is enrolled,
+	// so that the annotations they set are taken into account below.
+	if cr.GetAnnotations()[cmapi.VenafiPickupIDAnnotationKey] == "" {
+		if err := controllerpkg.MutateRequest(ctx, v.requestMutators, cr, issuerObj); err != nil {
+			var aborted *controllerpkg.RequestAbortedError
+			if errors.As(err, &aborted) {
+				reason := crutil.Reason(aborted.Reason)
+				if reason == "" {
+					reason = crutil.ReasonRequestAborted
+				}
+				message := "The request was aborted before enrollment"
+
+				reporter.Failed(cr, err, reason, message)
+				v.logSignError(log, reporter, cr, err, message)
+
+				return nil, nil
+			}
+
+			message := "Failed to mutate the request before enrollment"
+
+			reporter.Pending(cr, err, crutil.ReasonRequestMutationError, message)
+			v.logSignError(log, reporter, cr, err, message)
+
+			return nil, err
+		}
+	}
+
+	// Signings are failed fast while the Venafi platform of the issuer is
+	// unavailable, rather than each request adding load to it, until a probe
+	// signing succeeds.
+	if delay, err := v.breakers.allow(cr, issuerObj); err != nil {
+		message := fmt.Sprintf("The Venafi platform is unavailable after repeated failures, the request will be retried in %s", delay)
+
+		reporter.Pending(cr, err, crutil.ReasonBackendUnavailable, message)
+		v.logSignError(log, reporter, cr, err, message)
+
+		v.requeueAfter(cr, delay)
+		return nil, nil
+	}
+
+	// The reconcile budget is shared by the wait for a signing slot, the
+	// building of the client and every call to the Venafi platform below.
+	ctx = withReconcileBudget(ctx, v.clock, v.reconcileTimeout)
+
+	// Requests still waiting for a signing slot when the controller shuts
+	// down are not enrolled, only the signings in flight are drained.
+	start := v.clock.Now()
+	acquireCtx, cancel := withBudgetDeadline(ctx)
+	acquireCtx, stop := controllerpkg.CancelOnShutdown(acquireCtx)
+	release, err := v.limiter.acquire(acquireCtx, issuerObj)
+	stop()
+	cancel()
+	if err != nil {
+		if controllerpkg.ShuttingDown(ctx) {
+			return nil, err
+		}
+		if budget := reconcileBudgetFrom(ctx); budget != nil && ctx.Err() == nil {
+			err = budget.exhausted()
+			message := "Timed out waiting for a concurrent signing slot, the request will be retried"
+
+			reporter.Pending(cr, err, crutil.ReasonTimeout, message)
+			v.logSignError(log, reporter, cr, err, message)
+		}
+		return nil, err
+	}
+	// The slot is held until the calls made below to the Venafi platform
+	// have returned, including those which time out.
+	ctx, slot := withSigningSlot(ctx, release)
+	defer slot.done()
+
+	wait := v.clock.Since(start)
+	if wait > 0 {
+		log.V(logf.DebugLevel).Info("waited for a concurrent signing slot", "wait", wait)
+	}
+
+	zoneOverride, zoneOverridden := cr.GetAnnotations()[cmapi.VenafiZoneOverrideAnnotationKey]
+	if zoneOverridden {
+		if strings.TrimSpace(zoneOverride) == "" {
+			err := errors.New("zone must not be empty")
+			message := fmt.Sprintf("Invalid %q annotation", cmapi.VenafiZoneOverrideAnnotationKey)
+
+			reporter.Failed(cr, err, crutil.ReasonInvalidZone, message)
+			v.logSignError(log, reporter, cr, err, message)
+
+			return nil, nil
+		}
+
+		// Requests may only select the zones which the issuer allows, so that
+		// they cannot bypass the policy of the zones chosen for the issuer.
+		if !slices.Contains(issuerObj.GetSpec().Venafi.AllowedZoneOverrides, zoneOverride) {
+			err := fmt.Errorf("zone %q is not in the allowedZoneOverrides of the issuer", zoneOverride)
+			message := fmt.Sprintf("Invalid %q annotation", cmapi.VenafiZoneOverrideAnnotationKey)
+
+			reporter.Failed(cr, err, crutil.ReasonInvalidZone, message)
+			v.logSignError(log, reporter, cr, err, message)
+
+			return nil, nil
+		}
+
+		// Build the client from a copy of the issuer so that the zone is only
+		// overridden for this request.
+		issuerObj = withZone(issuerObj, zoneOverride)
+		log = log.WithValues("zone", zoneOverride)
+	}
+
+	client, err := v.buildClient(ctx, log, issuerObj)
+	if err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if isCallTimeout(err) {
+		message := "Timed out initialising venafi client for signing, the request will be retried"
+
+		reporter.Pending(cr, err, crutil.ReasonTimeout, message)
+		v.logSignError(log, reporter, cr, err, message)
+
+		return nil, err
+	}
+
 	if k8sErrors.IsNotFound(err) {
-		message := "Required secret resource not found"
+		// The Secret may have just been created and not yet been synced to
+		// the informer cache, so retry a few times before waiting for the
+		// Secret to be created.
+		delay, retry := v.missingSecretRetries.record(cr)
+		if !retry {
+			message := fmt.Sprintf("Required secret resource not found after %d retries, the request will be retried once it is created", maxMissingSecretRetries)
+
+			reporter.Pending(cr, err, crutil.ReasonSecretMissing, message)
+			v.logSignError(log, reporter, cr, err, message)
+
+			return nil, nil
+		}
+
+		message := fmt.Sprintf("Required secret resource not found, the request will be retried in %s", delay)
+
+		reporter.Pending(cr, err, crutil.ReasonSecretMissing, message)
+		v.logSignError(log, reporter, cr, err, message)
+
+		v.requeueAfter(cr, delay)
+		return nil, nil
+	}
+
+	if venaficlient.IsInvalidCredentialsError(err) {
+		// The Secret may be in the middle of being updated with new
+		// credentials, so retry a few times before waiting for the Secret to
+		// be updated.
+		delay, retry := v.missingSecretRetries.record(cr)
+		if !retry {
+			message := fmt.Sprintf("Required secret resource does not contain valid Venafi credentials after %d retries, the request will be retried once it is updated", maxMissingSecretRetries)
 
-		v.reporter.Pending(cr, err, "SecretMissing", message)
-		log.Error(err, message)
+			reporter.Pending(cr, err, crutil.ReasonInvalidCredentials, message)
+			v.logSignError(log, reporter, cr, err, message)
+
+			return nil, nil
+		}
+
+		message := fmt.Sprintf("Required secret resource does not contain valid Venafi credentials, the request will be retried in %s", delay)
+
+		reporter.Pending(cr, err, crutil.ReasonInvalidCredentials, message)
+		v.logSignError(log, reporter, cr, err, message)
+
+		v.requeueAfter(cr, delay)
+		return nil, nil
+	}
+	v.missingSecretRetries.forget(cr)
 
+	if venaficlient.IsAuthenticationError(err) {
+		v.reportAuthenticationError(reporter, log, cr, err)
 		return nil, nil
 	}
 
+	// Issuers which fail closed keep the request pending until the client,
+	// and so the zone policy, is available.
+	if err != nil && issuerObj.GetSpec().Venafi.FailClosed {
+		return nil, v.reportPolicyUnavailable(reporter, log, cr, err)
+	}
+
 	if err != nil {
 		message := "Failed to initialise venafi client for signing"
 
-		v.reporter.Pending(cr, err, "VenafiInitError", message)
-		log.Error(err, message)
+		reporter.Pending(cr, err, crutil.ReasonVenafiInitError, message)
+		v.logSignError(log, reporter, cr, err, message)
 
 		return nil, err
 	}
 
-	var customFields []api.CustomField
-	if annotation, exists := cr.GetAnnotations()[cmapi.VenafiCustomFieldsAnnotationKey]; exists && annotation != "" {
-		err := json.Unmarshal([]byte(annotation), &customFields)
+	// The issuer may have fallen back to alternative credentials if its
+	// credentials were rejected, so record the credentials which were used.
+	if credentials := client.CredentialsName(); credentials != "" {
+		log.V(logf.DebugLevel).Info("authenticated to the Venafi platform", "credentials", credentials)
+		metav1.SetMetaDataAnnotation(&cr.ObjectMeta, cmapi.VenafiCredentialsAnnotationKey, credentials)
+	}
+
+	// The annotations of the request override the default annotations of
+	// the issuer, except for custom fields which are merged by name.
+	annotations := requestAnnotations(cr, issuerObj)
+
+	customFields, err := requestCustomFields(cr, issuerObj)
+	if err != nil {
+		message := fmt.Sprintf("Failed to parse %q annotation", cmapi.VenafiCustomFieldsAnnotationKey)
+
+		reporter.Failed(cr, err, crutil.ReasonCustomFieldsError, message)
+		v.logSignError(log, reporter, cr, err, message)
+
+		return nil, nil
+	}
+
+	customFields, err = withOriginCustomField(customFields, cr, issuerObj)
+	if err != nil {
+		message := "Failed to record the origin of the request"
+
+		reporter.Failed(cr, err, crutil.ReasonInvalidOrigin, message)
+		v.logSignError(log, reporter, cr, err, message)
+
+		return nil, nil
+	}
+
+	// The friendly name defaults to the name derived from the CSR, which is
+	// its common name if set.
+	friendlyName, exists := annotations[cmapi.VenafiFriendlyNameAnnotationKey]
+	if exists {
+		if err := venaficlient.ValidateFriendlyName(friendlyName); err != nil {
+			message := fmt.Sprintf("Invalid %q annotation", cmapi.VenafiFriendlyNameAnnotationKey)
+
+			reporter.Failed(cr, err, crutil.ReasonInvalidFriendlyName, message)
+			v.logSignError(log, reporter, cr, err, message)
+
+			return nil, nil
+		}
+	}
+
+	// The instance and workload are recorded by Venafi Cloud for usage
+	// metering. TPP would create device objects for them instead, so they are
+	// rejected rather than silently ignored for TPP issuers, which take the
+	// path of the device object from its own annotation.
+	location, err := locationFromAnnotations(annotations)
+	if err == nil && location != nil && issuerObj.GetSpec().Venafi.TPP != nil {
+		err = errors.New("only supported by Venafi Cloud issuers")
+	}
+	if err != nil {
+		message := fmt.Sprintf("Invalid %q or %q annotation", cmapi.VenafiInstanceAnnotationKey, cmapi.VenafiWorkloadAnnotationKey)
+
+		reporter.Failed(cr, err, crutil.ReasonInvalidLocation, message)
+		v.logSignError(log, reporter, cr, err, message)
+
+		return nil, nil
+	}
+
+	if devicePath, ok := annotations[cmapi.VenafiTPPDevicePathAnnotationKey]; ok {
+		location, err = api.ParseDevicePath(devicePath)
+		if err == nil && issuerObj.GetSpec().Venafi.TPP == nil {
+			err = errors.New("only supported by Venafi TPP issuers")
+		}
 		if err != nil {
-			message := fmt.Sprintf("Failed to parse %q annotation", cmapi.VenafiCustomFieldsAnnotationKey)
+			message := fmt.Sprintf("Invalid %q annotation", cmapi.VenafiTPPDevicePathAnnotationKey)
+
+			reporter.Failed(cr, err, crutil.ReasonInvalidLocation, message)
+			v.logSignError(log, reporter, cr, err, message)
+
+			return nil, nil
+		}
+	}
+
+	signatureHash, err := crutil.SignatureHash(annotations)
+	if err != nil {
+		message := "The requested signature hash algorithm is not supported"
+
+		reporter.Failed(cr, err, crutil.ReasonUnsupportedSignatureAlgorithm, message)
+		v.logSignError(log, reporter, cr, err, message)
+
+		return nil, nil
+	}
+
+	// Issuers with additional zones enroll each request in the first of their
+	// zones whose policy accepts it, unless the zone is overridden.
+	if !zoneOverridden && len(issuerObj.GetSpec().Venafi.AdditionalZones) > 0 {
+		failClosed := issuerObj.GetSpec().Venafi.FailClosed
+		issuerObj, client, err = v.selectZone(ctx, log, cr, issuerObj, client, customFields)
+		var noMatchErr errNoMatchingZone
+		switch {
+		case errors.As(err, &noMatchErr):
+			message := "The request is not accepted by the policy of any of the Venafi zones of the issuer"
+
+			reporter.Failed(cr, err, crutil.ReasonNoMatchingZone, message)
+			v.logSignError(log, reporter, cr, err, message)
+
+			return nil, nil
+
+		case isCallTimeout(err):
+			message := "Timed out selecting the Venafi zone of the request, the request will be retried"
+
+			reporter.Pending(cr, err, crutil.ReasonTimeout, message)
+			v.logSignError(log, reporter, cr, err, message)
 
-			v.reporter.Failed(cr, err, "CustomFieldsError", message)
-			log.Error(err, message)
+			return nil, err
 
+		case venaficlient.IsAuthenticationError(err):
+			v.reportAuthenticationError(reporter, log, cr, err)
 			return nil, nil
+
+		case err != nil && failClosed:
+			return nil, v.reportPolicyUnavailable(reporter, log, cr, err)
+
+		case err != nil:
+			message := "Failed to select the Venafi zone of the request"
+
+			reporter.Pending(cr, err, crutil.ReasonVenafiInitError, message)
+			v.logSignError(log, reporter, cr, err, message)
+
+			return nil, err
 		}
+
+		log = log.WithValues("zone", issuerObj.GetSpec().Venafi.Zone)
 	}
 
+	// A CA request is not enrolled in a zone which is known not to permit CA
+	// certificates, rather than being failed once it has been issued.
+	if cr.Spec.IsCA && cr.GetAnnotations()[cmapi.VenafiPickupIDAnnotationKey] == "" && v.caZones.deniesCA(issuerObj) {
+		err := fmt.Errorf("zone %q issued a leaf certificate for a previous CA request", issuerObj.GetSpec().Venafi.Zone)
+		message := "Venafi zone does not permit issuing CA certificates, check the zone policy or remove isCA from the request"
+
+		reporter.Failed(cr, err, crutil.ReasonNotAllowedCA, message)
+		v.logSignError(log, reporter, cr, err, message)
+
+		return nil, nil
+	}
+
+	if cr.GetAnnotations()[cmapi.VenafiDryRunAnnotationKey] == "true" {
+		_, err := callWithTimeout(ctx, v.requestTimeout, func() (struct{}, error) {
+			return struct{}{}, client.ValidateCertificateRequest(cr.Spec.Request, customFields)
+		})
+		if isCallTimeout(err) {
+			message := "Timed out validating the request against the Venafi zone, the request will be retried"
+
+			reporter.Pending(cr, err, crutil.ReasonTimeout, message)
+			v.logSignError(log, reporter, cr, err, message)
+
+			return nil, err
+		}
+
+		if issuerObj.GetSpec().Venafi.FailClosed && venaficlient.IsZonePolicyUnavailableError(err) {
+			return nil, v.reportPolicyUnavailable(reporter, log, cr, err)
+		}
+
+		if err != nil {
+			message := "Venafi dry run validation failed"
+
+			reporter.Failed(cr, err, crutil.ReasonDryRunFailed, message)
+			v.logSignError(log, reporter, cr, err, message)
+
+			return nil, nil
+		}
+
+		message := "Certificate request would be accepted by the Venafi zone, no certificate was requested as this was a dry run"
+
+		reporter.DryRunValidated(cr, message)
+		log.V(logf.DebugLevel).Info(message)
+
+		return nil, nil
+	}
+
+	hash := enrollmentHash(cr, issuerObj)
 	pickupID := cr.ObjectMeta.Annotations[cmapi.VenafiPickupIDAnnotationKey]
 
+	// A pickup ID recorded for a different CSR or issuer, for example on a
+	// CertificateRequest copied from another one, does not belong to this
+	// request, so a new certificate is requested instead.
+	if recorded, ok := cr.ObjectMeta.Annotations[cmapi.VenafiEnrollmentHashAnnotationKey]; ok && pickupID != "" && recorded != hash {
+		log.V(logf.InfoLevel).Info("ignoring the pickup ID recorded for a different request", "pickupID", pickupID)
+
+		pickupID = ""
+		delete(cr.ObjectMeta.Annotations, cmapi.VenafiPickupIDAnnotationKey)
+		delete(cr.ObjectMeta.Annotations, cmapi.VenafiRetryCountAnnotationKey)
+		delete(cr.ObjectMeta.Annotations, cmapi.VenafiNextRetryTimeAnnotationKey)
+	}
+
+	// The pickup ID of a certificate requested by a previous sync may not
+	// have been persisted yet, in which case the pending enrollment is
+	// continued rather than enrolling the same CSR again.
+	if pending, ok := v.enrollments.lookup(hash); ok && pickupID == "" {
+		pickupID = pending
+		log.V(logf.DebugLevel).Info("continuing the pending venafi enrollment of the request", "pickupID", pickupID)
+
+		metav1.SetMetaDataAnnotation(&cr.ObjectMeta, cmapi.VenafiPickupIDAnnotationKey, pickupID)
+	}
+
 	// check if the pickup ID annotation is there, if not set it up.
 	if pickupID == "" {
-		pickupID, err = client.RequestCertificate(cr.Spec.Request, customFields)
+		// Venafi only accepts a validity duration, which is computed from the
+		// requested notAfter time. Without a notAfter time, the validity hint
+		// of the CSR is used if enabled, then the default duration of the
+		// issuer if set, otherwise the validity configured for the zone is
+		// used.
+		duration, err := crutil.NotAfterDuration(cr, v.clock.Now())
+		if err != nil {
+			message := "Invalid notAfter time requested"
+
+			reporter.Failed(cr, err, crutil.ReasonInvalidNotAfter, message)
+			v.logSignError(log, reporter, cr, err, message)
+
+			return nil, nil
+		}
+
+		if duration == 0 && v.validityHintOID != nil {
+			duration, err = validityHintDuration(log, cr, v.validityHintOID)
+			if err != nil {
+				message := "Failed to read the validity hint of the CSR"
+
+				reporter.Failed(cr, err, crutil.ReasonRequestParsingError, message)
+				v.logSignError(log, reporter, cr, err, message)
+
+				return nil, nil
+			}
+		}
+
+		if defaultDuration := issuerObj.GetSpec().Venafi.DefaultDuration; duration == 0 && defaultDuration != nil {
+			duration = defaultDuration.Duration
+		}
+
+		if issuerObj.GetSpec().Venafi.ReuseExisting {
+			reused, err := v.findReusableCertificate(ctx, client, cr, issuerObj)
+			switch {
+			case err != nil:
+				// Reuse only avoids issuing duplicate certificates, so a new
+				// certificate is requested if the search fails.
+				log.Error(err, "failed to search for an existing venafi certificate to reuse, requesting a new certificate")
+
+			case reused.pickupID != "":
+				log = log.WithValues("pickupID", reused.pickupID)
+
+				message := fmt.Sprintf("Reusing the existing Venafi certificate with pickup ID %q", reused.pickupID)
+				reporter.Reused(cr, message)
+				log.V(logf.DebugLevel).Info(message)
+
+				metav1.SetMetaDataAnnotation(&cr.ObjectMeta, cmapi.VenafiPickupIDAnnotationKey, reused.pickupID)
+				setEnrollmentAnnotations(cr, issuerObj)
+
+				return v.issueResponse(log, reporter, cr, issuerObj, reused.certPEM, nil)
+			}
+		}
+
+		// The duration is requested unchanged, but the Venafi zone will issue
+		// the certificate with a different validity, which would otherwise
+		// only be noticed once it is issued.
+		if effective := zoneDuration(duration, issuerObj.GetSpec().Venafi); effective != duration {
+			message := fmt.Sprintf("The requested duration %s is not allowed by the Venafi zone, the certificate will be issued with a duration of %s", duration, effective)
+
+			reporter.DurationAdjusted(cr, message)
+			log.V(logf.InfoLevel).Info(message, "requestedDuration", duration, "effectiveDuration", effective)
+		}
+
+		signStart := v.clock.Now()
+		// A certificate requested by a call which times out but then
+		// succeeds is recorded as a pending enrollment, so that the next sync
+		// retrieves it rather than requesting a duplicate certificate.
+		pickupID, err = callWithLateResult(ctx, v.requestTimeout, func() (string, error) {
+			return client.RequestCertificate(cr.Spec.Request, duration, friendlyName, location, signatureHash, customFields)
+		}, func(pickupID string, err error) {
+			if err != nil || pickupID == "" {
+				return
+			}
+			log.V(logf.InfoLevel).Info("venafi certificate was requested after the request timed out", "pickupID", pickupID)
+			v.enrollments.record(hash, pickupID)
+		})
 		// Check some known error types
 		if err != nil {
-			switch err.(type) {
+			// The CertificateRequest was deleted, or the controller is
+			// shutting down, so the request is not reported on.
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			v.observeSignDuration(cr, signStart, metrics.VenafiSignResultFailed)
+
+			if isCallTimeout(err) {
+				v.countSignError(cr, metrics.VenafiSignErrorTimeout)
+				v.breakers.failure(cr, issuerObj)
+
+				message := "Timed out requesting venafi certificate, the request will be retried"
+
+				reporter.Pending(cr, err, crutil.ReasonTimeout, message)
+				v.logSignError(log, reporter, cr, err, message)
+
+				return nil, err
+			}
 
+			switch err.(type) {
 			case venaficlient.ErrCustomFieldsType:
-				v.reporter.Failed(cr, err, "CustomFieldsError", err.Error())
-				log.Error(err, err.Error())
+				v.countSignError(cr, metrics.VenafiSignErrorInvalidRequest)
+
+				reporter.Failed(cr, err, crutil.ReasonCustomFieldsError, err.Error())
+				v.logSignError(log, reporter, cr, err, err.Error())
+
+				return nil, nil
+
+			case venaficlient.KeyPolicyViolationError:
+				v.countSignError(cr, metrics.VenafiSignErrorPolicyViolation)
+
+				message := "The key of the request is not allowed by the Venafi zone policy"
+				// Tell the user which algorithm to use if the zone does not
+				// allow the algorithm of the key at all.
+				if expected := err.(venaficlient.KeyPolicyViolationError).Expected; len(expected) > 0 {
+					message = fmt.Sprintf("The key algorithm of the request is not allowed by the Venafi zone policy, set spec.privateKey of the Certificate to %s", strings.Join(expected, ", or to "))
+				}
+
+				reporter.Failed(cr, err, crutil.ReasonPolicyViolation, message)
+				v.logSignError(log, reporter, cr, err, message)
+
+				return nil, nil
+
+			case venaficlient.SignatureHashPolicyViolationError:
+				v.countSignError(cr, metrics.VenafiSignErrorPolicyViolation)
+
+				message := "The requested signature hash algorithm is not allowed by the Venafi zone policy"
+
+				reporter.Failed(cr, err, crutil.ReasonUnsupportedSignatureAlgorithm, message)
+				v.logSignError(log, reporter, cr, err, message)
+
+				return nil, nil
+
+			case venaficlient.URISANPolicyViolationError:
+				v.countSignError(cr, metrics.VenafiSignErrorPolicyViolation)
+
+				message := "The URI SANs of the request are not allowed by the Venafi zone policy"
+
+				reporter.Failed(cr, err, crutil.ReasonPolicyViolation, message)
+				v.logSignError(log, reporter, cr, err, message)
+
+				return nil, nil
+
+			case venaficlient.WildcardPolicyViolationError:
+				v.countSignError(cr, metrics.VenafiSignErrorPolicyViolation)
+
+				message := "The wildcard names of the request are not allowed by the Venafi zone policy"
+
+				reporter.Failed(cr, err, crutil.ReasonPolicyViolation, message)
+				v.logSignError(log, reporter, cr, err, message)
+
+				return nil, nil
+
+			case venaficlient.ExtensionPolicyViolationError:
+				v.countSignError(cr, metrics.VenafiSignErrorPolicyViolation)
+
+				if err.(venaficlient.ExtensionPolicyViolationError).OID == utilpki.OIDExtensionTLSFeature.String() {
+					message := "The Venafi zone policy does not allow the OCSP Must-Staple extension, check the zone policy or unset ocspMustStaple on the Certificate"
+
+					reporter.Failed(cr, err, crutil.ReasonMustStapleNotSupported, message)
+					v.logSignError(log, reporter, cr, err, message)
+
+					return nil, nil
+				}
+
+				message := "The extensions of the request are not allowed by the Venafi zone policy"
+
+				reporter.Failed(cr, err, crutil.ReasonPolicyViolation, message)
+				v.logSignError(log, reporter, cr, err, message)
+
+				return nil, nil
+
+			case venaficlient.SubjectDefaultsPolicyViolationError:
+				v.countSignError(cr, metrics.VenafiSignErrorPolicyViolation)
+
+				message := "The subject defaults of the issuer are not allowed by the Venafi zone policy"
+
+				reporter.Failed(cr, err, crutil.ReasonPolicyViolation, message)
+				v.logSignError(log, reporter, cr, err, message)
 
 				return nil, nil
 
 			default:
+				if venaficlient.IsAuthenticationError(err) {
+					v.countSignError(cr, metrics.VenafiSignErrorAuthentication)
+					v.reportAuthenticationError(reporter, log, cr, err)
+					return nil, nil
+				}
+
+				if zoneOverridden && errors.Is(err, verror.ZoneNotFoundError) {
+					v.countSignError(cr, metrics.VenafiSignErrorInvalidRequest)
+
+					message := fmt.Sprintf("Venafi zone %q from the %q annotation was not found", zoneOverride, cmapi.VenafiZoneOverrideAnnotationKey)
+
+					reporter.Failed(cr, err, crutil.ReasonInvalidZone, message)
+					v.logSignError(log, reporter, cr, err, message)
+
+					return nil, nil
+				}
+
+				// Errors of other types may still be classified by their
+				// message.
+				classification, classified := classifyError(err)
+				if classified && classification.class == errorClassPolicy {
+					v.countSignError(cr, metrics.VenafiSignErrorPolicyViolation)
+
+					message := "The request is not allowed by the Venafi zone policy"
+
+					reporter.Failed(cr, err, classification.reason, message)
+					v.logSignError(log, reporter, cr, err, message)
+
+					return nil, nil
+				}
+
+				v.countSignError(cr, metrics.VenafiSignErrorRequest)
+				v.breakers.failure(cr, issuerObj)
+
+				if issuerObj.GetSpec().Venafi.FailClosed && venaficlient.IsZonePolicyUnavailableError(err) {
+					return nil, v.reportPolicyUnavailable(reporter, log, cr, err)
+				}
+
+				// Unless the issuer fails closed, requests are failed when the
+				// zone policy is unavailable, whatever the cause.
+				if classified && classification.class == errorClassTransient && !venaficlient.IsZonePolicyUnavailableError(err) {
+					message := "Failed to request venafi certificate, the request will be retried"
+
+					reporter.Pending(cr, err, classification.reason, message)
+					v.logSignError(log, reporter, cr, err, message)
+
+					return nil, err
+				}
+
 				message := "Failed to request venafi certificate"
 
-				v.reporter.Failed(cr, err, "RequestError", message)
-				log.Error(err, message)
+				reporter.Failed(cr, err, crutil.ReasonRequestError, message)
+				v.logSignError(log, reporter, cr, err, message)
 
 				return nil, err
 			}
 		}
 
-		v.reporter.Pending(cr, err, "IssuancePending", "Venafi certificate is requested")
+		v.observeSignDuration(cr, signStart, metrics.VenafiSignResultPending)
+		v.breakers.success(cr, issuerObj)
+		v.enrollments.record(hash, pickupID)
 
+		reporter.Pending(cr, err, crutil.ReasonIssuancePending, withSigningWait(withRequestedNames(fmt.Sprintf("Venafi certificate is requested with pickup ID %q", pickupID), cr.Spec.Request), wait))
+		log.V(logf.DebugLevel).Info("venafi certificate requested", "pickupID", pickupID)
+
+		// The pickup ID is persisted so that subsequent syncs retrieve the
+		// existing request rather than enrolling a new certificate.
 		metav1.SetMetaDataAnnotation(&cr.ObjectMeta, cmapi.VenafiPickupIDAnnotationKey, pickupID)
+		setEnrollmentAnnotations(cr, issuerObj)
 
 		return nil, nil
 	}
 
-	certPem, err := client.RetrieveCertificate(pickupID, cr.Spec.Request, customFields)
+	log = log.WithValues("pickupID", pickupID)
+
+	// Requests enrolled before the enrollment annotations were introduced
+	// are annotated while they are still pending or once they are issued.
+	setEnrollmentAnnotations(cr, issuerObj)
+
+	// A callback of the Venafi platform signals that the certificate has
+	// been issued, so it is retrieved without waiting for the backoffs below.
+	if v.callbacks.takeReceived(cr) {
+		log.V(logf.DebugLevel).Info("retrieving venafi certificate after callback")
+	} else {
+		// Avoid polling the Venafi platform before the backoff for a pending
+		// certificate has elapsed, for example when the CertificateRequest is
+		// resynced because its annotations were updated.
+		if next, ok := nextPendingRetryTime(cr); ok && v.clock.Now().Before(next) {
+			delay := next.Sub(v.clock.Now())
+			log.V(logf.DebugLevel).Info("waiting before retrying to retrieve pending venafi certificate", "delay", delay)
+			v.requeueAfter(cr, delay)
+			return nil, nil
+		}
+
+		// Likewise, avoid retrying a retrieval which failed unexpectedly
+		// before its backoff has elapsed.
+		if delay, ok := v.retrieveFailures.wait(cr); ok {
+			log.V(logf.DebugLevel).Info("waiting before retrying to retrieve venafi certificate after a failure", "delay", delay)
+			v.requeueAfter(cr, delay)
+			return nil, nil
+		}
+	}
+
+	signStart := v.clock.Now()
+	retrieved, err := callWithTimeout(ctx, v.requestTimeout, func() (retrievedCertificate, error) {
+		return retrieveCertificate(client, issuerObj, pickupID, cr.Spec.Request, customFields)
+	})
 	if err != nil {
-		switch err.(type) {
-		case endpoint.ErrCertificatePending, endpoint.ErrRetrieveCertificateTimeout:
-			message := "Venafi certificate still in a pending state, the request will be retried"
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 
-			v.reporter.Pending(cr, err, "IssuancePending", message)
-			log.Error(err, message)
-			return nil, err
+		_, certificatePending := err.(endpoint.ErrCertificatePending)
+		_, retrieveTimeout := err.(endpoint.ErrRetrieveCertificateTimeout)
+		callTimeout := isCallTimeout(err)
+
+		switch {
+		case certificatePending, retrieveTimeout, callTimeout:
+			v.observeSignDuration(cr, signStart, metrics.VenafiSignResultPending)
+			v.retrieveFailures.forget(cr)
+
+			// The Venafi platform responded, unless the call timed out.
+			if callTimeout {
+				v.breakers.failure(cr, issuerObj)
+			} else {
+				v.breakers.success(cr, issuerObj)
+			}
+
+			attempt := pendingRetryCount(cr) + 1
+			backoff := issuerObj.GetSpec().Venafi.RetryBackoff
+			delay := untilIssuanceDeadline(cr, issuerObj, v.clock.Now(), pendingRetryDelay(backoff, attempt)+pendingRetryJitter(backoff, cr, attempt))
+			metav1.SetMetaDataAnnotation(&cr.ObjectMeta, cmapi.VenafiRetryCountAnnotationKey, strconv.Itoa(attempt))
+			metav1.SetMetaDataAnnotation(&cr.ObjectMeta, cmapi.VenafiNextRetryTimeAnnotationKey, v.clock.Now().Add(delay).UTC().Format(time.RFC3339))
+
+			message := withSigningWait(fmt.Sprintf("Venafi certificate still in a pending state, the request will be retried in %s", delay), wait)
+
+			reason, errorReason := crutil.ReasonIssuancePending, metrics.VenafiSignErrorTimeout
+			switch {
+			case certificatePending:
+				errorReason = metrics.VenafiSignErrorPending
+			case callTimeout:
+				reason = crutil.ReasonTimeout
+			}
+			v.countSignError(cr, errorReason)
+
+			reporter.Pending(cr, err, reason, message)
+			v.logSignError(log, reporter, cr, err, message)
+
+			v.requeueAfter(cr, delay)
+			return nil, nil
 
 		default:
-			message := "Failed to obtain venafi certificate"
+			v.observeSignDuration(cr, signStart, metrics.VenafiSignResultFailed)
+
+			if venaficlient.IsAuthenticationError(err) {
+				v.countSignError(cr, metrics.VenafiSignErrorAuthentication)
+				v.reportAuthenticationError(reporter, log, cr, err)
+				return nil, nil
+			}
 
-			v.reporter.Failed(cr, err, "RetrieveError", message)
-			log.Error(err, message)
+			// Errors of other types may still be classified by their
+			// message. Transient errors are retried with the backoff below.
+			reason := crutil.ReasonRetrieveError
+			if classification, ok := classifyError(err); ok {
+				if classification.class == errorClassPolicy {
+					v.countSignError(cr, metrics.VenafiSignErrorPolicyViolation)
+					v.retrieveFailures.forget(cr)
+					v.enrollments.forget(hash)
 
-			return nil, err
+					message := "Venafi certificate was rejected by the Venafi zone policy"
+
+					reporter.Failed(cr, err, classification.reason, message)
+					v.logSignError(log, reporter, cr, err, message)
+
+					return nil, nil
+				}
+				reason = classification.reason
+			}
+
+			v.countSignError(cr, metrics.VenafiSignErrorRetrieve)
+			v.breakers.failure(cr, issuerObj)
+
+			// Unexpected errors, for example during an outage of the Venafi
+			// platform, are retried with a capped backoff rather than the
+			// rate limited requeue of the controller, until the request has
+			// been failing for too long.
+			delay, retry := v.retrieveFailures.record(cr)
+			if !retry {
+				v.enrollments.forget(hash)
+
+				message := "Failed to obtain venafi certificate, giving up after repeated failures"
+
+				reporter.Failed(cr, err, crutil.ReasonRetrieveError, message)
+				v.logSignError(log, reporter, cr, err, message)
+
+				return nil, nil
+			}
+
+			message := fmt.Sprintf("Failed to obtain venafi certificate, the request will be retried in %s", delay)
+
+			reporter.Pending(cr, err, reason, message)
+			v.logSignError(log, reporter, cr, err, message)
+
+			v.requeueAfter(cr, delay)
+			return nil, nil
 		}
 	}
 
+	v.observeSignDuration(cr, signStart, metrics.VenafiSignResultSuccess)
+	v.retrieveFailures.forget(cr)
+	v.enrollments.forget(hash)
+	v.breakers.success(cr, issuerObj)
+
 	log.V(logf.DebugLevel).Info("certificate issued")
 
+	return v.issueResponse(log, reporter, cr, issuerObj, retrieved.chainPEM, retrieved.keyPEM)
+}
+
+// retrievedCertificate is the certificate chain retrieved from the Venafi
+// platform, along with its private key if it was generated by the platform.
+type retrievedCertificate struct {
+	chainPEM []byte
+	keyPEM   []byte
+}
+
+// retrieveCertificate retrieves the certificate with the given pickup ID, and
+// its private key if the issuer uses keys generated by Venafi TPP.
+func retrieveCertificate(client venaficlient.Interface, issuerObj cmapi.GenericIssuer, pickupID string, csrPEM []byte, customFields []api.CustomField) (retrievedCertificate, error) {
+	if tppCfg := issuerObj.GetSpec().Venafi.TPP; tppCfg != nil && tppCfg.ServiceGeneratedKeys {
+		chainPEM, keyPEM, err := client.RetrieveCertificateAndKey(pickupID, csrPEM, customFields)
+		return retrievedCertificate{chainPEM: chainPEM, keyPEM: keyPEM}, err
+	}
+
+	chainPEM, err := client.RetrieveCertificate(pickupID, csrPEM, customFields)
+	return retrievedCertificate{chainPEM: chainPEM}, err
+}
+
+// issueResponse verifies the certificate chain returned by the Venafi platform
+// for the CertificateRequest, and returns it as the response of the issuer.
+// If the private key of the certificate was generated by the Venafi platform,
+// it is verified to match the certificate and returned with it.
+func (v *Venafi) issueResponse(log logr.Logger, reporter *signReporter, cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer, certPem, keyPEM []byte) (*issuerpkg.IssueResponse, error) {
 	bundle, err := utilpki.ParseSingleCertificateChainPEM(certPem)
 	if err != nil {
 		message := "Failed to parse returned certificate bundle"
-		v.reporter.Failed(cr, err, "ParseError", message)
-		log.Error(err, message)
+		reporter.Failed(cr, err, crutil.ReasonParseError, message)
+		v.logSignError(log, reporter, cr, err, message)
+		return nil, err
+	}
+
+	// The Venafi platform may return the certificate without the
+	// intermediates of the zone, in which case the chain is completed from the
+	// chain bundle of the issuer, if configured.
+	if ref := issuerObj.GetSpec().Venafi.ChainBundleSecretRef; ref != nil {
+		complete, err := hasRootCA(bundle)
+		if err != nil {
+			message := "Failed to decode returned CA certificate"
+			reporter.Failed(cr, err, crutil.ReasonParseError, message)
+			v.logSignError(log, reporter, cr, err, message)
+			return nil, err
+		}
+
+		if !complete {
+			chainBundle, err := v.secretKeyData(issuerObj, ref)
+			if err != nil {
+				message := "Failed to read the chain bundle of the issuer"
+				reporter.Pending(cr, err, crutil.ReasonSecretGetError, message)
+				v.logSignError(log, reporter, cr, err, message)
+				return nil, err
+			}
+
+			bundle, err = completeChain(bundle, chainBundle, v.clock.Now())
+			if err != nil {
+				message := "Returned certificate chain is incomplete and could not be verified against the chain bundle of the issuer"
+				reporter.Failed(cr, err, crutil.ReasonIncompleteChain, message)
+				v.logSignError(log, reporter, cr, err, message)
+				return nil, nil
+			}
+		}
+	}
+
+	// The chain may still not be trusted by the workloads using the
+	// certificate, for example if the intermediates of the zone are
+	// misconfigured, which is caught here rather than once the certificate is
+	// stored in the Secret of a Certificate.
+	if verification := issuerObj.GetSpec().Venafi.ChainVerification; verification != nil {
+		roots, err := v.trustAnchors(issuerObj, verification)
+		switch {
+		case err != nil && verification.SkipIfUnavailable:
+			log.V(logf.WarnLevel).Info("the trust anchors of the issuer could not be loaded, the returned certificate chain is not verified", "error", err.Error())
+
+		case err != nil:
+			message := "Failed to load the trust anchors of the issuer"
+			reporter.Pending(cr, err, crutil.ReasonSecretGetError, message)
+			v.logSignError(log, reporter, cr, err, message)
+			return nil, err
+
+		default:
+			if err := verifyChain(bundle, roots, v.clock.Now()); err != nil {
+				message := "Returned certificate chain could not be verified against the trust anchors of the issuer"
+				reporter.Failed(cr, err, crutil.ReasonUntrustedChain, message)
+				v.logSignError(log, reporter, cr, err, message)
+				return nil, nil
+			}
+		}
+	}
+
+	crt, err := utilpki.DecodeX509CertificateBytes(bundle.ChainPEM)
+	if err != nil {
+		message := "Failed to decode returned certificate"
+		reporter.Failed(cr, err, crutil.ReasonParseError, message)
+		v.logSignError(log, reporter, cr, err, message)
 		return nil, err
 	}
 
+	// The basic constraints requested in the CSR are sent to Venafi as is, but
+	// vcert does not expose whether the zone policy permits issuing CA
+	// certificates. Verify the issued certificate so that a CA request is not
+	// silently fulfilled with a leaf certificate, and remember the zone so
+	// that further CA requests are failed before they are enrolled.
+	if cr.Spec.IsCA && !crt.IsCA {
+		v.caZones.recordDenied(issuerObj)
+
+		err := errors.New("the issued certificate is not a CA certificate")
+		message := "Venafi zone does not permit issuing CA certificates, check the zone policy or remove isCA from the request"
+		reporter.Failed(cr, err, crutil.ReasonNotAllowedCA, message)
+		v.logSignError(log, reporter, cr, err, message)
+		return nil, nil
+	}
+
+	// The same applies to the requested usages, which the zone policy or
+	// certificate template may override.
+	if err := crutil.VerifyExtKeyUsages(cr, crt); err != nil {
+		message := "Venafi zone does not permit the requested usages, check the zone policy or change the usages of the request"
+		reporter.Failed(cr, err, crutil.ReasonUsagesNotPermitted, message)
+		v.logSignError(log, reporter, cr, err, message)
+		return nil, nil
+	}
+
+	// The requested validity is likewise subject to the zone policy and the
+	// CA behind the zone.
+	if err := crutil.VerifyNotAfter(cr, crt); err != nil {
+		message := "Venafi zone did not honor the requested notAfter time, check the zone policy or remove notAfter from the request"
+		reporter.Failed(cr, err, crutil.ReasonNotAfterNotHonored, message)
+		v.logSignError(log, reporter, cr, err, message)
+		return nil, nil
+	}
+
+	// The legacy extensions are added by the zone, so verify that it did
+	// rather than silently storing a certificate the legacy clients reject.
+	if issuerObj.GetSpec().Venafi.LegacyExtensions && !utilpki.HasNetscapeCertType(crt) {
+		err := errors.New("the issued certificate does not contain the Netscape certificate type extension")
+		message := "Venafi zone did not add the legacy extensions, check the zone policy or unset legacyExtensions on the issuer"
+		reporter.Failed(cr, err, crutil.ReasonLegacyExtensionsNotHonored, message)
+		v.logSignError(log, reporter, cr, err, message)
+		return nil, nil
+	}
+
+	// OCSP Must-Staple is passed to the zone with the CSR, but the zone may
+	// drop the extension, in which case clients would not require the
+	// server to staple OCSP responses as requested.
+	if csr, err := utilpki.DecodeX509CertificateRequestBytes(cr.Spec.Request); err == nil && utilpki.RequestsOCSPMustStaple(csr) && !utilpki.HasOCSPMustStaple(crt) {
+		err := errors.New("the issued certificate does not contain the OCSP Must-Staple extension")
+		message := "Venafi zone did not honor the OCSP Must-Staple extension, check the zone policy or unset ocspMustStaple on the Certificate"
+		reporter.Failed(cr, err, crutil.ReasonMustStapleNotSupported, message)
+		v.logSignError(log, reporter, cr, err, message)
+		return nil, nil
+	}
+
+	// vcert cannot read the hash algorithm of TPP zones, so the requested
+	// hash algorithm is only enforced here. The annotation has already been
+	// validated before the certificate was requested.
+	signatureHash, _ := crutil.SignatureHash(requestAnnotations(cr, issuerObj))
+	if err := crutil.VerifySignatureHash(crt, signatureHash); err != nil {
+		message := fmt.Sprintf("Venafi zone did not honor the requested signature hash algorithm, check the zone policy or remove the %q annotation", cmapi.CertificateRequestSignatureHashAnnotationKey)
+		reporter.Failed(cr, err, crutil.ReasonUnsupportedSignatureAlgorithm, message)
+		v.logSignError(log, reporter, cr, err, message)
+		return nil, nil
+	}
+
+	if len(keyPEM) > 0 {
+		key, err := utilpki.DecodePrivateKeyBytes(keyPEM)
+		if err != nil {
+			message := "Failed to decode the private key generated by Venafi"
+			reporter.Failed(cr, err, crutil.ReasonErrorParsingKey, message)
+			v.logSignError(log, reporter, cr, err, message)
+			return nil, nil
+		}
+
+		if ok, err := utilpki.PublicKeyMatchesCertificate(key.Public(), crt); err != nil || !ok {
+			if err == nil {
+				err = errors.New("the public key of the certificate does not match the private key")
+			}
+			message := "Private key generated by Venafi does not match the issued certificate"
+			reporter.Failed(cr, err, crutil.ReasonErrorKeyMatch, message)
+			v.logSignError(log, reporter, cr, err, message)
+			return nil, nil
+		}
+	}
+
+	v.recordIssuance(log, cr, crt)
+
 	return &issuerpkg.IssueResponse{
 		Certificate: bundle.ChainPEM,
 		CA:          bundle.CAPEM,
+		PrivateKey:  keyPEM,
 	}, nil
 }
+
+// setEnrollmentAnnotations records the Venafi zone and connector type the
+// CertificateRequest was enrolled with, so that the provenance of the issued
+// certificate can be reconstructed, and the hash of its enrollment.
+func setEnrollmentAnnotations(cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) {
+	venCfg := issuerObj.GetSpec().Venafi
+
+	connectorType := cmapi.VenafiConnectorTypeTPP
+	if venCfg.Cloud != nil {
+		connectorType = cmapi.VenafiConnectorTypeCloud
+	}
+
+	metav1.SetMetaDataAnnotation(&cr.ObjectMeta, cmapi.VenafiZoneAnnotationKey, venCfg.Zone)
+	metav1.SetMetaDataAnnotation(&cr.ObjectMeta, cmapi.VenafiConnectorTypeAnnotationKey, connectorType)
+	metav1.SetMetaDataAnnotation(&cr.ObjectMeta, cmapi.VenafiEnrollmentHashAnnotationKey, enrollmentHash(cr, issuerObj))
+}
+
+// recordIssuance sends a record of the certificate issued for the
+// CertificateRequest to the audit sink, if any. Failing to record the
+// issuance does not fail the request.
+func (v *Venafi) recordIssuance(log logr.Logger, cr *cmapi.CertificateRequest, crt *x509.Certificate) {
+	if v.auditSink == nil {
+		return
+	}
+
+	record := controllerpkg.NewIssuanceAuditRecord(v.clock.Now(), cr, crt, controllerpkg.IssuanceAuditResultIssued)
+	if err := v.auditSink.RecordIssuance(record); err != nil {
+		log.Error(err, "failed to record the issuance in the audit sink")
+	}
+}
+
+// reportAuthenticationError keeps the CertificateRequest pending because the
+// Venafi platform rejected the issuer credentials. Retrying with the same
+// credentials would not succeed, so the request is not retried until the
+// issuer or the Secret containing its credentials changes.
+func (v *Venafi) reportAuthenticationError(reporter *signReporter, log logr.Logger, cr *cmapi.CertificateRequest, err error) {
+	message := "Venafi rejected the issuer credentials, the request will be retried once the credentials referenced by the issuer change"
+
+	reporter.Pending(cr, err, crutil.ReasonAuthenticationError, message)
+	v.logSignError(log, reporter, cr, err, message)
+}
+
+// reportPolicyUnavailable keeps the CertificateRequest pending because the
+// policy of the Venafi zone could not be confirmed, and its issuer fails
+// closed rather than submitting the request unchecked. The returned error
+// causes the request to be retried with a backoff.
+func (v *Venafi) reportPolicyUnavailable(reporter *signReporter, log logr.Logger, cr *cmapi.CertificateRequest, err error) error {
+	message := "The policy of the Venafi zone could not be confirmed, the request will be retried"
+
+	reporter.Pending(cr, err, crutil.ReasonPolicyUnavailable, message)
+	v.logSignError(log, reporter, cr, err, message)
+
+	return err
+}
+
+// observeSignDuration records the time taken by a call to the Venafi platform
+// which started at the given time.
+func (v *Venafi) observeSignDuration(cr *cmapi.CertificateRequest, start time.Time, result string) {
+	if v.metrics == nil {
+		return
+	}
+
+	v.metrics.ObserveVenafiSignDuration(v.clock.Since(start), cr.Spec.IssuerRef, result)
+}
+
+// countSignError records an error when signing the CertificateRequest, by the
+// reason of the error.
+func (v *Venafi) countSignError(cr *cmapi.CertificateRequest, reason string) {
+	if v.metrics == nil {
+		return
+	}
+
+	v.metrics.IncrementVenafiSignError(cr.Spec.IssuerRef, reason)
+}
+
+// locationFromAnnotations returns the location of the certificate set by the
+// instance and workload annotations, or nil if neither annotation is set.
+func locationFromAnnotations(annotations map[string]string) (*api.Location, error) {
+	instance, hasInstance := annotations[cmapi.VenafiInstanceAnnotationKey]
+	workload, hasWorkload := annotations[cmapi.VenafiWorkloadAnnotationKey]
+	if !hasInstance && !hasWorkload {
+		return nil, nil
+	}
+
+	location := &api.Location{Instance: instance, Workload: workload}
+	if err := location.Validate(); err != nil {
+		return nil, err
+	}
+	return location, nil
+}
@@ -23,30 +23,39 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"errors"
+	"fmt"
 	"math/big"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/Venafi/vcert/v5/pkg/endpoint"
+	"github.com/Venafi/vcert/v5/pkg/verror"
 	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/rest"
 	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
 
-	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
 	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
 	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
 	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
 	internalvenafifake "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
@@ -92,8 +101,8 @@ func TestSign(t *testing.T) {
 		Subject: pkix.Name{
 			CommonName: "root-ca",
 		},
-		NotBefore: time.Now(),
-		NotAfter:  time.Now().Add(time.Minute),
+		NotBefore: fixedClockStart,
+		NotAfter:  fixedClockStart.Add(time.Minute),
 		KeyUsage:  x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
 	}
 	rootPEM, rootCert, err := pki.SignCertificate(rootTmpl, rootTmpl, rootPK.Public(), rootPK)
@@ -101,6 +110,18 @@ func TestSign(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	intermediatePK, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	intermediateTmpl := *rootTmpl
+	intermediateTmpl.Subject = pkix.Name{CommonName: "intermediate-ca"}
+	intermediateTmpl.PublicKey = intermediatePK.Public()
+	intermediatePEM, intermediateCert, err := pki.SignCertificate(&intermediateTmpl, rootCert, intermediatePK.Public(), rootPK)
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	testPK, err := pki.GenerateECPrivateKey(256)
 	if err != nil {
 		t.Fatal(err)
@@ -137,6 +158,7 @@ func TestSign(t *testing.T) {
 
 	tppIssuer := gen.IssuerFrom(baseIssuer,
 		gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+			Zone: "tpp-zone",
 			TPP: &cmapi.VenafiTPP{
 				CredentialsRef: cmmeta.LocalObjectReference{
 					Name: tppSecret.Name,
@@ -147,6 +169,21 @@ func TestSign(t *testing.T) {
 
 	cloudIssuer := gen.IssuerFrom(baseIssuer,
 		gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+			Zone: "cloud-zone",
+			Cloud: &cmapi.VenafiCloud{
+				APITokenSecretRef: cmmeta.SecretKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{
+						Name: cloudSecret.Name,
+					},
+				},
+			},
+		}),
+	)
+
+	cloudIssuerWithZoneOverrides := gen.IssuerFrom(cloudIssuer,
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+			Zone:                 "cloud-zone",
+			AllowedZoneOverrides: []string{"short-lived"},
 			Cloud: &cmapi.VenafiCloud{
 				APITokenSecretRef: cmmeta.SecretKeySelector{
 					LocalObjectReference: cmmeta.LocalObjectReference{
@@ -157,6 +194,63 @@ func TestSign(t *testing.T) {
 		}),
 	)
 
+	chainBundleSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-chain-bundle",
+			Namespace: gen.DefaultTestNamespace,
+		},
+		Data: map[string][]byte{
+			cmmeta.TLSCAKey: append(append([]byte{}, intermediatePEM...), rootPEM...),
+		},
+	}
+
+	rootOnlyChainBundleSecret := &corev1.Secret{
+		ObjectMeta: chainBundleSecret.ObjectMeta,
+		Data: map[string][]byte{
+			cmmeta.TLSCAKey: rootPEM,
+		},
+	}
+
+	tppChainBundleIssuer := gen.IssuerFrom(baseIssuer,
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+			Zone: "tpp-zone",
+			TPP: &cmapi.VenafiTPP{
+				CredentialsRef: cmmeta.LocalObjectReference{
+					Name: tppSecret.Name,
+				},
+			},
+			ChainBundleSecretRef: &cmmeta.SecretKeySelector{
+				LocalObjectReference: cmmeta.LocalObjectReference{
+					Name: chainBundleSecret.Name,
+				},
+			},
+		}),
+	)
+
+	tppAllowedDomainsIssuer := gen.IssuerFrom(tppIssuer,
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+			Zone: "tpp-zone",
+			TPP: &cmapi.VenafiTPP{
+				CredentialsRef: cmmeta.LocalObjectReference{
+					Name: tppSecret.Name,
+				},
+			},
+			AllowedDomains: []string{"*.example.com"},
+		}),
+	)
+
+	tppServiceGeneratedKeysIssuer := gen.IssuerFrom(tppIssuer,
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+			Zone: "tpp-zone",
+			TPP: &cmapi.VenafiTPP{
+				CredentialsRef: cmmeta.LocalObjectReference{
+					Name: tppSecret.Name,
+				},
+				ServiceGeneratedKeys: true,
+			},
+		}),
+	)
+
 	baseCRNotApproved := gen.CertificateRequest("test-cr",
 		gen.SetCertificateRequestCSR(csrPEM),
 	)
@@ -193,6 +287,18 @@ func TestSign(t *testing.T) {
 
 	tppCRWithInvalidCustomFieldType := gen.CertificateRequestFrom(tppCR, gen.SetCertificateRequestAnnotations(map[string]string{"venafi.cert-manager.io/custom-fields": `[{"name": "cert-manager-test", "value": "test ok", "type": "Bool"}]`}))
 
+	tppCRWithIsCA := gen.CertificateRequestFrom(tppCR, gen.SetCertificateRequestIsCA(true))
+
+	tppCRWithClientAuth := gen.CertificateRequestFrom(tppCR, gen.SetCertificateRequestKeyUsages(cmapi.UsageDigitalSignature, cmapi.UsageClientAuth))
+
+	tppCRWithNotAfter := gen.CertificateRequestFrom(tppCR, gen.SetCertificateRequestNotAfter(metav1.NewTime(fixedClockStart.Add(time.Hour))))
+
+	tppCRWithFriendlyName := gen.CertificateRequestFrom(tppCR, gen.SetCertificateRequestAnnotations(map[string]string{"venafi.cert-manager.io/friendly-name": "my-friendly-name"}))
+
+	tppCRWithLongFriendlyName := gen.CertificateRequestFrom(tppCR, gen.SetCertificateRequestAnnotations(map[string]string{"venafi.cert-manager.io/friendly-name": strings.Repeat("a", 256)}))
+
+	tppCRWithDryRun := gen.CertificateRequestFrom(tppCR, gen.SetCertificateRequestAnnotations(map[string]string{"venafi.cert-manager.io/dry-run": "true"}))
+
 	cloudCR := gen.CertificateRequestFrom(baseCR,
 		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
 			Group: certmanager.GroupName,
@@ -201,6 +307,23 @@ func TestSign(t *testing.T) {
 		}),
 	)
 
+	// The TPP and Cloud issuers share the same name, so the requests enrolled
+	// with either have the same enrollment hash.
+	testEnrollmentHash := enrollmentHash(tppCR, tppIssuer)
+
+	cloudCRWithInvalidWorkload := gen.CertificateRequestFrom(cloudCR, gen.SetCertificateRequestAnnotations(map[string]string{"venafi.cert-manager.io/workload": "pay\nments"}))
+
+	tppCRWithWorkload := gen.CertificateRequestFrom(tppCR, gen.SetCertificateRequestAnnotations(map[string]string{"venafi.cert-manager.io/workload": "payments"}))
+
+	tppCRWithInvalidDevicePath := gen.CertificateRequestFrom(tppCR, gen.SetCertificateRequestAnnotations(map[string]string{"venafi.cert-manager.io/tpp-device-path": "web-1"}))
+
+	cloudCRWithDevicePath := gen.CertificateRequestFrom(cloudCR, gen.SetCertificateRequestAnnotations(map[string]string{"venafi.cert-manager.io/tpp-device-path": `web-1\nginx`}))
+
+	cloudCRWithZoneOverride := gen.CertificateRequestFrom(cloudCR, gen.SetCertificateRequestAnnotations(map[string]string{"venafi.cert-manager.io/zone-override": "short-lived"}))
+
+	cloudCRWithEmptyZoneOverride := gen.CertificateRequestFrom(cloudCR, gen.SetCertificateRequestAnnotations(map[string]string{"venafi.cert-manager.io/zone-override": " "}))
+	cloudCRWithDisallowedZoneOverride := gen.CertificateRequestFrom(cloudCR, gen.SetCertificateRequestAnnotations(map[string]string{"venafi.cert-manager.io/zone-override": "other-zone"}))
+
 	failGetSecretLister := &testlisters.FakeSecretLister{
 		SecretsFn: func(namespace string) corelisters.SecretNamespaceLister {
 			return &testlisters.FakeSecretNamespaceLister{
@@ -215,6 +338,9 @@ func TestSign(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	// The returned chains are verified at the time of the fake clock, which
+	// may be well before the template was generated.
+	template.NotBefore = fixedClockStart
 
 	certPEM, _, err := pki.SignCertificate(template, rootCert, testPK.Public(), rootPK)
 	if err != nil {
@@ -222,7 +348,7 @@ func TestSign(t *testing.T) {
 	}
 
 	clientReturnsPending := &internalvenafifake.Venafi{
-		RequestCertificateFn: func(csrPEM []byte, customFields []api.CustomField) (string, error) {
+		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, customFields []api.CustomField) (string, error) {
 			return "test", nil
 		},
 		RetrieveCertificateFn: func(string, []byte, []api.CustomField) ([]byte, error) {
@@ -233,12 +359,76 @@ func TestSign(t *testing.T) {
 		},
 	}
 	clientReturnsGenericError := &internalvenafifake.Venafi{
-		RequestCertificateFn: func(csrPEM []byte, customFields []api.CustomField) (string, error) {
+		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, customFields []api.CustomField) (string, error) {
 			return "", errors.New("this is an error")
 		},
 	}
+	clientReturnsKeyPolicyViolation := &internalvenafifake.Venafi{
+		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, customFields []api.CustomField) (string, error) {
+			return "", client.KeyPolicyViolationError{Key: "ECDSA P521", Allowed: []string{"RSA (2048, 4096)"}, Expected: []string{"RSA with a size of 2048 or 4096"}}
+		},
+	}
+	clientReturnsSignatureHashPolicyViolation := &internalvenafifake.Venafi{
+		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, customFields []api.CustomField) (string, error) {
+			return "", client.SignatureHashPolicyViolationError{Requested: crypto.SHA384, Zone: crypto.SHA256}
+		},
+	}
+	clientReturnsURISANPolicyViolation := &internalvenafifake.Venafi{
+		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, customFields []api.CustomField) (string, error) {
+			return "", client.URISANPolicyViolationError{URI: "spiffe://example.org/app"}
+		},
+	}
+	clientReturnsWildcardPolicyViolation := &internalvenafifake.Venafi{
+		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, customFields []api.CustomField) (string, error) {
+			return "", client.WildcardPolicyViolationError{Name: "*.example.com"}
+		},
+	}
+	clientReturnsExtensionPolicyViolation := &internalvenafifake.Venafi{
+		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, customFields []api.CustomField) (string, error) {
+			return "", client.ExtensionPolicyViolationError{OID: "1.2.3.4", Allowed: []string{"1.2.3.5"}}
+		},
+	}
+	clientReturnsSubjectDefaultsPolicyViolation := &internalvenafifake.Venafi{
+		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, customFields []api.CustomField) (string, error) {
+			return "", client.SubjectDefaultsPolicyViolationError{Field: "organization", Value: "Example Inc.", Allowed: []string{"^Venafi$"}}
+		},
+	}
+	clientReturnsUnauthorized := &internalvenafifake.Venafi{
+		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, customFields []api.CustomField) (string, error) {
+			return "", verror.UnauthorizedError
+		},
+	}
 	clientReturnsCert := &internalvenafifake.Venafi{
-		RequestCertificateFn: func(csrPEM []byte, customFields []api.CustomField) (string, error) {
+		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, customFields []api.CustomField) (string, error) {
+			return "test", nil
+		},
+		RetrieveCertificateFn: func(string, []byte, []api.CustomField) ([]byte, error) {
+			return append(certPEM, rootPEM...), nil
+		},
+	}
+
+	otherPK, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKeyPEM, err := pki.EncodePKCS8PrivateKey(otherPK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientReturnsCertAndOtherKey := &internalvenafifake.Venafi{
+		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, customFields []api.CustomField) (string, error) {
+			return "test", nil
+		},
+		RetrieveCertificateAndKeyFn: func(string, []byte, []api.CustomField) ([]byte, []byte, error) {
+			return append(certPEM, rootPEM...), otherKeyPEM, nil
+		},
+	}
+
+	clientReturnsCertIfFriendlyName := &internalvenafifake.Venafi{
+		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, customFields []api.CustomField) (string, error) {
+			if friendlyName != "my-friendly-name" {
+				return "", fmt.Errorf("unexpected friendly name %q", friendlyName)
+			}
 			return "test", nil
 		},
 		RetrieveCertificateFn: func(string, []byte, []api.CustomField) ([]byte, error) {
@@ -246,8 +436,66 @@ func TestSign(t *testing.T) {
 		},
 	}
 
+	intermediateSignedCertPEM, _, err := pki.SignCertificate(template, intermediateCert, testPK.Public(), intermediatePK)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientReturnsCertWithoutIntermediate := &internalvenafifake.Venafi{
+		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, customFields []api.CustomField) (string, error) {
+			return "test", nil
+		},
+		RetrieveCertificateFn: func(string, []byte, []api.CustomField) ([]byte, error) {
+			return intermediateSignedCertPEM, nil
+		},
+	}
+
+	serverAuthTemplate := *template
+	serverAuthTemplate.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	serverAuthCertPEM, _, err := pki.SignCertificate(&serverAuthTemplate, rootCert, testPK.Public(), rootPK)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientReturnsServerAuthCert := &internalvenafifake.Venafi{
+		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, customFields []api.CustomField) (string, error) {
+			return "test", nil
+		},
+		RetrieveCertificateFn: func(string, []byte, []api.CustomField) ([]byte, error) {
+			return append(serverAuthCertPEM, rootPEM...), nil
+		},
+	}
+
+	notAfterTemplate := *template
+	notAfterTemplate.NotAfter = fixedClockStart.Add(time.Hour)
+	notAfterCertPEM, _, err := pki.SignCertificate(&notAfterTemplate, rootCert, testPK.Public(), rootPK)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientReturnsCertIfNotAfterDuration := &internalvenafifake.Venafi{
+		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, customFields []api.CustomField) (string, error) {
+			if duration != time.Hour {
+				return "", fmt.Errorf("unexpected duration %s", duration)
+			}
+			return "test", nil
+		},
+		RetrieveCertificateFn: func(string, []byte, []api.CustomField) ([]byte, error) {
+			return append(notAfterCertPEM, rootPEM...), nil
+		},
+	}
+
+	unblockHungClient := make(chan struct{})
+	defer close(unblockHungClient)
+	clientHangs := &internalvenafifake.Venafi{
+		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, customFields []api.CustomField) (string, error) {
+			<-unblockHungClient
+			return "test", nil
+		},
+	}
+
 	clientReturnsCertIfCustomField := &internalvenafifake.Venafi{
-		RequestCertificateFn: func(csrPEM []byte, fields []api.CustomField) (string, error) {
+		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, fields []api.CustomField) (string, error) {
 			if len(fields) > 0 && fields[0].Name == "cert-manager-test" && fields[0].Value == "test ok" {
 				return "test", nil
 			}
@@ -259,11 +507,29 @@ func TestSign(t *testing.T) {
 	}
 
 	clientReturnsInvalidCustomFieldType := &internalvenafifake.Venafi{
-		RequestCertificateFn: func(csrPEM []byte, fields []api.CustomField) (string, error) {
+		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, fields []api.CustomField) (string, error) {
 			return "", client.ErrCustomFieldsType{Type: fields[0].Type}
 		},
 	}
 
+	clientReturnsZoneNotFound := &internalvenafifake.Venafi{
+		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, customFields []api.CustomField) (string, error) {
+			return "", verror.ZoneNotFoundError
+		},
+	}
+
+	clientValidatesDryRun := &internalvenafifake.Venafi{
+		RequestCertificateFn: func(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, customFields []api.CustomField) (string, error) {
+			return "", errors.New("certificate should not be requested in a dry run")
+		},
+	}
+
+	clientFailsDryRun := &internalvenafifake.Venafi{
+		ValidateCertificateFn: func([]byte, []api.CustomField) error {
+			return errors.New("common name does not match zone policy")
+		},
+	}
+
 	tests := map[string]testT{
 		"a CertificateRequest without an approved condition should do nothing": {
 			certificateRequest: baseCRNotApproved.DeepCopy(),
@@ -280,7 +546,7 @@ func TestSign(t *testing.T) {
 			builder: &controllertest.Builder{
 				KubeObjects:        []runtime.Object{},
 				CertManagerObjects: []runtime.Object{baseCRDenied.DeepCopy(), baseIssuer.DeepCopy()},
-				ExpectedEvents:     []string{},
+				ExpectedEvents:     []string{"Warning Denied The CertificateRequest was denied by an approval controller"},
 				ExpectedActions: []controllertest.Action{
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
@@ -300,12 +566,46 @@ func TestSign(t *testing.T) {
 				},
 			},
 		},
-		"tpp: if fail to build client based on missing secret then return nil and hard fail": {
+		"tpp: if fail to build client based on missing secret then return nil, set pending and requeue": {
+			certificateRequest: tppCR.DeepCopy(),
+			builder: &controllertest.Builder{
+				CertManagerObjects: []runtime.Object{tppCR.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Normal SecretMissing Required secret resource not found, the request will be retried in 1s: secret "test-tpp-secret" not found`,
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            `Required secret resource not found, the request will be retried in 1s: secret "test-tpp-secret" not found`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+		},
+		"tpp: if fail to build client based on incomplete credentials in secret then return nil and set pending": {
 			certificateRequest: tppCR.DeepCopy(),
 			builder: &controllertest.Builder{
+				KubeObjects: []runtime.Object{&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-tpp-secret",
+						Namespace: gen.DefaultTestNamespace,
+					},
+					Data: map[string][]byte{
+						"username": []byte("test-username"),
+					},
+				}},
 				CertManagerObjects: []runtime.Object{tppCR.DeepCopy(), tppIssuer.DeepCopy()},
 				ExpectedEvents: []string{
-					`Normal SecretMissing Required secret resource not found: secret "test-tpp-secret" not found`,
+					`Normal InvalidCredentials Required secret resource does not contain valid Venafi credentials, the request will be retried in 1s: invalid Venafi credentials in secret "test-tpp-secret": both the "username" and "password" keys must be set, but the secret only has the keys "username"`,
 				},
 				ExpectedActions: []controllertest.Action{
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
@@ -317,7 +617,7 @@ func TestSign(t *testing.T) {
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
 								Reason:             cmapi.CertificateRequestReasonPending,
-								Message:            `Required secret resource not found: secret "test-tpp-secret" not found`,
+								Message:            `Required secret resource does not contain valid Venafi credentials, the request will be retried in 1s: invalid Venafi credentials in secret "test-tpp-secret": both the "username" and "password" keys must be set, but the secret only has the keys "username"`,
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
@@ -352,12 +652,12 @@ func TestSign(t *testing.T) {
 			fakeSecretLister: failGetSecretLister,
 			expectedErr:      true,
 		},
-		"cloud: if fail to build client based on missing secret then return nil and hard fail": {
+		"cloud: if fail to build client based on missing secret then return nil, set pending and requeue": {
 			certificateRequest: cloudCR.DeepCopy(),
 			builder: &controllertest.Builder{
 				CertManagerObjects: []runtime.Object{cloudCR.DeepCopy(), cloudIssuer.DeepCopy()},
 				ExpectedEvents: []string{
-					`Normal SecretMissing Required secret resource not found: secret "test-cloud-secret" not found`,
+					`Normal SecretMissing Required secret resource not found, the request will be retried in 1s: secret "test-cloud-secret" not found`,
 				},
 				ExpectedActions: []controllertest.Action{
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
@@ -369,7 +669,7 @@ func TestSign(t *testing.T) {
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
 								Reason:             cmapi.CertificateRequestReasonPending,
-								Message:            `Required secret resource not found: secret "test-cloud-secret" not found`,
+								Message:            `Required secret resource not found, the request will be retried in 1s: secret "test-cloud-secret" not found`,
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
@@ -439,8 +739,8 @@ func TestSign(t *testing.T) {
 				KubeObjects:        []runtime.Object{tppSecret},
 				CertManagerObjects: []runtime.Object{cloudCR.DeepCopy(), tppIssuer.DeepCopy()},
 				ExpectedEvents: []string{
-					"Normal IssuancePending Venafi certificate is requested",
-					"Normal IssuancePending Venafi certificate still in a pending state, the request will be retried: Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
+					"Normal IssuancePending Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+					"Normal IssuancePending Venafi certificate still in a pending state, the request will be retried in 5s: Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
 				},
 				ExpectedActions: []controllertest.Action{
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
@@ -452,10 +752,57 @@ func TestSign(t *testing.T) {
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
 								Reason:             cmapi.CertificateRequestReasonPending,
-								Message:            "Venafi certificate is requested",
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(cloudCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(cloudCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate still in a pending state, the request will be retried in 5s: Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
 								LastTransitionTime: &metaFixedClockStart,
 							}),
-							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+								cmapi.VenafiRetryCountAnnotationKey:     "1",
+								cmapi.VenafiNextRetryTimeAnnotationKey:  fixedClockStart.Add(time.Second * 5).UTC().Format(time.RFC3339),
+							}),
 						),
 					)),
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
@@ -467,17 +814,23 @@ func TestSign(t *testing.T) {
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
 								Reason:             cmapi.CertificateRequestReasonPending,
-								Message:            "Venafi certificate still in a pending state, the request will be retried: Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
+								Message:            "Venafi certificate still in a pending state, the request will be retried in 5s: Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
 								LastTransitionTime: &metaFixedClockStart,
 							}),
-							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+								cmapi.VenafiRetryCountAnnotationKey:     "1",
+								cmapi.VenafiNextRetryTimeAnnotationKey:  fixedClockStart.Add(time.Second * 5).UTC().Format(time.RFC3339),
+							}),
 						),
 					)),
 				},
 			},
 			fakeSecretLister: failGetSecretLister,
 			fakeClient:       clientReturnsPending,
-			expectedErr:      true,
 		},
 		"cloud: if sign returns pending error then set pending and return err": {
 			certificateRequest: cloudCR.DeepCopy(),
@@ -485,8 +838,8 @@ func TestSign(t *testing.T) {
 				KubeObjects:        []runtime.Object{cloudSecret},
 				CertManagerObjects: []runtime.Object{cloudCR.DeepCopy(), cloudIssuer.DeepCopy()},
 				ExpectedEvents: []string{
-					"Normal IssuancePending Venafi certificate is requested",
-					"Normal IssuancePending Venafi certificate still in a pending state, the request will be retried: Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
+					"Normal IssuancePending Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+					"Normal IssuancePending Venafi certificate still in a pending state, the request will be retried in 5s: Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
 				},
 				ExpectedActions: []controllertest.Action{
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
@@ -498,10 +851,57 @@ func TestSign(t *testing.T) {
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
 								Reason:             cmapi.CertificateRequestReasonPending,
-								Message:            "Venafi certificate is requested",
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "cloud-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeCloud,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(cloudCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "cloud-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeCloud,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(cloudCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate still in a pending state, the request will be retried in 5s: Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
 								LastTransitionTime: &metaFixedClockStart,
 							}),
-							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "cloud-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeCloud,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+								cmapi.VenafiRetryCountAnnotationKey:     "1",
+								cmapi.VenafiNextRetryTimeAnnotationKey:  fixedClockStart.Add(time.Second * 5).UTC().Format(time.RFC3339),
+							}),
 						),
 					)),
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
@@ -513,17 +913,23 @@ func TestSign(t *testing.T) {
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
 								Reason:             cmapi.CertificateRequestReasonPending,
-								Message:            "Venafi certificate still in a pending state, the request will be retried: Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
+								Message:            "Venafi certificate still in a pending state, the request will be retried in 5s: Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
 								LastTransitionTime: &metaFixedClockStart,
 							}),
-							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "cloud-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeCloud,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+								cmapi.VenafiRetryCountAnnotationKey:     "1",
+								cmapi.VenafiNextRetryTimeAnnotationKey:  fixedClockStart.Add(time.Second * 5).UTC().Format(time.RFC3339),
+							}),
 						),
 					)),
 				},
 			},
 			fakeSecretLister: failGetSecretLister,
 			fakeClient:       clientReturnsPending,
-			expectedErr:      true,
 		},
 		"tpp: if sign returns generic error then set pending and return error": {
 			certificateRequest: tppCR.DeepCopy(),
@@ -556,25 +962,25 @@ func TestSign(t *testing.T) {
 			expectedErr:        true,
 			skipSecondSignCall: false,
 		},
-		"cloud: if sign returns generic error then set pending and return error": {
-			certificateRequest: cloudCR.DeepCopy(),
+		"tpp: if the key is not allowed by the zone policy then fail with PolicyViolation": {
+			certificateRequest: tppCR.DeepCopy(),
 			builder: &controllertest.Builder{
-				KubeObjects:        []runtime.Object{cloudSecret},
-				CertManagerObjects: []runtime.Object{tppCR.DeepCopy(), cloudIssuer.DeepCopy()},
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCR.DeepCopy(), tppIssuer.DeepCopy()},
 				ExpectedEvents: []string{
-					"Warning RequestError Failed to request venafi certificate: this is an error",
+					"Warning PolicyViolation The key algorithm of the request is not allowed by the Venafi zone policy, set spec.privateKey of the Certificate to RSA with a size of 2048 or 4096: the Venafi zone does not allow ECDSA P521 keys, allowed keys are: RSA (2048, 4096)",
 				},
 				ExpectedActions: []controllertest.Action{
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
 						"status",
 						gen.DefaultTestNamespace,
-						gen.CertificateRequestFrom(cloudCR,
+						gen.CertificateRequestFrom(tppCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
 								Reason:             cmapi.CertificateRequestReasonFailed,
-								Message:            "Failed to request venafi certificate: this is an error",
+								Message:            "The key algorithm of the request is not allowed by the Venafi zone policy, set spec.privateKey of the Certificate to RSA with a size of 2048 or 4096: the Venafi zone does not allow ECDSA P521 keys, allowed keys are: RSA (2048, 4096)",
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
@@ -583,35 +989,50 @@ func TestSign(t *testing.T) {
 				},
 			},
 			fakeSecretLister:   failGetSecretLister,
-			fakeClient:         clientReturnsGenericError,
-			expectedErr:        true,
-			skipSecondSignCall: false,
+			fakeClient:         clientReturnsKeyPolicyViolation,
+			expectedErr:        false,
+			skipSecondSignCall: true,
 		},
-		"tpp: if sign returns cert then return cert and not failed": {
+		"tpp: if a URI SAN is not allowed by the zone policy then fail with PolicyViolation": {
 			certificateRequest: tppCR.DeepCopy(),
 			builder: &controllertest.Builder{
 				KubeObjects:        []runtime.Object{tppSecret},
 				CertManagerObjects: []runtime.Object{tppCR.DeepCopy(), tppIssuer.DeepCopy()},
 				ExpectedEvents: []string{
-					"Normal IssuancePending Venafi certificate is requested",
-					"Normal CertificateIssued Certificate fetched from issuer successfully",
+					`Warning PolicyViolation The URI SANs of the request are not allowed by the Venafi zone policy: the Venafi zone does not allow URI SANs, but "spiffe://example.org/app" was requested`,
 				},
 				ExpectedActions: []controllertest.Action{
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
-						"",
+						"status",
 						gen.DefaultTestNamespace,
 						gen.CertificateRequestFrom(tppCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
-								Reason:             cmapi.CertificateRequestReasonPending,
-								Message:            "Venafi certificate is requested",
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `The URI SANs of the request are not allowed by the Venafi zone policy: the Venafi zone does not allow URI SANs, but "spiffe://example.org/app" was requested`,
 								LastTransitionTime: &metaFixedClockStart,
 							}),
-							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
 					)),
+				},
+			},
+			fakeSecretLister:   failGetSecretLister,
+			fakeClient:         clientReturnsURISANPolicyViolation,
+			expectedErr:        false,
+			skipSecondSignCall: true,
+		},
+		"tpp: if the signature hash algorithm is not allowed by the zone policy then fail with UnsupportedSignatureAlgorithm": {
+			certificateRequest: tppCR.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCR.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Warning UnsupportedSignatureAlgorithm The requested signature hash algorithm is not allowed by the Venafi zone policy: the Venafi zone signs certificates with the SHA-256 hash algorithm, but SHA-384 was requested`,
+				},
+				ExpectedActions: []controllertest.Action{
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
 						"status",
@@ -619,28 +1040,211 @@ func TestSign(t *testing.T) {
 						gen.CertificateRequestFrom(tppCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
-								Status:             cmmeta.ConditionTrue,
-								Reason:             cmapi.CertificateRequestReasonIssued,
-								Message:            "Certificate fetched from issuer successfully",
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `The requested signature hash algorithm is not allowed by the Venafi zone policy: the Venafi zone signs certificates with the SHA-256 hash algorithm, but SHA-384 was requested`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+			fakeSecretLister:   failGetSecretLister,
+			fakeClient:         clientReturnsSignatureHashPolicyViolation,
+			expectedErr:        false,
+			skipSecondSignCall: true,
+		},
+		"tpp: if a wildcard name is not allowed by the zone policy then fail with PolicyViolation": {
+			certificateRequest: tppCR.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCR.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Warning PolicyViolation The wildcard names of the request are not allowed by the Venafi zone policy: the Venafi zone does not allow wildcard names, but "*.example.com" was requested`,
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `The wildcard names of the request are not allowed by the Venafi zone policy: the Venafi zone does not allow wildcard names, but "*.example.com" was requested`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+			fakeSecretLister:   failGetSecretLister,
+			fakeClient:         clientReturnsWildcardPolicyViolation,
+			expectedErr:        false,
+			skipSecondSignCall: true,
+		},
+		"tpp: if an extension is not allowed by the issuer then fail with PolicyViolation": {
+			certificateRequest: tppCR.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCR.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Warning PolicyViolation The extensions of the request are not allowed by the Venafi zone policy: the Venafi zone does not allow the extension "1.2.3.4", allowed extensions are: 1.2.3.5`,
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `The extensions of the request are not allowed by the Venafi zone policy: the Venafi zone does not allow the extension "1.2.3.4", allowed extensions are: 1.2.3.5`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+			fakeSecretLister:   failGetSecretLister,
+			fakeClient:         clientReturnsExtensionPolicyViolation,
+			expectedErr:        false,
+			skipSecondSignCall: true,
+		},
+		"tpp: if a subject default of the issuer is not allowed by the zone then fail with PolicyViolation": {
+			certificateRequest: tppCR.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCR.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Warning PolicyViolation The subject defaults of the issuer are not allowed by the Venafi zone policy: the issuer defaults the organization to "Example Inc.", which is not allowed by the Venafi zone, allowed values must match one of: ^Venafi$`,
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `The subject defaults of the issuer are not allowed by the Venafi zone policy: the issuer defaults the organization to "Example Inc.", which is not allowed by the Venafi zone, allowed values must match one of: ^Venafi$`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+			fakeSecretLister:   failGetSecretLister,
+			fakeClient:         clientReturnsSubjectDefaultsPolicyViolation,
+			expectedErr:        false,
+			skipSecondSignCall: true,
+		},
+		"tpp: if the venafi platform does not respond in time then set pending and return error": {
+			certificateRequest: tppCR.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCR.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal Timeout Timed out requesting venafi certificate, the request will be retried: the Venafi platform did not respond within 10ms",
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Timed out requesting venafi certificate, the request will be retried: the Venafi platform did not respond within 10ms",
 								LastTransitionTime: &metaFixedClockStart,
 							}),
-							gen.SetCertificateRequestCertificate(certPEM),
-							gen.SetCertificateRequestCA(rootPEM),
-							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
 						),
 					)),
 				},
 			},
 			fakeSecretLister: failGetSecretLister,
-			fakeClient:       clientReturnsCert,
+			fakeClient:       clientHangs,
+			requestTimeout:   10 * time.Millisecond,
+			expectedErr:      true,
 		},
-		"cloud: if sign returns cert then return cert and not failed": {
+		"cloud: if sign returns generic error then set pending and return error": {
+			certificateRequest: cloudCR.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{cloudSecret},
+				CertManagerObjects: []runtime.Object{tppCR.DeepCopy(), cloudIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Warning RequestError Failed to request venafi certificate: this is an error",
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(cloudCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            "Failed to request venafi certificate: this is an error",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+			fakeSecretLister:   failGetSecretLister,
+			fakeClient:         clientReturnsGenericError,
+			expectedErr:        true,
+			skipSecondSignCall: false,
+		},
+		"cloud: if the credentials are rejected then keep the request pending without retrying": {
 			certificateRequest: cloudCR.DeepCopy(),
 			builder: &controllertest.Builder{
 				KubeObjects:        []runtime.Object{cloudSecret},
 				CertManagerObjects: []runtime.Object{cloudCR.DeepCopy(), cloudIssuer.DeepCopy()},
 				ExpectedEvents: []string{
-					`Normal IssuancePending Venafi certificate is requested`,
+					"Normal AuthenticationError Venafi rejected the issuer credentials, the request will be retried once the credentials referenced by the issuer change: vcert error: server error: unauthorized or expired access credentials",
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(cloudCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi rejected the issuer credentials, the request will be retried once the credentials referenced by the issuer change: vcert error: server error: unauthorized or expired access credentials",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsUnauthorized,
+			expectedErr:      false,
+		},
+		"tpp: if sign returns cert then return cert and not failed": {
+			certificateRequest: tppCR.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCR.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal IssuancePending Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
 					"Normal CertificateIssued Certificate fetched from issuer successfully",
 				},
 				ExpectedActions: []controllertest.Action{
@@ -648,22 +1252,47 @@ func TestSign(t *testing.T) {
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
 						"",
 						gen.DefaultTestNamespace,
-						gen.CertificateRequestFrom(cloudCR,
+						gen.CertificateRequestFrom(tppCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
 								Reason:             cmapi.CertificateRequestReasonPending,
-								Message:            "Venafi certificate is requested",
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
 								LastTransitionTime: &metaFixedClockStart,
 							}),
-							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
 						),
 					)),
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
 						"status",
 						gen.DefaultTestNamespace,
-						gen.CertificateRequestFrom(cloudCR,
+						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCR,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionTrue,
@@ -672,8 +1301,15 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 							gen.SetCertificateRequestCertificate(certPEM),
+							gen.SetCertificateRequestChainLength(1),
+							gen.SetCertificateRequestSerialNumberOf(certPEM),
 							gen.SetCertificateRequestCA(rootPEM),
-							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
 						),
 					)),
 				},
@@ -681,12 +1317,13 @@ func TestSign(t *testing.T) {
 			fakeSecretLister: failGetSecretLister,
 			fakeClient:       clientReturnsCert,
 		},
-		"annotations: Custom Fields": {
-			certificateRequest: tppCRWithCustomFields.DeepCopy(),
+		"tpp: if a friendly name is requested then request it and return cert": {
+			certificateRequest: tppCRWithFriendlyName.DeepCopy(),
 			builder: &controllertest.Builder{
-				CertManagerObjects: []runtime.Object{tppCRWithCustomFields.DeepCopy(), tppIssuer.DeepCopy()},
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCRWithFriendlyName.DeepCopy(), tppIssuer.DeepCopy()},
 				ExpectedEvents: []string{
-					"Normal IssuancePending Venafi certificate is requested",
+					"Normal IssuancePending Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
 					"Normal CertificateIssued Certificate fetched from issuer successfully",
 				},
 				ExpectedActions: []controllertest.Action{
@@ -694,22 +1331,47 @@ func TestSign(t *testing.T) {
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
 						"",
 						gen.DefaultTestNamespace,
-						gen.CertificateRequestFrom(tppCRWithCustomFields,
+						gen.CertificateRequestFrom(tppCRWithFriendlyName,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
 								Reason:             cmapi.CertificateRequestReasonPending,
-								Message:            "Venafi certificate is requested",
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
 								LastTransitionTime: &metaFixedClockStart,
 							}),
-							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
 						),
 					)),
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
 						"status",
 						gen.DefaultTestNamespace,
-						gen.CertificateRequestFrom(tppCRWithCustomFields,
+						gen.CertificateRequestFrom(tppCRWithFriendlyName,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithFriendlyName,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionTrue,
@@ -718,34 +1380,41 @@ func TestSign(t *testing.T) {
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 							gen.SetCertificateRequestCertificate(certPEM),
+							gen.SetCertificateRequestChainLength(1),
+							gen.SetCertificateRequestSerialNumberOf(certPEM),
 							gen.SetCertificateRequestCA(rootPEM),
-							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
 						),
 					)),
 				},
 			},
 			fakeSecretLister: failGetSecretLister,
-			fakeClient:       clientReturnsCertIfCustomField,
-			expectedErr:      false,
+			fakeClient:       clientReturnsCertIfFriendlyName,
 		},
-		"annotations: Error on invalid JSON in custom fields": {
-			certificateRequest: tppCRWithInvalidCustomFields.DeepCopy(),
+		"tpp: if the requested friendly name is too long then fail with InvalidFriendlyName": {
+			certificateRequest: tppCRWithLongFriendlyName.DeepCopy(),
 			builder: &controllertest.Builder{
-				CertManagerObjects: []runtime.Object{tppCRWithInvalidCustomFields.DeepCopy(), tppIssuer.DeepCopy()},
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCRWithLongFriendlyName.DeepCopy(), tppIssuer.DeepCopy()},
 				ExpectedEvents: []string{
-					`Warning CustomFieldsError Failed to parse "venafi.cert-manager.io/custom-fields" annotation: invalid character 'c' looking for beginning of value`,
+					`Warning InvalidFriendlyName Invalid "venafi.cert-manager.io/friendly-name" annotation: friendly name must be no more than 255 characters, got 256`,
 				},
 				ExpectedActions: []controllertest.Action{
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
 						"status",
 						gen.DefaultTestNamespace,
-						gen.CertificateRequestFrom(tppCRWithInvalidCustomFields,
+						gen.CertificateRequestFrom(tppCRWithLongFriendlyName,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
 								Reason:             cmapi.CertificateRequestReasonFailed,
-								Message:            "Failed to parse \"venafi.cert-manager.io/custom-fields\" annotation: invalid character 'c' looking for beginning of value",
+								Message:            `Invalid "venafi.cert-manager.io/friendly-name" annotation: friendly name must be no more than 255 characters, got 256`,
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
@@ -754,28 +1423,28 @@ func TestSign(t *testing.T) {
 				},
 			},
 			fakeSecretLister:   failGetSecretLister,
-			fakeClient:         clientReturnsPending,
+			fakeClient:         clientReturnsCert,
 			skipSecondSignCall: true,
-			expectedErr:        false,
 		},
-		"annotations: Error on invalid type in custom fields": {
-			certificateRequest: tppCRWithInvalidCustomFieldType.DeepCopy(),
+		"cloud: if the requested workload is invalid then fail with InvalidLocation": {
+			certificateRequest: cloudCRWithInvalidWorkload.DeepCopy(),
 			builder: &controllertest.Builder{
-				CertManagerObjects: []runtime.Object{tppCRWithInvalidCustomFieldType.DeepCopy(), tppIssuer.DeepCopy()},
+				KubeObjects:        []runtime.Object{cloudSecret},
+				CertManagerObjects: []runtime.Object{cloudCRWithInvalidWorkload.DeepCopy(), cloudIssuer.DeepCopy()},
 				ExpectedEvents: []string{
-					`Warning CustomFieldsError certificate request contains an invalid Venafi custom fields type: "Bool": certificate request contains an invalid Venafi custom fields type: "Bool"`,
+					`Warning InvalidLocation Invalid "venafi.cert-manager.io/instance" or "venafi.cert-manager.io/workload" annotation: invalid workload: must not contain control characters`,
 				},
 				ExpectedActions: []controllertest.Action{
 					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
 						"status",
 						gen.DefaultTestNamespace,
-						gen.CertificateRequestFrom(tppCRWithInvalidCustomFieldType,
+						gen.CertificateRequestFrom(cloudCRWithInvalidWorkload,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
 								Reason:             cmapi.CertificateRequestReasonFailed,
-								Message:            "certificate request contains an invalid Venafi custom fields type: \"Bool\": certificate request contains an invalid Venafi custom fields type: \"Bool\"",
+								Message:            `Invalid "venafi.cert-manager.io/instance" or "venafi.cert-manager.io/workload" annotation: invalid workload: must not contain control characters`,
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
@@ -783,14 +1452,1104 @@ func TestSign(t *testing.T) {
 					)),
 				},
 			},
-			fakeSecretLister: failGetSecretLister,
-			fakeClient:       clientReturnsInvalidCustomFieldType,
-			expectedErr:      false,
+			fakeSecretLister:   failGetSecretLister,
+			fakeClient:         clientReturnsCert,
+			skipSecondSignCall: true,
 		},
-	}
-
-	for name, test := range tests {
-		t.Run(name, func(t *testing.T) {
+		"tpp: if a workload is requested then fail with InvalidLocation": {
+			certificateRequest: tppCRWithWorkload.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCRWithWorkload.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Warning InvalidLocation Invalid "venafi.cert-manager.io/instance" or "venafi.cert-manager.io/workload" annotation: only supported by Venafi Cloud issuers`,
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithWorkload,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `Invalid "venafi.cert-manager.io/instance" or "venafi.cert-manager.io/workload" annotation: only supported by Venafi Cloud issuers`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+			fakeSecretLister:   failGetSecretLister,
+			fakeClient:         clientReturnsCert,
+			skipSecondSignCall: true,
+		},
+		"tpp: if the device path is invalid then fail with InvalidLocation": {
+			certificateRequest: tppCRWithInvalidDevicePath.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCRWithInvalidDevicePath.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Warning InvalidLocation Invalid "venafi.cert-manager.io/tpp-device-path" annotation: must be of the form [folder\]device\application, got "web-1"`,
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithInvalidDevicePath,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `Invalid "venafi.cert-manager.io/tpp-device-path" annotation: must be of the form [folder\]device\application, got "web-1"`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+			fakeSecretLister:   failGetSecretLister,
+			fakeClient:         clientReturnsCert,
+			skipSecondSignCall: true,
+		},
+		"cloud: if a device path is requested then fail with InvalidLocation": {
+			certificateRequest: cloudCRWithDevicePath.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{cloudSecret},
+				CertManagerObjects: []runtime.Object{cloudCRWithDevicePath.DeepCopy(), cloudIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Warning InvalidLocation Invalid "venafi.cert-manager.io/tpp-device-path" annotation: only supported by Venafi TPP issuers`,
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(cloudCRWithDevicePath,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `Invalid "venafi.cert-manager.io/tpp-device-path" annotation: only supported by Venafi TPP issuers`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+			fakeSecretLister:   failGetSecretLister,
+			fakeClient:         clientReturnsCert,
+			skipSecondSignCall: true,
+		},
+		"tpp: if the request contains domains which the issuer does not allow then fail with DomainNotAllowed": {
+			certificateRequest: tppCR.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCR.DeepCopy(), tppAllowedDomainsIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Warning DomainNotAllowed Requested domains are not allowed: test-common-name not matched by the allowed domains of the issuer`,
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `Requested domains are not allowed: test-common-name not matched by the allowed domains of the issuer`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+			fakeSecretLister:   failGetSecretLister,
+			fakeClient:         clientReturnsCert,
+			skipSecondSignCall: true,
+		},
+		"tpp: if the private key generated by Venafi does not match the issued certificate then fail with ErrorKeyMatch": {
+			certificateRequest: tppCR.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCR.DeepCopy(), tppServiceGeneratedKeysIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal IssuancePending Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+					"Warning ErrorKeyMatch Private key generated by Venafi does not match the issued certificate: the public key of the certificate does not match the private key",
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            "Private key generated by Venafi does not match the issued certificate: the public key of the certificate does not match the private key",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsCertAndOtherKey,
+		},
+		"tpp: if the returned chain lacks intermediates then complete it from the chain bundle of the issuer": {
+			certificateRequest: tppCR.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret, chainBundleSecret},
+				CertManagerObjects: []runtime.Object{tppCR.DeepCopy(), tppChainBundleIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal IssuancePending Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestCertificate(append(append([]byte{}, intermediateSignedCertPEM...), intermediatePEM...)),
+							gen.SetCertificateRequestChainLength(2),
+							gen.SetCertificateRequestSerialNumberOf(append(append([]byte{}, intermediateSignedCertPEM...), intermediatePEM...)),
+							gen.SetCertificateRequestCA(rootPEM),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsCertWithoutIntermediate,
+		},
+		"tpp: if the returned chain cannot be completed from the chain bundle of the issuer then fail with IncompleteChain": {
+			certificateRequest: tppCR.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret, rootOnlyChainBundleSecret},
+				CertManagerObjects: []runtime.Object{tppCR.DeepCopy(), tppChainBundleIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal IssuancePending Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+					"Warning IncompleteChain Returned certificate chain is incomplete and could not be verified against the chain bundle of the issuer: x509: certificate signed by unknown authority",
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            "Returned certificate chain is incomplete and could not be verified against the chain bundle of the issuer: x509: certificate signed by unknown authority",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsCertWithoutIntermediate,
+		},
+		"tpp: if an isCA request is issued a leaf certificate then fail with NotAllowedCA": {
+			certificateRequest: tppCRWithIsCA.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCRWithIsCA.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal IssuancePending Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+					"Warning NotAllowedCA Venafi zone does not permit issuing CA certificates, check the zone policy or remove isCA from the request: the issued certificate is not a CA certificate",
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithIsCA,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithIsCA,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithIsCA,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            "Venafi zone does not permit issuing CA certificates, check the zone policy or remove isCA from the request: the issued certificate is not a CA certificate",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsCert,
+		},
+		"tpp: if the zone is known not to permit CA certificates then fail an isCA request with NotAllowedCA before enrolling it": {
+			certificateRequest: tppCRWithIsCA.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCRWithIsCA.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Warning NotAllowedCA Venafi zone does not permit issuing CA certificates, check the zone policy or remove isCA from the request: zone "tpp-zone" issued a leaf certificate for a previous CA request`,
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithIsCA,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `Venafi zone does not permit issuing CA certificates, check the zone policy or remove isCA from the request: zone "tpp-zone" issued a leaf certificate for a previous CA request`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient: &internalvenafifake.Venafi{
+				RequestCertificateFn: func([]byte, time.Duration, string, *api.Location, crypto.Hash, []api.CustomField) (string, error) {
+					t.Error("expected the request not to be enrolled")
+					return "", errors.New("unexpected call")
+				},
+			},
+			setup: func(v *Venafi) {
+				v.caZones = newCAZonePolicies(fixedClock, time.Minute)
+				v.caZones.recordDenied(tppIssuer)
+			},
+		},
+		"tpp: if the issued certificate does not permit the requested usages then fail with UsagesNotPermitted": {
+			certificateRequest: tppCRWithClientAuth.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCRWithClientAuth.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal IssuancePending Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+					"Warning UsagesNotPermitted Venafi zone does not permit the requested usages, check the zone policy or change the usages of the request: the issued certificate does not permit the requested usages [client auth], it only permits [server auth]",
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithClientAuth,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithClientAuth,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithClientAuth,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            "Venafi zone does not permit the requested usages, check the zone policy or change the usages of the request: the issued certificate does not permit the requested usages [client auth], it only permits [server auth]",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsServerAuthCert,
+		},
+		"tpp: if a notAfter time is requested then request the remaining duration and return cert": {
+			certificateRequest: tppCRWithNotAfter.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCRWithNotAfter.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal IssuancePending Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithNotAfter,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithNotAfter,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithNotAfter,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestCertificate(notAfterCertPEM),
+							gen.SetCertificateRequestChainLength(1),
+							gen.SetCertificateRequestSerialNumberOf(notAfterCertPEM),
+							gen.SetCertificateRequestCA(rootPEM),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsCertIfNotAfterDuration,
+		},
+		"tpp: if the issued certificate does not expire at the requested notAfter time then fail with NotAfterNotHonored": {
+			certificateRequest: tppCRWithNotAfter.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCRWithNotAfter.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal IssuancePending Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+					fmt.Sprintf("Warning NotAfterNotHonored Venafi zone did not honor the requested notAfter time, check the zone policy or remove notAfter from the request: the issued certificate expires at %s instead of the requested notAfter time %s",
+						template.NotAfter.UTC().Format(time.RFC3339), fixedClockStart.Add(time.Hour).UTC().Format(time.RFC3339)),
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithNotAfter,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithNotAfter,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithNotAfter,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:   cmapi.CertificateRequestConditionReady,
+								Status: cmmeta.ConditionFalse,
+								Reason: cmapi.CertificateRequestReasonFailed,
+								Message: fmt.Sprintf("Venafi zone did not honor the requested notAfter time, check the zone policy or remove notAfter from the request: the issued certificate expires at %s instead of the requested notAfter time %s",
+									template.NotAfter.UTC().Format(time.RFC3339), fixedClockStart.Add(time.Hour).UTC().Format(time.RFC3339)),
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsCert,
+		},
+		"cloud: if sign returns cert then return cert and not failed": {
+			certificateRequest: cloudCR.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{cloudSecret},
+				CertManagerObjects: []runtime.Object{cloudCR.DeepCopy(), cloudIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Normal IssuancePending Venafi certificate is requested with pickup ID "test" for CN "test-common-name" and SANs foo.example.com, bar.example.com`,
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(cloudCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "cloud-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeCloud,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(cloudCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "cloud-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeCloud,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(cloudCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestCertificate(certPEM),
+							gen.SetCertificateRequestChainLength(1),
+							gen.SetCertificateRequestSerialNumberOf(certPEM),
+							gen.SetCertificateRequestCA(rootPEM),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "cloud-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeCloud,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsCert,
+		},
+		"annotations: Custom Fields": {
+			certificateRequest: tppCRWithCustomFields.DeepCopy(),
+			builder: &controllertest.Builder{
+				CertManagerObjects: []runtime.Object{tppCRWithCustomFields.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal IssuancePending Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithCustomFields,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithCustomFields,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested with pickup ID \"test\" for CN \"test-common-name\" and SANs foo.example.com, bar.example.com",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithCustomFields,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestCertificate(certPEM),
+							gen.SetCertificateRequestChainLength(1),
+							gen.SetCertificateRequestSerialNumberOf(certPEM),
+							gen.SetCertificateRequestCA(rootPEM),
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.VenafiPickupIDAnnotationKey:       "test",
+								cmapi.VenafiZoneAnnotationKey:           "tpp-zone",
+								cmapi.VenafiConnectorTypeAnnotationKey:  cmapi.VenafiConnectorTypeTPP,
+								cmapi.VenafiEnrollmentHashAnnotationKey: testEnrollmentHash,
+							}),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsCertIfCustomField,
+			expectedErr:      false,
+		},
+		"annotations: Error on invalid JSON in custom fields": {
+			certificateRequest: tppCRWithInvalidCustomFields.DeepCopy(),
+			builder: &controllertest.Builder{
+				CertManagerObjects: []runtime.Object{tppCRWithInvalidCustomFields.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Warning CustomFieldsError Failed to parse "venafi.cert-manager.io/custom-fields" annotation: invalid character 'c' looking for beginning of value`,
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithInvalidCustomFields,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            "Failed to parse \"venafi.cert-manager.io/custom-fields\" annotation: invalid character 'c' looking for beginning of value",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+			fakeSecretLister:   failGetSecretLister,
+			fakeClient:         clientReturnsPending,
+			skipSecondSignCall: true,
+			expectedErr:        false,
+		},
+		"annotations: Error on invalid type in custom fields": {
+			certificateRequest: tppCRWithInvalidCustomFieldType.DeepCopy(),
+			builder: &controllertest.Builder{
+				CertManagerObjects: []runtime.Object{tppCRWithInvalidCustomFieldType.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Warning CustomFieldsError certificate request contains an invalid Venafi custom fields type: "Bool": certificate request contains an invalid Venafi custom fields type: "Bool"`,
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithInvalidCustomFieldType,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            "certificate request contains an invalid Venafi custom fields type: \"Bool\": certificate request contains an invalid Venafi custom fields type: \"Bool\"",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsInvalidCustomFieldType,
+			expectedErr:      false,
+		},
+		"annotations: Dry run validates the request without requesting a certificate": {
+			certificateRequest: tppCRWithDryRun.DeepCopy(),
+			builder: &controllertest.Builder{
+				CertManagerObjects: []runtime.Object{tppCRWithDryRun.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal DryRunValidated Certificate request would be accepted by the Venafi zone, no certificate was requested as this was a dry run",
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithDryRun,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            "Certificate request would be accepted by the Venafi zone, no certificate was requested as this was a dry run",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientValidatesDryRun,
+			expectedErr:      false,
+		},
+		"annotations: Dry run fails if the request does not match the zone policy": {
+			certificateRequest: tppCRWithDryRun.DeepCopy(),
+			builder: &controllertest.Builder{
+				CertManagerObjects: []runtime.Object{tppCRWithDryRun.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Warning DryRunFailed Venafi dry run validation failed: common name does not match zone policy",
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithDryRun,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            "Venafi dry run validation failed: common name does not match zone policy",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientFailsDryRun,
+			expectedErr:      false,
+		},
+		"annotations: Zone override is used to build the client": {
+			certificateRequest: cloudCRWithZoneOverride.DeepCopy(),
+			builder: &controllertest.Builder{
+				CertManagerObjects: []runtime.Object{cloudCRWithZoneOverride.DeepCopy(), cloudIssuerWithZoneOverrides.DeepCopy()},
+				ExpectedEvents: []string{
+					`Warning InvalidZone Venafi zone "short-lived" from the "venafi.cert-manager.io/zone-override" annotation was not found: vcert error: your data contains problems: zone not found`,
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(cloudCRWithZoneOverride,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `Venafi zone "short-lived" from the "venafi.cert-manager.io/zone-override" annotation was not found: vcert error: your data contains problems: zone not found`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsZoneNotFound,
+			expectedZone:     "short-lived",
+			expectedErr:      false,
+		},
+		"annotations: Error on a zone override which is not allowed by the issuer": {
+			certificateRequest: cloudCRWithDisallowedZoneOverride.DeepCopy(),
+			builder: &controllertest.Builder{
+				CertManagerObjects: []runtime.Object{cloudCRWithDisallowedZoneOverride.DeepCopy(), cloudIssuerWithZoneOverrides.DeepCopy()},
+				ExpectedEvents: []string{
+					`Warning InvalidZone Invalid "venafi.cert-manager.io/zone-override" annotation: zone "other-zone" is not in the allowedZoneOverrides of the issuer`,
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(cloudCRWithDisallowedZoneOverride,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `Invalid "venafi.cert-manager.io/zone-override" annotation: zone "other-zone" is not in the allowedZoneOverrides of the issuer`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsZoneNotFound,
+			expectedErr:      false,
+		},
+		"annotations: Error on a zone override if the issuer does not allow any": {
+			certificateRequest: cloudCRWithZoneOverride.DeepCopy(),
+			builder: &controllertest.Builder{
+				CertManagerObjects: []runtime.Object{cloudCRWithZoneOverride.DeepCopy(), cloudIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Warning InvalidZone Invalid "venafi.cert-manager.io/zone-override" annotation: zone "short-lived" is not in the allowedZoneOverrides of the issuer`,
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(cloudCRWithZoneOverride,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `Invalid "venafi.cert-manager.io/zone-override" annotation: zone "short-lived" is not in the allowedZoneOverrides of the issuer`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsZoneNotFound,
+			expectedErr:      false,
+		},
+		"annotations: Error on empty zone override": {
+			certificateRequest: cloudCRWithEmptyZoneOverride.DeepCopy(),
+			builder: &controllertest.Builder{
+				CertManagerObjects: []runtime.Object{cloudCRWithEmptyZoneOverride.DeepCopy(), cloudIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Warning InvalidZone Invalid "venafi.cert-manager.io/zone-override" annotation: zone must not be empty`,
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(cloudCRWithEmptyZoneOverride,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `Invalid "venafi.cert-manager.io/zone-override" annotation: zone must not be empty`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsZoneNotFound,
+			expectedErr:      false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
 			fixedClock.SetTime(fixedClockStart)
 			test.builder.Clock = fixedClock
 			runTest(t, test)
@@ -798,61 +2557,933 @@ func TestSign(t *testing.T) {
 	}
 }
 
-type testT struct {
-	builder            *controllertest.Builder
-	certificateRequest *cmapi.CertificateRequest
+type testT struct {
+	builder            *controllertest.Builder
+	certificateRequest *cmapi.CertificateRequest
+
+	fakeClient *internalvenafifake.Venafi
+
+	expectedErr bool
+
+	skipSecondSignCall bool
+
+	// expectedZone is the Venafi zone the client is expected to be built with.
+	expectedZone string
+
+	// requestTimeout is the timeout for each call to the Venafi platform.
+	requestTimeout time.Duration
+
+	// setup, if set, is called with the Venafi issuer before the sync.
+	setup func(v *Venafi)
+
+	fakeSecretLister *testlisters.FakeSecretLister
+}
+
+func runTest(t *testing.T, test testT) {
+	test.builder.T = t
+	test.builder.InitWithRESTConfig()
+	defer test.builder.Stop()
+
+	v := NewVenafi(test.builder.Context).(*Venafi)
+	v.requestTimeout = test.requestTimeout
+	if test.setup != nil {
+		test.setup(v)
+	}
+
+	if test.fakeSecretLister != nil {
+		v.credentialsResolver = client.NewSecretCredentialsResolver(test.fakeSecretLister)
+	}
+
+	if test.fakeClient != nil {
+		v.clientBuilder = func(namespace string, secretsLister client.CredentialsResolver,
+			issuer cmapi.GenericIssuer, _ *metrics.Metrics, _ logr.Logger, _ string) (client.Interface, error) {
+			if test.expectedZone != "" && issuer.GetSpec().Venafi.Zone != test.expectedZone {
+				t.Errorf("expected client to be built with zone %q, got %q", test.expectedZone, issuer.GetSpec().Venafi.Zone)
+			}
+			return test.fakeClient, nil
+		}
+	}
+
+	controller := certificaterequests.New(
+		apiutil.IssuerVenafi,
+		func(*controllerpkg.Context) certificaterequests.Issuer { return v },
+	)
+	if _, _, err := controller.Register(test.builder.Context); err != nil {
+		t.Fatal(err)
+	}
+	test.builder.Start()
+
+	// Deep copy the certificate request to prevent pulling condition state across tests
+	err := controller.Sync(context.Background(), test.certificateRequest)
+
+	if err == nil && test.fakeClient != nil && (test.fakeClient.RetrieveCertificateFn != nil || test.fakeClient.RetrieveCertificateAndKeyFn != nil) && !test.skipSecondSignCall {
+		// request state is ok! simulating a 2nd sync to fetch the cert
+		metav1.SetMetaDataAnnotation(&test.certificateRequest.ObjectMeta, cmapi.VenafiPickupIDAnnotationKey, "test")
+		issuerObj, getErr := test.builder.SharedInformerFactory.Certmanager().V1().Issuers().Lister().
+			Issuers(test.certificateRequest.Namespace).Get(test.certificateRequest.Spec.IssuerRef.Name)
+		if getErr != nil {
+			t.Fatal(getErr)
+		}
+		setEnrollmentAnnotations(test.certificateRequest, issuerObj)
+		err = controller.Sync(context.Background(), test.certificateRequest)
+	}
+
+	if err != nil && !test.expectedErr {
+		t.Errorf("expected to not get an error, but got: %v", err)
+	}
+	if err == nil && test.expectedErr {
+		t.Errorf("expected to get an error but did not get one")
+	}
+
+	test.builder.CheckAndFinish(err)
+}
+
+func TestSignDeniedRequest(t *testing.T) {
+	cr := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:   cmapi.CertificateRequestConditionDenied,
+			Status: cmmeta.ConditionTrue,
+			Reason: "Foo",
+		}),
+	)
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "tpp-zone", TPP: &cmapi.VenafiTPP{}}))
+
+	recorder := new(controllertest.FakeRecorder)
+	v := &Venafi{
+		reporter: crutil.NewReporter(fixedClock, recorder, 0),
+		clientBuilder: func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+			t.Error("expected no Venafi client to be built for a denied request")
+			return nil, errors.New("unexpected call")
+		},
+		clock:   fixedClock,
+		limiter: newSigningLimiter(0),
+	}
+
+	resp, err := v.Sign(context.Background(), cr, issuer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp != nil {
+		t.Errorf("expected no response for a denied request, got %v", resp)
+	}
+
+	if reason := apiutil.CertificateRequestReadyReason(cr); reason != cmapi.CertificateRequestReasonDenied {
+		t.Errorf("expected Ready reason %q, got %q", cmapi.CertificateRequestReasonDenied, reason)
+	}
+
+	expectedEvents := []string{"Warning Denied The CertificateRequest was denied by an approval controller"}
+	if !reflect.DeepEqual(recorder.Events, expectedEvents) {
+		t.Errorf("expected events %v, got %v", expectedEvents, recorder.Events)
+	}
+}
+
+func TestSignWithoutRecorder(t *testing.T) {
+	cr := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestAnnotations(map[string]string{cmapi.VenafiZoneOverrideAnnotationKey: " "}),
+	)
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "tpp-zone", TPP: &cmapi.VenafiTPP{}}))
+
+	v := &Venafi{
+		reporter: crutil.NewReporter(fixedClock, nil, 0),
+		clock:    fixedClock,
+		limiter:  newSigningLimiter(0),
+	}
+
+	resp, err := v.Sign(context.Background(), cr, issuer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp != nil {
+		t.Errorf("expected no response for an invalid request, got %v", resp)
+	}
+
+	if reason := apiutil.CertificateRequestReadyReason(cr); reason != cmapi.CertificateRequestReasonFailed {
+		t.Errorf("expected Ready reason %q, got %q", cmapi.CertificateRequestReasonFailed, reason)
+	}
+}
+
+func TestSignDebugLogging(t *testing.T) {
+	for name, test := range map[string]struct {
+		annotations     map[string]string
+		expectDebugLogs bool
+	}{
+		"debug messages are not logged by default": {},
+		"debug messages are logged if debug logging is enabled for the issuer": {
+			annotations:     map[string]string{cmapi.VenafiDebugLoggingAnnotationKey: "true"},
+			expectDebugLogs: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cr := gen.CertificateRequest("test-cr",
+				gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+					Type:   cmapi.CertificateRequestConditionDenied,
+					Status: cmmeta.ConditionTrue,
+					Reason: "Foo",
+				}),
+			)
+			issuer := gen.Issuer("test-issuer",
+				gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "tpp-zone", TPP: &cmapi.VenafiTPP{}}),
+				gen.SetIssuerAnnotations(test.annotations),
+			)
+
+			var messages []string
+			log := funcr.New(func(_, args string) {
+				messages = append(messages, args)
+			}, funcr.Options{Verbosity: logf.InfoLevel})
+
+			v := &Venafi{
+				reporter: crutil.NewReporter(fixedClock, nil, 0),
+				clock:    fixedClock,
+				limiter:  newSigningLimiter(0),
+			}
+
+			if _, err := v.Sign(logr.NewContext(context.Background(), log), cr, issuer); err != nil {
+				t.Fatal(err)
+			}
+
+			if logged := len(messages) > 0; logged != test.expectDebugLogs {
+				t.Errorf("expected debug messages to be logged: %t, got %v", test.expectDebugLogs, messages)
+			}
+		})
+	}
+}
+
+func TestSignRecordsCredentials(t *testing.T) {
+	testPK, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+		Zone:                    "tpp-zone",
+		TPP:                     &cmapi.VenafiTPP{CredentialsRef: cmmeta.LocalObjectReference{Name: "primary"}},
+		FallbackCredentialsRefs: []cmapi.VenafiCredentialsReference{{Name: "backup"}},
+	}))
+	cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestCSR(generateCSR(t, testPK)))
+
+	v := &Venafi{
+		reporter: crutil.NewReporter(fixedClock, new(controllertest.FakeRecorder), 0),
+		clientBuilder: func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+			return &internalvenafifake.Venafi{
+				RequestCertificateFn: func([]byte, time.Duration, string, *api.Location, crypto.Hash, []api.CustomField) (string, error) {
+					return "test-pickup-id", nil
+				},
+				CredentialsNameFn: func() string {
+					return "backup"
+				},
+			}, nil
+		},
+		clock:                fixedClock,
+		limiter:              newSigningLimiter(0),
+		missingSecretRetries: newMissingSecretRetries(fixedClock),
+		retrieveFailures:     newRetrieveFailures(fixedClock, 0),
+		enrollments:          newPendingEnrollments(fixedClock),
+	}
+
+	if _, err := v.Sign(context.Background(), cr, issuer); err != nil {
+		t.Fatal(err)
+	}
+
+	if credentials := cr.Annotations[cmapi.VenafiCredentialsAnnotationKey]; credentials != "backup" {
+		t.Errorf("expected the credentials %q to be recorded, got %q", "backup", credentials)
+	}
+}
+
+func TestSignRequestsTPPDevicePath(t *testing.T) {
+	testPK, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+		Zone: "tpp-zone",
+		TPP:  &cmapi.VenafiTPP{},
+	}))
+	cr := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestCSR(generateCSR(t, testPK)),
+		gen.SetCertificateRequestAnnotations(map[string]string{
+			cmapi.VenafiTPPDevicePathAnnotationKey: `\VED\Policy\Devices\web-1\nginx`,
+		}),
+	)
+
+	var requestedLocation *api.Location
+	v := &Venafi{
+		reporter: crutil.NewReporter(fixedClock, new(controllertest.FakeRecorder), 0),
+		clientBuilder: func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+			return &internalvenafifake.Venafi{
+				RequestCertificateFn: func(_ []byte, _ time.Duration, _ string, location *api.Location, _ crypto.Hash, _ []api.CustomField) (string, error) {
+					requestedLocation = location
+					return "test-pickup-id", nil
+				},
+			}, nil
+		},
+		clock:                fixedClock,
+		limiter:              newSigningLimiter(0),
+		missingSecretRetries: newMissingSecretRetries(fixedClock),
+		retrieveFailures:     newRetrieveFailures(fixedClock, 0),
+		enrollments:          newPendingEnrollments(fixedClock),
+	}
+
+	if _, err := v.Sign(context.Background(), cr, issuer); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &api.Location{Folder: `\VED\Policy\Devices`, Instance: "web-1", Workload: "nginx"}
+	if !reflect.DeepEqual(requestedLocation, expected) {
+		t.Errorf("expected the location %+v to be requested, got %+v", expected, requestedLocation)
+	}
+}
+
+func TestSignFailClosed(t *testing.T) {
+	testPK, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestCSR(generateCSR(t, testPK)))
+
+	policyErr := client.ZonePolicyUnavailableError{Err: errors.New("service unavailable")}
+	requestFails := func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+		return &internalvenafifake.Venafi{
+			RequestCertificateFn: func([]byte, time.Duration, string, *api.Location, crypto.Hash, []api.CustomField) (string, error) {
+				return "", policyErr
+			},
+		}, nil
+	}
+	initFails := func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+		return nil, errors.New("connection refused")
+	}
+
+	tests := map[string]struct {
+		failClosed    bool
+		clientBuilder client.VenafiClientBuilder
+
+		expectedOutcome signOutcome
+		expectedReason  crutil.Reason
+	}{
+		"if the zone policy is unavailable then fail the request by default": {
+			clientBuilder:   requestFails,
+			expectedOutcome: signOutcomeFailed,
+			expectedReason:  crutil.ReasonRequestError,
+		},
+		"if the zone policy is unavailable then keep the request pending when failing closed": {
+			failClosed:      true,
+			clientBuilder:   requestFails,
+			expectedOutcome: signOutcomePending,
+			expectedReason:  crutil.ReasonPolicyUnavailable,
+		},
+		"if the client cannot be initialised then report the policy as unavailable when failing closed": {
+			failClosed:      true,
+			clientBuilder:   initFails,
+			expectedOutcome: signOutcomePending,
+			expectedReason:  crutil.ReasonPolicyUnavailable,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+				Zone:       "tpp-zone",
+				TPP:        &cmapi.VenafiTPP{},
+				FailClosed: test.failClosed,
+			}))
+			v := &Venafi{
+				reporter:             crutil.NewReporter(fixedClock, new(controllertest.FakeRecorder), 0),
+				clientBuilder:        test.clientBuilder,
+				clock:                fixedClock,
+				limiter:              newSigningLimiter(0),
+				missingSecretRetries: newMissingSecretRetries(fixedClock),
+				retrieveFailures:     newRetrieveFailures(fixedClock, 0),
+				enrollments:          newPendingEnrollments(fixedClock),
+			}
+
+			result, err := v.signWithResult(context.Background(), cr.DeepCopy(), issuer)
+			if err == nil {
+				t.Error("expected an error to be returned so that the request is retried")
+			}
+			if result.outcome != test.expectedOutcome || result.reason != test.expectedReason {
+				t.Errorf("expected outcome %s with reason %s, got %s with reason %s", test.expectedOutcome, test.expectedReason, result.outcome, result.reason)
+			}
+		})
+	}
+}
+
+func TestSignClassifiesErrors(t *testing.T) {
+	testPK, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestCSR(generateCSR(t, testPK)))
+	pendingCR := gen.CertificateRequestFrom(cr, gen.SetCertificateRequestAnnotations(map[string]string{
+		cmapi.VenafiPickupIDAnnotationKey: "test-pickup-id",
+	}))
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+		Zone: "tpp-zone",
+		TPP:  &cmapi.VenafiTPP{},
+	}))
 
-	fakeClient *internalvenafifake.Venafi
+	policyErr := errors.New("the policy does not allow the requested key size")
+	rateLimitErr := errors.New("429 Too Many Requests")
+	unknownErr := errors.New("this is an error")
 
-	expectedErr bool
+	tests := map[string]struct {
+		cr          *cmapi.CertificateRequest
+		requestErr  error
+		retrieveErr error
 
-	skipSecondSignCall bool
+		expectedOutcome signOutcome
+		expectedReason  crutil.Reason
+		expectedErr     bool
+	}{
+		"if requesting fails with a policy error then fail the request": {
+			cr:              cr,
+			requestErr:      policyErr,
+			expectedOutcome: signOutcomeFailed,
+			expectedReason:  crutil.ReasonPolicyViolation,
+		},
+		"if requesting is rate limited then keep the request pending and retry": {
+			cr:              cr,
+			requestErr:      rateLimitErr,
+			expectedOutcome: signOutcomePending,
+			expectedReason:  crutil.ReasonRateLimited,
+			expectedErr:     true,
+		},
+		"if requesting fails with an unknown error then fail the request": {
+			cr:              cr,
+			requestErr:      unknownErr,
+			expectedOutcome: signOutcomeFailed,
+			expectedReason:  crutil.ReasonRequestError,
+			expectedErr:     true,
+		},
+		"if retrieving fails with a policy error then fail the request": {
+			cr:              pendingCR,
+			retrieveErr:     policyErr,
+			expectedOutcome: signOutcomeFailed,
+			expectedReason:  crutil.ReasonPolicyViolation,
+		},
+		"if retrieving is rate limited then keep the request pending": {
+			cr:              pendingCR,
+			retrieveErr:     rateLimitErr,
+			expectedOutcome: signOutcomePending,
+			expectedReason:  crutil.ReasonRateLimited,
+		},
+		"if retrieving fails with an unknown error then keep the request pending": {
+			cr:              pendingCR,
+			retrieveErr:     unknownErr,
+			expectedOutcome: signOutcomePending,
+			expectedReason:  crutil.ReasonRetrieveError,
+		},
+	}
 
-	fakeSecretLister *testlisters.FakeSecretLister
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := &Venafi{
+				reporter: crutil.NewReporter(fixedClock, new(controllertest.FakeRecorder), 0),
+				clientBuilder: func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+					return &internalvenafifake.Venafi{
+						RequestCertificateFn: func([]byte, time.Duration, string, *api.Location, crypto.Hash, []api.CustomField) (string, error) {
+							return "", test.requestErr
+						},
+						RetrieveCertificateFn: func(string, []byte, []api.CustomField) ([]byte, error) {
+							return nil, test.retrieveErr
+						},
+					}, nil
+				},
+				clock:                fixedClock,
+				limiter:              newSigningLimiter(0),
+				missingSecretRetries: newMissingSecretRetries(fixedClock),
+				retrieveFailures:     newRetrieveFailures(fixedClock, time.Hour),
+				enrollments:          newPendingEnrollments(fixedClock),
+			}
+
+			result, err := v.signWithResult(context.Background(), test.cr.DeepCopy(), issuer)
+			if (err != nil) != test.expectedErr {
+				t.Errorf("expected error %t, got %v", test.expectedErr, err)
+			}
+			if result.outcome != test.expectedOutcome || result.reason != test.expectedReason {
+				t.Errorf("expected outcome %s with reason %s, got %s with reason %s", test.expectedOutcome, test.expectedReason, result.outcome, result.reason)
+			}
+		})
+	}
 }
 
-func runTest(t *testing.T, test testT) {
-	test.builder.T = t
-	test.builder.InitWithRESTConfig()
-	defer test.builder.Stop()
+func TestSignRequestMutators(t *testing.T) {
+	testPK, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cr := gen.CertificateRequest("test-cr", gen.SetCertificateRequestCSR(generateCSR(t, testPK)))
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+		Zone: "tpp-zone",
+		TPP:  &cmapi.VenafiTPP{},
+	}))
 
-	v := NewVenafi(test.builder.Context).(*Venafi)
+	setFriendlyName := controllerpkg.RequestMutatorFunc(func(_ context.Context, cr *cmapi.CertificateRequest, _ cmapi.GenericIssuer) error {
+		metav1.SetMetaDataAnnotation(&cr.ObjectMeta, cmapi.VenafiFriendlyNameAnnotationKey, "friendly-"+cr.Name)
+		return nil
+	})
+	abort := controllerpkg.RequestMutatorFunc(func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer) error {
+		return controllerpkg.NewRequestAbortedError("NamingViolation", "the name of the request does not follow the naming convention")
+	})
+	fail := controllerpkg.RequestMutatorFunc(func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer) error {
+		return errors.New("policy service unavailable")
+	})
 
-	if test.fakeSecretLister != nil {
-		v.secretsLister = test.fakeSecretLister
+	tests := map[string]struct {
+		mutators []controllerpkg.RequestMutator
+
+		expectedOutcome      signOutcome
+		expectedReason       crutil.Reason
+		expectedErr          bool
+		expectedFriendlyName string
+	}{
+		"if there are no mutators then the request is enrolled unchanged": {
+			expectedOutcome: signOutcomePending,
+			expectedReason:  crutil.ReasonIssuancePending,
+		},
+		"if the mutators set annotations then they are used to enroll the request": {
+			mutators:             []controllerpkg.RequestMutator{controllerpkg.NoOpRequestMutator, setFriendlyName},
+			expectedOutcome:      signOutcomePending,
+			expectedReason:       crutil.ReasonIssuancePending,
+			expectedFriendlyName: "friendly-test-cr",
+		},
+		"if a mutator aborts the request then fail it with the reason of the mutator": {
+			mutators:        []controllerpkg.RequestMutator{setFriendlyName, abort},
+			expectedOutcome: signOutcomeFailed,
+			expectedReason:  "NamingViolation",
+		},
+		"if a mutator fails then keep the request pending and retry": {
+			mutators:        []controllerpkg.RequestMutator{fail, setFriendlyName},
+			expectedOutcome: signOutcomePending,
+			expectedReason:  crutil.ReasonRequestMutationError,
+			expectedErr:     true,
+		},
 	}
 
-	if test.fakeClient != nil {
-		v.clientBuilder = func(namespace string, secretsLister internalinformers.SecretLister,
-			issuer cmapi.GenericIssuer, _ *metrics.Metrics, _ logr.Logger, _ string) (client.Interface, error) {
-			return test.fakeClient, nil
-		}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requested bool
+			var requestedFriendlyName string
+			v := &Venafi{
+				reporter: crutil.NewReporter(fixedClock, new(controllertest.FakeRecorder), 0),
+				clientBuilder: func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+					return &internalvenafifake.Venafi{
+						RequestCertificateFn: func(_ []byte, _ time.Duration, friendlyName string, _ *api.Location, _ crypto.Hash, _ []api.CustomField) (string, error) {
+							requested = true
+							requestedFriendlyName = friendlyName
+							return "test-pickup-id", nil
+						},
+					}, nil
+				},
+				requestMutators:      test.mutators,
+				clock:                fixedClock,
+				limiter:              newSigningLimiter(0),
+				missingSecretRetries: newMissingSecretRetries(fixedClock),
+				retrieveFailures:     newRetrieveFailures(fixedClock, 0),
+				enrollments:          newPendingEnrollments(fixedClock),
+			}
+
+			request := cr.DeepCopy()
+			result, err := v.signWithResult(context.Background(), request, issuer)
+			if (err != nil) != test.expectedErr {
+				t.Errorf("expected error %t, got %v", test.expectedErr, err)
+			}
+			if result.outcome != test.expectedOutcome || result.reason != test.expectedReason {
+				t.Errorf("expected outcome %s with reason %s, got %s with reason %s", test.expectedOutcome, test.expectedReason, result.outcome, result.reason)
+			}
+
+			// The request is only enrolled if all the mutators succeeded,
+			// and only then are their changes kept.
+			enrolled := test.expectedReason == crutil.ReasonIssuancePending
+			if requested != enrolled {
+				t.Errorf("expected the request to be enrolled %t, got %t", enrolled, requested)
+			}
+			if requestedFriendlyName != test.expectedFriendlyName {
+				t.Errorf("expected the friendly name %q to be requested, got %q", test.expectedFriendlyName, requestedFriendlyName)
+			}
+			if _, ok := request.Annotations[cmapi.VenafiFriendlyNameAnnotationKey]; ok != (test.expectedFriendlyName != "") {
+				t.Errorf("unexpected annotations on the request: %v", request.Annotations)
+			}
+		})
 	}
+}
 
-	controller := certificaterequests.New(
-		apiutil.IssuerVenafi,
-		func(*controllerpkg.Context) certificaterequests.Issuer { return v },
+func TestSignLegacyExtensions(t *testing.T) {
+	rootPK, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootTmpl := &x509.Certificate{
+		Version:               3,
+		BasicConstraintsValid: true,
+		SerialNumber:          big.NewInt(1),
+		PublicKey:             rootPK.Public(),
+		IsCA:                  true,
+		Subject:               pkix.Name{CommonName: "root-ca"},
+		NotBefore:             fixedClockStart.Add(-time.Hour),
+		NotAfter:              fixedClockStart.Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	rootPEM, rootCert, err := pki.SignCertificate(rootTmpl, rootTmpl, rootPK.Public(), rootPK)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testPK, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cr := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestCSR(generateCSR(t, testPK)),
+		gen.SetCertificateRequestAnnotations(map[string]string{
+			cmapi.VenafiPickupIDAnnotationKey: "test-pickup-id",
+		}),
 	)
-	if _, _, err := controller.Register(test.builder.Context); err != nil {
+
+	issueCert := func(legacyExtensions bool) []byte {
+		template, err := pki.CertificateTemplateFromCertificateRequest(cr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if legacyExtensions {
+			if err := pki.AddNetscapeCertType(template); err != nil {
+				t.Fatal(err)
+			}
+		}
+		certPEM, _, err := pki.SignCertificate(template, rootCert, testPK.Public(), rootPK)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return append(certPEM, rootPEM...)
+	}
+
+	tests := map[string]struct {
+		legacyExtensions bool
+		chainPEM         []byte
+
+		expectedOutcome signOutcome
+		expectedReason  crutil.Reason
+	}{
+		"if legacy extensions are not required then a certificate without them is issued": {
+			chainPEM:        issueCert(false),
+			expectedOutcome: signOutcomeIssued,
+		},
+		"if legacy extensions are required and the zone added them then the certificate is issued": {
+			legacyExtensions: true,
+			chainPEM:         issueCert(true),
+			expectedOutcome:  signOutcomeIssued,
+		},
+		"if legacy extensions are required but the zone did not add them then fail the request": {
+			legacyExtensions: true,
+			chainPEM:         issueCert(false),
+			expectedOutcome:  signOutcomeFailed,
+			expectedReason:   crutil.ReasonLegacyExtensionsNotHonored,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+				Zone:             "tpp-zone",
+				TPP:              &cmapi.VenafiTPP{},
+				LegacyExtensions: test.legacyExtensions,
+			}))
+
+			v := &Venafi{
+				reporter: crutil.NewReporter(fixedClock, new(controllertest.FakeRecorder), 0),
+				clientBuilder: func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+					return &internalvenafifake.Venafi{
+						RetrieveCertificateFn: func(string, []byte, []api.CustomField) ([]byte, error) {
+							return test.chainPEM, nil
+						},
+					}, nil
+				},
+				clock:                fixedClock,
+				limiter:              newSigningLimiter(0),
+				missingSecretRetries: newMissingSecretRetries(fixedClock),
+				retrieveFailures:     newRetrieveFailures(fixedClock, time.Hour),
+				enrollments:          newPendingEnrollments(fixedClock),
+			}
+
+			result, err := v.signWithResult(context.Background(), cr.DeepCopy(), issuer)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if result.outcome != test.expectedOutcome || result.reason != test.expectedReason {
+				t.Errorf("expected outcome %s with reason %s, got %s with reason %s", test.expectedOutcome, test.expectedReason, result.outcome, result.reason)
+			}
+		})
+	}
+}
+
+func TestSignOCSPMustStaple(t *testing.T) {
+	rootPK, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootTmpl := &x509.Certificate{
+		Version:               3,
+		BasicConstraintsValid: true,
+		SerialNumber:          big.NewInt(1),
+		PublicKey:             rootPK.Public(),
+		IsCA:                  true,
+		Subject:               pkix.Name{CommonName: "root-ca"},
+		NotBefore:             fixedClockStart.Add(-time.Hour),
+		NotAfter:              fixedClockStart.Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	rootPEM, rootCert, err := pki.SignCertificate(rootTmpl, rootTmpl, rootPK.Public(), rootPK)
+	if err != nil {
 		t.Fatal(err)
 	}
-	test.builder.Start()
 
-	// Deep copy the certificate request to prevent pulling condition state across tests
-	err := controller.Sync(context.Background(), test.certificateRequest)
+	testPK, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	createCR := func(mustStaple bool, annotations map[string]string) *cmapi.CertificateRequest {
+		csrPEM, err := gen.CSRWithSigner(testPK,
+			gen.SetCSRCommonName("test-common-name"),
+			gen.SetCSRDNSNames("foo.example.com"),
+			func(csr *x509.CertificateRequest) error {
+				if !mustStaple {
+					return nil
+				}
+				ext, err := pki.MarshalOCSPMustStaple()
+				if err != nil {
+					return err
+				}
+				csr.ExtraExtensions = append(csr.ExtraExtensions, ext)
+				return nil
+			},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return gen.CertificateRequest("test-cr",
+			gen.SetCertificateRequestCSR(csrPEM),
+			gen.SetCertificateRequestAnnotations(annotations),
+		)
+	}
+	pickedUp := map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test-pickup-id"}
 
-	if err == nil && test.fakeClient != nil && test.fakeClient.RetrieveCertificateFn != nil && !test.skipSecondSignCall {
-		// request state is ok! simulating a 2nd sync to fetch the cert
-		metav1.SetMetaDataAnnotation(&test.certificateRequest.ObjectMeta, cmapi.VenafiPickupIDAnnotationKey, "test")
-		err = controller.Sync(context.Background(), test.certificateRequest)
+	issueCert := func(cr *cmapi.CertificateRequest, mustStaple bool) []byte {
+		template, err := pki.CertificateTemplateFromCertificateRequest(cr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !mustStaple {
+			var extensions []pkix.Extension
+			for _, ext := range template.ExtraExtensions {
+				if !ext.Id.Equal(pki.OIDExtensionTLSFeature) {
+					extensions = append(extensions, ext)
+				}
+			}
+			template.ExtraExtensions = extensions
+		}
+		certPEM, _, err := pki.SignCertificate(template, rootCert, testPK.Public(), rootPK)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return append(certPEM, rootPEM...)
 	}
 
-	if err != nil && !test.expectedErr {
-		t.Errorf("expected to not get an error, but got: %v", err)
+	tests := map[string]struct {
+		cr         *cmapi.CertificateRequest
+		mustStaple bool
+		requestErr error
+
+		expectedOutcome signOutcome
+		expectedReason  crutil.Reason
+	}{
+		"if OCSP Must-Staple is not requested then a certificate without it is issued": {
+			cr:              createCR(false, pickedUp),
+			expectedOutcome: signOutcomeIssued,
+		},
+		"if OCSP Must-Staple is requested and the zone honored it then the certificate is issued": {
+			cr:              createCR(true, pickedUp),
+			mustStaple:      true,
+			expectedOutcome: signOutcomeIssued,
+		},
+		"if OCSP Must-Staple is requested but the zone dropped it then fail the request": {
+			cr:              createCR(true, pickedUp),
+			expectedOutcome: signOutcomeFailed,
+			expectedReason:  crutil.ReasonMustStapleNotSupported,
+		},
+		"if OCSP Must-Staple is requested but the zone does not allow it then fail the request": {
+			cr:              createCR(true, nil),
+			requestErr:      client.ExtensionPolicyViolationError{OID: pki.OIDExtensionTLSFeature.String(), Allowed: []string{"1.2.3.4"}},
+			expectedOutcome: signOutcomeFailed,
+			expectedReason:  crutil.ReasonMustStapleNotSupported,
+		},
+		"if another extension is not allowed by the zone then fail with PolicyViolation": {
+			cr:              createCR(true, nil),
+			requestErr:      client.ExtensionPolicyViolationError{OID: "1.2.3.5", Allowed: []string{"1.2.3.4"}},
+			expectedOutcome: signOutcomeFailed,
+			expectedReason:  crutil.ReasonPolicyViolation,
+		},
 	}
-	if err == nil && test.expectedErr {
-		t.Errorf("expected to get an error but did not get one")
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+				Zone: "tpp-zone",
+				TPP:  &cmapi.VenafiTPP{},
+			}))
+
+			v := &Venafi{
+				reporter: crutil.NewReporter(fixedClock, new(controllertest.FakeRecorder), 0),
+				clientBuilder: func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+					return &internalvenafifake.Venafi{
+						RequestCertificateFn: func([]byte, time.Duration, string, *api.Location, crypto.Hash, []api.CustomField) (string, error) {
+							return "", test.requestErr
+						},
+						RetrieveCertificateFn: func(string, []byte, []api.CustomField) ([]byte, error) {
+							return issueCert(test.cr, test.mustStaple), nil
+						},
+					}, nil
+				},
+				clock:                fixedClock,
+				limiter:              newSigningLimiter(0),
+				missingSecretRetries: newMissingSecretRetries(fixedClock),
+				retrieveFailures:     newRetrieveFailures(fixedClock, time.Hour),
+				enrollments:          newPendingEnrollments(fixedClock),
+			}
+
+			result, err := v.signWithResult(context.Background(), test.cr.DeepCopy(), issuer)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if result.outcome != test.expectedOutcome || result.reason != test.expectedReason {
+				t.Errorf("expected outcome %s with reason %s, got %s with reason %s", test.expectedOutcome, test.expectedReason, result.outcome, result.reason)
+			}
+		})
 	}
+}
 
-	test.builder.CheckAndFinish(err)
+func TestNewVenafiIssuerOptions(t *testing.T) {
+	builder := &controllertest.Builder{
+		T: t,
+		Context: &controllerpkg.Context{
+			RootContext: context.Background(),
+			RESTConfig:  new(rest.Config),
+			ContextOptions: controllerpkg.ContextOptions{
+				IssuerOptions: controllerpkg.IssuerOptions{
+					ClusterResourceNamespace: "cert-manager",
+					VenafiRequestTimeout:     time.Minute,
+				},
+			},
+		},
+	}
+	builder.Init()
+	defer builder.Stop()
+
+	v := NewVenafi(builder.Context).(*Venafi)
+
+	options := v.IssuerOptions()
+	if options.ClusterResourceNamespace != "cert-manager" || options.VenafiRequestTimeout != time.Minute {
+		t.Errorf("expected the issuer options of the context, got %+v", options)
+	}
+	if ns := options.ResourceNamespace(gen.ClusterIssuer("test-issuer")); ns != "cert-manager" {
+		t.Errorf("expected the resources of ClusterIssuers to be read from %q, got %q", "cert-manager", ns)
+	}
+
+	// The returned options are a copy, so modifying them does not affect
+	// the issuer.
+	options.ClusterResourceNamespace = "other"
+	if ns := v.IssuerOptions().ClusterResourceNamespace; ns != "cert-manager" {
+		t.Errorf("expected the issuer options not to be modified, got cluster resource namespace %q", ns)
+	}
+}
+
+func TestNewVenafiTransport(t *testing.T) {
+	proxyCalled := false
+	transport := &http.Transport{
+		Proxy: func(*http.Request) (*url.URL, error) {
+			proxyCalled = true
+			return nil, errors.New("proxy unavailable")
+		},
+	}
+
+	tppSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-tpp-secret",
+			Namespace: gen.DefaultTestNamespace,
+		},
+		Data: map[string][]byte{
+			"access-token": []byte("test-access-token"),
+		},
+	}
+	tppIssuer := gen.Issuer("test-issuer",
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+			Zone: "tpp-zone",
+			TPP: &cmapi.VenafiTPP{
+				URL: "https://tpp.example.com/vedsdk",
+				CredentialsRef: cmmeta.LocalObjectReference{
+					Name: tppSecret.Name,
+				},
+			},
+		}),
+	)
+
+	builder := &controllertest.Builder{
+		T:           t,
+		KubeObjects: []runtime.Object{tppSecret},
+		Context: &controllerpkg.Context{
+			RootContext: context.Background(),
+			RESTConfig:  new(rest.Config),
+			ContextOptions: controllerpkg.ContextOptions{
+				VenafiTransport: transport,
+			},
+		},
+	}
+	builder.Init()
+	defer builder.Stop()
+
+	v := NewVenafi(builder.Context).(*Venafi)
+	builder.Start()
+
+	// The client may fail to be built if the Venafi platform is contacted
+	// to authenticate, so the request may be sent by either call.
+	vc, err := v.clientBuilder(gen.DefaultTestNamespace, v.credentialsResolver, tppIssuer, nil, logr.Discard(), "cert-manager/v0.0.0")
+	if err == nil {
+		err = vc.Ping()
+	}
+	if err == nil {
+		t.Fatalf("expected the request to fail as the proxy of the transport is unavailable")
+	}
+	if !proxyCalled {
+		t.Errorf("expected the request to be sent using the transport of the controller context, got error: %v", err)
+	}
+}
+
+func TestPriority(t *testing.T) {
+	tests := map[string]struct {
+		annotations map[string]string
+		expected    certificaterequests.Priority
+	}{
+		"without the annotation the request has normal priority": {
+			expected: certificaterequests.PriorityNormal,
+		},
+		"high priority": {
+			annotations: map[string]string{cmapi.VenafiPriorityAnnotationKey: cmapi.VenafiPriorityHigh},
+			expected:    certificaterequests.PriorityHigh,
+		},
+		"low priority": {
+			annotations: map[string]string{cmapi.VenafiPriorityAnnotationKey: cmapi.VenafiPriorityLow},
+			expected:    certificaterequests.PriorityLow,
+		},
+		"an unknown value has normal priority": {
+			annotations: map[string]string{cmapi.VenafiPriorityAnnotationKey: "Urgent"},
+			expected:    certificaterequests.PriorityNormal,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := gen.CertificateRequest("test", gen.SetCertificateRequestAnnotations(test.annotations))
+			if priority := (&Venafi{}).Priority(cr); priority != test.expected {
+				t.Errorf("expected priority %d, got %d", test.expected, priority)
+			}
+		})
+	}
 }
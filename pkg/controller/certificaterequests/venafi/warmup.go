@@ -0,0 +1,173 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/labels"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// issuerWarmUp sets up every Venafi Issuer and ClusterIssuer when the
+// controller starts, before any CertificateRequest is signed, so that a
+// misconfigured issuer is logged immediately after a deploy rather than
+// reported by the first CertificateRequest it signs.
+// The Ready condition of the issuers is owned by the issuers controllers, so
+// the warm up does not write the status of the issuers it sets up.
+// A nil *issuerWarmUp does nothing.
+type issuerWarmUp struct {
+	issuerLister cmlisters.IssuerLister
+	// clusterIssuerLister is nil if cert-manager is scoped to a single
+	// namespace.
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+
+	issuerFactory issuerpkg.Factory
+	namespace     string
+
+	// timeout bounds the whole warm up, so that the controller does not wait
+	// indefinitely for unresponsive Venafi platforms.
+	timeout time.Duration
+}
+
+// newIssuerWarmUp returns the warm up of the Venafi issuers, or nil if it is
+// disabled.
+func newIssuerWarmUp(ctx *controllerpkg.Context) *issuerWarmUp {
+	if ctx.IssuerOptions.VenafiIssuerWarmUpTimeout <= 0 {
+		return nil
+	}
+
+	w := &issuerWarmUp{
+		issuerLister:  ctx.SharedInformerFactory.Certmanager().V1().Issuers().Lister(),
+		issuerFactory: issuerpkg.NewFactory(ctx),
+		namespace:     ctx.Namespace,
+		timeout:       ctx.IssuerOptions.VenafiIssuerWarmUpTimeout,
+	}
+	if ctx.Namespace == "" {
+		w.clusterIssuerLister = ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Lister()
+	}
+
+	return w
+}
+
+// WarmUp sets up the Venafi issuers before the controller starts signing
+// CertificateRequests, if enabled.
+func (v *Venafi) WarmUp(ctx context.Context) {
+	v.warmUp.run(ctx)
+}
+
+func (w *issuerWarmUp) run(ctx context.Context) {
+	if w == nil {
+		return
+	}
+
+	log := logf.FromContext(ctx, "warmUp")
+
+	issuers, err := w.venafiIssuers()
+	if err != nil {
+		log.Error(err, "failed to list the venafi issuers to warm up")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	log.V(logf.InfoLevel).Info("warming up venafi issuers", "issuers", len(issuers), "timeout", w.timeout)
+
+	var ready int
+	for i, issuerObj := range issuers {
+		ok, err := w.setup(ctx, logf.WithResource(log, issuerObj), issuerObj)
+		if err != nil {
+			log.V(logf.WarnLevel).Info("timed out warming up venafi issuers, the remaining issuers will be set up by the issuers controllers", "remaining", len(issuers)-i)
+			return
+		}
+		if ok {
+			ready++
+		}
+	}
+
+	log.V(logf.InfoLevel).Info("warmed up venafi issuers", "ready", ready, "notReady", len(issuers)-ready)
+}
+
+// venafiIssuers returns the Venafi Issuers and ClusterIssuers watched by the
+// controller.
+func (w *issuerWarmUp) venafiIssuers() ([]cmapi.GenericIssuer, error) {
+	var venafiIssuers []cmapi.GenericIssuer
+
+	issuers, err := w.issuerLister.Issuers(w.namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, iss := range issuers {
+		if iss.Spec.Venafi != nil {
+			venafiIssuers = append(venafiIssuers, iss)
+		}
+	}
+
+	if w.clusterIssuerLister == nil {
+		return venafiIssuers, nil
+	}
+
+	clusterIssuers, err := w.clusterIssuerLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, iss := range clusterIssuers {
+		if iss.Spec.Venafi != nil {
+			venafiIssuers = append(venafiIssuers, iss)
+		}
+	}
+
+	return venafiIssuers, nil
+}
+
+// setup sets up a copy of the issuer and logs whether it is ready, which it
+// returns. An error is only returned if the issuer could not be set up in
+// time.
+func (w *issuerWarmUp) setup(ctx context.Context, log logr.Logger, issuerObj cmapi.GenericIssuer) (bool, error) {
+	issuerCopy := issuerObj.DeepCopyObject().(cmapi.GenericIssuer)
+
+	i, err := w.issuerFactory.IssuerFor(issuerCopy)
+	if err != nil {
+		log.Error(err, "failed to build venafi issuer")
+		return false, nil
+	}
+
+	// Setting up the issuer calls the Venafi platform, which does not support
+	// cancellation, so the warm up stops waiting for it once it times out.
+	_, err = callWithTimeout(ctx, 0, func() (struct{}, error) {
+		return struct{}{}, i.Setup(ctx)
+	})
+	if err != nil && ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	if err != nil {
+		log.Error(err, "venafi issuer is not ready")
+		return false, nil
+	}
+
+	log.V(logf.InfoLevel).Info("venafi issuer is ready")
+	return true, nil
+}
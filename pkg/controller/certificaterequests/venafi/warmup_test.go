@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/cache"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
+	issuerfake "github.com/cert-manager/cert-manager/pkg/issuer/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestIssuerWarmUp(t *testing.T) {
+	unblock := make(chan struct{})
+	defer close(unblock)
+
+	venafiSpec := cmapi.VenafiIssuer{Zone: "tpp-zone", TPP: &cmapi.VenafiTPP{}}
+	readyIssuer := gen.Issuer("ready-issuer", gen.SetIssuerNamespace("test-ns"), gen.SetIssuerVenafi(venafiSpec))
+	brokenIssuer := gen.Issuer("broken-issuer", gen.SetIssuerNamespace("test-ns"), gen.SetIssuerVenafi(venafiSpec))
+	caIssuer := gen.Issuer("ca-issuer", gen.SetIssuerNamespace("test-ns"), gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"}))
+	readyClusterIssuer := gen.ClusterIssuer("ready-cluster-issuer", gen.SetIssuerVenafi(venafiSpec))
+	slowClusterIssuer := gen.ClusterIssuer("slow-cluster-issuer", gen.SetIssuerVenafi(venafiSpec))
+
+	newWarmUp := func(timeout time.Duration, issuers []*cmapi.Issuer, clusterIssuers []*cmapi.ClusterIssuer) (*issuerWarmUp, *[]string) {
+		issuerIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		clusterIssuerIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		for _, iss := range issuers {
+			require.NoError(t, issuerIndexer.Add(iss))
+		}
+		for _, iss := range clusterIssuers {
+			require.NoError(t, clusterIssuerIndexer.Add(iss))
+		}
+
+		var setUp []string
+		return &issuerWarmUp{
+			issuerLister:        cmlisters.NewIssuerLister(issuerIndexer),
+			clusterIssuerLister: cmlisters.NewClusterIssuerLister(clusterIssuerIndexer),
+			issuerFactory: &issuerfake.Factory{
+				IssuerForFunc: func(iss cmapi.GenericIssuer) (issuerpkg.Interface, error) {
+					return &issuerfake.Issuer{
+						SetupFunc: func(context.Context) error {
+							setUp = append(setUp, iss.GetName())
+							switch iss.GetName() {
+							case "broken-issuer":
+								apiutil.SetIssuerCondition(iss, iss.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionFalse, "InvalidCredentials", "Failed to setup Venafi issuer")
+								return errors.New("this is an error")
+							case "slow-cluster-issuer":
+								<-unblock
+							}
+							apiutil.SetIssuerCondition(iss, iss.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionTrue, "Reachable", "Venafi issuer started")
+							return nil
+						},
+					}, nil
+				},
+			},
+			timeout: timeout,
+		}, &setUp
+	}
+
+	t.Run("the Venafi issuers are set up without modifying the issuers", func(t *testing.T) {
+		w, setUp := newWarmUp(time.Minute,
+			[]*cmapi.Issuer{readyIssuer, brokenIssuer, caIssuer},
+			[]*cmapi.ClusterIssuer{readyClusterIssuer},
+		)
+
+		w.run(context.Background())
+		assert.ElementsMatch(t, []string{"ready-issuer", "broken-issuer", "ready-cluster-issuer"}, *setUp)
+
+		// The Ready condition is left to the issuers controllers, and the
+		// issuers of the informer cache are not modified.
+		for _, iss := range []cmapi.GenericIssuer{readyIssuer, brokenIssuer, caIssuer, readyClusterIssuer} {
+			assert.Empty(t, iss.GetStatus().Conditions, "expected the status of %s not to be modified", iss.GetName())
+		}
+	})
+
+	t.Run("the warm up stops once it times out", func(t *testing.T) {
+		w, _ := newWarmUp(10*time.Millisecond, nil, []*cmapi.ClusterIssuer{slowClusterIssuer})
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			w.run(context.Background())
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected the warm up to return once it timed out")
+		}
+		assert.Empty(t, slowClusterIssuer.Status.Conditions)
+	})
+
+	t.Run("a nil warm up does nothing", func(t *testing.T) {
+		var w *issuerWarmUp
+		w.run(context.Background())
+	})
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	venaficlient "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// errNoMatchingZone is returned when a request is not accepted by the policy
+// of any of the zones of a Venafi issuer.
+type errNoMatchingZone struct {
+	// zones are the zones which were tried, in order.
+	zones []string
+	// errs are the errors returned by the validation against each zone.
+	errs []error
+}
+
+func (err errNoMatchingZone) Error() string {
+	reasons := make([]string, len(err.zones))
+	for i, zone := range err.zones {
+		reasons[i] = fmt.Sprintf("zone %q: %v", zone, err.errs[i])
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// issuerZones returns the zones of the Venafi issuer, in the order in which
+// requests are validated against them.
+func issuerZones(issuerObj cmapi.GenericIssuer) []string {
+	venCfg := issuerObj.GetSpec().Venafi
+	return append([]string{venCfg.Zone}, venCfg.AdditionalZones...)
+}
+
+// withZone returns a copy of the issuer configured with the given zone.
+func withZone(issuerObj cmapi.GenericIssuer, zone string) cmapi.GenericIssuer {
+	issuerObj = issuerObj.DeepCopyObject().(cmapi.GenericIssuer)
+	issuerObj.GetSpec().Venafi.Zone = zone
+	return issuerObj
+}
+
+// selectZone returns the issuer configured with the zone the
+// CertificateRequest is enrolled in, along with a client for that zone.
+// Requests which have already been enrolled keep the zone they were enrolled
+// in. Otherwise, the zones of the issuer are tried in order, and the first one
+// whose policy accepts the request is selected, so that the selection is
+// deterministic. client is the client for the first zone of the issuer.
+func (v *Venafi) selectZone(ctx context.Context, log logr.Logger, cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer, client venaficlient.Interface, customFields []api.CustomField) (cmapi.GenericIssuer, venaficlient.Interface, error) {
+	zones := issuerZones(issuerObj)
+
+	if cr.GetAnnotations()[cmapi.VenafiPickupIDAnnotationKey] != "" {
+		zone, ok := cr.GetAnnotations()[cmapi.VenafiZoneAnnotationKey]
+		if !ok || zone == zones[0] || !slices.Contains(zones, zone) {
+			return issuerObj, client, nil
+		}
+
+		issuerObj = withZone(issuerObj, zone)
+		client, err := v.buildClient(ctx, log, issuerObj)
+		return issuerObj, client, err
+	}
+
+	noMatch := errNoMatchingZone{}
+	for i, zone := range zones {
+		zoneIssuer, zoneClient := issuerObj, client
+		if i > 0 {
+			var err error
+			zoneIssuer = withZone(issuerObj, zone)
+			zoneClient, err = v.buildClient(ctx, log, zoneIssuer)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		_, err := callWithTimeout(ctx, v.requestTimeout, func() (struct{}, error) {
+			return struct{}{}, zoneClient.ValidateCertificateRequest(cr.Spec.Request, customFields)
+		})
+		if isCallTimeout(err) || venaficlient.IsAuthenticationError(err) {
+			return nil, nil, err
+		}
+
+		// A zone whose policy cannot be read does not reject the request, so
+		// issuers which fail closed wait for it rather than trying the next.
+		if zoneIssuer.GetSpec().Venafi.FailClosed && venaficlient.IsZonePolicyUnavailableError(err) {
+			return nil, nil, err
+		}
+
+		if err == nil {
+			log.V(logf.InfoLevel).Info("selected venafi zone accepting the request", "zone", zone)
+			return zoneIssuer, zoneClient, nil
+		}
+
+		log.V(logf.DebugLevel).Info("venafi zone does not accept the request", "zone", zone, "reason", err.Error())
+		noMatch.zones = append(noMatch.zones, zone)
+		noMatch.errs = append(noMatch.errs, err)
+	}
+
+	return nil, nil, noMatch
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Venafi/vcert/v5/pkg/verror"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
+	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestSelectZone(t *testing.T) {
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+		Zone:            "zone-a",
+		AdditionalZones: []string{"zone-b", "zone-c"},
+		TPP:             &cmapi.VenafiTPP{},
+	}))
+
+	enrolledCR := gen.CertificateRequest("test-cr", gen.SetCertificateRequestAnnotations(map[string]string{
+		cmapi.VenafiPickupIDAnnotationKey: "test",
+		cmapi.VenafiZoneAnnotationKey:     "zone-c",
+	}))
+
+	tests := map[string]struct {
+		cr *cmapi.CertificateRequest
+		// zoneErrs are the errors returned by the validation against each
+		// zone. Zones which are not listed accept the request.
+		zoneErrs map[string]error
+
+		expectedZone        string
+		expectedValidations []string
+		expectedNoMatch     bool
+		expectedErr         error
+	}{
+		"the first zone is selected if it accepts the request": {
+			expectedZone:        "zone-a",
+			expectedValidations: []string{"zone-a"},
+		},
+		"the first zone in order which accepts the request is selected": {
+			zoneErrs:            map[string]error{"zone-a": errors.New("not allowed")},
+			expectedZone:        "zone-b",
+			expectedValidations: []string{"zone-a", "zone-b"},
+		},
+		"fails if no zone accepts the request": {
+			zoneErrs: map[string]error{
+				"zone-a": errors.New("not allowed"),
+				"zone-b": errors.New("not allowed"),
+				"zone-c": errors.New("not allowed"),
+			},
+			expectedValidations: []string{"zone-a", "zone-b", "zone-c"},
+			expectedNoMatch:     true,
+		},
+		"stops on authentication errors": {
+			zoneErrs:            map[string]error{"zone-a": verror.UnauthorizedError},
+			expectedValidations: []string{"zone-a"},
+			expectedErr:         verror.UnauthorizedError,
+		},
+		"enrolled requests keep the zone they were enrolled in": {
+			cr:           enrolledCR,
+			expectedZone: "zone-c",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var validations []string
+			clientFor := func(zone string) client.Interface {
+				return &fake.Venafi{
+					ValidateCertificateFn: func([]byte, []api.CustomField) error {
+						validations = append(validations, zone)
+						return test.zoneErrs[zone]
+					},
+				}
+			}
+
+			v := &Venafi{
+				clientBuilder: func(_ string, _ client.CredentialsResolver, iss cmapi.GenericIssuer, _ *metrics.Metrics, _ logr.Logger, _ string) (client.Interface, error) {
+					return clientFor(iss.GetSpec().Venafi.Zone), nil
+				},
+			}
+
+			cr := test.cr
+			if cr == nil {
+				cr = gen.CertificateRequest("test-cr")
+			}
+
+			zoneIssuer, zoneClient, err := v.selectZone(context.Background(), logr.Discard(), cr, issuer, clientFor("zone-a"), nil)
+			assert.Equal(t, test.expectedValidations, validations)
+			assert.Equal(t, "zone-a", issuer.Spec.Venafi.Zone, "the issuer must not be modified")
+
+			if test.expectedNoMatch {
+				var noMatch errNoMatchingZone
+				require.True(t, errors.As(err, &noMatch), "expected errNoMatchingZone, got %v", err)
+				assert.EqualError(t, err, `zone "zone-a": not allowed; zone "zone-b": not allowed; zone "zone-c": not allowed`)
+				return
+			}
+			if test.expectedErr != nil {
+				assert.True(t, errors.Is(err, test.expectedErr), "expected %v, got %v", test.expectedErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedZone, zoneIssuer.GetSpec().Venafi.Zone)
+
+			// The returned client is the client of the selected zone.
+			validations = nil
+			require.NoError(t, zoneClient.ValidateCertificateRequest(nil, nil))
+			assert.Equal(t, []string{test.expectedZone}, validations)
+		})
+	}
+}
+
+func TestSignNoMatchingZone(t *testing.T) {
+	cr := gen.CertificateRequest("test-cr")
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+		Zone:            "zone-a",
+		AdditionalZones: []string{"zone-b"},
+		TPP:             &cmapi.VenafiTPP{},
+	}))
+
+	recorder := new(controllertest.FakeRecorder)
+	v := &Venafi{
+		reporter: crutil.NewReporter(fixedClock, recorder, 0),
+		clientBuilder: func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+			return &fake.Venafi{
+				ValidateCertificateFn: func([]byte, []api.CustomField) error {
+					return errors.New("not allowed")
+				},
+				RequestCertificateFn: func([]byte, time.Duration, string, *api.Location, crypto.Hash, []api.CustomField) (string, error) {
+					t.Error("expected no certificate to be requested")
+					return "", errors.New("unexpected call")
+				},
+			}, nil
+		},
+		clock:                fixedClock,
+		limiter:              newSigningLimiter(0),
+		missingSecretRetries: newMissingSecretRetries(fixedClock),
+	}
+
+	resp, err := v.Sign(context.Background(), cr, issuer)
+	require.NoError(t, err)
+	assert.Nil(t, resp)
+
+	assert.Equal(t, cmapi.CertificateRequestReasonFailed, apiutil.CertificateRequestReadyReason(cr))
+	assert.Equal(t, []string{
+		`Warning NoMatchingZone The request is not accepted by the policy of any of the Venafi zones of the issuer: zone "zone-a": not allowed; zone "zone-b": not allowed`,
+	}, recorder.Events)
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// caBundleFor returns the CA certificates to store in the `ca.crt` key of
+// the Certificate's Secret, given the CA certificate returned by the issuer.
+// If the Certificate configures a CA bundle, the bundle either replaces the
+// issuer CA or is appended to it, skipping the certificates which the issuer
+// CA already contains so that applying the bundle again is a no-op.
+func (s *SecretsManager) caBundleFor(ctx context.Context, crt *cmapi.Certificate, issuerCA []byte) ([]byte, error) {
+	if crt.Spec.CABundle == nil {
+		return issuerCA, nil
+	}
+
+	bundle, err := s.readCABundle(ctx, crt.Namespace, crt.Spec.CABundle)
+	if err != nil {
+		return nil, err
+	}
+	bundleCerts, err := utilpki.DecodeX509CertificateSetBytes(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA bundle: %w", err)
+	}
+
+	if crt.Spec.CABundle.Mode != cmapi.AppendCABundleMode || len(issuerCA) == 0 {
+		return bundle, nil
+	}
+
+	issuerCerts, err := utilpki.DecodeX509CertificateSetBytes(issuerCA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the CA certificate returned by the issuer: %w", err)
+	}
+
+	out := make([]byte, 0, len(issuerCA)+len(bundle))
+	out = append(out, bytes.TrimRight(issuerCA, "\n")...)
+	out = append(out, '\n')
+	for _, cert := range bundleCerts {
+		if containsCertificate(issuerCerts, cert.Raw) {
+			continue
+		}
+		certPEM, err := utilpki.EncodeX509(cert)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, certPEM...)
+	}
+
+	return out, nil
+}
+
+// readCABundle reads the PEM encoded CA bundle from the Secret or ConfigMap
+// it references, in the namespace of the Certificate.
+func (s *SecretsManager) readCABundle(ctx context.Context, namespace string, bundle *cmapi.CertificateCABundle) ([]byte, error) {
+	switch {
+	case bundle.SecretRef != nil:
+		ref := bundle.SecretRef
+		key := caBundleKey(ref.Key)
+		secret, err := s.secretLister.Secrets(namespace).Get(ref.Name)
+		if err != nil {
+			return nil, fmt.Errorf("fetching CA bundle from Secret: %v", err)
+		}
+		if len(secret.Data[key]) == 0 {
+			return nil, fmt.Errorf("CA bundle Secret contains no data for key %q", key)
+		}
+		return secret.Data[key], nil
+
+	case bundle.ConfigMapRef != nil:
+		ref := bundle.ConfigMapRef
+		key := caBundleKey(ref.Key)
+		configMap, err := s.configMapClient.ConfigMaps(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("fetching CA bundle from ConfigMap: %v", err)
+		}
+		if len(configMap.Data[key]) > 0 {
+			return []byte(configMap.Data[key]), nil
+		}
+		if len(configMap.BinaryData[key]) > 0 {
+			return configMap.BinaryData[key], nil
+		}
+		return nil, fmt.Errorf("CA bundle ConfigMap contains no data for key %q", key)
+
+	default:
+		return nil, fmt.Errorf("CA bundle must reference either a Secret or a ConfigMap")
+	}
+}
+
+func caBundleKey(key string) string {
+	if key == "" {
+		return cmmeta.TLSCAKey
+	}
+	return key
+}
+
+func containsCertificate(certs []*x509.Certificate, raw []byte) bool {
+	for _, cert := range certs {
+		if bytes.Equal(cert.Raw, raw) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	testcorelisters "github.com/cert-manager/cert-manager/test/unit/listers"
+)
+
+func Test_caBundleFor(t *testing.T) {
+	mustCreateCA := func(name string) []byte {
+		return testcrypto.MustCreateCert(t, testcrypto.MustCreatePEMPrivateKey(t),
+			gen.Certificate(name, gen.SetCertificateCommonName(name), gen.SetCertificateIsCA(true)),
+		)
+	}
+	issuerCA := mustCreateCA("issuer-ca")
+	rootA := mustCreateCA("root-a")
+	rootB := mustCreateCA("root-b")
+
+	bundle := append(append([]byte{}, rootA...), rootB...)
+
+	bundleSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: gen.DefaultTestNamespace, Name: "bundle"},
+		Data: map[string][]byte{
+			cmmeta.TLSCAKey: bundle,
+			"invalid":       []byte("not a certificate"),
+		},
+	}
+	bundleConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: gen.DefaultTestNamespace, Name: "bundle"},
+		Data: map[string]string{
+			"roots.pem": string(bundle),
+		},
+	}
+
+	secretRef := func(key string) *cmmeta.SecretKeySelector {
+		return &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "bundle"}, Key: key}
+	}
+	configMapRef := func(name, key string) *cmmeta.ConfigMapKeySelector {
+		return &cmmeta.ConfigMapKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: name}, Key: key}
+	}
+
+	tests := map[string]struct {
+		caBundle *cmapi.CertificateCABundle
+		issuerCA []byte
+		secret   *corev1.Secret
+
+		expectedCerts [][]byte
+		expectedErr   bool
+	}{
+		"if no CA bundle is configured, the issuer CA is stored": {
+			issuerCA:      issuerCA,
+			expectedCerts: [][]byte{issuerCA},
+		},
+		"if the mode is not set, the bundle replaces the issuer CA": {
+			caBundle:      &cmapi.CertificateCABundle{SecretRef: secretRef("")},
+			issuerCA:      issuerCA,
+			secret:        bundleSecret,
+			expectedCerts: [][]byte{rootA, rootB},
+		},
+		"if the mode is Append, the bundle is appended to the issuer CA": {
+			caBundle:      &cmapi.CertificateCABundle{ConfigMapRef: configMapRef("bundle", "roots.pem"), Mode: cmapi.AppendCABundleMode},
+			issuerCA:      issuerCA,
+			expectedCerts: [][]byte{issuerCA, rootA, rootB},
+		},
+		"if the mode is Append, certificates already stored are not appended again": {
+			caBundle:      &cmapi.CertificateCABundle{ConfigMapRef: configMapRef("bundle", "roots.pem"), Mode: cmapi.AppendCABundleMode},
+			issuerCA:      append(append([]byte{}, issuerCA...), rootB...),
+			expectedCerts: [][]byte{issuerCA, rootB, rootA},
+		},
+		"if the mode is Append and the issuer returned no CA, only the bundle is stored": {
+			caBundle:      &cmapi.CertificateCABundle{SecretRef: secretRef(""), Mode: cmapi.AppendCABundleMode},
+			secret:        bundleSecret,
+			expectedCerts: [][]byte{rootA, rootB},
+		},
+		"if the bundle does not parse, fail": {
+			caBundle:    &cmapi.CertificateCABundle{SecretRef: secretRef("invalid")},
+			issuerCA:    issuerCA,
+			secret:      bundleSecret,
+			expectedErr: true,
+		},
+		"if the key of the bundle is missing, fail": {
+			caBundle:    &cmapi.CertificateCABundle{SecretRef: secretRef("missing")},
+			issuerCA:    issuerCA,
+			secret:      bundleSecret,
+			expectedErr: true,
+		},
+		"if the ConfigMap does not exist, fail": {
+			caBundle:    &cmapi.CertificateCABundle{ConfigMapRef: configMapRef("missing", "")},
+			issuerCA:    issuerCA,
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mod := testcorelisters.SetFakeSecretNamespaceListerGet(nil, apierrors.NewNotFound(corev1.Resource("secret"), "not found"))
+			if test.secret != nil {
+				mod = testcorelisters.SetFakeSecretNamespaceListerGet(test.secret, nil)
+			}
+			s := NewSecretsManager(nil, testcorelisters.NewFakeSecretLister(mod), fake.NewSimpleClientset(bundleConfigMap).CoreV1(), "cert-manager-test", false)
+
+			crt := gen.Certificate("test", gen.SetCertificateNamespace(gen.DefaultTestNamespace))
+			crt.Spec.CABundle = test.caBundle
+
+			ca, err := s.caBundleFor(context.Background(), crt, test.issuerCA)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			certs, err := utilpki.DecodeX509CertificateSetBytes(ca)
+			assert.NoError(t, err)
+			var got [][]byte
+			for _, cert := range certs {
+				certPEM, err := utilpki.EncodeX509(cert)
+				assert.NoError(t, err)
+				got = append(got, certPEM)
+			}
+			assert.Equal(t, test.expectedCerts, got)
+
+			// Applying the bundle again to the stored CA must not change it.
+			again, err := s.caBundleFor(context.Background(), crt, ca)
+			assert.NoError(t, err)
+			assert.Equal(t, ca, again)
+		})
+	}
+}
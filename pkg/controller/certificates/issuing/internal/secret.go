@@ -47,6 +47,10 @@ type SecretsManager struct {
 	secretClient coreclient.SecretsGetter
 	secretLister internalinformers.SecretLister
 
+	// configMapClient is used to read the CA bundles referenced by
+	// Certificates from ConfigMaps, which are not cached by the controller.
+	configMapClient coreclient.ConfigMapsGetter
+
 	// fieldManager is the manager name used for the Apply operations on Secrets.
 	fieldManager string
 
@@ -70,12 +74,14 @@ type SecretData struct {
 func NewSecretsManager(
 	secretClient coreclient.SecretsGetter,
 	secretLister internalinformers.SecretLister,
+	configMapClient coreclient.ConfigMapsGetter,
 	fieldManager string,
 	enableSecretOwnerReferences bool,
 ) *SecretsManager {
 	return &SecretsManager{
 		secretClient:                secretClient,
 		secretLister:                secretLister,
+		configMapClient:             configMapClient,
 		fieldManager:                fieldManager,
 		enableSecretOwnerReferences: enableSecretOwnerReferences,
 	}
@@ -94,6 +100,11 @@ func (s *SecretsManager) UpdateData(ctx context.Context, crt *cmapi.Certificate,
 	log := logf.FromContext(ctx).WithName("secrets_manager")
 	log = logf.WithResource(log, secret)
 
+	data.CA, err = s.caBundleFor(ctx, crt, data.CA)
+	if err != nil {
+		return fmt.Errorf("failed to add CA bundle to Secret: %w", err)
+	}
+
 	if err := s.setValues(crt, secret, data); err != nil {
 		return err
 	}
@@ -773,7 +773,7 @@ func Test_SecretsManager(t *testing.T) {
 			secretLister := testcorelisters.NewFakeSecretLister(mod)
 
 			testManager := NewSecretsManager(
-				secretClient, secretLister,
+				secretClient, secretLister, nil,
 				"cert-manager-test",
 				test.certificateOptions.EnableOwnerRef,
 			)
@@ -28,6 +28,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
@@ -71,6 +72,10 @@ type controller struct {
 
 	client cmclient.Interface
 
+	// secretsClient is used to delete the Secrets of the private keys
+	// generated by issuers once they have been stored.
+	secretsClient corev1client.SecretsGetter
+
 	// secretsUpdateData is used by the SecretTemplate controller for
 	// re-reconciling Secrets where the SecretTemplate is not up to date with a
 	// Certificate's secret.
@@ -140,7 +145,7 @@ func NewController(
 	}
 
 	secretsManager := internal.NewSecretsManager(
-		ctx.Client.CoreV1(), secretsInformer.Lister(),
+		ctx.Client.CoreV1(), secretsInformer.Lister(), ctx.Client.CoreV1(),
 		ctx.FieldManager, ctx.CertificateOptions.EnableOwnerRef,
 	)
 
@@ -149,6 +154,7 @@ func NewController(
 		certificateRequestLister: certificateRequestInformer.Lister(),
 		secretLister:             secretsInformer.Lister(),
 		client:                   ctx.CMClient,
+		secretsClient:            ctx.Client.CoreV1(),
 		recorder:                 ctx.Recorder,
 		clock:                    ctx.Clock,
 		secretsUpdateData:        secretsManager.UpdateData,
@@ -336,7 +342,26 @@ func (c *controller) ProcessItem(ctx context.Context, key types.NamespacedName)
 	// If the CertificateRequest is valid and ready, verify its status and issue
 	// accordingly.
 	if crReadyCond.Reason == cmapi.CertificateRequestReasonIssued {
-		return c.issueCertificate(ctx, nextRevision, crt, req, pk)
+		// If the private key was generated by the issuer, it is stored
+		// instead of the next private key, which was only used for the
+		// request.
+		name, issuedKey := req.Annotations[cmapi.CertificateRequestIssuedPrivateKeyAnnotationKey]
+		if issuedKey {
+			pk, err = c.issuedPrivateKey(req, name)
+			if err != nil {
+				return err
+			}
+		}
+		if err := c.issueCertificate(ctx, nextRevision, crt, req, pk); err != nil {
+			return err
+		}
+		// The issued private key has been copied to the Secret of the
+		// Certificate, so the copy kept for the request is deleted rather
+		// than left for as long as the request exists.
+		if issuedKey {
+			return c.deleteIssuedPrivateKey(ctx, req, name)
+		}
+		return nil
 	}
 
 	// Issue temporary certificate if needed. If a certificate was issued, then
@@ -351,6 +376,58 @@ func (c *controller) ProcessItem(ctx context.Context, key types.NamespacedName)
 	return nil
 }
 
+// issuedPrivateKey returns the private key generated by the issuer for the
+// CertificateRequest, which is stored in the Secret with the given name, and
+// verifies that it matches the issued certificate.
+func (c *controller) issuedPrivateKey(req *cmapi.CertificateRequest, secretName string) (crypto.Signer, error) {
+	secret, err := c.secretLister.Secrets(req.Namespace).Get(secretName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the private key issued for the CertificateRequest: %w", err)
+	}
+	pk, _, err := utilkube.ParseTLSKeyFromSecret(secret, corev1.TLSPrivateKeyKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the private key issued for the CertificateRequest: %w", err)
+	}
+
+	cert, err := utilpki.DecodeX509CertificateBytes(req.Status.Certificate)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := utilpki.PublicKeyMatchesCertificate(pk.Public(), cert)
+	if err != nil {
+		return nil, err
+	}
+	if !matches {
+		return nil, fmt.Errorf("private key in Secret %q does not match the issued certificate", secretName)
+	}
+
+	return pk, nil
+}
+
+// deleteIssuedPrivateKey deletes the Secret with the given name in which the
+// private key generated by the issuer for the CertificateRequest was stored.
+// Secrets which are not owned by the CertificateRequest are never deleted.
+func (c *controller) deleteIssuedPrivateKey(ctx context.Context, req *cmapi.CertificateRequest, secretName string) error {
+	secret, err := c.secretLister.Secrets(req.Namespace).Get(secretName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(secret, req) {
+		return nil
+	}
+
+	err = c.secretsClient.Secrets(req.Namespace).Delete(ctx, secretName, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &secret.UID},
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the private key issued for the CertificateRequest: %w", err)
+	}
+	return nil
+}
+
 // failIssueCertificate will mark the Issuing condition of this Certificate as
 // false, set the Certificate's last failure time and issuance attempts, and log
 // an appropriate event. The reason and message of the Issuing condition will be that of
@@ -17,6 +17,7 @@ limitations under the License.
 package keymanager
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"reflect"
@@ -75,7 +76,64 @@ func relaxedSecretMatcher(l coretesting.Action, r coretesting.Action) error {
 	return nil
 }
 
+// privateKeySecretMatcher matches a created Secret like relaxedSecretMatcher,
+// and additionally checks whether its private key is the existing key.
+func privateKeySecretMatcher(existingKey []byte, reused bool) testpkg.ActionMatchFn {
+	return func(l coretesting.Action, r coretesting.Action) error {
+		if err := relaxedSecretMatcher(l, r); err != nil {
+			return err
+		}
+		key := r.(coretesting.CreateAction).GetObject().(*corev1.Secret).Data[corev1.TLSPrivateKeyKey]
+		if len(key) == 0 {
+			return fmt.Errorf("expected the Secret to contain a private key")
+		}
+		if bytes.Equal(key, existingKey) != reused {
+			return fmt.Errorf("expected the existing private key to be reused: %t", reused)
+		}
+		return nil
+	}
+}
+
 func TestProcessItem(t *testing.T) {
+	// existingKey is the private key of the Secret of a Certificate which is
+	// being renewed.
+	existingKey := mustGenerateRSA(t, 2048)
+	existingSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test-tls"},
+		Data:       map[string][]byte{corev1.TLSPrivateKeyKey: existingKey},
+	}
+	renewingCertificate := func(rotationPolicy cmapi.PrivateKeyRotationPolicy) *cmapi.Certificate {
+		return &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+			Spec: cmapi.CertificateSpec{
+				SecretName: "test-tls",
+				PrivateKey: &cmapi.CertificatePrivateKey{RotationPolicy: rotationPolicy},
+			},
+			Status: cmapi.CertificateStatus{
+				Conditions: []cmapi.CertificateCondition{
+					{
+						Type:   cmapi.CertificateConditionIssuing,
+						Status: cmmeta.ConditionTrue,
+					},
+				},
+			},
+		}
+	}
+	renewedCertificate := func(rotationPolicy cmapi.PrivateKeyRotationPolicy) *cmapi.Certificate {
+		crt := renewingCertificate(rotationPolicy)
+		crt.Status.NextPrivateKeySecretName = ptr.To("test-notrandom")
+		return crt
+	}
+	nextPrivateKeySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "testns",
+			GenerateName:    "test-",
+			Labels:          map[string]string{cmapi.IsNextPrivateKeySecretLabelKey: "true", cmapi.PartOfCertManagerControllerLabelKey: "true"},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(&cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"}}, certificateGvk)},
+		},
+		Data: map[string][]byte{"tls.key": nil},
+	}
+
 	ownedSecretWithName := func(namespace, name, owner string, data map[string][]byte) *corev1.Secret {
 		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
 			Namespace: namespace,
@@ -225,6 +283,95 @@ func TestProcessItem(t *testing.T) {
 				), relaxedSecretMatcher),
 			},
 		},
+		"reuse the private key of the existing Secret on renewal if the rotation policy is Never": {
+			certificate:    renewingCertificate(cmapi.RotationPolicyNever),
+			secrets:        []runtime.Object{existingSecret},
+			expectedEvents: []string{`Normal Reused Reusing private key stored in existing Secret resource "test-tls"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					"status",
+					"testns",
+					renewedCertificate(cmapi.RotationPolicyNever),
+				)),
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(
+					corev1.SchemeGroupVersion.WithResource("secrets"),
+					"testns",
+					nextPrivateKeySecret,
+				), privateKeySecretMatcher(existingKey, true)),
+			},
+		},
+		"reuse the private key of the existing Secret on renewal if the rotation policy is unset": {
+			certificate:    renewingCertificate(""),
+			secrets:        []runtime.Object{existingSecret},
+			expectedEvents: []string{`Normal Reused Reusing private key stored in existing Secret resource "test-tls"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					"status",
+					"testns",
+					renewedCertificate(""),
+				)),
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(
+					corev1.SchemeGroupVersion.WithResource("secrets"),
+					"testns",
+					nextPrivateKeySecret,
+				), privateKeySecretMatcher(existingKey, true)),
+			},
+		},
+		"generate a new private key on renewal if the rotation policy is Always": {
+			certificate:    renewingCertificate(cmapi.RotationPolicyAlways),
+			secrets:        []runtime.Object{existingSecret},
+			expectedEvents: []string{`Normal Generated Stored new private key in temporary Secret resource "test-notrandom"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					"status",
+					"testns",
+					renewedCertificate(cmapi.RotationPolicyAlways),
+				)),
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(
+					corev1.SchemeGroupVersion.WithResource("secrets"),
+					"testns",
+					nextPrivateKeySecret,
+				), privateKeySecretMatcher(existingKey, false)),
+			},
+		},
+		"generate a new private key on renewal if the existing key does not match the spec and the rotation policy is Always": {
+			certificate: func() *cmapi.Certificate {
+				crt := renewingCertificate(cmapi.RotationPolicyAlways)
+				crt.Spec.PrivateKey.Algorithm = cmapi.ECDSAKeyAlgorithm
+				return crt
+			}(),
+			secrets:        []runtime.Object{existingSecret},
+			expectedEvents: []string{`Normal Generated Stored new private key in temporary Secret resource "test-notrandom"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					"status",
+					"testns",
+					func() *cmapi.Certificate {
+						crt := renewedCertificate(cmapi.RotationPolicyAlways)
+						crt.Spec.PrivateKey.Algorithm = cmapi.ECDSAKeyAlgorithm
+						return crt
+					}(),
+				)),
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(
+					corev1.SchemeGroupVersion.WithResource("secrets"),
+					"testns",
+					nextPrivateKeySecret,
+				), privateKeySecretMatcher(existingKey, false)),
+			},
+		},
+		"do not generate a new private key on renewal if the existing key does not match the spec and the rotation policy is Never": {
+			certificate: func() *cmapi.Certificate {
+				crt := renewingCertificate(cmapi.RotationPolicyNever)
+				crt.Spec.PrivateKey.Algorithm = cmapi.ECDSAKeyAlgorithm
+				return crt
+			}(),
+			secrets:        []runtime.Object{existingSecret},
+			expectedEvents: []string{`Warning CannotRegenerateKey User intervention required: existing private key in Secret "test-tls" does not match requirements on Certificate resource, mismatching fields: [spec.privateKey.algorithm], but cert-manager cannot create new private key as the Certificate's .spec.privateKey.rotationPolicy is unset or set to Never. To allow cert-manager to create a new private key you can set .spec.privateKey.rotationPolicy to 'Always' (this will result in the private key being regenerated every time a cert is renewed) `},
+		},
 		// TODO: in this case we should adapt the controller behaviour to unset the nextPrivateKeySecretName to
 		//  gracefully recover
 		"error if an existing Secret exists and is named as status.nextPrivateKeySecretName but it is not owned by the Certificate": {
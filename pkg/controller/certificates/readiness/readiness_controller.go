@@ -19,6 +19,7 @@ package readiness
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -28,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
@@ -52,6 +54,18 @@ const (
 	ControllerName = "certificates-readiness"
 	// ReadyReason is the 'Ready' reason of a Certificate.
 	ReadyReason = "Ready"
+	// DurationTruncatedReason is the 'DurationTruncated' reason of a
+	// Certificate.
+	DurationTruncatedReason = "ShorterThanRequested"
+	// RenewalScheduledReason is the reason of the event sent when the renewal
+	// time of a Certificate is computed for a newly issued certificate.
+	RenewalScheduledReason = "RenewalScheduled"
+
+	// durationTruncatedTolerance is how much shorter than the requested
+	// duration an issued certificate may be before it is considered to have
+	// been truncated. Some issuers subtract a small amount from the requested
+	// duration, which should not be reported.
+	durationTruncatedTolerance = time.Minute
 )
 
 type controller struct {
@@ -61,6 +75,7 @@ type controller struct {
 	certificateRequestLister cmlisters.CertificateRequestLister
 	secretLister             internalinformers.SecretLister
 	client                   cmclient.Interface
+	recorder                 record.EventRecorder
 	gatherer                 *policies.Gatherer
 	// policyEvaluator builds Ready condition of a Certificate based on policy evaluation
 	policyEvaluator policyEvaluatorFunc
@@ -130,6 +145,7 @@ func NewController(
 		certificateRequestLister: certificateRequestInformer.Lister(),
 		secretLister:             secretsInformer.Lister(),
 		client:                   ctx.CMClient,
+		recorder:                 ctx.Recorder,
 		gatherer: &policies.Gatherer{
 			CertificateRequestLister: certificateRequestInformer.Lister(),
 			SecretLister:             secretsInformer.Lister(),
@@ -176,6 +192,7 @@ func (c *controller) ProcessItem(ctx context.Context, key types.NamespacedName)
 			crt.Status.NotAfter = nil
 			crt.Status.NotBefore = nil
 			crt.Status.RenewalTime = nil
+			removeDurationTruncatedCondition(crt)
 			break
 		}
 
@@ -188,21 +205,47 @@ func (c *controller) ProcessItem(ctx context.Context, key types.NamespacedName)
 		crt.Status.NotAfter = &notAfter
 		crt.Status.RenewalTime = renewalTime
 
+		setDurationTruncatedCondition(crt, x509cert.NotBefore, x509cert.NotAfter)
+
 	default:
 		// clear status fields if the secret does not have any data
 		crt.Status.NotAfter = nil
 		crt.Status.NotBefore = nil
 		crt.Status.RenewalTime = nil
+		removeDurationTruncatedCondition(crt)
 	}
 	if !apiequality.Semantic.DeepEqual(oldCrt.Status, crt.Status) {
 		log.V(logf.DebugLevel).Info("updating status fields", "notAfter",
 			crt.Status.NotAfter, "notBefore", crt.Status.NotBefore, "renewalTime",
 			crt.Status.RenewalTime)
-		return c.updateOrApplyStatus(ctx, crt)
+		if err := c.updateOrApplyStatus(ctx, crt); err != nil {
+			return err
+		}
+		c.recordRenewalScheduled(oldCrt, crt)
 	}
 	return nil
 }
 
+// recordRenewalScheduled sends an event when the renewal time of the
+// Certificate changes, so that upcoming renewals are visible ahead of time.
+// The renewal time is derived from the validity of the issued certificate,
+// which may be shorter than requested if the issuer truncated it.
+func (c *controller) recordRenewalScheduled(oldCrt, crt *cmapi.Certificate) {
+	renewalTime := crt.Status.RenewalTime
+	if renewalTime == nil || crt.Status.NotAfter == nil {
+		return
+	}
+	if oldCrt.Status.RenewalTime != nil && oldCrt.Status.RenewalTime.Equal(renewalTime) {
+		return
+	}
+
+	c.recorder.Eventf(crt, corev1.EventTypeNormal, RenewalScheduledReason,
+		"Renewal scheduled for %s, %s before the certificate expires at %s",
+		renewalTime.UTC().Format(time.RFC3339),
+		crt.Status.NotAfter.Sub(renewalTime.Time),
+		crt.Status.NotAfter.UTC().Format(time.RFC3339))
+}
+
 // updateOrApplyStatus will update the controller status. If the
 // ServerSideApply feature is enabled, the managed fields will instead get
 // applied using the relevant Patch API call.
@@ -212,6 +255,9 @@ func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certifi
 		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionReady); cond != nil {
 			conditions = []cmapi.CertificateCondition{*cond}
 		}
+		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionDurationTruncated); cond != nil {
+			conditions = append(conditions, *cond)
+		}
 		return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
 			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
 			Status: cmapi.CertificateStatus{
@@ -227,6 +273,35 @@ func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certifi
 	}
 }
 
+// setDurationTruncatedCondition sets the DurationTruncated condition on the
+// Certificate if the issued certificate, valid from notBefore until notAfter,
+// is shorter than the requested spec.duration. The condition is removed
+// otherwise, including when no duration was requested.
+func setDurationTruncatedCondition(crt *cmapi.Certificate, notBefore, notAfter time.Time) {
+	if crt.Spec.Duration == nil {
+		removeDurationTruncatedCondition(crt)
+		return
+	}
+
+	requested := crt.Spec.Duration.Duration
+	actual := notAfter.Sub(notBefore)
+	if actual+durationTruncatedTolerance >= requested {
+		removeDurationTruncatedCondition(crt)
+		return
+	}
+
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionDurationTruncated, cmmeta.ConditionTrue, DurationTruncatedReason,
+		fmt.Sprintf("Issued certificate is valid for %s, which is shorter than the requested duration of %s", actual, requested))
+}
+
+// removeDurationTruncatedCondition removes the DurationTruncated condition
+// from the Certificate, if present.
+func removeDurationTruncatedCondition(crt *cmapi.Certificate) {
+	if apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionDurationTruncated) != nil {
+		apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionDurationTruncated)
+	}
+}
+
 // BuildReadyConditionFromChain builds Certificate's Ready condition using the result of policy chain evaluation
 func BuildReadyConditionFromChain(chain policies.Chain, input policies.Input) cmapi.CertificateCondition {
 	reason, message, violationsFound := chain.Evaluate(input)
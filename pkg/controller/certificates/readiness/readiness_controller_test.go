@@ -18,6 +18,7 @@ package readiness
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 	fakeclock "k8s.io/utils/clock/testing"
 
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
@@ -63,6 +65,12 @@ func TestProcessItem(t *testing.T) {
 			DNSNames:   []string{"example.com"},
 		},
 	}
+	// the event sent when the renewal time of the certificate issued in the
+	// tests is scheduled
+	renewalScheduledEvent := fmt.Sprintf("Normal RenewalScheduled Renewal scheduled for %s, %s before the certificate expires at %s",
+		now.Add(time.Hour).Format(time.RFC3339),
+		now.Add(time.Hour*2).Truncate(time.Second).Sub(now.Add(time.Hour)),
+		now.Add(time.Hour*2).Truncate(time.Second).Format(time.RFC3339))
 	// base Secret to be used in tests
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -101,6 +109,13 @@ func TestProcessItem(t *testing.T) {
 		// renewalTime will be the updated Certificate's status.renewalTime
 		renewalTime *metav1.Time
 
+		// additionalConditions are the conditions other than Ready expected
+		// on the updated Certificate
+		additionalConditions []cmapi.CertificateCondition
+
+		// expectedEvents are the events expected to be sent for the Certificate
+		expectedEvents []string
+
 		wantsErr bool
 	}{
 		"do nothing if an empty 'key' is used": {},
@@ -130,6 +145,7 @@ func TestProcessItem(t *testing.T) {
 			notAfter:          func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 2).Truncate(time.Second))),
 			notBefore:         func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Truncate(time.Second))),
 			renewalTime:       func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour))),
+			expectedEvents:    []string{renewalScheduledEvent},
 		},
 		"update status for a Certificate that is evaluated as not Ready and whose spec.secretName secret contains a valid X509 cert": {
 			condition: cmapi.CertificateCondition{
@@ -145,6 +161,74 @@ func TestProcessItem(t *testing.T) {
 			notAfter:          func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 2).Truncate(time.Second))),
 			notBefore:         func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Truncate(time.Second))),
 			renewalTime:       func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour))),
+			expectedEvents:    []string{renewalScheduledEvent},
+		},
+		"set DurationTruncated condition if the X509 cert is shorter than the requested duration": {
+			condition: cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionReady,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             ReadyReason,
+				Message:            "ready message",
+				LastTransitionTime: &metaNow,
+			},
+			cert:              gen.CertificateFrom(cert, gen.SetCertificateDuration(&metav1.Duration{Duration: time.Hour * 24})),
+			certShouldUpdate:  true,
+			secretShouldExist: true,
+			notAfter:          func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 2).Truncate(time.Second))),
+			notBefore:         func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Truncate(time.Second))),
+			renewalTime:       func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour))),
+			expectedEvents:    []string{renewalScheduledEvent},
+			additionalConditions: []cmapi.CertificateCondition{
+				{
+					Type:               cmapi.CertificateConditionDurationTruncated,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             DurationTruncatedReason,
+					Message:            "Issued certificate is valid for 2h0m0s, which is shorter than the requested duration of 24h0m0s",
+					LastTransitionTime: &metaNow,
+				},
+			},
+		},
+		"remove DurationTruncated condition if the X509 cert matches the requested duration": {
+			condition: cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionReady,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             ReadyReason,
+				Message:            "ready message",
+				LastTransitionTime: &metaNow,
+			},
+			cert: gen.CertificateFrom(cert,
+				gen.SetCertificateDuration(&metav1.Duration{Duration: time.Hour * 2}),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:    cmapi.CertificateConditionDurationTruncated,
+					Status:  cmmeta.ConditionTrue,
+					Reason:  DurationTruncatedReason,
+					Message: "some message",
+				})),
+			certShouldUpdate:  true,
+			secretShouldExist: true,
+			notAfter:          func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 2).Truncate(time.Second))),
+			notBefore:         func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Truncate(time.Second))),
+			renewalTime:       func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour))),
+			expectedEvents:    []string{renewalScheduledEvent},
+		},
+		"do not send an event if the renewal time of the Certificate has not changed": {
+			condition: cmapi.CertificateCondition{
+				Type:               cmapi.CertificateConditionReady,
+				Status:             cmmeta.ConditionFalse,
+				Reason:             "some reason",
+				Message:            "some message",
+				LastTransitionTime: &metaNow,
+			},
+			cert: gen.CertificateFrom(cert,
+				gen.SetCertificateNotBefore(metav1.NewTime(now.Truncate(time.Second))),
+				gen.SetCertificateNotAfter(metav1.NewTime(now.Add(time.Hour*2).Truncate(time.Second))),
+				gen.SetCertificateRenewalTime(metav1.NewTime(now.Add(time.Hour))),
+			),
+			certShouldUpdate:  true,
+			secretShouldExist: true,
+			notAfter:          func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 2).Truncate(time.Second))),
+			notBefore:         func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Truncate(time.Second))),
+			renewalTime:       func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour))),
 		},
 		"update status for a Certificate whose spec.secretName secret does not exist": {
 			condition: cmapi.CertificateCondition{
@@ -248,7 +332,8 @@ func TestProcessItem(t *testing.T) {
 			builder := &testpkg.Builder{
 				T: t,
 				// Fix the clock to be able to set lastTransitionTime on Certificate's Ready condition.
-				Clock: fakeclock.NewFakeClock(now),
+				Clock:          fakeclock.NewFakeClock(now),
+				ExpectedEvents: test.expectedEvents,
 			}
 			if test.cert != nil {
 				// Ensures cert is loaded into the builder's fake clientset.
@@ -290,6 +375,12 @@ func TestProcessItem(t *testing.T) {
 			if test.certShouldUpdate {
 				c := gen.CertificateFrom(test.cert,
 					gen.SetCertificateStatusCondition(test.condition))
+				if len(test.additionalConditions) == 0 {
+					apiutil.RemoveCertificateCondition(c, cmapi.CertificateConditionDurationTruncated)
+				}
+				for _, cond := range test.additionalConditions {
+					c = gen.CertificateFrom(c, gen.SetCertificateStatusCondition(cond))
+				}
 
 				// gen package functions don't accept pointers- we need to test setting these values to nil in some scenarios.
 				c.Status.NotAfter = test.notAfter
@@ -325,6 +416,9 @@ func TestProcessItem(t *testing.T) {
 			if err := builder.AllActionsExecuted(); err != nil {
 				builder.T.Error(err)
 			}
+			if err := builder.AllEventsCalled(); err != nil {
+				builder.T.Error(err)
+			}
 		})
 	}
 }
@@ -0,0 +1,394 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafirevoker
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
+	venaficlient "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+)
+
+const (
+	ControllerName = "certificates-venafi-revoker"
+
+	reasonRevoked          = "Revoked"
+	reasonRevocationFailed = "RevocationFailed"
+
+	// revocationTimeout is how long after the deletion of a Certificate its
+	// certificate revocation is retried, before the finalizer is removed so
+	// that the deletion of the Certificate, and of its namespace, is not
+	// blocked forever.
+	revocationTimeout = time.Hour
+)
+
+// controller revokes the certificates issued by Venafi issuers which have
+// `spec.venafi.revokeOnDelete` set, when the Certificate they were issued for
+// is deleted. A finalizer is added to such Certificates so that they are only
+// removed once the certificate has been revoked.
+type controller struct {
+	certificateLister        cmlisters.CertificateLister
+	certificateRequestLister cmlisters.CertificateRequestLister
+	issuerHelper             issuerpkg.Helper
+	client                   cmclient.Interface
+	recorder                 record.EventRecorder
+	clock                    clock.Clock
+
+	issuerOptions       controllerpkg.IssuerOptions
+	credentialsResolver venaficlient.CredentialsResolver
+	clientBuilder       venaficlient.VenafiClientBuilder
+	metrics             *metrics.Metrics
+
+	// userAgent is the string used as the UserAgent when making HTTP calls.
+	userAgent string
+
+	// fieldManager is the string which will be used as the Field Manager on
+	// fields created or edited by the cert-manager Kubernetes client.
+	fieldManager string
+}
+
+func NewController(log logr.Logger, ctx *controllerpkg.Context) (*controller, workqueue.TypedRateLimitingInterface[types.NamespacedName], []cache.InformerSynced, error) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewTypedRateLimitingQueueWithConfig(
+		controllerpkg.DefaultCertificateRateLimiter(),
+		workqueue.TypedRateLimitingQueueConfig[types.NamespacedName]{
+			Name: ControllerName,
+		},
+	)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := ctx.SharedInformerFactory.Certmanager().V1().Certificates()
+	certificateRequestInformer := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests()
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
+	secretsInformer := ctx.KubeSharedInformerFactory.Secrets()
+
+	if _, err := certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue}); err != nil {
+		return nil, nil, nil, fmt.Errorf("error setting up event handler: %v", err)
+	}
+	if _, err := issuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		// Add or remove the finalizer when revokeOnDelete is changed on an Issuer
+		WorkFunc: enqueueCertificatesForIssuer(log, queue, certificateInformer.Lister(), cmapi.IssuerKind),
+	}); err != nil {
+		return nil, nil, nil, fmt.Errorf("error setting up event handler: %v", err)
+	}
+
+	mustSync := []cache.InformerSynced{
+		certificateInformer.Informer().HasSynced,
+		certificateRequestInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+		secretsInformer.Informer().HasSynced,
+	}
+
+	// if we are running in non-namespaced mode (i.e. --namespace=""), we also
+	// register event handlers and obtain a lister for clusterissuers.
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if ctx.Namespace == "" {
+		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+		if _, err := clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+			WorkFunc: enqueueCertificatesForIssuer(log, queue, certificateInformer.Lister(), cmapi.ClusterIssuerKind),
+		}); err != nil {
+			return nil, nil, nil, fmt.Errorf("error setting up event handler: %v", err)
+		}
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+
+	return &controller{
+		certificateLister:        certificateInformer.Lister(),
+		certificateRequestLister: certificateRequestInformer.Lister(),
+		issuerHelper:             issuerpkg.NewHelper(issuerInformer.Lister(), clusterIssuerLister),
+		client:                   ctx.CMClient,
+		recorder:                 ctx.Recorder,
+		clock:                    ctx.Clock,
+		issuerOptions:            ctx.IssuerOptions,
+		credentialsResolver:      venaficlient.NewSecretCredentialsResolver(secretsInformer.Lister()),
+		clientBuilder:            venaficlient.NewBuilder(),
+		metrics:                  ctx.Metrics,
+		userAgent:                ctx.RESTConfig.UserAgent,
+		fieldManager:             ctx.FieldManager,
+	}, queue, mustSync, nil
+}
+
+// enqueueCertificatesForIssuer returns a function which enqueues the
+// Certificates referencing the given Issuer or ClusterIssuer.
+func enqueueCertificatesForIssuer(log logr.Logger, queue workqueue.TypedInterface[types.NamespacedName], lister cmlisters.CertificateLister, kind string) func(obj interface{}) {
+	return func(obj interface{}) {
+		iss, ok := obj.(cmapi.GenericIssuer)
+		if !ok {
+			log.Error(nil, "object is not an issuer", "object", obj)
+			return
+		}
+
+		var crts []*cmapi.Certificate
+		var err error
+		if kind == cmapi.IssuerKind {
+			crts, err = lister.Certificates(iss.GetNamespace()).List(labels.Everything())
+		} else {
+			crts, err = lister.List(labels.Everything())
+		}
+		if err != nil {
+			log.Error(err, "failed listing Certificate resources")
+			return
+		}
+
+		for _, crt := range crts {
+			if crt.Spec.IssuerRef.Name != iss.GetName() || apiutil.IssuerKind(crt.Spec.IssuerRef) != kind {
+				continue
+			}
+			queue.Add(types.NamespacedName{Namespace: crt.Namespace, Name: crt.Name})
+		}
+	}
+}
+
+// ProcessItem adds the finalizer to Certificates whose issuer revokes
+// certificates on delete, and revokes the issued certificate once such a
+// Certificate is being deleted.
+func (c *controller) ProcessItem(ctx context.Context, key types.NamespacedName) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	namespace, name := key.Namespace, key.Name
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("certificate not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	log = logf.WithResource(log, crt)
+	ctx = logf.NewContext(ctx, log)
+
+	hasFinalizer := slices.Contains(crt.Finalizers, cmapi.VenafiRevocationFinalizer)
+
+	if !crt.DeletionTimestamp.IsZero() {
+		if !hasFinalizer {
+			return nil
+		}
+		return c.finalize(ctx, crt)
+	}
+
+	iss, err := c.revokingIssuer(crt.Spec.IssuerRef, crt.Namespace)
+	if err != nil {
+		// The finalizer is left as is until the issuer can be read, the
+		// Certificate is resynced when the issuer is created.
+		log.V(logf.DebugLevel).Info("failed to read the issuer of the certificate", "error", err.Error())
+		return nil
+	}
+
+	switch {
+	case iss != nil && !hasFinalizer:
+		log.V(logf.DebugLevel).Info("adding finalizer to revoke the certificate when the Certificate is deleted")
+		crt = crt.DeepCopy()
+		crt.Finalizers = append(crt.Finalizers, cmapi.VenafiRevocationFinalizer)
+		return c.updateCertificate(ctx, crt)
+	case iss == nil && hasFinalizer:
+		log.V(logf.DebugLevel).Info("removing finalizer as the issuer no longer revokes certificates on delete")
+		return c.removeFinalizer(ctx, crt)
+	}
+
+	return nil
+}
+
+// finalize revokes the certificate issued for the given Certificate, if any,
+// and removes the finalizer from the Certificate. If the certificate could not
+// be revoked, an error is returned so that revocation is retried until
+// revocationTimeout has passed since the Certificate was deleted.
+func (c *controller) finalize(ctx context.Context, crt *cmapi.Certificate) error {
+	log := logf.FromContext(ctx, "finalize")
+
+	req, err := c.issuedCertificateRequest(crt)
+	if err != nil {
+		return err
+	}
+	if req == nil {
+		log.V(logf.InfoLevel).Info("the certificate was never issued, not revoking")
+		return c.removeFinalizer(ctx, crt)
+	}
+
+	log = logf.WithRelatedResource(log, req)
+
+	pickupID := req.Annotations[cmapi.VenafiPickupIDAnnotationKey]
+	if pickupID == "" {
+		log.V(logf.InfoLevel).Info("the certificate was not issued by Venafi, not revoking")
+		return c.removeFinalizer(ctx, crt)
+	}
+
+	iss, err := c.revokingIssuer(req.Spec.IssuerRef, req.Namespace)
+	if err != nil {
+		// Without the issuer the certificate cannot be revoked, so do not
+		// block the deletion of the Certificate.
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRevocationFailed,
+			"Not revoking the certificate as its issuer could not be read: %v", err)
+		return c.removeFinalizer(ctx, crt)
+	}
+	if iss == nil {
+		log.V(logf.InfoLevel).Info("the issuer of the certificate does not revoke certificates on delete, not revoking")
+		return c.removeFinalizer(ctx, crt)
+	}
+
+	log = logf.WithRelatedResource(log, iss)
+
+	client, err := c.clientBuilder(c.issuerOptions.ResourceNamespace(iss), c.credentialsResolver, iss, c.metrics, log, c.userAgent)
+	if apierrors.IsNotFound(err) {
+		// Without the credentials the certificate cannot be revoked, which
+		// is likely when the whole namespace is being deleted.
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRevocationFailed,
+			"Not revoking the certificate as the credentials of its issuer could not be read: %v", err)
+		return c.removeFinalizer(ctx, crt)
+	}
+	if err != nil {
+		return c.revocationFailed(ctx, crt, fmt.Errorf("failed to initialise the Venafi client: %w", err))
+	}
+
+	if err := client.RevokeCertificate(pickupID); err != nil {
+		return c.revocationFailed(ctx, crt, err)
+	}
+
+	log.V(logf.InfoLevel).Info("revoked the certificate", "pickupID", pickupID)
+	c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonRevoked, "Revoked the certificate issued by CertificateRequest %q", req.Name)
+
+	return c.removeFinalizer(ctx, crt)
+}
+
+// revocationFailed records that the certificate of the given Certificate could
+// not be revoked. The error is returned so that revocation is retried, unless
+// the Certificate was deleted more than revocationTimeout ago, in which case
+// the finalizer is removed.
+func (c *controller) revocationFailed(ctx context.Context, crt *cmapi.Certificate, err error) error {
+	if c.clock.Since(crt.DeletionTimestamp.Time) < revocationTimeout {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRevocationFailed, "Failed to revoke the certificate: %v", err)
+		return err
+	}
+
+	c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRevocationFailed,
+		"Not revoking the certificate as it could not be revoked within %s of the deletion of the Certificate: %v", revocationTimeout, err)
+	return c.removeFinalizer(ctx, crt)
+}
+
+// revokingIssuer returns the issuer referenced by ref if it is a Venafi issuer
+// which revokes certificates on delete, and nil otherwise.
+func (c *controller) revokingIssuer(ref cmmeta.ObjectReference, namespace string) (cmapi.GenericIssuer, error) {
+	if ref.Group != "" && ref.Group != certmanager.GroupName {
+		return nil, nil
+	}
+
+	iss, err := c.issuerHelper.GetGenericIssuer(ref, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	venafi := iss.GetSpec().Venafi
+	if venafi == nil || !venafi.RevokeOnDelete {
+		return nil, nil
+	}
+
+	return iss, nil
+}
+
+// issuedCertificateRequest returns the CertificateRequest of the current
+// revision of the Certificate, or nil if the Certificate was never issued.
+func (c *controller) issuedCertificateRequest(crt *cmapi.Certificate) (*cmapi.CertificateRequest, error) {
+	if crt.Status.Revision == nil {
+		return nil, nil
+	}
+
+	reqs, err := certificates.ListCertificateRequestsMatchingPredicates(c.certificateRequestLister.CertificateRequests(crt.Namespace),
+		labels.Everything(),
+		predicate.ResourceOwnedBy(crt),
+		predicate.CertificateRequestRevision(*crt.Status.Revision),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(reqs) {
+	case 0:
+		return nil, nil
+	case 1:
+		return reqs[0], nil
+	default:
+		return nil, fmt.Errorf("found %d CertificateRequests for revision %d", len(reqs), *crt.Status.Revision)
+	}
+}
+
+func (c *controller) removeFinalizer(ctx context.Context, crt *cmapi.Certificate) error {
+	crt = crt.DeepCopy()
+	crt.Finalizers = slices.DeleteFunc(crt.Finalizers, func(f string) bool {
+		return f == cmapi.VenafiRevocationFinalizer
+	})
+	return c.updateCertificate(ctx, crt)
+}
+
+func (c *controller) updateCertificate(ctx context.Context, crt *cmapi.Certificate) error {
+	_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).Update(ctx, crt, metav1.UpdateOptions{FieldManager: c.fieldManager})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.TypedRateLimitingInterface[types.NamespacedName], []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync, err := NewController(log, ctx)
+	c.controller = ctrl
+
+	return queue, mustSync, err
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafirevoker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	coretesting "k8s.io/client-go/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	venaficlient "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
+	internalvenafifake "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestProcessItem(t *testing.T) {
+	now := metav1.Now()
+
+	revokingIssuer := gen.Issuer("venafi-issuer",
+		gen.SetIssuerNamespace(gen.DefaultTestNamespace),
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+			Zone:           "tpp-zone",
+			TPP:            &cmapi.VenafiTPP{},
+			RevokeOnDelete: true,
+		}),
+	)
+	nonRevokingIssuer := gen.IssuerFrom(revokingIssuer,
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+			Zone: "tpp-zone",
+			TPP:  &cmapi.VenafiTPP{},
+		}),
+	)
+
+	crt := gen.Certificate("test-cert",
+		gen.SetCertificateNamespace(gen.DefaultTestNamespace),
+		gen.SetCertificateUID("test-uid"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "venafi-issuer", Kind: cmapi.IssuerKind}),
+	)
+	crtWithFinalizer := gen.CertificateFrom(crt, func(crt *cmapi.Certificate) {
+		crt.Finalizers = []string{cmapi.VenafiRevocationFinalizer}
+	})
+	deletedCrt := gen.CertificateFrom(crtWithFinalizer, func(crt *cmapi.Certificate) {
+		crt.DeletionTimestamp = &now
+	})
+	deletedIssuedCrt := gen.CertificateFrom(deletedCrt, gen.SetCertificateRevision(2))
+	longDeletedIssuedCrt := gen.CertificateFrom(deletedIssuedCrt, func(crt *cmapi.Certificate) {
+		crt.DeletionTimestamp = &metav1.Time{Time: now.Add(-2 * time.Hour)}
+	})
+
+	issuedReq := gen.CertificateRequest("test-cert-2",
+		gen.SetCertificateRequestNamespace(gen.DefaultTestNamespace),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "venafi-issuer", Kind: cmapi.IssuerKind}),
+		gen.AddCertificateRequestOwnerReferences(gen.CertificateRef("test-cert", "test-uid")),
+		gen.SetCertificateRequestAnnotations(map[string]string{
+			cmapi.CertificateRequestRevisionAnnotationKey: "2",
+			cmapi.VenafiPickupIDAnnotationKey:             `\VED\Policy\tpp-zone\test-cert`,
+		}),
+	)
+
+	tests := map[string]struct {
+		certificate *cmapi.Certificate
+		issuer      *cmapi.Issuer
+		requests    []*cmapi.CertificateRequest
+
+		clientErr error
+		revokeErr error
+
+		expectedRevokedPickupID string
+		expectedActions         []testpkg.Action
+		expectedEvents          []string
+
+		// err is the expected error text returned by the controller, if any.
+		err string
+	}{
+		"do nothing if the issuer does not revoke on delete": {
+			certificate: crt,
+			issuer:      nonRevokingIssuer,
+		},
+		"do nothing if the issuer does not exist": {
+			certificate: crt,
+		},
+		"add the finalizer if the issuer revokes on delete": {
+			certificate: crt,
+			issuer:      revokingIssuer,
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), gen.DefaultTestNamespace, crtWithFinalizer)),
+			},
+		},
+		"remove the finalizer if the issuer no longer revokes on delete": {
+			certificate: crtWithFinalizer,
+			issuer:      nonRevokingIssuer,
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), gen.DefaultTestNamespace,
+					gen.CertificateFrom(crt, func(crt *cmapi.Certificate) { crt.Finalizers = []string{} }))),
+			},
+		},
+		"revoke the issued certificate and remove the finalizer when the Certificate is deleted": {
+			certificate:             deletedIssuedCrt,
+			issuer:                  revokingIssuer,
+			requests:                []*cmapi.CertificateRequest{issuedReq},
+			expectedRevokedPickupID: `\VED\Policy\tpp-zone\test-cert`,
+			expectedEvents:          []string{`Normal Revoked Revoked the certificate issued by CertificateRequest "test-cert-2"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), gen.DefaultTestNamespace,
+					gen.CertificateFrom(deletedIssuedCrt, func(crt *cmapi.Certificate) { crt.Finalizers = []string{} }))),
+			},
+		},
+		"remove the finalizer without revoking if the certificate was never issued": {
+			certificate: deletedCrt,
+			issuer:      revokingIssuer,
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), gen.DefaultTestNamespace,
+					gen.CertificateFrom(deletedCrt, func(crt *cmapi.Certificate) { crt.Finalizers = []string{} }))),
+			},
+		},
+		"remove the finalizer without revoking if the issued request has no pickup ID": {
+			certificate: deletedIssuedCrt,
+			issuer:      revokingIssuer,
+			requests: []*cmapi.CertificateRequest{
+				gen.CertificateRequestFrom(issuedReq, gen.DeleteCertificateRequestAnnotation(cmapi.VenafiPickupIDAnnotationKey)),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), gen.DefaultTestNamespace,
+					gen.CertificateFrom(deletedIssuedCrt, func(crt *cmapi.Certificate) { crt.Finalizers = []string{} }))),
+			},
+		},
+		"remove the finalizer with a warning if the issuer no longer exists": {
+			certificate:    deletedIssuedCrt,
+			requests:       []*cmapi.CertificateRequest{issuedReq},
+			expectedEvents: []string{`Warning RevocationFailed Not revoking the certificate as its issuer could not be read: issuer.cert-manager.io "venafi-issuer" not found`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), gen.DefaultTestNamespace,
+					gen.CertificateFrom(deletedIssuedCrt, func(crt *cmapi.Certificate) { crt.Finalizers = []string{} }))),
+			},
+		},
+		"keep the finalizer and retry if revocation fails": {
+			certificate:             deletedIssuedCrt,
+			issuer:                  revokingIssuer,
+			requests:                []*cmapi.CertificateRequest{issuedReq},
+			revokeErr:               errors.New("revocation is not allowed in this zone"),
+			expectedRevokedPickupID: `\VED\Policy\tpp-zone\test-cert`,
+			expectedEvents:          []string{"Warning RevocationFailed Failed to revoke the certificate: revocation is not allowed in this zone"},
+			err:                     "revocation is not allowed in this zone",
+		},
+		"keep the finalizer and retry if the Venafi client cannot be initialised": {
+			certificate:    deletedIssuedCrt,
+			issuer:         revokingIssuer,
+			requests:       []*cmapi.CertificateRequest{issuedReq},
+			clientErr:      errors.New("connection refused"),
+			expectedEvents: []string{"Warning RevocationFailed Failed to revoke the certificate: failed to initialise the Venafi client: connection refused"},
+			err:            "failed to initialise the Venafi client: connection refused",
+		},
+		"remove the finalizer with a warning if revocation fails long after the Certificate was deleted": {
+			certificate:             longDeletedIssuedCrt,
+			issuer:                  revokingIssuer,
+			requests:                []*cmapi.CertificateRequest{issuedReq},
+			revokeErr:               errors.New("revocation is not allowed in this zone"),
+			expectedRevokedPickupID: `\VED\Policy\tpp-zone\test-cert`,
+			expectedEvents:          []string{"Warning RevocationFailed Not revoking the certificate as it could not be revoked within 1h0m0s of the deletion of the Certificate: revocation is not allowed in this zone"},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), gen.DefaultTestNamespace,
+					gen.CertificateFrom(longDeletedIssuedCrt, func(crt *cmapi.Certificate) { crt.Finalizers = []string{} }))),
+			},
+		},
+		"remove the finalizer with a warning if the credentials of the issuer no longer exist": {
+			certificate:    deletedIssuedCrt,
+			issuer:         revokingIssuer,
+			requests:       []*cmapi.CertificateRequest{issuedReq},
+			clientErr:      apierrors.NewNotFound(corev1.Resource("secrets"), "venafi-credentials"),
+			expectedEvents: []string{`Warning RevocationFailed Not revoking the certificate as the credentials of its issuer could not be read: secrets "venafi-credentials" not found`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), gen.DefaultTestNamespace,
+					gen.CertificateFrom(deletedIssuedCrt, func(crt *cmapi.Certificate) { crt.Finalizers = []string{} }))),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				ExpectedEvents:     test.expectedEvents,
+				ExpectedActions:    test.expectedActions,
+				CertManagerObjects: []runtime.Object{test.certificate},
+			}
+			if test.issuer != nil {
+				builder.CertManagerObjects = append(builder.CertManagerObjects, test.issuer)
+			}
+			for _, req := range test.requests {
+				builder.CertManagerObjects = append(builder.CertManagerObjects, req)
+			}
+			builder.InitWithRESTConfig()
+
+			w := &controllerWrapper{}
+			_, _, err := w.Register(builder.Context)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var revokedPickupID string
+			w.controller.clientBuilder = func(string, venaficlient.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (venaficlient.Interface, error) {
+				if test.clientErr != nil {
+					return nil, test.clientErr
+				}
+				return &internalvenafifake.Venafi{
+					RevokeCertificateFn: func(pickupID string) error {
+						revokedPickupID = pickupID
+						return test.revokeErr
+					},
+				}, nil
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			err = w.controller.ProcessItem(context.Background(), types.NamespacedName{
+				Namespace: test.certificate.Namespace,
+				Name:      test.certificate.Name,
+			})
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, test.expectedRevokedPickupID, revokedPickupID)
+
+			if err := builder.AllEventsCalled(); err != nil {
+				builder.T.Error(err)
+			}
+			if err := builder.AllActionsExecuted(); err != nil {
+				builder.T.Error(err)
+			}
+		})
+	}
+}
@@ -131,6 +131,21 @@ func (c *CA) Sign(ctx context.Context, csr *certificatesv1.CertificateSigningReq
 	template.OCSPServer = issuerObj.GetSpec().CA.OCSPServers
 	template.IssuingCertificateURL = issuerObj.GetSpec().CA.IssuingCertificateURLs
 
+	if maxPathLen := issuerObj.GetSpec().CA.MaxPathLen; maxPathLen != nil && template.IsCA {
+		template.MaxPathLen = int(*maxPathLen)
+		template.MaxPathLenZero = *maxPathLen == 0
+	}
+
+	if issuerObj.GetSpec().CA.LegacyExtensions {
+		if err := pki.AddNetscapeCertType(template); err != nil {
+			message := fmt.Sprintf("Error adding legacy extensions to certificate template: %s", err)
+			c.recorder.Event(csr, corev1.EventTypeWarning, "SigningError", message)
+			util.CertificateSigningRequestSetFailed(csr, "SigningError", message)
+			_, err := util.UpdateOrApplyStatus(ctx, c.certClient, csr, certificatesv1.CertificateFailed, c.fieldManager)
+			return err
+		}
+	}
+
 	bundle, err := c.signingFn(caCerts, caKey, template)
 	if err != nil {
 		message := fmt.Sprintf("Error signing certificate: %s", err)
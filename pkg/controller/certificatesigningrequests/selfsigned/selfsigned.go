@@ -175,6 +175,21 @@ func (s *SelfSigned) Sign(ctx context.Context, csr *certificatesv1.CertificateSi
 
 	template.CRLDistributionPoints = issuerObj.GetSpec().SelfSigned.CRLDistributionPoints
 
+	if backdate := issuerObj.GetSpec().SelfSigned.NotBeforeBackdate; backdate != nil {
+		template.NotBefore = template.NotBefore.Add(-backdate.Duration)
+	}
+
+	if issuerObj.GetSpec().SelfSigned.LegacyExtensions {
+		if err := pki.AddNetscapeCertType(template); err != nil {
+			message := fmt.Sprintf("Error adding legacy extensions to certificate template: %s", err)
+			log.Error(err, message)
+			s.recorder.Event(csr, corev1.EventTypeWarning, "ErrorGenerating", message)
+			util.CertificateSigningRequestSetFailed(csr, "ErrorGenerating", message)
+			_, err = util.UpdateOrApplyStatus(ctx, s.certClient, csr, certificatesv1.CertificateFailed, s.fieldManager)
+			return err
+		}
+	}
+
 	// extract the public component of the key
 	publickey, err := pki.PublicKeyForPrivateKey(privatekey)
 	if err != nil {
@@ -749,6 +749,26 @@ func TestSign(t *testing.T) {
 				assert.Equal(t, []string{"http://www.example.com/crl/test.crl"}, gotCA.CRLDistributionPoints)
 			},
 		},
+		"when the Issuer has notBeforeBackdate set, notBefore should be moved into the past": {
+			csr: gen.CertificateSigningRequest("cr-1",
+				gen.AddCertificateSigningRequestAnnotations(map[string]string{
+					"experimental.cert-manager.io/private-key-secret-name": "test-secret",
+				}),
+				gen.SetCertificateSigningRequestRequest(csrBundle.csrPEM),
+				gen.SetCertificateSigningRequestSignerName("issuers.cert-manager.io/default-unit-test-ns.issuer-1"),
+			),
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{
+					NotBeforeBackdate: &metav1.Duration{Duration: 5 * time.Minute},
+				}),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				// See the notAfter test cases above for why a delta is used.
+				expectNotBefore := time.Now().UTC().Add(-5 * time.Minute)
+				deltaSec := math.Abs(expectNotBefore.Sub(got.NotBefore).Seconds())
+				assert.LessOrEqualf(t, deltaSec, 2., "expected a time delta lower than 2 second. Time expected='%s', got='%s'", expectNotBefore.String(), got.NotBefore.String())
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
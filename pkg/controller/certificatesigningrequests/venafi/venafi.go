@@ -18,7 +18,6 @@ package venafi
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/Venafi/vcert/v5/pkg/endpoint"
@@ -29,7 +28,6 @@ import (
 	certificatesclient "k8s.io/client-go/kubernetes/typed/certificates/v1"
 	"k8s.io/client-go/tools/record"
 
-	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	experimentalapi "github.com/cert-manager/cert-manager/pkg/apis/experimental/v1alpha1"
@@ -51,10 +49,10 @@ const (
 // signing CertificateSigningRequests that reference a cert-manager Venafi
 // Issuer or ClusterIssuer
 type Venafi struct {
-	issuerOptions controllerpkg.IssuerOptions
-	secretsLister internalinformers.SecretLister
-	certClient    certificatesclient.CertificateSigningRequestInterface
-	recorder      record.EventRecorder
+	issuerOptions       controllerpkg.IssuerOptions
+	credentialsResolver venaficlient.CredentialsResolver
+	certClient          certificatesclient.CertificateSigningRequestInterface
+	recorder            record.EventRecorder
 
 	clientBuilder venaficlient.VenafiClientBuilder
 
@@ -76,15 +74,22 @@ func init() {
 }
 
 func NewVenafi(ctx *controllerpkg.Context) certificatesigningrequests.Signer {
+	zoneCache := venaficlient.NewZoneConfigurationCache(ctx.Clock, ctx.IssuerOptions.VenafiZoneCacheTTL, ctx.Metrics)
+	clientBuilder := venaficlient.NewBuilder(
+		venaficlient.WithZoneConfigurationCache(zoneCache),
+		venaficlient.WithResponseLog(ctx.VenafiResponseLog),
+		venaficlient.WithTransport(ctx.VenafiTransport),
+	)
+
 	return &Venafi{
-		issuerOptions: ctx.IssuerOptions,
-		secretsLister: ctx.KubeSharedInformerFactory.Secrets().Lister(),
-		certClient:    ctx.Client.CertificatesV1().CertificateSigningRequests(),
-		recorder:      ctx.Recorder,
-		clientBuilder: venaficlient.New,
-		fieldManager:  ctx.FieldManager,
-		metrics:       ctx.Metrics,
-		userAgent:     ctx.RESTConfig.UserAgent,
+		issuerOptions:       ctx.IssuerOptions,
+		credentialsResolver: venaficlient.NewSecretCredentialsResolver(ctx.KubeSharedInformerFactory.Secrets().Lister()),
+		certClient:          ctx.Client.CertificatesV1().CertificateSigningRequests(),
+		recorder:            ctx.Recorder,
+		clientBuilder:       clientBuilder,
+		fieldManager:        ctx.FieldManager,
+		metrics:             ctx.Metrics,
+		userAgent:           ctx.RESTConfig.UserAgent,
 	}
 }
 
@@ -102,7 +107,7 @@ func (v *Venafi) Sign(ctx context.Context, csr *certificatesv1.CertificateSignin
 
 	resourceNamespace := v.issuerOptions.ResourceNamespace(issuerObj)
 
-	client, err := v.clientBuilder(resourceNamespace, v.secretsLister, issuerObj, v.metrics, log, v.userAgent)
+	client, err := v.clientBuilder(resourceNamespace, v.credentialsResolver, issuerObj, v.metrics, log, v.userAgent)
 	if apierrors.IsNotFound(err) {
 		message := "Required secret resource not found"
 		v.recorder.Event(csr, corev1.EventTypeWarning, "SecretNotFound", message)
@@ -117,9 +122,19 @@ func (v *Venafi) Sign(ctx context.Context, csr *certificatesv1.CertificateSignin
 		return err
 	}
 
+	// The private key of a CertificateSigningRequest is held by the requester,
+	// so a key generated by Venafi TPP could not be returned.
+	if tppCfg := issuerObj.GetSpec().Venafi.TPP; tppCfg != nil && tppCfg.ServiceGeneratedKeys {
+		message := "CertificateSigningRequests cannot be signed by Venafi issuers which use service generated keys"
+		v.recorder.Event(csr, corev1.EventTypeWarning, "ErrorServiceGeneratedKeys", message)
+		util.CertificateSigningRequestSetFailed(csr, "ErrorServiceGeneratedKeys", message)
+		_, userr := util.UpdateOrApplyStatus(ctx, v.certClient, csr, certificatesv1.CertificateFailed, v.fieldManager)
+		return userr
+	}
+
 	var customFields []venafiapi.CustomField
 	if annotation, exists := csr.GetAnnotations()[experimentalapi.CertificateSigningRequestVenafiCustomFieldsAnnotationKey]; exists && annotation != "" {
-		err := json.Unmarshal([]byte(annotation), &customFields)
+		customFields, err = venafiapi.ParseCustomFields([]byte(annotation))
 		if err != nil {
 			message := fmt.Sprintf("Failed to parse %q annotation: %s", experimentalapi.CertificateSigningRequestVenafiCustomFieldsAnnotationKey, err)
 			v.recorder.Event(csr, corev1.EventTypeWarning, "ErrorCustomFields", message)
@@ -136,7 +151,7 @@ func (v *Venafi) Sign(ctx context.Context, csr *certificatesv1.CertificateSignin
 
 	// check if the pickup ID annotation is there, if not set it up.
 	if len(pickupID) == 0 {
-		pickupID, err := client.RequestCertificate(csr.Spec.Request, customFields)
+		pickupID, err := client.RequestCertificate(csr.Spec.Request, 0, "", nil, 0, customFields)
 		// Check some known error types
 		if err != nil {
 			switch err.(type) {
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -47,6 +48,7 @@ import (
 	gwscheme "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/scheme"
 	gwinformers "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
 
+	"github.com/cert-manager/cert-manager/internal/apis/config/shared"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
@@ -54,6 +56,7 @@ import (
 	clientset "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	cmscheme "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/scheme"
 	informers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	venaficlient "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/util"
@@ -145,6 +148,38 @@ type ContextOptions struct {
 	// Metrics is used for exposing Prometheus metrics across the controllers
 	Metrics *metrics.Metrics
 
+	// IssuanceAuditSink receives a record of every certificate issued by the
+	// issuers which support auditing. If nil, no records are kept.
+	IssuanceAuditSink IssuanceAuditSink
+
+	// RequestMutators are invoked in order on each CertificateRequest before
+	// it is submitted to the backend of the issuers which support them. If
+	// empty, requests are not mutated.
+	RequestMutators []RequestMutator
+
+	// VenafiResponseLog records the raw responses of the Venafi platform to
+	// the enrollment and retrieval requests of the Venafi issuers. If nil,
+	// no responses are recorded.
+	VenafiResponseLog *venaficlient.ResponseLog
+
+	// VenafiTransport is used as the base HTTP transport of the clients of
+	// the Venafi issuers, for example to route requests to the Venafi API
+	// through a proxy. If nil, the default transport is used.
+	VenafiTransport *http.Transport
+
+	// ConcurrentWorkers is the number of concurrent workers of the controllers
+	// with the given names, so that controllers for slow or rate limited
+	// backends can be sized independently. Controllers which are not listed,
+	// or are listed with a value of zero or less, run the default number of
+	// workers.
+	ConcurrentWorkers map[string]int
+
+	// ShutdownGracePeriod is the maximum time the workers of the controllers
+	// are given to finish processing their current items once the controllers
+	// are stopped, before the items are cancelled. A value of zero or less
+	// cancels them immediately.
+	ShutdownGracePeriod time.Duration
+
 	IssuerOptions
 	ACMEOptions
 	IngressShimOptions
@@ -171,6 +206,90 @@ type IssuerOptions struct {
 	// IssuerAmbientCredentials controls whether an issuer should pick up ambient
 	// credentials, such as those from metadata services, to construct clients.
 	IssuerAmbientCredentials bool
+
+	// VenafiMaxConcurrentSignings is the maximum number of CertificateRequests
+	// that can be signed at once by each Venafi issuer. A value of zero or less
+	// means no limit.
+	VenafiMaxConcurrentSignings int
+
+	// IssuerHealthCheckInterval is how often each Issuer and ClusterIssuer is
+	// set up again to verify that it can reach its backend. A value of zero
+	// disables periodic checks.
+	IssuerHealthCheckInterval time.Duration
+
+	// VenafiRequestTimeout is the maximum time to wait for each call to the
+	// Venafi platform when signing a CertificateRequest. A value of zero or
+	// less disables the timeout.
+	VenafiRequestTimeout time.Duration
+
+	// VenafiReconcileTimeout is the maximum time a single sync of a Venafi
+	// CertificateRequest may spend building the Venafi client and calling the
+	// Venafi platform, shared by all the steps of the sync. A value of zero or
+	// less disables the budget.
+	VenafiReconcileTimeout time.Duration
+
+	// VenafiIssuerWarmUpTimeout is the maximum time spent setting up the
+	// Venafi Issuers and ClusterIssuers when the controller starts, before any
+	// Venafi CertificateRequest is signed. A value of zero or less disables
+	// the warm up.
+	VenafiIssuerWarmUpTimeout time.Duration
+
+	// VenafiRetrieveFailureTimeout is the maximum time for which retrieving a
+	// certificate from the Venafi platform is retried after unexpected errors
+	// before the CertificateRequest is failed. A value of zero or less means
+	// that retrieval is retried indefinitely.
+	VenafiRetrieveFailureTimeout time.Duration
+
+	// VenafiCircuitBreakerThreshold is the number of consecutive failures to
+	// reach the Venafi platform after which signings for the issuer are
+	// failed fast. A value of zero or less disables the circuit breaker.
+	VenafiCircuitBreakerThreshold int
+
+	// VenafiCircuitBreakerOpenDuration is how long signings for a Venafi
+	// issuer are failed fast once its circuit breaker has opened, before a
+	// signing is let through to probe the Venafi platform.
+	VenafiCircuitBreakerOpenDuration time.Duration
+
+	// CertificateRequestEventCooldown is the period during which identical
+	// consecutive events for a CertificateRequest are suppressed. A value of
+	// zero or less disables the suppression.
+	CertificateRequestEventCooldown time.Duration
+
+	// VenafiErrorLogInterval is the minimum interval between logging
+	// identical errors encountered while signing the same CertificateRequest
+	// with a Venafi issuer. A value of zero or less logs every error.
+	VenafiErrorLogInterval time.Duration
+
+	// VenafiZoneCacheTTL is how long the zone configuration of each Venafi
+	// issuer and zone is cached. A value of zero or less disables the cache.
+	VenafiZoneCacheTTL time.Duration
+
+	// EnableVenafiIssuerCache is whether the Venafi CertificateRequest
+	// controller caches the issuers it reads until they are next updated.
+	EnableVenafiIssuerCache bool
+
+	// VenafiValidityHintExtensionOID is the dotted OID of the CSR extension
+	// from which the validity requested for Venafi certificates is read. If
+	// empty, the CSR is not inspected.
+	VenafiValidityHintExtensionOID string
+
+	// VenafiFieldManager is the field manager name used by the Venafi issuer
+	// when updating CertificateRequests. If empty, the field manager of the
+	// controller is used.
+	VenafiFieldManager string
+
+	// VenafiCallbackListenAddress is the host and port that the Venafi
+	// callback endpoint listens on. If empty, the endpoint is disabled.
+	VenafiCallbackListenAddress string
+
+	// VenafiCallbackToken is the shared token that calls to the Venafi
+	// callback endpoint must carry as a bearer token.
+	VenafiCallbackToken string
+
+	// VenafiCallbackTLSConfig configures the serving certificate of the
+	// Venafi callback endpoint. If no certificate is configured, the endpoint
+	// is served without TLS.
+	VenafiCallbackTLSConfig shared.TLSConfig
 }
 
 type ACMEOptions struct {
@@ -23,6 +23,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
@@ -45,6 +46,29 @@ type queueingController interface {
 	ProcessItem(ctx context.Context, key types.NamespacedName) error
 }
 
+// warmingUpController is an optional interface that may be implemented by a
+// queueingController which needs to do some work once its informer caches
+// have synced, before any item is processed.
+type warmingUpController interface {
+	queueingController
+
+	// WarmUp is called once before the workers of the controller are
+	// started. It must return once ctx is cancelled.
+	WarmUp(ctx context.Context)
+}
+
+// runningController is an optional interface that may be implemented by a
+// queueingController which runs background tasks, such as servers, alongside
+// its workers.
+type runningController interface {
+	queueingController
+
+	// Runnables returns the functions run in their own goroutine once the
+	// informer caches of the controller have synced, before WarmUp is called.
+	// They must return once ctx is cancelled.
+	Runnables() []func(ctx context.Context)
+}
+
 func NewController(
 	name string,
 	metrics *metrics.Metrics,
@@ -53,6 +77,17 @@ func NewController(
 	runDurationFuncs []runDurationFunc,
 	queue workqueue.TypedRateLimitingInterface[types.NamespacedName],
 ) Interface {
+	return newController(name, metrics, syncFunc, mustSync, runDurationFuncs, queue)
+}
+
+func newController(
+	name string,
+	metrics *metrics.Metrics,
+	syncFunc func(ctx context.Context, key types.NamespacedName) error,
+	mustSync []cache.InformerSynced,
+	runDurationFuncs []runDurationFunc,
+	queue workqueue.TypedRateLimitingInterface[types.NamespacedName],
+) *controller {
 	return &controller{
 		name:             name,
 		metrics:          metrics,
@@ -75,7 +110,12 @@ type controller struct {
 	// this controller can start
 	mustSync []cache.InformerSynced
 
-	// a set of functions that will be called just after controller initialisation, once.
+	// a set of functions that will be run in their own goroutine once the
+	// informer caches have synced, before runFirstFuncs are called.
+	runnables []runFunc
+
+	// a set of functions that will be called once the informer caches have
+	// synced, before the workers are started.
 	runFirstFuncs []runFunc
 
 	// a set of functions that should be called every duration.
@@ -87,6 +127,15 @@ type controller struct {
 
 	// metrics is used to expose Prometheus, shared by all controllers
 	metrics *metrics.Metrics
+
+	// workers is the number of workers to run, overriding the number passed
+	// to Run if greater than zero.
+	workers int
+
+	// shutdownGracePeriod is the maximum time the workers are given to
+	// finish processing their current items once the controller is stopped,
+	// before the items are cancelled.
+	shutdownGracePeriod time.Duration
 }
 
 // Run starts the controller loop
@@ -95,25 +144,40 @@ func (c *controller) Run(workers int, ctx context.Context) error {
 	defer cancel()
 	log := logf.FromContext(ctx, c.name)
 
-	log.V(logf.DebugLevel).Info("starting control loop")
+	if c.workers > 0 {
+		workers = c.workers
+	}
+
+	log.V(logf.DebugLevel).Info("starting control loop", "workers", workers)
 	// wait for all the informer caches we depend on are synced
 	if !cache.WaitForCacheSync(ctx.Done(), c.mustSync...) {
 		return fmt.Errorf("error waiting for informer caches to sync")
 	}
 
+	for _, f := range c.runnables {
+		go f(ctx)
+	}
+
+	for _, f := range c.runFirstFuncs {
+		f(ctx)
+	}
+
+	// The items are processed with a context which is only cancelled once
+	// the shutdown grace period has elapsed after the controller is stopped,
+	// so that the items in flight can complete, for example to persist the
+	// requests made to an issuer.
+	workerCtx, cancelWorkers := context.WithCancel(withShutdownDrain(ctx))
+	defer cancelWorkers()
+
 	var wg sync.WaitGroup
 	for i := 0; i < workers; i++ {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			c.worker(ctx)
+			c.worker(workerCtx)
 		}()
 	}
 
-	for _, f := range c.runFirstFuncs {
-		f(ctx)
-	}
-
 	for _, f := range c.runDurationFuncs {
 		f := f // capture range variable
 		go wait.Until(func() { f.fn(ctx) }, f.duration, ctx.Done())
@@ -123,11 +187,37 @@ func (c *controller) Run(workers int, ctx context.Context) error {
 	log.V(logf.InfoLevel).Info("shutting down queue as workqueue signaled shutdown")
 	c.queue.ShutDown()
 	log.V(logf.DebugLevel).Info("waiting for workers to exit...")
-	wg.Wait()
+	c.drainWorkers(log, &wg, cancelWorkers)
 	log.V(logf.DebugLevel).Info("workers exited")
 	return nil
 }
 
+// drainWorkers waits for the workers to finish processing their current
+// items for up to the shutdown grace period, then cancels the items which
+// are still being processed and waits for the workers to exit.
+func (c *controller) drainWorkers(log logr.Logger, wg *sync.WaitGroup, cancelWorkers context.CancelFunc) {
+	exited := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(exited)
+	}()
+
+	if c.shutdownGracePeriod > 0 {
+		timer := time.NewTimer(c.shutdownGracePeriod)
+		defer timer.Stop()
+
+		select {
+		case <-exited:
+			return
+		case <-timer.C:
+			log.V(logf.InfoLevel).Info("cancelling the items still being processed after the shutdown grace period", "gracePeriod", c.shutdownGracePeriod)
+		}
+	}
+
+	cancelWorkers()
+	<-exited
+}
+
 func (c *controller) worker(ctx context.Context) {
 	log := logf.FromContext(ctx)
 
@@ -138,6 +228,13 @@ func (c *controller) worker(ctx context.Context) {
 			break
 		}
 
+		// The items left in the queue once the controller is stopped are not
+		// processed, they are processed again when the controller restarts.
+		if ShuttingDown(ctx) {
+			c.queue.Done(obj)
+			continue
+		}
+
 		// use an inlined function so we can use defer
 		func() {
 			defer c.queue.Done(obj)
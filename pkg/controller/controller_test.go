@@ -0,0 +1,280 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+func TestControllerRunWorkers(t *testing.T) {
+	tests := map[string]struct {
+		workers         int
+		override        int
+		expectedWorkers int
+	}{
+		"runs the number of workers passed to Run by default": {
+			workers:         2,
+			expectedWorkers: 2,
+		},
+		"runs the number of workers configured for the controller": {
+			workers:         1,
+			override:        3,
+			expectedWorkers: 3,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// each item blocks its worker until the controller is stopped
+			started := make(chan struct{})
+			syncFunc := func(ctx context.Context, _ types.NamespacedName) error {
+				select {
+				case started <- struct{}{}:
+				case <-ctx.Done():
+				}
+				<-ctx.Done()
+				return nil
+			}
+
+			queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[types.NamespacedName]())
+			for i := 0; i < 5; i++ {
+				queue.Add(types.NamespacedName{Name: fmt.Sprintf("item-%d", i)})
+			}
+
+			ctrl := newController("test", metrics.New(logf.Log, clock.RealClock{}), syncFunc, nil, nil, queue)
+			ctrl.workers = test.override
+
+			ctx, cancel := context.WithCancel(context.Background())
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = ctrl.Run(test.workers, ctx)
+			}()
+			defer func() {
+				cancel()
+				wg.Wait()
+			}()
+
+			for i := 0; i < test.expectedWorkers; i++ {
+				select {
+				case <-started:
+				case <-time.After(5 * time.Second):
+					t.Fatalf("expected %d items to be processed concurrently, got %d", test.expectedWorkers, i)
+				}
+			}
+
+			select {
+			case <-started:
+				t.Fatalf("expected only %d items to be processed concurrently", test.expectedWorkers)
+			case <-time.After(100 * time.Millisecond):
+			}
+		})
+	}
+}
+
+func TestControllerRunFirstFuncsBeforeWorkers(t *testing.T) {
+	var lock sync.Mutex
+	var calls []string
+	record := func(call string) {
+		lock.Lock()
+		defer lock.Unlock()
+		calls = append(calls, call)
+	}
+
+	processed := make(chan struct{})
+	syncFunc := func(context.Context, types.NamespacedName) error {
+		record("sync")
+		close(processed)
+		return nil
+	}
+
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[types.NamespacedName]())
+	queue.Add(types.NamespacedName{Name: "item"})
+
+	ctrl := newController("test", metrics.New(logf.Log, clock.RealClock{}), syncFunc, nil, nil, queue)
+	ctrl.runFirstFuncs = []runFunc{func(context.Context) {
+		time.Sleep(50 * time.Millisecond)
+		record("warm up")
+	}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = ctrl.Run(1, ctx)
+	}()
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	select {
+	case <-processed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the item to be processed")
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if len(calls) != 2 || calls[0] != "warm up" || calls[1] != "sync" {
+		t.Errorf("expected the first functions to be called before any item is processed, got %v", calls)
+	}
+}
+
+func TestControllerStartsRunnables(t *testing.T) {
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[types.NamespacedName]())
+
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	ctrl := newController("test", metrics.New(logf.Log, clock.RealClock{}), nil, nil, nil, queue)
+	ctrl.runnables = []runFunc{func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		close(stopped)
+	}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = ctrl.Run(1, ctx)
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the runnable to be started")
+	}
+
+	cancel()
+	wg.Wait()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the runnable to be stopped with the controller")
+	}
+}
+
+func TestControllerRunDrainsWorkers(t *testing.T) {
+	tests := map[string]struct {
+		gracePeriod time.Duration
+		// finish is how long the item in flight takes to finish once the
+		// controller is stopped, unless it is cancelled.
+		finish time.Duration
+
+		expectCancelled bool
+	}{
+		"the item in flight completes within the grace period": {
+			gracePeriod: 5 * time.Second,
+			finish:      50 * time.Millisecond,
+		},
+		"the item in flight is cancelled after the grace period": {
+			gracePeriod:     50 * time.Millisecond,
+			finish:          5 * time.Second,
+			expectCancelled: true,
+		},
+		"the item in flight is cancelled immediately without a grace period": {
+			finish:          5 * time.Second,
+			expectCancelled: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			started := make(chan struct{})
+			var lock sync.Mutex
+			var processed []string
+			var cancelled, shuttingDown bool
+			syncFunc := func(ctx context.Context, key types.NamespacedName) error {
+				lock.Lock()
+				processed = append(processed, key.Name)
+				lock.Unlock()
+
+				close(started)
+				for !ShuttingDown(ctx) {
+					time.Sleep(time.Millisecond)
+				}
+
+				select {
+				case <-time.After(test.finish):
+				case <-ctx.Done():
+				}
+
+				lock.Lock()
+				defer lock.Unlock()
+				cancelled = ctx.Err() != nil
+				shuttingDown = ShuttingDown(ctx)
+				return nil
+			}
+
+			queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[types.NamespacedName]())
+			queue.Add(types.NamespacedName{Name: "in-flight"})
+
+			ctrl := newController("test", metrics.New(logf.Log, clock.RealClock{}), syncFunc, nil, nil, queue)
+			ctrl.shutdownGracePeriod = test.gracePeriod
+
+			ctx, cancel := context.WithCancel(context.Background())
+			exited := make(chan struct{})
+			go func() {
+				defer close(exited)
+				_ = ctrl.Run(1, ctx)
+			}()
+
+			select {
+			case <-started:
+			case <-time.After(5 * time.Second):
+				t.Fatal("expected the item to be processed")
+			}
+
+			// Items queued once the controller is stopped are not processed.
+			queue.Add(types.NamespacedName{Name: "queued"})
+			cancel()
+
+			select {
+			case <-exited:
+			case <-time.After(10 * time.Second):
+				t.Fatal("expected the controller to exit")
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+			if !shuttingDown {
+				t.Error("expected the item to observe that the controller is shutting down")
+			}
+			if cancelled != test.expectCancelled {
+				t.Errorf("expected the item to be cancelled: %t, got %t", test.expectCancelled, cancelled)
+			}
+			if len(processed) != 1 {
+				t.Errorf("expected only the item in flight to be processed, got %v", processed)
+			}
+		})
+	}
+}
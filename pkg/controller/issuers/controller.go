@@ -19,6 +19,7 @@ package issuers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
@@ -27,6 +28,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
+	internalissuers "github.com/cert-manager/cert-manager/internal/controller/issuers"
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
@@ -58,6 +60,14 @@ type controller struct {
 
 	// fieldManager is the manager name used for the Apply operations.
 	fieldManager string
+
+	// healthCheckInterval is how often an issuer which was set up
+	// successfully is set up again to verify that it can reach its backend.
+	healthCheckInterval time.Duration
+
+	// failures tracks the consecutive failures to set up each issuer so
+	// that a single transient error does not flap the Ready condition.
+	failures *internalissuers.FailureTracker
 }
 
 // Register registers and constructs the controller using the provided context.
@@ -101,6 +111,8 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.TypedRateLi
 	c.issuerFactory = issuer.NewFactory(ctx)
 	c.cmClient = ctx.CMClient
 	c.fieldManager = ctx.FieldManager
+	c.healthCheckInterval = ctx.IssuerOptions.IssuerHealthCheckInterval
+	c.failures = internalissuers.NewFailureTracker()
 	c.recorder = ctx.Recorder
 
 	return c.queue, mustSync, nil
@@ -137,6 +149,7 @@ func (c *controller) ProcessItem(ctx context.Context, key types.NamespacedName)
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
 			log.Error(err, "issuer in work queue no longer exists")
+			c.failures.Reset(key)
 			return nil
 		}
 
@@ -22,6 +22,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/errors"
 
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
@@ -56,14 +57,25 @@ func (c *controller) Sync(ctx context.Context, iss *cmapi.Issuer) (err error) {
 		return err
 	}
 
+	key := types.NamespacedName{Namespace: iss.Namespace, Name: iss.Name}
+
 	err = i.Setup(ctx)
 	if err != nil {
 		s := messageErrorInitIssuer + err.Error()
 		log.V(logf.WarnLevel).Info(s)
 		c.recorder.Event(issuerCopy, corev1.EventTypeWarning, errorInitIssuer, s)
+
+		if failures := c.failures.Failed(key); internalissuers.KeepReadyCondition(iss, issuerCopy, failures) {
+			log.V(logf.InfoLevel).Info("keeping Ready condition until the issuer fails to be set up repeatedly", "failures", failures, "threshold", internalissuers.ReadyFailureThreshold)
+		}
 		return err
 	}
 
+	c.failures.Reset(key)
+	if c.healthCheckInterval > 0 {
+		c.queue.AddAfter(key, c.healthCheckInterval)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// RequestMutator is invoked on a CertificateRequest before it is submitted to
+// the backend of an issuer, so that organisation specific logic, such as
+// enriching the annotations of requests or enforcing naming conventions, can
+// be plugged in without forking the issuer.
+type RequestMutator interface {
+	// MutateRequest may modify the metadata of the CertificateRequest, such
+	// as its annotations and labels. The spec of a CertificateRequest,
+	// including its CSR, is immutable, so changes to it are discarded.
+	//
+	// Returning a *RequestAbortedError fails the request. Any other error is
+	// treated as transient and the request is retried, so mutators may be
+	// invoked more than once for the same request and must be idempotent.
+	// Implementations must be safe for concurrent use.
+	MutateRequest(ctx context.Context, cr *cmapi.CertificateRequest, issuer cmapi.GenericIssuer) error
+}
+
+// RequestMutatorFunc is a function which implements RequestMutator.
+type RequestMutatorFunc func(ctx context.Context, cr *cmapi.CertificateRequest, issuer cmapi.GenericIssuer) error
+
+func (f RequestMutatorFunc) MutateRequest(ctx context.Context, cr *cmapi.CertificateRequest, issuer cmapi.GenericIssuer) error {
+	return f(ctx, cr, issuer)
+}
+
+// NoOpRequestMutator is a RequestMutator which leaves requests unchanged.
+var NoOpRequestMutator RequestMutator = RequestMutatorFunc(func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer) error {
+	return nil
+})
+
+// RequestAbortedError is returned by a RequestMutator to fail the
+// CertificateRequest with the given reason and message, rather than
+// submitting it to the backend of the issuer.
+type RequestAbortedError struct {
+	// Reason is the machine-readable reason the request is failed with.
+	Reason string
+
+	// Message is the human-readable explanation of why the request was
+	// aborted.
+	Message string
+}
+
+// NewRequestAbortedError returns a RequestAbortedError with the given reason
+// and message.
+func NewRequestAbortedError(reason, message string) error {
+	return &RequestAbortedError{Reason: reason, Message: message}
+}
+
+func (e *RequestAbortedError) Error() string {
+	return e.Message
+}
+
+// MutateRequest invokes the mutators in order on a copy of the
+// CertificateRequest, and applies the changes they made to its annotations
+// and labels to the request. If a mutator returns an error, the remaining
+// mutators are not invoked, the request is left unchanged and the error is
+// returned.
+func MutateRequest(ctx context.Context, mutators []RequestMutator, cr *cmapi.CertificateRequest, issuer cmapi.GenericIssuer) error {
+	if len(mutators) == 0 {
+		return nil
+	}
+
+	mutated := cr.DeepCopy()
+	for _, mutator := range mutators {
+		if err := mutator.MutateRequest(ctx, mutated, issuer); err != nil {
+			return err
+		}
+	}
+
+	cr.Annotations = mutated.Annotations
+	cr.Labels = mutated.Labels
+
+	return nil
+}
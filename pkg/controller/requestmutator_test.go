@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestMutateRequest(t *testing.T) {
+	issuer := gen.Issuer("test-issuer")
+
+	setAnnotation := func(key, value string) RequestMutator {
+		return RequestMutatorFunc(func(_ context.Context, cr *cmapi.CertificateRequest, _ cmapi.GenericIssuer) error {
+			metav1.SetMetaDataAnnotation(&cr.ObjectMeta, key, value)
+			return nil
+		})
+	}
+	setLabelAndSpec := RequestMutatorFunc(func(_ context.Context, cr *cmapi.CertificateRequest, _ cmapi.GenericIssuer) error {
+		metav1.SetMetaDataLabel(&cr.ObjectMeta, "team", "web")
+		cr.Spec.Request = []byte("mutated")
+		return nil
+	})
+	abort := RequestMutatorFunc(func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer) error {
+		return NewRequestAbortedError("NamingViolation", "the name of the request does not follow the naming convention")
+	})
+
+	tests := map[string]struct {
+		mutators []RequestMutator
+
+		expectedAnnotations map[string]string
+		expectedLabels      map[string]string
+		expectedErr         error
+	}{
+		"if there are no mutators then the request is unchanged": {
+			expectedAnnotations: map[string]string{"existing": "true"},
+		},
+		"the no-op mutator leaves the request unchanged": {
+			mutators:            []RequestMutator{NoOpRequestMutator},
+			expectedAnnotations: map[string]string{"existing": "true"},
+		},
+		"mutators are invoked in order and their changes to the metadata are kept": {
+			mutators:            []RequestMutator{setAnnotation("a", "first"), setAnnotation("a", "second"), setLabelAndSpec},
+			expectedAnnotations: map[string]string{"existing": "true", "a": "second"},
+			expectedLabels:      map[string]string{"team": "web"},
+		},
+		"if a mutator aborts then no changes are kept and the remaining mutators are not invoked": {
+			mutators:            []RequestMutator{setAnnotation("a", "first"), abort, setLabelAndSpec},
+			expectedAnnotations: map[string]string{"existing": "true"},
+			expectedErr:         NewRequestAbortedError("NamingViolation", "the name of the request does not follow the naming convention"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := gen.CertificateRequest("test-cr",
+				gen.SetCertificateRequestCSR([]byte("original")),
+				gen.SetCertificateRequestAnnotations(map[string]string{"existing": "true"}),
+			)
+
+			err := MutateRequest(context.Background(), test.mutators, cr, issuer)
+			assert.Equal(t, test.expectedErr, err)
+
+			var aborted *RequestAbortedError
+			assert.Equal(t, test.expectedErr != nil, errors.As(err, &aborted))
+
+			assert.Equal(t, test.expectedAnnotations, cr.Annotations)
+			assert.Equal(t, test.expectedLabels, cr.Labels)
+
+			// The spec of a CertificateRequest is immutable.
+			assert.Equal(t, []byte("original"), cr.Spec.Request)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+)
+
+type shutdownKey struct{}
+
+// withShutdownDrain returns the context the workers of a controller process
+// items with. It is not cancelled when the controller is stopped, so that
+// the items in flight can complete during the shutdown grace period, but
+// records that the controller is shutting down once stopped is done.
+func withShutdownDrain(stopped context.Context) context.Context {
+	return context.WithValue(context.WithoutCancel(stopped), shutdownKey{}, stopped)
+}
+
+// ShuttingDown returns true if the controller processing the item of the
+// given context has started shutting down. Its workers are then draining
+// the items in flight, which should not start new work that could not be
+// completed within the shutdown grace period.
+func ShuttingDown(ctx context.Context) bool {
+	stopped, ok := ctx.Value(shutdownKey{}).(context.Context)
+	return ok && stopped.Err() != nil
+}
+
+// CancelOnShutdown returns a copy of ctx which is also cancelled once the
+// controller processing the item of ctx starts shutting down, rather than
+// once the shutdown grace period has elapsed. It is used for the steps of
+// processing an item which must not be started while the controller is
+// draining, such as waiting to make a request to an issuer.
+func CancelOnShutdown(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	stopped, ok := ctx.Value(shutdownKey{}).(context.Context)
+	if !ok {
+		return ctx, cancel
+	}
+
+	stop := context.AfterFunc(stopped, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
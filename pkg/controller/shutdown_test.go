@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCancelOnShutdown(t *testing.T) {
+	stopped, stop := context.WithCancel(context.Background())
+	ctx := withShutdownDrain(stopped)
+
+	shutdownCtx, cancel := CancelOnShutdown(ctx)
+	defer cancel()
+
+	if ShuttingDown(ctx) || shutdownCtx.Err() != nil {
+		t.Fatal("expected the controller not to be shutting down")
+	}
+
+	stop()
+	<-shutdownCtx.Done()
+
+	if !ShuttingDown(ctx) {
+		t.Error("expected the controller to be shutting down")
+	}
+	if ctx.Err() != nil {
+		t.Error("expected the context of the item not to be cancelled during the shutdown")
+	}
+}
+
+func TestCancelOnShutdownOutsideController(t *testing.T) {
+	ctx, cancel := CancelOnShutdown(context.Background())
+	if ShuttingDown(ctx) || ctx.Err() != nil {
+		t.Error("expected a context outside of a controller never to be shutting down")
+	}
+
+	cancel()
+	if ctx.Err() == nil {
+		t.Error("expected the context to be cancelled by its cancel func")
+	}
+}
@@ -49,6 +49,7 @@ import (
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/util"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	discoveryfake "github.com/cert-manager/cert-manager/test/unit/discovery"
 )
 
@@ -174,6 +175,9 @@ func (b *Builder) Init() {
 	// Fix the clock used in apiutil so that calls to set status conditions
 	// can be predictably tested
 	apiutil.Clock = b.Context.Clock
+	// Fix the clock used for the validity of certificate templates, so that
+	// signed certificates can be predictably tested
+	pki.Clock = b.Context.Clock
 }
 
 // InitWithRESTConfig() will call builder.Init(), then assign an initialised
@@ -308,6 +312,7 @@ func (b *Builder) Stop() {
 	b.stopCh = nil
 	// Reset the clock back to the RealClock in apiutil
 	apiutil.Clock = clock.RealClock{}
+	pki.Clock = clock.RealClock{}
 }
 
 func (b *Builder) Start() {
@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// tracerName is the name of the OpenTelemetry tracer used by the controllers.
+const tracerName = "github.com/cert-manager/cert-manager/pkg/controller"
+
+// StartReconcileSpan starts a span for a reconcile of a controller, and adds
+// its trace ID to the logger of the returned context, so that the log lines of
+// the reconcile can be correlated with the systems it calls. The span
+// continues the trace of the given context if it has one, and otherwise starts
+// a new trace using the global OpenTelemetry tracer provider. When no tracing
+// is configured, there is no trace ID and the context is only changed to carry
+// the no-op span. The returned function must be called to end the span.
+func StartReconcileSpan(ctx context.Context, name string) (context.Context, func()) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, name)
+
+	if traceID, ok := TraceIDFromContext(ctx); ok {
+		ctx = logf.NewContext(ctx, logf.FromContext(ctx).WithValues(logf.TraceIDKey, traceID))
+	}
+
+	return ctx, func() { span.End() }
+}
+
+// TraceIDFromContext returns the ID of the trace of the span of the context,
+// if any.
+func TraceIDFromContext(ctx context.Context) (string, bool) {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.HasTraceID() {
+		return "", false
+	}
+	return spanCtx.TraceID().String(), true
+}
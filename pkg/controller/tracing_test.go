@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestStartReconcileSpan(t *testing.T) {
+	traceID := trace.TraceID{0x01, 0x02, 0x03, 0x04}
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  trace.SpanID{0x01},
+	})
+
+	tests := map[string]struct {
+		ctx func(context.Context) context.Context
+
+		expectedLog string
+	}{
+		"no trace ID is logged when tracing is not configured": {
+			ctx:         func(ctx context.Context) context.Context { return ctx },
+			expectedLog: `"level"=0 "msg"="reconcile"`,
+		},
+		"the trace ID of the context is logged": {
+			ctx: func(ctx context.Context) context.Context {
+				return trace.ContextWithSpanContext(ctx, parent)
+			},
+			expectedLog: `"level"=0 "msg"="reconcile" "trace_id"="` + traceID.String() + `"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var lines []string
+			log := funcr.New(func(_, args string) {
+				lines = append(lines, args)
+			}, funcr.Options{})
+
+			ctx, end := StartReconcileSpan(test.ctx(logr.NewContext(context.Background(), log)), "test")
+			defer end()
+
+			logr.FromContextOrDiscard(ctx).Info("reconcile")
+			assert.Equal(t, []string{test.expectedLog}, lines)
+		})
+	}
+}
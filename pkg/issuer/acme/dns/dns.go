@@ -96,7 +96,7 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 		return err
 	}
 
-	fqdn, err := util.DNS01LookupFQDN(ctx, ch.Spec.DNSName, followCNAME(providerConfig.CNAMEStrategy), s.DNS01Nameservers...)
+	fqdn, err := util.DNS01LookupFQDN(ctx, ch.Spec.DNSName, followCNAME(providerConfig.CNAMEStrategy), s.cnameNameservers(providerConfig)...)
 	if err != nil {
 		return err
 	}
@@ -154,7 +154,7 @@ func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 		return err
 	}
 
-	fqdn, err := util.DNS01LookupFQDN(ctx, ch.Spec.DNSName, followCNAME(providerConfig.CNAMEStrategy), s.DNS01Nameservers...)
+	fqdn, err := util.DNS01LookupFQDN(ctx, ch.Spec.DNSName, followCNAME(providerConfig.CNAMEStrategy), s.cnameNameservers(providerConfig)...)
 	if err != nil {
 		return err
 	}
@@ -166,6 +166,16 @@ func followCNAME(strategy cmacme.CNAMEStrategy) bool {
 	return strategy == cmacme.FollowStrategy
 }
 
+// cnameNameservers returns the nameservers that should be used to follow the
+// CNAME chain of the challenge record, preferring those configured on the
+// solver over the controller wide recursive nameservers.
+func (s *Solver) cnameNameservers(cfg *cmacme.ACMEChallengeSolverDNS01) []string {
+	if len(cfg.CNAMENameservers) > 0 {
+		return cfg.CNAMENameservers
+	}
+	return s.DNS01Nameservers
+}
+
 func extractChallengeSolverConfig(ch *cmacme.Challenge) (*cmacme.ACMEChallengeSolverDNS01, error) {
 	if ch.Spec.Solver.DNS01 == nil {
 		return nil, fmt.Errorf("no dns01 challenge solver configuration found")
@@ -450,7 +460,7 @@ func (s *Solver) prepareChallengeRequest(ctx context.Context, issuer v1.GenericI
 		return nil, nil, err
 	}
 
-	fqdn, err := util.DNS01LookupFQDN(ctx, ch.Spec.DNSName, followCNAME(dns01Config.CNAMEStrategy), s.DNS01Nameservers...)
+	fqdn, err := util.DNS01LookupFQDN(ctx, ch.Spec.DNSName, followCNAME(dns01Config.CNAMEStrategy), s.cnameNameservers(dns01Config)...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -655,3 +655,28 @@ func TestRoute53AssumeRole(t *testing.T) {
 		}
 	}
 }
+
+func TestCNAMENameservers(t *testing.T) {
+	s := &Solver{
+		Context: &controller.Context{
+			ContextOptions: controller.ContextOptions{
+				ACMEOptions: controller.ACMEOptions{
+					DNS01Nameservers: []string{"8.8.8.8:53"},
+				},
+			},
+		},
+	}
+
+	got := s.cnameNameservers(&cmacme.ACMEChallengeSolverDNS01{})
+	if !reflect.DeepEqual(got, []string{"8.8.8.8:53"}) {
+		t.Errorf("expected the controller nameservers to be used, got %v", got)
+	}
+
+	got = s.cnameNameservers(&cmacme.ACMEChallengeSolverDNS01{
+		CNAMEStrategy:    cmacme.FollowStrategy,
+		CNAMENameservers: []string{"10.0.0.53:53"},
+	})
+	if !reflect.DeepEqual(got, []string{"10.0.0.53:53"}) {
+		t.Errorf("expected the solver nameservers to be used, got %v", got)
+	}
+}
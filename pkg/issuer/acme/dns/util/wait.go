@@ -11,6 +11,7 @@ package util
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -55,6 +56,10 @@ var RecursiveNameservers = getNameservers(defaultResolvConf, defaultNameservers)
 // DNSTimeout is used to override the default DNS timeout of 10 seconds.
 var DNSTimeout = 10 * time.Second
 
+// ErrCNAMELoop is returned when following the CNAME records of a domain leads
+// back to a domain that has already been visited.
+var ErrCNAMELoop = errors.New("CNAME loop detected")
+
 // getNameservers attempts to get systems nameservers before falling back to the defaults
 func getNameservers(path string, defaults []string) []string {
 	config, err := dns.ClientConfigFromFile(path)
@@ -97,7 +102,7 @@ func followCNAMEs(ctx context.Context, fqdn string, nameservers []string, fqdnCh
 			if cn.Target != fqdnInChain {
 				continue
 			}
-			return "", fmt.Errorf("Found recursive CNAME record to %q when looking up %q", cn.Target, fqdn)
+			return "", fmt.Errorf("Found recursive CNAME record to %q when looking up %q: %w", cn.Target, fqdn, ErrCNAMELoop)
 		}
 		return followCNAMEs(ctx, cn.Target, nameservers, append(fqdnChain, fqdn)...)
 	}
@@ -10,6 +10,7 @@ package util
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"sort"
@@ -367,10 +368,11 @@ func Test_followCNAMEs(t *testing.T) {
 		fqdnChain   []string
 	}
 	tests := []struct {
-		name    string
-		args    args
-		want    string
-		wantErr bool
+		name     string
+		args     args
+		want     string
+		wantErr  bool
+		wantLoop bool
 	}{
 		{
 			name: "Resolve CNAME 3 down",
@@ -400,7 +402,8 @@ func Test_followCNAMEs(t *testing.T) {
 			args: args{
 				fqdn: "recursive.example.com",
 			},
-			wantErr: true,
+			wantErr:  true,
+			wantLoop: true,
 		},
 	}
 	for _, tt := range tests {
@@ -410,6 +413,10 @@ func Test_followCNAMEs(t *testing.T) {
 				t.Errorf("followCNAMEs() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if errors.Is(err, ErrCNAMELoop) != tt.wantLoop {
+				t.Errorf("followCNAMEs() error = %v, wantLoop %v", err, tt.wantLoop)
+				return
+			}
 			if got != tt.want {
 				t.Errorf("followCNAMEs() got = %v, want %v", got, tt.want)
 			}
@@ -22,6 +22,7 @@ import (
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
@@ -30,6 +31,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/cert-manager/cert-manager/pkg/acme"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
@@ -51,11 +53,13 @@ const (
 
 	successAccountRegistered = "ACMEAccountRegistered"
 	successAccountVerified   = "ACMEAccountVerified"
+	successEABRotated        = "EABRotated"
 
 	messageAccountRegistrationFailed     = "Failed to register ACME account: "
 	messageAccountVerificationFailed     = "Failed to verify ACME account: "
 	messageAccountUpdateFailed           = "Failed to update ACME account:"
 	messageAccountRegistered             = "The ACME account was registered with the ACME server"
+	messageEABRotated                    = "The External Account Binding credentials changed and the ACME account was registered again with the ACME server"
 	messageAccountVerified               = "The ACME account was verified with the ACME server"
 	messageNoSecretKeyGenerationDisabled = "the ACME issuer config has 'disableAccountKeyGeneration' set to true, but the secret was not found: "
 	messageInvalidPrivateKey             = "Account private key is invalid: "
@@ -141,6 +145,43 @@ func (a *Acme) Setup(ctx context.Context) error {
 		msg = messageAccountVerificationFailed + err.Error()
 		return fmt.Errorf(msg)
 	}
+
+	// Retrieve the External Account Binding key up front so that rotated
+	// credentials can be detected. Errors are handled below, only if the
+	// account needs to be registered, because the External Account Binding
+	// Secret may have been removed after the account was registered.
+	var eabKey []byte
+	var eabErr error
+	var eabHash string
+	eabObj := a.issuer.GetSpec().ACME.ExternalAccountBinding
+	if eabObj != nil {
+		eabKey, eabErr = a.getEABKey(ctx, ns)
+		if eabErr == nil {
+			eabHash = externalAccountBindingHash(eabObj.KeyID, eabKey)
+		}
+	}
+
+	lastEABHash := a.issuer.GetStatus().ACMEStatus().LastExternalAccountBindingHash
+	eabRotated := eabHash != "" && lastEABHash != "" && eabHash != lastEABHash
+	if eabRotated {
+		log.V(logf.InfoLevel).Info("External Account Binding credentials have changed, registering a new ACME account")
+
+		// An existing account cannot be bound to a different external
+		// account, so a new account private key is needed to register a
+		// new account with the rotated credentials. If account key
+		// generation is disabled, the user is expected to rotate the
+		// account private key themselves.
+		if !a.issuer.GetSpec().ACME.DisableAccountKeyGeneration {
+			pk, err = a.rotateAccountPrivateKey(ctx, privateKeySelector, ns)
+			if err != nil {
+				reason = errorAccountRegistrationFailed
+				msg = messageAccountRegistrationFailed + err.Error()
+				return fmt.Errorf(msg)
+			}
+		}
+		a.issuer.GetStatus().ACMEStatus().URI = ""
+	}
+
 	rsaPk, ok := pk.(*rsa.PrivateKey)
 	if !ok {
 		reason = errorAccountVerificationFailed
@@ -204,7 +245,7 @@ func (a *Acme) Setup(ctx context.Context) error {
 		a.issuer.GetStatus().ACMEStatus().URI != "" &&
 		parsedAccountURL.Host == parsedServerURL.Host &&
 		a.issuer.GetStatus().ACMEStatus().LastRegisteredEmail == a.issuer.GetSpec().ACME.Email &&
-		isPKChecksumSame {
+		isPKChecksumSame && !eabRotated {
 		log.V(logf.InfoLevel).Info("skipping re-verifying ACME account as cached registration " +
 			"details look sufficient")
 
@@ -216,6 +257,12 @@ func (a *Acme) Setup(ctx context.Context) error {
 		msg = messageAccountRegistered
 		status = cmmeta.ConditionTrue
 
+		// Record the External Account Binding hash for accounts registered
+		// before it was tracked, so that later rotations are detected.
+		if eabHash != "" {
+			a.issuer.GetStatus().ACMEStatus().LastExternalAccountBindingHash = eabHash
+		}
+
 		// ensure the cached client in the account registry is up to date
 		a.accountRegistry.AddClient(httpClient, string(a.issuer.GetUID()), *a.issuer.GetSpec().ACME, rsaPk, a.userAgent)
 		return nil
@@ -228,22 +275,21 @@ func (a *Acme) Setup(ctx context.Context) error {
 	}
 
 	var eabAccount *acmeapi.ExternalAccountBinding
-	if eabObj := a.issuer.GetSpec().ACME.ExternalAccountBinding; eabObj != nil {
-		eabKey, err := a.getEABKey(ctx, ns)
+	if eabObj != nil {
 		switch {
 		// Do not re-try if we fail to get the MAC key as it does not exist at the reference.
-		case apierrors.IsNotFound(err), errors.IsInvalidData(err):
-			log.Error(err, "failed to verify ACME account")
+		case apierrors.IsNotFound(eabErr), errors.IsInvalidData(eabErr):
+			log.Error(eabErr, "failed to verify ACME account")
 			reason = errorAccountRegistrationFailed
-			msg = messageAccountRegistrationFailed + err.Error()
+			msg = messageAccountRegistrationFailed + eabErr.Error()
 			a.recorder.Event(a.issuer, corev1.EventTypeWarning,
 				errorAccountRegistrationFailed,
 				msg)
 			return nil
 
-		case err != nil:
+		case eabErr != nil:
 			reason = errorAccountRegistrationFailed
-			msg = messageAccountRegistrationFailed + err.Error()
+			msg = messageAccountRegistrationFailed + eabErr.Error()
 			return fmt.Errorf(msg)
 		}
 
@@ -322,6 +368,10 @@ func (a *Acme) Setup(ctx context.Context) error {
 	a.issuer.GetStatus().ACMEStatus().URI = account.URI
 	a.issuer.GetStatus().ACMEStatus().LastRegisteredEmail = registeredEmail
 	a.issuer.GetStatus().ACMEStatus().LastPrivateKeyHash = checksumString
+	a.issuer.GetStatus().ACMEStatus().LastExternalAccountBindingHash = eabHash
+	if eabRotated {
+		a.recorder.Event(a.issuer, corev1.EventTypeNormal, successEABRotated, messageEABRotated)
+	}
 	// ensure the cached client in the account registry is up to date
 	a.accountRegistry.AddClient(httpClient, string(a.issuer.GetUID()), *a.issuer.GetSpec().ACME, rsaPk, a.userAgent)
 
@@ -420,6 +470,42 @@ func (a *Acme) getEABKey(ctx context.Context, ns string) ([]byte, error) {
 	return keyData, nil
 }
 
+// externalAccountBindingHash returns a hash of the External Account Binding
+// key ID and key, used to detect when the credentials are rotated.
+func externalAccountBindingHash(keyID string, key []byte) string {
+	h := sha256.New()
+	h.Write([]byte(keyID))
+	h.Write([]byte{0})
+	h.Write(key)
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// rotateAccountPrivateKey will generate a new RSA private key, and store it in
+// the existing account private key secret resource in the apiserver.
+func (a *Acme) rotateAccountPrivateKey(ctx context.Context, sel cmmeta.SecretKeySelector, ns string) (*rsa.PrivateKey, error) {
+	sel = acme.PrivateKeySelector(sel)
+	accountPrivKey, err := pki.GenerateRSAPrivateKey(pki.MinRSAKeySize)
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"data": map[string][]byte{
+			sel.Key: pki.EncodePKCS1PrivateKey(accountPrivKey),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = a.secretsClient.Secrets(ns).Patch(ctx, sel.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return accountPrivKey, nil
+}
+
 // createAccountPrivateKey will generate a new RSA private key, and create it
 // as a secret resource in the apiserver.
 func (a *Acme) createAccountPrivateKey(ctx context.Context, sel cmmeta.SecretKeySelector, ns string) (*rsa.PrivateKey, error) {
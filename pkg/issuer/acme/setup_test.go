@@ -133,12 +133,17 @@ func TestAcme_Setup(t *testing.T) {
 		eabSecret       *corev1.Secret
 		eabSecretGetErr error
 
+		// Error returned when rotating the ACME account key.
+		acmePrivKeySecretPatchErr error
+
 		// expected ACME account passed to cl.Register
 		expectedRegisteredAcc *acmeapi.Account
 		// expected issuer conditions after Setup has been called.
 		expectedConditions []cmapi.IssuerCondition
 		expectedEvents     []string
-		wantsErr           bool
+		// expected External Account Binding hash in the issuer status, if set.
+		expectedLastEABHash string
+		wantsErr            bool
 	}{
 		"LetsEncrypt ACME v1 prod URL specified, return early": {
 			issuer: gen.IssuerFrom(baseIssuer,
@@ -365,6 +370,62 @@ func TestAcme_Setup(t *testing.T) {
 					gen.SetIssuerConditionMessage(messageAccountRegistered)),
 			},
 		},
+		"ACME Issuer is ready, EAB credentials have been rotated, account key is rotated and a new account registered": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEAccountURL(acmev2Prod),
+				gen.SetIssuerACMEEAB(someString, someString),
+				gen.SetIssuerACMELastPrivateKeyHash(someString),
+				gen.SetIssuerACMELastExternalAccountBindingHash("old-eab-hash"),
+				gen.AddIssuerCondition(*gen.IssuerConditionFrom(readyTrueCondition))),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+			eabSecret:                  eabSecret,
+			expectedRegisteredAcc: &acmeapi.Account{ExternalAccountBinding: &acmeapi.ExternalAccountBinding{
+				KID: someString,
+				Key: []byte(eabKey),
+			}},
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition),
+			},
+			expectedEvents: []string{
+				fmt.Sprintf("%s %s %s", corev1.EventTypeNormal, successEABRotated, messageEABRotated),
+			},
+			expectedLastEABHash: externalAccountBindingHash(someString, []byte(eabKey)),
+		},
+		"ACME Issuer is ready, EAB credentials have not changed, skip re-verifying": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEAccountURL(acmev2Prod),
+				gen.SetIssuerACMEEAB(someString, someString),
+				gen.SetIssuerACMELastPrivateKeyHash(someString),
+				gen.SetIssuerACMELastExternalAccountBindingHash(externalAccountBindingHash(someString, []byte(eabKey))),
+				gen.AddIssuerCondition(*gen.IssuerConditionFrom(readyTrueCondition))),
+			kfsKey:                     rsaPrivKey,
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+			eabSecret:                  eabSecret,
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition),
+			},
+			expectedLastEABHash: externalAccountBindingHash(someString, []byte(eabKey)),
+		},
+		"ACME Issuer is ready, EAB credentials have been rotated, rotating the account key fails": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEAccountURL(acmev2Prod),
+				gen.SetIssuerACMEEAB(someString, someString),
+				gen.SetIssuerACMELastPrivateKeyHash(someString),
+				gen.SetIssuerACMELastExternalAccountBindingHash("old-eab-hash"),
+				gen.AddIssuerCondition(*gen.IssuerConditionFrom(readyTrueCondition))),
+			kfsKey:                    rsaPrivKey,
+			eabSecret:                 eabSecret,
+			acmePrivKeySecretPatchErr: someErr,
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyFalseCondition,
+					gen.SetIssuerConditionReason(errorAccountRegistrationFailed),
+					gen.SetIssuerConditionMessage(messageAccountRegistrationFailed+someErr.Error())),
+			},
+			wantsErr: true,
+		},
 		"ACME account with legacy EAB key algorithm set and with an email is registered successfully": {
 			issuer: gen.IssuerFrom(baseIssuer,
 				gen.SetIssuerACMEEmail(someEmail),
@@ -523,6 +584,8 @@ func TestAcme_Setup(t *testing.T) {
 					test.acmePrivKeySecretCreateErr),
 				coreclients.SetFakeSecretsGetterGet(test.eabSecret,
 					test.eabSecretGetErr),
+				coreclients.SetFakeSecretsGetterPatch(nil,
+					test.acmePrivKeySecretPatchErr),
 			)
 
 			// Set up a mock keyFromSecret.
@@ -610,6 +673,14 @@ func TestAcme_Setup(t *testing.T) {
 					test.expectedConditions, gotConditions)
 			}
 
+			// Verify the External Account Binding hash recorded in the status.
+			if test.expectedLastEABHash != "" {
+				if got := a.issuer.GetStatus().ACMEStatus().LastExternalAccountBindingHash; got != test.expectedLastEABHash {
+					t.Errorf("Expected LastExternalAccountBindingHash %q, got %q",
+						test.expectedLastEABHash, got)
+				}
+			}
+
 			// Verify that the expected events were recorded.
 			if !slices.Equal(test.expectedEvents, recorder.Events) {
 				t.Errorf("Expected events:\n%+#v\ngot:%+#v",
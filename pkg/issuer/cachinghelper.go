@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"sync"
+
+	"k8s.io/client-go/tools/cache"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+// issuerCacheKey identifies an Issuer or ClusterIssuer in a CachingHelper.
+// The namespace of ClusterIssuers is empty.
+type issuerCacheKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// CachingHelper is a Helper which caches the issuers returned by another
+// Helper, by issuer ref, until they are invalidated by an informer event.
+//
+// Invalidate must be called with every Issuer and ClusterIssuer added,
+// updated or deleted, before the resources referencing it are queued, so that
+// the syncs triggered by a change of an issuer read the changed issuer.
+// The issuers returned are shared with the informer cache and other callers,
+// and must not be modified.
+type CachingHelper struct {
+	helper  Helper
+	metrics *metrics.Metrics
+
+	lock    sync.Mutex
+	issuers map[issuerCacheKey]cmapi.GenericIssuer
+	// generation is incremented by each invalidation. An issuer read from
+	// the helper is only cached if no invalidation happened while it was
+	// being read, as it may have been read before the change which was
+	// invalidated.
+	generation uint64
+}
+
+var _ Helper = &CachingHelper{}
+
+// NewCachingHelper returns a CachingHelper which caches the issuers returned
+// by the given helper. The hits and misses of the cache are recorded to the
+// given metrics, if it is not nil.
+func NewCachingHelper(helper Helper, metrics *metrics.Metrics) *CachingHelper {
+	return &CachingHelper{
+		helper:  helper,
+		metrics: metrics,
+		issuers: make(map[issuerCacheKey]cmapi.GenericIssuer),
+	}
+}
+
+// GetGenericIssuer returns the cached issuer for the given IssuerRef and
+// namespace, or reads it from the underlying helper and caches it.
+// Errors are not cached.
+func (h *CachingHelper) GetGenericIssuer(ref cmmeta.ObjectReference, ns string) (cmapi.GenericIssuer, error) {
+	key, ok := refCacheKey(ref, ns)
+	if !ok {
+		return h.helper.GetGenericIssuer(ref, ns)
+	}
+
+	h.lock.Lock()
+	issuer, ok := h.issuers[key]
+	generation := h.generation
+	h.lock.Unlock()
+
+	if ok {
+		h.metrics.IncrementIssuerCacheLookup(ref, metrics.IssuerCacheResultHit)
+		return issuer, nil
+	}
+
+	h.metrics.IncrementIssuerCacheLookup(ref, metrics.IssuerCacheResultMiss)
+	issuer, err := h.helper.GetGenericIssuer(ref, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.generation == generation {
+		h.issuers[key] = issuer
+	}
+
+	return issuer, nil
+}
+
+// Invalidate removes the given Issuer or ClusterIssuer from the cache. obj
+// may be a cache.DeletedFinalStateUnknown wrapping the issuer, as passed to
+// the DeleteFunc of informer event handlers. Other objects are ignored.
+// Invalidate does nothing if h is nil.
+func (h *CachingHelper) Invalidate(obj interface{}) {
+	if h == nil {
+		return
+	}
+
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	var key issuerCacheKey
+	switch issuer := obj.(type) {
+	case *cmapi.Issuer:
+		key = issuerCacheKey{kind: cmapi.IssuerKind, namespace: issuer.Namespace, name: issuer.Name}
+	case *cmapi.ClusterIssuer:
+		key = issuerCacheKey{kind: cmapi.ClusterIssuerKind, name: issuer.Name}
+	default:
+		return
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	delete(h.issuers, key)
+	h.generation++
+}
+
+// refCacheKey returns the cache key of the issuer referenced by the given
+// IssuerRef in the given namespace, or false if the ref has an invalid kind.
+func refCacheKey(ref cmmeta.ObjectReference, ns string) (issuerCacheKey, bool) {
+	switch ref.Kind {
+	case "", cmapi.IssuerKind:
+		return issuerCacheKey{kind: cmapi.IssuerKind, namespace: ns, name: ref.Name}, true
+	case cmapi.ClusterIssuerKind:
+		return issuerCacheKey{kind: cmapi.ClusterIssuerKind, name: ref.Name}, true
+	default:
+		return issuerCacheKey{}, false
+	}
+}
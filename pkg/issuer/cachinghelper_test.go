@@ -0,0 +1,274 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/cache"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// countingHelper is a Helper which returns the issuers in its map and counts
+// the lookups made, standing in for the listers.
+type countingHelper struct {
+	issuers map[issuerCacheKey]cmapi.GenericIssuer
+	calls   int
+	// onGet, if set, is called after the issuer has been read.
+	onGet func()
+}
+
+func (h *countingHelper) GetGenericIssuer(ref cmmeta.ObjectReference, ns string) (cmapi.GenericIssuer, error) {
+	h.calls++
+	key, _ := refCacheKey(ref, ns)
+	issuer, ok := h.issuers[key]
+	if h.onGet != nil {
+		h.onGet()
+	}
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return issuer, nil
+}
+
+func (h *countingHelper) set(issuer cmapi.GenericIssuer) {
+	kind := cmapi.IssuerKind
+	if _, ok := issuer.(*cmapi.ClusterIssuer); ok {
+		kind = cmapi.ClusterIssuerKind
+	}
+	h.issuers[issuerCacheKey{kind: kind, namespace: issuer.GetNamespace(), name: issuer.GetName()}] = issuer
+}
+
+func newCountingHelper(issuers ...cmapi.GenericIssuer) *countingHelper {
+	h := &countingHelper{issuers: make(map[issuerCacheKey]cmapi.GenericIssuer)}
+	for _, issuer := range issuers {
+		h.set(issuer)
+	}
+	return h
+}
+
+var (
+	issuerRef        = cmmeta.ObjectReference{Name: "issuer", Kind: cmapi.IssuerKind}
+	clusterIssuerRef = cmmeta.ObjectReference{Name: "issuer", Kind: cmapi.ClusterIssuerKind}
+)
+
+func TestCachingHelperBurst(t *testing.T) {
+	m := metrics.New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()))
+	inner := newCountingHelper(gen.Issuer("issuer", gen.SetIssuerNamespace("ns")))
+	h := NewCachingHelper(inner, m)
+
+	// A burst of syncs of CertificateRequests referencing the same issuer
+	// reads the lister once.
+	for i := 0; i < 100; i++ {
+		issuer, err := h.GetGenericIssuer(issuerRef, "ns")
+		require.NoError(t, err)
+		assert.Equal(t, "issuer", issuer.GetName())
+	}
+	assert.Equal(t, 1, inner.calls)
+
+	// An empty kind refers to an Issuer.
+	_, err := h.GetGenericIssuer(cmmeta.ObjectReference{Name: "issuer"}, "ns")
+	require.NoError(t, err)
+	assert.Equal(t, 1, inner.calls)
+}
+
+func TestCachingHelperKeys(t *testing.T) {
+	inner := newCountingHelper(
+		gen.Issuer("issuer", gen.SetIssuerNamespace("ns-1"), gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "ns-1"})),
+		gen.Issuer("issuer", gen.SetIssuerNamespace("ns-2"), gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "ns-2"})),
+		gen.ClusterIssuer("issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "cluster"})),
+	)
+	h := NewCachingHelper(inner, nil)
+
+	// Issuers of the same name in different namespaces, and a ClusterIssuer
+	// of the same name, are cached separately.
+	for i := 0; i < 2; i++ {
+		for ref, expectedZone := range map[struct {
+			ref cmmeta.ObjectReference
+			ns  string
+		}]string{
+			{issuerRef, "ns-1"}:        "ns-1",
+			{issuerRef, "ns-2"}:        "ns-2",
+			{clusterIssuerRef, "ns-1"}: "cluster",
+			{clusterIssuerRef, "ns-2"}: "cluster",
+		} {
+			issuer, err := h.GetGenericIssuer(ref.ref, ref.ns)
+			require.NoError(t, err)
+			assert.Equal(t, expectedZone, issuer.GetSpec().Venafi.Zone)
+		}
+	}
+	assert.Equal(t, 3, inner.calls)
+
+	_, err := h.GetGenericIssuer(cmmeta.ObjectReference{Name: "issuer", Kind: "Unknown"}, "ns-1")
+	assert.Error(t, err)
+}
+
+func TestCachingHelperInvalidate(t *testing.T) {
+	tests := map[string]struct {
+		issuer     cmapi.GenericIssuer
+		ref        cmmeta.ObjectReference
+		invalidate func(h *CachingHelper, old, new cmapi.GenericIssuer)
+	}{
+		"an updated Issuer is read again": {
+			issuer: gen.Issuer("issuer", gen.SetIssuerNamespace("ns")),
+			ref:    issuerRef,
+			invalidate: func(h *CachingHelper, _, new cmapi.GenericIssuer) {
+				h.Invalidate(new)
+			},
+		},
+		"an updated ClusterIssuer is read again": {
+			issuer: gen.ClusterIssuer("issuer"),
+			ref:    clusterIssuerRef,
+			invalidate: func(h *CachingHelper, _, new cmapi.GenericIssuer) {
+				h.Invalidate(new)
+			},
+		},
+		"a deleted Issuer whose final state is unknown is read again": {
+			issuer: gen.Issuer("issuer", gen.SetIssuerNamespace("ns")),
+			ref:    issuerRef,
+			invalidate: func(h *CachingHelper, old, _ cmapi.GenericIssuer) {
+				h.Invalidate(cache.DeletedFinalStateUnknown{Key: "ns/issuer", Obj: old})
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			inner := newCountingHelper(test.issuer)
+			h := NewCachingHelper(inner, nil)
+
+			_, err := h.GetGenericIssuer(test.ref, "ns")
+			require.NoError(t, err)
+
+			updated := test.issuer.DeepCopyObject().(cmapi.GenericIssuer)
+			updated.GetSpec().Venafi = &cmapi.VenafiIssuer{Zone: "updated"}
+			inner.set(updated)
+			test.invalidate(h, test.issuer, updated)
+
+			issuer, err := h.GetGenericIssuer(test.ref, "ns")
+			require.NoError(t, err)
+			assert.Equal(t, "updated", issuer.GetSpec().Venafi.Zone)
+			assert.Equal(t, 2, inner.calls)
+		})
+	}
+}
+
+func TestCachingHelperInvalidateOtherObjects(t *testing.T) {
+	inner := newCountingHelper(gen.Issuer("issuer", gen.SetIssuerNamespace("ns")))
+	h := NewCachingHelper(inner, nil)
+
+	_, err := h.GetGenericIssuer(issuerRef, "ns")
+	require.NoError(t, err)
+
+	// Objects other than the cached issuer do not evict it.
+	h.Invalidate(gen.Issuer("issuer", gen.SetIssuerNamespace("other-ns")))
+	h.Invalidate(gen.ClusterIssuer("issuer"))
+	h.Invalidate(gen.Certificate("issuer"))
+	_, err = h.GetGenericIssuer(issuerRef, "ns")
+	require.NoError(t, err)
+	assert.Equal(t, 1, inner.calls)
+
+	var nilHelper *CachingHelper
+	nilHelper.Invalidate(gen.ClusterIssuer("issuer"))
+}
+
+func TestCachingHelperIssuerChangedMidFlight(t *testing.T) {
+	old := gen.Issuer("issuer", gen.SetIssuerNamespace("ns"), gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "old"}))
+	updated := gen.IssuerFrom(old, gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "updated"}))
+
+	inner := newCountingHelper(old)
+	h := NewCachingHelper(inner, nil)
+
+	// The Issuer is updated, and the update is invalidated, after the old
+	// Issuer has been read from the lister but before it is cached.
+	inner.onGet = func() {
+		inner.onGet = nil
+		inner.set(updated)
+		h.Invalidate(updated)
+	}
+
+	issuer, err := h.GetGenericIssuer(issuerRef, "ns")
+	require.NoError(t, err)
+	assert.Equal(t, "old", issuer.GetSpec().Venafi.Zone)
+
+	// The old Issuer read by the lookup in flight was not cached, so the
+	// sync triggered by the update reads the updated Issuer.
+	issuer, err = h.GetGenericIssuer(issuerRef, "ns")
+	require.NoError(t, err)
+	assert.Equal(t, "updated", issuer.GetSpec().Venafi.Zone)
+	assert.Equal(t, 2, inner.calls)
+
+	_, err = h.GetGenericIssuer(issuerRef, "ns")
+	require.NoError(t, err)
+	assert.Equal(t, 2, inner.calls)
+}
+
+func TestCachingHelperDoesNotCacheErrors(t *testing.T) {
+	inner := newCountingHelper()
+	h := NewCachingHelper(inner, nil)
+
+	_, err := h.GetGenericIssuer(issuerRef, "ns")
+	assert.Error(t, err)
+
+	// The Issuer is created.
+	issuer := gen.Issuer("issuer", gen.SetIssuerNamespace("ns"))
+	inner.set(issuer)
+	h.Invalidate(issuer)
+
+	_, err = h.GetGenericIssuer(issuerRef, "ns")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, inner.calls)
+}
+
+// BenchmarkGetGenericIssuer compares reading an issuer from the listers with
+// reading it from a CachingHelper, with many issuers in the informer cache
+// and concurrent lookups, as when a burst of CertificateRequests is synced.
+func BenchmarkGetGenericIssuer(b *testing.B) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for i := 0; i < 1000; i++ {
+		require.NoError(b, indexer.Add(gen.Issuer(fmt.Sprintf("issuer-%d", i), gen.SetIssuerNamespace(fmt.Sprintf("ns-%d", i%100)))))
+	}
+	helper := NewHelper(cmlisters.NewIssuerLister(indexer), nil)
+	ref := cmmeta.ObjectReference{Name: "issuer-42", Kind: cmapi.IssuerKind}
+
+	for name, h := range map[string]Helper{
+		"lister": helper,
+		"cache":  NewCachingHelper(helper, nil),
+	} {
+		b.Run(name, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := h.GetGenericIssuer(ref, "ns-42"); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		})
+	}
+}
@@ -16,6 +16,12 @@ limitations under the License.
 
 package api
 
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
 type CustomFieldType string
 
 const (
@@ -28,3 +34,37 @@ type CustomField struct {
 	Name  string          `json:"name"`
 	Value string          `json:"value"`
 }
+
+// ParseCustomFields decodes JSON encoded custom fields. The data may either be
+// an array of CustomField objects, or an object mapping custom field names to
+// their values, in which case each field is of type Plain and the fields are
+// sorted by name.
+func ParseCustomFields(data []byte) ([]CustomField, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var fieldMap map[string]string
+		if err := json.Unmarshal(trimmed, &fieldMap); err != nil {
+			return nil, err
+		}
+
+		fields := make([]CustomField, 0, len(fieldMap))
+		for name, value := range fieldMap {
+			fields = append(fields, CustomField{
+				Type:  CustomFieldTypePlain,
+				Name:  name,
+				Value: value,
+			})
+		}
+		sort.Slice(fields, func(i, j int) bool {
+			return fields[i].Name < fields[j].Name
+		})
+
+		return fields, nil
+	}
+
+	var fields []CustomField
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCustomFields(t *testing.T) {
+	tests := map[string]struct {
+		data      string
+		expFields []CustomField
+		expErr    bool
+	}{
+		"array of custom fields": {
+			data: `[{"name": "cost-center", "value": "1234"}, {"type": "Bool", "name": "owner", "value": "team-a"}]`,
+			expFields: []CustomField{
+				{Name: "cost-center", Value: "1234"},
+				{Type: "Bool", Name: "owner", Value: "team-a"},
+			},
+		},
+		"map of custom fields is sorted by name": {
+			data: ` {"owner": "team-a", "cost-center": "1234"}`,
+			expFields: []CustomField{
+				{Type: CustomFieldTypePlain, Name: "cost-center", Value: "1234"},
+				{Type: CustomFieldTypePlain, Name: "owner", Value: "team-a"},
+			},
+		},
+		"map with non-string values": {
+			data:   `{"cost-center": 1234}`,
+			expErr: true,
+		},
+		"invalid JSON": {
+			data:   `cost-center=1234`,
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fields, err := ParseCustomFields([]byte(test.data))
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expFields, fields)
+		})
+	}
+}
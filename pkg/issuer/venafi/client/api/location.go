@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// MaxLocationNameLength is the maximum length of the instance and workload
+// names accepted by Venafi Cloud.
+const MaxLocationNameLength = 255
+
+// Location identifies where a certificate is used, which Venafi Cloud records
+// as the usage metadata of the certificate to attribute it to an application.
+type Location struct {
+	// Instance is the name of the instance of the application using the
+	// certificate, for example a host or node name.
+	Instance string
+
+	// Workload is the name of the application using the certificate. If not
+	// set, Venafi Cloud records a default application name.
+	Workload string
+
+	// Folder is the TPP policy folder of the device object the certificate
+	// is installed on. If not set, the device is created in the folder of the
+	// zone. Only used by TPP, see ParseDevicePath.
+	Folder string
+}
+
+// Validate checks whether the names of the Location are accepted by Venafi
+// Cloud. Names which are set must be non-blank, no longer than
+// MaxLocationNameLength and must not contain control characters.
+func (l Location) Validate() error {
+	if l.Instance == "" && l.Workload == "" {
+		return errors.New("at least one of instance or workload must be set")
+	}
+	if err := validateLocationName(l.Instance); err != nil {
+		return fmt.Errorf("invalid instance: %w", err)
+	}
+	if err := validateLocationName(l.Workload); err != nil {
+		return fmt.Errorf("invalid workload: %w", err)
+	}
+	return nil
+}
+
+func validateLocationName(name string) error {
+	switch {
+	case name == "":
+		return nil
+	case strings.TrimSpace(name) == "":
+		return errors.New("must not be blank")
+	case len(name) > MaxLocationNameLength:
+		return fmt.Errorf("must be no more than %d characters, got %d", MaxLocationNameLength, len(name))
+	case strings.IndexFunc(name, unicode.IsControl) >= 0:
+		return errors.New("must not contain control characters")
+	}
+	return nil
+}
+
+// ParseDevicePath parses the path of a TPP device and application object,
+// such as `\VED\Policy\Devices\web-1\nginx`, into the Location which
+// installs a certificate on that application. The last two components of the
+// path are the names of the device and the application, and the preceding
+// components, if any, are the policy folder of the device. The folder may be
+// absolute or relative to `\VED\Policy`, and defaults to the folder of the
+// zone. Each component must be a valid location name.
+func ParseDevicePath(path string) (*Location, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, errors.New("must not be empty")
+	}
+
+	components := strings.Split(strings.TrimPrefix(path, `\`), `\`)
+	if len(components) < 2 {
+		return nil, fmt.Errorf(`must be of the form [folder\]device\application, got %q`, path)
+	}
+	for i, component := range components {
+		if component == "" {
+			return nil, fmt.Errorf("component %d of %q must not be empty", i+1, path)
+		}
+		if err := validateLocationName(component); err != nil {
+			return nil, fmt.Errorf("component %d of %q %w", i+1, path, err)
+		}
+	}
+
+	n := len(components)
+	location := &Location{
+		Instance: components[n-2],
+		Workload: components[n-1],
+	}
+	if n > 2 {
+		location.Folder = `\` + strings.Join(components[:n-2], `\`)
+	}
+	return location, nil
+}
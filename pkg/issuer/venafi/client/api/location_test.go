@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLocationValidate(t *testing.T) {
+	tests := map[string]struct {
+		location Location
+		wantErr  string
+	}{
+		"instance and workload": {
+			location: Location{Instance: "node-1", Workload: "payments"},
+		},
+		"instance only": {
+			location: Location{Instance: "node-1"},
+		},
+		"workload only": {
+			location: Location{Workload: "payments"},
+		},
+		"neither instance nor workload": {
+			location: Location{},
+			wantErr:  "at least one of instance or workload must be set",
+		},
+		"blank instance": {
+			location: Location{Instance: "  ", Workload: "payments"},
+			wantErr:  "invalid instance: must not be blank",
+		},
+		"workload too long": {
+			location: Location{Workload: strings.Repeat("a", MaxLocationNameLength+1)},
+			wantErr:  "invalid workload: must be no more than 255 characters, got 256",
+		},
+		"workload with control characters": {
+			location: Location{Workload: "pay\nments"},
+			wantErr:  "invalid workload: must not contain control characters",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.location.Validate()
+			if test.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != test.wantErr {
+				t.Errorf("expected error %q, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParseDevicePath(t *testing.T) {
+	tests := map[string]struct {
+		path    string
+		want    *Location
+		wantErr string
+	}{
+		"device and application": {
+			path: `web-1\nginx`,
+			want: &Location{Instance: "web-1", Workload: "nginx"},
+		},
+		"folder relative to the policy root": {
+			path: `Devices\Kubernetes\web-1\nginx`,
+			want: &Location{Folder: `\Devices\Kubernetes`, Instance: "web-1", Workload: "nginx"},
+		},
+		"absolute folder": {
+			path: `\VED\Policy\Devices\web-1\nginx`,
+			want: &Location{Folder: `\VED\Policy\Devices`, Instance: "web-1", Workload: "nginx"},
+		},
+		"empty path": {
+			path:    " ",
+			wantErr: "must not be empty",
+		},
+		"device only": {
+			path:    `\web-1`,
+			wantErr: `must be of the form [folder\]device\application, got "\\web-1"`,
+		},
+		"empty component": {
+			path:    `Devices\\web-1\nginx`,
+			wantErr: `component 2 of "Devices\\\\web-1\\nginx" must not be empty`,
+		},
+		"trailing separator": {
+			path:    `web-1\nginx\`,
+			wantErr: `component 3 of "web-1\\nginx\\" must not be empty`,
+		},
+		"blank application": {
+			path:    `web-1\ `,
+			wantErr: `component 2 of "web-1\\ " must not be blank`,
+		},
+		"device too long": {
+			path:    strings.Repeat("a", MaxLocationNameLength+1) + `\nginx`,
+			wantErr: "must be no more than 255 characters, got 256",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseDevicePath(test.path)
+			if test.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if *got != *test.want {
+					t.Errorf("expected location %+v, got %+v", test.want, got)
+				}
+				return
+			}
+			if err == nil || !strings.HasSuffix(err.Error(), test.wantErr) {
+				t.Errorf("expected error %q, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	// DefaultOriginTemplate is the template of the origin of a certificate
+	// used when an issuer records origins without setting a template.
+	DefaultOriginTemplate = "{namespace}/{certificate}"
+
+	// MaxOriginLength is the maximum length of the rendered origin of a
+	// certificate, so that it fits in the text custom fields of TPP.
+	MaxOriginLength = 255
+)
+
+// originPlaceholders are the placeholders which may be used in origin
+// templates.
+var originPlaceholders = []string{"{namespace}", "{certificate}", "{request}"}
+
+// Origin identifies the Kubernetes object which requested a certificate, which
+// is recorded in a custom field of the certificate so that it can be traced
+// back from the Venafi platform.
+type Origin struct {
+	// Namespace is the namespace of the CertificateRequest.
+	Namespace string
+
+	// Certificate is the name of the Certificate the CertificateRequest was
+	// created for, or the name of the CertificateRequest itself if it was
+	// not created for a Certificate.
+	Certificate string
+
+	// Request is the name of the CertificateRequest.
+	Request string
+}
+
+// ValidateOriginTemplate checks whether the given origin template only uses
+// known placeholders, and whether its text outside of placeholders is no
+// longer than MaxOriginLength.
+func ValidateOriginTemplate(template string) error {
+	if strings.TrimSpace(template) == "" {
+		return errors.New("must not be blank")
+	}
+	if strings.IndexFunc(template, unicode.IsControl) >= 0 {
+		return errors.New("must not contain control characters")
+	}
+
+	var static int
+	for rest := template; rest != ""; {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			static += len(rest)
+			break
+		}
+		static += start
+
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return fmt.Errorf("unterminated placeholder %q", rest[start:])
+		}
+		placeholder := rest[start : start+end+1]
+		if !slices.Contains(originPlaceholders, placeholder) {
+			return fmt.Errorf("unknown placeholder %q, must be one of %s", placeholder, strings.Join(originPlaceholders, ", "))
+		}
+		rest = rest[start+end+1:]
+	}
+
+	if static > MaxOriginLength {
+		return fmt.Errorf("must be no more than %d characters excluding placeholders, got %d", MaxOriginLength, static)
+	}
+	return nil
+}
+
+// Render returns the value of the given origin template for the Origin. An
+// empty template renders DefaultOriginTemplate. The rendered value must be no
+// longer than MaxOriginLength.
+func (o Origin) Render(template string) (string, error) {
+	if template == "" {
+		template = DefaultOriginTemplate
+	}
+	if err := ValidateOriginTemplate(template); err != nil {
+		return "", fmt.Errorf("invalid origin template: %w", err)
+	}
+
+	value := strings.NewReplacer(
+		"{namespace}", o.Namespace,
+		"{certificate}", o.Certificate,
+		"{request}", o.Request,
+	).Replace(template)
+	if len(value) > MaxOriginLength {
+		return "", fmt.Errorf("origin %q must be no more than %d characters, got %d", value, MaxOriginLength, len(value))
+	}
+	return value, nil
+}
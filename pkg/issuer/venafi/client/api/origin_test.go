@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateOriginTemplate(t *testing.T) {
+	tests := map[string]struct {
+		template string
+		expErr   string
+	}{
+		"default template": {
+			template: DefaultOriginTemplate,
+		},
+		"all placeholders": {
+			template: "cluster-a: {namespace}/{certificate} ({request})",
+		},
+		"template without placeholders": {
+			template: "cluster-a",
+		},
+		"blank template": {
+			template: "  ",
+			expErr:   "must not be blank",
+		},
+		"unknown placeholder": {
+			template: "{namespace}/{name}",
+			expErr:   `unknown placeholder "{name}", must be one of {namespace}, {certificate}, {request}`,
+		},
+		"unterminated placeholder": {
+			template: "{namespace}/{certificate",
+			expErr:   `unterminated placeholder "{certificate"`,
+		},
+		"control characters": {
+			template: "{namespace}\n{certificate}",
+			expErr:   "must not contain control characters",
+		},
+		"template too long": {
+			template: strings.Repeat("x", MaxOriginLength) + "/{certificate}",
+			expErr:   "must be no more than 255 characters excluding placeholders, got 256",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateOriginTemplate(test.template)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestOriginRender(t *testing.T) {
+	origin := Origin{Namespace: "test-ns", Certificate: "test-certificate", Request: "test-certificate-1"}
+
+	value, err := origin.Render("")
+	assert.NoError(t, err)
+	assert.Equal(t, "test-ns/test-certificate", value)
+
+	value, err = origin.Render("{request} in {namespace}")
+	assert.NoError(t, err)
+	assert.Equal(t, "test-certificate-1 in test-ns", value)
+
+	_, err = origin.Render("{name}")
+	assert.EqualError(t, err, `invalid origin template: unknown placeholder "{name}", must be one of {namespace}, {certificate}, {request}`)
+
+	long := Origin{Namespace: "test-ns", Certificate: strings.Repeat("x", 253)}
+	_, err = long.Render("")
+	assert.EqualError(t, err, `origin "test-ns/`+strings.Repeat("x", 253)+`" must be no more than 255 characters, got 261`)
+}
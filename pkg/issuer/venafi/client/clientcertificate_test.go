@@ -0,0 +1,212 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// generateClientCertificate returns a PEM encoded self-signed client
+// certificate and its private key.
+func generateClientCertificate(t *testing.T, commonName string) ([]byte, []byte) {
+	pk, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, pk.Public(), pk)
+	require.NoError(t, err)
+
+	keyPEM, err := pki.EncodePKCS8PrivateKey(pk)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), keyPEM
+}
+
+func TestSecretCredentialsResolver_TPPClientCertificate(t *testing.T) {
+	certPEM, keyPEM := generateClientCertificate(t, "test-client")
+	otherCertPEM, _ := generateClientCertificate(t, "other-client")
+
+	lister := namedSecretLister(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "client-cert"},
+			Data:       map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "missing-key"},
+			Data:       map[string][]byte{corev1.TLSCertKey: certPEM},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "mismatched-key"},
+			Data:       map[string][]byte{corev1.TLSCertKey: otherCertPEM, corev1.TLSPrivateKeyKey: keyPEM},
+		},
+	)
+
+	tests := map[string]struct {
+		secretRef *cmmeta.LocalObjectReference
+
+		expectedCert        bool
+		expectedNotFound    bool
+		expectedInvalidCred bool
+		expectedErr         string
+	}{
+		"no client certificate is configured": {},
+		"the client certificate is read from the Secret": {
+			secretRef:    &cmmeta.LocalObjectReference{Name: "client-cert"},
+			expectedCert: true,
+		},
+		"the Secret does not exist": {
+			secretRef:        &cmmeta.LocalObjectReference{Name: "missing-secret"},
+			expectedNotFound: true,
+			expectedErr:      `could not access client certificate secret 'test-namespace/missing-secret': secrets "missing-secret" not found`,
+		},
+		"the Secret has no private key": {
+			secretRef:           &cmmeta.LocalObjectReference{Name: "missing-key"},
+			expectedInvalidCred: true,
+			expectedErr:         `invalid Venafi credentials in secret "missing-key": both the "tls.crt" and "tls.key" keys of the client certificate must be set, but the secret only has the keys "tls.crt"`,
+		},
+		"the private key does not match the certificate": {
+			secretRef:           &cmmeta.LocalObjectReference{Name: "mismatched-key"},
+			expectedInvalidCred: true,
+			expectedErr:         `invalid Venafi credentials in secret "mismatched-key": invalid client certificate: tls: private key does not match public key`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tpp := &cmapi.VenafiTPP{URL: tppUrl, ClientCertificateSecretRef: test.secretRef}
+
+			cert, err := NewSecretCredentialsResolver(lister).TPPClientCertificate("test-namespace", tpp)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				assert.Equal(t, test.expectedNotFound, apierrors.IsNotFound(err))
+				assert.Equal(t, test.expectedInvalidCred, IsInvalidCredentialsError(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedCert, cert != nil)
+		})
+	}
+}
+
+func TestNewTPPClientCertificate(t *testing.T) {
+	certPEM, keyPEM := generateClientCertificate(t, "test-client")
+	untrustedCertPEM, untrustedKeyPEM := generateClientCertificate(t, "untrusted-client")
+
+	clientCAs := x509.NewCertPool()
+	require.True(t, clientCAs.AppendCertsFromPEM(certPEM))
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/vedsdk/Identity/Self" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Identities": [{"Name": "test-client", "Universal": "test-client"}]}`))
+	}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	lister := namedSecretLister(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "credentials"},
+			Data:       map[string][]byte{tppAccessTokenKey: []byte(accessToken)},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "client-cert"},
+			Data:       map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "untrusted-client-cert"},
+			Data:       map[string][]byte{corev1.TLSCertKey: untrustedCertPEM, corev1.TLSPrivateKeyKey: untrustedKeyPEM},
+		},
+	)
+
+	tests := map[string]struct {
+		secretRef   *cmmeta.LocalObjectReference
+		expectedErr string
+	}{
+		"the client certificate is presented to the TPP server": {
+			secretRef: &cmmeta.LocalObjectReference{Name: "client-cert"},
+		},
+		"a missing client certificate is reported": {
+			expectedErr: "the TPP server requires a client certificate, which must be set with tpp.clientCertificateSecretRef",
+		},
+		"a rejected client certificate is reported": {
+			secretRef:   &cmmeta.LocalObjectReference{Name: "untrusted-client-cert"},
+			expectedErr: `the TPP server rejected the client certificate in secret "untrusted-client-cert"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			iss := gen.Issuer("venafi", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+				Zone: zone,
+				TPP: &cmapi.VenafiTPP{
+					URL:                        server.URL + "/vedsdk",
+					CredentialsRef:             cmmeta.LocalObjectReference{Name: "credentials"},
+					CABundle:                   serverCA,
+					ClientCertificateSecretRef: test.secretRef,
+				},
+			}))
+
+			_, err := New("test-namespace", NewSecretCredentialsResolver(lister), iss, nil, logr.Discard(), "cert-manager/v0.0.0")
+			if test.expectedErr != "" {
+				assert.ErrorContains(t, err, test.expectedErr)
+				assert.False(t, IsAuthenticationError(err), "expected the fallback credentials not to be tried")
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/tls"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// Credentials are used to authenticate to the Venafi platform. TPP is
+// authenticated with either an access token or a username and password, Venafi
+// Cloud is authenticated with an API key.
+type Credentials struct {
+	Username    string
+	Password    string
+	AccessToken string
+
+	APIKey string
+}
+
+// CredentialsResolver resolves the credentials and CA bundle referenced by a
+// Venafi issuer, so that the client does not depend on where they are stored.
+type CredentialsResolver interface {
+	// Credentials returns the credentials of the given issuer. Objects
+	// referenced by the issuer are read from the given namespace.
+	Credentials(namespace string, issuer cmapi.GenericIssuer) (*Credentials, error)
+
+	// TPPCABundle returns the CA bundle referenced by the given TPP
+	// configuration, or nil if no CA bundle is referenced. Objects referenced
+	// by the configuration are read from the given namespace.
+	TPPCABundle(namespace string, tpp *cmapi.VenafiTPP) ([]byte, error)
+
+	// TPPClientCertificate returns the client certificate referenced by the
+	// given TPP configuration, or nil if no client certificate is referenced.
+	// Objects referenced by the configuration are read from the given
+	// namespace.
+	TPPClientCertificate(namespace string, tpp *cmapi.VenafiTPP) (*tls.Certificate, error)
+}
+
+// secretCredentialsResolver reads the credentials of Venafi issuers from
+// Kubernetes Secrets.
+type secretCredentialsResolver struct {
+	secretsLister internalinformers.SecretLister
+}
+
+// NewSecretCredentialsResolver returns the default CredentialsResolver, which
+// reads the credentials of Venafi issuers from the Secrets in the given lister.
+func NewSecretCredentialsResolver(secretsLister internalinformers.SecretLister) CredentialsResolver {
+	return &secretCredentialsResolver{secretsLister: secretsLister}
+}
+
+func (r *secretCredentialsResolver) Credentials(namespace string, issuer cmapi.GenericIssuer) (*Credentials, error) {
+	venCfg := issuer.GetSpec().Venafi
+
+	ref := venCfg.CredentialsRef
+	if ref != nil && (ref.Group != "" || (ref.Kind != "" && ref.Kind != "Secret")) {
+		return nil, fmt.Errorf("unsupported credentials reference of kind %q in group %q, only Secrets are supported", ref.Kind, ref.Group)
+	}
+
+	switch {
+	case venCfg.TPP != nil:
+		secretName := venCfg.TPP.CredentialsRef.Name
+		if ref != nil {
+			secretName = ref.Name
+		}
+
+		tppSecret, err := r.secretsLister.Secrets(namespace).Get(secretName)
+		if err != nil {
+			return nil, err
+		}
+
+		return tppCredentialsFromSecret(secretName, tppSecret)
+	case venCfg.Cloud != nil:
+		secretName, k := venCfg.Cloud.APITokenSecretRef.Name, venCfg.Cloud.APITokenSecretRef.Key
+		if ref != nil {
+			secretName = ref.Name
+		}
+		if k == "" {
+			k = defaultAPIKeyKey
+		}
+
+		cloudSecret, err := r.secretsLister.Secrets(namespace).Get(secretName)
+		if err != nil {
+			return nil, err
+		}
+
+		apiKey := string(cloudSecret.Data[k])
+		if apiKey == "" {
+			return nil, InvalidCredentialsError{
+				SecretName: secretName,
+				Reason:     withSecretKeys(fmt.Sprintf("the %q key must be set", k), cloudSecret),
+			}
+		}
+
+		return &Credentials{APIKey: apiKey}, nil
+	}
+	// API validation in webhook and in the ClusterIssuer and Issuer controller
+	// Sync functions should make this unreachable in production.
+	return nil, fmt.Errorf("neither Venafi Cloud or TPP configuration found")
+}
+
+// tppCredentialsFromSecret returns the TPP credentials stored in the given
+// Secret.
+func tppCredentialsFromSecret(secretName string, tppSecret *corev1.Secret) (*Credentials, error) {
+	username := string(tppSecret.Data[tppUsernameKey])
+	password := string(tppSecret.Data[tppPasswordKey])
+	accessToken := string(tppSecret.Data[tppAccessTokenKey])
+
+	// TPP supports either an access token or a username and password. Only
+	// one of them may be set, as otherwise it is unclear which one is used.
+	switch {
+	case accessToken != "" && (username != "" || password != ""):
+		return nil, InvalidCredentialsError{
+			SecretName: secretName,
+			Reason: fmt.Sprintf("the %q key must not be set together with the %q and %q keys",
+				tppAccessTokenKey, tppUsernameKey, tppPasswordKey),
+		}
+	case accessToken != "", username != "" && password != "":
+	case username != "" || password != "":
+		return nil, InvalidCredentialsError{
+			SecretName: secretName,
+			Reason:     withSecretKeys(fmt.Sprintf("both the %q and %q keys must be set", tppUsernameKey, tppPasswordKey), tppSecret),
+		}
+	default:
+		return nil, InvalidCredentialsError{
+			SecretName: secretName,
+			Reason: withSecretKeys(fmt.Sprintf("either the %q key or the %q and %q keys must be set",
+				tppAccessTokenKey, tppUsernameKey, tppPasswordKey), tppSecret),
+		}
+	}
+
+	return &Credentials{
+		Username:    username,
+		Password:    password,
+		AccessToken: accessToken,
+	}, nil
+}
+
+// withSecretKeys appends the keys found in the given Secret to the reason
+// credentials are invalid, so that misspelled keys such as "access_token"
+// are easy to spot.
+func withSecretKeys(reason string, secret *corev1.Secret) string {
+	if len(secret.Data) == 0 {
+		return reason + ", but the secret has no keys"
+	}
+	keys := make([]string, 0, len(secret.Data))
+	for k := range secret.Data {
+		keys = append(keys, fmt.Sprintf("%q", k))
+	}
+	sort.Strings(keys)
+	return fmt.Sprintf("%s, but the secret only has the keys %s", reason, strings.Join(keys, ", "))
+}
+
+// TPPCABundle sets appropriate CA based on provided bundle or kubernetes secret
+// If no custom CA bundle is configured, an empty byte slice is returned.
+// Assumes exactly one of the in-line/Secret CA bundles are defined.
+// If the `key` of the Secret CA bundle is not defined, its value defaults to
+// `ca.crt`.
+func (r *secretCredentialsResolver) TPPCABundle(namespace string, tpp *cmapi.VenafiTPP) ([]byte, error) {
+	if len(tpp.CABundle) > 0 {
+		return tpp.CABundle, nil
+	}
+
+	secretRef := tpp.CABundleSecretRef
+	if secretRef == nil {
+		return nil, nil
+	}
+
+	secret, err := r.secretsLister.Secrets(namespace).Get(secretRef.Name)
+	if err != nil {
+		return nil, fmt.Errorf("could not access secret '%s/%s': %s", namespace, secretRef.Name, err)
+	}
+
+	key := secretRef.Key
+	if key == "" {
+		key = cmmeta.TLSCAKey
+	}
+
+	certBytes, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("no data for %q in secret '%s/%s'", key, namespace, secretRef.Name)
+	}
+
+	return certBytes, nil
+}
+
+// TPPClientCertificate reads the client certificate and private key used for
+// mutual TLS authentication to TPP from the `tls.crt` and `tls.key` keys of the
+// referenced Secret. If no client certificate is configured, nil is returned.
+func (r *secretCredentialsResolver) TPPClientCertificate(namespace string, tpp *cmapi.VenafiTPP) (*tls.Certificate, error) {
+	secretRef := tpp.ClientCertificateSecretRef
+	if secretRef == nil {
+		return nil, nil
+	}
+
+	secret, err := r.secretsLister.Secrets(namespace).Get(secretRef.Name)
+	if err != nil {
+		return nil, fmt.Errorf("could not access client certificate secret '%s/%s': %w", namespace, secretRef.Name, err)
+	}
+
+	certPEM, keyPEM := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+	if len(certPEM) == 0 || len(keyPEM) == 0 {
+		return nil, InvalidCredentialsError{
+			SecretName: secretRef.Name,
+			Reason: withSecretKeys(fmt.Sprintf("both the %q and %q keys of the client certificate must be set",
+				corev1.TLSCertKey, corev1.TLSPrivateKeyKey), secret),
+		}
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, InvalidCredentialsError{
+			SecretName: secretRef.Name,
+			Reason:     fmt.Sprintf("invalid client certificate: %v", err),
+		}
+	}
+
+	return &cert, nil
+}
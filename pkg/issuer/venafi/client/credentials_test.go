@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	testlisters "github.com/cert-manager/cert-manager/test/unit/listers"
+)
+
+// namedSecretLister returns a SecretLister which only returns the given
+// Secrets by name.
+func namedSecretLister(secrets ...*corev1.Secret) internalinformers.SecretLister {
+	return &testlisters.FakeSecretLister{
+		SecretsFn: func(string) corelisters.SecretNamespaceLister {
+			return &testlisters.FakeSecretNamespaceLister{
+				GetFn: func(name string) (*corev1.Secret, error) {
+					for _, s := range secrets {
+						if s.Name == name {
+							return s, nil
+						}
+					}
+					return nil, apierrors.NewNotFound(corev1.Resource("secrets"), name)
+				},
+			}
+		},
+	}
+}
+
+func TestSecretCredentialsResolver_Credentials(t *testing.T) {
+	tppSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "tpp-secret"},
+		Data:       map[string][]byte{tppAccessTokenKey: []byte("tpp-token")},
+	}
+	cloudSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cloud-secret"},
+		Data:       map[string][]byte{"custom-key": []byte("cloud-key")},
+	}
+	referencedSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "referenced-secret"},
+		Data: map[string][]byte{
+			tppUsernameKey:   []byte("user"),
+			tppPasswordKey:   []byte("pass"),
+			defaultAPIKeyKey: []byte("referenced-key"),
+			"custom-key":     []byte("referenced-custom-key"),
+		},
+	}
+	misspelledSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "misspelled-secret"},
+		Data: map[string][]byte{
+			"access_token": []byte("tpp-token"),
+			"apiKey":       []byte("cloud-key"),
+		},
+	}
+	emptySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "empty-secret"},
+	}
+	lister := namedSecretLister(tppSecret, cloudSecret, referencedSecret, misspelledSecret, emptySecret)
+
+	tpp := &cmapi.VenafiTPP{CredentialsRef: cmmeta.LocalObjectReference{Name: "tpp-secret"}}
+	cloud := &cmapi.VenafiCloud{APITokenSecretRef: cmmeta.SecretKeySelector{
+		LocalObjectReference: cmmeta.LocalObjectReference{Name: "cloud-secret"},
+		Key:                  "custom-key",
+	}}
+
+	tests := map[string]struct {
+		venafi   cmapi.VenafiIssuer
+		expCreds *Credentials
+		expErr   string
+	}{
+		"TPP credentials are read from the TPP credentialsRef": {
+			venafi:   cmapi.VenafiIssuer{TPP: tpp},
+			expCreds: &Credentials{AccessToken: "tpp-token"},
+		},
+		"Cloud credentials are read from the apiTokenSecretRef": {
+			venafi:   cmapi.VenafiIssuer{Cloud: cloud},
+			expCreds: &Credentials{APIKey: "cloud-key"},
+		},
+		"TPP credentials are read from the issuer credentialsRef if set": {
+			venafi: cmapi.VenafiIssuer{
+				TPP:            tpp,
+				CredentialsRef: &cmapi.VenafiCredentialsReference{Name: "referenced-secret"},
+			},
+			expCreds: &Credentials{Username: "user", Password: "pass"},
+		},
+		"Cloud credentials are read from the default key of the issuer credentialsRef if set": {
+			venafi: cmapi.VenafiIssuer{
+				Cloud:          &cmapi.VenafiCloud{},
+				CredentialsRef: &cmapi.VenafiCredentialsReference{Name: "referenced-secret", Kind: "Secret"},
+			},
+			expCreds: &Credentials{APIKey: "referenced-key"},
+		},
+		"Cloud credentials are read from the apiTokenSecretRef key of the issuer credentialsRef if set": {
+			venafi: cmapi.VenafiIssuer{
+				Cloud:          cloud,
+				CredentialsRef: &cmapi.VenafiCredentialsReference{Name: "referenced-secret", Kind: "Secret"},
+			},
+			expCreds: &Credentials{APIKey: "referenced-custom-key"},
+		},
+		"referenced Secret which does not exist": {
+			venafi: cmapi.VenafiIssuer{
+				Cloud:          cloud,
+				CredentialsRef: &cmapi.VenafiCredentialsReference{Name: "missing-secret"},
+			},
+			expErr: `secrets "missing-secret" not found`,
+		},
+		"TPP Secret with misspelled keys": {
+			venafi: cmapi.VenafiIssuer{
+				TPP:            tpp,
+				CredentialsRef: &cmapi.VenafiCredentialsReference{Name: "misspelled-secret"},
+			},
+			expErr: `invalid Venafi credentials in secret "misspelled-secret": either the "access-token" key or the "username" and "password" keys must be set, but the secret only has the keys "access_token", "apiKey"`,
+		},
+		"Cloud Secret with misspelled keys": {
+			venafi: cmapi.VenafiIssuer{
+				Cloud:          &cmapi.VenafiCloud{},
+				CredentialsRef: &cmapi.VenafiCredentialsReference{Name: "misspelled-secret"},
+			},
+			expErr: `invalid Venafi credentials in secret "misspelled-secret": the "api-key" key must be set, but the secret only has the keys "access_token", "apiKey"`,
+		},
+		"TPP Secret without keys": {
+			venafi: cmapi.VenafiIssuer{
+				TPP:            tpp,
+				CredentialsRef: &cmapi.VenafiCredentialsReference{Name: "empty-secret"},
+			},
+			expErr: `invalid Venafi credentials in secret "empty-secret": either the "access-token" key or the "username" and "password" keys must be set, but the secret has no keys`,
+		},
+		"credentialsRef of an unsupported kind": {
+			venafi: cmapi.VenafiIssuer{
+				TPP: tpp,
+				CredentialsRef: &cmapi.VenafiCredentialsReference{
+					Name:  "referenced-secret",
+					Kind:  "ExternalSecret",
+					Group: "secrets.example.com",
+				},
+			},
+			expErr: `unsupported credentials reference of kind "ExternalSecret" in group "secrets.example.com", only Secrets are supported`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			iss := gen.Issuer("venafi", gen.SetIssuerVenafi(test.venafi))
+
+			creds, err := NewSecretCredentialsResolver(lister).Credentials("test-namespace", iss)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expCreds, creds)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Venafi/vcert/v5/pkg/verror"
+)
+
+// IsAuthenticationError returns true if the error was caused by the Venafi
+// platform rejecting the configured credentials, for example an invalid or
+// expired TPP access token or Venafi Cloud API key. Such errors will not be
+// resolved by retrying with the same credentials.
+func IsAuthenticationError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// Venafi Cloud returns a typed error for unauthorized requests.
+	if errors.Is(err, verror.UnauthorizedError) {
+		return true
+	}
+
+	// TPP only includes the HTTP status in the error message, so we have to
+	// inspect it to distinguish rejected credentials from transient errors.
+	msg := err.Error()
+	for _, status := range []string{"401 Unauthorized", "403 Forbidden"} {
+		if strings.Contains(msg, status) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// clientCertificateAlerts are the TLS alerts sent by servers which require a
+// client certificate when none is presented, or which reject the client
+// certificate presented to them.
+var clientCertificateAlerts = []string{
+	"tls: certificate required",
+	"tls: bad certificate",
+	"tls: unknown certificate authority",
+	"tls: certificate expired",
+	"tls: unsupported certificate",
+}
+
+// isClientCertificateError returns true if the error was caused by the TLS
+// server requiring a client certificate, or rejecting the client certificate
+// it was presented.
+func isClientCertificateError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// vcert formats the errors of its HTTP client into its own errors, so the
+	// TLS alert sent by the server has to be found in the error message.
+	msg := err.Error()
+	for _, alert := range clientCertificateAlerts {
+		if strings.Contains(msg, alert) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// InvalidCredentialsError is returned when the Secret referenced by a Venafi
+// issuer does not contain a complete and unambiguous set of credentials.
+type InvalidCredentialsError struct {
+	SecretName string
+	Reason     string
+}
+
+func (err InvalidCredentialsError) Error() string {
+	return fmt.Sprintf("invalid Venafi credentials in secret %q: %s", err.SecretName, err.Reason)
+}
+
+// IsInvalidCredentialsError returns true if the error was caused by the
+// Secret referenced by a Venafi issuer not containing valid credentials.
+func IsInvalidCredentialsError(err error) bool {
+	var target InvalidCredentialsError
+	return errors.As(err, &target)
+}
+
+// ZonePolicyUnavailableError is returned when the configuration of the
+// Venafi zone, which contains its policy, could not be read, so requests
+// could not be checked against the policy of the zone.
+type ZonePolicyUnavailableError struct {
+	Err error
+}
+
+func (err ZonePolicyUnavailableError) Error() string {
+	return fmt.Sprintf("failed to read the policy of the Venafi zone: %v", err.Err)
+}
+
+func (err ZonePolicyUnavailableError) Unwrap() error {
+	return err.Err
+}
+
+// IsZonePolicyUnavailableError returns true if the error was caused by the
+// policy of the Venafi zone not being readable.
+func IsZonePolicyUnavailableError(err error) bool {
+	var target ZonePolicyUnavailableError
+	return errors.As(err, &target)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Venafi/vcert/v5/pkg/verror"
+)
+
+func TestIsAuthenticationError(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want bool
+	}{
+		"nil error": {
+			err:  nil,
+			want: false,
+		},
+		"cloud unauthorized error": {
+			err:  fmt.Errorf("error creating Venafi client: %w", verror.UnauthorizedError),
+			want: true,
+		},
+		"tpp authorize rejected": {
+			err:  fmt.Errorf("%w: %s", verror.AuthError, "unexpected status code on TPP Authorize. Status: 401 Unauthorized"),
+			want: true,
+		},
+		"tpp forbidden": {
+			err:  errors.New("Unexpected status code on TPP Config Operation. Status: 403 Forbidden"),
+			want: true,
+		},
+		"network error": {
+			err:  fmt.Errorf("%w: %s", verror.AuthError, "dial tcp 10.0.0.1:443: connect: connection refused"),
+			want: false,
+		},
+		"server unavailable": {
+			err:  verror.ServerTemporaryUnavailableError,
+			want: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := IsAuthenticationError(test.err); got != test.want {
+				t.Errorf("IsAuthenticationError() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
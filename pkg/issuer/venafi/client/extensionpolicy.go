@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/x509/pkix"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// ExtensionPolicyViolationError is returned when a certificate request
+// contains a custom X.509 extension which is not allowed by the policy of the
+// Venafi zone, as configured on the issuer.
+type ExtensionPolicyViolationError struct {
+	// OID is the object identifier of the requested extension which is not
+	// allowed.
+	OID string
+	// Allowed lists the object identifiers of the allowed custom extensions.
+	Allowed []string
+}
+
+func (err ExtensionPolicyViolationError) Error() string {
+	return fmt.Sprintf("the Venafi zone does not allow the extension %q, allowed extensions are: %s", err.OID, strings.Join(err.Allowed, ", "))
+}
+
+// validateExtensionPolicy checks the custom extensions of a certificate
+// request against the extensions allowed by the issuer. The Venafi platform
+// does not expose which extensions a zone allows, so requests are only
+// checked if the issuer lists the allowed extensions. Standard extensions,
+// such as the subject alternative names, are never checked. See
+// pki.IsCustomExtension.
+func validateExtensionPolicy(extensions []pkix.Extension, allowed []string) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	for _, ext := range pki.CustomExtensions(extensions) {
+		if !slices.Contains(allowed, ext.Id.String()) {
+			return ExtensionPolicyViolationError{OID: ext.Id.String(), Allowed: allowed}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"testing"
+
+	"github.com/Venafi/vcert/v5/pkg/certificate"
+	"github.com/Venafi/vcert/v5/pkg/endpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalfake "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestValidateExtensionPolicy(t *testing.T) {
+	customExtension := pkix.Extension{Id: []int{1, 2, 3, 4}, Value: []byte{0x05, 0x00}}
+	sanExtension := pkix.Extension{Id: []int{2, 5, 29, 17}, Value: []byte{0x30, 0x00}}
+
+	tests := map[string]struct {
+		extensions []pkix.Extension
+		allowed    []string
+		wantErr    error
+	}{
+		"all extensions are allowed if the allowed extensions are not set": {
+			extensions: []pkix.Extension{customExtension},
+		},
+		"allowed custom extensions are accepted": {
+			extensions: []pkix.Extension{customExtension},
+			allowed:    []string{"1.2.3.4"},
+		},
+		"standard extensions are not checked": {
+			extensions: []pkix.Extension{sanExtension},
+			allowed:    []string{"1.2.3.4"},
+		},
+		"custom extensions which are not allowed are rejected": {
+			extensions: []pkix.Extension{sanExtension, customExtension},
+			allowed:    []string{"1.2.3.5"},
+			wantErr:    ExtensionPolicyViolationError{OID: "1.2.3.4", Allowed: []string{"1.2.3.5"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.wantErr, validateExtensionPolicy(test.extensions, test.allowed))
+		})
+	}
+}
+
+func TestVenafi_RequestCertificateExtensionPolicyViolation(t *testing.T) {
+	privateKey, err := pki.GenerateRSAPrivateKey(2048)
+	require.NoError(t, err)
+	csrPEM, err := gen.CSRWithSigner(privateKey,
+		gen.SetCSRCommonName("example.com"),
+		gen.AddCSRExtraExtensions(pkix.Extension{Id: []int{1, 2, 3, 4}, Value: []byte{0x05, 0x00}}),
+	)
+	require.NoError(t, err)
+
+	v := &Venafi{
+		vcertClient: internalfake.Connector{
+			ReadZoneConfigurationFunc: func() (*endpoint.ZoneConfiguration, error) {
+				return &endpoint.ZoneConfiguration{}, nil
+			},
+			RequestCertificateFunc: func(*certificate.Request) (string, error) {
+				return "", errors.New("certificate should not be requested")
+			},
+		}.Default(),
+		allowedExtensions: []string{"1.2.3.5"},
+	}
+
+	_, err = v.RequestCertificate(csrPEM, 0, "", nil, 0, nil)
+	var policyErr ExtensionPolicyViolationError
+	require.True(t, errors.As(err, &policyErr))
+	assert.Equal(t, "1.2.3.4", policyErr.OID)
+	assert.EqualError(t, err, `the Venafi zone does not allow the extension "1.2.3.4", allowed extensions are: 1.2.3.5`)
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package fake
 
 import (
+	"time"
+
 	"github.com/Venafi/vcert/v5/pkg/certificate"
 	"github.com/Venafi/vcert/v5/pkg/endpoint"
 	"github.com/Venafi/vcert/v5/pkg/venafi/fake"
@@ -30,6 +32,8 @@ type Connector struct {
 	RetrieveCertificateFunc   func(*certificate.Request) (*certificate.PEMCollection, error)
 	RequestCertificateFunc    func(*certificate.Request) (string, error)
 	RenewCertificateFunc      func(*certificate.RenewalRequest) (string, error)
+	RevokeCertificateFunc     func(*certificate.RevocationRequest) error
+	SearchCertificateFunc     func(zone string, cn string, sans *certificate.Sans, certMinTimeLeft time.Duration) (*certificate.CertificateInfo, error)
 }
 
 func (f Connector) Default() *Connector {
@@ -73,3 +77,17 @@ func (f *Connector) RenewCertificate(req *certificate.RenewalRequest) (requestID
 	}
 	return f.Connector.RenewCertificate(req)
 }
+
+func (f *Connector) RevokeCertificate(req *certificate.RevocationRequest) (err error) {
+	if f.RevokeCertificateFunc != nil {
+		return f.RevokeCertificateFunc(req)
+	}
+	return f.Connector.RevokeCertificate(req)
+}
+
+func (f *Connector) SearchCertificate(zone string, cn string, sans *certificate.Sans, certMinTimeLeft time.Duration) (certificateInfo *certificate.CertificateInfo, err error) {
+	if f.SearchCertificateFunc != nil {
+		return f.SearchCertificateFunc(zone, cn, sans, certMinTimeLeft)
+	}
+	return f.Connector.SearchCertificate(zone, cn, sans, certMinTimeLeft)
+}
@@ -17,31 +17,66 @@ limitations under the License.
 package fake
 
 import (
+	"crypto"
+	"time"
+
 	"github.com/Venafi/vcert/v5/pkg/endpoint"
 
 	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
 )
 
 type Venafi struct {
-	PingFn                  func() error
-	RequestCertificateFn    func(csrPEM []byte, customFields []api.CustomField) (string, error)
-	RetrieveCertificateFn   func(pickupID string, csrPEM []byte, customFields []api.CustomField) ([]byte, error)
-	ReadZoneConfigurationFn func() (*endpoint.ZoneConfiguration, error)
-	VerifyCredentialsFn     func() error
+	PingFn                      func() error
+	RequestCertificateFn        func(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, customFields []api.CustomField) (string, error)
+	RetrieveCertificateFn       func(pickupID string, csrPEM []byte, customFields []api.CustomField) ([]byte, error)
+	RetrieveCertificateAndKeyFn func(pickupID string, csrPEM []byte, customFields []api.CustomField) ([]byte, []byte, error)
+	RevokeCertificateFn         func(pickupID string) error
+	FindReusableCertificateFn   func(csrPEM []byte, issuedAfter time.Time) (string, []byte, error)
+	ValidateCertificateFn       func(csrPEM []byte, customFields []api.CustomField) error
+	ReadZoneConfigurationFn     func() (*endpoint.ZoneConfiguration, error)
+	VerifyCredentialsFn         func() error
+	CredentialsNameFn           func() string
 }
 
 func (v *Venafi) Ping() error {
 	return v.PingFn()
 }
 
-func (v *Venafi) RequestCertificate(csrPEM []byte, customFields []api.CustomField) (string, error) {
-	return v.RequestCertificateFn(csrPEM, customFields)
+func (v *Venafi) RequestCertificate(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, customFields []api.CustomField) (string, error) {
+	return v.RequestCertificateFn(csrPEM, duration, friendlyName, location, signatureHash, customFields)
 }
 
 func (v *Venafi) RetrieveCertificate(pickupID string, csrPEM []byte, customFields []api.CustomField) ([]byte, error) {
 	return v.RetrieveCertificateFn(pickupID, csrPEM, customFields)
 }
 
+func (v *Venafi) RetrieveCertificateAndKey(pickupID string, csrPEM []byte, customFields []api.CustomField) ([]byte, []byte, error) {
+	return v.RetrieveCertificateAndKeyFn(pickupID, csrPEM, customFields)
+}
+
+func (v *Venafi) RevokeCertificate(pickupID string) error {
+	return v.RevokeCertificateFn(pickupID)
+}
+
+// FindReusableCertificate will return FindReusableCertificateFn if set,
+// otherwise no certificate.
+func (v *Venafi) FindReusableCertificate(csrPEM []byte, issuedAfter time.Time) (string, []byte, error) {
+	if v.FindReusableCertificateFn != nil {
+		return v.FindReusableCertificateFn(csrPEM, issuedAfter)
+	}
+
+	return "", nil, nil
+}
+
+// ValidateCertificateRequest will return ValidateCertificateFn if set, otherwise nil.
+func (v *Venafi) ValidateCertificateRequest(csrPEM []byte, customFields []api.CustomField) error {
+	if v.ValidateCertificateFn != nil {
+		return v.ValidateCertificateFn(csrPEM, customFields)
+	}
+
+	return nil
+}
+
 func (v *Venafi) ReadZoneConfiguration() (*endpoint.ZoneConfiguration, error) {
 	return v.ReadZoneConfigurationFn()
 }
@@ -56,3 +91,13 @@ func (v *Venafi) VerifyCredentials() error {
 
 	return nil
 }
+
+// CredentialsName will return CredentialsNameFn if set, otherwise an empty
+// string.
+func (v *Venafi) CredentialsName() string {
+	if v.CredentialsNameFn != nil {
+		return v.CredentialsNameFn()
+	}
+
+	return ""
+}
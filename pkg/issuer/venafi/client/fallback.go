@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/go-logr/logr"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// credentialsCandidate is a Venafi issuer configured to authenticate with
+// one of its credentials.
+type credentialsCandidate struct {
+	// name is the name of the object containing the credentials.
+	name   string
+	issuer cmapi.GenericIssuer
+}
+
+// credentialsCandidates returns the issuer configured with each of its
+// credentials, in the order in which they are tried: the primary
+// credentials, followed by the fallback credentials.
+func credentialsCandidates(issuer cmapi.GenericIssuer) []credentialsCandidate {
+	venCfg := issuer.GetSpec().Venafi
+	candidates := []credentialsCandidate{{name: primaryCredentialsName(venCfg), issuer: issuer}}
+
+	for _, ref := range venCfg.FallbackCredentialsRefs {
+		fallback := issuer.DeepCopyObject().(cmapi.GenericIssuer)
+		fallback.GetSpec().Venafi.CredentialsRef = &ref
+		fallback.GetSpec().Venafi.FallbackCredentialsRefs = nil
+
+		candidates = append(candidates, credentialsCandidate{name: ref.Name, issuer: fallback})
+	}
+
+	return candidates
+}
+
+// primaryCredentialsName returns the name of the object containing the
+// primary credentials of the issuer.
+func primaryCredentialsName(venCfg *cmapi.VenafiIssuer) string {
+	switch {
+	case venCfg.CredentialsRef != nil:
+		return venCfg.CredentialsRef.Name
+	case venCfg.TPP != nil:
+		return venCfg.TPP.CredentialsRef.Name
+	case venCfg.Cloud != nil:
+		return venCfg.Cloud.APITokenSecretRef.Name
+	}
+	return ""
+}
+
+// withFallbackCredentials builds a client for the issuer with each of its
+// credentials in turn, until the Venafi platform accepts them. Errors other
+// than authentication errors are returned immediately, as the fallback
+// credentials would not fix them.
+func withFallbackCredentials(logger logr.Logger, issuer cmapi.GenericIssuer, build func(cmapi.GenericIssuer) (*Venafi, error)) (*Venafi, error) {
+	candidates := credentialsCandidates(issuer)
+
+	var err error
+	for i, candidate := range candidates {
+		var client *Venafi
+		client, err = build(candidate.issuer)
+		if err == nil {
+			client.credentialsName = candidate.name
+			return client, nil
+		}
+
+		if !IsAuthenticationError(err) {
+			return nil, err
+		}
+
+		if i < len(candidates)-1 {
+			logger.V(logf.InfoLevel).Info("the Venafi platform rejected the credentials, trying the next credentials of the issuer",
+				"credentials", candidate.name, "nextCredentials", candidates[i+1].name, "error", err.Error())
+		}
+	}
+
+	return nil, err
+}
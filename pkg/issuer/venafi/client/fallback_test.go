@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Venafi/vcert/v5/pkg/verror"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestCredentialsCandidates(t *testing.T) {
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+		TPP: &cmapi.VenafiTPP{CredentialsRef: cmmeta.LocalObjectReference{Name: "primary"}},
+		FallbackCredentialsRefs: []cmapi.VenafiCredentialsReference{
+			{Name: "backup"},
+			{Name: "other-backup", Kind: "Secret"},
+		},
+	}))
+
+	candidates := credentialsCandidates(issuer)
+	require.Len(t, candidates, 3)
+
+	assert.Equal(t, "primary", candidates[0].name)
+	assert.Same(t, issuer, candidates[0].issuer)
+
+	assert.Equal(t, "backup", candidates[1].name)
+	assert.Equal(t, &cmapi.VenafiCredentialsReference{Name: "backup"}, candidates[1].issuer.GetSpec().Venafi.CredentialsRef)
+	assert.Empty(t, candidates[1].issuer.GetSpec().Venafi.FallbackCredentialsRefs)
+
+	assert.Equal(t, "other-backup", candidates[2].name)
+	assert.Equal(t, &cmapi.VenafiCredentialsReference{Name: "other-backup", Kind: "Secret"}, candidates[2].issuer.GetSpec().Venafi.CredentialsRef)
+
+	assert.Nil(t, issuer.GetSpec().Venafi.CredentialsRef, "expected the issuer not to be modified")
+}
+
+func TestPrimaryCredentialsName(t *testing.T) {
+	assert.Equal(t, "ref", primaryCredentialsName(&cmapi.VenafiIssuer{
+		CredentialsRef: &cmapi.VenafiCredentialsReference{Name: "ref"},
+		TPP:            &cmapi.VenafiTPP{CredentialsRef: cmmeta.LocalObjectReference{Name: "tpp"}},
+	}))
+	assert.Equal(t, "tpp", primaryCredentialsName(&cmapi.VenafiIssuer{
+		TPP: &cmapi.VenafiTPP{CredentialsRef: cmmeta.LocalObjectReference{Name: "tpp"}},
+	}))
+	assert.Equal(t, "cloud", primaryCredentialsName(&cmapi.VenafiIssuer{
+		Cloud: &cmapi.VenafiCloud{APITokenSecretRef: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "cloud"}}},
+	}))
+}
+
+func TestWithFallbackCredentials(t *testing.T) {
+	unauthorized := fmt.Errorf("error creating Venafi client: %w", verror.UnauthorizedError)
+
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+		TPP:                     &cmapi.VenafiTPP{CredentialsRef: cmmeta.LocalObjectReference{Name: "primary"}},
+		FallbackCredentialsRefs: []cmapi.VenafiCredentialsReference{{Name: "backup"}, {Name: "other-backup"}},
+	}))
+
+	tests := map[string]struct {
+		errs map[string]error
+
+		expectedTried       []string
+		expectedCredentials string
+		expectedErr         error
+	}{
+		"the primary credentials are used if accepted": {
+			expectedTried:       []string{"primary"},
+			expectedCredentials: "primary",
+		},
+		"the fallback credentials are tried in order if the credentials are rejected": {
+			errs:                map[string]error{"primary": unauthorized},
+			expectedTried:       []string{"primary", "backup"},
+			expectedCredentials: "backup",
+		},
+		"the last error is returned if all the credentials are rejected": {
+			errs:          map[string]error{"primary": unauthorized, "backup": unauthorized, "other-backup": unauthorized},
+			expectedTried: []string{"primary", "backup", "other-backup"},
+			expectedErr:   unauthorized,
+		},
+		"the fallback credentials are not tried for other errors": {
+			errs:          map[string]error{"primary": verror.ServerTemporaryUnavailableError},
+			expectedTried: []string{"primary"},
+			expectedErr:   verror.ServerTemporaryUnavailableError,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var tried []string
+			client, err := withFallbackCredentials(logr.Discard(), issuer, func(issuer cmapi.GenericIssuer) (*Venafi, error) {
+				name := primaryCredentialsName(issuer.GetSpec().Venafi)
+				tried = append(tried, name)
+				if err := test.errs[name]; err != nil {
+					return nil, err
+				}
+				return &Venafi{}, nil
+			})
+
+			assert.Equal(t, test.expectedTried, tried)
+			if test.expectedErr != nil {
+				assert.True(t, errors.Is(err, test.expectedErr), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedCredentials, client.CredentialsName())
+		})
+	}
+}
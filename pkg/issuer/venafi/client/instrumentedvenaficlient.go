@@ -70,6 +70,24 @@ func (ic instrumentedConnector) RetrieveCertificate(req *certificate.Request) (*
 	return pemCollection, err
 }
 
+func (ic instrumentedConnector) RevokeCertificate(req *certificate.RevocationRequest) error {
+	start := time.Now()
+	ic.logger.V(logf.TraceLevel).Info("calling RevokeCertificate")
+	err := ic.conn.RevokeCertificate(req)
+	labels := []string{"revoke_certificate"}
+	ic.metrics.ObserveVenafiRequestDuration(time.Since(start), labels...)
+	return err
+}
+
+func (ic instrumentedConnector) SearchCertificate(zone string, cn string, sans *certificate.Sans, certMinTimeLeft time.Duration) (*certificate.CertificateInfo, error) {
+	start := time.Now()
+	ic.logger.V(logf.TraceLevel).Info("calling SearchCertificate")
+	info, err := ic.conn.SearchCertificate(zone, cn, sans, certMinTimeLeft)
+	labels := []string{"search_certificate"}
+	ic.metrics.ObserveVenafiRequestDuration(time.Since(start), labels...)
+	return info, err
+}
+
 func (ic instrumentedConnector) Ping() error {
 	start := time.Now()
 	ic.logger.V(logf.TraceLevel).Info("calling Ping")
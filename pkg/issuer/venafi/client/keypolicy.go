@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Venafi/vcert/v5/pkg/certificate"
+	"github.com/Venafi/vcert/v5/pkg/endpoint"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// KeyPolicyViolationError is returned when the public key of a certificate
+// request is not allowed by the key configurations of the Venafi zone.
+type KeyPolicyViolationError struct {
+	// Key describes the requested key, for example "ECDSA P521".
+	Key string
+	// Allowed describes the key configurations allowed by the zone.
+	Allowed []string
+	// Expected describes the private key settings of a Certificate which
+	// match the key configurations of the zone, for example "RSA with a size
+	// of 2048 or 4096". It is only set if the zone does not allow the
+	// algorithm of the requested key at all, so that the user can be told
+	// which algorithm to use instead.
+	Expected []string
+}
+
+func (err KeyPolicyViolationError) Error() string {
+	return fmt.Sprintf("the Venafi zone does not allow %s keys, allowed keys are: %s", err.Key, strings.Join(err.Allowed, "; "))
+}
+
+// validateKeyPolicy checks the public key of a certificate request against the
+// allowed key configurations of a Venafi zone. This mirrors the key check done
+// by vcert and the Venafi platform, but returns an error which names the key
+// configurations the zone allows. A zone without key configurations allows
+// any key.
+func validateKeyPolicy(publicKey crypto.PublicKey, allowed []endpoint.AllowedKeyConfiguration) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	var (
+		keyType certificate.KeyType
+		size    int
+		curve   certificate.EllipticCurve
+		key     string
+	)
+	switch pub := publicKey.(type) {
+	case *rsa.PublicKey:
+		keyType, size = certificate.KeyTypeRSA, pub.N.BitLen()
+		key = fmt.Sprintf("%s %d", keyType.String(), size)
+	case *ecdsa.PublicKey:
+		keyType = certificate.KeyTypeECDSA
+		if err := curve.Set(pub.Curve.Params().Name); err != nil {
+			// Leave unknown curves to be rejected by the Venafi platform.
+			return nil
+		}
+		key = fmt.Sprintf("%s %s", keyType.String(), curve.String())
+	case ed25519.PublicKey:
+		keyType, curve = certificate.KeyTypeED25519, certificate.EllipticCurveED25519
+		key = keyType.String()
+	default:
+		return nil
+	}
+
+	algorithmAllowed := false
+	for _, cfg := range allowed {
+		if cfg.KeyType == keyType {
+			algorithmAllowed = true
+		}
+
+		switch {
+		case cfg.KeyType != keyType:
+			// Venafi Cloud lists Ed25519 as a curve of ECDSA keys.
+			if keyType == certificate.KeyTypeED25519 && cfg.KeyType == certificate.KeyTypeECDSA && slices.Contains(cfg.KeyCurves, curve) {
+				return nil
+			}
+		case keyType == certificate.KeyTypeRSA:
+			if slices.Contains(cfg.KeySizes, size) {
+				return nil
+			}
+		case keyType == certificate.KeyTypeECDSA:
+			if slices.Contains(cfg.KeyCurves, curve) {
+				return nil
+			}
+		case keyType == certificate.KeyTypeED25519:
+			return nil
+		}
+	}
+
+	violation := KeyPolicyViolationError{Key: key, Allowed: describeKeyConfigurations(allowed)}
+	if !algorithmAllowed {
+		violation.Expected = expectedPrivateKeys(allowed)
+	}
+	return violation
+}
+
+// expectedPrivateKeys returns a description of the private key settings of a
+// Certificate which match each of the given key configurations, for example
+// "ECDSA with a size of 256 or 384". Venafi Cloud lists Ed25519 as a curve of
+// ECDSA keys, but it is a separate algorithm for Certificates.
+func expectedPrivateKeys(allowed []endpoint.AllowedKeyConfiguration) []string {
+	var expected []string
+	add := func(algorithm cmapi.PrivateKeyAlgorithm, sizes []string) {
+		description := string(algorithm)
+		if len(sizes) > 0 {
+			description = fmt.Sprintf("%s with a size of %s", description, joinOr(sizes))
+		}
+		if !slices.Contains(expected, description) {
+			expected = append(expected, description)
+		}
+	}
+
+	for _, cfg := range allowed {
+		switch cfg.KeyType {
+		case certificate.KeyTypeRSA:
+			var sizes []string
+			for _, size := range cfg.KeySizes {
+				sizes = append(sizes, strconv.Itoa(size))
+			}
+			add(cmapi.RSAKeyAlgorithm, sizes)
+		case certificate.KeyTypeECDSA:
+			var sizes []string
+			ed25519 := false
+			for _, curve := range cfg.KeyCurves {
+				switch curve {
+				case certificate.EllipticCurveP256:
+					sizes = append(sizes, "256")
+				case certificate.EllipticCurveP384:
+					sizes = append(sizes, "384")
+				case certificate.EllipticCurveP521:
+					sizes = append(sizes, "521")
+				case certificate.EllipticCurveED25519:
+					ed25519 = true
+				}
+			}
+			if len(sizes) > 0 || !ed25519 {
+				add(cmapi.ECDSAKeyAlgorithm, sizes)
+			}
+			if ed25519 {
+				add(cmapi.Ed25519KeyAlgorithm, nil)
+			}
+		case certificate.KeyTypeED25519:
+			add(cmapi.Ed25519KeyAlgorithm, nil)
+		}
+	}
+	return expected
+}
+
+// joinOr joins the given values as "a, b or c".
+func joinOr(values []string) string {
+	if len(values) == 1 {
+		return values[0]
+	}
+	return strings.Join(values[:len(values)-1], ", ") + " or " + values[len(values)-1]
+}
+
+// describeKeyConfigurations returns a human readable description of each of
+// the given key configurations, for example "RSA (2048, 4096)".
+func describeKeyConfigurations(allowed []endpoint.AllowedKeyConfiguration) []string {
+	descriptions := make([]string, 0, len(allowed))
+	for _, cfg := range allowed {
+		var options []string
+		for _, size := range cfg.KeySizes {
+			options = append(options, strconv.Itoa(size))
+		}
+		for _, curve := range cfg.KeyCurves {
+			options = append(options, curve.String())
+		}
+
+		description := cfg.KeyType.String()
+		if len(options) > 0 {
+			description = fmt.Sprintf("%s (%s)", description, strings.Join(options, ", "))
+		}
+		descriptions = append(descriptions, description)
+	}
+	return descriptions
+}
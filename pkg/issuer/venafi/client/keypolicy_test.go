@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto"
+	"errors"
+	"testing"
+
+	"github.com/Venafi/vcert/v5/pkg/certificate"
+	"github.com/Venafi/vcert/v5/pkg/endpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalfake "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func TestValidateKeyPolicy(t *testing.T) {
+	mustKey := func(key crypto.Signer, err error) crypto.PublicKey {
+		require.NoError(t, err)
+		return key.Public()
+	}
+
+	rsa2048 := mustKey(pki.GenerateRSAPrivateKey(2048))
+	p256 := mustKey(pki.GenerateECPrivateKey(256))
+	p521 := mustKey(pki.GenerateECPrivateKey(521))
+	ed25519 := mustKey(pki.GenerateEd25519PrivateKey())
+
+	rsaOnly := []endpoint.AllowedKeyConfiguration{
+		{KeyType: certificate.KeyTypeRSA, KeySizes: []int{2048, 4096}},
+	}
+	rsaAndECDSA := []endpoint.AllowedKeyConfiguration{
+		{KeyType: certificate.KeyTypeRSA, KeySizes: []int{2048, 4096}},
+		{KeyType: certificate.KeyTypeECDSA, KeyCurves: []certificate.EllipticCurve{certificate.EllipticCurveP256, certificate.EllipticCurveP384}},
+	}
+
+	tests := map[string]struct {
+		publicKey crypto.PublicKey
+		allowed   []endpoint.AllowedKeyConfiguration
+		expErr    string
+		// expExpected are the private key settings expected by the zone.
+		expExpected []string
+	}{
+		"any key is allowed without key configurations": {
+			publicKey: p521,
+		},
+		"allowed RSA key": {
+			publicKey: rsa2048,
+			allowed:   rsaOnly,
+		},
+		"RSA key of a size which is not allowed": {
+			publicKey: rsa2048,
+			allowed:   []endpoint.AllowedKeyConfiguration{{KeyType: certificate.KeyTypeRSA, KeySizes: []int{4096}}},
+			expErr:    "the Venafi zone does not allow RSA 2048 keys, allowed keys are: RSA (4096)",
+		},
+		"ECDSA key in an RSA only zone": {
+			publicKey:   p256,
+			allowed:     rsaOnly,
+			expErr:      "the Venafi zone does not allow ECDSA P256 keys, allowed keys are: RSA (2048, 4096)",
+			expExpected: []string{"RSA with a size of 2048 or 4096"},
+		},
+		"RSA key in a zone which only allows ECDSA and Ed25519 keys": {
+			publicKey: rsa2048,
+			allowed: []endpoint.AllowedKeyConfiguration{
+				{KeyType: certificate.KeyTypeECDSA, KeyCurves: []certificate.EllipticCurve{certificate.EllipticCurveP256, certificate.EllipticCurveED25519}},
+			},
+			expErr:      "the Venafi zone does not allow RSA 2048 keys, allowed keys are: ECDSA (P256, ED25519)",
+			expExpected: []string{"ECDSA with a size of 256", "Ed25519"},
+		},
+		"allowed ECDSA key": {
+			publicKey: p256,
+			allowed:   rsaAndECDSA,
+		},
+		"ECDSA P521 key in a zone which does not allow P521": {
+			publicKey: p521,
+			allowed:   rsaAndECDSA,
+			expErr:    "the Venafi zone does not allow ECDSA P521 keys, allowed keys are: RSA (2048, 4096); ECDSA (P256, P384)",
+		},
+		"Ed25519 key listed as an ECDSA curve": {
+			publicKey: ed25519,
+			allowed:   []endpoint.AllowedKeyConfiguration{{KeyType: certificate.KeyTypeECDSA, KeyCurves: []certificate.EllipticCurve{certificate.EllipticCurveED25519}}},
+		},
+		"Ed25519 key in a zone which does not allow Ed25519": {
+			publicKey:   ed25519,
+			allowed:     rsaAndECDSA,
+			expErr:      "the Venafi zone does not allow ED25519 keys, allowed keys are: RSA (2048, 4096); ECDSA (P256, P384)",
+			expExpected: []string{"RSA with a size of 2048 or 4096", "ECDSA with a size of 256 or 384"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateKeyPolicy(test.publicKey, test.allowed)
+			if test.expErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, test.expErr)
+
+			var violation KeyPolicyViolationError
+			assert.True(t, errors.As(err, &violation))
+			assert.Equal(t, test.expExpected, violation.Expected)
+		})
+	}
+}
+
+func TestVenafi_RequestCertificateKeyPolicyViolation(t *testing.T) {
+	privateKey, err := pki.GenerateECPrivateKey(521)
+	require.NoError(t, err)
+	csrPEM := generateCSR(t, privateKey, "common-name", []string{"foo.example.com"})
+
+	v := &Venafi{
+		vcertClient: internalfake.Connector{
+			ReadZoneConfigurationFunc: func() (*endpoint.ZoneConfiguration, error) {
+				return &endpoint.ZoneConfiguration{
+					Policy: endpoint.Policy{
+						AllowedKeyConfigurations: []endpoint.AllowedKeyConfiguration{
+							{KeyType: certificate.KeyTypeRSA, KeySizes: []int{2048}},
+						},
+					},
+				}, nil
+			},
+			RequestCertificateFunc: func(*certificate.Request) (string, error) {
+				return "", errors.New("certificate should not be requested")
+			},
+		}.Default(),
+	}
+
+	_, err = v.RequestCertificate(csrPEM, 0, "", nil, 0, nil)
+	assert.EqualError(t, err, "the Venafi zone does not allow ECDSA P521 keys, allowed keys are: RSA (2048)")
+}
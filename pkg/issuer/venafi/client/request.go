@@ -17,6 +17,7 @@ limitations under the License.
 package client
 
 import (
+	"crypto"
 	"crypto/x509"
 	"errors"
 	"fmt"
@@ -24,9 +25,11 @@ import (
 	"time"
 
 	"github.com/Venafi/vcert/v5/pkg/certificate"
+	"github.com/Venafi/vcert/v5/pkg/endpoint"
 	"github.com/Venafi/vcert/v5/pkg/venafi/tpp"
 
 	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
@@ -39,18 +42,55 @@ func (err ErrCustomFieldsType) Error() string {
 	return fmt.Sprintf("certificate request contains an invalid Venafi custom fields type: %q", err.Type)
 }
 
+// MaxFriendlyNameLength is the maximum length of the friendly name of a
+// certificate. TPP uses the friendly name as the name of the certificate
+// object, which is limited to 255 characters.
+const MaxFriendlyNameLength = 255
+
 var ErrorMissingSubject = errors.New("Certificate requests submitted to Venafi issuers must have the 'commonName' field or at least one other subject field set.")
 
 // This function sends a request to Venafi to for a signed certificate.
 // The CSR will be decoded to be validated against the zone configuration policy.
 // Upon the template being successfully defaulted and validated, the CSR will be sent, as is.
+// If duration is non-zero, the certificate is requested to be valid for the
+// given duration instead of the validity configured for the zone.
+// If friendlyName is set, it is used as the name of the certificate instead
+// of the name derived from the CSR.
+// If location is set and the connector is Venafi Cloud, it is recorded as the
+// usage metadata of the certificate. If the connector is TPP, the certificate
+// is installed on the application object of the device object identified by
+// the location, which are created if they do not exist yet.
+// If signatureHash is non-zero, the certificate is requested to be signed with
+// the given hash algorithm, which must be allowed by the zone.
 // It will return a pickup ID which can be used with RetrieveCertificate to get the certificate
-func (v *Venafi) RequestCertificate(csrPEM []byte, customFields []api.CustomField) (string, error) {
-	vreq, err := v.buildVReq(csrPEM, customFields)
+func (v *Venafi) RequestCertificate(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, customFields []api.CustomField) (string, error) {
+	vreq, err := v.buildVReq(csrPEM, signatureHash, customFields)
 	if err != nil {
 		return "", err
 	}
 
+	if duration > 0 {
+		vreq.ValidityDuration = &duration
+	}
+
+	if friendlyName != "" {
+		vreq.FriendlyName = friendlyName
+	}
+
+	if location != nil {
+		vreq.Location = &certificate.Location{
+			Instance: location.Instance,
+			Workload: location.Workload,
+		}
+		// TPP refuses to associate a certificate with an application it is
+		// already associated with unless asked to replace the association,
+		// which would fail every renewal.
+		if v.tppClient != nil {
+			vreq.Location.Zone = location.Folder
+			vreq.Location.Replace = true
+		}
+	}
+
 	// If the connector is TPP, we unconditionally reset any prior failed enrollment
 	// so that we don't get stuck with "Fix any errors, and then click Retry."
 	// (60% of the time) or "WebSDK CertRequest" (40% of the time).
@@ -78,36 +118,136 @@ func (v *Venafi) RequestCertificate(csrPEM []byte, customFields []api.CustomFiel
 		}
 	}
 
+	if log := v.logger.V(logf.TraceLevel); log.Enabled() {
+		var zone string
+		if v.config != nil {
+			zone = v.config.Zone
+		}
+		log.Info("requesting certificate from the Venafi platform", requestLogValues(zone, vreq)...)
+	}
+
 	return v.vcertClient.RequestCertificate(vreq)
 }
 
+// ValidateCertificateRequest checks whether a CSR would be accepted by the
+// Venafi zone, by applying the zone defaults and validating the request against
+// the zone policy. No certificate is requested from Venafi.
+func (v *Venafi) ValidateCertificateRequest(csrPEM []byte, customFields []api.CustomField) error {
+	_, err := v.buildVReq(csrPEM, 0, customFields)
+	return err
+}
+
+// ValidateFriendlyName checks whether the given friendly name can be used as
+// the name of a certificate in Venafi.
+func ValidateFriendlyName(friendlyName string) error {
+	switch {
+	case strings.TrimSpace(friendlyName) == "":
+		return errors.New("friendly name must not be empty")
+	case len(friendlyName) > MaxFriendlyNameLength:
+		return fmt.Errorf("friendly name must be no more than %d characters, got %d", MaxFriendlyNameLength, len(friendlyName))
+	case strings.Contains(friendlyName, `\`):
+		return errors.New(`friendly name must not contain '\'`)
+	}
+	return nil
+}
+
 func (v *Venafi) RetrieveCertificate(pickupID string, csrPEM []byte, customFields []api.CustomField) ([]byte, error) {
-	vreq, err := v.buildVReq(csrPEM, customFields)
+	chain, _, err := v.retrieveCertificate(pickupID, csrPEM, customFields)
+	return chain, err
+}
+
+// RetrieveCertificateAndKey retrieves the certificate with the given pickup ID
+// along with the private key generated for it by TPP, if the client requests
+// service generated keys. The key is returned PEM encoded in PKCS#8 format.
+func (v *Venafi) RetrieveCertificateAndKey(pickupID string, csrPEM []byte, customFields []api.CustomField) ([]byte, []byte, error) {
+	if !v.serviceGeneratedKeys {
+		return nil, nil, errors.New("the private keys of the certificates of the issuer are not generated by the Venafi platform")
+	}
+
+	chain, keyPEM, err := v.retrieveCertificate(pickupID, csrPEM, customFields)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if len(keyPEM) == 0 {
+		return nil, nil, errors.New("the Venafi platform did not return the private key of the certificate")
+	}
+
+	return chain, keyPEM, nil
+}
+
+func (v *Venafi) retrieveCertificate(pickupID string, csrPEM []byte, customFields []api.CustomField) ([]byte, []byte, error) {
+	vreq, err := v.buildVReq(csrPEM, 0, customFields)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	vreq.PickupID = pickupID
 	vreq.Timeout = time.Second * 60
 
+	// TPP only returns service generated keys encrypted, so a password is
+	// generated for every retrieval and the key decrypted on receipt.
+	if v.serviceGeneratedKeys {
+		vreq.KeyPassword, err = newKeyPassword()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// Retrieve the certificate from request
 	pemCollection, err := v.vcertClient.RetrieveCertificate(vreq)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Construct the certificate chain and return the new keypair
 	cs := append([]string{pemCollection.Certificate}, pemCollection.Chain...)
 	chain := strings.Join(cs, "\n")
 
-	return []byte(chain), nil
+	if !v.serviceGeneratedKeys || pemCollection.PrivateKey == "" {
+		return []byte(chain), nil, nil
+	}
+
+	keyPEM, err := decryptPrivateKey(pemCollection.PrivateKey, vreq.KeyPassword)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return []byte(chain), keyPEM, nil
 }
 
-func (v *Venafi) buildVReq(csrPEM []byte, customFields []api.CustomField) (*certificate.Request, error) {
+// RevokeCertificate revokes the certificate with the given pickup ID. The
+// pickup ID of a certificate issued by TPP is the DN of the certificate, which
+// TPP uses to identify the certificate to revoke. Venafi Cloud does not support
+// revocation, and returns an error.
+func (v *Venafi) RevokeCertificate(pickupID string) error {
+	return v.vcertClient.RevokeCertificate(&certificate.RevocationRequest{
+		CertificateDN: pickupID,
+		Comments:      "revoked by cert-manager because the Certificate was deleted",
+	})
+}
+
+// readCachedZoneConfiguration reads the zone configuration through the zone
+// cache of the client, if it has one. Errors are returned as a
+// ZonePolicyUnavailableError.
+func (v *Venafi) readCachedZoneConfiguration() (*endpoint.ZoneConfiguration, error) {
+	var zoneCfg *endpoint.ZoneConfiguration
+	var err error
+	if v.zoneCache == nil {
+		zoneCfg, err = v.vcertClient.ReadZoneConfiguration()
+	} else {
+		zoneCfg, err = v.zoneCache.get(v.zoneCacheKey, v.vcertClient.ReadZoneConfiguration)
+	}
+	if err != nil {
+		return nil, ZonePolicyUnavailableError{Err: err}
+	}
+	return zoneCfg, nil
+}
+
+func (v *Venafi) buildVReq(csrPEM []byte, signatureHash crypto.Hash, customFields []api.CustomField) (*certificate.Request, error) {
 	// Retrieve a copy of the Venafi zone.
 	// This contains default values and policy control info that we can apply
 	// and check against locally.
-	zoneCfg, err := v.vcertClient.ReadZoneConfiguration()
+	zoneCfg, err := v.readCachedZoneConfiguration()
 	if err != nil {
 		return nil, err
 	}
@@ -121,6 +261,26 @@ func (v *Venafi) buildVReq(csrPEM []byte, customFields []api.CustomField) (*cert
 		return nil, ErrorMissingSubject
 	}
 
+	// Check the key before the rest of the policy, as the Venafi platform
+	// does not say which keys are allowed when it rejects a key.
+	if err := validateKeyPolicy(tmpl.PublicKey, zoneCfg.Policy.AllowedKeyConfigurations); err != nil {
+		return nil, err
+	}
+
+	if err := validateURISANPolicy(tmpl.URIs, zoneCfg.Policy.UriSanRegExs); err != nil {
+		return nil, err
+	}
+
+	if err := validateWildcardPolicy(tmpl.Subject.CommonName, tmpl.DNSNames, zoneCfg.Policy.AllowWildcards); err != nil {
+		return nil, err
+	}
+
+	// The CSR is sent as is, so its custom extensions are preserved if the
+	// Venafi zone allows them.
+	if err := validateExtensionPolicy(tmpl.Extensions, v.allowedExtensions); err != nil {
+		return nil, err
+	}
+
 	// Create a vcert Request structure
 	vreq := newVRequest(tmpl)
 
@@ -131,6 +291,12 @@ func (v *Venafi) buildVReq(csrPEM []byte, customFields []api.CustomField) (*cert
 	}
 	vreq.CustomFields = append(vreq.CustomFields, vfields...)
 
+	// Apply the subject defaults of the issuer before those of the Venafi
+	// zone, so that they take precedence over the zone defaults.
+	if err := applySubjectDefaults(&vreq.Subject, v.subjectDefaults, &zoneCfg.Policy); err != nil {
+		return nil, err
+	}
+
 	// Apply default values from the Venafi zone
 	zoneCfg.UpdateCertificateRequest(vreq)
 
@@ -142,12 +308,31 @@ func (v *Venafi) buildVReq(csrPEM []byte, customFields []api.CustomField) (*cert
 		return nil, err
 	}
 
+	// vcert reports SHA-256 as the hash algorithm of every TPP zone regardless
+	// of its policy, so it is only checked against the zones of Venafi Cloud,
+	// and the hash algorithm of issued certificates is verified instead.
+	zoneAlgorithm := zoneCfg.HashAlgorithm
+	if v.tppClient != nil {
+		zoneAlgorithm = x509.UnknownSignatureAlgorithm
+	}
+	if err := applySignatureHash(vreq, tmpl.PublicKey, signatureHash, zoneAlgorithm); err != nil {
+		return nil, err
+	}
+
 	friendlyName, err := getVcertFriendlyName(tmpl)
 	if err != nil {
 		return nil, err
 	}
 	vreq.FriendlyName = friendlyName
 
+	// If TPP generates the private key, it is generated with the algorithm
+	// of the key of the CSR, which newVRequest set on the request, and the
+	// CSR itself is not sent.
+	if v.serviceGeneratedKeys {
+		vreq.CsrOrigin = certificate.ServiceGeneratedCSR
+		return vreq, nil
+	}
+
 	// Set options on the request
 	vreq.CsrOrigin = certificate.UserProvidedCSR
 
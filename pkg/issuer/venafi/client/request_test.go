@@ -18,7 +18,10 @@ package client
 
 import (
 	"crypto"
+	"encoding/pem"
 	"errors"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/Venafi/vcert/v5/pkg/certificate"
@@ -29,6 +32,7 @@ import (
 	internalfake "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
 	"github.com/cert-manager/cert-manager/pkg/util"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
@@ -214,7 +218,7 @@ func TestVenafi_RequestCertificate(t *testing.T) {
 					"foo.example.com", "bar.example.com"})
 			}
 
-			got, err := v.RequestCertificate(tt.args.csrPEM, tt.args.customFields)
+			got, err := v.RequestCertificate(tt.args.csrPEM, 0, "", nil, 0, tt.args.customFields)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("RequestCertificate() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -226,6 +230,60 @@ func TestVenafi_RequestCertificate(t *testing.T) {
 	}
 }
 
+func TestVenafi_ValidateCertificateRequest(t *testing.T) {
+	privateKey, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		vcertClient connector
+		csrPEM      []byte
+		wantErr     bool
+	}{
+		{
+			name: "error if validating the certificate fails",
+			vcertClient: internalfake.Connector{
+				ReadZoneConfigurationFunc: func() (*endpoint.ZoneConfiguration, error) {
+					return &endpoint.ZoneConfiguration{
+						Policy: endpoint.Policy{
+							SubjectCNRegexes: []string{"foo"},
+						},
+					}, nil
+				},
+			}.Default(),
+			wantErr: true,
+		},
+		{
+			name: "no certificate is requested if validation succeeds",
+			vcertClient: internalfake.Connector{
+				RequestCertificateFunc: func(*certificate.Request) (string, error) {
+					return "", errors.New("certificate should not be requested")
+				},
+			}.Default(),
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &Venafi{
+				vcertClient: tt.vcertClient,
+			}
+
+			if tt.csrPEM == nil {
+				tt.csrPEM = generateCSR(t, privateKey, "common-name", []string{
+					"foo.example.com", "bar.example.com"})
+			}
+
+			err := v.ValidateCertificateRequest(tt.csrPEM, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCertificateRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestVenafi_RetrieveCertificate(t *testing.T) {
 	privateKey, err := pki.GenerateRSAPrivateKey(2048)
 	if err != nil {
@@ -278,7 +336,7 @@ func TestVenafi_RetrieveCertificate(t *testing.T) {
 			// this is needed to provide the fake venafi client with a "valid" pickup id
 			// testing errors in this should be done in TestVenafi_RequestCertificate
 			// any error returned in these tests is a hard fail
-			pickupID, err := v.RequestCertificate(tt.args.csrPEM, tt.args.customFields)
+			pickupID, err := v.RequestCertificate(tt.args.csrPEM, 0, "", nil, 0, tt.args.customFields)
 			if err != nil {
 				t.Errorf("RequestCertificate() should but error but got error = %v", err)
 			}
@@ -293,3 +351,244 @@ func TestVenafi_RetrieveCertificate(t *testing.T) {
 		})
 	}
 }
+
+func TestVenafi_RevokeCertificate(t *testing.T) {
+	var got *certificate.RevocationRequest
+	v := &Venafi{
+		vcertClient: internalfake.Connector{
+			RevokeCertificateFunc: func(req *certificate.RevocationRequest) error {
+				got = req
+				return nil
+			},
+		}.Default(),
+	}
+
+	if err := v.RevokeCertificate(`\VED\Policy\zone\test`); err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || got.CertificateDN != `\VED\Policy\zone\test` {
+		t.Errorf("expected the certificate DN to be the pickup ID, got %+v", got)
+	}
+}
+
+func TestVenafi_RequestCertificateFriendlyName(t *testing.T) {
+	privateKey, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrPEM := generateCSR(t, privateKey, "common-name", []string{"foo.example.com"})
+
+	tests := map[string]struct {
+		friendlyName string
+		want         string
+	}{
+		"defaults to the common name of the CSR": {
+			want: "common-name",
+		},
+		"uses the given friendly name": {
+			friendlyName: "my-friendly-name",
+			want:         "my-friendly-name",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got string
+			v := &Venafi{
+				vcertClient: internalfake.Connector{
+					RequestCertificateFunc: func(req *certificate.Request) (string, error) {
+						got = req.FriendlyName
+						return "pickup-id", nil
+					},
+				}.Default(),
+			}
+
+			if _, err := v.RequestCertificate(csrPEM, 0, tt.friendlyName, nil, 0, nil); err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("expected friendly name %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestVenafi_RequestCertificateLocation(t *testing.T) {
+	privateKey, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrPEM := generateCSR(t, privateKey, "common-name", []string{"foo.example.com"})
+
+	tests := map[string]struct {
+		location *api.Location
+		want     *certificate.Location
+	}{
+		"no location is requested by default": {},
+		"uses the given location": {
+			location: &api.Location{Instance: "node-1", Workload: "payments"},
+			want:     &certificate.Location{Instance: "node-1", Workload: "payments"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got *certificate.Location
+			v := &Venafi{
+				vcertClient: internalfake.Connector{
+					RequestCertificateFunc: func(req *certificate.Request) (string, error) {
+						got = req.Location
+						return "pickup-id", nil
+					},
+				}.Default(),
+			}
+
+			if _, err := v.RequestCertificate(csrPEM, 0, "", tt.location, 0, nil); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected location %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestVenafi_ServiceGeneratedKeys(t *testing.T) {
+	csrKey, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrPEM := generateCSR(t, csrKey, "common-name", []string{"foo.example.com"})
+
+	// The key generated by the Venafi platform.
+	serviceKey, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serviceKeyPEM, err := pki.EncodePKCS8PrivateKey(serviceKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := testcrypto.MustCreateCert(t, serviceKeyPEM, gen.Certificate("test", gen.SetCertificateCommonName("common-name")))
+
+	var requested, retrieved *certificate.Request
+	v := &Venafi{
+		serviceGeneratedKeys: true,
+		vcertClient: internalfake.Connector{
+			RequestCertificateFunc: func(req *certificate.Request) (string, error) {
+				requested = req
+				return "pickup-id", nil
+			},
+			RetrieveCertificateFunc: func(req *certificate.Request) (*certificate.PEMCollection, error) {
+				retrieved = req
+				block, err := certificate.GetEncryptedPrivateKeyPEMBock(serviceKey, []byte(req.KeyPassword), "legacy-pem")
+				if err != nil {
+					return nil, err
+				}
+				return &certificate.PEMCollection{
+					Certificate: string(certPEM),
+					PrivateKey:  string(pem.EncodeToMemory(block)),
+				}, nil
+			},
+		}.Default(),
+	}
+
+	if _, err := v.RequestCertificate(csrPEM, 0, "", nil, 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	if requested.CsrOrigin != certificate.ServiceGeneratedCSR {
+		t.Errorf("expected a service generated CSR to be requested, got %v", requested.CsrOrigin)
+	}
+	if len(requested.GetCSR()) != 0 {
+		t.Errorf("expected the CSR not to be sent")
+	}
+	if requested.KeyType != certificate.KeyTypeECDSA || requested.KeyCurve != certificate.EllipticCurveP256 {
+		t.Errorf("expected the key algorithm of the CSR to be requested, got %v %v", requested.KeyType, requested.KeyCurve)
+	}
+
+	chain, keyPEM, err := v.RetrieveCertificateAndKey("pickup-id", csrPEM, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retrieved.KeyPassword == "" {
+		t.Errorf("expected the private key to be retrieved with a password")
+	}
+	if string(chain) != string(certPEM) {
+		t.Errorf("unexpected certificate chain %q", chain)
+	}
+	key, err := pki.DecodePrivateKeyBytes(keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if equal, err := pki.PublicKeysEqual(key.Public(), serviceKey.Public()); err != nil || !equal {
+		t.Errorf("expected the private key generated by the Venafi platform to be returned")
+	}
+
+	// Clients which do not request service generated keys cannot retrieve them.
+	v.serviceGeneratedKeys = false
+	if _, _, err := v.RetrieveCertificateAndKey("pickup-id", csrPEM, nil); err == nil {
+		t.Errorf("expected an error retrieving the key of a certificate whose key was not generated by the Venafi platform")
+	}
+}
+
+func TestValidateFriendlyName(t *testing.T) {
+	tests := map[string]struct {
+		friendlyName string
+		wantErr      bool
+	}{
+		"valid friendly name": {
+			friendlyName: "my-friendly-name",
+		},
+		"friendly name of the maximum length": {
+			friendlyName: strings.Repeat("a", MaxFriendlyNameLength),
+		},
+		"empty friendly name": {
+			friendlyName: " ",
+			wantErr:      true,
+		},
+		"friendly name too long": {
+			friendlyName: strings.Repeat("a", MaxFriendlyNameLength+1),
+			wantErr:      true,
+		},
+		"friendly name containing a backslash": {
+			friendlyName: `policy\name`,
+			wantErr:      true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateFriendlyName(tt.friendlyName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateFriendlyName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVenafi_RequestCertificateZonePolicyUnavailable(t *testing.T) {
+	privateKey, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrPEM := generateCSR(t, privateKey, "common-name", []string{"foo.example.com"})
+
+	readErr := errors.New("zone configuration error")
+	requested := false
+	v := &Venafi{
+		vcertClient: internalfake.Connector{
+			ReadZoneConfigurationFunc: func() (*endpoint.ZoneConfiguration, error) {
+				return nil, readErr
+			},
+			RequestCertificateFunc: func(*certificate.Request) (string, error) {
+				requested = true
+				return "pickup-id", nil
+			},
+		}.Default(),
+	}
+
+	_, err = v.RequestCertificate(csrPEM, 0, "", nil, 0, nil)
+	if !IsZonePolicyUnavailableError(err) || !errors.Is(err, readErr) {
+		t.Errorf("expected a ZonePolicyUnavailableError wrapping %v, got %v", readErr, err)
+	}
+	if requested {
+		t.Error("expected no certificate to be requested when the zone policy is unavailable")
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/Venafi/vcert/v5/pkg/certificate"
+)
+
+// requestLogValues returns the key/value pairs describing the resolved
+// request sent to the Venafi platform, to debug requests rejected by the zone
+// policy. Only the fields describing the requested certificate are included:
+// the CSR, the private key and its password are never logged.
+func requestLogValues(zone string, vreq *certificate.Request) []interface{} {
+	ipAddresses := make([]string, 0, len(vreq.IPAddresses))
+	for _, ip := range vreq.IPAddresses {
+		ipAddresses = append(ipAddresses, ip.String())
+	}
+
+	uris := make([]string, 0, len(vreq.URIs))
+	for _, uri := range vreq.URIs {
+		uris = append(uris, uri.String())
+	}
+
+	customFields := make(map[string][]string, len(vreq.CustomFields))
+	for _, field := range vreq.CustomFields {
+		customFields[field.Name] = append(customFields[field.Name], field.Value)
+	}
+
+	values := []interface{}{
+		"zone", zone,
+		"subject", vreq.Subject.String(),
+		"dnsNames", vreq.DNSNames,
+		"ipAddresses", ipAddresses,
+		"uris", uris,
+		"emailAddresses", vreq.EmailAddresses,
+		"upns", vreq.UPNs,
+		"keyType", vreq.KeyType.String(),
+		"customFields", customFields,
+	}
+
+	if vreq.FriendlyName != "" {
+		values = append(values, "friendlyName", vreq.FriendlyName)
+	}
+	if vreq.ValidityDuration != nil {
+		values = append(values, "duration", vreq.ValidityDuration.String())
+	}
+	if vreq.Location != nil {
+		values = append(values, "instance", vreq.Location.Instance, "workload", vreq.Location.Workload)
+		if vreq.Location.Zone != "" {
+			values = append(values, "folder", vreq.Location.Zone)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/x509/pkix"
+	"net"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Venafi/vcert/v5"
+	"github.com/Venafi/vcert/v5/pkg/certificate"
+	"github.com/Venafi/vcert/v5/pkg/endpoint"
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalfake "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestRequestLogValues(t *testing.T) {
+	duration := time.Hour
+	vreq := &certificate.Request{
+		Subject:          pkix.Name{CommonName: "example.com", Organization: []string{"Example Inc."}},
+		DNSNames:         []string{"example.com"},
+		IPAddresses:      []net.IP{net.ParseIP("10.0.0.1")},
+		URIs:             []*url.URL{{Scheme: "spiffe", Host: "example.com"}},
+		KeyType:          certificate.KeyTypeRSA,
+		FriendlyName:     "example",
+		ValidityDuration: &duration,
+		CustomFields: []certificate.CustomField{
+			{Name: "environment", Value: "dev"},
+			{Name: "environment", Value: "test"},
+		},
+		KeyPassword: "key-password",
+	}
+
+	assert.Equal(t, []interface{}{
+		"zone", "test-zone",
+		"subject", "CN=example.com,O=Example Inc.",
+		"dnsNames", []string{"example.com"},
+		"ipAddresses", []string{"10.0.0.1"},
+		"uris", []string{"spiffe://example.com"},
+		"emailAddresses", []string(nil),
+		"upns", []string(nil),
+		"keyType", "RSA",
+		"customFields", map[string][]string{"environment": {"dev", "test"}},
+		"friendlyName", "example",
+		"duration", "1h0m0s",
+	}, requestLogValues("test-zone", vreq))
+}
+
+func TestVenafi_RequestCertificateLogsRequest(t *testing.T) {
+	privateKey, err := pki.GenerateRSAPrivateKey(2048)
+	require.NoError(t, err)
+	csrPEM, err := gen.CSRWithSigner(privateKey, gen.SetCSRCommonName("example.com"), gen.SetCSRDNSNames("example.com"))
+	require.NoError(t, err)
+
+	newClient := func(verbosity int) (*Venafi, *[]string) {
+		var messages []string
+		return &Venafi{
+			vcertClient: internalfake.Connector{}.Default(),
+			config: &vcert.Config{
+				Zone:        "test-zone",
+				Credentials: &endpoint.Authentication{User: "user", Password: "secret-password"},
+			},
+			logger: funcr.New(func(prefix, args string) {
+				messages = append(messages, args)
+			}, funcr.Options{Verbosity: verbosity}),
+		}, &messages
+	}
+
+	t.Run("the request is logged at trace verbosity", func(t *testing.T) {
+		v, messages := newClient(logf.TraceLevel)
+		_, err := v.RequestCertificate(csrPEM, 0, "", nil, 0, nil)
+		require.NoError(t, err)
+
+		require.Len(t, *messages, 1)
+		assert.Contains(t, (*messages)[0], `"zone"="test-zone"`)
+		assert.Contains(t, (*messages)[0], `"subject"="CN=example.com"`)
+		assert.Contains(t, (*messages)[0], `"dnsNames"=["example.com"]`)
+		assert.False(t, strings.Contains((*messages)[0], "secret-password"), "expected the credentials not to be logged")
+		assert.False(t, strings.Contains((*messages)[0], "CERTIFICATE REQUEST"), "expected the CSR not to be logged")
+	})
+
+	t.Run("the request is not logged by default", func(t *testing.T) {
+		v, messages := newClient(logf.InfoLevel)
+		_, err := v.RequestCertificate(csrPEM, 0, "", nil, 0, nil)
+		require.NoError(t, err)
+
+		assert.Empty(t, *messages)
+	})
+}
@@ -0,0 +1,319 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+const (
+	// ResponseLogPath is the path at which the response log is served on
+	// the debug server of the controller.
+	ResponseLogPath = "/debug/venafi/responses"
+
+	// MaxResponseLogBodySize is the maximum size of the body kept for each
+	// response, so that the memory used by the response log is bounded by
+	// its size multiplied by this value.
+	MaxResponseLogBodySize = 16 * 1024
+
+	// redacted replaces the secrets removed from the bodies of responses.
+	redacted = "REDACTED"
+)
+
+// redactedFields are the lowercase names of the JSON fields whose values are
+// removed from the bodies of responses.
+var redactedFields = map[string]bool{
+	"access_token":   true,
+	"apikey":         true,
+	"keypassword":    true,
+	"password":       true,
+	"privatekey":     true,
+	"privatekeydata": true,
+	"refresh_token":  true,
+}
+
+// ResponseLogEntry is a raw response of the Venafi platform to an enrollment
+// or retrieval request, with its secrets redacted.
+type ResponseLogEntry struct {
+	// Time is the time at which the response was received.
+	Time time.Time `json:"time"`
+	// Issuer is the namespace/name of the issuer which sent the request, or
+	// the name of the issuer for ClusterIssuers.
+	Issuer string `json:"issuer"`
+	// Method and URL are the method and URL of the request, without its
+	// query.
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	// StatusCode is the status code of the response, or zero if the request
+	// failed before a response was received.
+	StatusCode int `json:"statusCode,omitempty"`
+	// Body is the redacted body of the response, truncated to
+	// MaxResponseLogBodySize.
+	Body      string `json:"body,omitempty"`
+	Truncated bool   `json:"truncated,omitempty"`
+	// Error is the error of the request if no response was received.
+	Error string `json:"error,omitempty"`
+}
+
+// ResponseLog keeps the most recent raw responses of the Venafi platform to
+// enrollment and retrieval requests in memory, to debug unexpected behaviour
+// of the Venafi platform without enabling verbose logging. Older responses
+// are discarded once the log is full, and the log is lost when the
+// controller restarts.
+type ResponseLog struct {
+	clock clock.Clock
+
+	lock    sync.Mutex
+	entries []ResponseLogEntry
+	// next is the index of entries at which the next response is stored.
+	next int
+	// full is true once every element of entries holds a response.
+	full bool
+}
+
+// NewResponseLog returns a response log which keeps at most size responses.
+// A size of zero or less returns nil, which records nothing.
+func NewResponseLog(clock clock.Clock, size int) *ResponseLog {
+	if size <= 0 {
+		return nil
+	}
+	return &ResponseLog{
+		clock:   clock,
+		entries: make([]ResponseLogEntry, size),
+	}
+}
+
+// Entries returns the responses in the log, from the oldest to the most
+// recent.
+func (l *ResponseLog) Entries() []ResponseLogEntry {
+	if l == nil {
+		return nil
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if !l.full {
+		return append([]ResponseLogEntry(nil), l.entries[:l.next]...)
+	}
+	return append(append([]ResponseLogEntry(nil), l.entries[l.next:]...), l.entries[:l.next]...)
+}
+
+func (l *ResponseLog) add(entry ResponseLogEntry) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.entries[l.next] = entry
+	l.next++
+	if l.next == len(l.entries) {
+		l.next = 0
+		l.full = true
+	}
+}
+
+// ServeHTTP serves the responses in the log as JSON.
+func (l *ResponseLog) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	entries := l.Entries()
+	if entries == nil {
+		entries = []ResponseLogEntry{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(struct {
+		Entries []ResponseLogEntry `json:"entries"`
+	}{entries})
+}
+
+// roundTripper returns a RoundTripper which records the responses to the
+// enrollment and retrieval requests sent by the given issuer to the log.
+// If the log is nil, inner is returned.
+func (l *ResponseLog) roundTripper(inner http.RoundTripper, issuer string) http.RoundTripper {
+	if l == nil {
+		return inner
+	}
+	return &responseLogRoundTripper{inner: inner, log: l, issuer: issuer}
+}
+
+// responseLogIssuer returns the name under which the responses to the
+// requests of the issuer are recorded.
+func responseLogIssuer(issuer cmapi.GenericIssuer) string {
+	if issuer.GetNamespace() == "" {
+		return issuer.GetName()
+	}
+	return issuer.GetNamespace() + "/" + issuer.GetName()
+}
+
+type responseLogRoundTripper struct {
+	inner  http.RoundTripper
+	log    *ResponseLog
+	issuer string
+}
+
+// RoundTrip implements http.RoundTripper
+func (rt *responseLogRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.inner.RoundTrip(req)
+	if !isEnrollmentOrRetrieval(req) {
+		return resp, err
+	}
+
+	u := *req.URL
+	u.RawQuery = ""
+	u.User = nil
+	entry := ResponseLogEntry{
+		Time:   rt.log.clock.Now(),
+		Issuer: rt.issuer,
+		Method: req.Method,
+		URL:    u.String(),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+		rt.log.add(entry)
+		return resp, err
+	}
+
+	// The body is read in full so that it can be redacted, and replaced so
+	// that vcert can still read it.
+	body, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	entry.StatusCode = resp.StatusCode
+	if readErr != nil {
+		entry.Error = readErr.Error()
+	}
+	entry.Body = redactBody(body)
+	if len(entry.Body) > MaxResponseLogBodySize {
+		entry.Body = entry.Body[:MaxResponseLogBodySize]
+		entry.Truncated = true
+	}
+	rt.log.add(entry)
+
+	return resp, nil
+}
+
+// isEnrollmentOrRetrieval returns true if the request enrolls or retrieves
+// a certificate with the TPP or Venafi Cloud API.
+func isEnrollmentOrRetrieval(req *http.Request) bool {
+	path := strings.ToLower(req.URL.Path)
+	switch {
+	case strings.Contains(path, "/vedsdk/certificates/request"),
+		strings.Contains(path, "/vedsdk/certificates/retrieve"),
+		strings.Contains(path, "/outagedetection/v1/certificaterequests"):
+		return true
+	case strings.Contains(path, "/outagedetection/v1/certificates/"):
+		return strings.HasSuffix(path, "/contents")
+	}
+	return false
+}
+
+// redactBody returns the body of a response with its secrets redacted. The
+// values of the fields of JSON bodies which hold credentials or private keys
+// are replaced, and so are the private keys in PEM form, either in the body or
+// base64 encoded in a field of a JSON body.
+func redactBody(body []byte) string {
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return redactPEM(string(body))
+	}
+
+	redacted, err := json.Marshal(redactJSON(v))
+	if err != nil {
+		return redactPEM(string(body))
+	}
+	return string(redacted)
+}
+
+func redactJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if redactedFields[strings.ToLower(key)] {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactJSON(value)
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactJSON(value)
+		}
+		return v
+	case string:
+		if decoded, err := base64.StdEncoding.DecodeString(v); err == nil && containsPrivateKey(string(decoded)) {
+			return redacted
+		}
+		return redactPEM(v)
+	default:
+		return v
+	}
+}
+
+// redactPEM replaces the PEM encoded private keys in s.
+func redactPEM(s string) string {
+	if !containsPrivateKey(s) {
+		return s
+	}
+
+	var out strings.Builder
+	rest := []byte(s)
+	for {
+		start := bytes.Index(rest, []byte("-----BEGIN "))
+		if start < 0 {
+			out.Write(rest)
+			return out.String()
+		}
+		block, next := pem.Decode(rest[start:])
+		if block == nil {
+			// The rest cannot be decoded, so it is dropped in case it holds
+			// a private key.
+			out.Write(rest[:start])
+			out.WriteString(redacted)
+			return out.String()
+		}
+		out.Write(rest[:start])
+		if strings.Contains(block.Type, "PRIVATE KEY") {
+			out.WriteString(redacted + "\n")
+		} else {
+			out.Write(rest[start : len(rest)-len(next)])
+		}
+		rest = next
+	}
+}
+
+func containsPrivateKey(s string) bool {
+	return strings.Contains(s, "PRIVATE KEY-----")
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func respondWith(statusCode int, body string) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: statusCode,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+}
+
+func TestResponseLogRoundTripper(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	privateKeyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("private key")}))
+	certificatePEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("certificate")}))
+
+	tests := map[string]struct {
+		inner  http.RoundTripper
+		method string
+		url    string
+
+		expectedEntries []ResponseLogEntry
+	}{
+		"a TPP enrollment response is recorded": {
+			inner:  respondWith(http.StatusOK, `{"CertificateDN":"\\VED\\Policy\\test"}`),
+			method: http.MethodPost,
+			url:    "https://tpp.example.com/vedsdk/certificates/request",
+			expectedEntries: []ResponseLogEntry{{
+				Time:       now,
+				Issuer:     "test-namespace/test-issuer",
+				Method:     http.MethodPost,
+				URL:        "https://tpp.example.com/vedsdk/certificates/request",
+				StatusCode: http.StatusOK,
+				Body:       `{"CertificateDN":"\\VED\\Policy\\test"}`,
+			}},
+		},
+		"a Venafi Cloud retrieval response is recorded without its query": {
+			inner:  respondWith(http.StatusOK, certificatePEM),
+			method: http.MethodGet,
+			url:    "https://api.venafi.cloud/outagedetection/v1/certificates/1234/contents?format=PEM",
+			expectedEntries: []ResponseLogEntry{{
+				Time:       now,
+				Issuer:     "test-namespace/test-issuer",
+				Method:     http.MethodGet,
+				URL:        "https://api.venafi.cloud/outagedetection/v1/certificates/1234/contents",
+				StatusCode: http.StatusOK,
+				Body:       certificatePEM,
+			}},
+		},
+		"the credentials and private keys of a JSON response are redacted": {
+			inner: respondWith(http.StatusOK, `{"CertificateData":"`+base64.StdEncoding.EncodeToString([]byte(certificatePEM+privateKeyPEM))+`",`+
+				`"PrivateKeyData":"secret","access_token":"secret","Chain":["`+strings.ReplaceAll(privateKeyPEM, "\n", `\n`)+`"]}`),
+			method: http.MethodPost,
+			url:    "https://tpp.example.com/vedsdk/certificates/retrieve",
+			expectedEntries: []ResponseLogEntry{{
+				Time:       now,
+				Issuer:     "test-namespace/test-issuer",
+				Method:     http.MethodPost,
+				URL:        "https://tpp.example.com/vedsdk/certificates/retrieve",
+				StatusCode: http.StatusOK,
+				Body:       `{"CertificateData":"REDACTED","Chain":["REDACTED\n"],"PrivateKeyData":"REDACTED","access_token":"REDACTED"}`,
+			}},
+		},
+		"the private keys of a PEM response are redacted": {
+			inner:  respondWith(http.StatusOK, certificatePEM+privateKeyPEM),
+			method: http.MethodGet,
+			url:    "https://api.venafi.cloud/outagedetection/v1/certificates/1234/contents",
+			expectedEntries: []ResponseLogEntry{{
+				Time:       now,
+				Issuer:     "test-namespace/test-issuer",
+				Method:     http.MethodGet,
+				URL:        "https://api.venafi.cloud/outagedetection/v1/certificates/1234/contents",
+				StatusCode: http.StatusOK,
+				Body:       certificatePEM + "REDACTED\n",
+			}},
+		},
+		"a large response is truncated": {
+			inner:  respondWith(http.StatusOK, strings.Repeat("a", MaxResponseLogBodySize+1)),
+			method: http.MethodGet,
+			url:    "https://api.venafi.cloud/outagedetection/v1/certificaterequests/1234",
+			expectedEntries: []ResponseLogEntry{{
+				Time:       now,
+				Issuer:     "test-namespace/test-issuer",
+				Method:     http.MethodGet,
+				URL:        "https://api.venafi.cloud/outagedetection/v1/certificaterequests/1234",
+				StatusCode: http.StatusOK,
+				Body:       strings.Repeat("a", MaxResponseLogBodySize),
+				Truncated:  true,
+			}},
+		},
+		"a failed request is recorded": {
+			inner: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+				return nil, errors.New("connection refused")
+			}),
+			method: http.MethodPost,
+			url:    "https://tpp.example.com/vedsdk/certificates/request",
+			expectedEntries: []ResponseLogEntry{{
+				Time:   now,
+				Issuer: "test-namespace/test-issuer",
+				Method: http.MethodPost,
+				URL:    "https://tpp.example.com/vedsdk/certificates/request",
+				Error:  "connection refused",
+			}},
+		},
+		"other responses are not recorded": {
+			inner:  respondWith(http.StatusOK, `{"access_token":"secret"}`),
+			method: http.MethodPost,
+			url:    "https://tpp.example.com/vedauth/authorize/oauth",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			log := NewResponseLog(fakeclock.NewFakeClock(now), 10)
+			rt := log.roundTripper(test.inner, "test-namespace/test-issuer")
+
+			req, err := http.NewRequest(test.method, test.url, nil)
+			require.NoError(t, err)
+
+			resp, err := rt.RoundTrip(req)
+			if err == nil {
+				// The caller must still be able to read the unredacted body.
+				_, err := io.ReadAll(resp.Body)
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, test.expectedEntries, log.Entries())
+		})
+	}
+}
+
+func TestResponseLogKeepsTheMostRecentResponses(t *testing.T) {
+	log := NewResponseLog(fakeclock.NewFakeClock(time.Now()), 2)
+	for _, name := range []string{"first", "second", "third"} {
+		log.add(ResponseLogEntry{Issuer: name})
+	}
+
+	var issuers []string
+	for _, entry := range log.Entries() {
+		issuers = append(issuers, entry.Issuer)
+	}
+	assert.Equal(t, []string{"second", "third"}, issuers)
+}
+
+func TestResponseLogDisabled(t *testing.T) {
+	log := NewResponseLog(fakeclock.NewFakeClock(time.Now()), 0)
+	assert.Nil(t, log)
+
+	inner := respondWith(http.StatusOK, "")
+	assert.IsType(t, inner, log.roundTripper(inner, "test-issuer"))
+	assert.Empty(t, log.Entries())
+}
+
+func TestResponseLogServeHTTP(t *testing.T) {
+	log := NewResponseLog(fakeclock.NewFakeClock(time.Now()), 2)
+	log.add(ResponseLogEntry{Issuer: "test-issuer", StatusCode: http.StatusOK})
+
+	rec := httptest.NewRecorder()
+	log.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ResponseLogPath, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var body struct {
+		Entries []ResponseLogEntry `json:"entries"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, log.Entries(), body.Entries)
+
+	rec = httptest.NewRecorder()
+	log.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, ResponseLogPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
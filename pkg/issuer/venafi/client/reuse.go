@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Venafi/vcert/v5/pkg/certificate"
+	"github.com/Venafi/vcert/v5/pkg/verror"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// FindReusableCertificate searches the zone of the client for a valid
+// certificate issued after issuedAfter for the common name, DNS names and
+// public key of the given CSR, which can be returned for the request instead
+// of issuing a new certificate. If several certificates match, the one which
+// expires last is used.
+// It returns the pickup ID and the PEM encoded chain of the certificate, or
+// an empty pickup ID if there is no certificate to reuse.
+func (v *Venafi) FindReusableCertificate(csrPEM []byte, issuedAfter time.Time) (string, []byte, error) {
+	tmpl, err := pki.CertificateTemplateFromCSRPEM(csrPEM)
+	if err != nil {
+		return "", nil, err
+	}
+
+	info, err := v.vcertClient.SearchCertificate(v.config.Zone, tmpl.Subject.CommonName, &certificate.Sans{DNS: tmpl.DNSNames}, 0)
+	if errors.Is(err, verror.NoCertificateFoundError) || errors.Is(err, verror.NoCertificateWithMatchingZoneFoundError) {
+		return "", nil, nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	if info.ValidFrom.Before(issuedAfter) {
+		return "", nil, nil
+	}
+
+	// The certificate is retrieved by its thumbprint, which sets the pickup
+	// ID of the request to the DN of the certificate.
+	vreq := &certificate.Request{
+		Thumbprint:  info.Thumbprint,
+		ChainOption: certificate.ChainOptionRootLast,
+	}
+	pemCollection, err := v.vcertClient.RetrieveCertificate(vreq)
+	if err != nil {
+		return "", nil, err
+	}
+
+	cert, err := pki.DecodeX509CertificateBytes([]byte(pemCollection.Certificate))
+	if err != nil {
+		return "", nil, err
+	}
+
+	// A certificate issued for another key cannot be used with the private
+	// key of the request.
+	matches, err := pki.PublicKeysEqual(cert.PublicKey, tmpl.PublicKey)
+	if err != nil || !matches {
+		return "", nil, err
+	}
+
+	cs := append([]string{pemCollection.Certificate}, pemCollection.Chain...)
+	return vreq.PickupID, []byte(strings.Join(cs, "\n")), nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Venafi/vcert/v5"
+	"github.com/Venafi/vcert/v5/pkg/certificate"
+	"github.com/Venafi/vcert/v5/pkg/verror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalfake "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func selfSignedCertificatePEM(t *testing.T, key crypto.Signer, csrPEM []byte) string {
+	tmpl, err := pki.CertificateTemplateFromCSRPEM(csrPEM)
+	require.NoError(t, err)
+	tmpl.SerialNumber = big.NewInt(1)
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	certPEM, err := pki.EncodeX509(cert)
+	require.NoError(t, err)
+
+	return string(certPEM)
+}
+
+func TestVenafi_FindReusableCertificate(t *testing.T) {
+	privateKey, err := pki.GenerateRSAPrivateKey(2048)
+	require.NoError(t, err)
+	otherKey, err := pki.GenerateRSAPrivateKey(2048)
+	require.NoError(t, err)
+
+	csrPEM := generateCSR(t, privateKey, "common-name", []string{"foo.example.com"})
+	certPEM := selfSignedCertificatePEM(t, privateKey, csrPEM)
+	otherCertPEM := selfSignedCertificatePEM(t, otherKey, generateCSR(t, otherKey, "common-name", []string{"foo.example.com"}))
+
+	issuedAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	found := &certificate.CertificateInfo{
+		Thumbprint: "test-thumbprint",
+		ValidFrom:  issuedAfter.Add(time.Hour),
+	}
+
+	tests := map[string]struct {
+		info       *certificate.CertificateInfo
+		searchErr  error
+		certPEM    string
+		retrieved  bool
+		wantReused bool
+		wantErr    bool
+	}{
+		"a matching certificate issued within the max age is reused": {
+			info:       found,
+			certPEM:    certPEM,
+			retrieved:  true,
+			wantReused: true,
+		},
+		"no certificate is reused if none matches the request": {
+			searchErr: verror.NoCertificateFoundError,
+		},
+		"no certificate is reused if none matches the zone": {
+			searchErr: verror.NoCertificateWithMatchingZoneFoundError,
+		},
+		"a certificate issued before the max age is not reused": {
+			info: &certificate.CertificateInfo{
+				Thumbprint: "test-thumbprint",
+				ValidFrom:  issuedAfter.Add(-time.Hour),
+			},
+		},
+		"a certificate issued for another key is not reused": {
+			info:      found,
+			certPEM:   otherCertPEM,
+			retrieved: true,
+		},
+		"other errors of the search are returned": {
+			searchErr: errors.New("search error"),
+			wantErr:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			retrieved := false
+			v := &Venafi{
+				config: &vcert.Config{Zone: "test-zone"},
+				vcertClient: internalfake.Connector{
+					SearchCertificateFunc: func(zone string, cn string, sans *certificate.Sans, _ time.Duration) (*certificate.CertificateInfo, error) {
+						assert.Equal(t, "test-zone", zone)
+						assert.Equal(t, "common-name", cn)
+						assert.Equal(t, []string{"foo.example.com"}, sans.DNS)
+						return test.info, test.searchErr
+					},
+					RetrieveCertificateFunc: func(req *certificate.Request) (*certificate.PEMCollection, error) {
+						retrieved = true
+						assert.Equal(t, "test-thumbprint", req.Thumbprint)
+						req.PickupID = `\VED\Policy\test-zone\common-name`
+						return &certificate.PEMCollection{Certificate: test.certPEM, Chain: []string{"chain"}}, nil
+					},
+				}.Default(),
+			}
+
+			pickupID, chain, err := v.FindReusableCertificate(csrPEM, issuedAfter)
+			assert.Equal(t, test.retrieved, retrieved)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			if !test.wantReused {
+				assert.Empty(t, pickupID)
+				assert.Empty(t, chain)
+				return
+			}
+			assert.Equal(t, `\VED\Policy\test-zone\common-name`, pickupID)
+			assert.Equal(t, certPEM+"\nchain", string(chain))
+		})
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// URISANPolicyViolationError is returned when a URI SAN of a certificate
+// request is not allowed by the policy of the Venafi zone.
+type URISANPolicyViolationError struct {
+	// URI is the requested URI SAN which is not allowed.
+	URI string
+	// Allowed lists the regular expressions of the URI SANs allowed by the
+	// zone. An empty list means that the zone does not allow URI SANs.
+	Allowed []string
+}
+
+func (err URISANPolicyViolationError) Error() string {
+	if len(err.Allowed) == 0 {
+		return fmt.Sprintf("the Venafi zone does not allow URI SANs, but %q was requested", err.URI)
+	}
+	return fmt.Sprintf("the Venafi zone does not allow the URI SAN %q, allowed URI SANs must match one of: %s", err.URI, strings.Join(err.Allowed, "; "))
+}
+
+// validateURISANPolicy checks the URI SANs of a certificate request against
+// the URI SAN regular expressions of a Venafi zone. vcert only checks URI SANs
+// when the request already contains the CSR, which is not the case when the
+// request is validated locally, so the check is done here instead. Each URI
+// SAN must match at least one of the regular expressions, as is done by vcert.
+func validateURISANPolicy(uris []*url.URL, allowed []string) error {
+	for _, uri := range uris {
+		if !matchesAnyRegexp(uri.String(), allowed) {
+			return URISANPolicyViolationError{URI: uri.String(), Allowed: allowed}
+		}
+	}
+
+	return nil
+}
+
+// WildcardPolicyViolationError is returned when a certificate request contains
+// a wildcard name, but the policy of the Venafi zone does not allow
+// wildcards.
+type WildcardPolicyViolationError struct {
+	// Name is the requested wildcard name, as found in the request.
+	Name string
+}
+
+func (err WildcardPolicyViolationError) Error() string {
+	// Internationalized names are also shown in their ASCII form, which is
+	// the form in which the Venafi platform reports them.
+	if ascii, convErr := idna.ToASCII(strings.TrimPrefix(err.Name, "*.")); convErr == nil && "*."+ascii != err.Name {
+		return fmt.Sprintf("the Venafi zone does not allow wildcard names, but %q (%q) was requested", err.Name, "*."+ascii)
+	}
+	return fmt.Sprintf("the Venafi zone does not allow wildcard names, but %q was requested", err.Name)
+}
+
+// validateWildcardPolicy checks the common name and DNS SANs of a certificate
+// request against the wildcard policy of a Venafi zone. The Venafi platform
+// rejects wildcard names without saying why when the zone does not allow
+// them, and vcert does not check the policy locally, so the check is done
+// here instead.
+func validateWildcardPolicy(commonName string, dnsNames []string, allowWildcards bool) error {
+	if allowWildcards {
+		return nil
+	}
+
+	for _, name := range append([]string{commonName}, dnsNames...) {
+		if isWildcard(name) {
+			return WildcardPolicyViolationError{Name: name}
+		}
+	}
+
+	return nil
+}
+
+// isWildcard returns true if the given name is a wildcard DNS name, that is
+// a name whose left-most label is `*`.
+func isWildcard(name string) bool {
+	return strings.HasPrefix(strings.TrimSpace(name), "*.")
+}
+
+// matchesAnyRegexp returns true if s matches at least one of the given regular
+// expressions. Invalid regular expressions never match.
+func matchesAnyRegexp(s string, regexes []string) bool {
+	for _, r := range regexes {
+		if matched, err := regexp.MatchString(r, s); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
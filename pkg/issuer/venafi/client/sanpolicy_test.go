@@ -0,0 +1,214 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/Venafi/vcert/v5/pkg/certificate"
+	"github.com/Venafi/vcert/v5/pkg/endpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalfake "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestValidateURISANPolicy(t *testing.T) {
+	spiffeURI := &url.URL{Scheme: "spiffe", Host: "example.org", Path: "/ns/default/sa/app"}
+
+	tests := map[string]struct {
+		uris    []*url.URL
+		allowed []string
+		wantErr string
+	}{
+		"no URI SANs are always allowed": {
+			uris:    nil,
+			allowed: nil,
+		},
+		"URI SANs are not allowed when the zone has no URI SAN policy": {
+			uris:    []*url.URL{spiffeURI},
+			allowed: nil,
+			wantErr: `the Venafi zone does not allow URI SANs, but "spiffe://example.org/ns/default/sa/app" was requested`,
+		},
+		"any URI SAN is allowed by a match-all policy": {
+			uris:    []*url.URL{spiffeURI},
+			allowed: []string{".*"},
+		},
+		"SPIFFE URI SAN matching the trust domain is allowed": {
+			uris:    []*url.URL{spiffeURI},
+			allowed: []string{`^https://.*$`, `^spiffe://example\.org/.*$`},
+		},
+		"SPIFFE URI SAN of another trust domain is not allowed": {
+			uris:    []*url.URL{{Scheme: "spiffe", Host: "example.com", Path: "/app"}},
+			allowed: []string{`^spiffe://example\.org/.*$`},
+			wantErr: `the Venafi zone does not allow the URI SAN "spiffe://example.com/app", allowed URI SANs must match one of: ^spiffe://example\.org/.*$`,
+		},
+		"invalid regular expressions never match": {
+			uris:    []*url.URL{spiffeURI},
+			allowed: []string{"("},
+			wantErr: `the Venafi zone does not allow the URI SAN "spiffe://example.org/ns/default/sa/app", allowed URI SANs must match one of: (`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateURISANPolicy(test.uris, test.allowed)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, test.wantErr)
+			var policyErr URISANPolicyViolationError
+			assert.True(t, errors.As(err, &policyErr))
+		})
+	}
+}
+
+func TestValidateWildcardPolicy(t *testing.T) {
+	tests := map[string]struct {
+		commonName     string
+		dnsNames       []string
+		allowWildcards bool
+		wantErr        string
+	}{
+		"names without wildcards are always allowed": {
+			commonName: "example.com",
+			dnsNames:   []string{"example.com", "www.example.com"},
+		},
+		"wildcard names are allowed when the zone allows wildcards": {
+			commonName:     "*.example.com",
+			dnsNames:       []string{"*.example.com"},
+			allowWildcards: true,
+		},
+		"wildcard common name is not allowed": {
+			commonName: "*.example.com",
+			wantErr:    `the Venafi zone does not allow wildcard names, but "*.example.com" was requested`,
+		},
+		"wildcard DNS name is not allowed": {
+			commonName: "example.com",
+			dnsNames:   []string{"example.com", "*.example.com"},
+			wantErr:    `the Venafi zone does not allow wildcard names, but "*.example.com" was requested`,
+		},
+		"asterisk which is not the left-most label is not a wildcard": {
+			dnsNames: []string{"foo*.example.com", "foo.*.example.com"},
+		},
+		"internationalized wildcard name is not allowed": {
+			commonName: "*.bücher.example",
+			wantErr:    `the Venafi zone does not allow wildcard names, but "*.bücher.example" ("*.xn--bcher-kva.example") was requested`,
+		},
+		"punycode wildcard name is not allowed": {
+			dnsNames: []string{"*.xn--bcher-kva.example"},
+			wantErr:  `the Venafi zone does not allow wildcard names, but "*.xn--bcher-kva.example" was requested`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateWildcardPolicy(test.commonName, test.dnsNames, test.allowWildcards)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, test.wantErr)
+			var policyErr WildcardPolicyViolationError
+			assert.True(t, errors.As(err, &policyErr))
+		})
+	}
+}
+
+func TestVenafi_RequestCertificateWildcardPolicyViolation(t *testing.T) {
+	privateKey, err := pki.GenerateRSAPrivateKey(2048)
+	require.NoError(t, err)
+	csrPEM, err := gen.CSRWithSigner(privateKey,
+		gen.SetCSRCommonName("example.com"),
+		gen.SetCSRDNSNames("example.com", "*.example.com"),
+	)
+	require.NoError(t, err)
+
+	v := &Venafi{
+		vcertClient: internalfake.Connector{
+			ReadZoneConfigurationFunc: func() (*endpoint.ZoneConfiguration, error) {
+				return &endpoint.ZoneConfiguration{}, nil
+			},
+			RequestCertificateFunc: func(*certificate.Request) (string, error) {
+				return "", errors.New("certificate should not be requested")
+			},
+		}.Default(),
+	}
+
+	_, err = v.RequestCertificate(csrPEM, 0, "", nil, 0, nil)
+	var policyErr WildcardPolicyViolationError
+	require.True(t, errors.As(err, &policyErr))
+	assert.Equal(t, "*.example.com", policyErr.Name)
+}
+
+func TestVenafi_RequestCertificateURISANPolicyViolation(t *testing.T) {
+	privateKey, err := pki.GenerateRSAPrivateKey(2048)
+	require.NoError(t, err)
+	csrPEM, err := gen.CSRWithSigner(privateKey,
+		gen.SetCSRCommonName("common-name"),
+		gen.SetCSRURIsFromStrings("spiffe://example.org/ns/default/sa/app"),
+	)
+	require.NoError(t, err)
+
+	v := &Venafi{
+		vcertClient: internalfake.Connector{
+			ReadZoneConfigurationFunc: func() (*endpoint.ZoneConfiguration, error) {
+				return &endpoint.ZoneConfiguration{}, nil
+			},
+			RequestCertificateFunc: func(*certificate.Request) (string, error) {
+				return "", errors.New("certificate should not be requested")
+			},
+		}.Default(),
+	}
+
+	_, err = v.RequestCertificate(csrPEM, 0, "", nil, 0, nil)
+	var policyErr URISANPolicyViolationError
+	require.True(t, errors.As(err, &policyErr))
+	assert.Equal(t, "spiffe://example.org/ns/default/sa/app", policyErr.URI)
+}
+
+func TestVenafi_IssueCertificateWithSPIFFEURISAN(t *testing.T) {
+	privateKey, err := pki.GenerateRSAPrivateKey(2048)
+	require.NoError(t, err)
+	csrPEM, err := gen.CSRWithSigner(privateKey,
+		gen.SetCSRCommonName("common-name"),
+		gen.SetCSRURIsFromStrings("spiffe://example.org/ns/default/sa/app"),
+	)
+	require.NoError(t, err)
+
+	v := &Venafi{
+		vcertClient: internalfake.Connector{}.Default(),
+	}
+
+	pickupID, err := v.RequestCertificate(csrPEM, 0, "", nil, 0, nil)
+	require.NoError(t, err)
+
+	certPEM, err := v.RetrieveCertificate(pickupID, csrPEM, nil)
+	require.NoError(t, err)
+
+	crt, err := pki.DecodeX509CertificateBytes(certPEM)
+	require.NoError(t, err)
+	require.Len(t, crt.URIs, 1)
+	assert.Equal(t, "spiffe://example.org/ns/default/sa/app", crt.URIs[0].String())
+}
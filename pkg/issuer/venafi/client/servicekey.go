@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	vcertutil "github.com/Venafi/vcert/v5/pkg/util"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// keyPasswordPrefix guarantees that the generated key passwords contain the
+// upper case, lower case, digit and symbol characters required by the
+// default password policy of TPP. The strength of the passwords comes from
+// the random characters which follow it.
+const keyPasswordPrefix = "Cm1!"
+
+// newKeyPassword returns a random password with which TPP encrypts the
+// private key it returns.
+func newKeyPassword() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate a password for the private key: %w", err)
+	}
+	return keyPasswordPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decryptPrivateKey decrypts the PEM encoded private key returned by TPP with
+// the password it was retrieved with, and returns it PEM encoded in PKCS#8
+// format. TPP returns RSA keys in encrypted PKCS#8 format, and other keys
+// in the legacy encrypted PEM format.
+func decryptPrivateKey(keyPEM string, password string) ([]byte, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, errors.New("failed to decode the private key returned by the Venafi platform")
+	}
+
+	var keyBytes []byte
+	switch {
+	case block.Type == "ENCRYPTED PRIVATE KEY":
+		decrypted, err := vcertutil.DecryptPkcs8PrivateKey(keyPEM, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt the private key returned by the Venafi platform: %w", err)
+		}
+		// The decrypted key is encoded in PKCS#8 format whatever the type
+		// of its PEM block.
+		decryptedBlock, _ := pem.Decode([]byte(decrypted))
+		if decryptedBlock == nil {
+			return nil, errors.New("failed to decode the decrypted private key")
+		}
+		keyBytes = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: decryptedBlock.Bytes})
+
+	case block.Headers["DEK-Info"] != "":
+		decrypted, err := vcertutil.X509DecryptPEMBlock(block, []byte(password))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt the private key returned by the Venafi platform: %w", err)
+		}
+		keyBytes = pem.EncodeToMemory(&pem.Block{Type: block.Type, Bytes: decrypted})
+
+	default:
+		// Private keys are always requested encrypted, so an unencrypted
+		// key is not expected but accepted.
+		keyBytes = []byte(keyPEM)
+	}
+
+	key, err := pki.DecodePrivateKeyBytes(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the private key returned by the Venafi platform: %w", err)
+	}
+	return pki.EncodePKCS8PrivateKey(key)
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/Venafi/vcert/v5/pkg/certificate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func TestNewKeyPassword(t *testing.T) {
+	a, err := newKeyPassword()
+	require.NoError(t, err)
+	b, err := newKeyPassword()
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(a, keyPasswordPrefix))
+	assert.Greater(t, len(a), len(keyPasswordPrefix)+24)
+	assert.NotEqual(t, a, b)
+}
+
+func TestDecryptPrivateKey(t *testing.T) {
+	rsaKey, err := pki.GenerateRSAPrivateKey(2048)
+	require.NoError(t, err)
+	ecKey, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	encrypt := func(key crypto.Signer, password, format string) string {
+		block, err := certificate.GetEncryptedPrivateKeyPEMBock(key, []byte(password), format)
+		require.NoError(t, err)
+		return string(pem.EncodeToMemory(block))
+	}
+
+	tests := map[string]struct {
+		key      crypto.Signer
+		keyPEM   string
+		password string
+		wantErr  bool
+	}{
+		"RSA key encrypted in PKCS#8 format": {
+			key:      rsaKey,
+			keyPEM:   encrypt(rsaKey, "password", ""),
+			password: "password",
+		},
+		"EC key encrypted in the legacy PEM format": {
+			key:      ecKey,
+			keyPEM:   encrypt(ecKey, "password", "legacy-pem"),
+			password: "password",
+		},
+		"unencrypted key": {
+			key:    ecKey,
+			keyPEM: string(mustEncodePKCS8(t, ecKey)),
+		},
+		"wrong password": {
+			keyPEM:   encrypt(rsaKey, "password", ""),
+			password: "other-password",
+			wantErr:  true,
+		},
+		"not a key": {
+			keyPEM:  "not a key",
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			keyPEM, err := decryptPrivateKey(test.keyPEM, test.password)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			block, _ := pem.Decode(keyPEM)
+			require.NotNil(t, block)
+			assert.Equal(t, "PRIVATE KEY", block.Type)
+
+			key, err := pki.DecodePrivateKeyBytes(keyPEM)
+			require.NoError(t, err)
+			equal, err := pki.PublicKeysEqual(key.Public(), test.key.Public())
+			require.NoError(t, err)
+			assert.True(t, equal)
+		})
+	}
+}
+
+func mustEncodePKCS8(t *testing.T, key crypto.Signer) []byte {
+	keyPEM, err := pki.EncodePKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return keyPEM
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/Venafi/vcert/v5/pkg/certificate"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// SignatureHashPolicyViolationError is returned when a certificate cannot be
+// requested with the requested signature hash algorithm, because the key of
+// the request does not support choosing it, or the Venafi zone signs
+// certificates with another hash algorithm.
+type SignatureHashPolicyViolationError struct {
+	// Requested is the requested hash algorithm.
+	Requested crypto.Hash
+	// Zone is the hash algorithm the Venafi zone signs certificates with,
+	// or zero if the key of the request does not support the requested hash
+	// algorithm.
+	Zone crypto.Hash
+	// Err is the reason the key of the request does not support the
+	// requested hash algorithm, if any.
+	Err error
+}
+
+func (err SignatureHashPolicyViolationError) Error() string {
+	if err.Err != nil {
+		return err.Err.Error()
+	}
+	return fmt.Sprintf("the Venafi zone signs certificates with the %s hash algorithm, but %s was requested", err.Zone, err.Requested)
+}
+
+// applySignatureHash sets the signature algorithm of the request to the one
+// which signs with the requested hash algorithm using the given public key,
+// and checks it against the signature algorithm of the Venafi zone, unless it
+// is unknown.
+func applySignatureHash(vreq *certificate.Request, publicKey crypto.PublicKey, requested crypto.Hash, zoneAlgorithm x509.SignatureAlgorithm) error {
+	if requested == 0 {
+		return nil
+	}
+
+	algorithm, err := pki.SignatureAlgorithmWithHash(publicKey, requested)
+	if err != nil {
+		return SignatureHashPolicyViolationError{Requested: requested, Err: err}
+	}
+
+	if zone := pki.SignatureAlgorithmHash(zoneAlgorithm); zone != 0 && zone != requested {
+		return SignatureHashPolicyViolationError{Requested: requested, Zone: zone}
+	}
+
+	vreq.SignatureAlgorithm = algorithm
+	return nil
+}
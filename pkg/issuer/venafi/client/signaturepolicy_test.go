@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	"github.com/Venafi/vcert/v5/pkg/certificate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func TestApplySignatureHash(t *testing.T) {
+	mustKey := func(key crypto.Signer, err error) crypto.PublicKey {
+		require.NoError(t, err)
+		return key.Public()
+	}
+
+	rsa2048 := mustKey(pki.GenerateRSAPrivateKey(2048))
+	p256 := mustKey(pki.GenerateECPrivateKey(256))
+	ed25519 := mustKey(pki.GenerateEd25519PrivateKey())
+
+	tests := map[string]struct {
+		publicKey     crypto.PublicKey
+		requested     crypto.Hash
+		zoneAlgorithm x509.SignatureAlgorithm
+
+		expAlgorithm x509.SignatureAlgorithm
+		expErr       string
+	}{
+		"no requested hash algorithm leaves the request unchanged": {
+			publicKey:     rsa2048,
+			zoneAlgorithm: x509.SHA256WithRSA,
+		},
+		"requested hash algorithm of an unknown zone algorithm": {
+			publicKey:    p256,
+			requested:    crypto.SHA384,
+			expAlgorithm: x509.ECDSAWithSHA384,
+		},
+		"requested hash algorithm matching the zone": {
+			publicKey:     rsa2048,
+			requested:     crypto.SHA512,
+			zoneAlgorithm: x509.SHA512WithRSA,
+			expAlgorithm:  x509.SHA512WithRSA,
+		},
+		"requested hash algorithm not matching the zone": {
+			publicKey:     rsa2048,
+			requested:     crypto.SHA384,
+			zoneAlgorithm: x509.SHA256WithRSA,
+			expErr:        "the Venafi zone signs certificates with the SHA-256 hash algorithm, but SHA-384 was requested",
+		},
+		"Ed25519 keys cannot choose the hash algorithm": {
+			publicKey: ed25519,
+			requested: crypto.SHA384,
+			expErr:    "Ed25519 keys cannot sign with the SHA-384 hash algorithm",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			vreq := &certificate.Request{}
+
+			err := applySignatureHash(vreq, test.publicKey, test.requested, test.zoneAlgorithm)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+				assert.True(t, errors.As(err, new(SignatureHashPolicyViolationError)))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expAlgorithm, vreq.SignatureAlgorithm)
+		})
+	}
+}
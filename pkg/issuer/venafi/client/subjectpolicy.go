@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/x509/pkix"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Venafi/vcert/v5/pkg/endpoint"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// SubjectDefaultsPolicyViolationError is returned when a subject default of
+// the issuer, applied to a certificate request which does not set the field
+// in its CSR, is not allowed by the policy of the Venafi zone.
+type SubjectDefaultsPolicyViolationError struct {
+	// Field is the name of the subject field, for example "organization".
+	Field string
+	// Value is the default value of the field which is not allowed.
+	Value string
+	// Allowed lists the regular expressions of the values allowed by the
+	// zone. An empty list means that the zone does not allow the field.
+	Allowed []string
+}
+
+func (err SubjectDefaultsPolicyViolationError) Error() string {
+	if len(err.Allowed) == 0 {
+		return fmt.Sprintf("the issuer defaults the %s to %q, but the Venafi zone does not allow it to be set", err.Field, err.Value)
+	}
+	return fmt.Sprintf("the issuer defaults the %s to %q, which is not allowed by the Venafi zone, allowed values must match one of: %s", err.Field, err.Value, strings.Join(err.Allowed, "; "))
+}
+
+// applySubjectDefaults sets the subject fields which are not set by the CSR
+// of a certificate request to the subject defaults of the issuer, and checks
+// the applied defaults against the subject policy of the Venafi zone. The
+// fields set by the CSR are left as is, and are checked by vcert along with
+// the rest of the request.
+func applySubjectDefaults(subject *pkix.Name, defaults *cmapi.VenafiSubjectDefaults, policy *endpoint.Policy) error {
+	if defaults == nil {
+		return nil
+	}
+
+	fields := []struct {
+		name     string
+		value    *[]string
+		defaults []string
+		allowed  []string
+	}{
+		{"organization", &subject.Organization, defaults.Organizations, policy.SubjectORegexes},
+		{"organizational unit", &subject.OrganizationalUnit, defaults.OrganizationalUnits, policy.SubjectOURegexes},
+		{"locality", &subject.Locality, defaults.Localities, policy.SubjectLRegexes},
+		{"province", &subject.Province, defaults.Provinces, policy.SubjectSTRegexes},
+		{"country", &subject.Country, defaults.Countries, policy.SubjectCRegexes},
+	}
+
+	for _, f := range fields {
+		if len(*f.value) > 0 || len(f.defaults) == 0 {
+			continue
+		}
+
+		for _, value := range f.defaults {
+			if !matchesAnyRegexp(value, f.allowed) {
+				return SubjectDefaultsPolicyViolationError{Field: f.name, Value: value, Allowed: f.allowed}
+			}
+		}
+		*f.value = slices.Clone(f.defaults)
+	}
+
+	return nil
+}
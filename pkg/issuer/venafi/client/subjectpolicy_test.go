@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"testing"
+
+	"github.com/Venafi/vcert/v5/pkg/certificate"
+	"github.com/Venafi/vcert/v5/pkg/endpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	internalfake "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestApplySubjectDefaults(t *testing.T) {
+	allowAll := endpoint.Policy{
+		SubjectORegexes:  []string{".*"},
+		SubjectOURegexes: []string{".*"},
+		SubjectLRegexes:  []string{".*"},
+		SubjectSTRegexes: []string{".*"},
+		SubjectCRegexes:  []string{".*"},
+	}
+	defaults := &cmapi.VenafiSubjectDefaults{
+		Organizations:       []string{"Example Inc."},
+		OrganizationalUnits: []string{"Engineering"},
+		Localities:          []string{"Salt Lake City"},
+		Provinces:           []string{"Utah"},
+		Countries:           []string{"US"},
+	}
+
+	tests := map[string]struct {
+		subject  pkix.Name
+		defaults *cmapi.VenafiSubjectDefaults
+		policy   endpoint.Policy
+
+		expectedSubject pkix.Name
+		expectedErr     error
+	}{
+		"the subject is not changed if the issuer has no defaults": {
+			subject:         pkix.Name{CommonName: "example.com"},
+			policy:          allowAll,
+			expectedSubject: pkix.Name{CommonName: "example.com"},
+		},
+		"the fields not set by the CSR are defaulted": {
+			subject:  pkix.Name{CommonName: "example.com"},
+			defaults: defaults,
+			policy:   allowAll,
+			expectedSubject: pkix.Name{
+				CommonName:         "example.com",
+				Organization:       []string{"Example Inc."},
+				OrganizationalUnit: []string{"Engineering"},
+				Locality:           []string{"Salt Lake City"},
+				Province:           []string{"Utah"},
+				Country:            []string{"US"},
+			},
+		},
+		"the fields set by the CSR are not changed": {
+			subject:  pkix.Name{CommonName: "example.com", Organization: []string{"Other Inc."}, Country: []string{"GB"}},
+			defaults: defaults,
+			policy:   allowAll,
+			expectedSubject: pkix.Name{
+				CommonName:         "example.com",
+				Organization:       []string{"Other Inc."},
+				OrganizationalUnit: []string{"Engineering"},
+				Locality:           []string{"Salt Lake City"},
+				Province:           []string{"Utah"},
+				Country:            []string{"GB"},
+			},
+		},
+		"the fields set by the CSR are not checked": {
+			subject:         pkix.Name{CommonName: "example.com", Organization: []string{"Other Inc."}},
+			defaults:        &cmapi.VenafiSubjectDefaults{Organizations: []string{"Example Inc."}},
+			policy:          endpoint.Policy{SubjectORegexes: []string{"^Example Inc\\.$"}},
+			expectedSubject: pkix.Name{CommonName: "example.com", Organization: []string{"Other Inc."}},
+		},
+		"defaults which are not allowed by the zone are rejected": {
+			subject:  pkix.Name{CommonName: "example.com"},
+			defaults: defaults,
+			policy: endpoint.Policy{
+				SubjectORegexes:  []string{".*"},
+				SubjectOURegexes: []string{"^Operations$", "^Security$"},
+			},
+			expectedErr: SubjectDefaultsPolicyViolationError{
+				Field:   "organizational unit",
+				Value:   "Engineering",
+				Allowed: []string{"^Operations$", "^Security$"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			subject := test.subject
+			err := applySubjectDefaults(&subject, test.defaults, &test.policy)
+			assert.Equal(t, test.expectedErr, err)
+			if test.expectedErr == nil {
+				assert.Equal(t, test.expectedSubject, subject)
+			}
+		})
+	}
+}
+
+func TestVenafi_RequestCertificateSubjectDefaults(t *testing.T) {
+	privateKey, err := pki.GenerateRSAPrivateKey(2048)
+	require.NoError(t, err)
+	csrPEM, err := gen.CSRWithSigner(privateKey, gen.SetCSRCommonName("example.com"))
+	require.NoError(t, err)
+
+	zoneConfig := &endpoint.ZoneConfiguration{
+		Organization: "Zone Inc.",
+		Country:      "GB",
+		Policy: endpoint.Policy{
+			SubjectCNRegexes: []string{".*"},
+			SubjectORegexes:  []string{"^Example Inc\\.$", "^Zone Inc\\.$"},
+			SubjectOURegexes: []string{".*"},
+			SubjectLRegexes:  []string{".*"},
+			SubjectSTRegexes: []string{".*"},
+			SubjectCRegexes:  []string{"^GB$"},
+		},
+	}
+
+	t.Run("the subject defaults take precedence over the zone defaults", func(t *testing.T) {
+		var requested *certificate.Request
+		v := &Venafi{
+			vcertClient: internalfake.Connector{
+				ReadZoneConfigurationFunc: func() (*endpoint.ZoneConfiguration, error) {
+					return zoneConfig, nil
+				},
+				RequestCertificateFunc: func(req *certificate.Request) (string, error) {
+					requested = req
+					return "test-pickup-id", nil
+				},
+			}.Default(),
+			subjectDefaults: &cmapi.VenafiSubjectDefaults{Organizations: []string{"Example Inc."}},
+		}
+
+		pickupID, err := v.RequestCertificate(csrPEM, 0, "", nil, 0, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "test-pickup-id", pickupID)
+		require.NotNil(t, requested)
+		assert.Equal(t, []string{"Example Inc."}, requested.Subject.Organization)
+		assert.Equal(t, []string{"GB"}, requested.Subject.Country)
+	})
+
+	t.Run("subject defaults which are not allowed by the zone are rejected", func(t *testing.T) {
+		v := &Venafi{
+			vcertClient: internalfake.Connector{
+				ReadZoneConfigurationFunc: func() (*endpoint.ZoneConfiguration, error) {
+					return zoneConfig, nil
+				},
+				RequestCertificateFunc: func(*certificate.Request) (string, error) {
+					return "", errors.New("certificate should not be requested")
+				},
+			}.Default(),
+			subjectDefaults: &cmapi.VenafiSubjectDefaults{Countries: []string{"US"}},
+		}
+
+		_, err := v.RequestCertificate(csrPEM, 0, "", nil, 0, nil)
+		var policyErr SubjectDefaultsPolicyViolationError
+		require.True(t, errors.As(err, &policyErr))
+		assert.EqualError(t, err, `the issuer defaults the country to "US", which is not allowed by the Venafi zone, allowed values must match one of: ^GB$`)
+	})
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package test contains a scriptable Venafi client, which can be used to
+// test the behaviour of controllers on a given sequence of responses of the
+// Venafi platform.
+package test
+
+import (
+	"crypto"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Venafi/vcert/v5/pkg/endpoint"
+	"github.com/Venafi/vcert/v5/pkg/verror"
+	"github.com/go-logr/logr"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+// Step is the scripted response to a single call to the Venafi platform.
+type Step struct {
+	// PickupID is returned by calls to RequestCertificate.
+	PickupID string
+
+	// Certificate is the PEM encoded certificate chain returned by calls to
+	// RetrieveCertificate.
+	Certificate []byte
+
+	// Err is returned by the call, if set.
+	Err error
+}
+
+// Requested returns a Step for a request of a certificate which was accepted
+// with the given pickup ID.
+func Requested(pickupID string) Step {
+	return Step{PickupID: pickupID}
+}
+
+// Issued returns a Step for the retrieval of the given issued certificate.
+func Issued(certPEM []byte) Step {
+	return Step{Certificate: certPEM}
+}
+
+// Pending returns a Step for a call made while the certificate is still
+// pending issuance.
+func Pending() Step {
+	return Step{Err: endpoint.ErrCertificatePending{CertificateID: "test-cert-id", Status: "test-status-pending"}}
+}
+
+// TimedOut returns a Step for a call which timed out waiting for the
+// certificate to be issued.
+func TimedOut() Step {
+	return Step{Err: endpoint.ErrRetrieveCertificateTimeout{CertificateID: "test-cert-id"}}
+}
+
+// Unauthorized returns a Step for a call which was rejected because of the
+// credentials of the issuer.
+func Unauthorized() Step {
+	return Step{Err: fmt.Errorf("%w: the credentials were rejected", verror.UnauthorizedError)}
+}
+
+// Failed returns a Step for a call which failed with the given error.
+func Failed(err error) Step {
+	return Step{Err: err}
+}
+
+// Script is a scripted Venafi client. Each call to RequestCertificate or
+// RetrieveCertificate returns the next Step of the corresponding list, and
+// the last Step is repeated once the list is exhausted. Calls to a method
+// without any Steps fail. Scripts are safe for concurrent use.
+type Script struct {
+	// RequestCertificate are the Steps returned by calls to RequestCertificate.
+	RequestCertificate []Step
+
+	// RetrieveCertificate are the Steps returned by calls to
+	// RetrieveCertificate.
+	RetrieveCertificate []Step
+
+	lock          sync.Mutex
+	requestCalls  int
+	retrieveCalls int
+}
+
+// NewIssuingScript returns a Script for a certificate which is requested with
+// the given pickup ID, and then retrieved after the given Steps.
+func NewIssuingScript(pickupID string, certPEM []byte, before ...Step) *Script {
+	return &Script{
+		RequestCertificate:  []Step{Requested(pickupID)},
+		RetrieveCertificate: append(append([]Step{}, before...), Issued(certPEM)),
+	}
+}
+
+// NewPendingScript returns a Script for a certificate which is requested with
+// the given pickup ID, and then never leaves the pending state.
+func NewPendingScript(pickupID string) *Script {
+	return &Script{
+		RequestCertificate:  []Step{Requested(pickupID)},
+		RetrieveCertificate: []Step{Pending()},
+	}
+}
+
+// NewUnauthorizedScript returns a Script for an issuer whose credentials are
+// rejected by the Venafi platform.
+func NewUnauthorizedScript() *Script {
+	return &Script{
+		RequestCertificate:  []Step{Unauthorized()},
+		RetrieveCertificate: []Step{Unauthorized()},
+	}
+}
+
+// RequestCalls returns the number of calls made to RequestCertificate.
+func (s *Script) RequestCalls() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.requestCalls
+}
+
+// RetrieveCalls returns the number of calls made to RetrieveCertificate.
+func (s *Script) RetrieveCalls() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.retrieveCalls
+}
+
+// Client returns a Venafi client which responds following the Script.
+func (s *Script) Client() client.Interface {
+	return &fake.Venafi{
+		RequestCertificateFn: func([]byte, time.Duration, string, *api.Location, crypto.Hash, []api.CustomField) (string, error) {
+			step, err := s.next("RequestCertificate", s.RequestCertificate, &s.requestCalls)
+			if err != nil {
+				return "", err
+			}
+			return step.PickupID, step.Err
+		},
+		RetrieveCertificateFn: func(string, []byte, []api.CustomField) ([]byte, error) {
+			step, err := s.next("RetrieveCertificate", s.RetrieveCertificate, &s.retrieveCalls)
+			if err != nil {
+				return nil, err
+			}
+			return step.Certificate, step.Err
+		},
+	}
+}
+
+// ClientBuilder returns a VenafiClientBuilder which builds clients sharing
+// the Script, so that the Steps are followed across syncs.
+func (s *Script) ClientBuilder() client.VenafiClientBuilder {
+	return func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+		return s.Client(), nil
+	}
+}
+
+func (s *Script) next(method string, steps []Step, calls *int) (Step, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	*calls++
+	if len(steps) == 0 {
+		return Step{}, fmt.Errorf("unexpected call to %s: no steps scripted", method)
+	}
+
+	return steps[min(*calls, len(steps))-1], nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"testing"
+
+	"github.com/Venafi/vcert/v5/pkg/endpoint"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
+)
+
+func TestScript(t *testing.T) {
+	script := NewIssuingScript("test-pickup-id", []byte("cert"), Pending(), TimedOut())
+
+	c, err := script.ClientBuilder()("", nil, nil, nil, logr.Discard(), "")
+	require.NoError(t, err)
+
+	pickupID, err := c.RequestCertificate(nil, 0, "", nil, 0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "test-pickup-id", pickupID)
+
+	_, err = c.RetrieveCertificate(pickupID, nil, nil)
+	assert.IsType(t, endpoint.ErrCertificatePending{}, err)
+
+	// The Steps are shared by all the clients built from the Script.
+	c, err = script.ClientBuilder()("", nil, nil, nil, logr.Discard(), "")
+	require.NoError(t, err)
+
+	_, err = c.RetrieveCertificate(pickupID, nil, nil)
+	assert.IsType(t, endpoint.ErrRetrieveCertificateTimeout{}, err)
+
+	// The last Step is repeated once the Steps are exhausted.
+	for i := 0; i < 2; i++ {
+		cert, err := c.RetrieveCertificate(pickupID, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("cert"), cert)
+	}
+
+	assert.Equal(t, 1, script.RequestCalls())
+	assert.Equal(t, 4, script.RetrieveCalls())
+}
+
+func TestUnauthorizedScript(t *testing.T) {
+	_, err := NewUnauthorizedScript().Client().RequestCertificate(nil, 0, "", nil, 0, nil)
+	assert.True(t, client.IsAuthenticationError(err))
+}
+
+func TestScriptWithoutSteps(t *testing.T) {
+	script := &Script{RequestCertificate: []Step{Requested("test-pickup-id")}}
+
+	_, err := script.Client().RetrieveCertificate("test-pickup-id", nil, nil)
+	assert.EqualError(t, err, "unexpected call to RetrieveCertificate: no steps scripted")
+	assert.Equal(t, 1, script.RetrieveCalls())
+}
@@ -17,11 +17,13 @@ limitations under the License.
 package client
 
 import (
+	"crypto"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"time"
 
 	vcert "github.com/Venafi/vcert/v5"
@@ -30,11 +32,10 @@ import (
 	"github.com/Venafi/vcert/v5/pkg/venafi/cloud"
 	"github.com/Venafi/vcert/v5/pkg/venafi/tpp"
 	"github.com/go-logr/logr"
+	"golang.org/x/net/http/httpproxy"
 	"k8s.io/utils/ptr"
 
-	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
-	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/util"
@@ -48,17 +49,24 @@ const (
 	defaultAPIKeyKey = "api-key"
 )
 
-type VenafiClientBuilder func(namespace string, secretsLister internalinformers.SecretLister,
+type VenafiClientBuilder func(namespace string, credentialsResolver CredentialsResolver,
 	issuer cmapi.GenericIssuer, metrics *metrics.Metrics, logger logr.Logger, userAgent string) (Interface, error)
 
 // Interface implements a Venafi client
 type Interface interface {
-	RequestCertificate(csrPEM []byte, customFields []api.CustomField) (string, error)
+	RequestCertificate(csrPEM []byte, duration time.Duration, friendlyName string, location *api.Location, signatureHash crypto.Hash, customFields []api.CustomField) (string, error)
 	RetrieveCertificate(pickupID string, csrPEM []byte, customFields []api.CustomField) ([]byte, error)
+	RetrieveCertificateAndKey(pickupID string, csrPEM []byte, customFields []api.CustomField) ([]byte, []byte, error)
+	RevokeCertificate(pickupID string) error
+	FindReusableCertificate(csrPEM []byte, issuedAfter time.Time) (string, []byte, error)
+	ValidateCertificateRequest(csrPEM []byte, customFields []api.CustomField) error
 	Ping() error
 	ReadZoneConfiguration() (*endpoint.ZoneConfiguration, error)
 	SetClient(endpoint.Connector)
 	VerifyCredentials() error
+	// CredentialsName returns the name of the object containing the
+	// credentials the client authenticated with.
+	CredentialsName() string
 }
 
 // Venafi is a implementation of vcert library to manager certificates from TPP or Venafi Cloud
@@ -66,13 +74,39 @@ type Venafi struct {
 	// Namespace in which to read resources related to this Issuer from.
 	// For Issuers, this will be the namespace of the Issuer.
 	// For ClusterIssuers, this will be the cluster resource namespace.
-	namespace     string
-	secretsLister internalinformers.SecretLister
+	namespace           string
+	credentialsResolver CredentialsResolver
 
 	vcertClient connector
 	tppClient   *tpp.Connector
 	cloudClient *cloud.Connector
 	config      *vcert.Config
+
+	// zoneCache caches the zone configuration of the issuer under
+	// zoneCacheKey. If nil, the zone configuration is read for every request.
+	zoneCache    *ZoneConfigurationCache
+	zoneCacheKey zoneCacheKey
+
+	// allowedExtensions are the OIDs of the custom extensions allowed in
+	// requests. If empty, the extensions of requests are not checked.
+	allowedExtensions []string
+
+	// subjectDefaults are the subject fields set on requests which do not
+	// set them in their CSR. If nil, requests are not defaulted.
+	subjectDefaults *cmapi.VenafiSubjectDefaults
+
+	// serviceGeneratedKeys specifies whether the private keys of
+	// certificates are generated by TPP rather than from the CSR of requests.
+	serviceGeneratedKeys bool
+
+	// credentialsName is the name of the object containing the credentials
+	// the client authenticated with.
+	credentialsName string
+
+	// logger is used to log the requests sent to the Venafi platform at
+	// trace verbosity, which is enabled by the debug logging annotation of
+	// the issuer.
+	logger logr.Logger
 }
 
 // connector exposes a subset of the vcert Connector interface to make stubbing
@@ -82,21 +116,104 @@ type connector interface {
 	ReadZoneConfiguration() (config *endpoint.ZoneConfiguration, err error)
 	RequestCertificate(req *certificate.Request) (requestID string, err error)
 	RetrieveCertificate(req *certificate.Request) (certificates *certificate.PEMCollection, err error)
+	RevokeCertificate(req *certificate.RevocationRequest) (err error)
+	SearchCertificate(zone string, cn string, sans *certificate.Sans, certMinTimeLeft time.Duration) (certificateInfo *certificate.CertificateInfo, err error)
 	// TODO: (irbekrm) this method is never used- can it be removed?
 	RenewCertificate(req *certificate.RenewalRequest) (requestID string, err error)
 }
 
-// New constructs a Venafi client Interface. Errors may be network errors and
-// should be considered for retrying.
-func New(namespace string, secretsLister internalinformers.SecretLister, issuer cmapi.GenericIssuer, metrics *metrics.Metrics, logger logr.Logger, userAgent string) (Interface, error) {
-	cfg, err := configForIssuer(issuer, secretsLister, namespace, userAgent)
+// New constructs a Venafi client Interface, configured by the given options.
+// Errors may be network errors and should be considered for retrying.
+func New(namespace string, credentialsResolver CredentialsResolver, issuer cmapi.GenericIssuer, metrics *metrics.Metrics, logger logr.Logger, userAgent string, opts ...Option) (Interface, error) {
+	var options clientOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return newClient(namespace, credentialsResolver, issuer, metrics, logger, userAgent, options)
+}
+
+// NewBuilder returns a VenafiClientBuilder which constructs clients with New,
+// configured by the given options.
+func NewBuilder(opts ...Option) VenafiClientBuilder {
+	return func(namespace string, credentialsResolver CredentialsResolver, issuer cmapi.GenericIssuer, metrics *metrics.Metrics, logger logr.Logger, userAgent string) (Interface, error) {
+		return New(namespace, credentialsResolver, issuer, metrics, logger, userAgent, opts...)
+	}
+}
+
+// Option configures the clients constructed by New.
+type Option func(*clientOptions)
+
+// WithTransport makes clients send requests using a copy of the given
+// transport, for example to route requests to the Venafi API through a proxy.
+// The CA bundle and TLS renegotiation settings required by the issuer are
+// applied on top of the transport's TLS configuration. The proxy of the
+// transport is used as is, so a transport without a proxy sends requests
+// directly. A nil transport uses the default transport, which uses the proxy
+// configured in the environment.
+func WithTransport(transport *http.Transport) Option {
+	return func(opts *clientOptions) {
+		opts.transport = transport
+	}
+}
+
+// WithZoneConfigurationCache makes clients share the given cache of zone
+// configurations, instead of reading the zone configuration from the Venafi
+// platform for every request.
+func WithZoneConfigurationCache(cache *ZoneConfigurationCache) Option {
+	return func(opts *clientOptions) {
+		opts.zoneCache = cache
+	}
+}
+
+// WithResponseLog makes clients record the raw responses to their enrollment
+// and retrieval requests to the given response log. A nil response log
+// records nothing.
+func WithResponseLog(responseLog *ResponseLog) Option {
+	return func(opts *clientOptions) {
+		opts.responseLog = responseLog
+	}
+}
+
+// clientOptions contains the optional settings of the clients constructed by
+// New.
+type clientOptions struct {
+	// transport is used as the base HTTP transport of the client, if not nil.
+	transport *http.Transport
+	// zoneCache is used to cache the zone configuration, if not nil.
+	zoneCache *ZoneConfigurationCache
+	// responseLog records the responses to enrollment and retrieval
+	// requests, if not nil.
+	responseLog *ResponseLog
+}
+
+func newClient(namespace string, credentialsResolver CredentialsResolver, issuer cmapi.GenericIssuer, metrics *metrics.Metrics, logger logr.Logger, userAgent string, opts clientOptions) (Interface, error) {
+	// The fallback credentials of the issuer are tried in turn if the
+	// Venafi platform rejects its credentials when the client authenticates.
+	client, err := withFallbackCredentials(logger, issuer, func(issuer cmapi.GenericIssuer) (*Venafi, error) {
+		return newClientWithCredentials(namespace, credentialsResolver, issuer, metrics, logger, userAgent, opts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func newClientWithCredentials(namespace string, credentialsResolver CredentialsResolver, issuer cmapi.GenericIssuer, metrics *metrics.Metrics, logger logr.Logger, userAgent string, opts clientOptions) (*Venafi, error) {
+	cfg, err := configForIssuer(issuer, credentialsResolver, namespace, userAgent, opts.transport)
 	if err != nil {
 		return nil, err
 	}
+	cfg.Client.Transport = opts.responseLog.roundTripper(cfg.Client.Transport, responseLogIssuer(issuer))
 
 	vcertClient, err := vcert.NewClient(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("error creating Venafi client: %s", err.Error())
+		if tpp := issuer.GetSpec().Venafi.TPP; tpp != nil && isClientCertificateError(err) {
+			if tpp.ClientCertificateSecretRef == nil {
+				return nil, fmt.Errorf("error creating Venafi client: the TPP server requires a client certificate, which must be set with tpp.clientCertificateSecretRef: %w", err)
+			}
+			return nil, fmt.Errorf("error creating Venafi client: the TPP server rejected the client certificate in secret %q: %w", tpp.ClientCertificateSecretRef.Name, err)
+		}
+		return nil, fmt.Errorf("error creating Venafi client: %w", err)
 	}
 
 	var tppc *tpp.Connector
@@ -117,37 +234,49 @@ func New(namespace string, secretsLister internalinformers.SecretLister, issuer
 
 	instrumentedVCertClient := newInstumentedConnector(vcertClient, metrics, logger)
 
+	// Only TPP generates the private keys of certificates.
+	tppCfg := issuer.GetSpec().Venafi.TPP
+	serviceGeneratedKeys := tppc != nil && tppCfg != nil && tppCfg.ServiceGeneratedKeys
+
 	return &Venafi{
-		namespace:     namespace,
-		secretsLister: secretsLister,
-		vcertClient:   instrumentedVCertClient,
-		cloudClient:   cc,
-		tppClient:     tppc,
-		config:        cfg,
+		namespace:            namespace,
+		credentialsResolver:  credentialsResolver,
+		vcertClient:          instrumentedVCertClient,
+		cloudClient:          cc,
+		tppClient:            tppc,
+		config:               cfg,
+		zoneCache:            opts.zoneCache,
+		zoneCacheKey:         newZoneCacheKey(issuer),
+		allowedExtensions:    issuer.GetSpec().Venafi.AllowedExtensions,
+		subjectDefaults:      issuer.GetSpec().Venafi.SubjectDefaults,
+		serviceGeneratedKeys: serviceGeneratedKeys,
+		logger:               logger,
 	}, nil
 }
 
 // configForIssuer will convert a cert-manager Venafi issuer into a vcert.Config
 // that can be used to instantiate an API client.
-func configForIssuer(iss cmapi.GenericIssuer, secretsLister internalinformers.SecretLister, namespace string, userAgent string) (*vcert.Config, error) {
+// If transport is not nil, it is used as the base HTTP transport of the client.
+func configForIssuer(iss cmapi.GenericIssuer, credentialsResolver CredentialsResolver, namespace string, userAgent string, transport *http.Transport) (*vcert.Config, error) {
 	venCfg := iss.GetSpec().Venafi
 
 	switch {
 	case venCfg.TPP != nil:
 		tpp := venCfg.TPP
-		tppSecret, err := secretsLister.Secrets(namespace).Get(tpp.CredentialsRef.Name)
+		creds, err := credentialsResolver.Credentials(namespace, iss)
 		if err != nil {
 			return nil, err
 		}
 
-		caBundle, err := caBundleForVcertTPP(tpp, secretsLister, namespace)
+		caBundle, err := credentialsResolver.TPPCABundle(namespace, tpp)
 		if err != nil {
 			return nil, err
 		}
 
-		username := string(tppSecret.Data[tppUsernameKey])
-		password := string(tppSecret.Data[tppPasswordKey])
-		accessToken := string(tppSecret.Data[tppAccessTokenKey])
+		clientCert, err := credentialsResolver.TPPClientCertificate(namespace, tpp)
+		if err != nil {
+			return nil, err
+		}
 
 		return &vcert.Config{
 			ConnectorType: endpoint.ConnectorTypeTPP,
@@ -164,29 +293,25 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister internalinformers.Se
 			// https://github.com/Venafi/vcert/blob/89645a7710a7b529765274cb60dc5e28066217a1/client.go#L55-L61
 			ConnectionTrust: string(caBundle),
 			Credentials: &endpoint.Authentication{
-				User:        username,
-				Password:    password,
-				AccessToken: accessToken,
+				User:        creds.Username,
+				Password:    creds.Password,
+				AccessToken: creds.AccessToken,
 			},
 			Client: httpClientForVcert(&httpClientForVcertOptions{
+				Transport:               transport,
 				UserAgent:               ptr.To(userAgent),
 				CABundle:                caBundle,
+				ClientCertificate:       clientCert,
 				TLSRenegotiationSupport: ptr.To(tls.RenegotiateOnceAsClient),
 			}),
 		}, nil
 	case venCfg.Cloud != nil:
 		cloud := venCfg.Cloud
-		cloudSecret, err := secretsLister.Secrets(namespace).Get(cloud.APITokenSecretRef.Name)
+		creds, err := credentialsResolver.Credentials(namespace, iss)
 		if err != nil {
 			return nil, err
 		}
 
-		k := defaultAPIKeyKey
-		if cloud.APITokenSecretRef.Key != "" {
-			k = cloud.APITokenSecretRef.Key
-		}
-		apiKey := string(cloudSecret.Data[k])
-
 		return &vcert.Config{
 			ConnectorType: endpoint.ConnectorTypeCloud,
 			BaseUrl:       cloud.URL,
@@ -194,9 +319,10 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister internalinformers.Se
 			// always enable verbose logging for now
 			LogVerbose: true,
 			Credentials: &endpoint.Authentication{
-				APIKey: apiKey,
+				APIKey: creds.APIKey,
 			},
 			Client: httpClientForVcert(&httpClientForVcertOptions{
+				Transport: transport,
 				UserAgent: ptr.To(userAgent),
 			}),
 		}, nil
@@ -209,11 +335,17 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister internalinformers.Se
 // httpClientForVcertOptions contains options for `httpClientForVcert`, to allow
 // you to customize the HTTP client.
 type httpClientForVcertOptions struct {
+	// Transport will be copied and used instead of the default transport of
+	// vcert.
+	Transport *http.Transport
 	// UserAgent will add a User-Agent header to all HTTP requests.
 	UserAgent *string
 	// CABundle will override the CA certificates used to verify server
 	// certificates.
 	CABundle []byte
+	// ClientCertificate will be presented to servers which request a client
+	// certificate, for mutual TLS authentication.
+	ClientCertificate *tls.Certificate
 	// TLSRenegotiationSupport will override the TLSRenegotiationSupport setting
 	// of the client.
 	TLSRenegotiationSupport *tls.RenegotiationSupport
@@ -274,23 +406,21 @@ func httpClientForVcert(options *httpClientForVcertOptions) *http.Client {
 	// Copy vcert's default HTTP transport, which is mostly identical to the
 	// http.DefaultTransport settings in Go's stdlib.
 	// https://github.com/Venafi/vcert/blob/89645a7710a7b529765274cb60dc5e28066217a1/pkg/venafi/tpp/tpp.go#L481-L513
-	transport := &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		DialContext: (&net.Dialer{
-			Timeout:   30 * time.Second,
-			KeepAlive: 30 * time.Second,
-			// Note: This DualStack setting is copied from vcert but
-			// deviates from the http.DefaultTransport in Go's stdlib.
-			DualStack: true,
-		}).DialContext,
-		MaxIdleConns:          100,
-		IdleConnTimeout:       90 * time.Second,
-		TLSHandshakeTimeout:   10 * time.Second,
-		ExpectContinueTimeout: 1 * time.Second,
+	// The default transport uses the proxy configured in the environment of
+	// the controller, while an injected transport is used with its own proxy
+	// configuration, if any.
+	var transport *http.Transport
+	if options.Transport != nil {
+		transport = options.Transport.Clone()
+	} else {
+		transport = defaultVcertTransport()
 	}
 
 	// Copy vcert's initialization of the TLS client config
-	tlsClientConfig := http.DefaultTransport.(*http.Transport).TLSClientConfig.Clone()
+	tlsClientConfig := transport.TLSClientConfig
+	if tlsClientConfig == nil {
+		tlsClientConfig = http.DefaultTransport.(*http.Transport).TLSClientConfig.Clone()
+	}
 	if tlsClientConfig == nil {
 		tlsClientConfig = &tls.Config{}
 	}
@@ -299,6 +429,9 @@ func httpClientForVcert(options *httpClientForVcertOptions) *http.Client {
 		rootCAs.AppendCertsFromPEM(options.CABundle)
 		tlsClientConfig.RootCAs = rootCAs
 	}
+	if options.ClientCertificate != nil {
+		tlsClientConfig.Certificates = []tls.Certificate{*options.ClientCertificate}
+	}
 	transport.TLSClientConfig = tlsClientConfig
 
 	if options.TLSRenegotiationSupport != nil {
@@ -318,46 +451,43 @@ func httpClientForVcert(options *httpClientForVcertOptions) *http.Client {
 	}
 }
 
-// caBundleForVcertTPP is used to by ConnectionTrust and Client fields of vcert.Config.
-// This function sets appropriate CA based on provided bundle or kubernetes secret
-// If no custom CA bundle is configured, an empty byte slice is returned.
-// Assumes exactly one of the in-line/Secret CA bundles are defined.
-// If the `key` of the Secret CA bundle is not defined, its value defaults to
-// `ca.crt`.
-func caBundleForVcertTPP(tpp *cmapi.VenafiTPP, secretsLister internalinformers.SecretLister, namespace string) (caBundle []byte, err error) {
-	if len(tpp.CABundle) > 0 {
-		return tpp.CABundle, nil
+// proxyFromEnvironment returns a proxy function which selects the proxy for
+// a request using the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment
+// variables (or their lowercase versions), like http.ProxyFromEnvironment.
+// Unlike http.ProxyFromEnvironment, which reads the environment only once per
+// process, the environment is read when the function is created, so that
+// every client built for an issuer uses the current proxy configuration.
+func proxyFromEnvironment() func(*http.Request) (*url.URL, error) {
+	proxyFunc := httpproxy.FromEnvironment().ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
 	}
+}
 
-	secretRef := tpp.CABundleSecretRef
-	if secretRef == nil {
-		return nil, nil
-	}
-
-	var certBytes []byte
-	var ok bool
-
-	if secretRef != nil {
-		secret, err := secretsLister.Secrets(namespace).Get(secretRef.Name)
-		if err != nil {
-			return nil, fmt.Errorf("could not access secret '%s/%s': %s", namespace, secretRef.Name, err)
-		}
-
-		var key string
-		if secretRef.Key != "" {
-			key = secretRef.Key
-		} else {
-			key = cmmeta.TLSCAKey
-		}
-
-		certBytes, ok = secret.Data[key]
-		if !ok {
-			return nil, fmt.Errorf("no data for %q in secret '%s/%s'", key, namespace, secretRef.Name)
-		}
+// NewProxyTransport returns a copy of vcert's default HTTP transport which
+// sends requests through the given proxy, to be used with WithTransport.
+func NewProxyTransport(proxyURL *url.URL) *http.Transport {
+	transport := defaultVcertTransport()
+	transport.Proxy = http.ProxyURL(proxyURL)
+	return transport
+}
 
+// defaultVcertTransport returns a copy of vcert's default HTTP transport.
+func defaultVcertTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: proxyFromEnvironment(),
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			// Note: This DualStack setting is copied from vcert but
+			// deviates from the http.DefaultTransport in Go's stdlib.
+			DualStack: true,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
 	}
-
-	return certBytes, nil
 }
 
 func (v *Venafi) Ping() error {
@@ -372,6 +502,10 @@ func (v *Venafi) SetClient(client endpoint.Connector) {
 	v.vcertClient = client
 }
 
+func (v *Venafi) CredentialsName() string {
+	return v.credentialsName
+}
+
 // VerifyCredentials will remotely verify the credentials for the client, both for TPP and Cloud
 func (v *Venafi) VerifyCredentials() error {
 	switch {
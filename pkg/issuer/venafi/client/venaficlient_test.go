@@ -17,12 +17,17 @@ limitations under the License.
 package client
 
 import (
+	"encoding/pem"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	vcert "github.com/Venafi/vcert/v5"
 	corev1 "k8s.io/api/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/ptr"
 
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -255,6 +260,41 @@ func TestConfigForIssuerT(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		"if TPP and secret returns both access-token and user/pass, should error": {
+			iss: tppIssuer,
+			secretsLister: generateSecretLister(&corev1.Secret{
+				Data: map[string][]byte{
+					tppAccessTokenKey: []byte(accessToken),
+					tppUsernameKey:    []byte(username),
+					tppPasswordKey:    []byte(password),
+				},
+			}, nil),
+			CheckFn:              checkNoConfigReturned,
+			expectedErr:          true,
+			expectedInvalidCreds: true,
+		},
+		"if TPP and secret returns only a username, should error": {
+			iss: tppIssuer,
+			secretsLister: generateSecretLister(&corev1.Secret{
+				Data: map[string][]byte{
+					tppUsernameKey: []byte(username),
+				},
+			}, nil),
+			CheckFn:              checkNoConfigReturned,
+			expectedErr:          true,
+			expectedInvalidCreds: true,
+		},
+		"if TPP and secret returns no credentials, should error": {
+			iss: tppIssuer,
+			secretsLister: generateSecretLister(&corev1.Secret{
+				Data: map[string][]byte{
+					"unrelated": []byte("value"),
+				},
+			}, nil),
+			CheckFn:              checkNoConfigReturned,
+			expectedErr:          true,
+			expectedInvalidCreds: true,
+		},
 		// NOTE: Below scenarios assume valid TPP CAs, the scenarios with invalid TPP CAs are run part of TestCaBundleForVcertTPP test
 		"if TPP and a good caBundle specified, CA bundle should be added to ConnectionTrust and Client in vcert config": {
 			iss: tppIssuerWithCABundle,
@@ -270,10 +310,12 @@ func TestConfigForIssuerT(t *testing.T) {
 		},
 		"if TPP and a good caBundleSecretRef specified, CA bundle should be added to ConnectionTrust and Client in vcert config": {
 			iss: tppIssuerWithCABundleSecretRef,
-			// tppAccessTokenKey secret lister is not passed as we only have single secretsLister in testConfigForIssuerT struck
+			// The credentials and the CA bundle are stored in the same secret,
+			// as we only have single secretsLister in testConfigForIssuerT struct
 			secretsLister: generateSecretLister(&corev1.Secret{
 				Data: map[string][]byte{
-					customCaKey: []byte(testLeafCertificate),
+					tppAccessTokenKey: []byte(accessToken),
+					customCaKey:       []byte(testLeafCertificate),
 				},
 			}, nil),
 			CheckFn: func(t *testing.T, cnf *vcert.Config) {
@@ -317,6 +359,17 @@ func TestConfigForIssuerT(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		"if Cloud and secret does not contain the API key, should error": {
+			iss: cloudWithKeyIssuer,
+			secretsLister: generateSecretLister(&corev1.Secret{
+				Data: map[string][]byte{
+					defaultAPIKeyKey: []byte(apiKey),
+				},
+			}, nil),
+			CheckFn:              checkNoConfigReturned,
+			expectedErr:          true,
+			expectedInvalidCreds: true,
+		},
 		"if TPP and Cloud, should chose TPP": {
 			iss: gen.IssuerFrom(baseIssuer,
 				gen.SetIssuerVenafi(cmapi.VenafiIssuer{
@@ -456,17 +509,24 @@ type testConfigForIssuerT struct {
 
 	expectedErr bool
 
+	// expectedInvalidCreds is whether the error is expected to be an
+	// InvalidCredentialsError.
+	expectedInvalidCreds bool
+
 	CheckFn func(*testing.T, *vcert.Config)
 }
 
 func (c *testConfigForIssuerT) runTest(t *testing.T) {
-	resp, err := configForIssuer(c.iss, c.secretsLister, "test-namespace", "cert-manager/v0.0.0")
+	resp, err := configForIssuer(c.iss, NewSecretCredentialsResolver(c.secretsLister), "test-namespace", "cert-manager/v0.0.0", nil)
 	if err != nil && !c.expectedErr {
 		t.Errorf("expected to not get an error, but got: %v", err)
 	}
 	if err == nil && c.expectedErr {
 		t.Errorf("expected to get an error but did not get one")
 	}
+	if IsInvalidCredentialsError(err) != c.expectedInvalidCreds {
+		t.Errorf("expected IsInvalidCredentialsError to be %t, got error: %v", c.expectedInvalidCreds, err)
+	}
 
 	if c.CheckFn != nil {
 		c.CheckFn(t, resp)
@@ -474,7 +534,7 @@ func (c *testConfigForIssuerT) runTest(t *testing.T) {
 }
 
 func (c *testConfigForIssuerT) runTppCaTest(t *testing.T) {
-	caResp, err := caBundleForVcertTPP(c.iss.GetSpec().Venafi.TPP, c.secretsLister, "test-namespace")
+	caResp, err := NewSecretCredentialsResolver(c.secretsLister).TPPCABundle("test-namespace", c.iss.GetSpec().Venafi.TPP)
 
 	if err != nil && !c.expectedErr {
 		t.Errorf("expected to not get an error, but got: %v", err)
@@ -490,3 +550,139 @@ func (c *testConfigForIssuerT) runTppCaTest(t *testing.T) {
 		}
 	}
 }
+
+func TestHTTPClientForVcertTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.UserAgent()))
+	}))
+	defer server.Close()
+
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	proxyCalled := false
+	transport := &http.Transport{
+		Proxy: func(*http.Request) (*url.URL, error) {
+			proxyCalled = true
+			return nil, nil
+		},
+	}
+
+	tests := map[string]struct {
+		caBundle    []byte
+		expectedErr bool
+	}{
+		"a server with a self-signed certificate is not trusted without a CA bundle": {
+			expectedErr: true,
+		},
+		"a server with a self-signed certificate is trusted with a CA bundle": {
+			caBundle: serverCA,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			proxyCalled = false
+
+			client := httpClientForVcert(&httpClientForVcertOptions{
+				Transport: transport,
+				UserAgent: ptr.To("cert-manager/v0.0.0"),
+				CABundle:  test.caBundle,
+			})
+
+			resp, err := client.Get(server.URL)
+			if err != nil && !test.expectedErr {
+				t.Fatalf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Fatalf("expected to get an error but did not get one")
+			}
+			if err == nil {
+				defer resp.Body.Close()
+				if resp.StatusCode != http.StatusOK {
+					t.Errorf("got unexpected status code, exp=%d got=%d", http.StatusOK, resp.StatusCode)
+				}
+			}
+
+			if !proxyCalled {
+				t.Errorf("expected the request to be sent using the custom transport")
+			}
+		})
+	}
+
+	// Note that cloning a transport may set up its HTTP/2 TLS defaults, so
+	// only check that the CA bundle was not applied to the custom transport.
+	if transport.TLSClientConfig != nil && transport.TLSClientConfig.RootCAs != nil {
+		t.Errorf("expected the CA bundle not to be applied to the custom transport")
+	}
+}
+
+func TestHTTPClientForVcertProxyFromEnvironment(t *testing.T) {
+	t.Setenv("HTTPS_PROXY", "http://proxy.example.com:3128")
+	t.Setenv("HTTP_PROXY", "http://proxy.example.com:3128")
+	t.Setenv("NO_PROXY", "tpp.internal.example.com")
+
+	customProxy, err := url.Parse("http://custom-proxy.example.com:3128")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		transport     *http.Transport
+		url           string
+		expectedProxy string
+	}{
+		"the default transport uses the proxy of the environment": {
+			url:           "https://tpp.example.com/vedsdk",
+			expectedProxy: "http://proxy.example.com:3128",
+		},
+		"the default transport does not use a proxy for hosts in NO_PROXY": {
+			url: "https://tpp.internal.example.com/vedsdk",
+		},
+		"a custom transport without a proxy does not use a proxy": {
+			transport: &http.Transport{},
+			url:       "https://api.venafi.cloud/v1",
+		},
+		"a proxy transport uses its proxy for hosts in NO_PROXY": {
+			transport:     NewProxyTransport(customProxy),
+			url:           "https://tpp.internal.example.com/vedsdk",
+			expectedProxy: "http://custom-proxy.example.com:3128",
+		},
+		"a custom transport with a proxy keeps its proxy": {
+			transport:     &http.Transport{Proxy: http.ProxyURL(customProxy)},
+			url:           "https://tpp.internal.example.com/vedsdk",
+			expectedProxy: "http://custom-proxy.example.com:3128",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := httpClientForVcert(&httpClientForVcertOptions{
+				Transport: test.transport,
+			})
+
+			transport, ok := client.Transport.(*http.Transport)
+			if !ok {
+				t.Fatalf("expected an *http.Transport, got %T", client.Transport)
+			}
+
+			req, err := http.NewRequest(http.MethodGet, test.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var proxyURL string
+			if transport.Proxy != nil {
+				proxy, err := transport.Proxy(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if proxy != nil {
+					proxyURL = proxy.String()
+				}
+			}
+			if proxyURL != test.expectedProxy {
+				t.Errorf("got unexpected proxy, exp=%q got=%q", test.expectedProxy, proxyURL)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Venafi/vcert/v5/pkg/endpoint"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+// zoneCacheKey identifies the zone configuration of an issuer. The generation
+// of the issuer is part of the key, so that any change to the issuer spec
+// invalidates the cached zone configuration.
+type zoneCacheKey struct {
+	uid        apitypes.UID
+	namespace  string
+	name       string
+	generation int64
+	zone       string
+}
+
+// issuerRef returns the reference used to label the cache metrics.
+func (k zoneCacheKey) issuerRef() cmmeta.ObjectReference {
+	if k.namespace == "" {
+		return cmmeta.ObjectReference{Name: k.name, Kind: cmapi.ClusterIssuerKind}
+	}
+	return cmmeta.ObjectReference{Name: k.name, Kind: cmapi.IssuerKind}
+}
+
+type zoneCacheEntry struct {
+	config  *endpoint.ZoneConfiguration
+	expires time.Time
+}
+
+// ZoneConfigurationCache caches the zone configuration read from the Venafi
+// platform for a short time, so that a burst of requests for the same issuer
+// and zone reads the zone configuration only once.
+type ZoneConfigurationCache struct {
+	clock   clock.Clock
+	ttl     time.Duration
+	metrics *metrics.Metrics
+
+	lock    sync.Mutex
+	entries map[zoneCacheKey]zoneCacheEntry
+}
+
+// NewZoneConfigurationCache returns a cache which keeps zone configurations
+// for the given TTL. A TTL of zero or less disables caching.
+func NewZoneConfigurationCache(clock clock.Clock, ttl time.Duration, metrics *metrics.Metrics) *ZoneConfigurationCache {
+	return &ZoneConfigurationCache{
+		clock:   clock,
+		ttl:     ttl,
+		metrics: metrics,
+		entries: make(map[zoneCacheKey]zoneCacheEntry),
+	}
+}
+
+func newZoneCacheKey(issuer cmapi.GenericIssuer) zoneCacheKey {
+	return zoneCacheKey{
+		uid:        issuer.GetUID(),
+		namespace:  issuer.GetNamespace(),
+		name:       issuer.GetName(),
+		generation: issuer.GetGeneration(),
+		zone:       issuer.GetSpec().Venafi.Zone,
+	}
+}
+
+// get returns the cached zone configuration for the given key, or calls read
+// and caches its result if there is no unexpired entry. Errors are not cached.
+func (c *ZoneConfigurationCache) get(key zoneCacheKey, read func() (*endpoint.ZoneConfiguration, error)) (*endpoint.ZoneConfiguration, error) {
+	if c.ttl <= 0 {
+		return read()
+	}
+
+	now := c.clock.Now()
+
+	c.lock.Lock()
+	entry, ok := c.entries[key]
+	c.lock.Unlock()
+
+	if ok && now.Before(entry.expires) {
+		c.observe(key, metrics.VenafiZoneCacheResultHit)
+		return entry.config, nil
+	}
+	c.observe(key, metrics.VenafiZoneCacheResultMiss)
+
+	config, err := read()
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Drop expired entries, including those of older generations of the
+	// issuer, so that the cache does not grow without bound.
+	for k, e := range c.entries {
+		if !now.Before(e.expires) || (k.uid == key.uid && k.namespace == key.namespace && k.name == key.name) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = zoneCacheEntry{config: config, expires: now.Add(c.ttl)}
+
+	return config, nil
+}
+
+func (c *ZoneConfigurationCache) observe(key zoneCacheKey, result string) {
+	if c.metrics == nil {
+		return
+	}
+	c.metrics.IncrementVenafiZoneCacheLookup(key.issuerRef(), result)
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Venafi/vcert/v5/pkg/endpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	internalfake "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestZoneConfigurationCache(t *testing.T) {
+	issuer := gen.Issuer("venafi",
+		gen.SetIssuerNamespace("default"),
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "zone-a"}),
+	)
+	issuer.Generation = 1
+
+	changedIssuer := issuer.DeepCopy()
+	changedIssuer.Generation = 2
+
+	clusterIssuer := gen.ClusterIssuer("venafi",
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "zone-a"}),
+	)
+
+	tests := map[string]struct {
+		ttl time.Duration
+		// lookups are made in order, with the clock stepped by the given
+		// duration before each lookup.
+		lookups  []zoneCacheLookup
+		expReads int
+	}{
+		"repeated lookups within the TTL should read the zone once": {
+			ttl: time.Minute,
+			lookups: []zoneCacheLookup{
+				{issuer: issuer},
+				{issuer: issuer, step: time.Second * 30},
+				{issuer: issuer, step: time.Second * 29},
+			},
+			expReads: 1,
+		},
+		"lookup after the TTL should read the zone again": {
+			ttl: time.Minute,
+			lookups: []zoneCacheLookup{
+				{issuer: issuer},
+				{issuer: issuer, step: time.Minute},
+			},
+			expReads: 2,
+		},
+		"lookup after the issuer spec changed should read the zone again": {
+			ttl: time.Minute,
+			lookups: []zoneCacheLookup{
+				{issuer: issuer},
+				{issuer: changedIssuer},
+				{issuer: changedIssuer},
+			},
+			expReads: 2,
+		},
+		"issuers and cluster issuers should not share entries": {
+			ttl: time.Minute,
+			lookups: []zoneCacheLookup{
+				{issuer: issuer},
+				{issuer: clusterIssuer},
+			},
+			expReads: 2,
+		},
+		"errors should not be cached": {
+			ttl: time.Minute,
+			lookups: []zoneCacheLookup{
+				{issuer: issuer, err: errors.New("unavailable")},
+				{issuer: issuer},
+				{issuer: issuer},
+			},
+			expReads: 2,
+		},
+		"TTL of zero should disable the cache": {
+			lookups: []zoneCacheLookup{
+				{issuer: issuer},
+				{issuer: issuer},
+			},
+			expReads: 2,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			clock := fakeclock.NewFakeClock(time.Now())
+			cache := NewZoneConfigurationCache(clock, test.ttl, nil)
+
+			reads := 0
+			for _, lookup := range test.lookups {
+				clock.Step(lookup.step)
+
+				config, err := cache.get(newZoneCacheKey(lookup.issuer), func() (*endpoint.ZoneConfiguration, error) {
+					reads++
+					if lookup.err != nil {
+						return nil, lookup.err
+					}
+					return &endpoint.ZoneConfiguration{}, nil
+				})
+				if lookup.err != nil {
+					assert.Equal(t, lookup.err, err)
+					continue
+				}
+				require.NoError(t, err)
+				assert.NotNil(t, config)
+			}
+
+			assert.Equal(t, test.expReads, reads)
+		})
+	}
+}
+
+type zoneCacheLookup struct {
+	issuer cmapi.GenericIssuer
+	step   time.Duration
+	err    error
+}
+
+func TestVenafi_ZoneConfigurationCache(t *testing.T) {
+	privateKey, err := pki.GenerateRSAPrivateKey(2048)
+	require.NoError(t, err)
+	csrPEM := generateCSR(t, privateKey, "common-name", []string{"foo.example.com"})
+
+	issuer := gen.Issuer("venafi", gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "zone-a"}))
+	cache := NewZoneConfigurationCache(fakeclock.NewFakeClock(time.Now()), time.Minute, nil)
+
+	reads := 0
+	vcertClient := internalfake.Connector{}.Default()
+	vcertClient.ReadZoneConfigurationFunc = func() (*endpoint.ZoneConfiguration, error) {
+		reads++
+		return vcertClient.Connector.ReadZoneConfiguration()
+	}
+
+	// Clients constructed for each request share the cache.
+	for range 3 {
+		v := &Venafi{
+			vcertClient:  vcertClient,
+			zoneCache:    cache,
+			zoneCacheKey: newZoneCacheKey(issuer),
+		}
+		require.NoError(t, v.ValidateCertificateRequest(csrPEM, nil))
+	}
+
+	assert.Equal(t, 1, reads)
+}
@@ -21,29 +21,67 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	venaficlient "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
+const (
+	// reasonErrorSetup is the Ready condition reason of a Venafi issuer which
+	// is not configured correctly.
+	reasonErrorSetup = "ErrorSetup"
+	// reasonMissingSecret is the Ready condition reason of a Venafi issuer
+	// whose credentials Secret does not exist.
+	reasonMissingSecret = "MissingSecret"
+	// reasonInvalidCredentials is the Ready condition reason of a Venafi
+	// issuer whose credentials Secret does not contain the keys required by
+	// the Venafi platform it is configured for.
+	reasonInvalidCredentials = "InvalidCredentials"
+	// reasonUnreachable is the Ready condition reason of a Venafi issuer which
+	// is configured correctly, but whose Venafi server cannot be reached.
+	reasonUnreachable = "Unreachable"
+	// reasonReachable is the Ready condition reason of a Venafi issuer whose
+	// Venafi server was reached and has accepted the issuer credentials.
+	reasonReachable = "Reachable"
+)
+
 func (v *Venafi) Setup(ctx context.Context) (err error) {
+	reason := reasonErrorSetup
 	defer func() {
 		if err != nil {
 			errorMessage := "Failed to setup Venafi issuer"
 			v.log.Error(err, errorMessage)
-			apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionFalse, "ErrorSetup", fmt.Sprintf("%s: %v", errorMessage, err))
+			apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionFalse, reason, fmt.Sprintf("%s: %v", errorMessage, err))
 			err = fmt.Errorf("%s: %v", errorMessage, err)
 		}
 	}()
 
-	client, err := v.clientBuilder(v.resourceNamespace, v.secretsLister, v.issuer, v.Metrics, v.log, v.userAgent)
+	// The credentials are checked before building the client so that a
+	// missing Secret or a misspelled key is reported precisely, rather than
+	// as a generic setup error.
+	if v.credentialsResolver != nil {
+		if err := v.checkCredentials(); err != nil {
+			switch {
+			case apierrors.IsNotFound(err):
+				reason = reasonMissingSecret
+			case venaficlient.IsInvalidCredentialsError(err):
+				reason = reasonInvalidCredentials
+			}
+			return fmt.Errorf("error reading credentials: %v", err)
+		}
+	}
+
+	client, err := v.clientBuilder(v.resourceNamespace, v.credentialsResolver, v.issuer, v.Metrics, v.log, v.userAgent)
 	if err != nil {
 		return fmt.Errorf("error building client: %v", err)
 	}
 	err = client.Ping()
 	if err != nil {
+		reason = reasonUnreachable
 		return fmt.Errorf("error pinging Venafi API: %v", err)
 	}
 
@@ -61,7 +99,24 @@ func (v *Venafi) Setup(ctx context.Context) (err error) {
 		v.Recorder.Eventf(v.issuer, corev1.EventTypeNormal, "Ready", "Verified issuer with Venafi server")
 	}
 	v.log.V(logf.DebugLevel).Info("Venafi issuer started")
-	apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionTrue, "Venafi issuer started", "Venafi issuer started")
+	apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionTrue, reasonReachable, "Venafi issuer started")
+
+	return nil
+}
+
+// checkCredentials reads the credentials of the issuer, and the client
+// certificate of TPP issuers, to check that the Secrets they are read from
+// exist and contain the required keys.
+func (v *Venafi) checkCredentials() error {
+	if _, err := v.credentialsResolver.Credentials(v.resourceNamespace, v.issuer); err != nil {
+		return err
+	}
+
+	if tpp := v.issuer.GetSpec().Venafi.TPP; tpp != nil {
+		if _, err := v.credentialsResolver.TPPClientCertificate(v.resourceNamespace, tpp); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
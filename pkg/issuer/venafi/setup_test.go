@@ -24,9 +24,13 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
 
-	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	controllertest "github.com/cert-manager/cert-manager/pkg/controller/test"
 	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
@@ -34,17 +38,18 @@ import (
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
+	testlisters "github.com/cert-manager/cert-manager/test/unit/listers"
 )
 
 func TestSetup(t *testing.T) {
 	baseIssuer := gen.Issuer("test-issuer")
 
-	failingClientBuilder := func(string, internalinformers.SecretLister,
+	failingClientBuilder := func(string, client.CredentialsResolver,
 		cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
 		return nil, errors.New("this is an error")
 	}
 
-	failingPingClient := func(string, internalinformers.SecretLister,
+	failingPingClient := func(string, client.CredentialsResolver,
 		cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
 		return &internalvenafifake.Venafi{
 			PingFn: func() error {
@@ -53,7 +58,7 @@ func TestSetup(t *testing.T) {
 		}, nil
 	}
 
-	pingClient := func(string, internalinformers.SecretLister,
+	pingClient := func(string, client.CredentialsResolver,
 		cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
 		return &internalvenafifake.Venafi{
 			PingFn: func() error {
@@ -62,7 +67,7 @@ func TestSetup(t *testing.T) {
 		}, nil
 	}
 
-	verifyCredentialsClient := func(string, internalinformers.SecretLister, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+	verifyCredentialsClient := func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
 		return &internalvenafifake.Venafi{
 			PingFn: func() error {
 				return nil
@@ -73,7 +78,7 @@ func TestSetup(t *testing.T) {
 		}, nil
 	}
 
-	failingVerifyCredentialsClient := func(string, internalinformers.SecretLister, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
+	failingVerifyCredentialsClient := func(string, client.CredentialsResolver, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger, string) (client.Interface, error) {
 		return &internalvenafifake.Venafi{
 			PingFn: func() error {
 				return nil
@@ -84,7 +89,90 @@ func TestSetup(t *testing.T) {
 		}, nil
 	}
 
+	tppIssuer := gen.Issuer("test-issuer", gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+		TPP: &cmapi.VenafiTPP{CredentialsRef: cmmeta.LocalObjectReference{Name: "tpp-secret"}},
+	}))
+	secretResolver := func(secret *corev1.Secret) client.CredentialsResolver {
+		return client.NewSecretCredentialsResolver(&testlisters.FakeSecretLister{
+			SecretsFn: func(string) corelisters.SecretNamespaceLister {
+				return &testlisters.FakeSecretNamespaceLister{
+					GetFn: func(name string) (*corev1.Secret, error) {
+						if secret == nil || secret.Name != name {
+							return nil, apierrors.NewNotFound(corev1.Resource("secrets"), name)
+						}
+						return secret, nil
+					},
+				}
+			},
+		})
+	}
+
 	tests := map[string]testSetupT{
+		"if the credentials Secret does not exist then should set the MissingSecret reason": {
+			clientBuilder:       pingClient,
+			credentialsResolver: secretResolver(nil),
+			iss:                 tppIssuer.DeepCopy(),
+			expectedErr:         true,
+			expectedCondition: &cmapi.IssuerCondition{
+				Reason:  "MissingSecret",
+				Message: `Failed to setup Venafi issuer: error reading credentials: secrets "tpp-secret" not found`,
+				Status:  "False",
+			},
+		},
+
+		"if the credentials Secret has misspelled keys then should set the InvalidCredentials reason": {
+			clientBuilder: pingClient,
+			credentialsResolver: secretResolver(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "tpp-secret"},
+				Data:       map[string][]byte{"access_token": []byte("token")},
+			}),
+			iss:         tppIssuer.DeepCopy(),
+			expectedErr: true,
+			expectedCondition: &cmapi.IssuerCondition{
+				Reason:  "InvalidCredentials",
+				Message: `Failed to setup Venafi issuer: error reading credentials: invalid Venafi credentials in secret "tpp-secret": either the "access-token" key or the "username" and "password" keys must be set, but the secret only has the keys "access_token"`,
+				Status:  "False",
+			},
+		},
+
+		"if the client certificate Secret does not exist then should set the MissingSecret reason": {
+			clientBuilder: pingClient,
+			credentialsResolver: secretResolver(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "tpp-secret"},
+				Data:       map[string][]byte{"access-token": []byte("token")},
+			}),
+			iss: gen.IssuerFrom(tppIssuer, gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+				TPP: &cmapi.VenafiTPP{
+					CredentialsRef:             cmmeta.LocalObjectReference{Name: "tpp-secret"},
+					ClientCertificateSecretRef: &cmmeta.LocalObjectReference{Name: "client-cert"},
+				},
+			})),
+			expectedErr: true,
+			expectedCondition: &cmapi.IssuerCondition{
+				Reason:  "MissingSecret",
+				Message: `Failed to setup Venafi issuer: error reading credentials: could not access client certificate secret 'test-namespace/client-cert': secrets "client-cert" not found`,
+				Status:  "False",
+			},
+		},
+
+		"if the credentials Secret is valid then should set condition": {
+			clientBuilder: pingClient,
+			credentialsResolver: secretResolver(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "tpp-secret"},
+				Data:       map[string][]byte{"access-token": []byte("token")},
+			}),
+			iss:         tppIssuer.DeepCopy(),
+			expectedErr: false,
+			expectedCondition: &cmapi.IssuerCondition{
+				Message: "Venafi issuer started",
+				Reason:  "Reachable",
+				Status:  "True",
+			},
+			expectedEvents: []string{
+				"Normal Ready Verified issuer with Venafi server",
+			},
+		},
+
 		"if client builder fails then should error": {
 			clientBuilder: failingClientBuilder,
 			expectedErr:   true,
@@ -101,7 +189,7 @@ func TestSetup(t *testing.T) {
 			iss:           baseIssuer.DeepCopy(),
 			expectedErr:   true,
 			expectedCondition: &cmapi.IssuerCondition{
-				Reason:  "ErrorSetup",
+				Reason:  "Unreachable",
 				Message: "Failed to setup Venafi issuer: error pinging Venafi API: this is a ping error",
 				Status:  "False",
 			},
@@ -113,7 +201,7 @@ func TestSetup(t *testing.T) {
 			expectedErr:   false,
 			expectedCondition: &cmapi.IssuerCondition{
 				Message: "Venafi issuer started",
-				Reason:  "Venafi issuer started",
+				Reason:  "Reachable",
 				Status:  "True",
 			},
 			expectedEvents: []string{
@@ -126,7 +214,7 @@ func TestSetup(t *testing.T) {
 			expectedErr:   false,
 			expectedCondition: &cmapi.IssuerCondition{
 				Message: "Venafi issuer started",
-				Reason:  "Venafi issuer started",
+				Reason:  "Reachable",
 				Status:  "True",
 			},
 			expectedEvents: []string{
@@ -154,8 +242,9 @@ func TestSetup(t *testing.T) {
 }
 
 type testSetupT struct {
-	clientBuilder client.VenafiClientBuilder
-	iss           cmapi.GenericIssuer
+	clientBuilder       client.VenafiClientBuilder
+	credentialsResolver client.CredentialsResolver
+	iss                 cmapi.GenericIssuer
 
 	expectedErr       bool
 	expectedEvents    []string
@@ -170,9 +259,10 @@ func (s *testSetupT) runTest(t *testing.T) {
 		Context: &controllerpkg.Context{
 			Recorder: rec,
 		},
-		issuer:        s.iss,
-		clientBuilder: s.clientBuilder,
-		log:           logf.Log.WithName("venafi"),
+		issuer:              s.iss,
+		credentialsResolver: s.credentialsResolver,
+		clientBuilder:       s.clientBuilder,
+		log:                 logf.Log.WithName("venafi"),
 	}
 
 	err := v.Setup(context.TODO())
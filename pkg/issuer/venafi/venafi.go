@@ -19,7 +19,6 @@ package venafi
 import (
 	"github.com/go-logr/logr"
 
-	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller"
@@ -33,7 +32,7 @@ type Venafi struct {
 	issuer cmapi.GenericIssuer
 	*controller.Context
 
-	secretsLister internalinformers.SecretLister
+	credentialsResolver client.CredentialsResolver
 
 	// Namespace in which to read resources related to this Issuer from.
 	// For Issuers, this will be the namespace of the Issuer.
@@ -50,13 +49,13 @@ type Venafi struct {
 
 func NewVenafi(ctx *controller.Context, issuer cmapi.GenericIssuer) (issuer.Interface, error) {
 	return &Venafi{
-		issuer:            issuer,
-		secretsLister:     ctx.KubeSharedInformerFactory.Secrets().Lister(),
-		resourceNamespace: ctx.IssuerOptions.ResourceNamespace(issuer),
-		clientBuilder:     client.New,
-		Context:           ctx,
-		log:               logf.Log.WithName("venafi"),
-		userAgent:         ctx.RESTConfig.UserAgent,
+		issuer:              issuer,
+		credentialsResolver: client.NewSecretCredentialsResolver(ctx.KubeSharedInformerFactory.Secrets().Lister()),
+		resourceNamespace:   ctx.IssuerOptions.ResourceNamespace(issuer),
+		clientBuilder:       client.NewBuilder(),
+		Context:             ctx,
+		log:                 logf.Log.WithName("venafi"),
+		userAgent:           ctx.RESTConfig.UserAgent,
 	}, nil
 }
 
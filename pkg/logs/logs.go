@@ -104,6 +104,10 @@ const (
 	RelatedResourceNamespaceKey = "related_resource_namespace"
 	RelatedResourceKindKey      = "related_resource_kind"
 	RelatedResourceVersionKey   = "related_resource_version"
+
+	// TraceIDKey is the key of the ID of the trace of a reconcile, if
+	// tracing is configured.
+	TraceIDKey = "trace_id"
 )
 
 func WithResource(l logr.Logger, obj metav1.Object) logr.Logger {
@@ -168,6 +172,51 @@ func NewContext(ctx context.Context, l logr.Logger, names ...string) context.Con
 	return logr.NewContext(ctx, l)
 }
 
+// WithVerbosity returns a Logger which logs messages up to the given
+// verbosity level, even if the verbosity of the given Logger is lower. This
+// is used to enable debug logging for a single resource without raising the
+// global log level.
+func WithVerbosity(l logr.Logger, level int) logr.Logger {
+	if l.GetSink() == nil {
+		return l
+	}
+	return l.WithSink(verboseSink{LogSink: l.GetSink(), level: level})
+}
+
+// verboseSink is a LogSink which forwards the messages up to its verbosity
+// level to the wrapped LogSink as non-verbose messages, so that they are not
+// filtered out by the verbosity of the wrapped LogSink.
+type verboseSink struct {
+	logr.LogSink
+	level int
+}
+
+func (s verboseSink) Enabled(level int) bool {
+	return level <= s.level || s.LogSink.Enabled(level)
+}
+
+func (s verboseSink) Info(level int, msg string, keysAndValues ...any) {
+	if level <= s.level {
+		level = 0
+	}
+	s.LogSink.Info(level, msg, keysAndValues...)
+}
+
+func (s verboseSink) WithValues(keysAndValues ...any) logr.LogSink {
+	return verboseSink{LogSink: s.LogSink.WithValues(keysAndValues...), level: s.level}
+}
+
+func (s verboseSink) WithName(name string) logr.LogSink {
+	return verboseSink{LogSink: s.LogSink.WithName(name), level: s.level}
+}
+
+func (s verboseSink) WithCallDepth(depth int) logr.LogSink {
+	if sink, ok := s.LogSink.(logr.CallDepthLogSink); ok {
+		return verboseSink{LogSink: sink.WithCallDepth(depth), level: s.level}
+	}
+	return s
+}
+
 func V(level int) klog.Verbose {
 	return klog.V(klog.Level(level))
 }
@@ -0,0 +1,52 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithVerbosity(t *testing.T) {
+	var lines []string
+	log := funcr.New(func(prefix, args string) {
+		lines = append(lines, prefix+" "+args)
+	}, funcr.Options{Verbosity: InfoLevel})
+
+	log.V(DebugLevel).Info("filtered")
+
+	verbose := WithVerbosity(log, DebugLevel).WithName("sign").WithValues("key", "value")
+	verbose.V(DebugLevel).Info("debug")
+	verbose.V(TraceLevel).Info("trace")
+	verbose.Info("info")
+
+	// The original Logger is unchanged.
+	log.V(DebugLevel).Info("filtered")
+
+	assert.Equal(t, []string{
+		`sign "level"=0 "msg"="debug" "key"="value"`,
+		`sign "level"=0 "msg"="info" "key"="value"`,
+	}, lines)
+}
+
+func TestWithVerbosityDiscard(t *testing.T) {
+	log := WithVerbosity(logr.Discard(), TraceLevel)
+	assert.False(t, log.V(DebugLevel).Enabled())
+}
@@ -63,6 +63,7 @@ func (m *Metrics) updateCertificateRenewalTime(crt *cmapi.Certificate) {
 		"issuer_kind":  crt.Spec.IssuerRef.Kind,
 		"issuer_group": crt.Spec.IssuerRef.Group}).Set(renewalTime)
 
+	m.certificateRenewals.update(crt)
 }
 
 // updateCertificateStatus will update the metric for that Certificate
@@ -105,4 +106,5 @@ func (m *Metrics) RemoveCertificate(key types.NamespacedName) {
 	m.certificateExpiryTimeSeconds.DeletePartialMatch(prometheus.Labels{"name": name, "namespace": namespace})
 	m.certificateRenewalTimeSeconds.DeletePartialMatch(prometheus.Labels{"name": name, "namespace": namespace})
 	m.certificateReadyStatus.DeletePartialMatch(prometheus.Labels{"name": name, "namespace": namespace})
+	m.certificateRenewals.remove(key)
 }
@@ -0,0 +1,47 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+const (
+	// IssuerCacheResultHit is the result of a lookup of an issuer which was
+	// found in the cache.
+	IssuerCacheResultHit = "hit"
+	// IssuerCacheResultMiss is the result of a lookup of an issuer which had
+	// to be read from the lister.
+	IssuerCacheResultMiss = "miss"
+)
+
+// IncrementIssuerCacheLookup increments the count of lookups of the given
+// issuer by a controller which caches issuers, along with whether the lookup
+// was served from the cache.
+// It does nothing if m is nil, so that the cache can be used without metrics.
+func (m *Metrics) IncrementIssuerCacheLookup(issuerRef cmmeta.ObjectReference, result string) {
+	if m == nil {
+		return
+	}
+	m.issuerCacheLookupCount.With(prometheus.Labels{
+		"issuer_name": issuerRef.Name,
+		"issuer_kind": issuerRef.Kind,
+		"result":      result,
+	}).Inc()
+}
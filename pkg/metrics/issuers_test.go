@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestIncrementIssuerCacheLookup(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()))
+
+	issuerRef := cmmeta.ObjectReference{Name: "venafi", Kind: "ClusterIssuer"}
+	m.IncrementIssuerCacheLookup(issuerRef, IssuerCacheResultMiss)
+	m.IncrementIssuerCacheLookup(issuerRef, IssuerCacheResultHit)
+	m.IncrementIssuerCacheLookup(issuerRef, IssuerCacheResultHit)
+
+	expected := `
+# HELP certmanager_issuer_cache_lookup_count The number of lookups of issuers by controllers which cache them, by whether the issuer was found in the cache (hit) or read from the lister (miss).
+# TYPE certmanager_issuer_cache_lookup_count counter
+certmanager_issuer_cache_lookup_count{issuer_kind="ClusterIssuer",issuer_name="venafi",result="hit"} 2
+certmanager_issuer_cache_lookup_count{issuer_kind="ClusterIssuer",issuer_name="venafi",result="miss"} 1
+`
+	assert.NoError(t,
+		testutil.CollectAndCompare(m.issuerCacheLookupCount, strings.NewReader(expected), "certmanager_issuer_cache_lookup_count"),
+	)
+
+	// A nil Metrics records nothing.
+	var nilMetrics *Metrics
+	nilMetrics.IncrementIssuerCacheLookup(issuerRef, IssuerCacheResultHit)
+}
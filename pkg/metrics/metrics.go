@@ -19,13 +19,20 @@ limitations under the License.
 // certificate_expiration_timestamp_seconds{name, namespace, issuer_name, issuer_kind, issuer_group}
 // certificate_renewal_timestamp_seconds{name, namespace, issuer_name, issuer_kind, issuer_group}
 // certificate_ready_status{name, namespace, condition, issuer_name, issuer_kind, issuer_group}
+// certificate_renewals_due{issuer_name, issuer_kind, issuer_group, window}
 // acme_client_request_count{"scheme", "host", "path", "method", "status"}
 // acme_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
 // venafi_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
+// venafi_sign_duration_seconds{"issuer_name", "issuer_kind", "result"}
+// venafi_sign_errors_total{"issuer_name", "issuer_kind", "reason"}
+// venafi_zone_cache_lookup_count{"issuer_name", "issuer_kind", "result"}
+// venafi_circuit_breaker_state{"issuer_name", "issuer_kind", "state"}
+// issuer_cache_lookup_count{"issuer_name", "issuer_kind", "result"}
 // controller_sync_call_count{"controller"}
 package metrics
 
 import (
+	"errors"
 	"net"
 	"net/http"
 	"time"
@@ -37,6 +44,7 @@ import (
 	"k8s.io/utils/clock"
 
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
 const (
@@ -58,9 +66,15 @@ type Metrics struct {
 	certificateExpiryTimeSeconds       *prometheus.GaugeVec
 	certificateRenewalTimeSeconds      *prometheus.GaugeVec
 	certificateReadyStatus             *prometheus.GaugeVec
+	certificateRenewals                *certificateRenewalsCollector
 	acmeClientRequestDurationSeconds   *prometheus.SummaryVec
 	acmeClientRequestCount             *prometheus.CounterVec
 	venafiClientRequestDurationSeconds *prometheus.SummaryVec
+	venafiSignDurationSeconds          *prometheus.HistogramVec
+	venafiSignErrorsTotal              *prometheus.CounterVec
+	venafiZoneCacheLookupCount         *prometheus.CounterVec
+	issuerCacheLookupCount             *prometheus.CounterVec
+	venafiCircuitBreakerState          *prometheus.GaugeVec
 	controllerSyncCallCount            *prometheus.CounterVec
 	controllerSyncErrorCount           *prometheus.CounterVec
 }
@@ -168,6 +182,52 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			[]string{"api_call"},
 		)
 
+		venafiSignDurationSeconds = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "venafi_sign_duration_seconds",
+				Help:      "The time in seconds taken to request or retrieve a certificate from Venafi when signing a CertificateRequest.",
+				Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+			},
+			[]string{"issuer_name", "issuer_kind", "result"},
+		)
+
+		venafiSignErrorsTotal = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "venafi_sign_errors_total",
+				Help:      "The number of errors when signing CertificateRequests with a Venafi issuer, by the reason of the error.",
+			},
+			[]string{"issuer_name", "issuer_kind", "reason"},
+		)
+
+		venafiZoneCacheLookupCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "venafi_zone_cache_lookup_count",
+				Help:      "The number of lookups of the Venafi zone configuration, by whether the zone configuration was found in the cache (hit) or read from the Venafi platform (miss).",
+			},
+			[]string{"issuer_name", "issuer_kind", "result"},
+		)
+
+		venafiCircuitBreakerState = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "venafi_circuit_breaker_state",
+				Help:      "The state of the circuit breaker of each Venafi issuer. The gauge of the current state (closed, open or half_open) is 1, the others are 0.",
+			},
+			[]string{"issuer_name", "issuer_kind", "state"},
+		)
+
+		issuerCacheLookupCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "issuer_cache_lookup_count",
+				Help:      "The number of lookups of issuers by controllers which cache them, by whether the issuer was found in the cache (hit) or read from the lister (miss).",
+			},
+			[]string{"issuer_name", "issuer_kind", "result"},
+		)
+
 		controllerSyncCallCount = prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Namespace: namespace,
@@ -203,9 +263,15 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 		certificateExpiryTimeSeconds:       certificateExpiryTimeSeconds,
 		certificateRenewalTimeSeconds:      certificateRenewalTimeSeconds,
 		certificateReadyStatus:             certificateReadyStatus,
+		certificateRenewals:                newCertificateRenewalsCollector(c),
 		acmeClientRequestCount:             acmeClientRequestCount,
 		acmeClientRequestDurationSeconds:   acmeClientRequestDurationSeconds,
 		venafiClientRequestDurationSeconds: venafiClientRequestDurationSeconds,
+		venafiSignDurationSeconds:          venafiSignDurationSeconds,
+		venafiSignErrorsTotal:              venafiSignErrorsTotal,
+		venafiZoneCacheLookupCount:         venafiZoneCacheLookupCount,
+		venafiCircuitBreakerState:          venafiCircuitBreakerState,
+		issuerCacheLookupCount:             issuerCacheLookupCount,
 		controllerSyncCallCount:            controllerSyncCallCount,
 		controllerSyncErrorCount:           controllerSyncErrorCount,
 	}
@@ -214,17 +280,32 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 }
 
 // NewServer registers Prometheus metrics and returns a new Prometheus metrics HTTP server.
+// Metrics which cannot be registered are logged and left out, rather than
+// preventing the server from starting.
 func (m *Metrics) NewServer(ln net.Listener) *http.Server {
-	m.registry.MustRegister(m.clockTimeSeconds)
-	m.registry.MustRegister(m.clockTimeSecondsGauge)
-	m.registry.MustRegister(m.certificateExpiryTimeSeconds)
-	m.registry.MustRegister(m.certificateRenewalTimeSeconds)
-	m.registry.MustRegister(m.certificateReadyStatus)
-	m.registry.MustRegister(m.acmeClientRequestDurationSeconds)
-	m.registry.MustRegister(m.venafiClientRequestDurationSeconds)
-	m.registry.MustRegister(m.acmeClientRequestCount)
-	m.registry.MustRegister(m.controllerSyncCallCount)
-	m.registry.MustRegister(m.controllerSyncErrorCount)
+	if m.registry == nil {
+		m.log.V(logf.WarnLevel).Info("metrics registry is not set, using an empty registry")
+		m.registry = prometheus.NewRegistry()
+	}
+
+	m.register(
+		m.clockTimeSeconds,
+		m.clockTimeSecondsGauge,
+		m.certificateExpiryTimeSeconds,
+		m.certificateRenewalTimeSeconds,
+		m.certificateReadyStatus,
+		m.certificateRenewals,
+		m.acmeClientRequestDurationSeconds,
+		m.venafiClientRequestDurationSeconds,
+		m.venafiSignDurationSeconds,
+		m.venafiSignErrorsTotal,
+		m.venafiZoneCacheLookupCount,
+		m.venafiCircuitBreakerState,
+		m.issuerCacheLookupCount,
+		m.acmeClientRequestCount,
+		m.controllerSyncCallCount,
+		m.controllerSyncErrorCount,
+	)
 
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
@@ -240,6 +321,26 @@ func (m *Metrics) NewServer(ln net.Listener) *http.Server {
 	return server
 }
 
+// register registers the given collectors with the registry. Collectors which
+// are already registered, for example when cert-manager is embedded in a
+// program or test which shares the registry, are kept as is. Other
+// registration errors are logged, and the collector is not exposed.
+func (m *Metrics) register(cs ...prometheus.Collector) {
+	for _, c := range cs {
+		err := m.registry.Register(c)
+		if err == nil {
+			continue
+		}
+
+		if errors.As(err, &prometheus.AlreadyRegisteredError{}) {
+			m.log.V(logf.DebugLevel).Info("metric already registered, skipping", "error", err)
+			continue
+		}
+
+		m.log.V(logf.WarnLevel).Info("failed to register metric, it will not be exposed", "error", err)
+	}
+}
+
 // IncrementSyncCallCount will increase the sync counter for that controller.
 func (m *Metrics) IncrementSyncCallCount(controllerName string) {
 	m.controllerSyncCallCount.WithLabelValues(controllerName).Inc()
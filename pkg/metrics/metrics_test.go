@@ -18,6 +18,7 @@ package metrics
 
 import (
 	"fmt"
+	"net"
 	"strings"
 	"testing"
 	"time"
@@ -26,6 +27,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	fakeclock "k8s.io/utils/clock/testing"
 )
 
@@ -67,3 +69,54 @@ certmanager_clock_time_seconds_gauge %f
 		})
 	}
 }
+
+func TestNewServerRegistration(t *testing.T) {
+	newListener := func(t *testing.T) net.Listener {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		t.Cleanup(func() { ln.Close() })
+		return ln
+	}
+
+	t.Run("registering the metrics twice does not panic", func(t *testing.T) {
+		m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()))
+
+		assert.NotPanics(t, func() {
+			m.NewServer(newListener(t))
+			m.NewServer(newListener(t))
+		})
+	})
+
+	t.Run("metrics already registered with a shared registry are kept", func(t *testing.T) {
+		m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()))
+		other := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()))
+		other.registry = m.registry
+		m.NewServer(newListener(t))
+
+		assert.NotPanics(t, func() {
+			other.NewServer(newListener(t))
+		})
+		m.IncrementSyncCallCount("test")
+
+		count, err := testutil.GatherAndCount(m.registry, "certmanager_controller_sync_call_count")
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("a nil registry is replaced by an empty registry", func(t *testing.T) {
+		m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()))
+		m.registry = nil
+
+		server := m.NewServer(newListener(t))
+		require.NotNil(t, m.registry)
+		assert.NotNil(t, server.Handler)
+	})
+}
+
+func TestNilMetrics(t *testing.T) {
+	var m *Metrics
+
+	assert.NotPanics(t, func() {
+		m.ObserveVenafiRequestDuration(time.Second, "request_certificate")
+	})
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// certificateRenewalWindows are the windows, from now, for which the number
+// of certificates due for renewal is exposed.
+var certificateRenewalWindows = [...]time.Duration{
+	time.Hour,
+	6 * time.Hour,
+	24 * time.Hour,
+	7 * 24 * time.Hour,
+}
+
+// certificateRenewalsCollector exposes the number of certificates whose
+// renewal time falls within each of the certificateRenewalWindows, by issuer,
+// so that bursts of renewals can be anticipated. The counts change as time
+// passes without any certificate changing, so they are computed when the
+// metric is collected from the renewal times of the certificates.
+type certificateRenewalsCollector struct {
+	clock clock.Clock
+	desc  *prometheus.Desc
+
+	lock     sync.Mutex
+	renewals map[types.NamespacedName]certificateRenewal
+}
+
+type certificateRenewal struct {
+	issuerRef   cmmeta.ObjectReference
+	renewalTime time.Time
+}
+
+func newCertificateRenewalsCollector(c clock.Clock) *certificateRenewalsCollector {
+	return &certificateRenewalsCollector{
+		clock: c,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "certificate_renewals_due"),
+			"The number of certificates whose renewal time is within the given window from now, by issuer. Certificates whose renewal time has passed are included.",
+			[]string{"issuer_name", "issuer_kind", "issuer_group", "window"},
+			nil,
+		),
+		renewals: make(map[types.NamespacedName]certificateRenewal),
+	}
+}
+
+// update records the renewal time of the Certificate, or forgets the
+// Certificate if it has no renewal time.
+func (c *certificateRenewalsCollector) update(crt *cmapi.Certificate) {
+	key := types.NamespacedName{Namespace: crt.Namespace, Name: crt.Name}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if crt.Status.RenewalTime == nil {
+		delete(c.renewals, key)
+		return
+	}
+
+	c.renewals[key] = certificateRenewal{
+		issuerRef:   crt.Spec.IssuerRef,
+		renewalTime: crt.Status.RenewalTime.Time,
+	}
+}
+
+func (c *certificateRenewalsCollector) remove(key types.NamespacedName) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.renewals, key)
+}
+
+func (c *certificateRenewalsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *certificateRenewalsCollector) Collect(ch chan<- prometheus.Metric) {
+	now := c.clock.Now()
+
+	c.lock.Lock()
+	counts := make(map[cmmeta.ObjectReference]*[len(certificateRenewalWindows)]int)
+	for _, renewal := range c.renewals {
+		issuerCounts, ok := counts[renewal.issuerRef]
+		if !ok {
+			issuerCounts = new([len(certificateRenewalWindows)]int)
+			counts[renewal.issuerRef] = issuerCounts
+		}
+		for i, window := range certificateRenewalWindows {
+			if renewal.renewalTime.Before(now.Add(window)) {
+				issuerCounts[i]++
+			}
+		}
+	}
+	c.lock.Unlock()
+
+	for issuerRef, issuerCounts := range counts {
+		for i, window := range certificateRenewalWindows {
+			ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(issuerCounts[i]),
+				issuerRef.Name, issuerRef.Kind, issuerRef.Group, fmt.Sprintf("%dh", int(window.Hours())))
+		}
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+const renewalsDueMetadata = `
+	# HELP certmanager_certificate_renewals_due The number of certificates whose renewal time is within the given window from now, by issuer. Certificates whose renewal time has passed are included.
+	# TYPE certmanager_certificate_renewals_due gauge
+`
+
+func TestCertificateRenewalsDue(t *testing.T) {
+	now := time.Now()
+	clock := fakeclock.NewFakeClock(now)
+	m := New(logtesting.NewTestLogger(t), clock)
+
+	certificate := func(name, issuer string, renewalIn time.Duration) {
+		m.UpdateCertificate(gen.Certificate(name,
+			gen.SetCertificateNamespace("test-ns"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: issuer, Kind: "Issuer", Group: "cert-manager.io"}),
+			gen.SetCertificateRenewalTime(metav1.NewTime(now.Add(renewalIn))),
+		))
+	}
+	certificate("overdue", "issuer-a", -time.Minute)
+	certificate("in-3h", "issuer-a", 3*time.Hour)
+	certificate("in-2d", "issuer-a", 48*time.Hour)
+	certificate("in-30m", "issuer-b", 30*time.Minute)
+	certificate("in-30d", "issuer-b", 30*24*time.Hour)
+
+	// Certificates without a renewal time, or which are removed, are not
+	// counted.
+	m.UpdateCertificate(gen.Certificate("not-issued",
+		gen.SetCertificateNamespace("test-ns"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer-a", Kind: "Issuer", Group: "cert-manager.io"}),
+	))
+	certificate("removed", "issuer-a", time.Minute)
+	m.RemoveCertificate(types.NamespacedName{Namespace: "test-ns", Name: "removed"})
+
+	if err := testutil.CollectAndCompare(m.certificateRenewals, strings.NewReader(renewalsDueMetadata+`
+	certmanager_certificate_renewals_due{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="issuer-a",window="1h"} 1
+	certmanager_certificate_renewals_due{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="issuer-a",window="6h"} 2
+	certmanager_certificate_renewals_due{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="issuer-a",window="24h"} 2
+	certmanager_certificate_renewals_due{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="issuer-a",window="168h"} 3
+	certmanager_certificate_renewals_due{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="issuer-b",window="1h"} 1
+	certmanager_certificate_renewals_due{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="issuer-b",window="6h"} 1
+	certmanager_certificate_renewals_due{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="issuer-b",window="24h"} 1
+	certmanager_certificate_renewals_due{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="issuer-b",window="168h"} 1
+`), "certmanager_certificate_renewals_due"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	// The counts follow the passing of time.
+	clock.Step(24 * time.Hour)
+
+	if err := testutil.CollectAndCompare(m.certificateRenewals, strings.NewReader(renewalsDueMetadata+`
+	certmanager_certificate_renewals_due{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="issuer-a",window="1h"} 2
+	certmanager_certificate_renewals_due{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="issuer-a",window="6h"} 2
+	certmanager_certificate_renewals_due{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="issuer-a",window="24h"} 2
+	certmanager_certificate_renewals_due{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="issuer-a",window="168h"} 3
+	certmanager_certificate_renewals_due{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="issuer-b",window="1h"} 1
+	certmanager_certificate_renewals_due{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="issuer-b",window="6h"} 1
+	certmanager_certificate_renewals_due{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="issuer-b",window="24h"} 1
+	certmanager_certificate_renewals_due{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="issuer-b",window="168h"} 1
+`), "certmanager_certificate_renewals_due"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
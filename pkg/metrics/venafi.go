@@ -18,9 +18,120 @@ package metrics
 
 import (
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 )
 
+const (
+	// VenafiSignResultSuccess is the result of a signing which returned a
+	// certificate.
+	VenafiSignResultSuccess = "success"
+	// VenafiSignResultPending is the result of a signing which is still
+	// pending issuance on the Venafi platform.
+	VenafiSignResultPending = "pending"
+	// VenafiSignResultFailed is the result of a signing which failed.
+	VenafiSignResultFailed = "failed"
+
+	// VenafiSignErrorPending is the reason of a signing whose certificate is
+	// still pending issuance on the Venafi platform.
+	VenafiSignErrorPending = "pending"
+	// VenafiSignErrorTimeout is the reason of a signing which timed out
+	// waiting for the Venafi platform.
+	VenafiSignErrorTimeout = "timeout"
+	// VenafiSignErrorAuthentication is the reason of a signing whose issuer
+	// credentials were rejected by the Venafi platform.
+	VenafiSignErrorAuthentication = "authentication"
+	// VenafiSignErrorPolicyViolation is the reason of a signing whose request
+	// is not allowed by the policy of the Venafi zone.
+	VenafiSignErrorPolicyViolation = "policy_violation"
+	// VenafiSignErrorInvalidRequest is the reason of a signing whose request
+	// cannot be submitted to the Venafi platform, for example because of
+	// invalid custom fields.
+	VenafiSignErrorInvalidRequest = "invalid_request"
+	// VenafiSignErrorRequest is the reason of a signing which failed to
+	// request a certificate for an unexpected reason.
+	VenafiSignErrorRequest = "request"
+	// VenafiSignErrorRetrieve is the reason of a signing which failed to
+	// retrieve a certificate for an unexpected reason.
+	VenafiSignErrorRetrieve = "retrieve"
+
+	// VenafiZoneCacheResultHit is the result of a lookup of a zone
+	// configuration which was found in the cache.
+	VenafiZoneCacheResultHit = "hit"
+	// VenafiZoneCacheResultMiss is the result of a lookup of a zone
+	// configuration which had to be read from the Venafi platform.
+	VenafiZoneCacheResultMiss = "miss"
+
+	// VenafiCircuitBreakerStateClosed is the state of a circuit breaker which
+	// lets signings through.
+	VenafiCircuitBreakerStateClosed = "closed"
+	// VenafiCircuitBreakerStateOpen is the state of a circuit breaker which
+	// fails signings fast after repeated failures to reach the Venafi
+	// platform.
+	VenafiCircuitBreakerStateOpen = "open"
+	// VenafiCircuitBreakerStateHalfOpen is the state of a circuit breaker
+	// which has let a single signing through to probe the Venafi platform.
+	VenafiCircuitBreakerStateHalfOpen = "half_open"
+)
+
+var venafiCircuitBreakerStates = [...]string{VenafiCircuitBreakerStateClosed, VenafiCircuitBreakerStateOpen, VenafiCircuitBreakerStateHalfOpen}
+
 // ObserveVenafiRequestDuration increases bucket counters for that Venafi client duration.
+// It does nothing if m is nil, so that Venafi clients can be built without metrics.
 func (m *Metrics) ObserveVenafiRequestDuration(duration time.Duration, labels ...string) {
+	if m == nil {
+		return
+	}
 	m.venafiClientRequestDurationSeconds.WithLabelValues(labels...).Observe(duration.Seconds())
 }
+
+// ObserveVenafiSignDuration records the time taken to request or retrieve a
+// certificate from the given Venafi issuer, along with the result.
+func (m *Metrics) ObserveVenafiSignDuration(duration time.Duration, issuerRef cmmeta.ObjectReference, result string) {
+	m.venafiSignDurationSeconds.With(prometheus.Labels{
+		"issuer_name": issuerRef.Name,
+		"issuer_kind": issuerRef.Kind,
+		"result":      result,
+	}).Observe(duration.Seconds())
+}
+
+// IncrementVenafiSignError increments the count of errors of the given
+// Venafi issuer when signing CertificateRequests, by the reason of the error.
+func (m *Metrics) IncrementVenafiSignError(issuerRef cmmeta.ObjectReference, reason string) {
+	m.venafiSignErrorsTotal.With(prometheus.Labels{
+		"issuer_name": issuerRef.Name,
+		"issuer_kind": issuerRef.Kind,
+		"reason":      reason,
+	}).Inc()
+}
+
+// IncrementVenafiZoneCacheLookup increments the count of lookups of the zone
+// configuration of the given Venafi issuer, along with whether the lookup was
+// served from the cache.
+func (m *Metrics) IncrementVenafiZoneCacheLookup(issuerRef cmmeta.ObjectReference, result string) {
+	m.venafiZoneCacheLookupCount.With(prometheus.Labels{
+		"issuer_name": issuerRef.Name,
+		"issuer_kind": issuerRef.Kind,
+		"result":      result,
+	}).Inc()
+}
+
+// SetVenafiCircuitBreakerState records the current state of the circuit
+// breaker of the given Venafi issuer.
+func (m *Metrics) SetVenafiCircuitBreakerState(issuerRef cmmeta.ObjectReference, current string) {
+	for _, state := range venafiCircuitBreakerStates {
+		value := 0.0
+
+		if current == state {
+			value = 1.0
+		}
+
+		m.venafiCircuitBreakerState.With(prometheus.Labels{
+			"issuer_name": issuerRef.Name,
+			"issuer_kind": issuerRef.Kind,
+			"state":       state,
+		}).Set(value)
+	}
+}
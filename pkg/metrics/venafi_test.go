@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestObserveVenafiSignDuration(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()))
+
+	issuerRef := cmmeta.ObjectReference{Name: "venafi", Kind: "ClusterIssuer"}
+	m.ObserveVenafiSignDuration(time.Second*3, issuerRef, VenafiSignResultPending)
+
+	expected := `
+# HELP certmanager_venafi_sign_duration_seconds The time in seconds taken to request or retrieve a certificate from Venafi when signing a CertificateRequest.
+# TYPE certmanager_venafi_sign_duration_seconds histogram
+certmanager_venafi_sign_duration_seconds_bucket{issuer_kind="ClusterIssuer",issuer_name="venafi",result="pending",le="0.1"} 0
+certmanager_venafi_sign_duration_seconds_bucket{issuer_kind="ClusterIssuer",issuer_name="venafi",result="pending",le="0.25"} 0
+certmanager_venafi_sign_duration_seconds_bucket{issuer_kind="ClusterIssuer",issuer_name="venafi",result="pending",le="0.5"} 0
+certmanager_venafi_sign_duration_seconds_bucket{issuer_kind="ClusterIssuer",issuer_name="venafi",result="pending",le="1"} 0
+certmanager_venafi_sign_duration_seconds_bucket{issuer_kind="ClusterIssuer",issuer_name="venafi",result="pending",le="2.5"} 0
+certmanager_venafi_sign_duration_seconds_bucket{issuer_kind="ClusterIssuer",issuer_name="venafi",result="pending",le="5"} 1
+certmanager_venafi_sign_duration_seconds_bucket{issuer_kind="ClusterIssuer",issuer_name="venafi",result="pending",le="10"} 1
+certmanager_venafi_sign_duration_seconds_bucket{issuer_kind="ClusterIssuer",issuer_name="venafi",result="pending",le="30"} 1
+certmanager_venafi_sign_duration_seconds_bucket{issuer_kind="ClusterIssuer",issuer_name="venafi",result="pending",le="60"} 1
+certmanager_venafi_sign_duration_seconds_bucket{issuer_kind="ClusterIssuer",issuer_name="venafi",result="pending",le="120"} 1
+certmanager_venafi_sign_duration_seconds_bucket{issuer_kind="ClusterIssuer",issuer_name="venafi",result="pending",le="+Inf"} 1
+certmanager_venafi_sign_duration_seconds_sum{issuer_kind="ClusterIssuer",issuer_name="venafi",result="pending"} 3
+certmanager_venafi_sign_duration_seconds_count{issuer_kind="ClusterIssuer",issuer_name="venafi",result="pending"} 1
+`
+
+	assert.NoError(t,
+		testutil.CollectAndCompare(m.venafiSignDurationSeconds, strings.NewReader(expected), "certmanager_venafi_sign_duration_seconds"),
+	)
+}
+
+func TestIncrementVenafiSignError(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()))
+
+	issuerRef := cmmeta.ObjectReference{Name: "venafi", Kind: "Issuer"}
+	m.IncrementVenafiSignError(issuerRef, VenafiSignErrorPending)
+	m.IncrementVenafiSignError(issuerRef, VenafiSignErrorPending)
+	m.IncrementVenafiSignError(issuerRef, VenafiSignErrorAuthentication)
+
+	expected := `
+# HELP certmanager_venafi_sign_errors_total The number of errors when signing CertificateRequests with a Venafi issuer, by the reason of the error.
+# TYPE certmanager_venafi_sign_errors_total counter
+certmanager_venafi_sign_errors_total{issuer_kind="Issuer",issuer_name="venafi",reason="authentication"} 1
+certmanager_venafi_sign_errors_total{issuer_kind="Issuer",issuer_name="venafi",reason="pending"} 2
+`
+
+	assert.NoError(t,
+		testutil.CollectAndCompare(m.venafiSignErrorsTotal, strings.NewReader(expected), "certmanager_venafi_sign_errors_total"),
+	)
+}
+
+func TestIncrementVenafiZoneCacheLookup(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()))
+
+	issuerRef := cmmeta.ObjectReference{Name: "venafi", Kind: "Issuer"}
+	m.IncrementVenafiZoneCacheLookup(issuerRef, VenafiZoneCacheResultMiss)
+	m.IncrementVenafiZoneCacheLookup(issuerRef, VenafiZoneCacheResultHit)
+	m.IncrementVenafiZoneCacheLookup(issuerRef, VenafiZoneCacheResultHit)
+
+	expected := `
+# HELP certmanager_venafi_zone_cache_lookup_count The number of lookups of the Venafi zone configuration, by whether the zone configuration was found in the cache (hit) or read from the Venafi platform (miss).
+# TYPE certmanager_venafi_zone_cache_lookup_count counter
+certmanager_venafi_zone_cache_lookup_count{issuer_kind="Issuer",issuer_name="venafi",result="hit"} 2
+certmanager_venafi_zone_cache_lookup_count{issuer_kind="Issuer",issuer_name="venafi",result="miss"} 1
+`
+
+	assert.NoError(t,
+		testutil.CollectAndCompare(m.venafiZoneCacheLookupCount, strings.NewReader(expected), "certmanager_venafi_zone_cache_lookup_count"),
+	)
+}
+
+func TestSetVenafiCircuitBreakerState(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()))
+
+	issuerRef := cmmeta.ObjectReference{Name: "venafi", Kind: "Issuer"}
+	m.SetVenafiCircuitBreakerState(issuerRef, VenafiCircuitBreakerStateOpen)
+	m.SetVenafiCircuitBreakerState(issuerRef, VenafiCircuitBreakerStateHalfOpen)
+
+	expected := `
+# HELP certmanager_venafi_circuit_breaker_state The state of the circuit breaker of each Venafi issuer. The gauge of the current state (closed, open or half_open) is 1, the others are 0.
+# TYPE certmanager_venafi_circuit_breaker_state gauge
+certmanager_venafi_circuit_breaker_state{issuer_kind="Issuer",issuer_name="venafi",state="closed"} 0
+certmanager_venafi_circuit_breaker_state{issuer_kind="Issuer",issuer_name="venafi",state="half_open"} 1
+certmanager_venafi_circuit_breaker_state{issuer_kind="Issuer",issuer_name="venafi",state="open"} 0
+`
+
+	assert.NoError(t,
+		testutil.CollectAndCompare(m.venafiCircuitBreakerState, strings.NewReader(expected), "certmanager_venafi_circuit_breaker_state"),
+	)
+}
@@ -27,12 +27,17 @@ import (
 	"time"
 
 	certificatesv1 "k8s.io/api/certificates/v1"
+	"k8s.io/utils/clock"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	experimentalapi "github.com/cert-manager/cert-manager/pkg/apis/experimental/v1alpha1"
 )
 
+// Clock is defined as a package var so it can be stubbed out during tests.
+// It is used to determine the start of the validity of certificate templates.
+var Clock clock.Clock = clock.RealClock{}
+
 type CertificateTemplateValidatorMutator func(*x509.CertificateRequest, *x509.Certificate) error
 
 func hasExtension(checkReq *x509.CertificateRequest, extensionID asn1.ObjectIdentifier) bool {
@@ -55,12 +60,25 @@ func hasExtension(checkReq *x509.CertificateRequest, extensionID asn1.ObjectIden
 // certificate duration.
 func CertificateTemplateOverrideDuration(duration time.Duration) CertificateTemplateValidatorMutator {
 	return func(req *x509.CertificateRequest, cert *x509.Certificate) error {
-		cert.NotBefore = time.Now()
+		cert.NotBefore = Clock.Now()
 		cert.NotAfter = cert.NotBefore.Add(duration)
 		return nil
 	}
 }
 
+// CertificateTemplateOverrideNotAfter returns a CertificateTemplateValidatorMutator that overrides the
+// certificate validity to start now and end at the given notAfter time.
+func CertificateTemplateOverrideNotAfter(notAfter time.Time) CertificateTemplateValidatorMutator {
+	return func(req *x509.CertificateRequest, cert *x509.Certificate) error {
+		cert.NotBefore = Clock.Now()
+		if !notAfter.After(cert.NotBefore) {
+			return fmt.Errorf("requested notAfter time %s is not in the future", notAfter.Format(time.RFC3339))
+		}
+		cert.NotAfter = notAfter
+		return nil
+	}
+}
+
 // CertificateTemplateValidateAndOverrideBasicConstraints returns a CertificateTemplateValidatorMutator that overrides
 // the certificate basic constraints.
 func CertificateTemplateValidateAndOverrideBasicConstraints(isCA bool, maxPathLen *int) CertificateTemplateValidatorMutator {
@@ -143,6 +161,9 @@ func (k printKeyUsage) String() string {
 
 // CertificateTemplateFromCSR will create a x509.Certificate for the
 // given *x509.CertificateRequest.
+// Custom extensions of the CSR are copied into the template, while the
+// extensions which describe the issuer, such as the authority key identifier,
+// are dropped.
 func CertificateTemplateFromCSR(csr *x509.CertificateRequest, validatorMutators ...CertificateTemplateValidatorMutator) (*x509.Certificate, error) {
 	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
 	if err != nil {
@@ -230,6 +251,13 @@ func CertificateTemplateFromCSR(csr *x509.CertificateRequest, validatorMutators
 			template.ExtraExtensions = append(template.ExtraExtensions, val)
 		}
 
+		// Extensions which cert-manager does not handle, such as
+		// organisation specific extensions, are copied as is. See
+		// IsCustomExtension for the extensions which are dropped.
+		if IsCustomExtension(val.Id) {
+			template.ExtraExtensions = append(template.ExtraExtensions, val)
+		}
+
 		return nil
 	}
 
@@ -271,6 +299,15 @@ func CertificateTemplateFromCSR(csr *x509.CertificateRequest, validatorMutators
 				cert.ExtraExtensions[i].Critical = IsASN1SubjectEmpty(asn1Subject)
 			}
 		}
+
+		// The TLS feature extension of the CSR is replaced by a canonical
+		// OCSP Must-Staple extension, so that the issued certificate carries
+		// it regardless of how the CSR encoded it.
+		if RequestsOCSPMustStaple(csr) {
+			if err := AddOCSPMustStaple(cert); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	return cert, nil
@@ -316,7 +353,13 @@ func CertificateTemplateFromCertificate(crt *v1.Certificate) (*x509.Certificate,
 // CertificateTemplateFromCertificateRequest will create a x509.Certificate for the given
 // CertificateRequest resource
 func CertificateTemplateFromCertificateRequest(cr *v1.CertificateRequest) (*x509.Certificate, error) {
-	certDuration := apiutil.DefaultCertDuration(cr.Spec.Duration)
+	// An explicitly requested notAfter time takes precedence over the
+	// (default) duration.
+	overrideValidity := CertificateTemplateOverrideDuration(apiutil.DefaultCertDuration(cr.Spec.Duration))
+	if cr.Spec.NotAfter != nil {
+		overrideValidity = CertificateTemplateOverrideNotAfter(cr.Spec.NotAfter.Time)
+	}
+
 	keyUsage, extKeyUsage, err := KeyUsagesForCertificateOrCertificateRequest(cr.Spec.Usages, cr.Spec.IsCA)
 	if err != nil {
 		return nil, err
@@ -324,7 +367,7 @@ func CertificateTemplateFromCertificateRequest(cr *v1.CertificateRequest) (*x509
 
 	return CertificateTemplateFromCSRPEM(
 		cr.Spec.Request,
-		CertificateTemplateOverrideDuration(certDuration),
+		overrideValidity,
 		CertificateTemplateValidateAndOverrideBasicConstraints(cr.Spec.IsCA, nil), // Override the basic constraints, but make sure they match the constraints in the CSR if present
 		CertificateTemplateValidateAndOverrideKeyUsages(keyUsage, extKeyUsage),    // Override the key usages, but make sure they match the usages in the CSR if present
 	)
@@ -22,6 +22,10 @@ import (
 	"encoding/asn1"
 	"reflect"
 	"testing"
+	"time"
+
+	"k8s.io/utils/clock"
+	fakeclock "k8s.io/utils/clock/testing"
 )
 
 func TestCertificateTemplateFromCSR(t *testing.T) {
@@ -92,7 +96,7 @@ func TestCertificateTemplateFromCSR(t *testing.T) {
 			},
 		},
 		{
-			name: "should ignore unknown extensions",
+			name: "should copy custom extensions",
 			csr: &x509.CertificateRequest{
 				ExtraExtensions: []pkix.Extension{
 					{
@@ -101,6 +105,30 @@ func TestCertificateTemplateFromCSR(t *testing.T) {
 					},
 				},
 			},
+			expected: &x509.Certificate{
+				Version: 3,
+				ExtraExtensions: []pkix.Extension{
+					{
+						Id:    []int{1, 2, 3},
+						Value: []byte("test"),
+					},
+				},
+			},
+		},
+		{
+			name: "should drop extensions set by the issuer",
+			csr: &x509.CertificateRequest{
+				ExtraExtensions: []pkix.Extension{
+					{
+						Id:    []int{2, 5, 29, 35},
+						Value: []byte("test"),
+					},
+					{
+						Id:    []int{1, 3, 6, 1, 5, 5, 7, 1, 1},
+						Value: []byte("test"),
+					},
+				},
+			},
 			expected: &x509.Certificate{
 				Version: 3,
 			},
@@ -171,3 +199,48 @@ func TestCertificateTemplateFromCSR(t *testing.T) {
 		})
 	}
 }
+
+func TestCertificateTemplateOverrideNotAfter(t *testing.T) {
+	notAfter := time.Now().Add(time.Hour)
+
+	cert := &x509.Certificate{}
+	if err := CertificateTemplateOverrideNotAfter(notAfter)(&x509.CertificateRequest{}, cert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cert.NotAfter.Equal(notAfter) {
+		t.Errorf("unexpected notAfter, exp=%s got=%s", notAfter, cert.NotAfter)
+	}
+	if !cert.NotBefore.Before(notAfter) {
+		t.Errorf("expected notBefore %s to be before notAfter %s", cert.NotBefore, notAfter)
+	}
+
+	err := CertificateTemplateOverrideNotAfter(time.Now().Add(-time.Hour))(&x509.CertificateRequest{}, &x509.Certificate{})
+	if err == nil {
+		t.Errorf("expected an error for a notAfter time in the past")
+	}
+}
+
+func TestCertificateTemplateOverrideDuration(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	Clock = fakeclock.NewFakeClock(now)
+	defer func() { Clock = clock.RealClock{} }()
+
+	cert := &x509.Certificate{}
+	if err := CertificateTemplateOverrideDuration(time.Hour)(&x509.CertificateRequest{}, cert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cert.NotBefore.Equal(now) {
+		t.Errorf("unexpected notBefore, exp=%s got=%s", now, cert.NotBefore)
+	}
+	if exp := now.Add(time.Hour); !cert.NotAfter.Equal(exp) {
+		t.Errorf("unexpected notAfter, exp=%s got=%s", exp, cert.NotAfter)
+	}
+
+	// A notAfter time which is in the future of the real clock but not of
+	// the stubbed clock must be rejected.
+	Clock = fakeclock.NewFakeClock(time.Now().Add(2 * time.Hour))
+	err := CertificateTemplateOverrideNotAfter(time.Now().Add(time.Hour))(&x509.CertificateRequest{}, &x509.Certificate{})
+	if err == nil {
+		t.Errorf("expected an error for a notAfter time before the current time of the clock")
+	}
+}
@@ -319,6 +319,15 @@ func GenerateCSR(crt *v1.Certificate, optFuncs ...GenerateCSROption) (*x509.Cert
 		}
 	}
 
+	if crt.Spec.OCSPMustStaple {
+		extension, err := MarshalOCSPMustStaple()
+		if err != nil {
+			return nil, err
+		}
+
+		extraExtensions = append(extraExtensions, extension)
+	}
+
 	cr := &x509.CertificateRequest{
 		// Version 0 is the only one defined in the PKCS#10 standard, RFC2986.
 		// This value isn't used by Go at the time of writing.
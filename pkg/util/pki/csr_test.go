@@ -797,6 +797,40 @@ func TestGenerateCSR(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Generate CSR from certificate requesting OCSP Must-Staple",
+			crt: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					DNSNames:       []string{"example.org"},
+					OCSPMustStaple: true,
+				},
+			},
+			want: &x509.CertificateRequest{
+				Version:            0,
+				SignatureAlgorithm: x509.SHA256WithRSA,
+				PublicKeyAlgorithm: x509.RSA,
+				ExtraExtensions: []pkix.Extension{
+					sansGenerator(
+						t,
+						[]asn1.RawValue{
+							{Tag: nameTypeDNSName, Class: 2, Bytes: []byte("example.org")},
+						},
+						true,
+					),
+					{
+						Id:       OIDExtensionKeyUsage,
+						Value:    asn1DefaultKeyUsage,
+						Critical: true,
+					},
+					{
+						Id:    OIDExtensionTLSFeature,
+						Value: []byte{0x30, 0x03, 0x02, 0x01, 0x05},
+					},
+				},
+				RawSubject: subjectGenerator(t, pkix.Name{}),
+			},
+			wantErr: false,
+		},
 		{
 			name: "Generate CSR from certificate with NameConstraints flag enabled",
 			crt: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
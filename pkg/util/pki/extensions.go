@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+)
+
+// Copied from x509.go
+var (
+	oidExtensionSubjectKeyId          = []int{2, 5, 29, 14}
+	oidExtensionAuthorityKeyId        = []int{2, 5, 29, 35}
+	oidExtensionCRLDistributionPoints = []int{2, 5, 29, 31}
+	oidExtensionAuthorityInfoAccess   = []int{1, 3, 6, 1, 5, 5, 7, 1, 1}
+)
+
+// standardExtensions are the extensions of a CSR which are not copied into
+// certificate templates as is. The basic constraints, name constraints, key
+// usage, extended key usage and subject alternative name extensions are
+// represented by the fields of the template. The key identifiers, CRL
+// distribution points and authority information access extensions describe
+// the issuer, so they are set by the issuer and dropped from the CSR.
+var standardExtensions = []asn1.ObjectIdentifier{
+	OIDExtensionBasicConstraints,
+	OIDExtensionNameConstraints,
+	OIDExtensionKeyUsage,
+	OIDExtensionExtendedKeyUsage,
+	oidExtensionSubjectAltName,
+	oidExtensionSubjectKeyId,
+	oidExtensionAuthorityKeyId,
+	oidExtensionCRLDistributionPoints,
+	oidExtensionAuthorityInfoAccess,
+}
+
+// IsCustomExtension returns true if the extension with the given OID is not a
+// standard extension handled by cert-manager. Custom extensions of a CSR are
+// copied as is into the certificate templates created from it.
+func IsCustomExtension(oid asn1.ObjectIdentifier) bool {
+	for _, standard := range standardExtensions {
+		if oid.Equal(standard) {
+			return false
+		}
+	}
+	return true
+}
+
+// CustomExtensions returns the custom extensions among the given extensions.
+func CustomExtensions(extensions []pkix.Extension) []pkix.Extension {
+	var custom []pkix.Extension
+	for _, ext := range extensions {
+		if IsCustomExtension(ext.Id) {
+			custom = append(custom, ext)
+		}
+	}
+	return custom
+}
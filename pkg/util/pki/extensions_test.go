@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomExtensions(t *testing.T) {
+	custom := pkix.Extension{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 20, 2}, Value: []byte("test")}
+
+	extensions := []pkix.Extension{
+		{Id: OIDExtensionKeyUsage},
+		custom,
+		{Id: oidExtensionSubjectAltName},
+		{Id: oidExtensionAuthorityKeyId},
+		{Id: oidExtensionCRLDistributionPoints},
+	}
+
+	assert.Equal(t, []pkix.Extension{custom}, CustomExtensions(extensions))
+	assert.Nil(t, CustomExtensions(nil))
+}
@@ -227,6 +227,9 @@ func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpe
 	if !reflect.DeepEqual(req.Spec.IssuerRef, spec.IssuerRef) {
 		violations = append(violations, "spec.issuerRef")
 	}
+	if RequestsOCSPMustStaple(x509req) != spec.OCSPMustStaple {
+		violations = append(violations, "spec.ocspMustStaple")
+	}
 
 	// TODO: check spec.EncodeBasicConstraintsInRequest and spec.EncodeUsagesInRequest
 
@@ -480,3 +480,70 @@ func mustBuildCertificateRequest(t *testing.T, crt *cmapi.Certificate) *cmapi.Ce
 
 	return cr
 }
+
+func TestRequestMatchesSpecOCSPMustStaple(t *testing.T) {
+	createCSRBlob := func(mustStaple bool) []byte {
+		pemBytes, _, err := gen.CSR(x509.Ed25519, func(cr *x509.CertificateRequest) error {
+			cr.DNSNames = []string{"example.com"}
+			if mustStaple {
+				ext, err := pki.MarshalOCSPMustStaple()
+				if err != nil {
+					return err
+				}
+				cr.ExtraExtensions = append(cr.ExtraExtensions, ext)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return pemBytes
+	}
+
+	tests := map[string]struct {
+		x509CSR        []byte
+		ocspMustStaple bool
+		violations     []string
+	}{
+		"requested and present in the CSR": {
+			x509CSR:        createCSRBlob(true),
+			ocspMustStaple: true,
+		},
+		"not requested and absent from the CSR": {
+			x509CSR: createCSRBlob(false),
+		},
+		"requested but absent from the CSR": {
+			x509CSR:        createCSRBlob(false),
+			ocspMustStaple: true,
+			violations:     []string{"spec.ocspMustStaple"},
+		},
+		"not requested but present in the CSR": {
+			x509CSR:    createCSRBlob(true),
+			violations: []string{"spec.ocspMustStaple"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			violations, err := pki.RequestMatchesSpec(
+				&cmapi.CertificateRequest{
+					Spec: cmapi.CertificateRequestSpec{
+						Request: test.x509CSR,
+					},
+				},
+				cmapi.CertificateSpec{
+					DNSNames:       []string{"example.com"},
+					OCSPMustStaple: test.ocspMustStaple,
+				},
+			)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err.Error())
+			}
+
+			if !reflect.DeepEqual(violations, test.violations) {
+				t.Errorf("violations did not match, got=%s, exp=%s", violations, test.violations)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/bits"
+)
+
+// OIDExtensionNetscapeCertType is the object identifier of the legacy
+// Netscape certificate type extension, which is still required by some old
+// clients and appliances.
+var OIDExtensionNetscapeCertType = asn1.ObjectIdentifier{2, 16, 840, 1, 113730, 1, 1}
+
+// The bits of the Netscape certificate type, where the first bit of the
+// BIT STRING is the most significant bit of the byte.
+const (
+	netscapeCertTypeSSLClient       byte = 0x80
+	netscapeCertTypeSSLServer       byte = 0x40
+	netscapeCertTypeSMIME           byte = 0x20
+	netscapeCertTypeObjectSigning   byte = 0x10
+	netscapeCertTypeSSLCA           byte = 0x04
+	netscapeCertTypeSMIMECA         byte = 0x02
+	netscapeCertTypeObjectSigningCA byte = 0x01
+)
+
+// netscapeCertType returns the Netscape certificate types of the given
+// certificate template, derived from its extended key usages and whether it
+// is a CA. A template without extended key usages may be used for any purpose.
+func netscapeCertType(template *x509.Certificate) byte {
+	var leaf, ca byte
+	if len(template.ExtKeyUsage) == 0 {
+		leaf = netscapeCertTypeSSLClient | netscapeCertTypeSSLServer | netscapeCertTypeSMIME | netscapeCertTypeObjectSigning
+		ca = netscapeCertTypeSSLCA | netscapeCertTypeSMIMECA | netscapeCertTypeObjectSigningCA
+	}
+
+	for _, usage := range template.ExtKeyUsage {
+		switch usage {
+		case x509.ExtKeyUsageAny:
+			leaf |= netscapeCertTypeSSLClient | netscapeCertTypeSSLServer | netscapeCertTypeSMIME | netscapeCertTypeObjectSigning
+			ca |= netscapeCertTypeSSLCA | netscapeCertTypeSMIMECA | netscapeCertTypeObjectSigningCA
+		case x509.ExtKeyUsageClientAuth:
+			leaf |= netscapeCertTypeSSLClient
+			ca |= netscapeCertTypeSSLCA
+		case x509.ExtKeyUsageServerAuth:
+			leaf |= netscapeCertTypeSSLServer
+			ca |= netscapeCertTypeSSLCA
+		case x509.ExtKeyUsageEmailProtection:
+			leaf |= netscapeCertTypeSMIME
+			ca |= netscapeCertTypeSMIMECA
+		case x509.ExtKeyUsageCodeSigning:
+			leaf |= netscapeCertTypeObjectSigning
+			ca |= netscapeCertTypeObjectSigningCA
+		}
+	}
+
+	if template.IsCA {
+		return ca
+	}
+	return leaf
+}
+
+// MarshalNetscapeCertType returns the Netscape certificate type extension
+// for the given certificate template. It returns false if none of the
+// certificate types apply to the template, for example because its only
+// extended key usage is OCSP signing.
+func MarshalNetscapeCertType(template *x509.Certificate) (pkix.Extension, bool, error) {
+	certType := netscapeCertType(template)
+	if certType == 0 {
+		return pkix.Extension{}, false, nil
+	}
+
+	// DER requires the trailing zero bits of named bit lists to be omitted.
+	value, err := asn1.Marshal(asn1.BitString{
+		Bytes:     []byte{certType},
+		BitLength: 8 - bits.TrailingZeros8(certType),
+	})
+	if err != nil {
+		return pkix.Extension{}, false, err
+	}
+
+	return pkix.Extension{Id: OIDExtensionNetscapeCertType, Value: value}, true, nil
+}
+
+// AddNetscapeCertType adds the Netscape certificate type extension to the
+// extra extensions of the given certificate template, replacing any copied
+// from the CSR the template was created from.
+func AddNetscapeCertType(template *x509.Certificate) error {
+	ext, ok, err := MarshalNetscapeCertType(template)
+	if err != nil || !ok {
+		return err
+	}
+
+	extensions := make([]pkix.Extension, 0, len(template.ExtraExtensions)+1)
+	for _, extraExt := range template.ExtraExtensions {
+		if !extraExt.Id.Equal(OIDExtensionNetscapeCertType) {
+			extensions = append(extensions, extraExt)
+		}
+	}
+	template.ExtraExtensions = append(extensions, ext)
+
+	return nil
+}
+
+// HasNetscapeCertType returns true if the given certificate contains the
+// Netscape certificate type extension.
+func HasNetscapeCertType(cert *x509.Certificate) bool {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(OIDExtensionNetscapeCertType) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalNetscapeCertType(t *testing.T) {
+	tests := map[string]struct {
+		template      *x509.Certificate
+		expectedOK    bool
+		expectedValue []byte
+	}{
+		"a server and client certificate is an SSL server and client": {
+			template:      &x509.Certificate{ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}},
+			expectedOK:    true,
+			expectedValue: []byte{0x03, 0x02, 0x06, 0xc0},
+		},
+		"an email protection certificate is an S/MIME certificate": {
+			template:      &x509.Certificate{ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageEmailProtection}},
+			expectedOK:    true,
+			expectedValue: []byte{0x03, 0x02, 0x05, 0x20},
+		},
+		"a certificate without extended key usages has all the leaf types": {
+			template:      &x509.Certificate{},
+			expectedOK:    true,
+			expectedValue: []byte{0x03, 0x02, 0x04, 0xf0},
+		},
+		"a CA without extended key usages has all the CA types": {
+			template:      &x509.Certificate{IsCA: true},
+			expectedOK:    true,
+			expectedValue: []byte{0x03, 0x02, 0x00, 0x07},
+		},
+		"a code signing CA is an object signing CA": {
+			template:      &x509.Certificate{IsCA: true, ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning}},
+			expectedOK:    true,
+			expectedValue: []byte{0x03, 0x02, 0x00, 0x01},
+		},
+		"a certificate whose usages have no certificate type has no extension": {
+			template:   &x509.Certificate{ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageOCSPSigning}},
+			expectedOK: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ext, ok, err := MarshalNetscapeCertType(test.template)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedOK, ok)
+			if !test.expectedOK {
+				return
+			}
+			assert.True(t, ext.Id.Equal(OIDExtensionNetscapeCertType))
+			assert.False(t, ext.Critical)
+			assert.Equal(t, test.expectedValue, ext.Value)
+		})
+	}
+}
+
+func TestAddNetscapeCertType(t *testing.T) {
+	custom := pkix.Extension{Id: []int{1, 3, 6, 1, 4, 1, 311, 20, 2}, Value: []byte("test")}
+	template := &x509.Certificate{
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		ExtraExtensions: []pkix.Extension{
+			{Id: OIDExtensionNetscapeCertType, Value: []byte("from the CSR")},
+			custom,
+		},
+	}
+
+	require.NoError(t, AddNetscapeCertType(template))
+	assert.Equal(t, []pkix.Extension{
+		custom,
+		{Id: OIDExtensionNetscapeCertType, Value: []byte{0x03, 0x02, 0x06, 0x40}},
+	}, template.ExtraExtensions)
+
+	assert.True(t, HasNetscapeCertType(&x509.Certificate{Extensions: template.ExtraExtensions}))
+	assert.False(t, HasNetscapeCertType(&x509.Certificate{Extensions: []pkix.Extension{custom}}))
+}
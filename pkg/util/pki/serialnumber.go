@@ -0,0 +1,50 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// FormatSerialNumber formats the serial number of a certificate as
+// colon-separated pairs of upper case hexadecimal digits, for example
+// `0A:1B:2C`, which is how it is commonly displayed by tools such as OpenSSL.
+// Negative serial numbers, which are invalid but accepted by some parsers,
+// are prefixed with `-`.
+func FormatSerialNumber(serial *big.Int) string {
+	if serial == nil {
+		return ""
+	}
+
+	b := new(big.Int).Abs(serial).Bytes()
+	if len(b) == 0 {
+		b = []byte{0}
+	}
+
+	pairs := make([]string, len(b))
+	for i, v := range b {
+		pairs[i] = fmt.Sprintf("%02X", v)
+	}
+
+	formatted := strings.Join(pairs, ":")
+	if serial.Sign() < 0 {
+		formatted = "-" + formatted
+	}
+	return formatted
+}
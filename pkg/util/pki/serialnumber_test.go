@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestFormatSerialNumber(t *testing.T) {
+	large, _ := new(big.Int).SetString("7f3a0c9e112233445566778899aabbccddeeff00", 16)
+
+	tests := map[string]struct {
+		serial *big.Int
+		want   string
+	}{
+		"nil serial number": {
+			serial: nil,
+			want:   "",
+		},
+		"zero": {
+			serial: big.NewInt(0),
+			want:   "00",
+		},
+		"single byte is zero padded": {
+			serial: big.NewInt(10),
+			want:   "0A",
+		},
+		"multiple bytes are colon-separated": {
+			serial: big.NewInt(0x0a1b2c),
+			want:   "0A:1B:2C",
+		},
+		"20 byte serial number": {
+			serial: large,
+			want:   "7F:3A:0C:9E:11:22:33:44:55:66:77:88:99:AA:BB:CC:DD:EE:FF:00",
+		},
+		"negative serial number": {
+			serial: big.NewInt(-0x0a1b),
+			want:   "-0A:1B",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := FormatSerialNumber(test.serial); got != test.want {
+				t.Errorf("FormatSerialNumber() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+)
+
+// signatureHashes are the hash algorithms which can be requested for the
+// signature of a certificate, by name.
+var signatureHashes = map[string]crypto.Hash{
+	"SHA256": crypto.SHA256,
+	"SHA384": crypto.SHA384,
+	"SHA512": crypto.SHA512,
+}
+
+// ParseSignatureHash returns the hash algorithm with the given name, which
+// must be one of "SHA256", "SHA384" or "SHA512".
+func ParseSignatureHash(name string) (crypto.Hash, error) {
+	hash, ok := signatureHashes[name]
+	if !ok {
+		return 0, fmt.Errorf("unsupported signature hash algorithm %q, must be one of SHA256, SHA384 or SHA512", name)
+	}
+	return hash, nil
+}
+
+// SignatureAlgorithmWithHash returns the signature algorithm which signs with
+// a key of the same type as the given public key, using the given hash
+// algorithm. Only SHA-256, SHA-384 and SHA-512 are supported, and Ed25519 keys
+// do not support choosing the hash algorithm.
+func SignatureAlgorithmWithHash(publicKey crypto.PublicKey, hash crypto.Hash) (x509.SignatureAlgorithm, error) {
+	var algorithms map[crypto.Hash]x509.SignatureAlgorithm
+	switch publicKey.(type) {
+	case *rsa.PublicKey:
+		algorithms = map[crypto.Hash]x509.SignatureAlgorithm{
+			crypto.SHA256: x509.SHA256WithRSA,
+			crypto.SHA384: x509.SHA384WithRSA,
+			crypto.SHA512: x509.SHA512WithRSA,
+		}
+	case *ecdsa.PublicKey:
+		algorithms = map[crypto.Hash]x509.SignatureAlgorithm{
+			crypto.SHA256: x509.ECDSAWithSHA256,
+			crypto.SHA384: x509.ECDSAWithSHA384,
+			crypto.SHA512: x509.ECDSAWithSHA512,
+		}
+	case ed25519.PublicKey:
+		return x509.UnknownSignatureAlgorithm, fmt.Errorf("Ed25519 keys cannot sign with the %s hash algorithm", hash)
+	default:
+		return x509.UnknownSignatureAlgorithm, fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+
+	algorithm, ok := algorithms[hash]
+	if !ok {
+		return x509.UnknownSignatureAlgorithm, fmt.Errorf("unsupported signature hash algorithm %s", hash)
+	}
+
+	return algorithm, nil
+}
+
+// SignatureAlgorithmHash returns the hash algorithm used by the given
+// signature algorithm, or zero if it is unknown or does not use a separate
+// hash algorithm, such as Ed25519.
+func SignatureAlgorithmHash(algorithm x509.SignatureAlgorithm) crypto.Hash {
+	switch algorithm {
+	case x509.SHA256WithRSA, x509.SHA256WithRSAPSS, x509.ECDSAWithSHA256:
+		return crypto.SHA256
+	case x509.SHA384WithRSA, x509.SHA384WithRSAPSS, x509.ECDSAWithSHA384:
+		return crypto.SHA384
+	case x509.SHA512WithRSA, x509.SHA512WithRSAPSS, x509.ECDSAWithSHA512:
+		return crypto.SHA512
+	case x509.SHA1WithRSA, x509.ECDSAWithSHA1, x509.DSAWithSHA1:
+		return crypto.SHA1
+	case x509.MD5WithRSA:
+		return crypto.MD5
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto"
+	"crypto/x509"
+	"testing"
+)
+
+func TestParseSignatureHash(t *testing.T) {
+	tests := map[string]struct {
+		name    string
+		want    crypto.Hash
+		wantErr bool
+	}{
+		"SHA256": {name: "SHA256", want: crypto.SHA256},
+		"SHA384": {name: "SHA384", want: crypto.SHA384},
+		"SHA512": {name: "SHA512", want: crypto.SHA512},
+		"names are case sensitive": {
+			name:    "sha384",
+			wantErr: true,
+		},
+		"weak hash algorithms are not supported": {
+			name:    "SHA1",
+			wantErr: true,
+		},
+		"empty name": {
+			name:    "",
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseSignatureHash(test.name)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseSignatureHash() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("ParseSignatureHash() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSignatureAlgorithmWithHash(t *testing.T) {
+	rsaKey, err := GenerateRSAPrivateKey(MinRSAKeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edKey, err := GenerateEd25519PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		publicKey crypto.PublicKey
+		hash      crypto.Hash
+		want      x509.SignatureAlgorithm
+		wantErr   bool
+	}{
+		"RSA with SHA-384": {
+			publicKey: rsaKey.Public(),
+			hash:      crypto.SHA384,
+			want:      x509.SHA384WithRSA,
+		},
+		"ECDSA with SHA-512": {
+			publicKey: ecKey.Public(),
+			hash:      crypto.SHA512,
+			want:      x509.ECDSAWithSHA512,
+		},
+		"ECDSA with SHA-1 is not supported": {
+			publicKey: ecKey.Public(),
+			hash:      crypto.SHA1,
+			wantErr:   true,
+		},
+		"Ed25519 does not support choosing the hash algorithm": {
+			publicKey: edKey.Public(),
+			hash:      crypto.SHA256,
+			wantErr:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := SignatureAlgorithmWithHash(test.publicKey, test.hash)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("SignatureAlgorithmWithHash() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("SignatureAlgorithmWithHash() = %v, want %v", got, test.want)
+			}
+			if err == nil && SignatureAlgorithmHash(got) != test.hash {
+				t.Errorf("SignatureAlgorithmHash(%v) = %v, want %v", got, SignatureAlgorithmHash(got), test.hash)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"slices"
+)
+
+// OIDExtensionTLSFeature is the object identifier of the TLS feature
+// extension (RFC 7633), which is used to mark certificates as OCSP
+// Must-Staple.
+var OIDExtensionTLSFeature = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 24}
+
+// tlsFeatureStatusRequest is the status_request TLS extension (RFC 6066),
+// which requires the server to staple an OCSP response.
+const tlsFeatureStatusRequest = 5
+
+// MarshalOCSPMustStaple returns the TLS feature extension requiring the
+// status_request feature, which marks a certificate as OCSP Must-Staple.
+func MarshalOCSPMustStaple() (pkix.Extension, error) {
+	value, err := asn1.Marshal([]int{tlsFeatureStatusRequest})
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+	return pkix.Extension{Id: OIDExtensionTLSFeature, Value: value}, nil
+}
+
+// IsOCSPMustStaple returns true if the given extensions contain a TLS
+// feature extension requiring the status_request feature.
+func IsOCSPMustStaple(extensions []pkix.Extension) bool {
+	for _, ext := range extensions {
+		if !ext.Id.Equal(OIDExtensionTLSFeature) {
+			continue
+		}
+		var features []int
+		if rest, err := asn1.Unmarshal(ext.Value, &features); err != nil || len(rest) > 0 {
+			continue
+		}
+		if slices.Contains(features, tlsFeatureStatusRequest) {
+			return true
+		}
+	}
+	return false
+}
+
+// RequestsOCSPMustStaple returns true if the given CSR requests the OCSP
+// Must-Staple extension.
+func RequestsOCSPMustStaple(csr *x509.CertificateRequest) bool {
+	return IsOCSPMustStaple(csr.Extensions) || IsOCSPMustStaple(csr.ExtraExtensions)
+}
+
+// HasOCSPMustStaple returns true if the given certificate contains the OCSP
+// Must-Staple extension.
+func HasOCSPMustStaple(cert *x509.Certificate) bool {
+	return IsOCSPMustStaple(cert.Extensions)
+}
+
+// AddOCSPMustStaple adds the OCSP Must-Staple extension to the extra
+// extensions of the given certificate template, replacing any TLS feature
+// extension copied from the CSR the template was created from.
+func AddOCSPMustStaple(template *x509.Certificate) error {
+	ext, err := MarshalOCSPMustStaple()
+	if err != nil {
+		return err
+	}
+
+	extensions := make([]pkix.Extension, 0, len(template.ExtraExtensions)+1)
+	for _, extraExt := range template.ExtraExtensions {
+		if !extraExt.Id.Equal(OIDExtensionTLSFeature) {
+			extensions = append(extensions, extraExt)
+		}
+	}
+	template.ExtraExtensions = append(extensions, ext)
+
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalOCSPMustStaple(t *testing.T) {
+	ext, err := MarshalOCSPMustStaple()
+	require.NoError(t, err)
+	assert.True(t, ext.Id.Equal(OIDExtensionTLSFeature))
+	assert.False(t, ext.Critical)
+	assert.Equal(t, []byte{0x30, 0x03, 0x02, 0x01, 0x05}, ext.Value)
+}
+
+func TestIsOCSPMustStaple(t *testing.T) {
+	tests := map[string]struct {
+		extensions []pkix.Extension
+		expected   bool
+	}{
+		"no extensions": {},
+		"the status_request feature is OCSP Must-Staple": {
+			extensions: []pkix.Extension{{Id: OIDExtensionTLSFeature, Value: []byte{0x30, 0x03, 0x02, 0x01, 0x05}}},
+			expected:   true,
+		},
+		"the status_request feature amongst others is OCSP Must-Staple": {
+			extensions: []pkix.Extension{{Id: OIDExtensionTLSFeature, Value: []byte{0x30, 0x06, 0x02, 0x01, 0x11, 0x02, 0x01, 0x05}}},
+			expected:   true,
+		},
+		"other TLS features are not OCSP Must-Staple": {
+			extensions: []pkix.Extension{{Id: OIDExtensionTLSFeature, Value: []byte{0x30, 0x03, 0x02, 0x01, 0x11}}},
+		},
+		"a malformed TLS feature extension is not OCSP Must-Staple": {
+			extensions: []pkix.Extension{{Id: OIDExtensionTLSFeature, Value: []byte("malformed")}},
+		},
+		"other extensions are not OCSP Must-Staple": {
+			extensions: []pkix.Extension{{Id: OIDExtensionNetscapeCertType, Value: []byte{0x30, 0x03, 0x02, 0x01, 0x05}}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsOCSPMustStaple(test.extensions))
+		})
+	}
+}
+
+func TestAddOCSPMustStaple(t *testing.T) {
+	custom := pkix.Extension{Id: []int{1, 3, 6, 1, 4, 1, 311, 20, 2}, Value: []byte("test")}
+	template := &x509.Certificate{
+		ExtraExtensions: []pkix.Extension{
+			{Id: OIDExtensionTLSFeature, Value: []byte("from the CSR")},
+			custom,
+		},
+	}
+
+	require.NoError(t, AddOCSPMustStaple(template))
+	assert.Equal(t, []pkix.Extension{
+		custom,
+		{Id: OIDExtensionTLSFeature, Value: []byte{0x30, 0x03, 0x02, 0x01, 0x05}},
+	}, template.ExtraExtensions)
+
+	assert.True(t, HasOCSPMustStaple(&x509.Certificate{Extensions: template.ExtraExtensions}))
+	assert.False(t, HasOCSPMustStaple(&x509.Certificate{Extensions: []pkix.Extension{custom}}))
+}
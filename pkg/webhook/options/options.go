@@ -91,6 +91,9 @@ func AddConfigFlags(fs *pflag.FlagSet, c *config.WebhookConfiguration) {
 
 	logf.AddFlags(&c.Logging, fs)
 
+	fs.BoolVar(&c.EnableVenafiDurationValidation, "enable-venafi-duration-validation", c.EnableVenafiDurationValidation, ""+
+		"Reject CertificateRequests which request a longer duration than the maxDuration of their Venafi issuer. "+
+		"The webhook then watches Issuers and ClusterIssuers, so it must be allowed to list and watch them.")
 	fs.StringVar(&c.MetricsListenAddress, "metrics-listen-address", c.MetricsListenAddress, "The host and port that the metrics endpoint should listen on. The value '0' disables the metrics server")
 	fs.StringVar(&c.MetricsTLSConfig.Filesystem.CertFile, "metrics-tls-cert-file", c.MetricsTLSConfig.Filesystem.CertFile, "path to the file containing the TLS certificate to serve metrics with")
 	fs.StringVar(&c.MetricsTLSConfig.Filesystem.KeyFile, "metrics-tls-private-key-file", c.MetricsTLSConfig.Filesystem.KeyFile, "path to the file containing the TLS private key to serve metrics with")
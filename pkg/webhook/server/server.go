@@ -101,6 +101,10 @@ type Server struct {
 	// MetricsMinTLSVersion is the minimum TLS version supported.
 	// Values are from tls package constants (https://golang.org/pkg/crypto/tls/#pkg-constants).
 	MetricsMinTLSVersion string
+
+	// Runnables are run with the webhook server, for example to run the
+	// informers read by the admission plugins.
+	Runnables []manager.Runnable
 }
 
 func (s *Server) Run(ctx context.Context) error {
@@ -263,6 +267,12 @@ func (s *Server) Run(ctx context.Context) error {
 		}
 	}
 
+	for _, runnable := range s.Runnables {
+		if err := mgr.Add(runnable); err != nil {
+			return err
+		}
+	}
+
 	mgr.GetWebhookServer().Register("/mutate", cmadmission.NewCustomMutationWebhook(s.MutationWebhook))
 
 	mgr.GetWebhookServer().Register("/validate", cmadmission.NewCustomValidationWebhook(mgr.GetScheme(), s.ValidationWebhook))
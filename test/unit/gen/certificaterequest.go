@@ -18,9 +18,11 @@ package gen
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 type CertificateRequestModifier func(*v1.CertificateRequest)
@@ -68,12 +70,43 @@ func SetCertificateRequestDuration(duration *metav1.Duration) CertificateRequest
 	}
 }
 
+func SetCertificateRequestNotAfter(notAfter metav1.Time) CertificateRequestModifier {
+	return func(cr *v1.CertificateRequest) {
+		cr.Spec.NotAfter = &notAfter
+	}
+}
+
 func SetCertificateRequestCA(ca []byte) CertificateRequestModifier {
 	return func(cr *v1.CertificateRequest) {
 		cr.Status.CA = ca
 	}
 }
 
+func SetCertificateRequestChainLength(length int) CertificateRequestModifier {
+	return func(cr *v1.CertificateRequest) {
+		cr.Status.ChainLength = &length
+	}
+}
+
+func SetCertificateRequestSerialNumber(serialNumber string) CertificateRequestModifier {
+	return func(cr *v1.CertificateRequest) {
+		cr.Status.SerialNumber = serialNumber
+	}
+}
+
+// SetCertificateRequestSerialNumberOf sets the serial number of the
+// CertificateRequest to the serial number of the first certificate of the
+// given PEM encoded chain, or leaves it unset if the chain cannot be decoded.
+func SetCertificateRequestSerialNumberOf(chainPEM []byte) CertificateRequestModifier {
+	return func(cr *v1.CertificateRequest) {
+		chain, err := pki.DecodeX509CertificateChainBytes(chainPEM)
+		if err != nil {
+			return
+		}
+		cr.Status.SerialNumber = pki.FormatSerialNumber(chain[0].SerialNumber)
+	}
+}
+
 func SetCertificateRequestCertificate(cert []byte) CertificateRequestModifier {
 	return func(cr *v1.CertificateRequest) {
 		cr.Status.Certificate = cert
@@ -108,6 +141,12 @@ func SetCertificateRequestNamespace(namespace string) CertificateRequestModifier
 	}
 }
 
+func SetCertificateRequestUID(uid types.UID) CertificateRequestModifier {
+	return func(cr *v1.CertificateRequest) {
+		cr.UID = uid
+	}
+}
+
 func SetCertificateRequestName(name string) CertificateRequestModifier {
 	return func(cr *v1.CertificateRequest) {
 		cr.ObjectMeta.Name = name
@@ -23,6 +23,7 @@ import (
 	"crypto/elliptic"
 	"crypto/rsa"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/pem"
 	"fmt"
 	"net"
@@ -228,3 +229,10 @@ func SetCSREmails(emails []string) CSRModifier {
 		return nil
 	}
 }
+
+func AddCSRExtraExtensions(extensions ...pkix.Extension) CSRModifier {
+	return func(c *x509.CertificateRequest) error {
+		c.ExtraExtensions = append(c.ExtraExtensions, extensions...)
+		return nil
+	}
+}
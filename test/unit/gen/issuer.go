@@ -247,6 +247,16 @@ func SetIssuerACMELastPrivateKeyHash(privateKeyHash string) IssuerModifier {
 	}
 }
 
+func SetIssuerACMELastExternalAccountBindingHash(eabHash string) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		status := iss.GetStatus()
+		if status.ACME == nil {
+			status.ACME = &cmacme.ACMEIssuerStatus{}
+		}
+		status.ACME.LastExternalAccountBindingHash = eabHash
+	}
+}
+
 func SetIssuerCA(a v1.CAIssuer) IssuerModifier {
 	return func(iss v1.GenericIssuer) {
 		iss.GetSpec().CA = &a
@@ -445,8 +455,26 @@ func AddIssuerCondition(c v1.IssuerCondition) IssuerModifier {
 	}
 }
 
+func SetIssuerGeneration(generation int64) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetObjectMeta().Generation = generation
+	}
+}
+
 func SetIssuerNamespace(namespace string) IssuerModifier {
 	return func(iss v1.GenericIssuer) {
 		iss.GetObjectMeta().Namespace = namespace
 	}
 }
+
+func SetIssuerAnnotations(annotations map[string]string) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		meta := iss.GetObjectMeta()
+		if meta.Annotations == nil {
+			meta.Annotations = make(map[string]string)
+		}
+		for k, v := range annotations {
+			meta.Annotations[k] = v
+		}
+	}
+}